@@ -0,0 +1,260 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Allocator is an optional memory source for the decoder, used when it needs to
+// create new byte slices or objects. It permits decoding into per-request arenas
+// that are released wholesale, instead of hitting Go's GC for every item.
+type Allocator interface {
+	// AllocBytes returns a zeroed byte slice of length n.
+	AllocBytes(n int) []byte
+
+	// AllocObject returns a pointer to a new, zeroed object of the same type as
+	// the typed nil pointer passed in (e.g. `(*Withdrawal)(nil)` -> `*Withdrawal`).
+	// Returning nil (or a different type) falls back to allocating via Go.
+	AllocObject(kind any) any
+}
+
+// AllocObject creates a new object of type U, either via the decoder's custom
+// allocator (if set) or via Go's runtime.
+//
+// The object is charged against the decoder's allocation budget, but since it
+// is small and needed by the caller, it is returned even if the budget is
+// exceeded (the decoder is failed nonetheless).
+func AllocObject[U any](dec *Decoder) *U {
+	dec.chargeAlloc(uint64(sizeOf[U]()))
+
+	if dec.alloc != nil {
+		if obj, ok := dec.alloc.AllocObject((*U)(nil)).(*U); ok && obj != nil {
+			return obj
+		}
+	}
+	return new(U)
+}
+
+// allocObjectOf is the reflection based version of AllocObject, used when the
+// type of the object to create is only known at runtime. The returned value is
+// a pointer to the new object.
+func allocObjectOf(dec *Decoder, typ reflect.Type) reflect.Value {
+	dec.chargeAlloc(uint64(typ.Size()))
+
+	if dec.alloc != nil {
+		kind := reflect.PointerTo(typ)
+		if obj := reflect.ValueOf(dec.alloc.AllocObject(reflect.Zero(kind).Interface())); obj.IsValid() && obj.Type() == kind && !obj.IsNil() {
+			return obj
+		}
+	}
+	return reflect.New(typ)
+}
+
+// allocBytes creates a new byte slice of length n, either via the decoder's
+// custom allocator (if set) or via Go's runtime. If the allocation budget would
+// be exceeded, nil is returned and the decoder is failed.
+func (dec *Decoder) allocBytes(n uint64) []byte {
+	if !dec.chargeAlloc(n) {
+		return nil
+	}
+	if dec.alloc != nil {
+		return dec.alloc.AllocBytes(int(n))
+	}
+	return make([]byte, n)
+}
+
+// chargeAlloc accounts for size bytes about to be allocated against the decoder's
+// allocation budget, failing the decoding if it would be exceeded.
+func (dec *Decoder) chargeAlloc(size uint64) bool {
+	if dec.allocMax == 0 {
+		return true
+	}
+	if dec.allocShared != nil {
+		dec.allocUsed = dec.allocShared.Add(size) - size
+	}
+	if dec.allocUsed+size > dec.allocMax {
+		if dec.err == nil {
+			dec.err = fmt.Errorf("%w: allocating %d bytes, %d of %d used", ErrMaxAllocExceeded, size, dec.allocUsed, dec.allocMax)
+		}
+		return false
+	}
+	dec.allocUsed += size
+	return true
+}
+
+// streamChunkBytes is the maximum size of a slice allocated up front for data
+// that is yet to be read from a stream. Since the declared lengths in a stream
+// are not backed by actual data until read, larger slices are grown gradually
+// as the data arrives, so a bogus length cannot trigger a huge allocation.
+const streamChunkBytes = 64 * 1024
+
+// reserveSlice resizes a slice to be filled with n decoded items. If the slice
+// has enough capacity, it's simply resliced, otherwise a new one is allocated,
+// carrying over any previously decoded items so their nested memory can be
+// reused.
+//
+// When decoding from a stream, the new slice is capped to streamChunkBytes and
+// growSlice must be called to extend it as the items are filled.
+func reserveSlice[T any](dec *Decoder, s *[]T, n uint32) {
+	if dec.inReader != nil && uint32(cap(*s)) < n {
+		if chunk := uint32(streamChunkBytes / max(sizeOf[T](), 1)); chunk < n {
+			n = max(chunk, uint32(cap(*s)))
+		}
+	}
+	resizeSlice(dec, s, n)
+}
+
+// growSlice extends a partially reserved slice, doubling its size, but capped
+// to the total number of items n.
+func growSlice[T any](dec *Decoder, s *[]T, n uint32) {
+	resizeSlice(dec, s, min(2*uint32(len(*s)), n))
+}
+
+// resizeSlice resizes a slice to hold exactly n items. If the slice has enough
+// capacity, it's simply resliced, otherwise a new one is allocated, carrying
+// over any previously decoded items.
+func resizeSlice[T any](dec *Decoder, s *[]T, n uint32) {
+	if uint32(cap(*s)) >= n {
+		*s = (*s)[:n]
+		return
+	}
+	if !dec.chargeAlloc(uint64(n) * uint64(sizeOf[T]())) {
+		return
+	}
+	items := make([]T, n)
+	copy(items, (*s)[:cap(*s)])
+	*s = items
+}
+
+// reserveBytes resizes a byte slice to be filled with n bytes. If the slice has
+// enough capacity, it's simply resliced, otherwise a new one is allocated.
+//
+// When decoding from a stream, the new slice is capped to streamChunkBytes and
+// the data must be read via readBytes to extend it as the bytes arrive.
+func (dec *Decoder) reserveBytes(blob *[]byte, n uint32) {
+	if uint32(cap(*blob)) >= n {
+		*blob = (*blob)[:n]
+		return
+	}
+	if dec.inReader != nil && n > streamChunkBytes {
+		if uint32(cap(*blob)) >= streamChunkBytes {
+			*blob = (*blob)[:cap(*blob)]
+			return
+		}
+		n = streamChunkBytes
+	}
+	*blob = dec.allocBytes(uint64(n))
+}
+
+// readBytes fills a (potentially partially) reserved byte slice with n bytes
+// from the input stream, growing it gradually as the data arrives.
+func (dec *Decoder) readBytes(blob *[]byte, n uint32) {
+	var read int
+	for {
+		_, dec.err = io.ReadFull(dec.inReader, (*blob)[read:])
+		if dec.err != nil || uint32(len(*blob)) == n {
+			return
+		}
+		read = len(*blob)
+
+		grown := dec.allocBytes(uint64(min(2*uint32(read), n)))
+		if dec.err != nil {
+			return
+		}
+		copy(grown, *blob)
+		*blob = grown
+	}
+}
+
+// arenaChunkBytes is the size of the byte chunks an Arena allocates at once.
+const arenaChunkBytes = 64 * 1024
+
+// arenaChunkItems is the number of objects an Arena allocates at once per type.
+const arenaChunkItems = 256
+
+// Arena is a simple Allocator that carves byte slices and objects out of large
+// chunks, and allows releasing everything wholesale via Reset. It is not safe
+// for concurrent use.
+type Arena struct {
+	bytes [][]byte                    // Byte chunks allocated so far
+	bytei int                         // Index of the byte chunk in use
+	bytep int                         // Position within the byte chunk in use
+	slabs map[reflect.Type]*arenaSlab // Object chunks allocated so far, per type
+}
+
+// arenaSlab is a list of object chunks of the same type.
+type arenaSlab struct {
+	chunks []reflect.Value // Slices of objects allocated so far
+	chunki int             // Index of the object chunk in use
+	chunkp int             // Position within the object chunk in use
+}
+
+// NewArena creates a new, empty arena allocator.
+func NewArena() *Arena {
+	return &Arena{slabs: make(map[reflect.Type]*arenaSlab)}
+}
+
+// AllocBytes implements Allocator, returning a zeroed byte slice of length n.
+func (a *Arena) AllocBytes(n int) []byte {
+	// Large blobs are not worth chunking up, allocate them directly
+	if n > arenaChunkBytes/4 {
+		return make([]byte, n)
+	}
+	// Small blob, carve it out of the current chunk (or a new one)
+	if a.bytei < len(a.bytes) && a.bytep+n > arenaChunkBytes {
+		a.bytei, a.bytep = a.bytei+1, 0
+	}
+	if a.bytei == len(a.bytes) {
+		a.bytes = append(a.bytes, make([]byte, arenaChunkBytes))
+	}
+	blob := a.bytes[a.bytei][a.bytep : a.bytep+n : a.bytep+n]
+	a.bytep += n
+	return blob
+}
+
+// AllocObject implements Allocator, returning a new zeroed object of the same
+// type as the typed nil pointer passed in.
+func (a *Arena) AllocObject(kind any) any {
+	typ := reflect.TypeOf(kind)
+	if typ == nil || typ.Kind() != reflect.Pointer {
+		return nil
+	}
+	typ = typ.Elem()
+
+	slab, ok := a.slabs[typ]
+	if !ok {
+		slab = new(arenaSlab)
+		a.slabs[typ] = slab
+	}
+	if slab.chunki < len(slab.chunks) && slab.chunkp == arenaChunkItems {
+		slab.chunki, slab.chunkp = slab.chunki+1, 0
+	}
+	if slab.chunki == len(slab.chunks) {
+		slab.chunks = append(slab.chunks, reflect.MakeSlice(reflect.SliceOf(typ), arenaChunkItems, arenaChunkItems))
+	}
+	obj := slab.chunks[slab.chunki].Index(slab.chunkp).Addr().Interface()
+	slab.chunkp++
+	return obj
+}
+
+// Reset releases all the memory handed out by the arena, zeroing it and making
+// it available for subsequent allocations. Any previously returned byte slices
+// or objects must not be used after calling this method.
+func (a *Arena) Reset() {
+	for i := 0; i <= a.bytei && i < len(a.bytes); i++ {
+		clear(a.bytes[i])
+	}
+	a.bytei, a.bytep = 0, 0
+
+	for _, slab := range a.slabs {
+		for i := 0; i <= slab.chunki && i < len(slab.chunks); i++ {
+			slab.chunks[i].Clear()
+		}
+		slab.chunki, slab.chunkp = 0, 0
+	}
+}
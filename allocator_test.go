@@ -0,0 +1,57 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that decoding into a custom arena allocator produces the same objects
+// as decoding via Go's allocator, and that the arena can be reset and reused.
+func TestDecodeWithArena(t *testing.T) {
+	obj := &types.ExecutionPayload{
+		BlockNumber:   1,
+		ExtraData:     []byte{0x01, 0x02, 0x03},
+		Transactions:  [][]byte{{0x04}, {0x05, 0x06}},
+		BaseFeePerGas: uint256.NewInt(7),
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	arena := ssz.NewArena()
+	for i := 0; i < 2; i++ {
+		dec := new(types.ExecutionPayload)
+		if err := ssz.DecodeFromBytesWithConfig(blob, dec, &ssz.DecoderConfig{Allocator: arena}); err != nil {
+			t.Fatalf("run %d: failed to decode object: %v", i, err)
+		}
+		if ssz.HashSequential(obj) != ssz.HashSequential(dec) {
+			t.Errorf("run %d: hash mismatch after arena round trip", i)
+		}
+		arena.Reset()
+	}
+}
+
+// Tests that the decoder rejects inputs that would need more memory allocated
+// than the configured budget, but accepts them when decoding in place.
+func TestDecodeMaxAlloc(t *testing.T) {
+	obj := &testBigListType{Items: make([]uint64, 1024)}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	cfg := &ssz.DecoderConfig{MaxAlloc: 4096}
+	if err := ssz.DecodeFromBytesWithConfig(blob, new(testBigListType), cfg); !errors.Is(err, ssz.ErrMaxAllocExceeded) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, ssz.ErrMaxAllocExceeded)
+	}
+	if err := ssz.DecodeFromBytesWithConfig(blob, obj, cfg); err != nil {
+		t.Errorf("failed to decode in place: %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build purego
+
+package ssz
+
+import "reflect"
+
+// byteArrays is the set of byte array types that need to be viewed as slices.
+type byteArrays interface {
+	commonBytesLengths | commonBitsLengths
+}
+
+// arrayBytes returns a byte slice view of a byte array, without copying.
+//
+// This is the reflection based variant for environments where package unsafe
+// is not available (e.g. TinyGo, GopherJS or restricted sandboxes).
+func arrayBytes[T byteArrays](blob *T) []byte {
+	return reflect.ValueOf(blob).Elem().Slice(0, len(*blob)).Bytes()
+}
+
+// arrayUint64s returns a uint64 slice view of a uint64 array, without copying.
+func arrayUint64s[T commonUint64sLengths](ns *T) []uint64 {
+	return reflect.ValueOf(ns).Elem().Slice(0, len(*ns)).Interface().([]uint64)
+}
+
+// uint64sBytes would return a byte slice view of a uint64 slice, but that needs
+// package unsafe, so callers always fall back to encoding the items one by one.
+func uint64sBytes[T ~uint64](ns []T) ([]byte, bool) {
+	return nil, false
+}
+
+// itemsBytes would return a byte slice view of a slice of byte arrays, but that
+// needs package unsafe, so callers always fall back to copying item by item.
+func itemsBytes[T commonBytesLengths](blobs []T) ([]byte, bool) {
+	return nil, false
+}
+
+// uint64sView would be the zero-copy view behind uint64sBytes, but that needs
+// package unsafe, so it is never available.
+func uint64sView[T ~uint64](ns []T) ([]byte, bool) {
+	return nil, false
+}
+
+// itemsView would be the zero-copy view behind itemsBytes, but that needs package
+// unsafe, so it is never available.
+func itemsView[T commonBytesLengths](blobs []T) ([]byte, bool) {
+	return nil, false
+}
+
+// arrayItems returns a slice view of an array of byte arrays, without copying.
+func arrayItems[T commonBytesArrayLengths[U], U commonBytesLengths](blobs *T) []U {
+	return reflect.ValueOf(blobs).Elem().Slice(0, len(*blobs)).Interface().([]U)
+}
+
+// stringBytes returns a byte slice view of a string. This variant copies.
+func stringBytes(s string) []byte {
+	return []byte(s)
+}
+
+// bufferAddr returns the address of the first byte of a non-empty buffer, used
+// to track positions within the decoder's input.
+func bufferAddr(buf []byte) uintptr {
+	return reflect.ValueOf(buf).Pointer()
+}
+
+// sizeOf returns the memory size of a value of type T.
+func sizeOf[T any]() uintptr {
+	return reflect.TypeOf((*T)(nil)).Elem().Size()
+}
@@ -0,0 +1,94 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build !purego
+
+package ssz
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// littleEndian is whether the platform stores integers in little endian byte
+// order, matching the ssz encoding of uints.
+var littleEndian = binary.NativeEndian.Uint16([]byte{0x01, 0x00}) == 1
+
+// byteArrays is the set of byte array types that need to be viewed as slices.
+type byteArrays interface {
+	commonBytesLengths | commonBitsLengths
+}
+
+// arrayBytes returns a byte slice view of a byte array, without copying.
+//
+// The code below should have been `(*blob)[:]`, alas Go's generics compiler is
+// missing that (i.e. a bug): https://github.com/golang/go/issues/51740
+func arrayBytes[T byteArrays](blob *T) []byte {
+	return unsafe.Slice(&(*blob)[0], len(*blob))
+}
+
+// arrayUint64s returns a uint64 slice view of a uint64 array, without copying.
+func arrayUint64s[T commonUint64sLengths](ns *T) []uint64 {
+	return unsafe.Slice(&(*ns)[0], len(*ns))
+}
+
+// uint64sBytes returns a byte slice view of a uint64 slice, without copying, if
+// its memory layout matches the ssz encoding (i.e. on little endian platforms).
+// The view must not be modified.
+func uint64sBytes[T ~uint64](ns []T) ([]byte, bool) {
+	if !fastViews {
+		return nil, false
+	}
+	return uint64sView(ns)
+}
+
+// uint64sView is the zero-copy view behind uint64sBytes, regardless of whether
+// the platform self-check disabled it or not.
+func uint64sView[T ~uint64](ns []T) ([]byte, bool) {
+	if !littleEndian || len(ns) == 0 {
+		return nil, false
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&ns[0])), len(ns)*8), true
+}
+
+// itemsBytes returns a byte slice view of a slice of byte arrays, without
+// copying, the arrays being laid out back to back in memory.
+func itemsBytes[T commonBytesLengths](blobs []T) ([]byte, bool) {
+	if !fastViews {
+		return nil, false
+	}
+	return itemsView(blobs)
+}
+
+// itemsView is the zero-copy view behind itemsBytes, regardless of whether the
+// platform self-check disabled it or not.
+func itemsView[T commonBytesLengths](blobs []T) ([]byte, bool) {
+	if len(blobs) == 0 {
+		return nil, false
+	}
+	return unsafe.Slice(&blobs[0][0], len(blobs)*len(blobs[0])), true
+}
+
+// arrayItems returns a slice view of an array of byte arrays, without copying.
+func arrayItems[T commonBytesArrayLengths[U], U commonBytesLengths](blobs *T) []U {
+	return unsafe.Slice(&(*blobs)[0], len(*blobs))
+}
+
+// stringBytes returns a byte slice view of a string, without copying. The view
+// must not be modified.
+func stringBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// bufferAddr returns the address of the first byte of a non-empty buffer, used
+// to track positions within the decoder's input.
+func bufferAddr(buf []byte) uintptr {
+	return uintptr(unsafe.Pointer(&buf[0]))
+}
+
+// sizeOf returns the memory size of a value of type T.
+func sizeOf[T any]() uintptr {
+	var sizer T
+	return unsafe.Sizeof(sizer)
+}
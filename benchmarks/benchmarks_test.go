@@ -0,0 +1,124 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package benchmarks
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// validators is the size of the validator registry in the state corpus. It is
+// smaller than mainnet by default to keep the benchmarks quick to set up; pass
+// -validators=1000000 for the real thing.
+var validators = flag.Int("validators", 100_000, "validators in the benchmark state")
+
+// Tests that the corpora are self consistent: they round trip through decoding
+// and are deterministic across constructions.
+func TestCorpora(t *testing.T) {
+	corpora, err := Corpora(1024)
+	if err != nil {
+		t.Fatalf("failed to create corpora: %v", err)
+	}
+	again, err := Corpora(1024)
+	if err != nil {
+		t.Fatalf("failed to recreate corpora: %v", err)
+	}
+	for i, corpus := range corpora {
+		if !bytes.Equal(corpus.Blob, again[i].Blob) {
+			t.Errorf("%s: corpus not deterministic", corpus.Name)
+		}
+		obj := corpus.New()
+		if err := ssz.DecodeFromBytes(corpus.Blob, obj); err != nil {
+			t.Fatalf("%s: failed to decode corpus: %v", corpus.Name, err)
+		}
+		if have, want := ssz.HashSequential(obj), ssz.HashSequential(corpus.Obj); have != want {
+			t.Errorf("%s: hash mismatch: have %#x, want %#x", corpus.Name, have, want)
+		}
+	}
+}
+
+func BenchmarkCorpora(b *testing.B) {
+	corpora, err := Corpora(*validators)
+	if err != nil {
+		b.Fatalf("failed to create corpora: %v", err)
+	}
+	for _, corpus := range corpora {
+		corpus := corpus // Take care, closure
+
+		b.Run(corpus.Name+"/encode", func(b *testing.B) {
+			b.SetBytes(int64(len(corpus.Blob)))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if err := ssz.EncodeToStream(io.Discard, corpus.Obj); err != nil {
+					b.Fatalf("failed to encode: %v", err)
+				}
+			}
+		})
+		b.Run(corpus.Name+"/encode-concurrent", func(b *testing.B) {
+			buf := make([]byte, len(corpus.Blob))
+
+			b.SetBytes(int64(len(corpus.Blob)))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if err := ssz.EncodeToBytesConcurrent(buf, corpus.Obj); err != nil {
+					b.Fatalf("failed to encode: %v", err)
+				}
+			}
+		})
+		b.Run(corpus.Name+"/decode", func(b *testing.B) {
+			obj := corpus.New()
+
+			b.SetBytes(int64(len(corpus.Blob)))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if err := ssz.DecodeFromBytes(corpus.Blob, obj); err != nil {
+					b.Fatalf("failed to decode: %v", err)
+				}
+			}
+		})
+		b.Run(corpus.Name+"/decode-concurrent", func(b *testing.B) {
+			obj := corpus.New()
+			cfg := &ssz.DecoderConfig{Concurrent: true}
+
+			b.SetBytes(int64(len(corpus.Blob)))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if err := ssz.DecodeFromBytesWithConfig(corpus.Blob, obj, cfg); err != nil {
+					b.Fatalf("failed to decode: %v", err)
+				}
+			}
+		})
+		b.Run(corpus.Name+"/merkleize-sequential", func(b *testing.B) {
+			b.SetBytes(int64(len(corpus.Blob)))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				ssz.HashSequential(corpus.Obj)
+			}
+		})
+		b.Run(corpus.Name+"/merkleize-concurrent", func(b *testing.B) {
+			b.SetBytes(int64(len(corpus.Blob)))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				ssz.HashConcurrent(corpus.Obj)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package benchmarks contains realistic, mainnet-shaped corpora of consensus
+// objects and the benchmarks running the codec against them.
+//
+// The corpora are generated deterministically from a seed, so the same inputs
+// are measured across revisions without checking large blobs into the repo.
+// The shapes (list lengths, bitfield sizes, transaction sizes) follow what is
+// seen on mainnet around the deneb fork, but the contents are random.
+//
+// To detect performance regressions, run the benchmarks on two revisions and
+// compare them with benchstat:
+//
+//	go test ./benchmarks -run=NONE -bench=. -count=10 > new.txt
+//	benchstat old.txt new.txt
+package benchmarks
+
+import (
+	"math/rand"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/bitfield"
+	"github.com/karalabe/ssz/sszcommon"
+)
+
+// Mainnet shapes of the deneb beacon blocks and states.
+const (
+	MainnetValidators      = 1_000_000 // Number of validators in the registry
+	MainnetCommitteeSize   = 488       // Attesters in a committee at the above validator count
+	MainnetAttestations    = 128       // Attestations in a full block
+	MainnetTransactions    = 200       // Transactions in an average execution payload
+	MainnetTransactionSize = 400       // Average size of a transaction in bytes
+	MainnetWithdrawals     = 16        // Withdrawals in a full execution payload
+	MainnetBlobs           = 3         // Blob commitments in an average block
+	MainnetHistoricalRoots = 758       // Historical roots frozen at the capella fork
+	MainnetSummaries       = 1000      // Historical summaries accumulated since capella
+	MainnetEth1Votes       = 1024      // Eth1 data votes halfway through a voting period
+)
+
+// Corpus is a named object to run the benchmarks on, along with its encoding.
+type Corpus struct {
+	Name string            // Name of the corpus, used as the benchmark name
+	Obj  ssz.Object        // Object to encode and hash
+	Blob []byte            // SSZ encoding of the object, to decode
+	New  func() ssz.Object // Constructor for empty objects to decode into
+}
+
+// NewCorpus creates a benchmark corpus from an object, encoding it upfront.
+func NewCorpus[T ssz.Object](name string, obj T, fresh func() T) (*Corpus, error) {
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		return nil, err
+	}
+	return &Corpus{
+		Name: name,
+		Obj:  obj,
+		Blob: blob,
+		New:  func() ssz.Object { return fresh() },
+	}, nil
+}
+
+// BlockDeneb creates a signed deneb beacon block shaped like an average mainnet
+// block: full attestations and withdrawals, a few hundred transactions and some
+// blobs, but no slashings, deposits or exits.
+func BlockDeneb(seed int64) *sszcommon.SignedBeaconBlockDeneb {
+	rng := rand.New(rand.NewSource(seed))
+
+	body := &sszcommon.BeaconBlockBodyDeneb{
+		Eth1Data:      randEth1Data(rng),
+		SyncAggregate: new(sszcommon.SyncAggregate),
+		ExecutionPayload: &sszcommon.ExecutionPayloadDeneb{
+			BlockNumber:   rng.Uint64(),
+			GasLimit:      30_000_000,
+			GasUsed:       rng.Uint64() % 30_000_000,
+			Timestamp:     rng.Uint64(),
+			ExtraData:     randBytes(rng, 32),
+			BaseFeePerGas: uint256.NewInt(rng.Uint64()),
+		},
+	}
+	rng.Read(body.RandaoReveal[:])
+	rng.Read(body.Graffiti[:])
+	rng.Read(body.SyncAggregate.SyncCommitteeBits[:])
+	rng.Read(body.SyncAggregate.SyncCommitteeSignature[:])
+
+	for i := 0; i < MainnetAttestations; i++ {
+		att := &sszcommon.Attestation{
+			AggregationBits: bitfield.NewBitlist(MainnetCommitteeSize),
+			Data:            randAttestationData(rng),
+		}
+		for j := uint64(0); j < MainnetCommitteeSize; j++ {
+			if rng.Intn(8) != 0 {
+				att.AggregationBits.SetBitAt(j, true)
+			}
+		}
+		rng.Read(att.Signature[:])
+		body.Attestations = append(body.Attestations, att)
+	}
+	payload := body.ExecutionPayload
+	rng.Read(payload.ParentHash[:])
+	rng.Read(payload.FeeRecipient[:])
+	rng.Read(payload.StateRoot[:])
+	rng.Read(payload.ReceiptsRoot[:])
+	rng.Read(payload.LogsBloom[:])
+	rng.Read(payload.PrevRandao[:])
+	rng.Read(payload.BlockHash[:])
+
+	for i := 0; i < MainnetTransactions; i++ {
+		payload.Transactions = append(payload.Transactions, randBytes(rng, 1+rng.Intn(2*MainnetTransactionSize)))
+	}
+	for i := 0; i < MainnetWithdrawals; i++ {
+		withdrawal := &sszcommon.Withdrawal{
+			Index:          rng.Uint64(),
+			ValidatorIndex: rng.Uint64() % MainnetValidators,
+			Amount:         rng.Uint64() % 64_000_000_000,
+		}
+		rng.Read(withdrawal.Address[:])
+		payload.Withdrawals = append(payload.Withdrawals, withdrawal)
+	}
+	body.BlobKZGCommitments = make([]sszcommon.KZGCommitment, MainnetBlobs)
+	for i := range body.BlobKZGCommitments {
+		rng.Read(body.BlobKZGCommitments[i][:])
+	}
+	block := &sszcommon.SignedBeaconBlockDeneb{
+		Message: &sszcommon.BeaconBlockDeneb{
+			Slot:          rng.Uint64(),
+			ProposerIndex: rng.Uint64() % MainnetValidators,
+			Body:          body,
+		},
+	}
+	rng.Read(block.Message.ParentRoot[:])
+	rng.Read(block.Message.StateRoot[:])
+	rng.Read(block.Signature[:])
+
+	return block
+}
+
+// StateDeneb creates a deneb beacon state shaped like a mainnet one, with the
+// requested number of validators (MainnetValidators for the real thing).
+func StateDeneb(seed int64, validators int) *sszcommon.BeaconStateDeneb {
+	rng := rand.New(rand.NewSource(seed))
+
+	state := &sszcommon.BeaconStateDeneb{
+		GenesisTime:                 rng.Uint64(),
+		Slot:                        rng.Uint64(),
+		Fork:                        new(sszcommon.Fork),
+		LatestBlockHeader:           new(sszcommon.BeaconBlockHeader),
+		Eth1Data:                    randEth1Data(rng),
+		Eth1DepositIndex:            rng.Uint64(),
+		PreviousJustifiedCheckpoint: randCheckpoint(rng),
+		CurrentJustifiedCheckpoint:  randCheckpoint(rng),
+		FinalizedCheckpoint:         randCheckpoint(rng),
+		CurrentSyncCommittee:        new(sszcommon.SyncCommittee),
+		NextSyncCommittee:           new(sszcommon.SyncCommittee),
+		LatestExecutionPayloadHeader: &sszcommon.ExecutionPayloadHeaderDeneb{
+			BlockNumber:   rng.Uint64(),
+			GasLimit:      30_000_000,
+			ExtraData:     randBytes(rng, 32),
+			BaseFeePerGas: uint256.NewInt(rng.Uint64()),
+		},
+		NextWithdrawalIndex:          rng.Uint64(),
+		NextWithdrawalValidatorIndex: rng.Uint64() % uint64(max(validators, 1)),
+	}
+	rng.Read(state.GenesisValidatorsRoot[:])
+	rng.Read(state.LatestBlockHeader.ParentRoot[:])
+	rng.Read(state.LatestBlockHeader.BodyRoot[:])
+	for i := range state.BlockRoots {
+		rng.Read(state.BlockRoots[i][:])
+		rng.Read(state.StateRoots[i][:])
+		state.Slashings[i] = rng.Uint64()
+	}
+	for i := range state.RandaoMixes {
+		rng.Read(state.RandaoMixes[i][:])
+	}
+	state.HistoricalRoots = make([][32]byte, MainnetHistoricalRoots)
+	for i := range state.HistoricalRoots {
+		rng.Read(state.HistoricalRoots[i][:])
+	}
+	for i := 0; i < MainnetEth1Votes; i++ {
+		state.Eth1DataVotes = append(state.Eth1DataVotes, randEth1Data(rng))
+	}
+	for i := 0; i < MainnetSummaries; i++ {
+		summary := new(sszcommon.HistoricalSummary)
+		rng.Read(summary.BlockSummaryRoot[:])
+		rng.Read(summary.StateSummaryRoot[:])
+		state.HistoricalSummaries = append(state.HistoricalSummaries, summary)
+	}
+	for i := range state.CurrentSyncCommittee.Pubkeys {
+		rng.Read(state.CurrentSyncCommittee.Pubkeys[i][:])
+		rng.Read(state.NextSyncCommittee.Pubkeys[i][:])
+	}
+	// Fill the validator registry and all the per-validator lists
+	state.Validators = make([]*sszcommon.Validator, validators)
+	state.Balances = make([]uint64, validators)
+	state.PreviousEpochParticipation = make([]byte, validators)
+	state.CurrentEpochParticipation = make([]byte, validators)
+	state.InactivityScores = make([]uint64, validators)
+
+	for i := 0; i < validators; i++ {
+		validator := &sszcommon.Validator{
+			EffectiveBalance:           32_000_000_000,
+			ActivationEligibilityEpoch: rng.Uint64() % 300_000,
+			ActivationEpoch:            rng.Uint64() % 300_000,
+			ExitEpoch:                  ^uint64(0),
+			WithdrawableEpoch:          ^uint64(0),
+		}
+		rng.Read(validator.Pubkey[:])
+		rng.Read(validator.WithdrawalCredentials[:])
+		state.Validators[i] = validator
+
+		state.Balances[i] = 32_000_000_000 + rng.Uint64()%100_000_000
+		state.PreviousEpochParticipation[i] = 7
+		state.CurrentEpochParticipation[i] = byte(rng.Intn(8))
+	}
+	return state
+}
+
+// Corpora creates the standard benchmark corpora: a mainnet block, and a state
+// with the given number of validators.
+func Corpora(validators int) ([]*Corpus, error) {
+	block, err := NewCorpus("block-deneb", BlockDeneb(1), func() *sszcommon.SignedBeaconBlockDeneb {
+		return new(sszcommon.SignedBeaconBlockDeneb)
+	})
+	if err != nil {
+		return nil, err
+	}
+	state, err := NewCorpus("state-deneb", StateDeneb(1, validators), func() *sszcommon.BeaconStateDeneb {
+		return new(sszcommon.BeaconStateDeneb)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []*Corpus{block, state}, nil
+}
+
+// randBytes creates a random byte slice of the given length.
+func randBytes(rng *rand.Rand, n int) []byte {
+	blob := make([]byte, n)
+	rng.Read(blob)
+	return blob
+}
+
+// randCheckpoint creates a random checkpoint.
+func randCheckpoint(rng *rand.Rand) *sszcommon.Checkpoint {
+	checkpoint := &sszcommon.Checkpoint{Epoch: rng.Uint64()}
+	rng.Read(checkpoint.Root[:])
+	return checkpoint
+}
+
+// randEth1Data creates a random eth1 data vote.
+func randEth1Data(rng *rand.Rand) *sszcommon.Eth1Data {
+	data := &sszcommon.Eth1Data{DepositCount: rng.Uint64()}
+	rng.Read(data.DepositRoot[:])
+	rng.Read(data.BlockHash[:])
+	return data
+}
+
+// randAttestationData creates a random attestation vote.
+func randAttestationData(rng *rand.Rand) *sszcommon.AttestationData {
+	data := &sszcommon.AttestationData{
+		Slot:   rng.Uint64(),
+		Index:  rng.Uint64() % 64,
+		Source: randCheckpoint(rng),
+		Target: randCheckpoint(rng),
+	}
+	rng.Read(data.BeaconBlockRoot[:])
+	return data
+}
@@ -0,0 +1,168 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package bitfield_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/bitfield"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	prysm "github.com/prysmaticlabs/go-bitfield"
+)
+
+type bits2 struct{}
+
+func (bits2) Limit() uint64 { return 2 }
+
+type bits8 struct{}
+
+func (bits8) Limit() uint64 { return 8 }
+
+type bits12 struct{}
+
+func (bits12) Limit() uint64 { return 12 }
+
+// Tests the basic bitlist accessors and set operations.
+func TestBitlist(t *testing.T) {
+	a := bitfield.NewBitlist(10)
+	if a.Len() != 10 || a.Count() != 0 {
+		t.Fatalf("fresh bitlist mismatch: len %d, count %d", a.Len(), a.Count())
+	}
+	a.SetBitAt(1, true)
+	a.SetBitAt(9, true)
+	a.SetBitAt(10, true) // out of bounds, must not touch the length bit
+	if a.Len() != 10 || a.Count() != 2 || !a.BitAt(9) || a.BitAt(10) {
+		t.Fatalf("bitlist mismatch after set: len %d, count %d", a.Len(), a.Count())
+	}
+	if want := prysm.NewBitlist(10); !bytes.Equal(bitfield.NewBitlist(10), want) {
+		t.Errorf("encoding mismatch with prysm: have %x, want %x", bitfield.NewBitlist(10), want)
+	}
+	b := bitfield.NewBitlist(10)
+	b.SetBitAt(1, true)
+
+	if or, _ := a.Or(b); !bytes.Equal(or, a) {
+		t.Errorf("union mismatch: have %x, want %x", or, a)
+	}
+	if and, _ := a.And(b); !bytes.Equal(and, b) {
+		t.Errorf("intersection mismatch: have %x, want %x", and, b)
+	}
+	if ok, _ := a.Contains(b); !ok {
+		t.Errorf("subset not detected")
+	}
+	if ok, _ := b.Contains(a); ok {
+		t.Errorf("superset reported as subset")
+	}
+	b.SetBitAt(1, false)
+	if ok, _ := a.Overlaps(b); ok {
+		t.Errorf("overlap reported on disjoint bitlists")
+	}
+	if _, err := a.Or(bitfield.NewBitlist(11)); !errors.Is(err, bitfield.ErrLengthMismatch) {
+		t.Errorf("length mismatch error mismatch: have %v, want %v", err, bitfield.ErrLengthMismatch)
+	}
+	if _, err := a.Or(nil); !errors.Is(err, bitfield.ErrLengthBitMissing) {
+		t.Errorf("length bit error mismatch: have %v, want %v", err, bitfield.ErrLengthBitMissing)
+	}
+}
+
+// Tests the basic bitvector accessors and set operations.
+func TestBitvector(t *testing.T) {
+	a := bitfield.NewBitvector[bits12]()
+	if len(a) != 2 || a.Len() != 12 {
+		t.Fatalf("fresh bitvector mismatch: bytes %d, len %d", len(a), a.Len())
+	}
+	a.SetBitAt(0, true)
+	a.SetBitAt(11, true)
+	a.SetBitAt(12, true) // out of bounds, must not create junk
+	if a.Count() != 2 || !a.BitAt(11) || a[1] != 0x08 {
+		t.Fatalf("bitvector mismatch after set: %x", []byte(a))
+	}
+	b := bitfield.NewBitvector[bits12]()
+	b.SetBitAt(11, true)
+
+	if or := a.Or(b); !bytes.Equal(or, a) {
+		t.Errorf("union mismatch: have %x, want %x", or, a)
+	}
+	if and := a.And(b); !bytes.Equal(and, b) {
+		t.Errorf("intersection mismatch: have %x, want %x", and, b)
+	}
+	if !a.Contains(b) || b.Contains(a) {
+		t.Errorf("subset check mismatch")
+	}
+	if !a.Overlaps(b) {
+		t.Errorf("overlap not detected")
+	}
+}
+
+// bitsStruct is the same as types.BitsStruct, but using the bitfield types.
+type bitsStruct struct {
+	A bitfield.Bitlist
+	B bitfield.Bitvector[bits2]
+	C [1]byte
+	D bitfield.Bitlist
+	E bitfield.Bitvector[bits8]
+}
+
+func (obj *bitsStruct) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(4 + 1 + 1 + 4 + 1)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfBits(obj.A)
+	size += ssz.SizeSliceOfBits(obj.D)
+
+	return size
+}
+
+func (obj *bitsStruct) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfBitsOffset(codec, &obj.A, 5)
+	ssz.DefineCheckedArrayOfBits(codec, &obj.B, 2)
+	ssz.DefineArrayOfBits(codec, &obj.C, 1)
+	ssz.DefineSliceOfBitsOffset(codec, &obj.D, 6)
+	ssz.DefineCheckedArrayOfBits(codec, &obj.E, 8)
+
+	ssz.DefineSliceOfBitsContent(codec, &obj.A, 5)
+	ssz.DefineSliceOfBitsContent(codec, &obj.D, 6)
+}
+
+// Tests that the bitfield types encode, decode and hash the same way as the
+// prysm bitlist and the static bit arrays.
+func TestBitfieldCodec(t *testing.T) {
+	want := &types.BitsStruct{
+		A: prysm.Bitlist{0x25},
+		B: [1]byte{0x02},
+		C: [1]byte{0x01},
+		D: prysm.Bitlist{0x4a},
+		E: [1]byte{0xa5},
+	}
+	blob := make([]byte, ssz.Size(want))
+	if err := ssz.EncodeToBytes(blob, want); err != nil {
+		t.Fatalf("failed to encode reference object: %v", err)
+	}
+	obj := new(bitsStruct)
+	if err := ssz.DecodeFromBytes(blob, obj); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if obj.A.Count() != 2 || !obj.B.BitAt(1) || obj.E.Count() != 4 {
+		t.Errorf("decoded content mismatch: %+v", obj)
+	}
+	have := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(have, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if !bytes.Equal(have, blob) {
+		t.Errorf("encoding mismatch: have %x, want %x", have, blob)
+	}
+	if have, want := ssz.HashSequential(obj), ssz.HashSequential(want); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	// Junk in the high bits of a bitvector must be rejected
+	blob[4] |= 0x04
+	if err := ssz.DecodeFromBytes(blob, obj); !errors.Is(err, ssz.ErrJunkInBitvector) {
+		t.Errorf("junk error mismatch: have %v, want %v", err, ssz.ErrJunkInBitvector)
+	}
+}
@@ -0,0 +1,171 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package bitfield implements the SSZ bitlist and bitvector types along with the
+// set operations commonly needed on them (e.g. aggregating attestations).
+//
+// Both types are plain byte slices in their SSZ wire format, so they can be fed
+// directly into the ssz codec's bit helpers without any conversion.
+package bitfield
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+var (
+	// ErrLengthMismatch is returned when an operation is attempted on two bitlists
+	// of differing lengths.
+	ErrLengthMismatch = errors.New("bitfield: length mismatch")
+
+	// ErrLengthBitMissing is returned when an operation is attempted on a bitlist
+	// that does not have its length bit set (e.g. a zero value).
+	ErrLengthBitMissing = errors.New("bitfield: length bit missing")
+)
+
+// Bitlist is a variable length list of bits, packed into bytes in little-endian
+// bit order, with an extra length bit set right after the last item. This is the
+// exact SSZ encoding, so a Bitlist can be used with ssz.DefineSliceOfBitsOffset
+// and ssz.DefineSliceOfBitsContent directly.
+//
+// The zero value is not a valid bitlist (the length bit is missing), use the
+// NewBitlist constructor to create one.
+type Bitlist []byte
+
+// NewBitlist creates a new bitlist of n bits, all cleared.
+func NewBitlist(n uint64) Bitlist {
+	b := make(Bitlist, n>>3+1)
+	b[n>>3] = 1 << (n & 0x7)
+	return b
+}
+
+// Len returns the number of bits in the bitlist, excluding the length bit.
+func (b Bitlist) Len() uint64 {
+	if len(b) == 0 {
+		return 0
+	}
+	msb := bits.Len8(b[len(b)-1])
+	if msb == 0 {
+		return 0 // malformed, length bit missing
+	}
+	return uint64(len(b)-1)<<3 + uint64(msb) - 1
+}
+
+// BitAt returns the value of the i-th bit, or false if it's out of bounds.
+func (b Bitlist) BitAt(i uint64) bool {
+	if i >= b.Len() {
+		return false
+	}
+	return b[i>>3]&(1<<(i&0x7)) != 0
+}
+
+// SetBitAt sets the i-th bit to the given value. Setting an out of bounds bit
+// is a noop, as it would corrupt the length bit.
+func (b Bitlist) SetBitAt(i uint64, v bool) {
+	if i >= b.Len() {
+		return
+	}
+	if v {
+		b[i>>3] |= 1 << (i & 0x7)
+	} else {
+		b[i>>3] &^= 1 << (i & 0x7)
+	}
+}
+
+// Count returns the number of set bits in the bitlist, excluding the length bit.
+func (b Bitlist) Count() uint64 {
+	var count int
+	for _, x := range b {
+		count += bits.OnesCount8(x)
+	}
+	if count == 0 {
+		return 0 // malformed, length bit missing
+	}
+	return uint64(count) - 1
+}
+
+// Bytes returns the bits packed into bytes, without the length bit and with
+// any trailing zero bytes trimmed.
+func (b Bitlist) Bytes() []byte {
+	if len(b) == 0 {
+		return []byte{}
+	}
+	out := make([]byte, len(b))
+	copy(out, b)
+
+	msb := bits.Len8(out[len(out)-1])
+	if msb > 0 {
+		out[len(out)-1] &^= 1 << (msb - 1)
+	}
+	for len(out) > 0 && out[len(out)-1] == 0 {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// And returns the intersection of two equal length bitlists.
+func (b Bitlist) And(other Bitlist) (Bitlist, error) {
+	if err := b.checkLength(other); err != nil {
+		return nil, err
+	}
+	out := make(Bitlist, len(b))
+	for i := range b {
+		out[i] = b[i] & other[i] // length bits are at the same position, kept
+	}
+	return out, nil
+}
+
+// Or returns the union of two equal length bitlists.
+func (b Bitlist) Or(other Bitlist) (Bitlist, error) {
+	if err := b.checkLength(other); err != nil {
+		return nil, err
+	}
+	out := make(Bitlist, len(b))
+	for i := range b {
+		out[i] = b[i] | other[i] // length bits are at the same position, kept
+	}
+	return out, nil
+}
+
+// Overlaps returns whether two equal length bitlists have any set bits in common.
+func (b Bitlist) Overlaps(other Bitlist) (bool, error) {
+	if err := b.checkLength(other); err != nil {
+		return false, err
+	}
+	last := len(b) - 1
+	for i := 0; i < last; i++ {
+		if b[i]&other[i] != 0 {
+			return true, nil
+		}
+	}
+	// Mask out the length bit from the last byte
+	mask := byte(1)<<(bits.Len8(b[last])-1) - 1
+	return b[last]&other[last]&mask != 0, nil
+}
+
+// Contains returns whether all the bits set in other are also set in b, i.e.
+// whether other is a subset of b.
+func (b Bitlist) Contains(other Bitlist) (bool, error) {
+	if err := b.checkLength(other); err != nil {
+		return false, err
+	}
+	for i := range b {
+		if other[i]&^b[i] != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// checkLength ensures that two bitlists can be used together in a set operation.
+func (b Bitlist) checkLength(other Bitlist) error {
+	if len(b) == 0 || b[len(b)-1] == 0 || len(other) == 0 || other[len(other)-1] == 0 {
+		return ErrLengthBitMissing
+	}
+	if b.Len() != other.Len() {
+		return fmt.Errorf("%w: %d bits != %d bits", ErrLengthMismatch, b.Len(), other.Len())
+	}
+	return nil
+}
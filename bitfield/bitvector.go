@@ -0,0 +1,105 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package bitfield
+
+import (
+	"math/bits"
+
+	"github.com/karalabe/ssz"
+)
+
+// Bitvector is a fixed length vector of N bits, packed into bytes in little-endian
+// bit order. This is the exact SSZ encoding, so a Bitvector can be used with
+// ssz.DefineCheckedArrayOfBits directly.
+//
+// The zero value is an empty slice, use the NewBitvector constructor to create a
+// bitvector of the correct size.
+type Bitvector[N ssz.Bound] []byte
+
+// NewBitvector creates a new bitvector of N bits, all cleared.
+func NewBitvector[N ssz.Bound]() Bitvector[N] {
+	var bound N
+	return make(Bitvector[N], (bound.Limit()+7)>>3)
+}
+
+// Len returns the number of bits in the bitvector.
+func (b Bitvector[N]) Len() uint64 {
+	var bound N
+	return bound.Limit()
+}
+
+// BitAt returns the value of the i-th bit, or false if it's out of bounds.
+func (b Bitvector[N]) BitAt(i uint64) bool {
+	if i >= b.Len() || i>>3 >= uint64(len(b)) {
+		return false
+	}
+	return b[i>>3]&(1<<(i&0x7)) != 0
+}
+
+// SetBitAt sets the i-th bit to the given value. Setting an out of bounds bit
+// is a noop, as it would make the bitvector non-canonical.
+func (b Bitvector[N]) SetBitAt(i uint64, v bool) {
+	if i >= b.Len() || i>>3 >= uint64(len(b)) {
+		return
+	}
+	if v {
+		b[i>>3] |= 1 << (i & 0x7)
+	} else {
+		b[i>>3] &^= 1 << (i & 0x7)
+	}
+}
+
+// Count returns the number of set bits in the bitvector.
+func (b Bitvector[N]) Count() uint64 {
+	var count int
+	for _, x := range b {
+		count += bits.OnesCount8(x)
+	}
+	return uint64(count)
+}
+
+// And returns the intersection of two bitvectors.
+func (b Bitvector[N]) And(other Bitvector[N]) Bitvector[N] {
+	out := NewBitvector[N]()
+	for i := 0; i < len(out) && i < len(b) && i < len(other); i++ {
+		out[i] = b[i] & other[i]
+	}
+	return out
+}
+
+// Or returns the union of two bitvectors.
+func (b Bitvector[N]) Or(other Bitvector[N]) Bitvector[N] {
+	out := NewBitvector[N]()
+	copy(out, b)
+	for i := 0; i < len(out) && i < len(other); i++ {
+		out[i] |= other[i]
+	}
+	return out
+}
+
+// Overlaps returns whether two bitvectors have any set bits in common.
+func (b Bitvector[N]) Overlaps(other Bitvector[N]) bool {
+	for i := 0; i < len(b) && i < len(other); i++ {
+		if b[i]&other[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns whether all the bits set in other are also set in b, i.e.
+// whether other is a subset of b.
+func (b Bitvector[N]) Contains(other Bitvector[N]) bool {
+	for i := range other {
+		var have byte
+		if i < len(b) {
+			have = b[i]
+		}
+		if other[i]&^have != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,180 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import "fmt"
+
+// Some fields are dynamic on the wire, but constrained further by the application
+// (e.g. graffiti-like payloads that must be exactly, or at least, a given number
+// of bytes). The bounded and exact variants below validate these constraints as
+// part of encoding and decoding, instead of leaving it to the caller afterwards.
+// Hashing is unaffected, it always uses the wire format's own limit.
+
+// checkBoundedBytes returns an error if the length of a dynamic binary blob is
+// outside of its application constraints.
+func checkBoundedBytes(blob []byte, minSize uint64, maxSize uint64) error {
+	if size := uint64(len(blob)); size < minSize || size > maxSize {
+		if minSize == maxSize {
+			return fmt.Errorf("%w: have %d bytes, want %d", ErrDynamicBytesSizeMismatch, size, minSize)
+		}
+		return fmt.Errorf("%w: have %d bytes, want %d-%d", ErrDynamicBytesSizeMismatch, size, minSize, maxSize)
+	}
+	return nil
+}
+
+// DefineDynamicBytesBoundedOffset defines the next field as a dynamic binary blob,
+// which must be at least minSize bytes long.
+func DefineDynamicBytesBoundedOffset(c *Codec, blob *[]byte, minSize uint64, maxSize uint64) {
+	if c.enc != nil {
+		EncodeDynamicBytesBoundedOffset(c.enc, *blob, minSize, maxSize)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeDynamicBytesBoundedOffset(c.dec, blob)
+		return
+	}
+	DefineDynamicBytesOffset(c, blob, maxSize)
+}
+
+// DefineDynamicBytesBoundedContent defines the next field as a dynamic binary blob,
+// which must be at least minSize bytes long.
+func DefineDynamicBytesBoundedContent(c *Codec, blob *[]byte, minSize uint64, maxSize uint64) {
+	if c.enc != nil {
+		EncodeDynamicBytesBoundedContent(c.enc, *blob)
+		return
+	}
+	if c.dec != nil {
+		DecodeDynamicBytesBoundedContent(c.dec, blob, minSize, maxSize)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineDynamicBytesExactOffset defines the next field as a dynamic binary blob,
+// which must be exactly size bytes long. The maxSize is the limit of the blob in
+// the wire format, used for hashing.
+func DefineDynamicBytesExactOffset(c *Codec, blob *[]byte, size uint64, maxSize uint64) {
+	if c.enc != nil {
+		EncodeDynamicBytesExactOffset(c.enc, *blob, size)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeDynamicBytesExactOffset(c.dec, blob)
+		return
+	}
+	DefineDynamicBytesOffset(c, blob, maxSize)
+}
+
+// DefineDynamicBytesExactContent defines the next field as a dynamic binary blob,
+// which must be exactly size bytes long.
+func DefineDynamicBytesExactContent(c *Codec, blob *[]byte, size uint64, maxSize uint64) {
+	if c.enc != nil {
+		EncodeDynamicBytesExactContent(c.enc, *blob)
+		return
+	}
+	if c.dec != nil {
+		DecodeDynamicBytesExactContent(c.dec, blob, size)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// EncodeDynamicBytesBoundedOffset serializes a dynamic binary blob, which must be
+// between minSize and maxSize bytes long.
+//
+// Note, blobs out of bounds will be serialized, but halt encoding with an error.
+func EncodeDynamicBytesBoundedOffset(enc *Encoder, blob []byte, minSize uint64, maxSize uint64) {
+	if enc.err == nil {
+		enc.err = checkBoundedBytes(blob, minSize, maxSize)
+	}
+	EncodeDynamicBytesOffset(enc, blob)
+}
+
+// EncodeDynamicBytesBoundedContent is the lazy data writer for EncodeDynamicBytesBoundedOffset.
+func EncodeDynamicBytesBoundedContent(enc *Encoder, blob []byte) {
+	EncodeDynamicBytesContent(enc, blob)
+}
+
+// EncodeDynamicBytesExactOffset serializes a dynamic binary blob, which must be
+// exactly size bytes long.
+//
+// Note, blobs of a different size will be serialized, but halt encoding with an
+// error.
+func EncodeDynamicBytesExactOffset(enc *Encoder, blob []byte, size uint64) {
+	EncodeDynamicBytesBoundedOffset(enc, blob, size, size)
+}
+
+// EncodeDynamicBytesExactContent is the lazy data writer for EncodeDynamicBytesExactOffset.
+func EncodeDynamicBytesExactContent(enc *Encoder, blob []byte) {
+	EncodeDynamicBytesContent(enc, blob)
+}
+
+// DecodeDynamicBytesBoundedOffset parses a dynamic binary blob, which must be at
+// least minSize bytes long.
+func DecodeDynamicBytesBoundedOffset(dec *Decoder, blob *[]byte) {
+	DecodeDynamicBytesOffset(dec, blob)
+}
+
+// DecodeDynamicBytesBoundedContent is the lazy data reader of DecodeDynamicBytesBoundedOffset,
+// rejecting the blob if it is shorter than minSize.
+func DecodeDynamicBytesBoundedContent(dec *Decoder, blob *[]byte, minSize uint64, maxSize uint64) {
+	if dec.err != nil {
+		return
+	}
+	if size := dec.peekSize(); dec.err == nil && uint64(size) < minSize {
+		dec.traceDynamic()
+		dec.err = fmt.Errorf("%w: decoded %d bytes, min %d", ErrDynamicBytesSizeMismatch, size, minSize)
+		return
+	}
+	DecodeDynamicBytesContent(dec, blob, maxSize)
+}
+
+// DecodeDynamicBytesExactOffset parses a dynamic binary blob, which must be exactly
+// size bytes long.
+func DecodeDynamicBytesExactOffset(dec *Decoder, blob *[]byte) {
+	DecodeDynamicBytesOffset(dec, blob)
+}
+
+// DecodeDynamicBytesExactContent is the lazy data reader of DecodeDynamicBytesExactOffset,
+// rejecting the blob if it is not exactly size bytes long.
+func DecodeDynamicBytesExactContent(dec *Decoder, blob *[]byte, size uint64) {
+	if dec.err != nil {
+		return
+	}
+	if have := dec.peekSize(); dec.err == nil && uint64(have) != size {
+		dec.traceDynamic()
+		dec.err = fmt.Errorf("%w: decoded %d bytes, want %d", ErrDynamicBytesSizeMismatch, have, size)
+		return
+	}
+	DecodeDynamicBytesContent(dec, blob, size)
+}
+
+// HashDynamicBytesBounded hashes a dynamic binary blob, which must be at least
+// minSize bytes long.
+func HashDynamicBytesBounded(h *Hasher, blob []byte, maxSize uint64) {
+	HashDynamicBytes(h, blob, maxSize)
+}
+
+// HashDynamicBytesExact hashes a dynamic binary blob, which must be exactly size
+// bytes long.
+func HashDynamicBytesExact(h *Hasher, blob []byte, maxSize uint64) {
+	HashDynamicBytes(h, blob, maxSize)
+}
+
+// SizeDynamicBytesBounded returns the serialized size of the dynamic part of a
+// dynamic binary blob, which must be at least minSize bytes long.
+func SizeDynamicBytesBounded(blob []byte) uint32 {
+	return SizeDynamicBytes(blob)
+}
+
+// SizeDynamicBytesExact returns the serialized size of the dynamic part of a
+// dynamic binary blob, which must be exactly size bytes long.
+func SizeDynamicBytesExact(blob []byte) uint32 {
+	return SizeDynamicBytes(blob)
+}
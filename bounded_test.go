@@ -0,0 +1,109 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// testBoundedType is a container with dynamic binary fields constrained by the
+// application beyond their wire format limits.
+type testBoundedType struct {
+	Graffiti []byte
+	Memo     []byte
+}
+
+func (t *testBoundedType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8
+	}
+	return 8 + ssz.SizeDynamicBytesExact(t.Graffiti) + ssz.SizeDynamicBytesBounded(t.Memo)
+}
+
+func (t *testBoundedType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicBytesExactOffset(codec, &t.Graffiti, 8, 16)
+	ssz.DefineDynamicBytesBoundedOffset(codec, &t.Memo, 2, 4)
+
+	ssz.DefineDynamicBytesExactContent(codec, &t.Graffiti, 8, 16)
+	ssz.DefineDynamicBytesBoundedContent(codec, &t.Memo, 2, 4)
+}
+
+// testUnboundedType has the same wire format as testBoundedType, but without
+// the application constraints.
+type testUnboundedType struct {
+	Graffiti []byte
+	Memo     []byte
+}
+
+func (t *testUnboundedType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8
+	}
+	return 8 + ssz.SizeDynamicBytes(t.Graffiti) + ssz.SizeDynamicBytes(t.Memo)
+}
+
+func (t *testUnboundedType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicBytesOffset(codec, &t.Graffiti, 16)
+	ssz.DefineDynamicBytesOffset(codec, &t.Memo, 4)
+
+	ssz.DefineDynamicBytesContent(codec, &t.Graffiti, 16)
+	ssz.DefineDynamicBytesContent(codec, &t.Memo, 4)
+}
+
+// Tests that exact and bounded dynamic binary fields are validated both when
+// encoding and decoding, while hashing as plain dynamic binary blobs.
+func TestDynamicBytesBounds(t *testing.T) {
+	tests := []struct {
+		graffiti []byte
+		memo     []byte
+		err      error
+	}{
+		{make([]byte, 8), make([]byte, 2), nil},
+		{make([]byte, 8), make([]byte, 4), nil},
+		{make([]byte, 7), make([]byte, 2), ssz.ErrDynamicBytesSizeMismatch},
+		{make([]byte, 9), make([]byte, 2), ssz.ErrDynamicBytesSizeMismatch},
+		{make([]byte, 8), make([]byte, 1), ssz.ErrDynamicBytesSizeMismatch},
+		{make([]byte, 8), nil, ssz.ErrDynamicBytesSizeMismatch},
+	}
+	for i, tt := range tests {
+		bounded := &testBoundedType{Graffiti: tt.graffiti, Memo: tt.memo}
+		if err := ssz.EncodeToBytes(make([]byte, ssz.Size(bounded)), bounded); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: encode error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if err := ssz.EncodeToStream(io.Discard, bounded); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: stream encode error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		// Craft the same encoding without the constraints and ensure decoding
+		// enforces them
+		unbounded := &testUnboundedType{Graffiti: tt.graffiti, Memo: tt.memo}
+
+		blob := make([]byte, ssz.Size(unbounded))
+		if err := ssz.EncodeToBytes(blob, unbounded); err != nil {
+			t.Fatalf("test %d: failed to encode unbounded object: %v", i, err)
+		}
+		for _, stream := range []bool{false, true} {
+			var (
+				decoded = new(testBoundedType)
+				err     error
+			)
+			if stream {
+				err = ssz.DecodeFromStream(bytes.NewReader(blob), decoded, uint32(len(blob)))
+			} else {
+				err = ssz.DecodeFromBytes(blob, decoded)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("test %d, stream %v: decode error mismatch: have %v, want %v", i, stream, err, tt.err)
+			}
+			if tt.err == nil && ssz.HashSequential(decoded) != ssz.HashSequential(unbounded) {
+				t.Errorf("test %d, stream %v: hash mismatch", i, stream)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/holiman/uint256"
+)
+
+// CheckUint256Max creates a validator for DefineUint256Checked, rejecting values
+// above the given limit (inclusive).
+func CheckUint256Max(limit *uint256.Int) func(n *uint256.Int) error {
+	return func(n *uint256.Int) error {
+		if n.Gt(limit) {
+			return fmt.Errorf("value %s above maximum %s", n.Dec(), limit.Dec())
+		}
+		return nil
+	}
+}
+
+// CheckUint256NonZero is a validator for DefineUint256Checked, rejecting zero.
+func CheckUint256NonZero(n *uint256.Int) error {
+	if n.IsZero() {
+		return errors.New("value is zero")
+	}
+	return nil
+}
+
+// CheckUint256All combines multiple validators for DefineUint256Checked, running
+// them in order and returning the first failure.
+func CheckUint256All(validators ...func(n *uint256.Int) error) func(n *uint256.Int) error {
+	return func(n *uint256.Int) error {
+		for _, validate := range validators {
+			if err := validate(n); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package checksum implements an optional framing around the SSZ stream encoder
+// and decoder, appending a checksum of the payload as a footer and validating it
+// when decoding.
+//
+// It is meant for storage scenarios (e.g. archived states or blocks), where the
+// silent corruption of a blob must be caught without recomputing its merkle root.
+// CRC32C is built in; other algorithms can be plugged in by implementing the
+// Checksum interface, e.g. xxhash:
+//
+//	type xxhashChecksum struct{}
+//
+//	func (xxhashChecksum) New() hash.Hash {
+//		return xxhash.New()
+//	}
+package checksum
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"github.com/karalabe/ssz"
+)
+
+var (
+	// ErrChecksumMismatch is returned if the checksum footer does not match the
+	// payload it is framing.
+	ErrChecksumMismatch = errors.New("checksum: payload checksum mismatch")
+
+	// ErrPayloadTooLarge is returned if the framed payload exceeds the size
+	// permitted for the object being decoded.
+	ErrPayloadTooLarge = errors.New("checksum: payload size exceeds limit")
+
+	// ErrMissingFooter is returned if the framed data is too short to contain
+	// the checksum footer.
+	ErrMissingFooter = errors.New("checksum: missing footer")
+)
+
+// Checksum is a checksum algorithm that can be used to frame SSZ payloads.
+type Checksum interface {
+	// New creates a hasher computing the checksum. The footer is the output of
+	// its Sum method, so its Size must be constant.
+	New() hash.Hash
+}
+
+// CRC32C is the CRC-32 checksum with the Castagnoli polynomial, which is hardware
+// accelerated on most platforms. The footer is 4 bytes, big endian.
+var CRC32C Checksum = crc32c{}
+
+// Encode serializes the object into the writer, followed by the checksum of the
+// serialized payload.
+func Encode(w io.Writer, obj ssz.Object, c Checksum) error {
+	h := c.New()
+	if err := ssz.EncodeToStream(io.MultiWriter(w, h), obj); err != nil {
+		return err
+	}
+	_, err := w.Write(h.Sum(nil))
+	return err
+}
+
+// Decode reads a checksum framed payload from the reader, validates the footer
+// and parses the payload into the object. Nothing is decoded if the checksum is
+// invalid.
+//
+// Static objects are required to have a payload of exactly their size, dynamic
+// ones of at most maxSize bytes. Reading is aborted as soon as the limit (plus
+// the footer) is exceeded.
+func Decode(r io.Reader, obj ssz.Object, c Checksum, maxSize uint32) error {
+	limit := maxSize
+	if static, ok := obj.(ssz.StaticObject); ok {
+		limit = static.SizeSSZ()
+	}
+	h := c.New()
+	footer := h.Size()
+
+	blob, err := io.ReadAll(io.LimitReader(r, int64(limit)+int64(footer)+1))
+	if err != nil {
+		return err
+	}
+	if len(blob) < footer {
+		return fmt.Errorf("%w: have %d bytes, want at least %d", ErrMissingFooter, len(blob), footer)
+	}
+	payload, sum := blob[:len(blob)-footer], blob[len(blob)-footer:]
+	if uint64(len(payload)) > uint64(limit) {
+		return fmt.Errorf("%w: more than %d bytes", ErrPayloadTooLarge, limit)
+	}
+	h.Write(payload)
+	if want := h.Sum(nil); !bytes.Equal(sum, want) {
+		return fmt.Errorf("%w: have %x, want %x", ErrChecksumMismatch, sum, want)
+	}
+	return ssz.DecodeFromBytes(payload, obj)
+}
+
+// crc32c is the built in CRC32C checksum.
+type crc32c struct{}
+
+// castagnoli is the lookup table of the CRC32C checksum.
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// New implements Checksum.
+func (crc32c) New() hash.Hash {
+	return crc32.New(castagnoli)
+}
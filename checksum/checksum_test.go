@@ -0,0 +1,95 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package checksum_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/checksum"
+	"github.com/karalabe/ssz/sszcommon"
+)
+
+// sha256Checksum is a third party algorithm plugged into the checksum API.
+type sha256Checksum struct{}
+
+func (sha256Checksum) New() hash.Hash {
+	return sha256.New()
+}
+
+// Tests that objects round trip through the built in and plugged checksums and
+// that corruptions and size limits are detected.
+func TestChecksums(t *testing.T) {
+	checksums := map[string]checksum.Checksum{
+		"crc32c": checksum.CRC32C,
+		"sha256": sha256Checksum{},
+	}
+	for name, c := range checksums {
+		// Round trip a dynamic object and check the limits on it
+		requests := &sszcommon.ExecutionRequests{
+			Withdrawals: []*sszcommon.WithdrawalRequest{{Amount: 1}, {Amount: 2}},
+		}
+		buf := new(bytes.Buffer)
+		if err := checksum.Encode(buf, requests, c); err != nil {
+			t.Fatalf("%s: failed to encode dynamic object: %v", name, err)
+		}
+		framed := buf.Bytes()
+
+		dec := new(sszcommon.ExecutionRequests)
+		if err := checksum.Decode(bytes.NewReader(framed), dec, c, ssz.Size(requests)); err != nil {
+			t.Fatalf("%s: failed to decode dynamic object: %v", name, err)
+		}
+		if have, want := ssz.HashSequential(dec), ssz.HashSequential(requests); have != want {
+			t.Errorf("%s: dynamic object mismatch: have %#x, want %#x", name, have, want)
+		}
+		err := checksum.Decode(bytes.NewReader(framed), dec, c, ssz.Size(requests)-1)
+		if !errors.Is(err, checksum.ErrPayloadTooLarge) {
+			t.Errorf("%s: dynamic limit error mismatch: have %v, want %v", name, err, checksum.ErrPayloadTooLarge)
+		}
+		// Ensure any single bit flip in the payload or footer is detected
+		for i := 0; i < len(framed)*8; i++ {
+			corrupt := bytes.Clone(framed)
+			corrupt[i/8] ^= 1 << (i % 8)
+
+			err := checksum.Decode(bytes.NewReader(corrupt), new(sszcommon.ExecutionRequests), c, ssz.Size(requests))
+			if !errors.Is(err, checksum.ErrChecksumMismatch) {
+				t.Fatalf("%s: bit %d flip error mismatch: have %v, want %v", name, i, err, checksum.ErrChecksumMismatch)
+			}
+		}
+		// Ensure truncated frames are rejected
+		err = checksum.Decode(bytes.NewReader(framed[:2]), new(sszcommon.ExecutionRequests), c, ssz.Size(requests))
+		if !errors.Is(err, checksum.ErrMissingFooter) {
+			t.Errorf("%s: truncation error mismatch: have %v, want %v", name, err, checksum.ErrMissingFooter)
+		}
+		// Ensure static objects are limited to their own size
+		err = checksum.Decode(bytes.NewReader(make([]byte, 1024*1024)), new(sszcommon.Checkpoint), c, 1024*1024)
+		if !errors.Is(err, checksum.ErrPayloadTooLarge) {
+			t.Errorf("%s: static limit error mismatch: have %v, want %v", name, err, checksum.ErrPayloadTooLarge)
+		}
+	}
+}
+
+// Tests that the CRC32C footer is the big endian Castagnoli checksum of the
+// plain SSZ encoding.
+func TestCRC32CFormat(t *testing.T) {
+	obj := &sszcommon.Checkpoint{Epoch: 1, Root: sszcommon.Hash{0x02}}
+	blob := make([]byte, ssz.Size(obj))
+	ssz.EncodeToBytes(blob, obj)
+
+	buf := new(bytes.Buffer)
+	if err := checksum.Encode(buf, obj, checksum.CRC32C); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	want := binary.BigEndian.AppendUint32(bytes.Clone(blob), crc32.Checksum(blob, crc32.MakeTable(crc32.Castagnoli)))
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("encoding mismatch: have %x, want %x", buf.Bytes(), want)
+	}
+}
@@ -0,0 +1,150 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// descriptorKinds are the field kinds the runtime descriptor walker supports,
+// named after the Define methods of the opsets (sans prefix/suffix).
+var descriptorKinds = map[string]bool{
+	"Bool":                      true,
+	"Uint8":                     true,
+	"Uint16":                    true,
+	"Uint32":                    true,
+	"Uint64":                    true,
+	"Uint256":                   true,
+	"Uint256BigInt":             true,
+	"StaticBytes":               true,
+	"CheckedStaticBytes":        true,
+	"DynamicBytes":              true,
+	"ArrayOfBits":               true,
+	"SliceOfBits":               true,
+	"ArrayOfUint64s":            true,
+	"SliceOfUint64s":            true,
+	"ArrayOfStaticBytes":        true,
+	"CheckedArrayOfStaticBytes": true,
+	"SliceOfStaticBytes":        true,
+	"SliceOfDynamicBytes":       true,
+	"StaticObject":              true,
+	"DynamicObject":             true,
+	"SliceOfStaticObjects":      true,
+	"SliceOfDynamicObjects":     true,
+}
+
+// descriptorBytesLengths are the byte array lengths the runtime can handle in
+// slices of static binary blobs (mirrors the library's commonBytesLengths).
+var descriptorBytesLengths = map[int64]bool{
+	4: true, 20: true, 31: true, 32: true, 48: true, 64: true, 96: true, 256: true, 131072: true,
+}
+
+// describeContainer assembles the field descriptors of a container, or returns
+// nil if any of the fields cannot be interpreted by the runtime walker.
+func describeContainer(typ *sszContainer) []string {
+	fields := make([]string, 0, len(typ.fields))
+	for i := range typ.fields {
+		field, ok := describeField(typ.fields[i], typ.types[i], typ.opsets[i])
+		if !ok {
+			return nil
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// describeField creates the descriptor literal of a single field. The kind and
+// the limits are extracted from the opset's Define call, so the descriptor and
+// the generated methods cannot diverge.
+func describeField(name string, typ types.Type, op opset) (string, bool) {
+	// The runtime only walks top level fields, not ones flattened from embeds
+	if strings.Contains(name, ".") {
+		return "", false
+	}
+	var (
+		call    string
+		dynamic bool
+	)
+	switch op := op.(type) {
+	case *opsetStatic:
+		call = generateCall(op.define, "codec", "obj."+name, op.bytes...)
+	case *opsetDynamic:
+		call = generateCall(op.defineOffset, "codec", "obj."+name, op.limits...)
+		dynamic = true
+	}
+	method, args, _ := strings.Cut(strings.TrimSuffix(call, ")"), "(")
+	kind := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(method, "Define"), "Unsafe"), "Offset")
+	if !descriptorKinds[kind] {
+		return "", false
+	}
+	// The runtime can only view lists of a few types without unsafe conversions,
+	// reject anything it would not accept
+	switch kind {
+	case "ArrayOfUint64s", "SliceOfUint64s":
+		if !types.Identical(listElem(typ), types.Typ[types.Uint64]) {
+			return "", false
+		}
+	case "CheckedArrayOfStaticBytes", "SliceOfStaticBytes":
+		blob, ok := listElem(typ).(*types.Array)
+		if !ok || !types.Identical(blob.Elem(), types.Typ[types.Byte]) || !descriptorBytesLengths[blob.Len()] {
+			return "", false
+		}
+	}
+	// Assemble the descriptor with the sizes and limits of the field
+	field := fmt.Sprintf("ssz.FieldDescriptor{Name: %q, Kind: ssz.Field%s", name, kind)
+
+	params := strings.Split(args, ", ")[2:] // codec and field
+	switch {
+	case len(params) == 1 && !dynamic:
+		field += ", Size: " + params[0]
+	case len(params) == 1 && kind == "DynamicBytes":
+		field += ", MaxSize: " + params[0]
+	case len(params) == 1:
+		field += ", MaxItems: " + params[0]
+	case len(params) == 2:
+		field += ", MaxItems: " + params[0] + ", MaxSize: " + params[1]
+	}
+	return field + "}", true
+}
+
+// listElem returns the item type of an array or slice type.
+func listElem(typ types.Type) types.Type {
+	switch typ := typ.Underlying().(type) {
+	case *types.Array:
+		return typ.Elem()
+	case *types.Slice:
+		return typ.Elem()
+	default:
+		return nil
+	}
+}
+
+// generateDescriptorSSZ is the descriptor mode alternative of generateDefineSSZ,
+// emitting a field table and a DefineSSZ method interpreting it.
+func generateDescriptorSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var b bytes.Buffer
+
+	// Add a needed import of the ssz encoder
+	ctx.addImport(sszPkgPath, "")
+
+	// Generate the code itself
+	name := typ.named.Obj().Name()
+
+	fmt.Fprintf(&b, "// descriptor%s is the ssz schema of %s, interpreted at runtime.\n", name, name)
+	fmt.Fprintf(&b, "var descriptor%s = ssz.NewDescriptor((*%s)(nil),\n", name, name)
+	for _, field := range typ.descriptor {
+		fmt.Fprintf(&b, "	%s,\n", field)
+	}
+	fmt.Fprint(&b, ")\n\n")
+
+	fmt.Fprint(&b, "// DefineSSZ defines how an object is encoded/decoded.\n")
+	fmt.Fprintf(&b, "func (obj *%s) DefineSSZ(codec *ssz.Codec) {\n", name)
+	fmt.Fprintf(&b, "	descriptor%s.Define(codec, obj)\n", name)
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
@@ -0,0 +1,307 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// diffMain runs the diff subcommand, reporting the wire-incompatible changes
+// between two versions of some ssz containers. It exits with an error if any
+// such change is found, so it can be used as a pre-merge gate.
+func diffMain(args []string) {
+	flags := flag.NewFlagSet("sszgen diff", flag.ExitOnError)
+	typename := flags.String("type", "", "types to compare (default is all containers)")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage: sszgen diff [flags] <old> <new>\n\n")
+		fmt.Fprint(flags.Output(), "Compares the ssz layouts of two versions of a Go file or package directory.\n\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	var names []string
+	if len(*typename) > 0 {
+		names = strings.Split(*typename, ",")
+	}
+	changes, err := diffSchemas(flags.Arg(0), flags.Arg(1), names)
+	if err != nil {
+		fatal(err)
+	}
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+}
+
+// diffSchemas loads the containers from the old and new versions of a Go file
+// or package directory and compares their wire layouts.
+//
+// Files are loaded in the context of their package, so the old version must be
+// checked out into its own copy of the module (e.g. a git worktree). If no types
+// are requested, all the structs declared in a file, or all the structs of a
+// package implementing the ssz objects interfaces are compared.
+func diffSchemas(oldPath, newPath string, names []string) ([]string, error) {
+	olds, err := loadContainers(oldPath, names)
+	if err != nil {
+		return nil, err
+	}
+	news, err := loadContainers(newPath, names)
+	if err != nil {
+		return nil, err
+	}
+	return diffContainers(olds, news), nil
+}
+
+// loadContainers parses the requested containers from a Go file or a package
+// directory, defaulting to all the ones declared within.
+func loadContainers(path string, names []string) ([]*sszContainer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	dir := path
+	if !info.IsDir() {
+		dir = filepath.Dir(path)
+	}
+	parser, target, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		if info.IsDir() {
+			names = parser.objectStructs(target)
+		} else if names, err = declaredStructs(path); err != nil {
+			return nil, err
+		}
+	}
+	return parser.parsePackage(target, names)
+}
+
+// objectStructs returns the names of all the structs in a package implementing
+// either of the ssz object interfaces.
+func (p *parseContext) objectStructs(pkg *types.Package) []string {
+	var names []string
+	for _, name := range pkg.Scope().Names() {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.TypeParams() != nil {
+			continue
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			continue
+		}
+		ptr := types.NewPointer(named)
+		if types.Implements(ptr, p.staticObjectIface) || types.Implements(ptr, p.dynamicObjectIface) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// declaredStructs returns the names of all the (non-generic) structs declared
+// in a Go file, in declaration order.
+func declaredStructs(path string) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.TypeSpec)
+			if _, ok := spec.Type.(*ast.StructType); ok && spec.TypeParams == nil && !spec.Assign.IsValid() {
+				names = append(names, spec.Name.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// wireField is a container field reduced to what matters on the wire: its ssz
+// type. The name is only tracked to match up the fields of two versions.
+type wireField struct {
+	name string
+	typ  string
+}
+
+// diffContainers compares the wire layouts of the old and new versions of some
+// containers, returning the incompatible changes found. Containers only present
+// in the new version are new types, not breaking changes.
+func diffContainers(olds, news []*sszContainer) []string {
+	index := make(map[string]*sszContainer)
+	for _, typ := range news {
+		index[typ.named.Obj().Name()] = typ
+	}
+	var changes []string
+	for _, old := range olds {
+		name := old.named.Obj().Name()
+
+		typ, ok := index[name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s: container removed", name))
+			continue
+		}
+		changes = append(changes, diffFields(name, wireFields(old), wireFields(typ))...)
+	}
+	return changes
+}
+
+// diffFields compares the wire layouts of two versions of a container. Fields
+// are matched by name, except fields renamed in place with the same ssz type,
+// which are wire compatible.
+func diffFields(container string, olds, news []wireField) []string {
+	oldIndex := make(map[string]int)
+	for i, field := range olds {
+		oldIndex[field.name] = i
+	}
+	newIndex := make(map[string]int)
+	for i, field := range news {
+		newIndex[field.name] = i
+	}
+	// Track the fields renamed in place under their old names
+	news = append([]wireField{}, news...)
+	for i := 0; i < min(len(olds), len(news)); i++ {
+		if olds[i].name == news[i].name || olds[i].typ != news[i].typ {
+			continue
+		}
+		_, oldKept := newIndex[olds[i].name]
+		_, newKnown := oldIndex[news[i].name]
+		if !oldKept && !newKnown {
+			delete(newIndex, news[i].name)
+			news[i].name = olds[i].name
+			newIndex[news[i].name] = i
+		}
+	}
+	// Report removed and changed fields, collecting the common ones
+	var changes, common []string
+	for _, field := range olds {
+		i, ok := newIndex[field.name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s.%s: field removed", container, field.name))
+			continue
+		}
+		if field.typ != news[i].typ {
+			changes = append(changes, fmt.Sprintf("%s.%s: type changed from %s to %s", container, field.name, field.typ, news[i].typ))
+		}
+		common = append(common, field.name)
+	}
+	// Report the common fields whose order changed relative to each other
+	var reordered []string
+	for _, field := range news {
+		if _, ok := oldIndex[field.name]; ok {
+			reordered = append(reordered, field.name)
+		}
+	}
+	for i, name := range common {
+		if reordered[i] != name {
+			changes = append(changes, fmt.Sprintf("%s.%s: field moved from index %d to %d", container, name, oldIndex[name], newIndex[name]))
+		}
+	}
+	// Report the new fields
+	for _, field := range news {
+		if _, ok := oldIndex[field.name]; !ok {
+			changes = append(changes, fmt.Sprintf("%s.%s: field added", container, field.name))
+		}
+	}
+	return changes
+}
+
+// wireFields reduces the fields of a container to their ssz types.
+func wireFields(typ *sszContainer) []wireField {
+	fields := make([]wireField, len(typ.fields))
+	for i := range typ.fields {
+		fields[i] = wireField{name: typ.fields[i], typ: wireType(typ.types[i], typ.opsets[i])}
+	}
+	return fields
+}
+
+// wireType describes the ssz type of a field in the notation of the spec (e.g.
+// List[uint64, 16]). Go types with the same ssz encoding (e.g. byte arrays and
+// checked byte slices) are described identically. Nested containers are named
+// after their Go types, their layouts are compared separately.
+func wireType(typ types.Type, op opset) string {
+	var (
+		call   string
+		sizes  []int
+		limits []int
+	)
+	switch op := op.(type) {
+	case *opsetStatic:
+		call, sizes = generateCall(op.define, "codec", "field", op.bytes...), op.bytes
+	case *opsetDynamic:
+		call, limits = generateCall(op.defineOffset, "codec", "field", op.limits...), op.limits
+	}
+	method, args, _ := strings.Cut(strings.TrimSuffix(call, ")"), "(")
+	params := strings.Split(args, ", ")[2:] // codec and field
+
+	switch kind := strings.TrimSuffix(strings.TrimPrefix(method, "Define"), "Offset"); kind {
+	case "Bool":
+		return "boolean"
+	case "Uint8", "EnumUint8":
+		return "uint8"
+	case "Uint16":
+		return "uint16"
+	case "Uint32":
+		return "uint32"
+	case "Uint64", "EnumUint64":
+		return "uint64"
+	case "Uint256", "Uint256BigInt":
+		return "uint256"
+	case "StaticBytes", "CheckedStaticBytes":
+		return fmt.Sprintf("Vector[byte, %d]", sizes[0])
+	case "DynamicBytes", "DynamicString":
+		return fmt.Sprintf("List[byte, %d]", limits[0])
+	case "ArrayOfBits":
+		return fmt.Sprintf("Bitvector[%s]", params[0])
+	case "SliceOfBits":
+		return fmt.Sprintf("Bitlist[%s]", params[0])
+	case "ArrayOfUint64s", "CheckedStaticUint64":
+		return fmt.Sprintf("Vector[uint64, %d]", sizes[0])
+	case "SliceOfUint64s":
+		return fmt.Sprintf("List[uint64, %d]", limits[0])
+	case "UnsafeArrayOfStaticBytes", "CheckedArrayOfStaticBytes":
+		return fmt.Sprintf("Vector[Vector[byte, %d], %d]", sizes[1], sizes[0])
+	case "SliceOfStaticBytes":
+		blob := listElem(typ).Underlying().(*types.Array)
+		return fmt.Sprintf("List[Vector[byte, %d], %d]", blob.Len(), limits[0])
+	case "CheckedSliceOfStaticBytes":
+		return fmt.Sprintf("List[Vector[byte, %d], %d]", limits[1], limits[0])
+	case "SliceOfDynamicBytes":
+		return fmt.Sprintf("List[List[byte, %d], %d]", limits[1], limits[0])
+	case "StaticObject", "DynamicObject":
+		return containerName(typ)
+	case "SliceOfStaticObjects", "SliceOfDynamicObjects":
+		return fmt.Sprintf("List[%s, %d]", containerName(listElem(typ)), limits[0])
+	default:
+		return kind
+	}
+}
+
+// containerName returns the name of a nested container type, referenced via a
+// pointer.
+func containerName(typ types.Type) string {
+	ptr := types.Unalias(typ).(*types.Pointer)
+	return types.Unalias(ptr.Elem()).(*types.Named).Obj().Name()
+}
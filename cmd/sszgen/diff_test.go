@@ -0,0 +1,60 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Tests that wire-incompatible changes between two versions of some containers
+// are reported, whereas compatible ones (renames, equivalent Go types) are not.
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		old   string
+		new   string
+		types []string
+		want  []string
+	}{
+		// Files compare all the structs declared within
+		{
+			old: "testdata/diff/old/types.go",
+			new: "testdata/diff/new/types.go",
+			want: []string{
+				"Header.Extra: type changed from List[byte, 32] to List[byte, 64]",
+				"Header.Removed: field removed",
+				"Header.Slot: field moved from index 0 to 1",
+				"Header.Proposer: field moved from index 1 to 0",
+				"Header.Added: field added",
+				"Removed: container removed",
+			},
+		},
+		// Packages compare the ssz objects by default, or the requested types
+		{old: "testdata/diff/old", new: "testdata/diff/new"},
+		{
+			old:   "testdata/diff/old",
+			new:   "testdata/diff/new",
+			types: []string{"Renamed", "Header"},
+			want: []string{
+				"Header.Extra: type changed from List[byte, 32] to List[byte, 64]",
+				"Header.Removed: field removed",
+				"Header.Slot: field moved from index 0 to 1",
+				"Header.Proposer: field moved from index 1 to 0",
+				"Header.Added: field added",
+			},
+		},
+		// Identical versions are always compatible
+		{old: "testdata/diff/new/types.go", new: "testdata/diff/new/types.go"},
+	}
+	for i, tt := range tests {
+		have, err := diffSchemas(tt.old, tt.new, tt.types)
+		if err != nil {
+			t.Fatalf("test %d: failed to diff schemas: %v", i, err)
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("test %d: changes mismatch:\nhave %q\nwant %q", i, have, tt.want)
+		}
+	}
+}
@@ -6,11 +6,13 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"go/types"
-	"html/template"
 	"math"
 	"sort"
+	"strings"
+	"text/template"
 )
 
 const (
@@ -19,14 +21,22 @@ const (
 )
 
 type genContext struct {
-	pkg     *types.Package
-	imports map[string]string
+	pkg         *types.Package
+	imports     map[string]string // Import paths mapped to their aliases (empty if none)
+	names       map[string]string // Package names in use mapped to their import paths
+	descriptors bool              // Whether to generate descriptor tables where possible
+	flat        bool              // Whether to generate flat list decoders where possible
+	families    map[string]bool   // Method families to generate (ssz, hash, json)
 }
 
-func newGenContext(pkg *types.Package) *genContext {
+func newGenContext(pkg *types.Package, descriptors bool, flat bool) *genContext {
 	return &genContext{
-		pkg:     pkg,
-		imports: make(map[string]string),
+		pkg:         pkg,
+		imports:     make(map[string]string),
+		names:       map[string]string{"ssz": sszPkgPath}, // referenced verbatim
+		descriptors: descriptors,
+		flat:        flat,
+		families:    map[string]bool{familySSZ: true},
 	}
 }
 
@@ -41,6 +51,36 @@ func (ctx *genContext) addImport(path string, alias string) error {
 	return nil
 }
 
+// qualifier is a types.Qualifier that imports the referenced package and returns
+// the name it can be accessed by. Packages with colliding names (with each other
+// or with identifiers of the target package) are aliased by a numeric suffix.
+func (ctx *genContext) qualifier(pkg *types.Package) string {
+	if pkg.Path() == ctx.pkg.Path() {
+		return ""
+	}
+	if alias, ok := ctx.imports[pkg.Path()]; ok {
+		if alias == "" {
+			return pkg.Name()
+		}
+		return alias
+	}
+	name := pkg.Name()
+	for i := 2; ; i++ {
+		path, taken := ctx.names[name]
+		if path == pkg.Path() || (!taken && ctx.pkg.Scope().Lookup(name) == nil) {
+			break
+		}
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+	ctx.names[name] = pkg.Path()
+	if name == pkg.Name() {
+		ctx.addImport(pkg.Path(), "")
+	} else {
+		ctx.addImport(pkg.Path(), name)
+	}
+	return name
+}
+
 func (ctx *genContext) header() []byte {
 	var paths sort.StringSlice
 	for path := range ctx.imports {
@@ -62,8 +102,16 @@ func (ctx *genContext) header() []byte {
 		}
 		return b.Bytes()
 	}
+	// Standard library packages (no dot in the first path element) go first,
+	// separated from the rest by an empty line
+	sort.SliceStable(paths, func(i, j int) bool {
+		return isStdlib(paths[i]) && !isStdlib(paths[j])
+	})
 	fmt.Fprintf(&b, "import (\n")
-	for _, path := range paths {
+	for i, path := range paths {
+		if i > 0 && isStdlib(paths[i-1]) && !isStdlib(path) {
+			fmt.Fprintf(&b, "\n")
+		}
 		alias := ctx.imports[path]
 		if alias == "" {
 			fmt.Fprintf(&b, "\"%s\"\n", path)
@@ -71,27 +119,71 @@ func (ctx *genContext) header() []byte {
 			fmt.Fprintf(&b, "%s \"%s\"\n", alias, path)
 		}
 	}
-	//fmt.Fprintf(&b, ")\n")
+	fmt.Fprintf(&b, ")\n")
 	return b.Bytes()
 }
 
+// schemaHash computes a digest of the ssz schemas of the containers (field names,
+// types, the resolved opsets, which include all size tags, and the fork tags). It
+// is embedded in the generated code to make schema changes stand out in diffs.
+func schemaHash(containers []*sszContainer) string {
+	hasher := sha256.New()
+	for _, typ := range containers {
+		fmt.Fprintf(hasher, "%s\n", typ.named.Obj().Name())
+		for i, field := range typ.fields {
+			fmt.Fprintf(hasher, "\t%s %s %+v\n", field, types.TypeString(typ.types[i], nil), typ.opsets[i])
+			if fork := typ.forks[i]; fork != nil {
+				fmt.Fprintf(hasher, "\t\tfork %+v\n", *fork)
+			}
+		}
+	}
+	return fmt.Sprintf("%x", hasher.Sum(nil))
+}
+
+// isStdlib reports whether an import path belongs to the standard library, i.e.
+// its first path element has no dot in it.
+func isStdlib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
 func generate(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var generators []func(ctx *genContext, typ *sszContainer) ([]byte, error)
+	if ctx.families[familySSZ] {
+		generators = append(generators, generateSizeSSZ, generateDefineSSZ)
+		if ctx.descriptors && !typ.forked {
+			if typ.descriptor = describeContainer(typ); typ.descriptor != nil {
+				generators[1] = generateDescriptorSSZ
+			}
+		}
+		if ctx.flat && typ.static {
+			generators = append(generators, generateDecodeFlatSSZ)
+		}
+	}
+	if ctx.families[familyHash] {
+		generators = append(generators, generateHashSSZ)
+	}
+	if ctx.families[familyJSON] {
+		generators = append(generators, generateJSON)
+	}
 	var codes [][]byte
-	for _, fn := range []func(ctx *genContext, typ *sszContainer) ([]byte, error){
-		generateSizeSSZ,
-		generateDefineSSZ,
-	} {
+	for _, fn := range generators {
 		code, err := fn(ctx, typ)
 		if err != nil {
 			return nil, err
 		}
-		codes = append(codes, code)
+		if code != nil {
+			codes = append(codes, code)
+		}
 	}
 	//fmt.Println(string(bytes.Join(codes, []byte("\n"))))
 	return bytes.Join(codes, []byte("\n")), nil
 }
 
 func generateSizeSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	if typ.forked {
+		return generateSizeSSZOnFork(ctx, typ)
+	}
 	var b bytes.Buffer
 
 	// Generate the code itself
@@ -118,14 +210,7 @@ func generateSizeSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
 						fmt.Fprintf(&b, "%d*%d", bytes[0], bytes[1])
 					}
 				} else {
-					typ := typ.types[i].(*types.Pointer).Elem().(*types.Named)
-					pkg := typ.Obj().Pkg()
-					if pkg.Path() == ctx.pkg.Path() {
-						fmt.Fprintf(&b, "(*%s)(nil).SizeSSZ()", typ.Obj().Name())
-					} else {
-						ctx.addImport(pkg.Path(), "")
-						fmt.Fprintf(&b, "(*%s.%s)(nil).SizeSSZ()", pkg.Name(), typ.Obj().Name())
-					}
+					fmt.Fprintf(&b, "(%s)(nil).SizeSSZ()", types.TypeString(typ.types[i], ctx.qualifier))
 				}
 				if i < len(typ.opsets)-1 {
 					fmt.Fprint(&b, " + ")
@@ -179,14 +264,7 @@ func generateSizeSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
 							fmt.Fprintf(&b, "%d*%d", t.bytes[0], t.bytes[1])
 						}
 					} else {
-						typ := typ.types[i].(*types.Pointer).Elem().(*types.Named)
-						pkg := typ.Obj().Pkg()
-						if pkg.Path() == ctx.pkg.Path() {
-							fmt.Fprintf(&b, "(*%s)(nil).SizeSSZ()", typ.Obj().Name())
-						} else {
-							ctx.addImport(pkg.Path(), "")
-							fmt.Fprintf(&b, "(*%s.%s)(nil).SizeSSZ()", pkg.Name(), typ.Obj().Name())
-						}
+						fmt.Fprintf(&b, "(%s)(nil).SizeSSZ()", types.TypeString(typ.types[i], ctx.qualifier))
 					}
 				case *opsetDynamic:
 					fmt.Fprintf(&b, "%d", offsetBytes)
@@ -201,12 +279,7 @@ func generateSizeSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
 			fmt.Fprintf(&b, "	if (fixed) {\n")
 			fmt.Fprintf(&b, "		return size\n")
 			fmt.Fprintf(&b, "	}\n")
-			for i := range typ.opsets {
-				if opset, ok := typ.opsets[i].(*opsetDynamic); ok {
-					call := generateCall(opset.size, "", "obj."+typ.fields[i])
-					fmt.Fprintf(&b, "	size += ssz.%s\n", call)
-				}
-			}
+			generateSizeDynamic(ctx, &b, typ)
 			fmt.Fprintf(&b, "\n")
 			fmt.Fprintf(&b, "	return size\n")
 			fmt.Fprintf(&b, "}\n")
@@ -233,12 +306,7 @@ func generateSizeSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
 			fmt.Fprintf(&b, "	if (fixed) {\n")
 			fmt.Fprintf(&b, "		return size\n")
 			fmt.Fprintf(&b, "	}\n")
-			for i := range typ.opsets {
-				if opset, ok := typ.opsets[i].(*opsetDynamic); ok {
-					call := generateCall(opset.size, "", "obj."+typ.fields[i])
-					fmt.Fprintf(&b, "	size += ssz.%s\n", call)
-				}
-			}
+			generateSizeDynamic(ctx, &b, typ)
 			fmt.Fprintf(&b, "\n")
 			fmt.Fprintf(&b, "	return size\n")
 			fmt.Fprintf(&b, "}\n")
@@ -247,6 +315,82 @@ func generateSizeSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// generateSizeSSZOnFork generates the size methods of a monolithic container.
+// The sizes of the fork-conditional fields are only counted on the forks they
+// are present on, and nested monolithic objects are sized on the same fork.
+func generateSizeSSZOnFork(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var (
+		b     bytes.Buffer
+		name  = typ.named.Obj().Name()
+		terms []string
+	)
+	// Collect the static size of the unconditional fields; the conditional ones
+	// are added one by one, depending on the fork
+	sizeTerm := func(i int) string {
+		switch t := typ.opsets[i].(type) {
+		case *opsetStatic:
+			switch len(t.bytes) {
+			case 0:
+				return fmt.Sprintf("(%s)(nil).SizeSSZ()", types.TypeString(typ.types[i], ctx.qualifier))
+			case 1:
+				return fmt.Sprintf("%d", t.bytes[0])
+			default:
+				return fmt.Sprintf("%d*%d", t.bytes[0], t.bytes[1])
+			}
+		default:
+			return fmt.Sprintf("%d", offsetBytes)
+		}
+	}
+	for i := range typ.opsets {
+		if typ.forks[i] == nil {
+			terms = append(terms, sizeTerm(i))
+		}
+	}
+	fmt.Fprint(&b, "// SizeSSZ returns either the static size of the object if fixed == true, or\n")
+	fmt.Fprint(&b, "// the total size otherwise, using the newest layout of the object.\n")
+	fmt.Fprintf(&b, "func (obj *%s) SizeSSZ(fixed bool) uint32 {\n", name)
+	fmt.Fprint(&b, "	return obj.SizeSSZOnFork(ssz.ForkUnknown, fixed)\n")
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprint(&b, "// SizeSSZOnFork returns either the static size of the object on the given fork\n")
+	fmt.Fprint(&b, "// if fixed == true, or the total size otherwise.\n")
+	fmt.Fprintf(&b, "func (obj *%s) SizeSSZOnFork(fork ssz.Fork, fixed bool) uint32 {\n", name)
+	fmt.Fprintf(&b, "	var size = uint32(%s)\n", strings.Join(terms, " + "))
+	for i := range typ.opsets {
+		if fork := typ.forks[i]; fork != nil {
+			fmt.Fprintf(&b, "	if (%s).Active(fork) {\n", fork.filter())
+			fmt.Fprintf(&b, "		size += %s\n", sizeTerm(i))
+			fmt.Fprint(&b, "	}\n")
+		}
+	}
+	fmt.Fprint(&b, "	if fixed {\n")
+	fmt.Fprint(&b, "		return size\n")
+	fmt.Fprint(&b, "	}\n")
+	for i := range typ.opsets {
+		opset, ok := typ.opsets[i].(*opsetDynamic)
+		if !ok {
+			continue
+		}
+		// Nested dynamic objects might be monolithic too, size them on the fork
+		size := opset.size
+		size = strings.Replace(size, "SizeDynamicObject(", "SizeDynamicObjectOnFork(fork, ", 1)
+		size = strings.Replace(size, "SizeSliceOfDynamicObjects(", "SizeSliceOfDynamicObjectsOnFork(fork, ", 1)
+
+		call := generateCall(size, "", fieldAccess(ctx, typ, i))
+		if fork := typ.forks[i]; fork != nil {
+			fmt.Fprintf(&b, "	if (%s).Active(fork) {\n", fork.filter())
+			fmt.Fprintf(&b, "		size += ssz.%s\n", call)
+			fmt.Fprint(&b, "	}\n")
+		} else {
+			fmt.Fprintf(&b, "	size += ssz.%s\n", call)
+		}
+	}
+	fmt.Fprint(&b, "\n")
+	fmt.Fprint(&b, "	return size\n")
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
 func generateDefineSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
 	var b bytes.Buffer
 
@@ -287,19 +431,21 @@ func generateDefineSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
 		field := typ.fields[i]
 		switch opset := typ.opsets[i].(type) {
 		case *opsetStatic:
-			call := generateCall(opset.define, "codec", "obj."+field, opset.bytes...)
+			call := generateCall(opset.define, "codec", fieldAccess(ctx, typ, i), opset.bytes...)
 			switch len(opset.bytes) {
 			case 0:
-				typ := typ.types[i].(*types.Pointer).Elem().(*types.Named)
-				fmt.Fprintf(&b, "	ssz.%s // Field  ("+indexRule+") - "+nameRule+" - %"+sizeRule+"s bytes (%s)\n", call, i, field, "?", typ.Obj().Name())
+				typ := types.Unalias(types.Unalias(typ.types[i]).(*types.Pointer).Elem()).(*types.Named)
+				call = fmt.Sprintf("ssz.%s // Field  ("+indexRule+") - "+nameRule+" - %"+sizeRule+"s bytes (%s)", call, i, field, "?", typ.Obj().Name())
 			case 1:
-				fmt.Fprintf(&b, "	ssz.%s // Field  ("+indexRule+") - "+nameRule+" - %"+sizeRule+"d bytes\n", call, i, field, opset.bytes[0])
+				call = fmt.Sprintf("ssz.%s // Field  ("+indexRule+") - "+nameRule+" - %"+sizeRule+"d bytes", call, i, field, opset.bytes[0])
 			case 2:
-				fmt.Fprintf(&b, "	ssz.%s // Field  ("+indexRule+") - "+nameRule+" - %"+sizeRule+"d bytes\n", call, i, field, opset.bytes[0]*opset.bytes[1])
+				call = fmt.Sprintf("ssz.%s // Field  ("+indexRule+") - "+nameRule+" - %"+sizeRule+"d bytes", call, i, field, opset.bytes[0]*opset.bytes[1])
 			}
+			generateForkGuard(&b, typ, i, call)
 		case *opsetDynamic:
-			call := generateCall(opset.defineOffset, "codec", "obj."+field, opset.limits...)
-			fmt.Fprintf(&b, "	ssz.%s // Offset ("+indexRule+") - "+nameRule+" - %"+sizeRule+"d bytes\n", call, i, field, offsetBytes)
+			call := generateCall(opset.defineOffset, "codec", fieldAccess(ctx, typ, i), opset.limits...)
+			call = fmt.Sprintf("ssz.%s // Offset ("+indexRule+") - "+nameRule+" - %"+sizeRule+"d bytes", call, i, field, offsetBytes)
+			generateForkGuard(&b, typ, i, call)
 		}
 	}
 	if !typ.static {
@@ -307,15 +453,150 @@ func generateDefineSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
 		for i := 0; i < len(typ.fields); i++ {
 			field := typ.fields[i]
 			if opset, ok := (typ.opsets[i]).(*opsetDynamic); ok {
-				call := generateCall(opset.defineContent, "codec", "obj."+field, opset.limits...)
-				fmt.Fprintf(&b, "	ssz.%s // Field  ("+indexRule+") - "+nameRule+" - ? bytes\n", call, i, field)
+				call := generateCall(opset.defineContent, "codec", fieldAccess(ctx, typ, i), opset.limits...)
+				call = fmt.Sprintf("ssz.%s // Field  ("+indexRule+") - "+nameRule+" - ? bytes", call, i, field)
+				generateForkGuard(&b, typ, i, call)
+			}
+		}
+	}
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
+// generateHashSSZ generates the HashTreeRoot method of the object, the merkle
+// root accessor used by other ssz libraries, so the types can be passed to code
+// built against those too.
+func generateHashSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var b bytes.Buffer
+
+	ctx.addImport(sszPkgPath, "")
+
+	fmt.Fprint(&b, "// HashTreeRoot computes the ssz merkle root of the object.\n")
+	fmt.Fprintf(&b, "func (obj *%s) HashTreeRoot() ([32]byte, error) {\n", typ.named.Obj().Name())
+	fmt.Fprint(&b, "	return ssz.HashSequentialChecked(obj)\n")
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
+// generateForkGuard emits a line of the DefineSSZ method, wrapping it into a
+// check of the codec's fork if the field it defines is fork-conditional.
+func generateForkGuard(b *bytes.Buffer, typ *sszContainer, i int, line string) {
+	fork := typ.forks[i]
+	if fork == nil {
+		fmt.Fprintf(b, "	%s\n", line)
+		return
+	}
+	fmt.Fprintf(b, "	if ssz.OnFork(codec, &obj.%s, %s) {\n", typ.fields[i], fork.filter())
+	fmt.Fprintf(b, "		%s\n", line)
+	fmt.Fprint(b, "	}\n")
+}
+
+// generateDecodeFlatSSZ generates the ssz.FlatDecoder implementation of a static
+// container, decoding lists of it straight out of the input. Only containers of
+// plain integers and byte arrays are supported, since anything else would need
+// validating or allocating; for others, nil is returned and lists of them are
+// decoded via DefineSSZ as usual.
+func generateDecodeFlatSSZ(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	var (
+		body   bytes.Buffer
+		offset int
+		binary bool
+	)
+	for i := range typ.fields {
+		opset, ok := typ.opsets[i].(*opsetStatic)
+		if !ok {
+			return nil, nil
+		}
+		field := fieldAccess(ctx, typ, i)
+
+		// Integers of named types need an explicit conversion
+		conv := "%s"
+		if _, ok := types.Unalias(typ.types[i]).(*types.Named); ok {
+			conv = types.TypeString(typ.types[i], ctx.qualifier) + "(%s)"
+		}
+		switch method, _, _ := strings.Cut(opset.define, "("); method {
+		case "DefineUint8":
+			fmt.Fprintf(&body, "		%s = "+conv+"\n", field, fmt.Sprintf("item[%d]", offset))
+			offset++
+		case "DefineUint16", "DefineUint32", "DefineUint64":
+			bits := strings.TrimPrefix(method, "DefineUint")
+			size := opset.bytes[0]
+			fmt.Fprintf(&body, "		%s = "+conv+"\n", field, fmt.Sprintf("binary.LittleEndian.Uint%s(item[%d:%d])", bits, offset, offset+size))
+			offset += size
+			binary = true
+		case "DefineStaticBytes":
+			size := opset.bytes[0]
+			fmt.Fprintf(&body, "		copy(%s[:], item[%d:%d])\n", field, offset, offset+size)
+			offset += size
+		case "DefineUnsafeArrayOfStaticBytes":
+			outer, inner := opset.bytes[0], opset.bytes[1]
+			start := fmt.Sprintf("%d*j", inner)
+			if offset > 0 {
+				start = fmt.Sprintf("%d+%s", offset, start)
 			}
+			fmt.Fprintf(&body, "		for j := range %s {\n", field)
+			fmt.Fprintf(&body, "			copy(%s[j][:], item[%s:])\n", field, start)
+			fmt.Fprintf(&body, "		}\n")
+			offset += outer * inner
+		default:
+			return nil, nil
 		}
 	}
+	if binary {
+		ctx.addImport("encoding/binary", "")
+	}
+	ctx.addImport("io", "")
+
+	var (
+		b    bytes.Buffer
+		name = typ.named.Obj().Name()
+	)
+	fmt.Fprint(&b, "// DecodeFlatSSZ decodes a list of objects from their back-to-back encodings in\n")
+	fmt.Fprint(&b, "// one go, implementing ssz.FlatDecoder.\n")
+	fmt.Fprintf(&b, "func (*%s) DecodeFlatSSZ(blob []byte, objs []*%s) error {\n", name, name)
+	fmt.Fprintf(&b, "	if len(blob) < len(objs)*%d {\n", offset)
+	fmt.Fprint(&b, "		return io.ErrUnexpectedEOF\n")
+	fmt.Fprint(&b, "	}\n")
+	fmt.Fprint(&b, "	for i, obj := range objs {\n")
+	fmt.Fprintf(&b, "		item := blob[i*%d : (i+1)*%d]\n", offset, offset)
+	b.Write(body.Bytes())
+	fmt.Fprint(&b, "	}\n")
+	fmt.Fprint(&b, "	return nil\n")
 	fmt.Fprint(&b, "}\n")
 	return b.Bytes(), nil
 }
 
+// generateSizeDynamic emits the accumulation of the dynamic field sizes into the
+// SizeSSZ method of a dynamic container.
+func generateSizeDynamic(ctx *genContext, b *bytes.Buffer, typ *sszContainer) {
+	if typ.descriptor != nil {
+		fmt.Fprintf(b, "	size += descriptor%s.SizeDynamic(obj)\n", typ.named.Obj().Name())
+		return
+	}
+	for i := range typ.opsets {
+		if opset, ok := typ.opsets[i].(*opsetDynamic); ok {
+			call := generateCall(opset.size, "", fieldAccess(ctx, typ, i))
+			fmt.Fprintf(b, "	size += ssz.%s\n", call)
+		}
+	}
+}
+
+// fieldAccess returns the expression accessing a field of the container. Named
+// slice types are converted to their underlying slices, since many of the ssz
+// helpers operating on slices are not generic over the slice type itself.
+func fieldAccess(ctx *genContext, typ *sszContainer, i int) string {
+	field := "obj." + typ.fields[i]
+
+	named, ok := types.Unalias(typ.types[i]).(*types.Named)
+	if !ok || isBitlist(named) {
+		return field
+	}
+	if _, ok := named.Underlying().(*types.Slice); !ok {
+		return field
+	}
+	return fmt.Sprintf("*(*%s)(&%s)", types.TypeString(named.Underlying(), ctx.qualifier), field)
+}
+
 // generateCall parses a Go template and fills it with the provided data. This
 // could be done more optimally, but we really don't care for a code generator.
 func generateCall(tmpl string, recv string, field string, limits ...int) string {
@@ -337,5 +618,6 @@ func generateCall(tmpl string, recv string, field string, limits ...int) string
 	if err := t.Execute(buf, d); err != nil {
 		panic(err)
 	}
-	return string(buf.Bytes())
+	// Taking the address of a converted field (&*(*T)(&field)) is a no-op
+	return strings.ReplaceAll(buf.String(), "&*(", "(")
 }
@@ -0,0 +1,50 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/sszcommon"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that containers referencing types from other packages are generated with
+// the correct imports, encoding identically to the single package containers.
+func TestCrossPackageReferences(t *testing.T) {
+	local := &types.AttestationData{
+		Slot:            1,
+		Index:           2,
+		BeaconBlockHash: types.Hash{0x03},
+		Source:          &types.Checkpoint{Epoch: 4, Root: types.Hash{0x05}},
+		Target:          &types.Checkpoint{Epoch: 6, Root: types.Hash{0x07}},
+	}
+	mixed := &types.AttestationDataVariation{
+		Slot:            1,
+		Index:           2,
+		BeaconBlockRoot: sszcommon.Hash{0x03},
+		Source:          &sszcommon.Checkpoint{Epoch: 4, Root: sszcommon.Hash{0x05}},
+		Target:          &types.Checkpoint{Epoch: 6, Root: types.Hash{0x07}},
+	}
+	if have, want := ssz.Size(mixed), ssz.Size(local); have != want {
+		t.Fatalf("size mismatch: have %d, want %d", have, want)
+	}
+	want := make([]byte, ssz.Size(local))
+	if err := ssz.EncodeToBytes(want, local); err != nil {
+		t.Fatalf("failed to encode local container: %v", err)
+	}
+	have := make([]byte, ssz.Size(mixed))
+	if err := ssz.EncodeToBytes(have, mixed); err != nil {
+		t.Fatalf("failed to encode mixed container: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", have, want)
+	}
+	if have, want := ssz.HashSequential(mixed), ssz.HashSequential(local); have != want {
+		t.Errorf("hash mismatch: have %#x, want %#x", have, want)
+	}
+}
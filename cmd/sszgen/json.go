@@ -0,0 +1,134 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// generateJSON generates the MarshalJSON and UnmarshalJSON methods of the object.
+// Both go through a struct of ssz.JSONField views onto the fields of the object,
+// each of them created by the JSON counterpart of the field's Define method, so
+// the JSON mapping enforces the same sizes and limits as the ssz codec does.
+func generateJSON(ctx *genContext, typ *sszContainer) ([]byte, error) {
+	name := typ.named.Obj().Name()
+	if typ.forked {
+		return nil, fmt.Errorf("monolithic container %s cannot have JSON methods: its fields depend on the fork", name)
+	}
+	// Resolve the JSON name and view of each field before emitting anything
+	var (
+		names = make([]string, len(typ.fields))
+		calls = make([]string, len(typ.fields))
+		seen  = make(map[string]string)
+	)
+	for i, field := range typ.fields {
+		jsonName, err := jsonFieldName(field, typ.jsons[i])
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %v", name, field, err)
+		}
+		if prev, ok := seen[jsonName]; ok {
+			return nil, fmt.Errorf("fields %s.%s and %s.%s both map to JSON name %q", name, prev, name, field, jsonName)
+		}
+		seen[jsonName] = field
+		names[i] = jsonName
+
+		var call string
+		switch opset := typ.opsets[i].(type) {
+		case *opsetStatic:
+			call = generateCall(opset.define, "codec", fieldAccess(ctx, typ, i), opset.bytes...)
+		case *opsetDynamic:
+			call = generateCall(opset.defineOffset, "codec", fieldAccess(ctx, typ, i), opset.limits...)
+		}
+		if calls[i], err = jsonCall(call); err != nil {
+			return nil, fmt.Errorf("field %s.%s: %v", name, field, err)
+		}
+	}
+	ctx.addImport("encoding/json", "")
+	ctx.addImport(sszPkgPath, "")
+
+	var b bytes.Buffer
+	fmt.Fprint(&b, "// MarshalJSON implements json.Marshaler, encoding the object with the JSON\n")
+	fmt.Fprint(&b, "// mapping of its ssz fields.\n")
+	fmt.Fprintf(&b, "func (obj *%s) MarshalJSON() ([]byte, error) {\n", name)
+	fmt.Fprint(&b, "	return json.Marshal(obj.jsonFields())\n")
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprint(&b, "// UnmarshalJSON implements json.Unmarshaler, decoding the object from the JSON\n")
+	fmt.Fprint(&b, "// mapping of its ssz fields. Fields missing from the input are left untouched.\n")
+	fmt.Fprintf(&b, "func (obj *%s) UnmarshalJSON(data []byte) error {\n", name)
+	fmt.Fprint(&b, "	return json.Unmarshal(data, obj.jsonFields())\n")
+	fmt.Fprint(&b, "}\n\n")
+
+	fmt.Fprint(&b, "// jsonFields returns the JSON views of the ssz fields of the object.\n")
+	fmt.Fprintf(&b, "func (obj *%s) jsonFields() any {\n", name)
+	fmt.Fprint(&b, "	return &struct {\n")
+	for i, field := range typ.fields {
+		// Embedded fields are flattened into exported names, for encoding/json
+		field = strings.ReplaceAll(field, ".", "")
+		field = strings.ToUpper(field[:1]) + field[1:]
+		fmt.Fprintf(&b, "		%s ssz.JSONField `json:\"%s\"`\n", field, names[i])
+	}
+	fmt.Fprint(&b, "	}{\n")
+	for i := range typ.fields {
+		fmt.Fprintf(&b, "		ssz.%s,\n", calls[i])
+	}
+	fmt.Fprint(&b, "	}\n")
+	fmt.Fprint(&b, "}\n")
+	return b.Bytes(), nil
+}
+
+// jsonFieldName returns the JSON name of a field: the name in its json tag if it
+// has one, or the snake_case form of its Go name (the one used by the consensus
+// specs) otherwise. Fields of embedded structs are named by their own Go name.
+func jsonFieldName(field string, tag string) (string, error) {
+	name, _, _ := strings.Cut(tag, ",")
+	switch name {
+	case "-":
+		return "", fmt.Errorf("json:\"-\" tag cannot omit ssz fields, use ssz:\"-\" instead")
+	case "":
+		if i := strings.LastIndexByte(field, '.'); i >= 0 {
+			field = field[i+1:]
+		}
+		return snakeCase(field), nil
+	}
+	return name, nil
+}
+
+// snakeCase converts a Go identifier to snake_case, keeping acronyms together
+// (e.g. BlobKZGCommitments becomes blob_kzg_commitments) and digits attached to
+// the preceding word (e.g. Eth1Data becomes eth1_data).
+func snakeCase(name string) string {
+	var (
+		runes = []rune(name)
+		out   []rune
+	)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || next {
+				out = append(out, '_')
+			}
+		}
+		out = append(out, unicode.ToLower(r))
+	}
+	return string(out)
+}
+
+// jsonCall converts the Define call of a field into the call creating its JSON
+// view, e.g. DefineSliceOfUint64sOffset(codec, &obj.F, 8) into
+// JSONSliceOfUint64s(&obj.F, 8).
+func jsonCall(define string) (string, error) {
+	fn, args, _ := strings.Cut(define, "(")
+	fn = strings.TrimSuffix(strings.TrimPrefix(fn, "Define"), "Offset")
+	if fn == "CheckedStaticUint64" {
+		return "", fmt.Errorf("no JSON mapping for %s fields", fn)
+	}
+	args = strings.TrimPrefix(args, "codec, ")
+	return "JSON" + fn + "(" + args, nil
+}
@@ -0,0 +1,75 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the JSON methods generated alongside the ssz ones use the mapping
+// of the beacon APIs, round trip, and enforce the ssz limits of the fields.
+func TestJSONFamily(t *testing.T) {
+	payload := &types.ExecutionPayloadFamilies{
+		ParentHash:    types.Hash{0x01},
+		BlockNumber:   2,
+		ExtraData:     []byte{0x03, 0x04},
+		BaseFeePerGas: uint256.NewInt(5),
+		Transactions:  [][]byte{{0x06}, {}},
+		Withdrawals:   []*types.WithdrawalFamilies{{Index: 7, Validator: 8, Address: types.Address{0x09}, Amount: 10}},
+	}
+	blob, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to marshal payload: %v", err)
+	}
+	for _, want := range []string{
+		`"parent_hash":"0x0100000000000000000000000000000000000000000000000000000000000000"`,
+		`"block_number":"2"`,
+		`"extra_data":"0x0304"`,
+		`"base_fee_per_gas":"5"`,
+		`"transactions":["0x06","0x"]`,
+		`"withdrawals":[{"index":"7","validator_index":"8","address":"0x0900000000000000000000000000000000000000","amount":"10"}]`,
+	} {
+		if !strings.Contains(string(blob), want) {
+			t.Errorf("marshalled payload missing %s: %s", want, blob)
+		}
+	}
+	decoded := new(types.ExecutionPayloadFamilies)
+	if err := json.Unmarshal(blob, decoded); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, payload) {
+		t.Errorf("unmarshalled payload mismatch: have %+v, want %+v", decoded, payload)
+	}
+	if have, want := ssz.HashSequential(decoded), ssz.HashSequential(payload); have != want {
+		t.Errorf("unmarshalled payload root mismatch: have %x, want %x", have, want)
+	}
+	if root, err := decoded.HashTreeRoot(); err != nil || root != ssz.HashSequential(payload) {
+		t.Errorf("hash tree root mismatch: have %x, %v, want %x", root, err, ssz.HashSequential(payload))
+	}
+	// Values violating the ssz schema must be rejected
+	for i, tt := range []struct {
+		input string
+		err   error
+	}{
+		{`{"extra_data":"0x` + strings.Repeat("00", 33) + `"}`, ssz.ErrMaxLengthExceeded},
+		{`{"parent_hash":"0x01"}`, ssz.ErrStaticBytesSizeMismatch},
+		{`{"withdrawals":[` + strings.Repeat(`{},`, 16) + `{}]}`, ssz.ErrMaxItemsExceeded},
+		{`{"block_number":2}`, ssz.ErrInvalidJSON},
+		{`{"block_number":"18446744073709551616"}`, ssz.ErrInvalidJSON},
+		{`{"extra_data":"0304"}`, ssz.ErrInvalidJSON},
+	} {
+		if err := json.Unmarshal([]byte(tt.input), new(types.ExecutionPayloadFamilies)); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
@@ -12,32 +12,85 @@ import (
 	"go/types"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
 )
 
 func main() {
+	// The diff subcommand has its own set of flags
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		diffMain(os.Args[2:])
+		return
+	}
 	var (
 		pkgdir   = flag.String("dir", ".", "input package")
 		output   = flag.String("out", "-", "output file (default is stdout)")
 		typename = flag.String("type", "", "type to generate methods for")
+		mode     = flag.String("mode", modeMethods, "generation mode (methods, descriptor)")
+		tests    = flag.String("tests", "", "output file for round trip tests and fuzzers (default is none)")
+		check    = flag.Bool("check", false, "verify the output files are up to date instead of writing them")
+		flat     = flag.Bool("flat", false, "generate flat list decoders for static containers of integers and byte arrays")
+		families = flag.String("families", familySSZ, "method families to generate (ssz, hash, json)")
 	)
 	flag.Parse()
 
-	cfg := Config{Dir: *pkgdir}
+	// Methods can only be declared in the package of their receivers, so the
+	// output files must be placed into the input package
+	for _, out := range []string{*output, *tests} {
+		if out == "-" || out == "" {
+			continue
+		}
+		indir, _ := filepath.Abs(*pkgdir)
+		outdir, _ := filepath.Abs(filepath.Dir(out))
+		if indir != outdir {
+			fatal(fmt.Sprintf("output %s outside of input package %s: methods must be generated into the package of their types", out, *pkgdir))
+		}
+	}
+	if *tests != "" && !strings.HasSuffix(*tests, "_test.go") {
+		fatal(fmt.Sprintf("test output %s must be a _test.go file", *tests))
+	}
+	cfg := Config{Dir: *pkgdir, Mode: *mode, Tests: *tests != "", Flat: *flat, Families: strings.Split(*families, ",")}
 	if len(*typename) > 0 {
 		cfg.Types = strings.Split(*typename, ",")
 	}
-	code, err := cfg.process()
+	code, testcode, err := cfg.process()
 	if err != nil {
 		fatal(err)
 	}
+	if *check {
+		if *output == "-" {
+			fatal("check mode requires an output file")
+		}
+		checkOutput(*output, code)
+		if *tests != "" {
+			checkOutput(*tests, testcode)
+		}
+		return
+	}
 	if *output == "-" {
 		os.Stdout.Write(code)
 	} else if err := os.WriteFile(*output, code, 0600); err != nil {
 		fatal(err)
 	}
+	if *tests != "" {
+		if err := os.WriteFile(*tests, testcode, 0600); err != nil {
+			fatal(err)
+		}
+	}
+}
+
+// checkOutput exits with an error if the content of an output file differs from
+// the freshly generated code.
+func checkOutput(path string, code []byte) {
+	have, err := os.ReadFile(path)
+	if err != nil {
+		fatal(err)
+	}
+	if !bytes.Equal(have, code) {
+		fatal(fmt.Sprintf("%s is out of date, regenerate it", path))
+	}
 }
 
 func fatal(args ...interface{}) {
@@ -45,30 +98,131 @@ func fatal(args ...interface{}) {
 	os.Exit(1)
 }
 
+// Generation modes supported by the code generator.
+const (
+	// modeMethods generates the encoding of every field into the methods of the
+	// types. It is the fastest at runtime, but bloats binaries with hundreds of
+	// types.
+	modeMethods = "methods"
+
+	// modeDescriptor generates compact field descriptor tables, interpreted via
+	// reflection by the ssz runtime. It trades a little CPU for much less code.
+	// Types with fields the runtime cannot interpret fall back to methods.
+	modeDescriptor = "descriptor"
+)
+
+// Method families the code generator can emit for each type.
+const (
+	// familySSZ generates the SizeSSZ and DefineSSZ methods of the ssz codec.
+	familySSZ = "ssz"
+
+	// familyHash generates a HashTreeRoot method, the interface other ssz
+	// libraries (e.g. fastssz) use to merkleize objects.
+	familyHash = "hash"
+
+	// familyJSON generates the MarshalJSON and UnmarshalJSON methods, using the
+	// JSON mapping of the consensus specs and beacon APIs.
+	familyJSON = "json"
+)
+
 type Config struct {
-	Dir   string // input package directory
-	Types []string
+	Dir      string // input package directory
+	Types    []string
+	Mode     string   // generation mode (empty defaults to methods)
+	Tests    bool     // whether to generate round trip tests and fuzzers too
+	Flat     bool     // whether to generate flat list decoders where possible
+	Families []string // method families to generate (empty defaults to ssz)
+}
+
+// families validates the requested method families and returns them as a set.
+func (cfg *Config) families() (map[string]bool, error) {
+	if len(cfg.Families) == 0 {
+		return map[string]bool{familySSZ: true}, nil
+	}
+	families := make(map[string]bool)
+	for _, family := range cfg.Families {
+		switch family {
+		case familySSZ, familyHash, familyJSON:
+			families[family] = true
+		default:
+			return nil, fmt.Errorf("unknown method family: %s", family)
+		}
+	}
+	if families[familyHash] && !families[familySSZ] {
+		return nil, fmt.Errorf("method family %s requires %s", familyHash, familySSZ)
+	}
+	if cfg.Tests && !families[familySSZ] {
+		return nil, fmt.Errorf("tests require method family %s", familySSZ)
+	}
+	return families, nil
 }
 
-// process generates the Go code.
-func (cfg *Config) process() ([]byte, error) {
+// process generates the Go code, and the test code if requested.
+func (cfg *Config) process() ([]byte, []byte, error) {
+	if cfg.Mode != "" && cfg.Mode != modeMethods && cfg.Mode != modeDescriptor {
+		return nil, nil, fmt.Errorf("unknown generation mode: %s", cfg.Mode)
+	}
+	families, err := cfg.families()
+	if err != nil {
+		return nil, nil, err
+	}
 	// Display a single log for mass generates
 	log.Printf("Generating SSZ bindings for: %v", cfg.Types)
 
+	parser, target, err := loadPackage(cfg.Dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	types, err := parser.parsePackage(target, cfg.Types)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		ctx    = newGenContext(target, cfg.Mode == modeDescriptor, cfg.Flat)
+		chunks [][]byte
+	)
+	ctx.families = families
+	for _, typ := range types {
+		ret, err := generate(ctx, typ)
+		if err != nil {
+			return nil, nil, err
+		}
+		chunks = append(chunks, ret)
+	}
+	hash := schemaHash(types)
+
+	code, err := finalize(ctx, bytes.Join(chunks, []byte("\n\n")), hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !cfg.Tests {
+		return code, nil, nil
+	}
+	ctx = newGenContext(target, false, false)
+	tests, err := finalize(ctx, generateTests(ctx, types), hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return code, tests, nil
+}
+
+// loadPackage loads the Go package in a directory in the context of the ssz
+// library, returning the parser to interpret its types with.
+func loadPackage(dir string) (*parseContext, *types.Package, error) {
 	// Load the ssz library package and the target package to generate into
 	pcfg := &packages.Config{
 		Mode: packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedDeps,
-		Dir:  cfg.Dir,
+		Dir:  dir,
 	}
 	ps, err := packages.Load(pcfg, sszPkgPath, ".")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(ps) == 0 {
-		return nil, fmt.Errorf("no Go package found in %s", cfg.Dir)
+		return nil, nil, fmt.Errorf("no Go package found in %s", dir)
 	}
 	if len(ps) != 2 {
-		return nil, fmt.Errorf("at most one package can be processed at the same time")
+		return nil, nil, fmt.Errorf("at most one package can be processed at the same time")
 	}
 	packages.PrintErrors(ps)
 
@@ -79,7 +233,7 @@ func (cfg *Config) process() ([]byte, error) {
 	)
 	for _, p := range ps {
 		if len(p.Errors) > 0 {
-			return nil, fmt.Errorf("package %s has errors", p.PkgPath)
+			return nil, nil, fmt.Errorf("package %s has errors", p.PkgPath)
 		}
 		if p.PkgPath == sszPkgPath {
 			library = p.Types
@@ -88,34 +242,20 @@ func (cfg *Config) process() ([]byte, error) {
 		}
 	}
 	// Parse the package in the context of the ssz library
-	parser := newParseContext(library)
-
-	types, err := parser.parsePackage(target, cfg.Types)
-	if err != nil {
-		return nil, err
-	}
-	var (
-		ctx    = newGenContext(target)
-		chunks [][]byte
-	)
-	for _, typ := range types {
-		ret, err := generate(ctx, typ)
-		if err != nil {
-			return nil, err
-		}
-		chunks = append(chunks, ret)
-	}
-	code := bytes.Join(chunks, []byte("\n\n"))
+	return newParseContext(library), target, nil
+}
 
-	// Add package and imports definition and format code
-	code = append(ctx.header(), code...)
-	code, err = format.Source(code)
+// finalize adds the package and imports definitions to the generated code, then
+// formats it and adds the generated code header.
+func finalize(ctx *genContext, code []byte, hash string) ([]byte, error) {
+	code, err := format.Source(append(ctx.header(), code...))
 	if err != nil {
 		return nil, err
 	}
 	// Add build comments.
 	// This is done here to avoid processing these lines with gofmt.
 	var header bytes.Buffer
-	fmt.Fprint(&header, "// Code generated by github.com/karalabe/ssz. DO NOT EDIT.\n\n")
+	fmt.Fprint(&header, "// Code generated by github.com/karalabe/ssz. DO NOT EDIT.\n")
+	fmt.Fprintf(&header, "// Schema hash: %s\n\n", hash)
 	return append(header.Bytes(), code...), nil
 }
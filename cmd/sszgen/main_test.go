@@ -0,0 +1,131 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// goldenSources are the files whose go:generate directives are verified by the
+// golden tests. Regenerating the whole repo takes minutes, so only the sources
+// exercising the more exotic generator features are checked here (CI checks all
+// of them via go generate).
+var goldenSources = []string{
+	"../../tests/testtypes/consensus-spec-tests/types_variation.go",
+	"../../tests/testtypes/descriptors/types.go",
+}
+
+// Tests that the generator reproduces the committed generated code byte by byte,
+// guarding against both unintended output changes and nondeterminism.
+func TestGolden(t *testing.T) {
+	for _, source := range goldenSources {
+		for _, args := range parseDirectives(t, source) {
+			flags := flag.NewFlagSet("sszgen", flag.ContinueOnError)
+			var (
+				typename = flags.String("type", "", "")
+				output   = flags.String("out", "", "")
+				mode     = flags.String("mode", modeMethods, "")
+				tests    = flags.String("tests", "", "")
+				flat     = flags.Bool("flat", false, "")
+				families = flags.String("families", familySSZ, "")
+			)
+			if err := flags.Parse(args); err != nil {
+				t.Fatalf("%s: failed to parse directive %v: %v", source, args, err)
+			}
+			t.Run(*output, func(t *testing.T) {
+				cfg := Config{Dir: filepath.Dir(source), Types: strings.Split(*typename, ","), Mode: *mode, Tests: *tests != "", Flat: *flat, Families: strings.Split(*families, ",")}
+				have, haveTests, err := cfg.process()
+				if err != nil {
+					t.Fatalf("failed to generate code: %v", err)
+				}
+				want, err := os.ReadFile(filepath.Join(filepath.Dir(source), *output))
+				if err != nil {
+					t.Fatalf("failed to read golden file: %v", err)
+				}
+				if !bytes.Equal(have, want) {
+					t.Fatalf("generated code mismatch:\n%s", have)
+				}
+				if *tests == "" {
+					return
+				}
+				want, err = os.ReadFile(filepath.Join(filepath.Dir(source), *tests))
+				if err != nil {
+					t.Fatalf("failed to read golden tests file: %v", err)
+				}
+				if !bytes.Equal(haveTests, want) {
+					t.Fatalf("generated tests mismatch:\n%s", haveTests)
+				}
+			})
+		}
+	}
+}
+
+// parseDirectives extracts the sszgen arguments of the go:generate directives
+// in a source file.
+func parseDirectives(t *testing.T, source string) [][]string {
+	file, err := os.Open(source)
+	if err != nil {
+		t.Fatalf("failed to open source: %v", err)
+	}
+	defer file.Close()
+
+	var directives [][]string
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "//go:generate" {
+			continue
+		}
+		for i, field := range fields {
+			if strings.HasSuffix(field, "cmd/sszgen") {
+				directives = append(directives, fields[i+1:])
+				break
+			}
+		}
+	}
+	if len(directives) == 0 {
+		t.Fatalf("no sszgen directives in %s", source)
+	}
+	return directives
+}
+
+// Tests that JSON names default to the snake_case field names of the specs.
+func TestJSONFieldName(t *testing.T) {
+	for field, want := range map[string]string{
+		"Slot":                      "slot",
+		"Eth1Data":                  "eth1_data",
+		"BlobKZGCommitments":        "blob_kzg_commitments",
+		"BLSToExecutionChanges":     "bls_to_execution_changes",
+		"ExecutionPayloadHeader.ID": "id",
+	} {
+		if have, err := jsonFieldName(field, ""); err != nil || have != want {
+			t.Errorf("field %s: name mismatch: have %q (%v), want %q", field, have, err, want)
+		}
+	}
+	if have, err := jsonFieldName("Slot", "block_slot,omitempty"); err != nil || have != "block_slot" {
+		t.Errorf("tagged name mismatch: have %q (%v), want %q", have, err, "block_slot")
+	}
+	if _, err := jsonFieldName("Slot", "-"); err == nil {
+		t.Errorf("omitted field accepted")
+	}
+}
+
+// Tests that invalid method family combinations are rejected.
+func TestFamilies(t *testing.T) {
+	for _, cfg := range []Config{
+		{Families: []string{"xml"}},
+		{Families: []string{familyHash}},
+		{Families: []string{familyJSON}, Tests: true},
+	} {
+		if _, err := cfg.families(); err == nil {
+			t.Errorf("families %v (tests %v) accepted", cfg.Families, cfg.Tests)
+		}
+	}
+}
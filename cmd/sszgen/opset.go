@@ -42,6 +42,22 @@ type opsetDynamic struct {
 // field. Yes, we could maybe have some of these be "computed" instead of hard
 // coded, but it makes things brittle for corner-cases.
 func (p *parseContext) resolveBasicOpset(typ *types.Basic, tags *sizeTag) (opset, error) {
+	// Strings are dynamic byte lists, they need an ssz-max tag instead
+	if typ.Kind() == types.String {
+		if tags == nil || len(tags.limit) != 1 || tags.size != nil {
+			return nil, fmt.Errorf("string basic type requires 1D ssz-max tag")
+		}
+		return &opsetDynamic{
+			"SizeDynamicString({{.Field}})",
+			"DefineDynamicStringOffset({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+			"DefineDynamicStringContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+			"EncodeDynamicStringOffset({{.Codec}}, &{{.Field}})",
+			"EncodeDynamicStringContent({{.Codec}}, &{{.Field}})",
+			"DecodeDynamicStringOffset({{.Codec}}, &{{.Field}})",
+			"DecodeDynamicStringContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+			nil, tags.limit,
+		}, nil
+	}
 	// Sanity check a few tag constraints relevant for all basic types
 	if tags != nil {
 		if tags.limit != nil {
@@ -108,6 +124,30 @@ func (p *parseContext) resolveBasicOpset(typ *types.Basic, tags *sizeTag) (opset
 	}
 }
 
+// resolveEnumOpset retrieves the opset required to handle a validated enum.
+func (p *parseContext) resolveEnumOpset(typ *types.Basic, tags *sizeTag) (opset, error) {
+	// Enums are plain uints on the wire, validate the tags against those
+	if _, err := p.resolveBasicOpset(typ, tags); err != nil {
+		return nil, err
+	}
+	switch typ.Kind() {
+	case types.Uint8:
+		return &opsetStatic{
+			"DefineEnumUint8({{.Codec}}, &{{.Field}})",
+			"EncodeEnumUint8({{.Codec}}, &{{.Field}})",
+			"DecodeEnumUint8({{.Codec}}, &{{.Field}})",
+			[]int{1},
+		}, nil
+	default:
+		return &opsetStatic{
+			"DefineEnumUint64({{.Codec}}, &{{.Field}})",
+			"EncodeEnumUint64({{.Codec}}, &{{.Field}})",
+			"DecodeEnumUint64({{.Codec}}, &{{.Field}})",
+			[]int{8},
+		}, nil
+	}
+}
+
 func (p *parseContext) resolveBitlistOpset(tags *sizeTag) (opset, error) {
 	if tags == nil || tags.limit == nil {
 		return nil, fmt.Errorf("slice of bits type requires ssz-max tag")
@@ -131,7 +171,7 @@ func (p *parseContext) resolveBitlistOpset(tags *sizeTag) (opset, error) {
 }
 
 func (p *parseContext) resolveArrayOpset(typ types.Type, size int, tags *sizeTag) (opset, error) {
-	switch typ := typ.(type) {
+	switch typ := types.Unalias(typ).(type) {
 	case *types.Basic:
 		// Sanity check a few tag constraints relevant for all arrays of basic types
 		if tags != nil {
@@ -198,7 +238,7 @@ func (p *parseContext) resolveArrayOpset(typ types.Type, size int, tags *sizeTag
 }
 
 func (p *parseContext) resolveArrayOfArrayOpset(typ types.Type, outerSize, innerSize int, tags *sizeTag) (opset, error) {
-	switch typ := typ.(type) {
+	switch typ := types.Unalias(typ).(type) {
 	case *types.Basic:
 		// Sanity check a few tag constraints relevant for all arrays of basic types
 		if tags != nil {
@@ -234,7 +274,7 @@ func (p *parseContext) resolveSliceOpset(typ types.Type, tags *sizeTag) (opset,
 	if tags == nil {
 		return nil, fmt.Errorf("slice type requires ssz tags")
 	}
-	switch typ := typ.(type) {
+	switch typ := types.Unalias(typ).(type) {
 	case *types.Basic:
 		switch typ.Kind() {
 		case types.Byte:
@@ -365,7 +405,7 @@ func (p *parseContext) resolveSliceOpset(typ types.Type, tags *sizeTag) (opset,
 }
 
 func (p *parseContext) resolveSliceOfArrayOpset(typ types.Type, innerSize int, tags *sizeTag) (opset, error) {
-	switch typ := typ.(type) {
+	switch typ := types.Unalias(typ).(type) {
 	case *types.Basic:
 		switch typ.Kind() {
 		case types.Byte:
@@ -411,7 +451,7 @@ func (p *parseContext) resolveSliceOfArrayOpset(typ types.Type, innerSize int, t
 }
 
 func (p *parseContext) resolveSliceOfSliceOpset(typ types.Type, tags *sizeTag) (*opsetDynamic, error) {
-	switch typ := typ.(type) {
+	switch typ := types.Unalias(typ).(type) {
 	case *types.Basic:
 		switch typ.Kind() {
 		case types.Byte:
@@ -420,6 +460,21 @@ func (p *parseContext) resolveSliceOfSliceOpset(typ types.Type, tags *sizeTag) (
 			// call that we have to make. Reject any conflicts in the tags, after
 			// which assemble the required combo.
 			switch {
+			case len(tags.size) == 2 && tags.size[0] == 0 && tags.size[1] > 0:
+				if len(tags.limit) != 1 {
+					return nil, fmt.Errorf("dynamic slice of static slice of byte basic type tag conflict: needs [N] ssz-max tag, has %v", tags.limit)
+				}
+				return &opsetDynamic{
+					"SizeCheckedSliceOfStaticBytes({{.Field}})",
+					"DefineCheckedSliceOfStaticBytesOffset({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					"DefineCheckedSliceOfStaticBytesContent({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					"EncodeCheckedSliceOfStaticBytesOffset({{.Codec}}, &{{.Field}})",
+					"EncodeCheckedSliceOfStaticBytesContent({{.Codec}}, &{{.Field}}, {{.MaxSize}})",
+					"DecodeCheckedSliceOfStaticBytesOffset({{.Codec}}, &{{.Field}})",
+					"DecodeCheckedSliceOfStaticBytesContent({{.Codec}}, &{{.Field}}, {{.MaxItems}}, {{.MaxSize}})",
+					nil, []int{tags.limit[0], tags.size[1]},
+				}, nil
+
 			case len(tags.size) > 0 && len(tags.limit) == 0:
 				return nil, fmt.Errorf("static slice of static slice of bytes not implemented yet")
 
@@ -482,6 +537,25 @@ func (p *parseContext) resolvePointerOpset(typ *types.Pointer, tags *sizeTag) (o
 			[]int{32},
 		}, nil
 	}
+	if arr, ok := typ.Elem().Underlying().(*types.Array); ok {
+		if basic, ok := arr.Elem().Underlying().(*types.Basic); ok && basic.Kind() == types.Byte {
+			size := int(arr.Len())
+			if tags != nil {
+				if tags.limit != nil || tags.bits {
+					return nil, fmt.Errorf("pointer to byte array cannot have ssz-max or ssz bitvector tags")
+				}
+				if len(tags.size) != 1 || tags.size[0] != size {
+					return nil, fmt.Errorf("pointer to byte array tag conflict: field is %d bytes, tag wants %v bytes", size, tags.size)
+				}
+			}
+			return &opsetStatic{
+				"DefineStaticBytesPointer({{.Codec}}, &{{.Field}})",
+				"EncodeStaticBytesPointer({{.Codec}}, {{.Field}})",
+				"DecodeStaticBytesPointer({{.Codec}}, &{{.Field}})",
+				[]int{size},
+			}, nil
+		}
+	}
 	if types.Implements(typ, p.staticObjectIface) {
 		if tags != nil {
 			return nil, fmt.Errorf("static object type cannot have any ssz tags")
@@ -13,6 +13,7 @@ import (
 type parseContext struct {
 	staticObjectIface  *types.Interface
 	dynamicObjectIface *types.Interface
+	forkedObjectIface  *types.Interface
 }
 
 // newParseContext loads a few ssz library interfaces for the generator.
@@ -20,10 +21,12 @@ func newParseContext(library *types.Package) *parseContext {
 	var (
 		static  = library.Scope().Lookup("StaticObject").Type().Underlying()
 		dynamic = library.Scope().Lookup("DynamicObject").Type().Underlying()
+		forked  = library.Scope().Lookup("ForkedObject").Type().Underlying()
 	)
 	return &parseContext{
 		staticObjectIface:  static.(*types.Interface),
 		dynamicObjectIface: dynamic.(*types.Interface),
+		forkedObjectIface:  forked.(*types.Interface),
 	}
 }
 
@@ -14,8 +14,23 @@ const (
 	sszTagIdent     = "ssz"
 	sszSizeTagIdent = "ssz-size"
 	sszMaxTagIdent  = "ssz-max"
+	sszForkTagIdent = "ssz-fork"
+	jsonTagIdent    = "json"
 )
 
+// sszForks are the fork names accepted by the ssz-fork tag, mapped to the ssz
+// package constants they are generated as.
+var sszForks = map[string]string{
+	"phase0":    "ForkPhase0",
+	"altair":    "ForkAltair",
+	"bellatrix": "ForkBellatrix",
+	"capella":   "ForkCapella",
+	"deneb":     "ForkDeneb",
+	"electra":   "ForkElectra",
+	"fulu":      "ForkFulu",
+	"future":    "ForkFuture",
+}
+
 // sizeTag describes the size restriction for types.
 type sizeTag struct {
 	bits  bool  // whether the sizes are bits instead of bytes
@@ -23,13 +38,30 @@ type sizeTag struct {
 	limit []int // 0 means the limit for that dimension is undefined
 }
 
-func parseTags(input string) (bool, *sizeTag, error) {
+// forkTag describes the forks a fork-conditional field is present on. A field
+// tagged ssz-fork:"deneb" is added in deneb, one tagged ssz-fork:"!deneb" is
+// removed in deneb.
+type forkTag struct {
+	added   string // ssz package constant of the fork the field was added in
+	removed string // ssz package constant of the fork the field was removed in
+}
+
+// filter returns the ssz.ForkFilter literal matching the fork tag.
+func (tag *forkTag) filter() string {
+	if tag.added != "" {
+		return fmt.Sprintf("ssz.ForkFilter{Added: ssz.%s}", tag.added)
+	}
+	return fmt.Sprintf("ssz.ForkFilter{Removed: ssz.%s}", tag.removed)
+}
+
+func parseTags(input string) (bool, *sizeTag, *forkTag, error) {
 	if len(input) == 0 {
-		return false, nil, nil
+		return false, nil, nil, nil
 	}
 	var (
 		ignore bool
 		tags   sizeTag
+		fork   *forkTag
 		setTag = func(v int, ident string) {
 			if ident == sszMaxTagIdent {
 				tags.limit = append(tags.limit, v)
@@ -41,7 +73,7 @@ func parseTags(input string) (bool, *sizeTag, error) {
 	for _, tag := range strings.Fields(input) {
 		parts := strings.Split(tag, ":")
 		if len(parts) != 2 {
-			return false, nil, fmt.Errorf("invalid tag %s", tag)
+			return false, nil, nil, fmt.Errorf("invalid tag %s", tag)
 		}
 		ident, remain := parts[0], strings.Trim(parts[1], "\"")
 		switch ident {
@@ -51,6 +83,17 @@ func parseTags(input string) (bool, *sizeTag, error) {
 			} else if remain == "bits" {
 				tags.bits = true
 			}
+		case sszForkTagIdent:
+			name, removed := strings.CutPrefix(remain, "!")
+			ident, ok := sszForks[name]
+			if !ok {
+				return false, nil, nil, fmt.Errorf("unknown fork %q in tag %s", name, tag)
+			}
+			if removed {
+				fork = &forkTag{removed: ident}
+			} else {
+				fork = &forkTag{added: ident}
+			}
 		case sszMaxTagIdent, sszSizeTagIdent:
 			parts := strings.Split(remain, ",")
 			for _, p := range parts {
@@ -60,14 +103,14 @@ func parseTags(input string) (bool, *sizeTag, error) {
 				}
 				num, err := strconv.ParseInt(p, 10, 64)
 				if err != nil {
-					return false, nil, err
+					return false, nil, nil, err
 				}
 				setTag(int(num), ident)
 			}
 		}
 	}
 	if tags.size == nil && tags.limit == nil {
-		return ignore, nil, nil
+		return ignore, nil, fork, nil
 	}
-	return ignore, &tags, nil
+	return ignore, &tags, fork, nil
 }
@@ -0,0 +1,30 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package new
+
+type Checkpoint struct {
+	Epoch uint64
+	Root  [32]byte
+}
+
+type Header struct {
+	Proposer uint64 // Moved before Slot
+	Slot     uint64 // Moved after Proposer
+	Root     []byte `ssz-size:"32"` // Same wire type as before
+	Extra    []byte `ssz-max:"64"`  // Limit raised
+	Source   *Checkpoint
+	Added    bool
+}
+
+type Hash [32]byte
+
+type Renamed struct {
+	ValidatorIndex uint64
+	BlockRoots     []Hash `ssz-max:"16"`
+}
+
+type Added struct {
+	Index uint64
+}
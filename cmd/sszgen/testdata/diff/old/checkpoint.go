@@ -0,0 +1,13 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package old
+
+import "github.com/karalabe/ssz"
+
+func (c *Checkpoint) SizeSSZ() uint32 { return 8 + 32 }
+func (c *Checkpoint) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &c.Epoch)
+	ssz.DefineStaticBytes(codec, &c.Root)
+}
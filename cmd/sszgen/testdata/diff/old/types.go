@@ -0,0 +1,28 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package old
+
+type Checkpoint struct {
+	Epoch uint64
+	Root  [32]byte
+}
+
+type Header struct {
+	Slot     uint64
+	Proposer uint64
+	Root     [32]byte
+	Extra    []byte `ssz-max:"32"`
+	Removed  uint32
+	Source   *Checkpoint
+}
+
+type Renamed struct {
+	Index uint64
+	Roots [][32]byte `ssz-max:"16"`
+}
+
+type Removed struct {
+	Index uint64
+}
@@ -0,0 +1,81 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// ssztestPkgPath is the import path of the test helpers used by generated tests.
+const ssztestPkgPath = "github.com/karalabe/ssz/ssztest"
+
+// generateTests creates a round trip test and a fuzzer for each container, both
+// delegating to the ssztest helpers.
+func generateTests(ctx *genContext, containers []*sszContainer) []byte {
+	ctx.addImport("testing", "")
+	ctx.addImport(ssztestPkgPath, "")
+
+	var b bytes.Buffer
+	for i, typ := range containers {
+		if i > 0 {
+			fmt.Fprint(&b, "\n")
+		}
+		name := typ.named.Obj().Name()
+		fresh := fmt.Sprintf("func() *%s { return new(%s) }", name, name)
+
+		fmt.Fprintf(&b, "// TestSSZ%s checks that %s encodings round trip.\n", name, name)
+		fmt.Fprintf(&b, "func TestSSZ%s(t *testing.T) {\n", name)
+		fmt.Fprintf(&b, "	ssztest.AssertRoundTrip(t, ssztest.Populate(new(%s)), %s)\n", name, fresh)
+		fmt.Fprint(&b, "}\n\n")
+
+		fmt.Fprintf(&b, "// FuzzSSZ%s checks that all accepted %s encodings round trip.\n", name, name)
+		fmt.Fprintf(&b, "func FuzzSSZ%s(f *testing.F) {\n", name)
+		fmt.Fprintf(&b, "	ssztest.FuzzRoundTrip(f, %s, %s)\n", fresh, maxSizeExpr(ctx, typ))
+		fmt.Fprint(&b, "}\n")
+	}
+	return b.Bytes()
+}
+
+// maxSizeExpr assembles an expression evaluating to the maximum encoded size of
+// a container, derived from the size limits of its dynamic fields. If any field
+// has no derivable limit (e.g. nested dynamic objects), 0 is returned to signal
+// an unbounded size.
+func maxSizeExpr(ctx *genContext, typ *sszContainer) string {
+	if typ.static {
+		return fmt.Sprintf("uint64(new(%s).SizeSSZ())", typ.named.Obj().Name())
+	}
+	terms := []string{fmt.Sprintf("uint64(new(%s).SizeSSZ(true))", typ.named.Obj().Name())}
+	for i, op := range typ.opsets {
+		op, ok := op.(*opsetDynamic)
+		if !ok {
+			continue
+		}
+		kind, _, _ := strings.Cut(op.size, "(")
+		switch kind {
+		case "SizeDynamicBytes", "SizeDynamicString":
+			terms = append(terms, fmt.Sprintf("%d", op.limits[0]))
+		case "SizeSliceOfBits":
+			terms = append(terms, fmt.Sprintf("%d", op.limits[0]+1)) // sentinel bit might need a new byte
+		case "SizeSliceOfUint64s":
+			terms = append(terms, fmt.Sprintf("%d*8", op.limits[0]))
+		case "SizeSliceOfStaticBytes":
+			blob := typ.types[i].Underlying().(*types.Slice).Elem().Underlying().(*types.Array)
+			terms = append(terms, fmt.Sprintf("%d*%d", op.limits[0], blob.Len()))
+		case "SizeCheckedSliceOfStaticBytes":
+			terms = append(terms, fmt.Sprintf("%d*%d", op.limits[0], op.limits[1]))
+		case "SizeSliceOfDynamicBytes":
+			terms = append(terms, fmt.Sprintf("%d*(4+%d)", op.limits[0], op.limits[1]))
+		case "SizeSliceOfStaticObjects":
+			elem := typ.types[i].Underlying().(*types.Slice).Elem()
+			terms = append(terms, fmt.Sprintf("%d*uint64((%s)(nil).SizeSSZ())", op.limits[0], types.TypeString(elem, ctx.qualifier)))
+		default:
+			return "0"
+		}
+	}
+	return strings.Join(terms, " + ")
+}
@@ -7,6 +7,7 @@ package main
 import (
 	"fmt"
 	"go/types"
+	"reflect"
 )
 
 type sszContainer struct {
@@ -16,63 +17,131 @@ type sszContainer struct {
 	fields []string
 	types  []types.Type
 	opsets []opset
+	forks  []*forkTag // Fork conditions of the fields (nil if unconditional)
+	jsons  []string   // JSON tags of the fields (empty if untagged)
+
+	forked     bool     // Whether the container is monolithic (layout depends on the fork)
+	descriptor []string // Field descriptors, if generated in descriptor mode
 }
 
 // makeContainer iterates over the fields of the struct and attempt to match each
 // field with an opset for encoding/decoding ssz.
 func (p *parseContext) makeContainer(named *types.Named, typ *types.Struct) (*sszContainer, error) {
-	var (
-		static = true
-		fields []string
-		types  []types.Type
-		opsets []opset
-	)
-	// Iterate over all the fields of the struct
+	container := &sszContainer{
+		Struct: typ,
+		named:  named,
+		static: true,
+	}
+	if err := p.collectFields(container, typ, ""); err != nil {
+		return nil, err
+	}
+	if container.forked {
+		if err := validateForked(container); err != nil {
+			return nil, err
+		}
+	}
+	return container, nil
+}
+
+// collectFields iterates over the fields of a struct and appends each of them
+// with the matching opset to the container. Embedded (non-pointer) structs are
+// flattened in declaration order, with their fields accessed via the embedding
+// path. Embedded pointers are regular fields, holding nested containers.
+func (p *parseContext) collectFields(container *sszContainer, typ *types.Struct, path string) error {
 	for i := 0; i < typ.NumFields(); i++ {
-		// Skip private fields, and skip ignored ssz fields
+		// Skip ignored ssz fields, and private ones unless they are embedded
+		// structs, which might still promote public fields
 		f := typ.Field(i)
-		if !f.Exported() {
+
+		embedded, isEmbedded := f.Type().Underlying().(*types.Struct)
+		isEmbedded = isEmbedded && f.Embedded()
+
+		if !f.Exported() && !isEmbedded {
 			continue
 		}
-		ignore, tags, err := parseTags(typ.Tag(i))
+		ignore, tags, fork, err := parseTags(typ.Tag(i))
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse field %s.%s tags: %v", named.Obj().Name(), f.Name(), err)
+			return fmt.Errorf("failed to parse field %s.%s tags: %v", container.named.Obj().Name(), path+f.Name(), err)
 		}
 		if ignore {
 			continue
 		}
+		if isEmbedded {
+			if tags != nil {
+				return fmt.Errorf("embedded struct %s.%s cannot have size tags", container.named.Obj().Name(), path+f.Name())
+			}
+			if fork != nil {
+				return fmt.Errorf("embedded struct %s.%s cannot have fork tags", container.named.Obj().Name(), path+f.Name())
+			}
+			if err := p.collectFields(container, embedded, path+f.Name()+"."); err != nil {
+				return err
+			}
+			continue
+		}
 		// Required field found, validate type with tag content
 		opset, err := p.resolveOpset(f.Type(), tags)
 		if err != nil {
-			return nil, fmt.Errorf("failed to validate field %s.%s: %v", named.Obj().Name(), f.Name(), err)
+			return fmt.Errorf("failed to validate field %s.%s: %v", container.named.Obj().Name(), path+f.Name(), err)
 		}
 		if _, ok := (opset).(*opsetDynamic); ok {
-			static = false
+			container.static = false
+		}
+		container.fields = append(container.fields, path+f.Name())
+		container.types = append(container.types, f.Type())
+		container.opsets = append(container.opsets, opset)
+		container.forks = append(container.forks, fork)
+		container.jsons = append(container.jsons, reflect.StructTag(typ.Tag(i)).Get(jsonTagIdent))
+
+		if fork != nil || p.isForked(f.Type()) {
+			container.forked = true
 		}
-		fields = append(fields, f.Name())
-		types = append(types, f.Type())
-		opsets = append(opsets, opset)
 	}
-	return &sszContainer{
-		Struct: typ,
-		named:  named,
-		static: static,
-		fields: fields,
-		types:  types,
-		opsets: opsets,
-	}, nil
+	return nil
+}
+
+// isForked checks whether 'typ' is a monolithic object, or a list of them, whose
+// size depends on the fork. Containers embedding such fields are monolithic too,
+// since they need to propagate the fork into the nested size calculations.
+func (p *parseContext) isForked(typ types.Type) bool {
+	if slice, ok := types.Unalias(typ).Underlying().(*types.Slice); ok {
+		typ = slice.Elem()
+	}
+	return types.Implements(typ, p.forkedObjectIface)
+}
+
+// validateForked checks that a monolithic container can be encoded on all forks.
+// The layout is only allowed to differ in its field set, not in whether it's a
+// static or a dynamic container, which would change the encoding of the parent.
+func validateForked(container *sszContainer) error {
+	if container.static {
+		return fmt.Errorf("static container %s cannot have fork-conditional fields", container.named.Obj().Name())
+	}
+	for i, opset := range container.opsets {
+		if _, ok := opset.(*opsetDynamic); ok && container.forks[i] == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("monolithic container %s needs an unconditional dynamic field", container.named.Obj().Name())
 }
 
 // resolveOpset compares the type of the field to the provided tags and returns
 // whether there's a collision between them, or if more tags are needed to fully
 // derive the size. If the type/tags are in sync and well-defined, an opset will
 // be returned that the generator can use to create the code.
+//
+// Type aliases are resolved (through any number of layers) to their targets, and
+// named types to their underlying types, unless the named type has a dedicated
+// opset of its own (e.g. bitlists, enums).
 func (p *parseContext) resolveOpset(typ types.Type, tags *sizeTag) (opset, error) {
+	typ = types.Unalias(typ)
 	switch t := typ.(type) {
 	case *types.Named:
 		if isBitlist(typ) {
 			return p.resolveBitlistOpset(tags)
 		}
+		if isEnum(typ) {
+			return p.resolveEnumOpset(t.Underlying().(*types.Basic), tags)
+		}
 		return p.resolveOpset(t.Underlying(), tags)
 
 	case *types.Basic:
@@ -92,7 +161,7 @@ func (p *parseContext) resolveOpset(typ types.Type, tags *sizeTag) (opset, error
 
 // isBigInt checks whether 'typ' is "math/big".Int.
 func isBigInt(typ types.Type) bool {
-	named, ok := typ.(*types.Named)
+	named, ok := types.Unalias(typ).(*types.Named)
 	if !ok {
 		return false
 	}
@@ -102,7 +171,7 @@ func isBigInt(typ types.Type) bool {
 
 // isUint256 checks whether 'typ' is "github.com/holiman/uint256".Int.
 func isUint256(typ types.Type) bool {
-	named, ok := typ.(*types.Named)
+	named, ok := types.Unalias(typ).(*types.Named)
 	if !ok {
 		return false
 	}
@@ -110,12 +179,36 @@ func isUint256(typ types.Type) bool {
 	return name.Pkg().Path() == "github.com/holiman/uint256" && name.Name() == "Int"
 }
 
-// isBitlist checks whether 'typ' is "github.com/prysmaticlabs/go-bitfield".Bitlist.
+// isEnum checks whether 'typ' is a named uint8 or uint64 with a `Valid() bool`
+// method, satisfying the ssz.Enum interface.
+func isEnum(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok || (basic.Kind() != types.Uint8 && basic.Kind() != types.Uint64) {
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(typ, false, nil, "Valid")
+	method, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := method.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	result, ok := sig.Results().At(0).Type().(*types.Basic)
+	return ok && result.Kind() == types.Bool
+}
+
+// isBitlist checks whether 'typ' is "github.com/prysmaticlabs/go-bitfield".Bitlist
+// or "github.com/karalabe/ssz/bitfield".Bitlist.
 func isBitlist(typ types.Type) bool {
-	named, ok := typ.(*types.Named)
+	named, ok := types.Unalias(typ).(*types.Named)
 	if !ok {
 		return false
 	}
 	name := named.Obj()
-	return name.Pkg().Path() == "github.com/prysmaticlabs/go-bitfield" && name.Name() == "Bitlist"
+	if name.Name() != "Bitlist" {
+		return false
+	}
+	return name.Pkg().Path() == "github.com/prysmaticlabs/go-bitfield" || name.Pkg().Path() == "github.com/karalabe/ssz/bitfield"
 }
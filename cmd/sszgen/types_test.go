@@ -0,0 +1,63 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that containers composed via struct embedding are flattened by the code
+// generator, producing the same encoding and hash as the inlined containers.
+func TestEmbeddedStructFlattening(t *testing.T) {
+	header := types.ExecutionPayloadHeader{
+		ParentHash:  [32]byte{0x01},
+		BlockNumber: 2,
+		ExtraData:   []byte{0x03, 0x04},
+		BlockHash:   [32]byte{0x05},
+	}
+	embedded := &types.ExecutionPayloadHeaderDenebVariation{
+		ExecutionPayloadHeaderCapellaVariation: types.ExecutionPayloadHeaderCapellaVariation{
+			ExecutionPayloadHeader: header,
+			WithdrawalRoot:         [32]byte{0x06},
+		},
+		BlobGasUsed:   7,
+		ExcessBlobGas: 8,
+	}
+	inlined := &types.ExecutionPayloadHeaderDeneb{
+		ParentHash:     header.ParentHash,
+		BlockNumber:    header.BlockNumber,
+		ExtraData:      header.ExtraData,
+		BlockHash:      header.BlockHash,
+		WithdrawalRoot: [32]byte{0x06},
+		BlobGasUsed:    7,
+		ExcessBlobGas:  8,
+	}
+	want := make([]byte, ssz.Size(inlined))
+	if err := ssz.EncodeToBytes(want, inlined); err != nil {
+		t.Fatalf("failed to encode inlined container: %v", err)
+	}
+	have := make([]byte, ssz.Size(embedded))
+	if err := ssz.EncodeToBytes(have, embedded); err != nil {
+		t.Fatalf("failed to encode embedded container: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", have, want)
+	}
+	if have, want := ssz.HashSequential(embedded), ssz.HashSequential(inlined); have != want {
+		t.Errorf("hash mismatch: have %#x, want %#x", have, want)
+	}
+	decoded := new(types.ExecutionPayloadHeaderDenebVariation)
+	if err := ssz.DecodeFromBytes(want, decoded); err != nil {
+		t.Fatalf("failed to decode embedded container: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, embedded) {
+		t.Errorf("decoded mismatch: have %+v, want %+v", decoded, embedded)
+	}
+}
@@ -5,10 +5,11 @@
 package ssz
 
 import (
+	"fmt"
 	"math/big"
+	"strconv"
 
 	"github.com/holiman/uint256"
-	"github.com/prysmaticlabs/go-bitfield"
 )
 
 // Codec is a unified SSZ encoder and decoder that allows simple structs to
@@ -18,6 +19,15 @@ type Codec struct {
 	enc *Encoder
 	dec *Decoder
 	has *Hasher
+	fmt *formatter
+
+	fork Fork // Fork the operation runs on, for monolithic types
+}
+
+// hashing returns whether the encoder also feeds the hasher with the fields it
+// serializes (i.e. EncodeAndHash). Hashing stops at the first encoding error.
+func (c *Codec) hashing() bool {
+	return c.has != nil && c.enc.err == nil
 }
 
 // DefineEncoder uses a dedicated encoder in case the types SSZ conversion is for
@@ -27,7 +37,12 @@ type Codec struct {
 // In reality, it will be the live code run when the object is being serialized.
 func (c *Codec) DefineEncoder(impl func(enc *Encoder)) {
 	if c.enc != nil {
+		// If the encoder also feeds the hasher, keep it out of the loop, the
+		// dedicated hasher will be run separately
+		has := c.has
+		c.has = nil
 		impl(c.enc)
+		c.has = has
 	}
 }
 
@@ -51,18 +66,46 @@ func (c *Codec) DefineHasher(impl func(has *Hasher)) {
 	if c.has != nil {
 		impl(c.has)
 	}
+	if c.fmt != nil {
+		c.fmt.note("asymmetric codec, fields not rendered")
+	}
+}
+
+// SplitObject is the set of methods implemented by types that define their ssz
+// encoder, decoder and hasher as separate methods (i.e. the asymmetric style),
+// instead of a single codec definition.
+type SplitObject interface {
+	EncodeSSZ(enc *Encoder) // Serializes the object's fields
+	DecodeSSZ(dec *Decoder) // Parses the object's fields
+	HashSSZ(has *Hasher)    // Hashes the object's fields
+}
+
+// DefineSplit routes the codec to the dedicated encoder, decoder and hasher
+// methods of the object. It allows types written in the split style to satisfy
+// the Object interface with a one liner, after which they can be nested within
+// codec defined types (and vice versa) freely.
+func (c *Codec) DefineSplit(obj SplitObject) {
+	c.DefineEncoder(obj.EncodeSSZ)
+	c.DefineDecoder(obj.DecodeSSZ)
+	c.DefineHasher(obj.HashSSZ)
 }
 
 // DefineBool defines the next field as a 1 byte boolean.
 func DefineBool[T ~bool](c *Codec, v *T) {
 	if c.enc != nil {
 		EncodeBool(c.enc, *v)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeBool(c.dec, v)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(v, strconv.FormatBool(bool(*v)))
+		return
+	}
 	HashBool(c.has, *v)
 }
 
@@ -70,12 +113,18 @@ func DefineBool[T ~bool](c *Codec, v *T) {
 func DefineUint8[T ~uint8](c *Codec, n *T) {
 	if c.enc != nil {
 		EncodeUint8(c.enc, *n)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeUint8(c.dec, n)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(n, strconv.FormatUint(uint64(*n), 10))
+		return
+	}
 	HashUint8(c.has, *n)
 }
 
@@ -83,12 +132,18 @@ func DefineUint8[T ~uint8](c *Codec, n *T) {
 func DefineUint16[T ~uint16](c *Codec, n *T) {
 	if c.enc != nil {
 		EncodeUint16(c.enc, *n)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeUint16(c.dec, n)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(n, strconv.FormatUint(uint64(*n), 10))
+		return
+	}
 	HashUint16(c.has, *n)
 }
 
@@ -96,12 +151,18 @@ func DefineUint16[T ~uint16](c *Codec, n *T) {
 func DefineUint32[T ~uint32](c *Codec, n *T) {
 	if c.enc != nil {
 		EncodeUint32(c.enc, *n)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeUint32(c.dec, n)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(n, strconv.FormatUint(uint64(*n), 10))
+		return
+	}
 	HashUint32(c.has, *n)
 }
 
@@ -109,12 +170,18 @@ func DefineUint32[T ~uint32](c *Codec, n *T) {
 func DefineUint64[T ~uint64](c *Codec, n *T) {
 	if c.enc != nil {
 		EncodeUint64(c.enc, *n)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeUint64(c.dec, n)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(n, strconv.FormatUint(uint64(*n), 10))
+		return
+	}
 	HashUint64(c.has, *n)
 }
 
@@ -122,67 +189,160 @@ func DefineUint64[T ~uint64](c *Codec, n *T) {
 func DefineUint256(c *Codec, n **uint256.Int) {
 	if c.enc != nil {
 		EncodeUint256(c.enc, *n)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeUint256(c.dec, n)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUint256(*n))
+		return
+	}
 	HashUint256(c.has, *n)
 }
 
+// DefineUint256Checked defines the next field as a uint256, running the validator
+// on it right after it's decoded (e.g. to bound a base fee, see CheckUint256Max).
+func DefineUint256Checked(c *Codec, n **uint256.Int, validate func(n *uint256.Int) error) {
+	if c.enc != nil {
+		EncodeUint256Checked(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeUint256Checked(c.dec, n, validate)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUint256(*n))
+		return
+	}
+	HashUint256Checked(c.has, *n)
+}
+
 // DefineUint256BigInt defines the next field as a uint256.
 func DefineUint256BigInt(c *Codec, n **big.Int) {
 	if c.enc != nil {
 		EncodeUint256BigInt(c.enc, *n)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeUint256BigInt(c.dec, n)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatBigInt(*n))
+		return
+	}
 	HashUint256BigInt(c.has, *n)
 }
 
+// DefineUint256Bytes defines the next field as a uint256, stored as a 32 byte
+// big-endian array in the Go struct.
+func DefineUint256Bytes(c *Codec, n *[32]byte) {
+	if c.enc != nil {
+		EncodeUint256Bytes(c.enc, n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeUint256Bytes(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, new(uint256.Int).SetBytes32(n[:]).Dec())
+		return
+	}
+	HashUint256Bytes(c.has, n)
+}
+
 // DefineStaticBytes defines the next field as static binary blob. This method
 // can be used for byte arrays.
 func DefineStaticBytes[T commonBytesLengths](c *Codec, blob *T) {
 	if c.enc != nil {
 		EncodeStaticBytes(c.enc, blob)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeStaticBytes(c.dec, blob)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(blob, formatStaticBytes(arrayBytes(blob)))
+		return
+	}
 	HashStaticBytes(c.has, blob)
 }
 
 // DefineCheckedStaticBytes defines the next field as static binary blob. This
 // method can be used for plain byte slices, which is more expensive, since it
 // needs runtime size validation.
+//
+// Note, an empty slice is encoded and hashed as size zero bytes, but any other
+// length mismatch will halt encoding and hashing with an error.
 func DefineCheckedStaticBytes(c *Codec, blob *[]byte, size uint64) {
 	if c.enc != nil {
-		EncodeCheckedStaticBytes(c.enc, *blob)
-		return
+		switch uint64(len(*blob)) {
+		case size:
+			EncodeCheckedStaticBytes(c.enc, *blob)
+		case 0:
+			c.enc.encodeZeroBytes(size)
+		default:
+			if c.enc.err == nil {
+				c.enc.err = fmt.Errorf("%w: have %d bytes, want %d", ErrStaticBytesSizeMismatch, len(*blob), size)
+			}
+			c.enc.encodeZeroBytes(size) // keep the buffered output position consistent
+		}
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeCheckedStaticBytes(c.dec, blob, size)
 		return
 	}
-	HashCheckedStaticBytes(c.has, *blob)
+	if c.fmt != nil {
+		c.fmt.line(blob, formatStaticBytes(*blob))
+		return
+	}
+	switch uint64(len(*blob)) {
+	case size:
+		HashCheckedStaticBytes(c.has, *blob)
+	case 0:
+		c.has.hashZeroBytes(size)
+	default:
+		if c.has.err == nil {
+			c.has.err = fmt.Errorf("%w: have %d bytes, want %d", ErrStaticBytesSizeMismatch, len(*blob), size)
+		}
+		c.has.hashZeroBytes(size) // keep the shape of the tree consistent
+	}
 }
 
 // DefineDynamicBytesOffset defines the next field as dynamic binary blob.
 func DefineDynamicBytesOffset(c *Codec, blob *[]byte, maxSize uint64) {
 	if c.enc != nil {
 		EncodeDynamicBytesOffset(c.enc, *blob)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeDynamicBytesOffset(c.dec, blob)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(blob, fmt.Sprintf("%s (%d%s bytes)", formatBytes(*blob), len(*blob), formatLimit(maxSize)))
+		return
+	}
 	HashDynamicBytes(c.has, *blob, maxSize)
 }
 
@@ -199,6 +359,40 @@ func DefineDynamicBytesContent(c *Codec, blob *[]byte, maxSize uint64) {
 	// No hashing, done at the offset position
 }
 
+// DefineDynamicStringOffset defines the next field as a dynamic binary blob
+// held in a Go string.
+func DefineDynamicStringOffset[T ~string](c *Codec, str *T, maxSize uint64) {
+	if c.enc != nil {
+		EncodeDynamicStringOffset(c.enc, *str)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeDynamicStringOffset(c.dec, str)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(str, fmt.Sprintf("%q (%d%s bytes)", *str, len(*str), formatLimit(maxSize)))
+		return
+	}
+	HashDynamicString(c.has, *str, maxSize)
+}
+
+// DefineDynamicStringContent defines the next field as a dynamic binary blob
+// held in a Go string.
+func DefineDynamicStringContent[T ~string](c *Codec, str *T, maxSize uint64) {
+	if c.enc != nil {
+		EncodeDynamicStringContent(c.enc, *str)
+		return
+	}
+	if c.dec != nil {
+		DecodeDynamicStringContent(c.dec, str, maxSize)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
 // DefineStaticObject defines the next field as a static ssz object.
 func DefineStaticObject[T newableStaticObject[U], U any](c *Codec, obj *T) {
 	if c.enc != nil {
@@ -209,19 +403,71 @@ func DefineStaticObject[T newableStaticObject[U], U any](c *Codec, obj *T) {
 		DecodeStaticObject(c.dec, obj)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.object(obj, *obj)
+		return
+	}
 	HashStaticObject(c.has, *obj)
 }
 
+// DefineStaticObjectPointer defines the next field as an optional static ssz
+// object, encoded and hashed as the zero object if nil, and allocated on demand
+// when decoding.
+func DefineStaticObjectPointer[T newableStaticObject[U], U any](c *Codec, obj *T) {
+	if c.enc != nil {
+		EncodeStaticObjectPointer(c.enc, *obj)
+		return
+	}
+	if c.dec != nil {
+		DecodeStaticObjectPointer(c.dec, obj)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.object(obj, *obj)
+		return
+	}
+	HashStaticObjectPointer(c.has, *obj)
+}
+
+// DefineStaticBinaryMarshaler defines the next field as a static binary blob of
+// an opaque type, converted to and from its SSZ form via the type's binary
+// marshaling methods. This method can be used to plug external types (e.g. BLS
+// signatures or public keys) directly into ssz objects.
+func DefineStaticBinaryMarshaler[T newableBinaryMarshaler[U], U any](c *Codec, v *T, size uint64) {
+	if c.enc != nil {
+		EncodeStaticBinaryMarshaler(c.enc, *v, size)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeStaticBinaryMarshaler(c.dec, v, size)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(v, formatBinaryMarshaler(*v))
+		return
+	}
+	HashStaticBinaryMarshaler(c.has, *v, size)
+}
+
 // DefineDynamicObjectOffset defines the next field as a dynamic ssz object.
 func DefineDynamicObjectOffset[T newableDynamicObject[U], U any](c *Codec, obj *T) {
 	if c.enc != nil {
 		EncodeDynamicObjectOffset(c.enc, *obj)
+		if c.hashing() {
+			c.has.reserveChunk()
+		}
 		return
 	}
 	if c.dec != nil {
 		DecodeDynamicObjectOffset(c.dec, obj)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.object(obj, *obj)
+		return
+	}
 	HashDynamicObject(c.has, *obj)
 }
 
@@ -229,6 +475,9 @@ func DefineDynamicObjectOffset[T newableDynamicObject[U], U any](c *Codec, obj *
 func DefineDynamicObjectContent[T newableDynamicObject[U], U any](c *Codec, obj *T) {
 	if c.enc != nil {
 		EncodeDynamicObjectContent(c.enc, *obj)
+		if c.hashing() {
+			c.has.fillChunk()
+		}
 		return
 	}
 	if c.dec != nil {
@@ -242,30 +491,63 @@ func DefineDynamicObjectContent[T newableDynamicObject[U], U any](c *Codec, obj
 func DefineArrayOfBits[T commonBitsLengths](c *Codec, bits *T, size uint64) {
 	if c.enc != nil {
 		EncodeArrayOfBits(c.enc, bits)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeArrayOfBits(c.dec, bits, size)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(bits, fmt.Sprintf("%s (%d bits)", formatBytes(arrayBytes(bits)), size))
+		return
+	}
 	HashArrayOfBits(c.has, bits)
 }
 
+// DefineCheckedArrayOfBits defines the next field as a static array of (packed)
+// bits. This method can be used for plain byte slices, which is more expensive,
+// since it needs runtime size validation.
+func DefineCheckedArrayOfBits[T ~[]byte](c *Codec, bits *T, size uint64) {
+	if c.enc != nil {
+		EncodeCheckedArrayOfBits(c.enc, *bits)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeCheckedArrayOfBits(c.dec, bits, size)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(bits, fmt.Sprintf("%s (%d bits)", formatBytes(*bits), size))
+		return
+	}
+	HashCheckedArrayOfBits(c.has, *bits)
+}
+
 // DefineSliceOfBitsOffset defines the next field as a dynamic slice of (packed) bits.
-func DefineSliceOfBitsOffset(c *Codec, bits *bitfield.Bitlist, maxBits uint64) {
+func DefineSliceOfBitsOffset[T ~[]byte](c *Codec, bits *T, maxBits uint64) {
 	if c.enc != nil {
 		EncodeSliceOfBitsOffset(c.enc, *bits)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeSliceOfBitsOffset(c.dec, bits)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(bits, formatBitlist(*bits, maxBits))
+		return
+	}
 	HashSliceOfBits(c.has, *bits, maxBits)
 }
 
 // DefineSliceOfBitsContent defines the next field as a dynamic slice of (packed) bits.
-func DefineSliceOfBitsContent(c *Codec, bits *bitfield.Bitlist, maxBits uint64) {
+func DefineSliceOfBitsContent[T ~[]byte](c *Codec, bits *T, maxBits uint64) {
 	if c.enc != nil {
 		EncodeSliceOfBitsContent(c.enc, *bits)
 		return
@@ -281,12 +563,18 @@ func DefineSliceOfBitsContent(c *Codec, bits *bitfield.Bitlist, maxBits uint64)
 func DefineArrayOfUint64s[T commonUint64sLengths](c *Codec, ns *T) {
 	if c.enc != nil {
 		EncodeArrayOfUint64s(c.enc, ns)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeArrayOfUint64s(c.dec, ns)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(ns, formatUint64s(arrayUint64s(ns)))
+		return
+	}
 	HashArrayOfUint64s(c.has, ns)
 }
 
@@ -294,12 +582,18 @@ func DefineArrayOfUint64s[T commonUint64sLengths](c *Codec, ns *T) {
 func DefineSliceOfUint64sOffset[T ~uint64](c *Codec, ns *[]T, maxItems uint64) {
 	if c.enc != nil {
 		EncodeSliceOfUint64sOffset(c.enc, *ns)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeSliceOfUint64sOffset(c.dec, ns)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.line(ns, fmt.Sprintf("%s (%d%s items)", formatUint64s(*ns), len(*ns), formatLimit(maxItems)))
+		return
+	}
 	HashSliceOfUint64s(c.has, *ns, maxItems)
 }
 
@@ -321,12 +615,19 @@ func DefineSliceOfUint64sContent[T ~uint64](c *Codec, ns *[]T, maxItems uint64)
 func DefineArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](c *Codec, blobs *T) {
 	if c.enc != nil {
 		EncodeArrayOfStaticBytes[T, U](c.enc, blobs)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeArrayOfStaticBytes[T, U](c.dec, blobs)
 		return
 	}
+	if c.fmt != nil {
+		items := arrayItems[T, U](blobs)
+		c.fmt.items(blobs, len(items), "", func(i int) string { return formatStaticBytes(arrayBytes(&items[i])) })
+		return
+	}
 	HashArrayOfStaticBytes[T, U](c.has, blobs)
 }
 
@@ -337,28 +638,65 @@ func DefineArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths
 func DefineUnsafeArrayOfStaticBytes[T commonBytesLengths](c *Codec, blobs []T) {
 	if c.enc != nil {
 		EncodeUnsafeArrayOfStaticBytes(c.enc, blobs)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeUnsafeArrayOfStaticBytes(c.dec, blobs)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.items(blobs, len(blobs), "", func(i int) string { return formatStaticBytes(arrayBytes(&blobs[i])) })
+		return
+	}
 	HashUnsafeArrayOfStaticBytes(c.has, blobs)
 }
 
 // DefineCheckedArrayOfStaticBytes defines the next field as a static array of
 // static binary blobs. This method can be used for plain slices of byte arrays,
 // which is more expensive since it needs runtime size validation.
+//
+// Note, an empty slice is encoded and hashed as size zero blobs, but any other
+// length mismatch will halt encoding and hashing with an error.
 func DefineCheckedArrayOfStaticBytes[T commonBytesLengths](c *Codec, blobs *[]T, size uint64) {
 	if c.enc != nil {
-		EncodeCheckedArrayOfStaticBytes(c.enc, *blobs)
-		return
+		var blob T
+		switch uint64(len(*blobs)) {
+		case size:
+			EncodeCheckedArrayOfStaticBytes(c.enc, *blobs)
+		case 0:
+			c.enc.encodeZeroBytes(size * uint64(len(blob)))
+		default:
+			if c.enc.err == nil {
+				c.enc.err = fmt.Errorf("%w: have %d items, want %d", ErrStaticBytesSizeMismatch, len(*blobs), size)
+			}
+			c.enc.encodeZeroBytes(size * uint64(len(blob))) // keep the buffered output position consistent
+		}
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeCheckedArrayOfStaticBytes(c.dec, blobs, size)
 		return
 	}
-	HashCheckedArrayOfStaticBytes(c.has, *blobs)
+	if c.fmt != nil {
+		c.fmt.items(blobs, len(*blobs), "", func(i int) string { return formatStaticBytes(arrayBytes(&(*blobs)[i])) })
+		return
+	}
+	var blob T
+	switch uint64(len(*blobs)) {
+	case size:
+		HashCheckedArrayOfStaticBytes(c.has, *blobs)
+	case 0:
+		c.has.hashZeroArrayOfBytes(size, uint64(len(blob)))
+	default:
+		if c.has.err == nil {
+			c.has.err = fmt.Errorf("%w: have %d items, want %d", ErrStaticBytesSizeMismatch, len(*blobs), size)
+		}
+		c.has.hashZeroArrayOfBytes(size, uint64(len(blob))) // keep the shape of the tree consistent
+	}
 }
 
 // DefineSliceOfStaticBytesOffset defines the next field as a dynamic slice of static
@@ -366,12 +704,18 @@ func DefineCheckedArrayOfStaticBytes[T commonBytesLengths](c *Codec, blobs *[]T,
 func DefineSliceOfStaticBytesOffset[T commonBytesLengths](c *Codec, bytes *[]T, maxItems uint64) {
 	if c.enc != nil {
 		EncodeSliceOfStaticBytesOffset(c.enc, *bytes)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeSliceOfStaticBytesOffset(c.dec, bytes)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.items(bytes, len(*bytes), formatLimit(maxItems), func(i int) string { return formatStaticBytes(arrayBytes(&(*bytes)[i])) })
+		return
+	}
 	HashSliceOfStaticBytes(c.has, *bytes, maxItems)
 }
 
@@ -389,17 +733,61 @@ func DefineSliceOfStaticBytesContent[T commonBytesLengths](c *Codec, blobs *[]T,
 	// No hashing, done at the offset position
 }
 
+// DefineCheckedSliceOfStaticBytesOffset defines the next field as a dynamic slice
+// of static binary blobs. This method can be used for plain slices of byte slices,
+// which is more expensive since it needs runtime size validation.
+func DefineCheckedSliceOfStaticBytesOffset(c *Codec, blobs *[][]byte, maxItems uint64, size uint64) {
+	if c.enc != nil {
+		EncodeCheckedSliceOfStaticBytesOffset(c.enc, *blobs)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeCheckedSliceOfStaticBytesOffset(c.dec, blobs)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.items(blobs, len(*blobs), formatLimit(maxItems), func(i int) string { return formatStaticBytes((*blobs)[i]) })
+		return
+	}
+	HashCheckedSliceOfStaticBytes(c.has, *blobs, maxItems)
+}
+
+// DefineCheckedSliceOfStaticBytesContent defines the next field as a dynamic slice
+// of static binary blobs. This method can be used for plain slices of byte slices,
+// which is more expensive since it needs runtime size validation.
+func DefineCheckedSliceOfStaticBytesContent(c *Codec, blobs *[][]byte, maxItems uint64, size uint64) {
+	if c.enc != nil {
+		EncodeCheckedSliceOfStaticBytesContent(c.enc, *blobs, size)
+		return
+	}
+	if c.dec != nil {
+		DecodeCheckedSliceOfStaticBytesContent(c.dec, blobs, maxItems, size)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
 // DefineSliceOfDynamicBytesOffset defines the next field as a dynamic slice of dynamic
 // binary blobs.
 func DefineSliceOfDynamicBytesOffset(c *Codec, blobs *[][]byte, maxItems uint64, maxSize uint64) {
 	if c.enc != nil {
 		EncodeSliceOfDynamicBytesOffset(c.enc, *blobs)
-		return
+		if !c.hashing() {
+			return
+		}
 	}
 	if c.dec != nil {
 		DecodeSliceOfDynamicBytesOffset(c.dec, blobs)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.items(blobs, len(*blobs), formatLimit(maxItems), func(i int) string {
+			return fmt.Sprintf("%s (%d%s bytes)", formatBytes((*blobs)[i]), len((*blobs)[i]), formatLimit(maxSize))
+		})
+		return
+	}
 	HashSliceOfDynamicBytes(c.has, *blobs, maxItems, maxSize)
 }
 
@@ -422,12 +810,19 @@ func DefineSliceOfDynamicBytesContent(c *Codec, blobs *[][]byte, maxItems uint64
 func DefineSliceOfStaticObjectsOffset[T newableStaticObject[U], U any](c *Codec, objects *[]T, maxItems uint64) {
 	if c.enc != nil {
 		EncodeSliceOfStaticObjectsOffset(c.enc, *objects)
+		if c.hashing() {
+			c.has.reserveChunk()
+		}
 		return
 	}
 	if c.dec != nil {
 		DecodeSliceOfStaticObjectsOffset(c.dec, objects)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.objects(objects, len(*objects), formatLimit(maxItems), func(i int) Object { return (*objects)[i] })
+		return
+	}
 	HashSliceOfStaticObjects(c.has, *objects, maxItems)
 }
 
@@ -435,7 +830,14 @@ func DefineSliceOfStaticObjectsOffset[T newableStaticObject[U], U any](c *Codec,
 // ssz objects.
 func DefineSliceOfStaticObjectsContent[T newableStaticObject[U], U any](c *Codec, objects *[]T, maxItems uint64) {
 	if c.enc != nil {
+		if c.hashing() {
+			c.has.descendMixinLayer()
+		}
 		EncodeSliceOfStaticObjectsContent(c.enc, *objects)
+		if c.hashing() {
+			c.has.ascendMixinLayer(uint64(len(*objects)), maxItems)
+			c.has.fillChunk()
+		}
 		return
 	}
 	if c.dec != nil {
@@ -445,17 +847,47 @@ func DefineSliceOfStaticObjectsContent[T newableStaticObject[U], U any](c *Codec
 	// No hashing, done at the offset posiiton
 }
 
+// DefineSliceOfCheckedStaticObjectsContent defines the next field as a dynamic slice of
+// static ssz objects, running the validator on every item right after it's decoded
+// (e.g. to check that indices are monotonic). The field's offset is defined via
+// DefineSliceOfStaticObjectsOffset as usual.
+func DefineSliceOfCheckedStaticObjectsContent[T newableStaticObject[U], U any](c *Codec, objects *[]T, maxItems uint64, validate func(i uint32, item T) error) {
+	if c.enc != nil {
+		if c.hashing() {
+			c.has.descendMixinLayer()
+		}
+		EncodeSliceOfCheckedStaticObjectsContent(c.enc, *objects)
+		if c.hashing() {
+			c.has.ascendMixinLayer(uint64(len(*objects)), maxItems)
+			c.has.fillChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfCheckedStaticObjectsContent(c.dec, objects, maxItems, validate)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
 // DefineSliceOfDynamicObjectsOffset defines the next field as a dynamic slice of dynamic
 // ssz objects.
 func DefineSliceOfDynamicObjectsOffset[T newableDynamicObject[U], U any](c *Codec, objects *[]T, maxItems uint64) {
 	if c.enc != nil {
 		EncodeSliceOfDynamicObjectsOffset(c.enc, *objects)
+		if c.hashing() {
+			c.has.reserveChunk()
+		}
 		return
 	}
 	if c.dec != nil {
 		DecodeSliceOfDynamicObjectsOffset(c.dec, objects)
 		return
 	}
+	if c.fmt != nil {
+		c.fmt.objects(objects, len(*objects), formatLimit(maxItems), func(i int) Object { return (*objects)[i] })
+		return
+	}
 	HashSliceOfDynamicObjects(c.has, *objects, maxItems)
 }
 
@@ -463,7 +895,14 @@ func DefineSliceOfDynamicObjectsOffset[T newableDynamicObject[U], U any](c *Code
 // ssz objects.
 func DefineSliceOfDynamicObjectsContent[T newableDynamicObject[U], U any](c *Codec, objects *[]T, maxItems uint64) {
 	if c.enc != nil {
+		if c.hashing() {
+			c.has.descendMixinLayer()
+		}
 		EncodeSliceOfDynamicObjectsContent(c.enc, *objects)
+		if c.hashing() {
+			c.has.ascendMixinLayer(uint64(len(*objects)), maxItems)
+			c.has.fillChunk()
+		}
 		return
 	}
 	if c.dec != nil {
@@ -472,3 +911,87 @@ func DefineSliceOfDynamicObjectsContent[T newableDynamicObject[U], U any](c *Cod
 	}
 	// No hashing, done at the offset position
 }
+
+// DefineSliceOfStaticObjectsOffsetFunc defines the next field as a dynamic slice
+// of static ssz objects, instantiated via a user provided factory method.
+func DefineSliceOfStaticObjectsOffsetFunc[T StaticObject](c *Codec, objects *[]T, maxItems uint64, newItem func() T) {
+	if c.enc != nil {
+		EncodeSliceOfStaticObjectsOffset(c.enc, *objects)
+		if c.hashing() {
+			c.has.reserveChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfStaticObjectsOffsetFunc(c.dec, objects)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.objects(objects, len(*objects), formatLimit(maxItems), func(i int) Object { return (*objects)[i] })
+		return
+	}
+	HashSliceOfStaticObjects(c.has, *objects, maxItems)
+}
+
+// DefineSliceOfStaticObjectsContentFunc defines the next field as a dynamic slice
+// of static ssz objects, instantiated via a user provided factory method.
+func DefineSliceOfStaticObjectsContentFunc[T StaticObject](c *Codec, objects *[]T, maxItems uint64, newItem func() T) {
+	if c.enc != nil {
+		if c.hashing() {
+			c.has.descendMixinLayer()
+		}
+		EncodeSliceOfStaticObjectsContent(c.enc, *objects)
+		if c.hashing() {
+			c.has.ascendMixinLayer(uint64(len(*objects)), maxItems)
+			c.has.fillChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfStaticObjectsContentFunc(c.dec, objects, maxItems, newItem)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineSliceOfDynamicObjectsOffsetFunc defines the next field as a dynamic slice
+// of dynamic ssz objects, instantiated via a user provided factory method.
+func DefineSliceOfDynamicObjectsOffsetFunc[T DynamicObject](c *Codec, objects *[]T, maxItems uint64, newItem func() T) {
+	if c.enc != nil {
+		EncodeSliceOfDynamicObjectsOffset(c.enc, *objects)
+		if c.hashing() {
+			c.has.reserveChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfDynamicObjectsOffsetFunc(c.dec, objects)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.objects(objects, len(*objects), formatLimit(maxItems), func(i int) Object { return (*objects)[i] })
+		return
+	}
+	HashSliceOfDynamicObjects(c.has, *objects, maxItems)
+}
+
+// DefineSliceOfDynamicObjectsContentFunc defines the next field as a dynamic slice
+// of dynamic ssz objects, instantiated via a user provided factory method.
+func DefineSliceOfDynamicObjectsContentFunc[T DynamicObject](c *Codec, objects *[]T, maxItems uint64, newItem func() T) {
+	if c.enc != nil {
+		if c.hashing() {
+			c.has.descendMixinLayer()
+		}
+		EncodeSliceOfDynamicObjectsContent(c.enc, *objects)
+		if c.hashing() {
+			c.has.ascendMixinLayer(uint64(len(*objects)), maxItems)
+			c.has.fillChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfDynamicObjectsContentFunc(c.dec, objects, maxItems, newItem)
+		return
+	}
+	// No hashing, done at the offset position
+}
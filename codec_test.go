@@ -0,0 +1,885 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the uint256 alternatives (big-endian byte arrays and big.Ints) are
+// encoded, decoded and hashed the same way as uint256.Int.
+func TestUint256Alternatives(t *testing.T) {
+	want := new(uint256.Int).Lsh(uint256.NewInt(0x0102), 200)
+
+	obj := &testUint256Type{
+		Native: want,
+		Bytes:  want.Bytes32(),
+		Big:    want.ToBig(),
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if !bytes.Equal(blob[:32], blob[32:64]) || !bytes.Equal(blob[:32], blob[64:]) {
+		t.Fatalf("encoding mismatch: %x", blob)
+	}
+	dec := new(testUint256Type)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if dec.Bytes != obj.Bytes || dec.Big.Cmp(obj.Big) != 0 {
+		t.Errorf("decoded mismatch: have %x/%v, want %x/%v", dec.Bytes, dec.Big, obj.Bytes, obj.Big)
+	}
+	// All three fields hash into the same chunk, so the root is easy to compute
+	chunk := make([]byte, 32)
+	want.WriteToSlice(chunk)
+	slices.Reverse(chunk)
+
+	left := sha256.Sum256(append(chunk, chunk...))
+	right := sha256.Sum256(append(chunk, make([]byte, 32)...))
+	if have, want := ssz.HashSequential(obj), sha256.Sum256(append(left[:], right[:]...)); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	// Out of range big.Ints must be rejected
+	for _, n := range []*big.Int{new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(-1)} {
+		obj.Big = n
+		if err := ssz.EncodeToBytes(blob, obj); !errors.Is(err, ssz.ErrUint256Overflow) {
+			t.Errorf("buffer encoding error mismatch for %v: have %v, want %v", n, err, ssz.ErrUint256Overflow)
+		}
+		if err := ssz.EncodeToStream(io.Discard, obj); !errors.Is(err, ssz.ErrUint256Overflow) {
+			t.Errorf("stream encoding error mismatch for %v: have %v, want %v", n, err, ssz.ErrUint256Overflow)
+		}
+		if _, err := ssz.HashSequentialChecked(obj); !errors.Is(err, ssz.ErrUint256Overflow) {
+			t.Errorf("hashing error mismatch for %v: have %v, want %v", n, err, ssz.ErrUint256Overflow)
+		}
+		// Concurrently hashed list items must report the error too
+		list := &testUint256ListType{Items: make([]*testUint256Type, 1024)}
+		for i := range list.Items {
+			list.Items[i] = new(testUint256Type)
+		}
+		list.Items[len(list.Items)-1].Big = n
+
+		if _, err := ssz.HashConcurrentChecked(list); !errors.Is(err, ssz.ErrUint256Overflow) {
+			t.Errorf("concurrent hashing error mismatch for %v: have %v, want %v", n, err, ssz.ErrUint256Overflow)
+		}
+	}
+	// The unchecked hashers hash out of range values as zero, without failing
+	obj.Big = nil
+	root := ssz.HashSequential(obj)
+
+	obj.Big = big.NewInt(-1)
+	if have := ssz.HashSequential(obj); have != root {
+		t.Errorf("unchecked hash mismatch: have %x, want %x", have, root)
+	}
+	obj.Big = big.NewInt(1)
+	if _, err := ssz.HashSequentialChecked(obj); err != nil {
+		t.Errorf("failed to hash valid object: %v", err)
+	}
+}
+
+type testUint256ListType struct {
+	Items []*testUint256Type
+}
+
+func (t *testUint256ListType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(4)
+	if !fixed {
+		size += ssz.SizeSliceOfStaticObjects(t.Items)
+	}
+	return size
+}
+
+func (t *testUint256ListType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Items, 1024)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Items, 1024)
+}
+
+type testUint256Type struct {
+	Native *uint256.Int
+	Bytes  [32]byte
+	Big    *big.Int
+}
+
+func (t *testUint256Type) SizeSSZ() uint32 { return 96 }
+
+func (t *testUint256Type) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint256(codec, &t.Native)
+	ssz.DefineUint256Bytes(codec, &t.Bytes)
+	ssz.DefineUint256BigInt(codec, &t.Big)
+}
+
+// Tests that opaque types implementing the binary marshaling interfaces can be
+// plugged into objects as static binary blobs.
+func TestStaticBinaryMarshaler(t *testing.T) {
+	obj := &testMarshalerType{Signature: &testSignature{0x01, 0x02, 95: 0x03}}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if !bytes.Equal(blob, obj.Signature[:]) {
+		t.Fatalf("encoding mismatch: have %x, want %x", blob, obj.Signature[:])
+	}
+	for _, stream := range []bool{false, true} {
+		dec := new(testMarshalerType)
+		if stream {
+			if err := ssz.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob))); err != nil {
+				t.Fatalf("failed to decode object from stream: %v", err)
+			}
+		} else {
+			if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+				t.Fatalf("failed to decode object from bytes: %v", err)
+			}
+		}
+		if *dec.Signature != *obj.Signature {
+			t.Errorf("stream %v: decoded mismatch: have %x, want %x", stream, dec.Signature[:], obj.Signature[:])
+		}
+	}
+	raw := &testMarshalerRawType{Signature: *obj.Signature}
+	if have, want := ssz.HashSequential(obj), ssz.HashSequential(raw); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	// Marshalers producing the wrong size must be rejected
+	short := &testMarshalerShortType{Signature: obj.Signature}
+	if err := ssz.EncodeToBytes(make([]byte, ssz.Size(short)), short); !errors.Is(err, ssz.ErrMarshaledSizeMismatch) {
+		t.Errorf("encoding error mismatch: have %v, want %v", err, ssz.ErrMarshaledSizeMismatch)
+	}
+	// Marshaling failures must be reported by the checked hashers and hashed as
+	// zero by the unchecked ones
+	zero := ssz.HashSequential(&testMarshalerRawType{})
+	if _, err := ssz.HashSequentialChecked(short); !errors.Is(err, ssz.ErrMarshaledSizeMismatch) {
+		t.Errorf("hashing error mismatch: have %v, want %v", err, ssz.ErrMarshaledSizeMismatch)
+	}
+	failing := &testMarshalerFailingType{Signature: new(testFailingSignature)}
+	if _, err := ssz.HashSequentialChecked(failing); !errors.Is(err, errTestMarshal) {
+		t.Errorf("hashing error mismatch: have %v, want %v", err, errTestMarshal)
+	}
+	if have := ssz.HashSequential(failing); have != zero {
+		t.Errorf("unchecked hash mismatch: have %x, want %x", have, zero)
+	}
+	if err := ssz.EncodeToBytes(make([]byte, ssz.Size(failing)), failing); !errors.Is(err, errTestMarshal) {
+		t.Errorf("encoding error mismatch: have %v, want %v", err, errTestMarshal)
+	}
+}
+
+type testSignature [96]byte
+
+func (s *testSignature) MarshalBinary() ([]byte, error) {
+	return s[:], nil
+}
+
+func (s *testSignature) UnmarshalBinary(blob []byte) error {
+	if len(blob) != len(s) {
+		return fmt.Errorf("invalid signature length %d", len(blob))
+	}
+	copy(s[:], blob)
+	return nil
+}
+
+type testMarshalerType struct {
+	Signature *testSignature
+}
+
+func (t *testMarshalerType) SizeSSZ() uint32 { return 96 }
+
+func (t *testMarshalerType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBinaryMarshaler(codec, &t.Signature, 96)
+}
+
+type testMarshalerShortType struct {
+	Signature *testSignature
+}
+
+func (t *testMarshalerShortType) SizeSSZ() uint32 { return 48 }
+
+func (t *testMarshalerShortType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBinaryMarshaler(codec, &t.Signature, 48)
+}
+
+// errTestMarshal is the error returned by testFailingSignature.
+var errTestMarshal = errors.New("marshal failed")
+
+type testFailingSignature [96]byte
+
+func (s *testFailingSignature) MarshalBinary() ([]byte, error) {
+	return nil, errTestMarshal
+}
+
+func (s *testFailingSignature) UnmarshalBinary(blob []byte) error {
+	return errTestMarshal
+}
+
+type testMarshalerFailingType struct {
+	Signature *testFailingSignature
+}
+
+func (t *testMarshalerFailingType) SizeSSZ() uint32 { return 96 }
+
+func (t *testMarshalerFailingType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBinaryMarshaler(codec, &t.Signature, 96)
+}
+
+type testMarshalerRawType struct {
+	Signature [96]byte
+}
+
+func (t *testMarshalerRawType) SizeSSZ() uint32 { return 96 }
+
+func (t *testMarshalerRawType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &t.Signature)
+}
+
+// testOptionalType is a container with an optional static object field, which
+// may be left nil by the user.
+type testOptionalType struct {
+	Epoch      uint64
+	Checkpoint *types.Checkpoint
+}
+
+func (t *testOptionalType) SizeSSZ() uint32 { return 8 + 40 }
+
+func (t *testOptionalType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Epoch)
+	ssz.DefineStaticObjectPointer(codec, &t.Checkpoint)
+}
+
+// Tests that optional static objects are encoded and hashed as zero objects if
+// nil, and allocated when decoding.
+func TestStaticObjectPointer(t *testing.T) {
+	empty := &testOptionalType{Epoch: 1}
+	zero := &testOptionalType{Epoch: 1, Checkpoint: new(types.Checkpoint)}
+
+	have := make([]byte, ssz.Size(empty))
+	if err := ssz.EncodeToBytes(have, empty); err != nil {
+		t.Fatalf("failed to encode nil object: %v", err)
+	}
+	want := make([]byte, ssz.Size(zero))
+	if err := ssz.EncodeToBytes(want, zero); err != nil {
+		t.Fatalf("failed to encode zero object: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", have, want)
+	}
+	if have, want := ssz.HashSequential(empty), ssz.HashSequential(zero); have != want {
+		t.Errorf("hash mismatch: have %#x, want %#x", have, want)
+	}
+	if out := ssz.Format(empty); !strings.Contains(out, "\n  Checkpoint: nil\n") {
+		t.Errorf("formatted output missing nil checkpoint:\n%s", out)
+	}
+	decoded := new(testOptionalType)
+	if err := ssz.DecodeFromBytes(have, decoded); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, zero) {
+		t.Errorf("decoded object mismatch: have %+v, want %+v", decoded, zero)
+	}
+}
+
+// testRootsType is a container with a list of roots modelled as byte arrays,
+// and testCheckedRootsType is the same with the roots as plain byte slices.
+type testRootsType struct {
+	Slot  uint64
+	Roots [][32]byte
+}
+
+func (t *testRootsType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8 + 4
+	}
+	return 8 + 4 + ssz.SizeSliceOfStaticBytes(t.Roots)
+}
+
+func (t *testRootsType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineSliceOfStaticBytesOffset(codec, &t.Roots, 16)
+	ssz.DefineSliceOfStaticBytesContent(codec, &t.Roots, 16)
+}
+
+type testCheckedRootsType struct {
+	Slot  uint64
+	Roots [][]byte
+}
+
+func (t *testCheckedRootsType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8 + 4
+	}
+	return 8 + 4 + ssz.SizeCheckedSliceOfStaticBytes(t.Roots)
+}
+
+func (t *testCheckedRootsType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineCheckedSliceOfStaticBytesOffset(codec, &t.Roots, 16, 32)
+	ssz.DefineCheckedSliceOfStaticBytesContent(codec, &t.Roots, 16, 32)
+}
+
+// Tests that slices of static binary blobs modelled as plain byte slices are
+// encoded, decoded and hashed identically to ones modelled as byte arrays, and
+// that the item sizes are validated.
+func TestCheckedSliceOfStaticBytes(t *testing.T) {
+	array := &testRootsType{Slot: 1, Roots: [][32]byte{{0x01}, {0x02}, {0x03}}}
+	checked := &testCheckedRootsType{Slot: 1}
+	for _, root := range array.Roots {
+		checked.Roots = append(checked.Roots, bytes.Clone(root[:]))
+	}
+	want := make([]byte, ssz.Size(array))
+	if err := ssz.EncodeToBytes(want, array); err != nil {
+		t.Fatalf("failed to encode array roots: %v", err)
+	}
+	have := make([]byte, ssz.Size(checked))
+	if err := ssz.EncodeToBytes(have, checked); err != nil {
+		t.Fatalf("failed to encode checked roots: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", have, want)
+	}
+	if have, want := ssz.HashSequential(checked), ssz.HashSequential(array); have != want {
+		t.Errorf("hash mismatch: have %#x, want %#x", have, want)
+	}
+	for _, stream := range []bool{false, true} {
+		decoded := new(testCheckedRootsType)
+
+		var err error
+		if stream {
+			err = ssz.DecodeFromStream(bytes.NewReader(want), decoded, uint32(len(want)))
+		} else {
+			err = ssz.DecodeFromBytes(want, decoded)
+		}
+		if err != nil {
+			t.Fatalf("stream %v: failed to decode checked roots: %v", stream, err)
+		}
+		if !reflect.DeepEqual(decoded, checked) {
+			t.Errorf("stream %v: decoded mismatch: have %+v, want %+v", stream, decoded, checked)
+		}
+	}
+	// Ensure invalid item sizes are rejected both ways
+	checked.Roots[1] = checked.Roots[1][:31]
+	if err := ssz.EncodeToBytes(make([]byte, ssz.Size(checked)), checked); !errors.Is(err, ssz.ErrStaticBytesSizeMismatch) {
+		t.Errorf("encode to bytes error mismatch: have %v, want %v", err, ssz.ErrStaticBytesSizeMismatch)
+	}
+	if err := ssz.EncodeToStream(io.Discard, checked); !errors.Is(err, ssz.ErrStaticBytesSizeMismatch) {
+		t.Errorf("encode to stream error mismatch: have %v, want %v", err, ssz.ErrStaticBytesSizeMismatch)
+	}
+	if err := ssz.DecodeFromBytes(want[:len(want)-1], new(testCheckedRootsType)); !errors.Is(err, ssz.ErrDynamicStaticsIndivisible) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, ssz.ErrDynamicStaticsIndivisible)
+	}
+}
+
+// testStringType is a container with a string field, and testStringBytesType is
+// the same with the string modelled as a byte slice.
+type testStringType struct {
+	Slot uint64
+	Name string
+}
+
+func (t *testStringType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8 + 4
+	}
+	return 8 + 4 + ssz.SizeDynamicString(t.Name)
+}
+
+func (t *testStringType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineDynamicStringOffset(codec, &t.Name, 64)
+	ssz.DefineDynamicStringContent(codec, &t.Name, 64)
+}
+
+type testStringBytesType struct {
+	Slot uint64
+	Name []byte
+}
+
+func (t *testStringBytesType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8 + 4
+	}
+	return 8 + 4 + ssz.SizeDynamicBytes(t.Name)
+}
+
+func (t *testStringBytesType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineDynamicBytesOffset(codec, &t.Name, 64)
+	ssz.DefineDynamicBytesContent(codec, &t.Name, 64)
+}
+
+// Tests that string fields are encoded, decoded and hashed identically to byte
+// lists, and that their maximum length is enforced.
+func TestDynamicString(t *testing.T) {
+	str := &testStringType{Slot: 1, Name: "karalabe/ssz"}
+	blob := &testStringBytesType{Slot: 1, Name: []byte("karalabe/ssz")}
+
+	want := make([]byte, ssz.Size(blob))
+	if err := ssz.EncodeToBytes(want, blob); err != nil {
+		t.Fatalf("failed to encode byte list: %v", err)
+	}
+	have := make([]byte, ssz.Size(str))
+	if err := ssz.EncodeToBytes(have, str); err != nil {
+		t.Fatalf("failed to encode string: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", have, want)
+	}
+	stream := new(bytes.Buffer)
+	if err := ssz.EncodeToStream(stream, str); err != nil {
+		t.Fatalf("failed to stream string: %v", err)
+	}
+	if !bytes.Equal(stream.Bytes(), want) {
+		t.Errorf("stream encoding mismatch: have %x, want %x", stream.Bytes(), want)
+	}
+	if have, want := ssz.HashSequential(str), ssz.HashSequential(blob); have != want {
+		t.Errorf("hash mismatch: have %#x, want %#x", have, want)
+	}
+	if out := ssz.Format(str); !strings.Contains(out, `Name: "karalabe/ssz" (12/64 bytes)`) {
+		t.Errorf("formatted output missing name:\n%s", out)
+	}
+	for _, stream := range []bool{false, true} {
+		decoded := new(testStringType)
+
+		var err error
+		if stream {
+			err = ssz.DecodeFromStream(bytes.NewReader(want), decoded, uint32(len(want)))
+		} else {
+			err = ssz.DecodeFromBytes(want, decoded)
+		}
+		if err != nil {
+			t.Fatalf("stream %v: failed to decode string: %v", stream, err)
+		}
+		if *decoded != *str {
+			t.Errorf("stream %v: decoded mismatch: have %+v, want %+v", stream, decoded, str)
+		}
+	}
+	// Ensure re-decoding an unchanged string does not allocate
+	decoded := new(testStringType)
+	allocs := testing.AllocsPerRun(10, func() {
+		if err := ssz.DecodeFromBytes(want, decoded); err != nil {
+			t.Fatalf("failed to redecode string: %v", err)
+		}
+	})
+	if allocs != 0 && !puregoBuild {
+		t.Errorf("allocations mismatch: have %v, want 0", allocs)
+	}
+	// Ensure the maximum length is enforced
+	blob.Name = bytes.Repeat([]byte{'x'}, 65)
+	long := make([]byte, ssz.Size(blob))
+	if err := ssz.EncodeToBytes(long, blob); err != nil {
+		t.Fatalf("failed to encode long byte list: %v", err)
+	}
+	if err := ssz.DecodeFromBytes(long, new(testStringType)); !errors.Is(err, ssz.ErrMaxLengthExceeded) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, ssz.ErrMaxLengthExceeded)
+	}
+}
+
+// testZeroCheckedType is a container with checked static fields, and testZeroArrayType
+// is the same with the fields modelled as arrays.
+type testZeroCheckedType struct {
+	Root  []byte
+	Roots [][32]byte
+}
+
+func (t *testZeroCheckedType) SizeSSZ() uint32 { return 48 + 8*32 }
+
+func (t *testZeroCheckedType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineCheckedStaticBytes(codec, &t.Root, 48)
+	ssz.DefineCheckedArrayOfStaticBytes(codec, &t.Roots, 8)
+}
+
+type testZeroArrayType struct {
+	Root  [48]byte
+	Roots [8][32]byte
+}
+
+func (t *testZeroArrayType) SizeSSZ() uint32 { return 48 + 8*32 }
+
+func (t *testZeroArrayType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &t.Root)
+	ssz.DefineArrayOfStaticBytes[[8][32]byte, [32]byte](codec, &t.Roots)
+}
+
+// Tests that empty checked static fields are encoded and hashed as zero values,
+// but that any other size mismatch is rejected by both.
+func TestCheckedStaticBytesZero(t *testing.T) {
+	checked, array := new(testZeroCheckedType), new(testZeroArrayType)
+
+	want := make([]byte, ssz.Size(array))
+	if err := ssz.EncodeToBytes(want, array); err != nil {
+		t.Fatalf("failed to encode array type: %v", err)
+	}
+	have := make([]byte, ssz.Size(checked))
+	if err := ssz.EncodeToBytes(have, checked); err != nil {
+		t.Fatalf("failed to encode checked type: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", have, want)
+	}
+	stream := new(bytes.Buffer)
+	if err := ssz.EncodeToStream(stream, checked); err != nil {
+		t.Fatalf("failed to stream encode checked type: %v", err)
+	}
+	if !bytes.Equal(stream.Bytes(), want) {
+		t.Errorf("stream encoding mismatch: have %x, want %x", stream.Bytes(), want)
+	}
+	root, err := ssz.HashSequentialChecked(checked)
+	if err != nil {
+		t.Fatalf("failed to hash checked type: %v", err)
+	}
+	if have, want := root, ssz.HashSequential(array); have != want {
+		t.Errorf("hash mismatch: have %#x, want %#x", have, want)
+	}
+	// Ensure partially filled fields are rejected
+	for _, obj := range []*testZeroCheckedType{
+		{Root: make([]byte, 47)},
+		{Roots: make([][32]byte, 3)},
+	} {
+		if err := ssz.EncodeToBytes(make([]byte, ssz.Size(obj)), obj); !errors.Is(err, ssz.ErrStaticBytesSizeMismatch) {
+			t.Errorf("encode to bytes error mismatch: have %v, want %v", err, ssz.ErrStaticBytesSizeMismatch)
+		}
+		if err := ssz.EncodeToStream(io.Discard, obj); !errors.Is(err, ssz.ErrStaticBytesSizeMismatch) {
+			t.Errorf("encode to stream error mismatch: have %v, want %v", err, ssz.ErrStaticBytesSizeMismatch)
+		}
+		if _, err := ssz.HashSequentialChecked(obj); !errors.Is(err, ssz.ErrStaticBytesSizeMismatch) {
+			t.Errorf("hash error mismatch: have %v, want %v", err, ssz.ErrStaticBytesSizeMismatch)
+		}
+	}
+}
+
+// testSplitWithdrawal is a static type written in the split style, with the same
+// schema as the codec defined types.Withdrawal.
+type testSplitWithdrawal struct {
+	Index     uint64
+	Validator uint64
+	Address   [20]byte
+	Amount    uint64
+}
+
+func (w *testSplitWithdrawal) SizeSSZ() uint32            { return 44 }
+func (w *testSplitWithdrawal) DefineSSZ(codec *ssz.Codec) { codec.DefineSplit(w) }
+
+func (w *testSplitWithdrawal) EncodeSSZ(enc *ssz.Encoder) {
+	ssz.EncodeUint64(enc, w.Index)
+	ssz.EncodeUint64(enc, w.Validator)
+	ssz.EncodeStaticBytes(enc, &w.Address)
+	ssz.EncodeUint64(enc, w.Amount)
+}
+func (w *testSplitWithdrawal) DecodeSSZ(dec *ssz.Decoder) {
+	ssz.DecodeUint64(dec, &w.Index)
+	ssz.DecodeUint64(dec, &w.Validator)
+	ssz.DecodeStaticBytes(dec, &w.Address)
+	ssz.DecodeUint64(dec, &w.Amount)
+}
+func (w *testSplitWithdrawal) HashSSZ(has *ssz.Hasher) {
+	ssz.HashUint64(has, w.Index)
+	ssz.HashUint64(has, w.Validator)
+	ssz.HashStaticBytes(has, &w.Address)
+	ssz.HashUint64(has, w.Amount)
+}
+
+// testSplitPayload is a dynamic type written in the split style, embedding both
+// codec defined and split style objects.
+type testSplitPayload struct {
+	Head        *types.Checkpoint
+	Withdrawals []*testSplitWithdrawal
+}
+
+func (p *testSplitPayload) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 40 + 4
+	}
+	return 40 + 4 + ssz.SizeSliceOfStaticObjects(p.Withdrawals)
+}
+func (p *testSplitPayload) DefineSSZ(codec *ssz.Codec) { codec.DefineSplit(p) }
+
+func (p *testSplitPayload) EncodeSSZ(enc *ssz.Encoder) {
+	ssz.EncodeStaticObject(enc, p.Head)
+	ssz.EncodeSliceOfStaticObjectsOffset(enc, p.Withdrawals)
+	ssz.EncodeSliceOfStaticObjectsContent(enc, p.Withdrawals)
+}
+func (p *testSplitPayload) DecodeSSZ(dec *ssz.Decoder) {
+	ssz.DecodeStaticObject(dec, &p.Head)
+	ssz.DecodeSliceOfStaticObjectsOffset(dec, &p.Withdrawals)
+	ssz.DecodeSliceOfStaticObjectsContent(dec, &p.Withdrawals, 4)
+}
+func (p *testSplitPayload) HashSSZ(has *ssz.Hasher) {
+	ssz.HashStaticObject(has, p.Head)
+	ssz.HashSliceOfStaticObjects(has, p.Withdrawals, 4)
+}
+
+// testMixedContainer is a codec defined type embedding split style objects.
+type testMixedContainer struct {
+	Payload *testSplitPayload
+	Items   []*testSplitWithdrawal
+}
+
+func (c *testMixedContainer) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8
+	}
+	return 8 + ssz.SizeDynamicObject(c.Payload) + ssz.SizeSliceOfStaticObjects(c.Items)
+}
+func (c *testMixedContainer) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicObjectOffset(codec, &c.Payload)
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &c.Items, 4)
+
+	ssz.DefineDynamicObjectContent(codec, &c.Payload)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &c.Items, 4)
+}
+
+// testPlainPayload is the codec defined equivalent of testSplitPayload.
+type testPlainPayload struct {
+	Head        *types.Checkpoint
+	Withdrawals []*types.Withdrawal
+}
+
+func (p *testPlainPayload) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 40 + 4
+	}
+	return 40 + 4 + ssz.SizeSliceOfStaticObjects(p.Withdrawals)
+}
+func (p *testPlainPayload) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &p.Head)
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &p.Withdrawals, 4)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &p.Withdrawals, 4)
+}
+
+// testPlainContainer is the codec defined equivalent of testMixedContainer.
+type testPlainContainer struct {
+	Payload *testPlainPayload
+	Items   []*types.Withdrawal
+}
+
+func (c *testPlainContainer) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8
+	}
+	return 8 + ssz.SizeDynamicObject(c.Payload) + ssz.SizeSliceOfStaticObjects(c.Items)
+}
+func (c *testPlainContainer) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicObjectOffset(codec, &c.Payload)
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &c.Items, 4)
+
+	ssz.DefineDynamicObjectContent(codec, &c.Payload)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &c.Items, 4)
+}
+
+// Tests that split style and codec defined types can be nested within each other
+// freely, producing the same encodings and hashes as purely codec defined ones.
+func TestSplitObjects(t *testing.T) {
+	mixed := &testMixedContainer{
+		Payload: &testSplitPayload{
+			Head:        &types.Checkpoint{Epoch: 1, Root: types.Hash{0x02}},
+			Withdrawals: []*testSplitWithdrawal{{Index: 3, Address: [20]byte{0x04}}, {Amount: 5}},
+		},
+		Items: []*testSplitWithdrawal{{Validator: 6}},
+	}
+	plain := &testPlainContainer{
+		Payload: &testPlainPayload{
+			Head:        &types.Checkpoint{Epoch: 1, Root: types.Hash{0x02}},
+			Withdrawals: []*types.Withdrawal{{Index: 3, Address: types.Address{0x04}}, {Amount: 5}},
+		},
+		Items: []*types.Withdrawal{{Validator: 6}},
+	}
+	blob := make([]byte, ssz.Size(mixed))
+	if err := ssz.EncodeToBytes(blob, mixed); err != nil {
+		t.Fatalf("failed to encode mixed object: %v", err)
+	}
+	want := make([]byte, ssz.Size(plain))
+	if err := ssz.EncodeToBytes(want, plain); err != nil {
+		t.Fatalf("failed to encode plain object: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Fatalf("encoding mismatch: have %x, want %x", blob, want)
+	}
+	if have, want := ssz.HashSequential(mixed), ssz.HashSequential(plain); have != want {
+		t.Errorf("sequential hash mismatch: have %#x, want %#x", have, want)
+	}
+	if have, want := ssz.HashConcurrent(mixed), ssz.HashSequential(plain); have != want {
+		t.Errorf("concurrent hash mismatch: have %#x, want %#x", have, want)
+	}
+	for _, stream := range []bool{false, true} {
+		decoded := new(testMixedContainer)
+		if stream {
+			if err := ssz.DecodeFromStream(bytes.NewReader(blob), decoded, uint32(len(blob))); err != nil {
+				t.Fatalf("failed to stream decode mixed object: %v", err)
+			}
+		} else if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+			t.Fatalf("failed to decode mixed object: %v", err)
+		}
+		if !reflect.DeepEqual(decoded, mixed) {
+			t.Errorf("stream %v: decoded mismatch: have %+v, want %+v", stream, decoded, mixed)
+		}
+	}
+}
+
+// Tests that lists of static objects can be validated item by item while being
+// decoded, aborting at the first invalid one (or skipping it when resyncing).
+func TestCheckedStaticObjects(t *testing.T) {
+	obj := &testCheckedWithdrawalsType{
+		Withdrawals: []*types.Withdrawal{{Index: 1}, {Index: 2}, {Index: 2}, {Index: 3}, {Index: 1}},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	// Without resyncing, decoding must abort at the first invalid item
+	dec := new(testCheckedWithdrawalsType)
+	err := ssz.DecodeFromBytes(blob, dec)
+	if !errors.Is(err, ssz.ErrInvalidItem) || !errors.Is(err, errTestUnorderedIndex) {
+		t.Fatalf("validation error mismatch: have %v, want %v", err, ssz.ErrInvalidItem)
+	}
+	var derr *ssz.DecodeError
+	if !errors.As(err, &derr) || derr.Kind != ssz.KindInvalidItem {
+		t.Errorf("error kind mismatch: have %v, want %v", err, ssz.KindInvalidItem)
+	}
+	if dec.validated != 3 {
+		t.Errorf("validated item count mismatch: have %d, want 3", dec.validated)
+	}
+	// With resyncing, all invalid items should be reported
+	dec = new(testCheckedWithdrawalsType)
+	err = ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), dec, uint32(len(blob)), &ssz.DecoderConfig{ResyncElements: true})
+
+	var indices []uint32
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var eerr *ssz.ElementError
+		if errors.As(err, &eerr) && errors.Is(eerr, ssz.ErrInvalidItem) {
+			indices = append(indices, eerr.Index)
+		}
+	}
+	if !slices.Equal(indices, []uint32{2, 4}) {
+		t.Errorf("resynced failures mismatch: have %v, want [2 4]", indices)
+	}
+	if dec.validated != 5 {
+		t.Errorf("resynced validated item count mismatch: have %d, want 5", dec.validated)
+	}
+	// Valid lists must decode without any issues
+	obj.Withdrawals = obj.Withdrawals[:2]
+	blob = make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode valid object: %v", err)
+	}
+	if err := ssz.DecodeFromBytes(blob, new(testCheckedWithdrawalsType)); err != nil {
+		t.Errorf("failed to decode valid object: %v", err)
+	}
+}
+
+var errTestUnorderedIndex = errors.New("withdrawal index not increasing")
+
+// testCheckedWithdrawalsType is a container with a list of withdrawals, which
+// must have strictly increasing indices.
+type testCheckedWithdrawalsType struct {
+	Withdrawals []*types.Withdrawal
+
+	validated int // Number of items validated during the last decoding
+}
+
+func (t *testCheckedWithdrawalsType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(4)
+	if !fixed {
+		size += ssz.SizeSliceOfStaticObjects(t.Withdrawals)
+	}
+	return size
+}
+
+func (t *testCheckedWithdrawalsType) DefineSSZ(codec *ssz.Codec) {
+	t.validated = 0
+
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Withdrawals, 16)
+	ssz.DefineSliceOfCheckedStaticObjectsContent(codec, &t.Withdrawals, 16, func(i uint32, item *types.Withdrawal) error {
+		t.validated++
+		if i > 0 && item.Index <= t.Withdrawals[i-1].Index {
+			return fmt.Errorf("%w: %d after %d", errTestUnorderedIndex, item.Index, t.Withdrawals[i-1].Index)
+		}
+		return nil
+	})
+}
+
+// Tests that checked uint256 fields are validated during decoding, with failures
+// classified as invalid values.
+func TestCheckedUint256(t *testing.T) {
+	tests := []struct {
+		fee  *uint256.Int
+		fail bool
+	}{
+		{uint256.NewInt(1), false},
+		{uint256.NewInt(1000), false},
+		{uint256.NewInt(0), true},
+		{uint256.NewInt(1001), true},
+	}
+	for i, tt := range tests {
+		obj := &testCheckedUint256Type{Slot: 1, BaseFee: tt.fee}
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("test %d: failed to encode object: %v", i, err)
+		}
+		for _, stream := range []bool{false, true} {
+			dec := new(testCheckedUint256Type)
+
+			var err error
+			if stream {
+				err = ssz.DecodeFromStream(bytes.NewReader(blob), dec, uint32(len(blob)))
+			} else {
+				err = ssz.DecodeFromBytes(blob, dec)
+			}
+			if !tt.fail {
+				if err != nil {
+					t.Errorf("test %d, stream %v: failed to decode valid object: %v", i, stream, err)
+				} else if !dec.BaseFee.Eq(tt.fee) {
+					t.Errorf("test %d, stream %v: base fee mismatch: have %v, want %v", i, stream, dec.BaseFee, tt.fee)
+				}
+				continue
+			}
+			var derr *ssz.DecodeError
+			if !errors.As(err, &derr) || derr.Kind != ssz.KindInvalidValue {
+				t.Errorf("test %d, stream %v: error mismatch: have %v, want %v", i, stream, err, ssz.ErrInvalidValue)
+			}
+		}
+		// Hashing should be unaffected by the validation
+		if have, want := ssz.HashSequential(obj), ssz.HashSequential(&testPlainUint256Type{Slot: 1, BaseFee: tt.fee}); have != want {
+			t.Errorf("test %d: hash mismatch: have %#x, want %#x", i, have, want)
+		}
+	}
+}
+
+// testCheckedUint256Type is a container with a base fee bounded to (0, 1000].
+type testCheckedUint256Type struct {
+	Slot    uint64
+	BaseFee *uint256.Int
+}
+
+func (t *testCheckedUint256Type) SizeSSZ() uint32 { return 8 + 32 }
+
+func (t *testCheckedUint256Type) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineUint256Checked(codec, &t.BaseFee, ssz.CheckUint256All(
+		ssz.CheckUint256NonZero,
+		ssz.CheckUint256Max(uint256.NewInt(1000)),
+	))
+}
+
+// testPlainUint256Type is the unchecked equivalent of testCheckedUint256Type.
+type testPlainUint256Type struct {
+	Slot    uint64
+	BaseFee *uint256.Int
+}
+
+func (t *testPlainUint256Type) SizeSSZ() uint32 { return 8 + 32 }
+
+func (t *testPlainUint256Type) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineUint256(codec, &t.BaseFee)
+}
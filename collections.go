@@ -0,0 +1,455 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Bound is a type-level constant used to parameterize the size of the generic
+// List and Vector collections, since Go generics cannot be parameterized with
+// integer values directly. Bounds are meant to be implemented on empty structs:
+//
+//	type MaxWithdrawals struct{}
+//
+//	func (MaxWithdrawals) Limit() uint64 { return 16 }
+type Bound interface {
+	// Limit returns the maximum number of items (List) or the exact number of
+	// items (Vector) in a collection.
+	Limit() uint64
+}
+
+// List is a bounded list of items, which by construction cannot grow beyond the
+// limit of the spec it was declared with.
+//
+// The zero value is an empty list ready to use.
+type List[T any, N Bound] struct {
+	items []T
+}
+
+// Len returns the number of items in the list.
+func (l *List[T, N]) Len() int {
+	return len(l.items)
+}
+
+// At returns the item at the given index.
+func (l *List[T, N]) At(i int) T {
+	return l.items[i]
+}
+
+// Set replaces the item at the given index.
+func (l *List[T, N]) Set(i int, item T) {
+	l.items[i] = item
+}
+
+// Items returns the items in the list. The returned slice must not be appended
+// to, as that would circumvent the list's limit.
+func (l *List[T, N]) Items() []T {
+	return l.items
+}
+
+// Append adds new items to the end of the list, or returns an error if the list
+// would exceed its limit.
+func (l *List[T, N]) Append(items ...T) error {
+	var bound N
+	if limit := bound.Limit(); uint64(len(l.items)+len(items)) > limit {
+		return fmt.Errorf("%w: appending %d to %d, max %d", ErrMaxItemsExceeded, len(items), len(l.items), limit)
+	}
+	l.items = append(l.items, items...)
+	return nil
+}
+
+// Reset removes all items from the list, retaining its capacity for reuse.
+func (l *List[T, N]) Reset() {
+	clear(l.items)
+	l.items = l.items[:0]
+}
+
+// Vector is a fixed-size list of items, which by construction always contains
+// exactly the number of items of the spec it was declared with.
+//
+// The zero value is a vector of zero items ready to use.
+type Vector[T any, N Bound] struct {
+	items []T
+}
+
+// Len returns the number of items in the vector.
+func (v *Vector[T, N]) Len() int {
+	var bound N
+	return int(bound.Limit())
+}
+
+// At returns the item at the given index.
+func (v *Vector[T, N]) At(i int) T {
+	return v.Items()[i]
+}
+
+// Set replaces the item at the given index.
+func (v *Vector[T, N]) Set(i int, item T) {
+	v.Items()[i] = item
+}
+
+// Items returns the items in the vector.
+func (v *Vector[T, N]) Items() []T {
+	if v.items == nil {
+		var bound N
+		v.items = make([]T, bound.Limit())
+	}
+	return v.items
+}
+
+// DefineListOfUint64sOffset defines the next field as a bounded list of uint64s.
+func DefineListOfUint64sOffset[T ~uint64, N Bound](c *Codec, l *List[T, N]) {
+	var bound N
+	DefineSliceOfUint64sOffset(c, &l.items, bound.Limit())
+}
+
+// DefineListOfUint64sContent defines the next field as a bounded list of uint64s.
+func DefineListOfUint64sContent[T ~uint64, N Bound](c *Codec, l *List[T, N]) {
+	var bound N
+	DefineSliceOfUint64sContent(c, &l.items, bound.Limit())
+}
+
+// DefineListOfStaticBytesOffset defines the next field as a bounded list of
+// static binary blobs.
+func DefineListOfStaticBytesOffset[T commonBytesLengths, N Bound](c *Codec, l *List[T, N]) {
+	var bound N
+	DefineSliceOfStaticBytesOffset(c, &l.items, bound.Limit())
+}
+
+// DefineListOfStaticBytesContent defines the next field as a bounded list of
+// static binary blobs.
+func DefineListOfStaticBytesContent[T commonBytesLengths, N Bound](c *Codec, l *List[T, N]) {
+	var bound N
+	DefineSliceOfStaticBytesContent(c, &l.items, bound.Limit())
+}
+
+// DefineListOfStaticObjectsOffset defines the next field as a bounded list of
+// static ssz objects.
+func DefineListOfStaticObjectsOffset[T newableStaticObject[U], U any, N Bound](c *Codec, l *List[T, N]) {
+	var bound N
+	DefineSliceOfStaticObjectsOffset(c, &l.items, bound.Limit())
+}
+
+// DefineListOfStaticObjectsContent defines the next field as a bounded list of
+// static ssz objects.
+func DefineListOfStaticObjectsContent[T newableStaticObject[U], U any, N Bound](c *Codec, l *List[T, N]) {
+	var bound N
+	DefineSliceOfStaticObjectsContent(c, &l.items, bound.Limit())
+}
+
+// DefineListOfDynamicObjectsOffset defines the next field as a bounded list of
+// dynamic ssz objects.
+func DefineListOfDynamicObjectsOffset[T newableDynamicObject[U], U any, N Bound](c *Codec, l *List[T, N]) {
+	var bound N
+	DefineSliceOfDynamicObjectsOffset(c, &l.items, bound.Limit())
+}
+
+// DefineListOfDynamicObjectsContent defines the next field as a bounded list of
+// dynamic ssz objects.
+func DefineListOfDynamicObjectsContent[T newableDynamicObject[U], U any, N Bound](c *Codec, l *List[T, N]) {
+	var bound N
+	DefineSliceOfDynamicObjectsContent(c, &l.items, bound.Limit())
+}
+
+// DefineVectorOfUint64s defines the next field as a fixed-size vector of uint64s.
+func DefineVectorOfUint64s[T ~uint64, N Bound](c *Codec, v *Vector[T, N]) {
+	if c.enc != nil {
+		EncodeVectorOfUint64s(c.enc, v)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeVectorOfUint64s(c.dec, v)
+		return
+	}
+	if c.fmt != nil {
+		items := v.Items()
+		c.fmt.line(v, fmt.Sprintf("%s (%d items)", formatUint64s(items), len(items)))
+		return
+	}
+	HashVectorOfUint64s(c.has, v)
+}
+
+// DefineVectorOfStaticBytes defines the next field as a fixed-size vector of
+// static binary blobs.
+func DefineVectorOfStaticBytes[T commonBytesLengths, N Bound](c *Codec, v *Vector[T, N]) {
+	if c.enc != nil {
+		EncodeVectorOfStaticBytes(c.enc, v)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeVectorOfStaticBytes(c.dec, v)
+		return
+	}
+	if c.fmt != nil {
+		items := v.Items()
+		c.fmt.items(v, len(items), "", func(i int) string { return formatStaticBytes(arrayBytes(&items[i])) })
+		return
+	}
+	HashVectorOfStaticBytes(c.has, v)
+}
+
+// DefineVectorOfStaticObjects defines the next field as a fixed-size vector of
+// static ssz objects. Any nil items in the vector are instantiated as empty.
+func DefineVectorOfStaticObjects[T newableStaticObject[U], U any, N Bound](c *Codec, v *Vector[T, N]) {
+	if c.fmt != nil {
+		items := v.Items()
+		c.fmt.objects(v, len(items), "", func(i int) Object { return items[i] })
+		return
+	}
+	if c.enc != nil {
+		if c.hashing() {
+			c.has.descendLayer()
+		}
+		EncodeVectorOfStaticObjects(c.enc, v)
+		if c.hashing() {
+			c.has.ascendLayer(0)
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeVectorOfStaticObjects(c.dec, v)
+		return
+	}
+	HashVectorOfStaticObjects(c.has, v)
+}
+
+// EncodeListOfUint64sOffset serializes a bounded list of uint64s.
+func EncodeListOfUint64sOffset[T ~uint64, N Bound](enc *Encoder, l *List[T, N]) {
+	EncodeSliceOfUint64sOffset(enc, l.items)
+}
+
+// EncodeListOfUint64sContent serializes a bounded list of uint64s.
+func EncodeListOfUint64sContent[T ~uint64, N Bound](enc *Encoder, l *List[T, N]) {
+	EncodeSliceOfUint64sContent(enc, l.items)
+}
+
+// EncodeListOfStaticBytesOffset serializes a bounded list of static binary blobs.
+func EncodeListOfStaticBytesOffset[T commonBytesLengths, N Bound](enc *Encoder, l *List[T, N]) {
+	EncodeSliceOfStaticBytesOffset(enc, l.items)
+}
+
+// EncodeListOfStaticBytesContent serializes a bounded list of static binary blobs.
+func EncodeListOfStaticBytesContent[T commonBytesLengths, N Bound](enc *Encoder, l *List[T, N]) {
+	EncodeSliceOfStaticBytesContent(enc, l.items)
+}
+
+// EncodeListOfStaticObjectsOffset serializes a bounded list of static ssz objects.
+func EncodeListOfStaticObjectsOffset[T StaticObject, N Bound](enc *Encoder, l *List[T, N]) {
+	EncodeSliceOfStaticObjectsOffset(enc, l.items)
+}
+
+// EncodeListOfStaticObjectsContent serializes a bounded list of static ssz objects.
+func EncodeListOfStaticObjectsContent[T StaticObject, N Bound](enc *Encoder, l *List[T, N]) {
+	EncodeSliceOfStaticObjectsContent(enc, l.items)
+}
+
+// EncodeListOfDynamicObjectsOffset serializes a bounded list of dynamic ssz objects.
+func EncodeListOfDynamicObjectsOffset[T DynamicObject, N Bound](enc *Encoder, l *List[T, N]) {
+	EncodeSliceOfDynamicObjectsOffset(enc, l.items)
+}
+
+// EncodeListOfDynamicObjectsContent serializes a bounded list of dynamic ssz objects.
+func EncodeListOfDynamicObjectsContent[T DynamicObject, N Bound](enc *Encoder, l *List[T, N]) {
+	EncodeSliceOfDynamicObjectsContent(enc, l.items)
+}
+
+// EncodeVectorOfUint64s serializes a fixed-size vector of uint64s.
+func EncodeVectorOfUint64s[T ~uint64, N Bound](enc *Encoder, v *Vector[T, N]) {
+	EncodeSliceOfUint64sContent(enc, v.Items())
+}
+
+// EncodeVectorOfStaticBytes serializes a fixed-size vector of static binary blobs.
+func EncodeVectorOfStaticBytes[T commonBytesLengths, N Bound](enc *Encoder, v *Vector[T, N]) {
+	EncodeUnsafeArrayOfStaticBytes(enc, v.Items())
+}
+
+// EncodeVectorOfStaticObjects serializes a fixed-size vector of static ssz objects.
+// Any nil items in the vector are instantiated as empty.
+func EncodeVectorOfStaticObjects[T newableStaticObject[U], U any, N Bound](enc *Encoder, v *Vector[T, N]) {
+	for _, item := range fillVectorOfStaticObjects(v) {
+		EncodeStaticObject(enc, item)
+	}
+}
+
+// DecodeListOfUint64sOffset parses a bounded list of uint64s.
+func DecodeListOfUint64sOffset[T ~uint64, N Bound](dec *Decoder, l *List[T, N]) {
+	DecodeSliceOfUint64sOffset(dec, &l.items)
+}
+
+// DecodeListOfUint64sContent parses a bounded list of uint64s.
+func DecodeListOfUint64sContent[T ~uint64, N Bound](dec *Decoder, l *List[T, N]) {
+	var bound N
+	DecodeSliceOfUint64sContent(dec, &l.items, bound.Limit())
+}
+
+// DecodeListOfStaticBytesOffset parses a bounded list of static binary blobs.
+func DecodeListOfStaticBytesOffset[T commonBytesLengths, N Bound](dec *Decoder, l *List[T, N]) {
+	DecodeSliceOfStaticBytesOffset(dec, &l.items)
+}
+
+// DecodeListOfStaticBytesContent parses a bounded list of static binary blobs.
+func DecodeListOfStaticBytesContent[T commonBytesLengths, N Bound](dec *Decoder, l *List[T, N]) {
+	var bound N
+	DecodeSliceOfStaticBytesContent(dec, &l.items, bound.Limit())
+}
+
+// DecodeListOfStaticObjectsOffset parses a bounded list of static ssz objects.
+func DecodeListOfStaticObjectsOffset[T newableStaticObject[U], U any, N Bound](dec *Decoder, l *List[T, N]) {
+	DecodeSliceOfStaticObjectsOffset(dec, &l.items)
+}
+
+// DecodeListOfStaticObjectsContent parses a bounded list of static ssz objects.
+func DecodeListOfStaticObjectsContent[T newableStaticObject[U], U any, N Bound](dec *Decoder, l *List[T, N]) {
+	var bound N
+	DecodeSliceOfStaticObjectsContent(dec, &l.items, bound.Limit())
+}
+
+// DecodeListOfDynamicObjectsOffset parses a bounded list of dynamic ssz objects.
+func DecodeListOfDynamicObjectsOffset[T newableDynamicObject[U], U any, N Bound](dec *Decoder, l *List[T, N]) {
+	DecodeSliceOfDynamicObjectsOffset(dec, &l.items)
+}
+
+// DecodeListOfDynamicObjectsContent parses a bounded list of dynamic ssz objects.
+func DecodeListOfDynamicObjectsContent[T newableDynamicObject[U], U any, N Bound](dec *Decoder, l *List[T, N]) {
+	var bound N
+	DecodeSliceOfDynamicObjectsContent(dec, &l.items, bound.Limit())
+}
+
+// DecodeVectorOfUint64s parses a fixed-size vector of uint64s.
+func DecodeVectorOfUint64s[T ~uint64, N Bound](dec *Decoder, v *Vector[T, N]) {
+	items := v.Items()
+	if dec.tracer != nil {
+		dec.traceStatic(uint32(8 * len(items)))
+		dec.traceDescend()
+		defer dec.traceAscend()
+	}
+	for i := range items {
+		DecodeUint64(dec, &items[i])
+	}
+}
+
+// DecodeVectorOfStaticBytes parses a fixed-size vector of static binary blobs.
+func DecodeVectorOfStaticBytes[T commonBytesLengths, N Bound](dec *Decoder, v *Vector[T, N]) {
+	DecodeUnsafeArrayOfStaticBytes(dec, v.Items())
+}
+
+// DecodeVectorOfStaticObjects parses a fixed-size vector of static ssz objects.
+func DecodeVectorOfStaticObjects[T newableStaticObject[U], U any, N Bound](dec *Decoder, v *Vector[T, N]) {
+	items := v.Items()
+	if dec.tracer != nil && len(items) > 0 {
+		dec.traceStatic(uint32(len(items)) * T(new(U)).SizeSSZ())
+		dec.traceDescend()
+		defer dec.traceAscend()
+	}
+	for i := range items {
+		DecodeStaticObject(dec, &items[i])
+	}
+}
+
+// HashListOfUint64s hashes a bounded list of uint64s.
+func HashListOfUint64s[T ~uint64, N Bound](h *Hasher, l *List[T, N]) {
+	var bound N
+	HashSliceOfUint64s(h, l.items, bound.Limit())
+}
+
+// HashListOfStaticBytes hashes a bounded list of static binary blobs.
+func HashListOfStaticBytes[T commonBytesLengths, N Bound](h *Hasher, l *List[T, N]) {
+	var bound N
+	HashSliceOfStaticBytes(h, l.items, bound.Limit())
+}
+
+// HashListOfStaticObjects hashes a bounded list of static ssz objects.
+func HashListOfStaticObjects[T StaticObject, N Bound](h *Hasher, l *List[T, N]) {
+	var bound N
+	HashSliceOfStaticObjects(h, l.items, bound.Limit())
+}
+
+// HashListOfDynamicObjects hashes a bounded list of dynamic ssz objects.
+func HashListOfDynamicObjects[T DynamicObject, N Bound](h *Hasher, l *List[T, N]) {
+	var bound N
+	HashSliceOfDynamicObjects(h, l.items, bound.Limit())
+}
+
+// HashVectorOfUint64s hashes a fixed-size vector of uint64s.
+func HashVectorOfUint64s[T ~uint64, N Bound](h *Hasher, v *Vector[T, N]) {
+	items := v.Items()
+	h.descendLayer()
+
+	var buffer [32]byte
+	for len(items) > 4 {
+		binary.LittleEndian.PutUint64(buffer[:], uint64(items[0]))
+		binary.LittleEndian.PutUint64(buffer[8:], uint64(items[1]))
+		binary.LittleEndian.PutUint64(buffer[16:], uint64(items[2]))
+		binary.LittleEndian.PutUint64(buffer[24:], uint64(items[3]))
+
+		h.insertChunk(buffer, 0)
+		items = items[4:]
+	}
+	if len(items) > 0 {
+		buffer = [32]byte{}
+		for i := 0; i < len(items); i++ {
+			binary.LittleEndian.PutUint64(buffer[i<<3:], uint64(items[i]))
+		}
+		h.insertChunk(buffer, 0)
+	}
+	h.ascendLayer(0)
+}
+
+// HashVectorOfStaticBytes hashes a fixed-size vector of static binary blobs.
+func HashVectorOfStaticBytes[T commonBytesLengths, N Bound](h *Hasher, v *Vector[T, N]) {
+	HashUnsafeArrayOfStaticBytes(h, v.Items())
+}
+
+// HashVectorOfStaticObjects hashes a fixed-size vector of static ssz objects.
+// Any nil items in the vector are instantiated as empty.
+func HashVectorOfStaticObjects[T newableStaticObject[U], U any, N Bound](h *Hasher, v *Vector[T, N]) {
+	h.descendLayer()
+	for _, item := range fillVectorOfStaticObjects(v) {
+		HashStaticObject(h, item)
+	}
+	h.ascendLayer(0)
+}
+
+// SizeListOfUint64s returns the serialized size of the dynamic part of a bounded
+// list of uint64s.
+func SizeListOfUint64s[T ~uint64, N Bound](l *List[T, N]) uint32 {
+	return SizeSliceOfUint64s(l.items)
+}
+
+// SizeListOfStaticBytes returns the serialized size of the dynamic part of a
+// bounded list of static binary blobs.
+func SizeListOfStaticBytes[T commonBytesLengths, N Bound](l *List[T, N]) uint32 {
+	return SizeSliceOfStaticBytes(l.items)
+}
+
+// SizeListOfStaticObjects returns the serialized size of the dynamic part of a
+// bounded list of static ssz objects.
+func SizeListOfStaticObjects[T StaticObject, N Bound](l *List[T, N]) uint32 {
+	return SizeSliceOfStaticObjects(l.items)
+}
+
+// SizeListOfDynamicObjects returns the serialized size of the dynamic part of a
+// bounded list of dynamic ssz objects.
+func SizeListOfDynamicObjects[T DynamicObject, N Bound](l *List[T, N]) uint32 {
+	return SizeSliceOfDynamicObjects(l.items)
+}
+
+// fillVectorOfStaticObjects returns the items of a vector of static objects,
+// instantiating any nil ones as empty.
+func fillVectorOfStaticObjects[T newableStaticObject[U], U any, N Bound](v *Vector[T, N]) []T {
+	items := v.Items()
+	for i := range items {
+		if items[i] == nil {
+			items[i] = new(U)
+		}
+	}
+	return items
+}
@@ -0,0 +1,223 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package compress implements pluggable compression around the SSZ stream
+// encoder and decoder.
+//
+// The p2p protocols mandate snappy (framed for req/resp, raw blocks for gossip),
+// both of which are built in, along with zstd for archival pipelines. Other
+// algorithms can be plugged in by implementing the Compressor interface, e.g.
+// gzip from the standard library:
+//
+//	type gzipCompressor struct{}
+//
+//	func (gzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+//		return gzip.NewWriter(w), nil
+//	}
+//
+//	func (gzipCompressor) Decompress(r io.Reader, limit uint32) (io.ReadCloser, error) {
+//		return gzip.NewReader(r)
+//	}
+//
+// Whatever the algorithm, decompression is cut off at the maximum size of the
+// object being decoded, so compressed inputs cannot blow up memory use.
+package compress
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/golang/snappy"
+	"github.com/karalabe/ssz"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	// ErrDecompressedTooLarge is returned if the decompressed data exceeds the
+	// size permitted for the object being decoded.
+	ErrDecompressedTooLarge = errors.New("compress: decompressed size exceeds limit")
+
+	// ErrNoSizeLimit is returned if a dynamic object is to be decoded without
+	// a maximum size, neither given by the caller nor declared by the object.
+	ErrNoSizeLimit = errors.New("compress: no size limit for dynamic object")
+)
+
+// Compressor is a compression algorithm that can be plugged around the stream
+// encoder and decoder.
+type Compressor interface {
+	// Compress wraps a writer with a compressor. Closing the returned writer must
+	// flush all pending data, but must not close the underlying writer.
+	Compress(w io.Writer) (io.WriteCloser, error)
+
+	// Decompress wraps a reader with a decompressor. The limit is the maximum
+	// number of decompressed bytes the caller will accept; algorithms should
+	// use it to reject inputs early if they can, but they are not required to
+	// enforce it.
+	Decompress(r io.Reader, limit uint32) (io.ReadCloser, error)
+}
+
+var (
+	// Snappy is the framed snappy compression, as used by the ssz_snappy req/resp
+	// encoding of the p2p protocol.
+	Snappy Compressor = snappyFramed{}
+
+	// SnappyRaw is the raw (block) snappy compression, as used by the ssz_snappy
+	// gossip encoding of the p2p protocol. Since blocks cannot be streamed, the
+	// data is buffered in full.
+	SnappyRaw Compressor = snappyRaw{}
+
+	// Zstd is the zstd compression, trading some speed for a better ratio than
+	// snappy (e.g. for archiving data).
+	Zstd Compressor = zstdCompressor{}
+)
+
+// Encode serializes the object and streams it compressed into the writer.
+func Encode(w io.Writer, obj ssz.Object, c Compressor) error {
+	cw, err := c.Compress(w)
+	if err != nil {
+		return err
+	}
+	if err := ssz.EncodeToStream(cw, obj); err != nil {
+		cw.Close()
+		return err
+	}
+	return cw.Close()
+}
+
+// Decode decompresses the reader's data and parses it into the object.
+//
+// Static objects are required to decompress into exactly their size, dynamic
+// ones into at most maxSize bytes. Dynamic objects implementing ssz.MaxSizer are
+// also limited to their declared maximum size, which is used on its own if the
+// maxSize is 0. Dynamic objects without either limit are rejected. Decompression
+// is aborted as soon as the limit is exceeded.
+func Decode(r io.Reader, obj ssz.Object, c Compressor, maxSize uint32) error {
+	limit := maxSize
+	switch v := obj.(type) {
+	case ssz.StaticObject:
+		limit = v.SizeSSZ()
+	case ssz.MaxSizer:
+		if limit == 0 || v.MaxSizeSSZ() < limit {
+			limit = v.MaxSizeSSZ()
+		}
+	default:
+		if limit == 0 {
+			return fmt.Errorf("%w: %T", ErrNoSizeLimit, obj)
+		}
+	}
+	cr, err := c.Decompress(r, limit)
+	if err != nil {
+		return err
+	}
+	defer cr.Close()
+
+	blob, err := io.ReadAll(io.LimitReader(cr, int64(limit)+1))
+	if err != nil {
+		return err
+	}
+	if uint64(len(blob)) > uint64(limit) {
+		return fmt.Errorf("%w: more than %d bytes", ErrDecompressedTooLarge, limit)
+	}
+	return ssz.DecodeFromBytes(blob, obj)
+}
+
+// snappyFramed is the framed snappy compressor.
+type snappyFramed struct{}
+
+// Compress implements Compressor.
+func (snappyFramed) Compress(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+// Decompress implements Compressor.
+func (snappyFramed) Decompress(r io.Reader, limit uint32) (io.ReadCloser, error) {
+	return io.NopCloser(snappy.NewReader(r)), nil
+}
+
+// snappyRaw is the raw snappy compressor.
+type snappyRaw struct{}
+
+// Compress implements Compressor.
+func (snappyRaw) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &snappyRawWriter{w: w}, nil
+}
+
+// Decompress implements Compressor.
+func (snappyRaw) Decompress(r io.Reader, limit uint32) (io.ReadCloser, error) {
+	// Blocks can only be decoded in full, but their compressed size is bounded
+	// by the decompressed one, so don't read more than the worst case (or the
+	// largest block, if the limit is beyond what snappy can represent)
+	maxLen := int64(snappy.MaxEncodedLen(int(limit)))
+	if maxLen < 0 {
+		maxLen = math.MaxUint32
+	}
+	data, err := io.ReadAll(io.LimitReader(r, maxLen+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxLen {
+		return nil, fmt.Errorf("%w: more than %d compressed bytes, limit %d", ErrDecompressedTooLarge, maxLen, limit)
+	}
+	size, err := snappy.DecodedLen(data)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(size) > uint64(limit) {
+		return nil, fmt.Errorf("%w: %d bytes, limit %d", ErrDecompressedTooLarge, size, limit)
+	}
+	blob, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(blob)), nil
+}
+
+// zstdWindowSize is the window size of the zstd encoder at its default level.
+const zstdWindowSize = 8 << 20
+
+// zstdCompressor is the zstd compressor.
+type zstdCompressor struct{}
+
+// Compress implements Compressor.
+func (zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+}
+
+// Decompress implements Compressor.
+func (zstdCompressor) Decompress(r io.Reader, limit uint32) (io.ReadCloser, error) {
+	// Frames may declare a larger window than the data they carry (the encoder
+	// defaults to 8MB), so only tighten the window beyond that to the limit
+	window := uint64(limit)
+	if window < zstdWindowSize {
+		window = zstdWindowSize
+	}
+	dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxWindow(window))
+	if err != nil {
+		return nil, err
+	}
+	return dec.IOReadCloser(), nil
+}
+
+// snappyRawWriter buffers all the written data and compresses it into a single
+// snappy block when closed.
+type snappyRawWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// Write implements io.Writer, buffering the data.
+func (w *snappyRawWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// Close implements io.Closer, compressing and flushing the buffered data.
+func (w *snappyRawWriter) Close() error {
+	_, err := w.w.Write(snappy.Encode(nil, w.buf))
+	w.buf = nil
+	return err
+}
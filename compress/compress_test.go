@@ -0,0 +1,165 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package compress_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/compress"
+	"github.com/karalabe/ssz/sszcommon"
+)
+
+// gzipCompressor is a third party algorithm plugged into the compressor API.
+type gzipCompressor struct{}
+
+func (gzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+func (gzipCompressor) Decompress(r io.Reader, limit uint32) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// Tests that objects round trip through the built in and plugged compressors
+// and that the decompressed size limits are enforced.
+func TestCompressors(t *testing.T) {
+	compressors := map[string]compress.Compressor{
+		"snappy":     compress.Snappy,
+		"snappy-raw": compress.SnappyRaw,
+		"zstd":       compress.Zstd,
+		"gzip":       gzipCompressor{},
+	}
+	for name, c := range compressors {
+		// Round trip a dynamic object and check the limits on it
+		requests := &sszcommon.ExecutionRequests{
+			Withdrawals: []*sszcommon.WithdrawalRequest{{Amount: 1}, {Amount: 2}},
+		}
+		buf := new(bytes.Buffer)
+		if err := compress.Encode(buf, requests, c); err != nil {
+			t.Fatalf("%s: failed to encode dynamic object: %v", name, err)
+		}
+		dec := new(sszcommon.ExecutionRequests)
+		if err := compress.Decode(bytes.NewReader(buf.Bytes()), dec, c, ssz.Size(requests)); err != nil {
+			t.Fatalf("%s: failed to decode dynamic object: %v", name, err)
+		}
+		if have, want := ssz.HashSequential(dec), ssz.HashSequential(requests); have != want {
+			t.Errorf("%s: dynamic object mismatch: have %#x, want %#x", name, have, want)
+		}
+		err := compress.Decode(bytes.NewReader(buf.Bytes()), dec, c, ssz.Size(requests)-1)
+		if !errors.Is(err, compress.ErrDecompressedTooLarge) {
+			t.Errorf("%s: dynamic limit error mismatch: have %v, want %v", name, err, compress.ErrDecompressedTooLarge)
+		}
+		// Ensure static objects are limited to their own size
+		buf.Reset()
+		cw, _ := c.Compress(buf)
+		cw.Write(make([]byte, 1024*1024))
+		cw.Close()
+
+		err = compress.Decode(bytes.NewReader(buf.Bytes()), new(sszcommon.Checkpoint), c, 1024*1024)
+		if !errors.Is(err, compress.ErrDecompressedTooLarge) {
+			t.Errorf("%s: static limit error mismatch: have %v, want %v", name, err, compress.ErrDecompressedTooLarge)
+		}
+	}
+}
+
+// maxSizedRequests is a dynamic object declaring its maximum size.
+type maxSizedRequests struct {
+	sszcommon.ExecutionRequests
+	limit uint32
+}
+
+func (r *maxSizedRequests) MaxSizeSSZ() uint32 { return r.limit }
+
+// Tests that the declared maximum size of dynamic objects limits decompression,
+// both on its own and tightening an explicit limit.
+func TestMaxSizerLimit(t *testing.T) {
+	requests := &sszcommon.ExecutionRequests{
+		Withdrawals: []*sszcommon.WithdrawalRequest{{Amount: 1}, {Amount: 2}},
+	}
+	buf := new(bytes.Buffer)
+	if err := compress.Encode(buf, requests, compress.Snappy); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	size := ssz.Size(requests)
+
+	for i, tt := range []struct {
+		limit   uint32
+		maxSize uint32
+		fail    bool
+	}{
+		{limit: size, maxSize: 0},
+		{limit: size - 1, maxSize: 0, fail: true},
+		{limit: size, maxSize: size - 1, fail: true},
+		{limit: size - 1, maxSize: size, fail: true},
+		{limit: size, maxSize: size},
+	} {
+		err := compress.Decode(bytes.NewReader(buf.Bytes()), &maxSizedRequests{limit: tt.limit}, compress.Snappy, tt.maxSize)
+		switch {
+		case tt.fail && !errors.Is(err, compress.ErrDecompressedTooLarge):
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, compress.ErrDecompressedTooLarge)
+		case !tt.fail && err != nil:
+			t.Errorf("test %d: failed to decode object: %v", i, err)
+		}
+	}
+}
+
+// Tests that the snappy compressors produce the p2p wire formats.
+func TestSnappyWireFormats(t *testing.T) {
+	obj := &sszcommon.Checkpoint{Epoch: 1, Root: sszcommon.Hash{0x02}}
+	blob := make([]byte, ssz.Size(obj))
+	ssz.EncodeToBytes(blob, obj)
+
+	buf := new(bytes.Buffer)
+	if err := compress.Encode(buf, obj, compress.SnappyRaw); err != nil {
+		t.Fatalf("failed to encode raw: %v", err)
+	}
+	if want := snappy.Encode(nil, blob); !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("raw encoding mismatch: have %x, want %x", buf.Bytes(), want)
+	}
+	buf.Reset()
+	if err := compress.Encode(buf, obj, compress.Snappy); err != nil {
+		t.Fatalf("failed to encode framed: %v", err)
+	}
+	have, err := io.ReadAll(snappy.NewReader(buf))
+	if err != nil {
+		t.Fatalf("failed to decompress framed: %v", err)
+	}
+	if !bytes.Equal(have, blob) {
+		t.Errorf("framed encoding mismatch: have %x, want %x", have, blob)
+	}
+}
+
+// Tests that dynamic objects without any size limit are rejected instead of
+// being decompressed unbounded.
+func TestDecodeNoSizeLimit(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := compress.Encode(buf, new(sszcommon.ExecutionRequests), compress.Snappy); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	err := compress.Decode(bytes.NewReader(buf.Bytes()), new(sszcommon.ExecutionRequests), compress.Snappy, 0)
+	if !errors.Is(err, compress.ErrNoSizeLimit) {
+		t.Errorf("error mismatch: have %v, want %v", err, compress.ErrNoSizeLimit)
+	}
+}
+
+// Tests that raw snappy blocks are not read beyond the largest compressed size
+// the decompressed limit permits.
+func TestSnappyRawReadLimit(t *testing.T) {
+	r := bytes.NewReader(make([]byte, 1024*1024))
+
+	err := compress.Decode(r, new(sszcommon.Checkpoint), compress.SnappyRaw, 0)
+	if !errors.Is(err, compress.ErrDecompressedTooLarge) {
+		t.Errorf("error mismatch: have %v, want %v", err, compress.ErrDecompressedTooLarge)
+	}
+	if read, limit := r.Size()-int64(r.Len()), int64(snappy.MaxEncodedLen(int(ssz.Size(new(sszcommon.Checkpoint)))))+1; read > limit {
+		t.Errorf("read too much: have %d, want at most %d", read, limit)
+	}
+}
@@ -0,0 +1,113 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// SizeFn returns the static SSZ size of a custom leaf type.
+type SizeFn[T any] func() uint32
+
+// EncodeFn serializes a custom leaf type, usually via the Encode* helpers.
+type EncodeFn[T any] func(enc *Encoder, v *T)
+
+// DecodeFn parses a custom leaf type, usually via the Decode* helpers.
+type DecodeFn[T any] func(dec *Decoder, v *T)
+
+// HashFn hashes a custom leaf type, usually via the Hash* helpers. It must hash
+// the value as a single field (i.e. one Hash* call or a single sub-tree).
+type HashFn[T any] func(h *Hasher, v *T)
+
+// CustomCodec is the set of methods needed to plug a custom static leaf type
+// into the ssz codec (e.g. a `Wei` type that should be treated as an uint256),
+// without having to wrap it into a struct implementing ssz.StaticObject.
+type CustomCodec[T any] struct {
+	Size   SizeFn[T]
+	Encode EncodeFn[T]
+	Decode DecodeFn[T]
+	Hash   HashFn[T]
+}
+
+// customCodecs is the registry of custom codecs, mapping reflect.Type keys to
+// *CustomCodec[T] values.
+var customCodecs sync.Map
+
+// RegisterCustom registers the codec methods to use for the custom type T. Any
+// DefineCustom call on values of type T will route through them.
+//
+// Registration is meant to be done during package initialization. Registering
+// a type twice or with missing methods panics.
+func RegisterCustom[T any](codec CustomCodec[T]) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if codec.Size == nil || codec.Encode == nil || codec.Decode == nil || codec.Hash == nil {
+		panic(fmt.Sprintf("incomplete custom codec for %v", typ))
+	}
+	if _, dup := customCodecs.LoadOrStore(typ, &codec); dup {
+		panic(fmt.Sprintf("duplicate custom codec for %v", typ))
+	}
+}
+
+// customCodec retrieves the registered custom codec for type T.
+func customCodec[T any]() *CustomCodec[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	codec, ok := customCodecs.Load(typ)
+	if !ok {
+		panic(fmt.Sprintf("no custom codec registered for %v", typ))
+	}
+	return codec.(*CustomCodec[T])
+}
+
+// DefineCustom defines the next field as a custom static leaf type, which needs
+// to be registered via RegisterCustom beforehand.
+func DefineCustom[T any](c *Codec, v *T) {
+	if c.enc != nil {
+		EncodeCustom(c.enc, v)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeCustom(c.dec, v)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(v, fmt.Sprintf("%v", *v))
+		return
+	}
+	HashCustom(c.has, v)
+}
+
+// EncodeCustom serializes a custom static leaf type.
+func EncodeCustom[T any](enc *Encoder, v *T) {
+	customCodec[T]().Encode(enc, v)
+}
+
+// DecodeCustom parses a custom static leaf type.
+func DecodeCustom[T any](dec *Decoder, v *T) {
+	if dec.err != nil {
+		return
+	}
+	codec := customCodec[T]()
+	if dec.tracer != nil {
+		dec.traceStatic(codec.Size())
+	}
+	dec.traceDescend()
+	codec.Decode(dec, v)
+	dec.traceAscend()
+}
+
+// HashCustom hashes a custom static leaf type.
+func HashCustom[T any](h *Hasher, v *T) {
+	customCodec[T]().Hash(h, v)
+}
+
+// SizeCustom returns the serialized size of a custom static leaf type.
+func SizeCustom[T any]() uint32 {
+	return customCodec[T]().Size()
+}
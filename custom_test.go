@@ -0,0 +1,89 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// Tests that custom leaf types registered with the codec are encoded, decoded
+// and hashed via their registered methods.
+func TestCustomCodec(t *testing.T) {
+	obj := &testCustomType{Slot: 7}
+	(*big.Int)(&obj.Balance).SetUint64(1_000_000_000_000_000_000)
+
+	raw := &testCustomRawType{Slot: 7, Balance: new(big.Int).SetUint64(1_000_000_000_000_000_000)}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	want := make([]byte, ssz.Size(raw))
+	if err := ssz.EncodeToBytes(want, raw); err != nil {
+		t.Fatalf("failed to encode raw object: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Fatalf("encoding mismatch: have %x, want %x", blob, want)
+	}
+	dec := new(testCustomType)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if (*big.Int)(&dec.Balance).Cmp(raw.Balance) != 0 || dec.Slot != obj.Slot {
+		t.Errorf("decoded mismatch: have %v, want %v", (*big.Int)(&dec.Balance), raw.Balance)
+	}
+	if have, want := ssz.HashSequential(obj), ssz.HashSequential(raw); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+}
+
+type testWei big.Int
+
+func init() {
+	ssz.RegisterCustom(ssz.CustomCodec[testWei]{
+		Size: func() uint32 { return 32 },
+		Encode: func(enc *ssz.Encoder, v *testWei) {
+			ssz.EncodeUint256BigInt(enc, (*big.Int)(v))
+		},
+		Decode: func(dec *ssz.Decoder, v *testWei) {
+			var n *big.Int
+			ssz.DecodeUint256BigInt(dec, &n)
+			if n != nil {
+				(*big.Int)(v).Set(n)
+			}
+		},
+		Hash: func(h *ssz.Hasher, v *testWei) {
+			ssz.HashUint256BigInt(h, (*big.Int)(v))
+		},
+	})
+}
+
+type testCustomType struct {
+	Slot    uint64
+	Balance testWei
+}
+
+func (t *testCustomType) SizeSSZ() uint32 { return 8 + ssz.SizeCustom[testWei]() }
+
+func (t *testCustomType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineCustom(codec, &t.Balance)
+}
+
+type testCustomRawType struct {
+	Slot    uint64
+	Balance *big.Int
+}
+
+func (t *testCustomRawType) SizeSSZ() uint32 { return 40 }
+
+func (t *testCustomRawType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineUint256BigInt(codec, &t.Balance)
+}
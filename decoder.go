@@ -6,14 +6,17 @@ package ssz
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"math/bits"
-	"unsafe"
+	"reflect"
+	"runtime"
+	"sync/atomic"
 
 	"github.com/holiman/uint256"
-	"github.com/prysmaticlabs/go-bitfield"
+	"golang.org/x/sync/errgroup"
 )
 
 // Decoder is a wrapper around an io.Reader or a []byte buffer to implement SSZ
@@ -48,29 +51,52 @@ import (
 //     aggressively enough (neither does it allow explicitly directing it to),
 //     and in such tight loops, extra calls matter on performance.
 type Decoder struct {
-	inReader io.Reader // Underlying input stream to read from (streaming mode)
-	inRead   uint32    // Bytes already consumed from the reader (streaming mode)
-	inReads  []uint32  // Stack of consumed bytes from outer calls (streaming mode)
+	inReader io.Reader   // Underlying input stream to read from (streaming mode)
+	inExact  ExactReader // Length enforcing wrapper of the input stream (streaming mode)
+	inRead   uint32      // Bytes already consumed from the reader (streaming mode)
 
-	inBuffer  []byte    // Underlying input buffer to read from (buffered mode)
-	inBufPtr  uintptr   // Starting pointer in the input buffer (buffered mode)
-	inBufPtrs []uintptr // Stack of starting pointers from outer calls (buffered mode)
-	inBufEnd  uintptr   // Ending pointer in the input buffer (buffered mode)
+	inBuffer []byte  // Underlying input buffer to read from (buffered mode)
+	inBufPtr uintptr // Starting pointer in the input buffer (buffered mode)
+	inBufBeg uintptr // Beginning pointer in the input buffer (buffered mode)
+	inBufEnd uintptr // Ending pointer in the input buffer (buffered mode)
 
 	err error // Any write error to halt future encoding calls
 
-	codec  *Codec      // Self-referencing to pass DefineSSZ calls through (API trick)
-	buf    [32]byte    // Integer conversion buffer
-	bufInt uint256.Int // Big.Int conversion buffer (not pointer, alloc free)
-
-	length  uint32   // Message length being decoded
-	lengths []uint32 // Stack of lengths from outer calls
-
-	offset  uint32   // Starting offset we expect, or last offset seen after
-	offsets []uint32 // Queue of offsets for dynamic size calculations
-
-	sizes  []uint32   // Computed sizes for the dynamic objects
-	sizess [][]uint32 // Stack of computed sizes from outer calls
+	codec   *Codec      // Self-referencing to pass DefineSSZ calls through (API trick)
+	buf     [32]byte    // Integer conversion buffer
+	bufInt  uint256.Int // Big.Int conversion buffer (not pointer, alloc free)
+	bufBlob []byte      // Opaque binary blob buffer (streaming mode)
+
+	length uint32       // Length of the data slot being decoded
+	first  uint32       // Offset expected to start the dynamic section of the slot
+	slots  []decodeSlot // Stack of suspended data slots from outer calls
+	depth  int          // Maximum number of nested data slots permitted
+
+	table     []uint32 // Offset table of the data slots being decoded, outer first
+	tableBeg  int      // Index of the current slot's first offset in the table
+	tableNext int      // Index of the next offset in the table to decode content of
+
+	strict   bool // Whether to reject all non-canonical encodings
+	forward  bool // Whether to skip unknown trailing fields of containers
+	relaxed  bool // Whether to accept padded first offsets of containers
+	resync   bool // Whether to skip over list elements failing to decode
+	truncate bool // Whether to drop list items or bytes beyond their limits
+	threads  bool // Whether threaded decoding is allowed or not (buffered mode)
+
+	stopList func(index uint32, item Object) bool // Optional predicate to cut lists short
+
+	alloc       Allocator      // Optional custom allocator for new byte slices and objects
+	allocMax    uint64         // Optional budget for the bytes allocated during decoding
+	allocUsed   uint64         // Bytes allocated so far during decoding
+	allocShared *atomic.Uint64 // Bytes allocated so far, shared by concurrent workers
+
+	tracer      FieldTracer  // Optional callback to report the fields being decoded
+	traceFrames []traceFrame // Stack of tracing states for the nested objects
+	traceQueue  []int        // Queue of dynamic field indices awaiting their content
+	tracePath   []int        // Reusable buffer to assemble field paths in
+
+	warnings []error // Non-canonical encodings accepted in relaxed mode
+	failures []error // List elements skipped over in resync mode
 }
 
 // DecodeBool parses a boolean.
@@ -78,6 +104,8 @@ func DecodeBool[T ~bool](dec *Decoder, v *T) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(1)
+
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:1])
 		if dec.err != nil {
@@ -114,6 +142,8 @@ func DecodeUint8[T ~uint8](dec *Decoder, n *T) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(1)
+
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:1])
 		*n = T(dec.buf[0])
@@ -133,6 +163,8 @@ func DecodeUint16[T ~uint16](dec *Decoder, n *T) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(2)
+
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:2])
 		*n = T(binary.LittleEndian.Uint16(dec.buf[:2]))
@@ -152,6 +184,8 @@ func DecodeUint32[T ~uint32](dec *Decoder, n *T) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(4)
+
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:4])
 		*n = T(binary.LittleEndian.Uint32(dec.buf[:4]))
@@ -171,6 +205,8 @@ func DecodeUint64[T ~uint64](dec *Decoder, n *T) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(8)
+
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:8])
 		*n = T(binary.LittleEndian.Uint64(dec.buf[:8]))
@@ -190,6 +226,8 @@ func DecodeUint256(dec *Decoder, n **uint256.Int) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(32)
+
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:32])
 		if dec.err != nil {
@@ -198,7 +236,7 @@ func DecodeUint256(dec *Decoder, n **uint256.Int) {
 		dec.inRead += 32
 
 		if *n == nil {
-			*n = new(uint256.Int)
+			*n = AllocObject[uint256.Int](dec)
 		}
 		(*n).UnmarshalSSZ(dec.buf[:32])
 	} else {
@@ -207,18 +245,31 @@ func DecodeUint256(dec *Decoder, n **uint256.Int) {
 			return
 		}
 		if *n == nil {
-			*n = new(uint256.Int)
+			*n = AllocObject[uint256.Int](dec)
 		}
 		(*n).UnmarshalSSZ(dec.inBuffer[:32])
 		dec.inBuffer = dec.inBuffer[32:]
 	}
 }
 
+// DecodeUint256Checked parses a uint256, running the validator on it. Failures
+// abort decoding, wrapped into ErrInvalidValue.
+func DecodeUint256Checked(dec *Decoder, n **uint256.Int, validate func(n *uint256.Int) error) {
+	DecodeUint256(dec, n)
+	if dec.err == nil && validate != nil {
+		if err := validate(*n); err != nil {
+			dec.err = fmt.Errorf("%w: %w", ErrInvalidValue, err)
+		}
+	}
+}
+
 // DecodeUint256BigInt parses a uint256 into a big.Int.
 func DecodeUint256BigInt(dec *Decoder, n **big.Int) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(32)
+
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:32])
 		if dec.err != nil {
@@ -239,24 +290,51 @@ func DecodeUint256BigInt(dec *Decoder, n **big.Int) {
 	}
 }
 
+// DecodeUint256Bytes parses a uint256 into a big-endian 32 byte array.
+func DecodeUint256Bytes(dec *Decoder, n *[32]byte) {
+	if dec.err != nil {
+		return
+	}
+	dec.traceStatic(32)
+
+	if dec.inReader != nil {
+		_, dec.err = io.ReadFull(dec.inReader, dec.buf[:32])
+		if dec.err != nil {
+			return
+		}
+		dec.inRead += 32
+
+		for i := 0; i < 32; i++ {
+			n[i] = dec.buf[31-i]
+		}
+	} else {
+		if len(dec.inBuffer) < 32 {
+			dec.err = io.ErrUnexpectedEOF
+			return
+		}
+		for i := 0; i < 32; i++ {
+			n[i] = dec.inBuffer[31-i]
+		}
+		dec.inBuffer = dec.inBuffer[32:]
+	}
+}
+
 // DecodeStaticBytes parses a static binary blob.
 func DecodeStaticBytes[T commonBytesLengths](dec *Decoder, blob *T) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(uint32(len(*blob)))
+
 	if dec.inReader != nil {
-		// The code below should have used `*blob[:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		_, dec.err = io.ReadFull(dec.inReader, unsafe.Slice(&(*blob)[0], len(*blob)))
+		_, dec.err = io.ReadFull(dec.inReader, arrayBytes(blob))
 		dec.inRead += uint32(len(*blob))
 	} else {
 		if len(dec.inBuffer) < len(*blob) {
 			dec.err = io.ErrUnexpectedEOF
 			return
 		}
-		// The code below should have used `*blob[:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		copy(unsafe.Slice(&(*blob)[0], len(*blob)), dec.inBuffer)
+		copy(arrayBytes(blob), dec.inBuffer)
 		dec.inBuffer = dec.inBuffer[len(*blob):]
 	}
 }
@@ -266,9 +344,14 @@ func DecodeCheckedStaticBytes(dec *Decoder, blob *[]byte, size uint64) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(uint32(size))
+
 	// Expand the byte slice if needed and fill it with the data
 	if uint64(cap(*blob)) < size {
-		*blob = make([]byte, size)
+		*blob = dec.allocBytes(size)
+		if dec.err != nil {
+			return
+		}
 	} else {
 		*blob = (*blob)[:size]
 	}
@@ -287,6 +370,7 @@ func DecodeCheckedStaticBytes(dec *Decoder, blob *[]byte, size uint64) {
 
 // DecodeDynamicBytesOffset parses a dynamic binary blob.
 func DecodeDynamicBytesOffset(dec *Decoder, blob *[]byte) {
+	dec.traceOffset()
 	dec.decodeOffset(false)
 }
 
@@ -295,23 +379,27 @@ func DecodeDynamicBytesContent(dec *Decoder, blob *[]byte, maxSize uint64) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceDynamic()
+
 	// Compute the length of the blob based on the seen offsets
 	size := dec.retrieveSize()
 	if uint64(size) > maxSize {
-		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxLengthExceeded, size, maxSize)
-		return
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxLengthExceeded, size, maxSize)) {
+			return
+		}
+		defer dec.skipTruncated(size - uint32(maxSize))
+		size = uint32(maxSize)
 	}
 	// Expand the byte slice if needed and fill it with the data
-	if uint32(cap(*blob)) < size {
-		*blob = make([]byte, size)
-	} else {
-		*blob = (*blob)[:size]
+	dec.reserveBytes(blob, size)
+	if dec.err != nil {
+		return
 	}
 	// Inline:
 	//
 	// DecodeStaticBytes(dec, *(blob))
 	if dec.inReader != nil {
-		_, dec.err = io.ReadFull(dec.inReader, *blob)
+		dec.readBytes(blob, size)
 		dec.inRead += size
 	} else {
 		if uint32(len(dec.inBuffer)) < size {
@@ -323,19 +411,124 @@ func DecodeDynamicBytesContent(dec *Decoder, blob *[]byte, maxSize uint64) {
 	}
 }
 
+// DecodeDynamicStringOffset parses a dynamic binary blob into a string.
+func DecodeDynamicStringOffset[T ~string](dec *Decoder, str *T) {
+	dec.traceOffset()
+	dec.decodeOffset(false)
+}
+
+// DecodeDynamicStringContent is the lazy data reader of DecodeDynamicStringOffset.
+//
+// Note, if the decoded data matches the current content of the string, it is
+// left untouched, avoiding an allocation.
+func DecodeDynamicStringContent[T ~string](dec *Decoder, str *T, maxSize uint64) {
+	if dec.err != nil {
+		return
+	}
+	dec.traceDynamic()
+
+	// Compute the length of the string based on the seen offsets
+	size := dec.retrieveSize()
+	if uint64(size) > maxSize {
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxLengthExceeded, size, maxSize)) {
+			return
+		}
+		defer dec.skipTruncated(size - uint32(maxSize))
+		size = uint32(maxSize)
+	}
+	// Retrieve the data, gradually reading it into the blob buffer when
+	// streaming, so a bogus size cannot trigger a large allocation
+	var blob []byte
+	if dec.inReader != nil {
+		blob = dec.bufBlob[:0]
+		for read := uint32(0); read < size; {
+			chunk := min(size-read, max(read, streamChunkBytes))
+			blob = append(blob, make([]byte, chunk)...)
+			if _, dec.err = io.ReadFull(dec.inReader, blob[read:]); dec.err != nil {
+				return
+			}
+			read += chunk
+		}
+		dec.bufBlob = blob
+		dec.inRead += size
+	} else {
+		if uint32(len(dec.inBuffer)) < size {
+			dec.err = io.ErrUnexpectedEOF
+			return
+		}
+		blob = dec.inBuffer[:size]
+		dec.inBuffer = dec.inBuffer[size:]
+	}
+	// Only replace the string if it changed, which needs a new allocation
+	if string(*str) != string(blob) {
+		if !dec.chargeAlloc(uint64(size)) {
+			return
+		}
+		*str = T(blob)
+	}
+}
+
 // DecodeStaticObject parses a static ssz object.
 func DecodeStaticObject[T newableStaticObject[U], U any](dec *Decoder, obj *T) {
 	if dec.err != nil {
 		return
 	}
 	if *obj == nil {
-		*obj = T(new(U))
+		*obj = T(AllocObject[U](dec))
 	}
+	if dec.tracer != nil {
+		dec.traceStatic((*obj).SizeSSZ())
+	}
+	dec.traceDescend()
 	(*obj).DefineSSZ(dec.codec)
+	dec.traceAscend()
+}
+
+// DecodeStaticObjectPointer parses an optional static ssz object, allocating it
+// if nil.
+func DecodeStaticObjectPointer[T newableStaticObject[U], U any](dec *Decoder, obj *T) {
+	DecodeStaticObject(dec, obj)
+}
+
+// DecodeStaticBinaryMarshaler parses a static binary blob into an opaque binary
+// unmarshaler.
+func DecodeStaticBinaryMarshaler[T newableBinaryMarshaler[U], U any](dec *Decoder, v *T, size uint64) {
+	if dec.err != nil {
+		return
+	}
+	dec.traceStatic(uint32(size))
+
+	// Retrieve the blob without copying if possible, unmarshalers must not retain
+	// it anyway as per the encoding.BinaryUnmarshaler contract
+	var blob []byte
+	if dec.inReader != nil {
+		if uint64(cap(dec.bufBlob)) < size {
+			dec.bufBlob = make([]byte, size)
+		}
+		blob = dec.bufBlob[:size]
+
+		_, dec.err = io.ReadFull(dec.inReader, blob)
+		if dec.err != nil {
+			return
+		}
+		dec.inRead += uint32(size)
+	} else {
+		if uint64(len(dec.inBuffer)) < size {
+			dec.err = io.ErrUnexpectedEOF
+			return
+		}
+		blob = dec.inBuffer[:size]
+		dec.inBuffer = dec.inBuffer[size:]
+	}
+	if *v == nil {
+		*v = T(AllocObject[U](dec))
+	}
+	dec.err = (*v).UnmarshalBinary(blob)
 }
 
 // DecodeDynamicObjectOffset parses a dynamic ssz object.
 func DecodeDynamicObjectOffset[T newableDynamicObject[U], U any](dec *Decoder, obj *T) {
+	dec.traceOffset()
 	dec.decodeOffset(false)
 }
 
@@ -344,6 +537,8 @@ func DecodeDynamicObjectContent[T newableDynamicObject[U], U any](dec *Decoder,
 	if dec.err != nil {
 		return
 	}
+	dec.traceDynamic()
+
 	// Compute the length of the object based on the seen offsets
 	size := dec.retrieveSize()
 
@@ -352,11 +547,12 @@ func DecodeDynamicObjectContent[T newableDynamicObject[U], U any](dec *Decoder,
 	defer dec.ascendFromSlot()
 
 	if *obj == nil {
-		*obj = T(new(U))
+		*obj = T(AllocObject[U](dec))
 	}
-	dec.startDynamics((*obj).SizeSSZ(true))
+	dec.traceDescend()
+	dec.startDynamics(sizeOnFork(*obj, dec.codec.fork, true))
 	(*obj).DefineSSZ(dec.codec)
-	dec.flushDynamics()
+	dec.traceAscend()
 }
 
 // DecodeArrayOfBits parses a static array of (packed) bits.
@@ -364,9 +560,9 @@ func DecodeArrayOfBits[T commonBitsLengths](dec *Decoder, bits *T, size uint64)
 	if dec.err != nil {
 		return
 	}
-	// The code below should have used `*bits[:]`, alas Go's generics compiler
-	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	bitvector := unsafe.Slice(&(*bits)[0], len(*bits))
+	dec.traceStatic(uint32(len(*bits)))
+
+	bitvector := arrayBytes(bits)
 
 	if dec.inReader != nil {
 		_, dec.err = io.ReadFull(dec.inReader, bitvector)
@@ -391,16 +587,58 @@ func DecodeArrayOfBits[T commonBitsLengths](dec *Decoder, bits *T, size uint64)
 	}
 }
 
+// DecodeCheckedArrayOfBits parses a static array of (packed) bits.
+func DecodeCheckedArrayOfBits[T ~[]byte](dec *Decoder, bitvector *T, size uint64) {
+	if dec.err != nil {
+		return
+	}
+	bytes := (size + 7) >> 3
+	dec.traceStatic(uint32(bytes))
+
+	// Expand the byte slice if needed and fill it with the data
+	if uint64(cap(*bitvector)) < bytes {
+		*bitvector = T(dec.allocBytes(bytes))
+		if dec.err != nil {
+			return
+		}
+	} else {
+		*bitvector = (*bitvector)[:bytes]
+	}
+	if dec.inReader != nil {
+		_, dec.err = io.ReadFull(dec.inReader, *bitvector)
+		if dec.err != nil {
+			return
+		}
+		dec.inRead += uint32(bytes)
+	} else {
+		if uint64(len(dec.inBuffer)) < bytes {
+			dec.err = io.ErrUnexpectedEOF
+			return
+		}
+		copy(*bitvector, dec.inBuffer)
+		dec.inBuffer = dec.inBuffer[bytes:]
+	}
+	// Only the last byte may contain junk, so it's enough to check that
+	if size&0x7 != 0 {
+		if junk := (*bitvector)[bytes-1] >> (size & 0x7); junk != 0 {
+			dec.err = fmt.Errorf("%w: bit %d set, size %d bits", ErrJunkInBitvector, size+uint64(bits.TrailingZeros8(junk))+1, size)
+		}
+	}
+}
+
 // DecodeSliceOfBitsOffset parses a dynamic slice of (packed) bits.
-func DecodeSliceOfBitsOffset(dec *Decoder, bitlist *bitfield.Bitlist) {
+func DecodeSliceOfBitsOffset[T ~[]byte](dec *Decoder, bitlist *T) {
+	dec.traceOffset()
 	dec.decodeOffset(false)
 }
 
 // DecodeSliceOfBitsContent is the lazy data reader of DecodeSliceOfBitsOffset.
-func DecodeSliceOfBitsContent(dec *Decoder, bitlist *bitfield.Bitlist, maxBits uint64) {
+func DecodeSliceOfBitsContent[T ~[]byte](dec *Decoder, bitlist *T, maxBits uint64) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceDynamic()
+
 	// Compute the length of the encoded bits based on the seen offsets
 	size := dec.retrieveSize()
 	if size == 0 {
@@ -414,7 +652,10 @@ func DecodeSliceOfBitsContent(dec *Decoder, bitlist *bitfield.Bitlist, maxBits u
 	}
 	// Expand the slice if needed and read the bits
 	if uint32(cap(*bitlist)) < size {
-		*bitlist = make([]byte, size)
+		*bitlist = T(dec.allocBytes(uint64(size)))
+		if dec.err != nil {
+			return
+		}
 	} else {
 		*bitlist = (*bitlist)[:size]
 	}
@@ -438,7 +679,7 @@ func DecodeSliceOfBitsContent(dec *Decoder, bitlist *bitfield.Bitlist, maxBits u
 		dec.err = fmt.Errorf("%w: high byte unset", ErrJunkInBitlist)
 		return
 	}
-	if len := ((len(*bitlist) - 1) >> 3) + bits.Len8(high) - 1; uint64(len) > maxBits {
+	if len := ((len(*bitlist) - 1) << 3) + bits.Len8(high) - 1; uint64(len) > maxBits {
 		dec.err = fmt.Errorf("%w: decoded %d bits, max %d bits", ErrMaxItemsExceeded, len, maxBits)
 		return
 	}
@@ -446,36 +687,12 @@ func DecodeSliceOfBitsContent(dec *Decoder, bitlist *bitfield.Bitlist, maxBits u
 
 // DecodeArrayOfUint64s parses a static array of uint64s.
 func DecodeArrayOfUint64s[T commonUint64sLengths](dec *Decoder, ns *T) {
-	if dec.err != nil {
-		return
-	}
-	// The code below should have used `*blob[:]`, alas Go's generics compiler
-	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	nums := unsafe.Slice(&(*ns)[0], len(*ns))
-
-	if dec.inReader != nil {
-		for i := 0; i < len(nums); i++ {
-			_, dec.err = io.ReadFull(dec.inReader, dec.buf[:8])
-			if dec.err != nil {
-				return
-			}
-			nums[i] = binary.LittleEndian.Uint64(dec.buf[:8])
-			dec.inRead += 8
-		}
-	} else {
-		for i := 0; i < len(nums); i++ {
-			if len(dec.inBuffer) < 8 {
-				dec.err = io.ErrUnexpectedEOF
-				return
-			}
-			nums[i] = binary.LittleEndian.Uint64(dec.inBuffer)
-			dec.inBuffer = dec.inBuffer[8:]
-		}
-	}
+	dec.decodeUint64s(arrayUint64s(ns))
 }
 
 // DecodeSliceOfUint64sOffset parses a dynamic slice of uint64s.
 func DecodeSliceOfUint64sOffset[T ~uint64](dec *Decoder, ns *[]T) {
+	dec.traceOffset()
 	dec.decodeOffset(false)
 }
 
@@ -484,6 +701,8 @@ func DecodeSliceOfUint64sContent[T ~uint64](dec *Decoder, ns *[]T, maxItems uint
 	if dec.err != nil {
 		return
 	}
+	dec.traceDynamic()
+
 	// Compute the length of the encoded binaries based on the seen offsets
 	size := dec.retrieveSize()
 	if size == 0 {
@@ -498,17 +717,25 @@ func DecodeSliceOfUint64sContent[T ~uint64](dec *Decoder, ns *[]T, maxItems uint
 	}
 	itemCount := size >> 3
 	if uint64(itemCount) > maxItems {
-		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
-		return
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)) {
+			return
+		}
+		defer dec.skipTruncated(size - uint32(maxItems)<<3)
+		itemCount = uint32(maxItems)
 	}
 	// Expand the slice if needed and decode the objects
-	if uint32(cap(*ns)) < itemCount {
-		*ns = make([]T, itemCount)
-	} else {
-		*ns = (*ns)[:itemCount]
+	reserveSlice(dec, ns, itemCount)
+	if dec.err != nil {
+		return
 	}
 	if dec.inReader != nil {
 		for i := uint32(0); i < itemCount; i++ {
+			if i == uint32(len(*ns)) {
+				growSlice(dec, ns, itemCount)
+				if dec.err != nil {
+					return
+				}
+			}
 			_, dec.err = io.ReadFull(dec.inReader, dec.buf[:8])
 			if dec.err != nil {
 				return
@@ -530,9 +757,7 @@ func DecodeSliceOfUint64sContent[T ~uint64](dec *Decoder, ns *[]T, maxItems uint
 
 // DecodeArrayOfStaticBytes parses a static array of static binary blobs.
 func DecodeArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](dec *Decoder, blobs *T) {
-	// The code below should have used `(*blobs)[:]`, alas Go's generics compiler
-	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	DecodeUnsafeArrayOfStaticBytes(dec, unsafe.Slice(&(*blobs)[0], len(*blobs)))
+	DecodeUnsafeArrayOfStaticBytes(dec, arrayItems[T, U](blobs))
 }
 
 // DecodeUnsafeArrayOfStaticBytes parses a static array of static binary blobs.
@@ -540,25 +765,28 @@ func DecodeUnsafeArrayOfStaticBytes[T commonBytesLengths](dec *Decoder, blobs []
 	if dec.err != nil {
 		return
 	}
+	var sizer T // only the length is needed, zero value is fine
+	dec.traceStatic(uint32(len(blobs) * len(sizer)))
+
 	if dec.inReader != nil {
 		for i := 0; i < len(blobs); i++ {
-			// The code below should have used `(*blobs)[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, dec.err = io.ReadFull(dec.inReader, unsafe.Slice(&(blobs)[i][0], len((blobs)[i])))
+			_, dec.err = io.ReadFull(dec.inReader, arrayBytes(&blobs[i]))
 			if dec.err != nil {
 				return
 			}
 			dec.inRead += uint32(len((blobs)[i]))
 		}
 	} else {
+		if blob, ok := itemsBytes(blobs); ok {
+			dec.decodeItemsBytes(blob)
+			return
+		}
 		for i := 0; i < len(blobs); i++ {
 			if len(dec.inBuffer) < len((blobs)[i]) {
 				dec.err = io.ErrUnexpectedEOF
 				return
 			}
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			copy(unsafe.Slice(&(blobs)[i][0], len((blobs)[i])), dec.inBuffer)
+			copy(arrayBytes(&blobs[i]), dec.inBuffer)
 			dec.inBuffer = dec.inBuffer[len((blobs)[i]):]
 		}
 	}
@@ -569,6 +797,9 @@ func DecodeCheckedArrayOfStaticBytes[T commonBytesLengths](dec *Decoder, blobs *
 	if dec.err != nil {
 		return
 	}
+	var sizer T // only the length is needed, zero value is fine
+	dec.traceStatic(uint32(size) * uint32(len(sizer)))
+
 	// Expand the byte-array slice if needed and fill it with the data
 	if uint64(cap(*blobs)) < size {
 		*blobs = make([]T, size)
@@ -577,23 +808,23 @@ func DecodeCheckedArrayOfStaticBytes[T commonBytesLengths](dec *Decoder, blobs *
 	}
 	if dec.inReader != nil {
 		for i := 0; i < len(*blobs); i++ {
-			// The code below should have used `(*blobs)[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, dec.err = io.ReadFull(dec.inReader, unsafe.Slice(&(*blobs)[i][0], len((*blobs)[i])))
+			_, dec.err = io.ReadFull(dec.inReader, arrayBytes(&(*blobs)[i]))
 			if dec.err != nil {
 				return
 			}
 			dec.inRead += uint32(len((*blobs)[i]))
 		}
 	} else {
+		if blob, ok := itemsBytes(*blobs); ok {
+			dec.decodeItemsBytes(blob)
+			return
+		}
 		for i := 0; i < len(*blobs); i++ {
 			if len(dec.inBuffer) < len((*blobs)[i]) {
 				dec.err = io.ErrUnexpectedEOF
 				return
 			}
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			copy(unsafe.Slice(&(*blobs)[i][0], len((*blobs)[i])), dec.inBuffer)
+			copy(arrayBytes(&(*blobs)[i]), dec.inBuffer)
 			dec.inBuffer = dec.inBuffer[len((*blobs)[i]):]
 		}
 	}
@@ -601,6 +832,7 @@ func DecodeCheckedArrayOfStaticBytes[T commonBytesLengths](dec *Decoder, blobs *
 
 // DecodeSliceOfStaticBytesOffset parses a dynamic slice of static binary blobs.
 func DecodeSliceOfStaticBytesOffset[T commonBytesLengths](dec *Decoder, blobs *[]T) {
+	dec.traceOffset()
 	dec.decodeOffset(false)
 }
 
@@ -609,6 +841,8 @@ func DecodeSliceOfStaticBytesContent[T commonBytesLengths](dec *Decoder, blobs *
 	if dec.err != nil {
 		return
 	}
+	dec.traceDynamic()
+
 	// Compute the length of the encoded binaries based on the seen offsets
 	size := dec.retrieveSize()
 	if size == 0 {
@@ -626,14 +860,16 @@ func DecodeSliceOfStaticBytesContent[T commonBytesLengths](dec *Decoder, blobs *
 	}
 	itemCount := size / itemSize
 	if uint64(itemCount) > maxItems {
-		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
-		return
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)) {
+			return
+		}
+		defer dec.skipTruncated(size - uint32(maxItems)*itemSize)
+		itemCount, size = uint32(maxItems), uint32(maxItems)*itemSize
 	}
 	// Expand the slice if needed and decode the objects
-	if uint32(cap(*blobs)) < itemCount {
-		*blobs = make([]T, itemCount)
-	} else {
-		*blobs = (*blobs)[:itemCount]
+	reserveSlice(dec, blobs, itemCount)
+	if dec.err != nil {
+		return
 	}
 	// Descend into a new data slot to track/verify a new sub-length
 	dec.descendIntoSlot(size)
@@ -641,30 +877,113 @@ func DecodeSliceOfStaticBytesContent[T commonBytesLengths](dec *Decoder, blobs *
 
 	if dec.inReader != nil {
 		for i := uint32(0); i < itemCount; i++ {
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, dec.err = io.ReadFull(dec.inReader, unsafe.Slice(&(*blobs)[i][0], len((*blobs)[i])))
+			if i == uint32(len(*blobs)) {
+				growSlice(dec, blobs, itemCount)
+				if dec.err != nil {
+					return
+				}
+			}
+			_, dec.err = io.ReadFull(dec.inReader, arrayBytes(&(*blobs)[i]))
 			if dec.err != nil {
 				return
 			}
 			dec.inRead += uint32(len((*blobs)[i]))
 		}
 	} else {
+		if blob, ok := itemsBytes(*blobs); ok {
+			dec.decodeItemsBytes(blob)
+			return
+		}
 		for i := uint32(0); i < itemCount; i++ {
 			if len(dec.inBuffer) < len((*blobs)[i]) {
 				dec.err = io.ErrUnexpectedEOF
 				return
 			}
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			copy(unsafe.Slice(&(*blobs)[i][0], len((*blobs)[i])), dec.inBuffer)
+			copy(arrayBytes(&(*blobs)[i]), dec.inBuffer)
 			dec.inBuffer = dec.inBuffer[len((*blobs)[i]):]
 		}
 	}
 }
 
+// DecodeCheckedSliceOfStaticBytesOffset parses a dynamic slice of static binary
+// blobs.
+func DecodeCheckedSliceOfStaticBytesOffset(dec *Decoder, blobs *[][]byte) {
+	dec.traceOffset()
+	dec.decodeOffset(false)
+}
+
+// DecodeCheckedSliceOfStaticBytesContent is the lazy data reader of
+// DecodeCheckedSliceOfStaticBytesOffset.
+func DecodeCheckedSliceOfStaticBytesContent(dec *Decoder, blobs *[][]byte, maxItems uint64, size uint64) {
+	if dec.err != nil {
+		return
+	}
+	dec.traceDynamic()
+
+	// Compute the length of the encoded binaries based on the seen offsets
+	length := dec.retrieveSize()
+	if length == 0 {
+		// Empty slice, remove anything extra
+		*blobs = (*blobs)[:0]
+		return
+	}
+	// Compute the number of items based on the declared item size
+	if size == 0 || uint64(length)%size != 0 {
+		dec.err = fmt.Errorf("%w: length %d, item size %d", ErrDynamicStaticsIndivisible, length, size)
+		return
+	}
+	itemCount := uint32(uint64(length) / size)
+	if uint64(itemCount) > maxItems {
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)) {
+			return
+		}
+		defer dec.skipTruncated(length - uint32(maxItems*size))
+		itemCount, length = uint32(maxItems), uint32(maxItems*size)
+	}
+	// Expand the slice if needed and decode the blobs
+	reserveSlice(dec, blobs, itemCount)
+	if dec.err != nil {
+		return
+	}
+	// Descend into a new data slot to track/verify a new sub-length
+	dec.descendIntoSlot(length)
+	defer dec.ascendFromSlot()
+
+	for i := uint32(0); i < itemCount; i++ {
+		if i == uint32(len(*blobs)) {
+			growSlice(dec, blobs, itemCount)
+			if dec.err != nil {
+				return
+			}
+		}
+		blob := &(*blobs)[i]
+		if uint64(cap(*blob)) < size {
+			*blob = dec.allocBytes(size)
+			if dec.err != nil {
+				return
+			}
+		} else {
+			*blob = (*blob)[:size]
+		}
+		if dec.inReader != nil {
+			if _, dec.err = io.ReadFull(dec.inReader, *blob); dec.err != nil {
+				return
+			}
+			dec.inRead += uint32(size)
+		} else {
+			if uint64(len(dec.inBuffer)) < size {
+				dec.err = io.ErrUnexpectedEOF
+				return
+			}
+			copy(*blob, dec.inBuffer)
+			dec.inBuffer = dec.inBuffer[size:]
+		}
+	}
+}
+
 // DecodeSliceOfDynamicBytesOffset parses a dynamic slice of dynamic binary blobs.
 func DecodeSliceOfDynamicBytesOffset(dec *Decoder, blobs *[][]byte) {
+	dec.traceOffset()
 	dec.decodeOffset(false)
 }
 
@@ -673,6 +992,8 @@ func DecodeSliceOfDynamicBytesContent(dec *Decoder, blobs *[][]byte, maxItems ui
 	if dec.err != nil {
 		return
 	}
+	dec.traceDynamic()
+
 	// Compute the length of the blob slice based on the seen offsets and sanity
 	// check for empty slice or possibly bad data (too short to encode anything)
 	size := dec.retrieveSize()
@@ -689,50 +1010,86 @@ func DecodeSliceOfDynamicBytesContent(dec *Decoder, blobs *[][]byte, maxItems ui
 	dec.descendIntoSlot(size)
 	defer dec.ascendFromSlot()
 
+	dec.traceDescend()
+	defer dec.traceAscend()
+
 	// Since we're decoding a dynamic slice of dynamic objects (blobs here), the
 	// first offset will also act as a counter at to how many items there are in
 	// the list (x4 bytes for offsets being uint32).
-	dec.decodeOffset(true)
+	dec.traceOffset()
+	counter := dec.decodeOffset(true)
 	if dec.err != nil {
 		return
 	}
-	if dec.offset == 0 {
+	if counter == 0 {
 		dec.err = ErrZeroCounterOffset
 		return
 	}
-	if dec.offset&3 != 0 {
-		dec.err = fmt.Errorf("%w: %d bytes", ErrBadCounterOffset, dec.offsets)
+	if counter&3 != 0 {
+		dec.err = fmt.Errorf("%w: %d bytes", ErrBadCounterOffset, counter)
 		return
 	}
-	items := dec.offset >> 2
+	items, keep := counter>>2, counter>>2
 	if uint64(items) > maxItems {
-		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, items, maxItems)
-		return
-	}
-	// Expand the blob slice if needed
-	if uint32(cap(*blobs)) < items {
-		*blobs = make([][]byte, items)
-	} else {
-		*blobs = (*blobs)[:items]
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, items, maxItems)) {
+			return
+		}
+		keep = uint32(maxItems)
 	}
+	// Decode all the offsets before expanding the blob slice, so that a bogus
+	// item count cannot trigger a large allocation without the data to back it
 	for i := uint32(1); i < items; i++ {
-		DecodeDynamicBytesOffset(dec, &(*blobs)[i])
+		dec.traceOffset()
+		dec.decodeOffset(false)
+	}
+	if dec.err != nil {
+		return
 	}
-	for i := uint32(0); i < items; i++ {
+	resizeSlice(dec, blobs, keep)
+	for i := uint32(0); i < keep; i++ {
 		DecodeDynamicBytesContent(dec, &(*blobs)[i], maxSize)
 	}
+	if keep < items {
+		dec.skipTruncated(dec.length - dec.slotRead())
+	}
 }
 
 // DecodeSliceOfStaticObjectsOffset parses a dynamic slice of static ssz objects.
 func DecodeSliceOfStaticObjectsOffset[T newableStaticObject[U], U any](dec *Decoder, objects *[]T) {
+	dec.traceOffset()
 	dec.decodeOffset(false)
 }
 
 // DecodeSliceOfStaticObjectsContent is the lazy data reader of DecodeSliceOfStaticObjectsOffset.
 func DecodeSliceOfStaticObjectsContent[T newableStaticObject[U], U any](dec *Decoder, objects *[]T, maxItems uint64) {
+	decodeSliceOfStaticObjectsContent(dec, objects, maxItems, nil)
+}
+
+// DecodeSliceOfCheckedStaticObjectsContent is the lazy data reader of DecodeSliceOfStaticObjectsOffset,
+// running the validator on every item right after it's decoded. Any failure aborts
+// decoding (or skips the item in resync mode), wrapped into ErrInvalidItem.
+//
+// Note, items are always decoded sequentially, even if concurrent decoding is
+// enabled, so the validator sees them in order.
+func DecodeSliceOfCheckedStaticObjectsContent[T newableStaticObject[U], U any](dec *Decoder, objects *[]T, maxItems uint64, validate func(i uint32, item T) error) {
+	decodeSliceOfStaticObjectsContent(dec, objects, maxItems, validate)
+}
+
+// flatDecodable reports whether lists may be decoded in one go via FlatDecoder,
+// i.e. decoding from a buffer with no per item hooks (tracing, list cutoffs) and
+// no per item checks or recovery (strict, resync, truncate mode) configured.
+func (dec *Decoder) flatDecodable() bool {
+	return dec.inReader == nil && dec.tracer == nil && dec.stopList == nil && !dec.strict && !dec.resync && !dec.truncate
+}
+
+// decodeSliceOfStaticObjectsContent is the lazy data reader of DecodeSliceOfStaticObjectsOffset,
+// running the optional validator on every decoded item.
+func decodeSliceOfStaticObjectsContent[T newableStaticObject[U], U any](dec *Decoder, objects *[]T, maxItems uint64, validate func(i uint32, item T) error) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceDynamic()
+
 	// Compute the length of the encoded objects based on the seen offsets
 	size := dec.retrieveSize()
 	if size == 0 {
@@ -750,32 +1107,175 @@ func DecodeSliceOfStaticObjectsContent[T newableStaticObject[U], U any](dec *Dec
 	}
 	itemCount := size / itemSize
 	if uint64(itemCount) > maxItems {
-		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)
-		return
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)) {
+			return
+		}
+		defer dec.skipTruncated(size - uint32(maxItems)*itemSize)
+		itemCount, size = uint32(maxItems), uint32(maxItems)*itemSize
 	}
 	// Expand the slice if needed and decode the objects
-	if uint32(cap(*objects)) < itemCount {
-		*objects = make([]T, itemCount)
-	} else {
-		*objects = (*objects)[:itemCount]
+	reserveSlice(dec, objects, itemCount)
+	if dec.err != nil {
+		return
 	}
 	// Descend into a new data slot to track/verify a new sub-length
 	dec.descendIntoSlot(size)
 	defer dec.ascendFromSlot()
 
-	for i := uint32(0); i < itemCount; i++ {
-		if (*objects)[i] == nil {
-			(*objects)[i] = new(U)
+	dec.traceDescend()
+	defer dec.traceAscend()
+
+	// If the items can decode themselves in one go and no per item hooks or
+	// recovery are needed, allocate all items upfront and decode the entire list
+	// at once
+	if flat, ok := any(sizer).(FlatDecoder[T]); ok && dec.flatDecodable() && validate == nil {
+		for i := range *objects {
+			if (*objects)[i] == nil {
+				(*objects)[i] = AllocObject[U](dec)
+			}
 		}
-		(*objects)[i].DefineSSZ(dec.codec)
 		if dec.err != nil {
 			return
 		}
-	}
-}
+		if uint32(len(dec.inBuffer)) < size {
+			dec.err = io.ErrUnexpectedEOF
+			return
+		}
+		if dec.err = flat.DecodeFlatSSZ(dec.inBuffer[:size], *objects); dec.err != nil {
+			return
+		}
+		dec.inBuffer = dec.inBuffer[size:]
+		return
+	}
+	// If the list is large enough and threading is allowed, allocate all items
+	// upfront and fan the decoding out to multiple threads
+	if validate == nil && dec.threads && dec.inReader == nil && size >= concurrencyThreshold {
+		for i := range *objects {
+			if (*objects)[i] == nil {
+				(*objects)[i] = AllocObject[U](dec)
+			}
+		}
+		if dec.err == nil {
+			decodeStaticObjectsConcurrent(dec, *objects, itemSize)
+		}
+		return
+	}
+	for i := uint32(0); i < itemCount; i++ {
+		if i == uint32(len(*objects)) {
+			growSlice(dec, objects, itemCount)
+			if dec.err != nil {
+				return
+			}
+		}
+		if (*objects)[i] == nil {
+			(*objects)[i] = AllocObject[U](dec)
+		}
+		mark := dec.markElement(dec.slotRead() + itemSize)
+
+		dec.traceStatic(itemSize)
+		dec.traceDescend()
+		(*objects)[i].DefineSSZ(dec.codec)
+		dec.traceAscend()
+		if dec.err == nil && validate != nil {
+			if err := validate(i, (*objects)[i]); err != nil {
+				dec.err = fmt.Errorf("%w: item %d: %w", ErrInvalidItem, i, err)
+			}
+		}
+		if dec.err != nil && !dec.resyncElement(mark, i) {
+			return
+		}
+		if dec.stopListAt(i, (*objects)[i]) {
+			*objects = (*objects)[:i+1]
+			return
+		}
+	}
+}
+
+// decodeStaticObjectsConcurrent decodes a list of already allocated static ssz
+// objects from the input buffer, splitting them across multiple threads. Since
+// the items are of fixed size, the data boundaries of each split are known.
+//
+// Note, any allocations needed by the nested fields of the items are done by the
+// worker threads and are not charged against the decoder's allocation budget.
+func decodeStaticObjectsConcurrent[T StaticObject](dec *Decoder, objects []T, itemSize uint32) {
+	// Split across a higher thread count than cores to avoid starvation if some
+	// workers are slower than others
+	var (
+		splits  = min(4*runtime.NumCPU(), len(objects))
+		subtask = (len(objects) + splits - 1) / splits
+		tasks   = (len(objects) + subtask - 1) / subtask
+
+		errs     = make([]error, tasks)   // Errors hit by the individual workers
+		rests    = make([][]byte, tasks)  // Unconsumed inputs of the individual workers
+		warnings = make([][]error, tasks) // Warnings recorded by the individual workers
+		failures = make([][]error, tasks) // Elements skipped by the individual workers
+
+		allocated atomic.Uint64 // Allocation budget used, shared by all workers
+	)
+	allocated.Store(dec.allocUsed)
+
+	var workers errgroup.Group
+	workers.SetLimit(runtime.NumCPU())
+
+	for i := 0; i < tasks; i++ {
+		var (
+			task     = i // Take care, closure
+			from, to = task * subtask, min((task+1)*subtask, len(objects))
+			blob     = dec.inBuffer[uint32(from)*itemSize : uint32(to)*itemSize]
+		)
+		workers.Go(func() error {
+			codec := decoderPool.Get().(*Codec)
+			defer decoderPool.Put(codec)
+			codec.fork = dec.codec.fork
+
+			// Point the sub-decoder into the original input, so any error
+			// positions are reported relative to the start of it
+			codec.dec.inBuffer = blob
+			codec.dec.inBufBeg = dec.inBufBeg
+			codec.dec.inBufEnd = bufferAddr(blob) + uintptr(len(blob))
+			codec.dec.inherit(dec, &allocated)
+
+			codec.dec.descendIntoSlot(uint32(len(blob)))
+			for _, obj := range objects[from:to] {
+				obj.DefineSSZ(codec)
+				if codec.dec.err != nil {
+					break
+				}
+			}
+			codec.dec.ascendFromSlot()
+			errs[task], rests[task] = codec.dec.err, codec.dec.inBuffer
+			warnings[task], failures[task] = codec.dec.warnings, codec.dec.failures
+
+			codec.dec.inBufBeg = 0
+			codec.dec.inBufEnd = 0
+			codec.dec.inBuffer = nil
+			codec.dec.err = nil
+			codec.dec.configure(nil)
+			return nil
+		})
+	}
+	workers.Wait()
+
+	if dec.allocMax != 0 {
+		dec.allocUsed = allocated.Load()
+	}
+	// Report the first error in input order, positioning the decoder at the
+	// failure, or skip past all the decoded items. Anything recorded by the
+	// workers up to that point is reported as if decoded sequentially.
+	for i, err := range errs {
+		dec.warnings = append(dec.warnings, warnings[i]...)
+		dec.failures = append(dec.failures, failures[i]...)
+		if err != nil {
+			dec.err, dec.inBuffer = err, rests[i]
+			return
+		}
+	}
+	dec.inBuffer = dec.inBuffer[uint32(len(objects))*itemSize:]
+}
 
 // DecodeSliceOfDynamicObjectsOffset parses a dynamic slice of dynamic ssz objects.
 func DecodeSliceOfDynamicObjectsOffset[T newableDynamicObject[U], U any](dec *Decoder, objects *[]T) {
+	dec.traceOffset()
 	dec.decodeOffset(false)
 }
 
@@ -784,6 +1284,8 @@ func DecodeSliceOfDynamicObjectsContent[T newableDynamicObject[U], U any](dec *D
 	if dec.err != nil {
 		return
 	}
+	dec.traceDynamic()
+
 	// Compute the length of the blob slice based on the seen offsets and sanity
 	// check for empty slice or possibly bad data (too short to encode anything)
 	size := dec.retrieveSize()
@@ -801,193 +1303,815 @@ func DecodeSliceOfDynamicObjectsContent[T newableDynamicObject[U], U any](dec *D
 	dec.descendIntoSlot(size)
 	defer dec.ascendFromSlot()
 
+	dec.traceDescend()
+	defer dec.traceAscend()
+
 	// Since we're decoding a dynamic slice of dynamic objects (blobs here), the
 	// first offset will also act as a counter at to how many items there are in
 	// the list (x4 bytes for offsets being uint32).
-	dec.decodeOffset(true)
+	dec.traceOffset()
+	counter := dec.decodeOffset(true)
 	if dec.err != nil {
 		return
 	}
-	if dec.offset == 0 {
+	if counter == 0 {
 		dec.err = ErrZeroCounterOffset
 		return
 	}
-	if dec.offset&3 != 0 {
-		dec.err = fmt.Errorf("%w: %d bytes", ErrBadCounterOffset, dec.offsets)
+	if counter&3 != 0 {
+		dec.err = fmt.Errorf("%w: %d bytes", ErrBadCounterOffset, counter)
 		return
 	}
-	items := dec.offset >> 2
+	items, keep := counter>>2, counter>>2
 	if uint64(items) > maxItems {
-		dec.err = fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, items, maxItems)
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, items, maxItems)) {
+			return
+		}
+		keep = uint32(maxItems)
+	}
+	// Decode all the offsets before expanding the object slice, so that a bogus
+	// item count cannot trigger a large allocation without the data to back it
+	for i := uint32(1); i < items; i++ {
+		dec.traceOffset()
+		dec.decodeOffset(false)
+	}
+	if dec.err != nil {
 		return
 	}
-	// Expand the blob slice if needed
-	if uint32(cap(*objects)) < items {
-		*objects = make([]T, items)
-	} else {
-		*objects = (*objects)[:items]
+	resizeSlice(dec, objects, keep)
+	for i := uint32(0); i < keep; i++ {
+		mark := dec.markElement(dec.table[dec.tableNext] + dec.peekSize())
+
+		DecodeDynamicObjectContent(dec, &(*objects)[i])
+		if dec.err != nil && !dec.resyncElement(mark, i) {
+			return
+		}
+		if dec.stopListAt(i, (*objects)[i]) {
+			*objects = (*objects)[:i+1]
+			return
+		}
+	}
+	if keep < items {
+		dec.skipTruncated(dec.length - dec.slotRead())
+	}
+}
+
+// reusableItem reports whether an existing item of a factory-built slice can be
+// decoded into, or whether it needs to be replaced by a fresh one because it is
+// nil (or a typed nil) or of a different concrete type than the factory's.
+func reusableItem(item any, sample any) bool {
+	if item == nil {
+		return false
+	}
+	v := reflect.ValueOf(item)
+	if v.Type() != reflect.TypeOf(sample) {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return !v.IsNil()
+	}
+	return true
+}
+
+// DecodeSliceOfStaticObjectsOffsetFunc parses a dynamic slice of static ssz
+// objects, instantiated via a user provided factory method.
+func DecodeSliceOfStaticObjectsOffsetFunc[T StaticObject](dec *Decoder, objects *[]T) {
+	dec.traceOffset()
+	dec.decodeOffset(false)
+}
+
+// DecodeSliceOfStaticObjectsContentFunc is the lazy data reader of DecodeSliceOfStaticObjectsOffsetFunc.
+//
+// The newItem factory is called to create any missing items in the slice, and
+// also to determine the static size of a single item. This permits decoding
+// into interface-typed slices, where the concrete types depend on some outer
+// context (e.g. fork or union selector).
+func DecodeSliceOfStaticObjectsContentFunc[T StaticObject](dec *Decoder, objects *[]T, maxItems uint64, newItem func() T) {
+	if dec.err != nil {
+		return
+	}
+	dec.traceDynamic()
+
+	// Compute the length of the encoded objects based on the seen offsets
+	size := dec.retrieveSize()
+	if size == 0 {
+		// Empty slice, remove anything extra
+		*objects = (*objects)[:0]
+		return
+	}
+	// Compute the number of items based on the item size of the type. Since we
+	// can't rely on a nil pointer for sizing, instantiate an item and keep it.
+	sizer, spare := newItem(), true
+
+	itemSize := sizer.SizeSSZ()
+	if itemSize == 0 {
+		dec.err = fmt.Errorf("%w: %T", ErrZeroSizeItem, sizer)
+		return
+	}
+	if size%itemSize != 0 {
+		dec.err = fmt.Errorf("%w: length %d, item size %d", ErrDynamicStaticsIndivisible, size, itemSize)
+		return
 	}
+	itemCount := size / itemSize
+	if uint64(itemCount) > maxItems {
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, itemCount, maxItems)) {
+			return
+		}
+		defer dec.skipTruncated(size - uint32(maxItems)*itemSize)
+		itemCount, size = uint32(maxItems), uint32(maxItems)*itemSize
+	}
+	// Expand the slice if needed and decode the objects
+	reserveSlice(dec, objects, itemCount)
+	if dec.err != nil {
+		return
+	}
+	// Descend into a new data slot to track/verify a new sub-length
+	dec.descendIntoSlot(size)
+	defer dec.ascendFromSlot()
+
+	dec.traceDescend()
+	defer dec.traceAscend()
+
+	// If the list is large enough and threading is allowed, allocate all items
+	// upfront and fan the decoding out to multiple threads
+	if dec.threads && dec.inReader == nil && size >= concurrencyThreshold {
+		for i := range *objects {
+			if !reusableItem((*objects)[i], sizer) {
+				if spare {
+					(*objects)[i], spare = sizer, false
+				} else {
+					(*objects)[i] = newItem()
+				}
+			}
+		}
+		decodeStaticObjectsConcurrent(dec, *objects, itemSize)
+		return
+	}
+	for i := uint32(0); i < itemCount; i++ {
+		if i == uint32(len(*objects)) {
+			growSlice(dec, objects, itemCount)
+			if dec.err != nil {
+				return
+			}
+		}
+		if !reusableItem((*objects)[i], sizer) {
+			if spare {
+				(*objects)[i], spare = sizer, false
+			} else {
+				(*objects)[i] = newItem()
+			}
+		}
+		mark := dec.markElement(dec.slotRead() + itemSize)
+
+		dec.traceStatic(itemSize)
+		dec.traceDescend()
+		(*objects)[i].DefineSSZ(dec.codec)
+		dec.traceAscend()
+		if dec.err != nil && !dec.resyncElement(mark, i) {
+			return
+		}
+		if dec.stopListAt(i, (*objects)[i]) {
+			*objects = (*objects)[:i+1]
+			return
+		}
+	}
+}
+
+// DecodeSliceOfDynamicObjectsOffsetFunc parses a dynamic slice of dynamic ssz
+// objects, instantiated via a user provided factory method.
+func DecodeSliceOfDynamicObjectsOffsetFunc[T DynamicObject](dec *Decoder, objects *[]T) {
+	dec.traceOffset()
+	dec.decodeOffset(false)
+}
+
+// DecodeSliceOfDynamicObjectsContentFunc is the lazy data reader of DecodeSliceOfDynamicObjectsOffsetFunc.
+//
+// The newItem factory is called to create any missing items in the slice, and
+// also to determine the concrete type of the items. This permits decoding into
+// interface-typed slices, where the concrete types depend on some outer context
+// (e.g. fork or union selector).
+func DecodeSliceOfDynamicObjectsContentFunc[T DynamicObject](dec *Decoder, objects *[]T, maxItems uint64, newItem func() T) {
+	if dec.err != nil {
+		return
+	}
+	dec.traceDynamic()
+
+	// Compute the length of the blob slice based on the seen offsets and sanity
+	// check for empty slice or possibly bad data (too short to encode anything)
+	size := dec.retrieveSize()
+	if size == 0 {
+		// Empty slice, remove anything extra
+		*objects = (*objects)[:0]
+		return
+	}
+	if size < 4 {
+		dec.err = fmt.Errorf("%w: %d bytes available", ErrShortCounterOffset, size)
+		return
+	}
+	// Descend into a new dynamic list type to track a new sub-length and work
+	// with a fresh set of dynamic offsets
+	dec.descendIntoSlot(size)
+	defer dec.ascendFromSlot()
+
+	dec.traceDescend()
+	defer dec.traceAscend()
+
+	// Since we're decoding a dynamic slice of dynamic objects, the first offset
+	// will also act as a counter at to how many items there are in the list (x4
+	// bytes for offsets being uint32).
+	dec.traceOffset()
+	counter := dec.decodeOffset(true)
+	if dec.err != nil {
+		return
+	}
+	if counter == 0 {
+		dec.err = ErrZeroCounterOffset
+		return
+	}
+	if counter&3 != 0 {
+		dec.err = fmt.Errorf("%w: %d bytes", ErrBadCounterOffset, counter)
+		return
+	}
+	items, keep := counter>>2, counter>>2
+	if uint64(items) > maxItems {
+		if !dec.truncateLimit(fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, items, maxItems)) {
+			return
+		}
+		keep = uint32(maxItems)
+	}
+	// Decode all the offsets before expanding the object slice, so that a bogus
+	// item count cannot trigger a large allocation without the data to back it
 	for i := uint32(1); i < items; i++ {
-		DecodeDynamicObjectOffset(dec, &(*objects)[i])
+		dec.traceOffset()
+		dec.decodeOffset(false)
 	}
-	for i := uint32(0); i < items; i++ {
-		DecodeDynamicObjectContent(dec, &(*objects)[i])
+	if dec.err != nil {
+		return
+	}
+	resizeSlice(dec, objects, keep)
+
+	// Instantiate an item to check the existing ones against, and keep it
+	sample, spare := newItem(), true
+	for i := uint32(0); i < keep; i++ {
+		mark := dec.markElement(dec.table[dec.tableNext] + dec.peekSize())
+
+		// Inline:
+		//
+		// DecodeDynamicObjectContent(dec, &(*objects)[i])
+		dec.traceDynamic()
+		size := dec.retrieveSize()
+
+		dec.descendIntoSlot(size)
+		if !reusableItem((*objects)[i], sample) {
+			if spare {
+				(*objects)[i], spare = sample, false
+			} else {
+				(*objects)[i] = newItem()
+			}
+		}
+		dec.traceDescend()
+		dec.startDynamics(sizeOnFork((*objects)[i], dec.codec.fork, true))
+		(*objects)[i].DefineSSZ(dec.codec)
+		dec.traceAscend()
+		dec.ascendFromSlot()
+
+		if dec.err != nil && !dec.resyncElement(mark, i) {
+			return
+		}
+		if dec.stopListAt(i, (*objects)[i]) {
+			*objects = (*objects)[:i+1]
+			return
+		}
+	}
+	if keep < items {
+		dec.skipTruncated(dec.length - dec.slotRead())
 	}
 }
 
-// decodeOffset decodes the next uint32 as an offset and validates it.
-func (dec *Decoder) decodeOffset(list bool) {
+// DecodeSkipStatic discards the next n bytes of a static field. It can be used
+// to ignore unknown fields when decoding forward-compatible containers.
+func DecodeSkipStatic(dec *Decoder, n uint32) {
 	if dec.err != nil {
 		return
 	}
+	dec.traceStatic(n)
+	dec.skip(n)
+}
+
+// DecodeSkipDynamicOffset parses the offset of a dynamic field to be discarded.
+// It can be used to ignore unknown fields when decoding forward-compatible
+// containers.
+func DecodeSkipDynamicOffset(dec *Decoder) {
+	dec.traceOffset()
+	dec.decodeOffset(false)
+}
+
+// DecodeSkipDynamicContent is the lazy data discarder of DecodeSkipDynamicOffset.
+func DecodeSkipDynamicContent(dec *Decoder) {
+	if dec.err != nil {
+		return
+	}
+	dec.traceDynamic()
+	dec.skip(dec.retrieveSize())
+}
+
+// Consumed returns the number of bytes consumed so far from the input being
+// decoded. It can be used by asymmetric decoders wrapping higher level protocols
+// to verify chunk boundaries without having to track the reader themselves.
+func (dec *Decoder) Consumed() uint32 {
+	if dec.inReader != nil {
+		// The first suspended slot is from before the outermost data slot, stale
+		// from any previous decoding run, so skip it. If decoding already ascended
+		// out of the outermost slot, the current count is the total.
+		if len(dec.slots) == 0 {
+			return dec.inRead
+		}
+		pos := dec.inRead
+		for _, slot := range dec.slots[1:] {
+			pos += slot.inRead
+		}
+		return pos
+	}
+	if len(dec.inBuffer) > 0 {
+		return uint32(bufferAddr(dec.inBuffer) - dec.inBufBeg)
+	}
+	return uint32(dec.inBufEnd - dec.inBufBeg)
+}
+
+// Remaining returns the number of bytes left to decode from the input.
+func (dec *Decoder) Remaining() uint32 {
+	// The outermost length is the whole input, but it may be suspended if the
+	// decoder is currently within a nested data slot.
+	length := dec.length
+	if len(dec.slots) > 1 {
+		length = dec.slots[1].length
+	}
+	return length - dec.Consumed()
+}
+
+// decodeItemsBytes fills a list of static binary blobs, viewed as one single
+// contiguous blob, from the input buffer with one bounds check and copy.
+func (dec *Decoder) decodeItemsBytes(blob []byte) {
+	if len(dec.inBuffer) < len(blob) {
+		dec.err = io.ErrUnexpectedEOF
+		return
+	}
+	copy(blob, dec.inBuffer)
+	dec.inBuffer = dec.inBuffer[len(blob):]
+}
+
+// decodeUint64s parses a static array of uint64s into a plain slice view of it.
+func (dec *Decoder) decodeUint64s(nums []uint64) {
+	if dec.err != nil {
+		return
+	}
+	dec.traceStatic(uint32(8 * len(nums)))
+
+	if dec.inReader != nil {
+		for i := 0; i < len(nums); i++ {
+			_, dec.err = io.ReadFull(dec.inReader, dec.buf[:8])
+			if dec.err != nil {
+				return
+			}
+			nums[i] = binary.LittleEndian.Uint64(dec.buf[:8])
+			dec.inRead += 8
+		}
+	} else {
+		for i := 0; i < len(nums); i++ {
+			if len(dec.inBuffer) < 8 {
+				dec.err = io.ErrUnexpectedEOF
+				return
+			}
+			nums[i] = binary.LittleEndian.Uint64(dec.inBuffer)
+			dec.inBuffer = dec.inBuffer[8:]
+		}
+	}
+}
+
+// decodeError wraps any failure hit during decoding into a DecodeError, or nil
+// if decoding succeeded. In resync mode, the failures of the skipped over list
+// elements are joined in front.
+func (dec *Decoder) decodeError() error {
+	var err error
+	if dec.err != nil {
+		err = newDecodeError(dec.err, dec.Consumed(), dec.traceField())
+	}
+	if len(dec.failures) == 0 {
+		return err
+	}
+	return errors.Join(append(dec.failures, err)...)
+}
+
+// truncateLimit handles a list or blob exceeding its limit. In truncating mode,
+// the overflow is recorded as a warning and true returned, with the caller only
+// decoding up to the limit. Otherwise, the overflow is set as the error.
+func (dec *Decoder) truncateLimit(err error) bool {
+	if !dec.truncate {
+		dec.err = err
+		return false
+	}
+	dec.warnings = append(dec.warnings, newDecodeError(err, dec.Consumed(), dec.traceField()))
+	return true
+}
+
+// skipTruncated discards the items of a list or blob beyond its limit, unless
+// decoding already failed.
+func (dec *Decoder) skipTruncated(n uint32) {
+	if dec.err == nil && n > 0 {
+		dec.skip(n)
+	}
+}
+
+// resyncMark is the decoding state at the start of a list element, needed to
+// skip over the element if it fails to decode in resync mode.
+type resyncMark struct {
+	end    uint32 // Position within the data slot where the element ends
+	frames int    // Depth of the tracing stack at the element's start
+	queue  int    // Length of the tracing queue at the element's start
+}
+
+// markElement saves the decoding state at the start of a list element ending at
+// the given position within the current data slot.
+func (dec *Decoder) markElement(end uint32) resyncMark {
+	return resyncMark{end: end, frames: len(dec.traceFrames), queue: len(dec.traceQueue)}
+}
+
+// resyncElement handles a failure to decode a list element. In resync mode, the
+// failure is recorded and decoding skips to the end of the element, returning
+// true if the next element can be decoded. Otherwise, or if the failure cannot
+// be recovered from, the error is retained and false returned.
+func (dec *Decoder) resyncElement(mark resyncMark, index uint32) bool {
+	if !dec.resync || errors.Is(dec.err, ErrMaxAllocExceeded) {
+		return false
+	}
+	read := dec.slotRead()
+	if read > mark.end {
+		return false
+	}
+	dec.failures = append(dec.failures, &ElementError{
+		Index: index,
+		Err:   newDecodeError(dec.err, dec.Consumed(), dec.traceField()),
+	})
+	dec.err = nil
+
+	if dec.tracer != nil {
+		dec.traceFrames = dec.traceFrames[:mark.frames]
+		dec.traceQueue = dec.traceQueue[:mark.queue]
+	}
+	dec.skip(mark.end - read)
+	return dec.err == nil
+}
+
+// traceField returns the path of the field being decoded, or nil if tracing is
+// not enabled.
+func (dec *Decoder) traceField() []int {
+	var field []int
+	if dec.tracer != nil {
+		for _, frame := range dec.traceFrames {
+			field = append(field, frame.field)
+		}
+	}
+	return field
+}
+
+// configure sets up the optional decoding behaviors from a user config, or
+// resets them to the defaults if the config is nil.
+func (dec *Decoder) configure(cfg *DecoderConfig) {
+	if cfg == nil {
+		cfg = new(DecoderConfig)
+	}
+	dec.strict = cfg.Strict
+	dec.depth = DefaultMaxDepth
+	if cfg.MaxDepth != 0 {
+		dec.depth = cfg.MaxDepth
+	}
+	dec.forward = cfg.ForwardCompatible && !cfg.Strict
+	dec.relaxed = cfg.RelaxFirstOffset && !cfg.Strict
+	dec.resync = cfg.ResyncElements
+	dec.truncate = cfg.TruncateOversized && !cfg.Strict
+	dec.warnings = nil
+	dec.failures = nil
+	dec.threads = cfg.Concurrent && cfg.Allocator == nil && cfg.OnField == nil && !cfg.ResyncElements && cfg.StopList == nil
+
+	dec.stopList = nil
+	if !cfg.Strict {
+		dec.stopList = cfg.StopList
+	}
+
+	dec.alloc = cfg.Allocator
+	dec.allocMax = cfg.MaxAlloc
+	dec.allocUsed = 0
+	dec.allocShared = nil
+
+	dec.tracer = cfg.OnField
+	if dec.tracer != nil {
+		dec.traceReset()
+	}
+}
+
+// inherit configures a worker decoder to decode a part of the parent's current
+// data slot with the same resolved configuration, charging its allocations to
+// the budget shared by all the workers.
+func (dec *Decoder) inherit(parent *Decoder, allocated *atomic.Uint64) {
+	dec.strict = parent.strict
+	dec.depth = parent.depth - len(parent.slots) + 1 // worker slot stands in for the parent's
+	dec.forward = parent.forward
+	dec.relaxed = parent.relaxed
+	dec.resync = parent.resync
+	dec.truncate = parent.truncate
+	dec.stopList = parent.stopList
+
+	dec.alloc = parent.alloc
+	dec.allocMax = parent.allocMax
+	dec.allocShared = allocated
+}
+
+// stopListAt invokes the optional list stopping predicate on a successfully
+// decoded item, and if it requests so, skips over the rest of the current list.
+// The caller is responsible for dropping the items beyond index from the slice.
+func (dec *Decoder) stopListAt(index uint32, item Object) bool {
+	if dec.stopList == nil || dec.err != nil || !dec.stopList(index, item) {
+		return false
+	}
+	dec.skip(dec.length - dec.slotRead())
+	return true
+}
+
+// skip discards the next n bytes of the input.
+func (dec *Decoder) skip(n uint32) {
+	if dec.inReader != nil {
+		for left := n; left > 0; {
+			chunk := min(left, uint32(len(dec.buf)))
+			if _, dec.err = io.ReadFull(dec.inReader, dec.buf[:chunk]); dec.err != nil {
+				return
+			}
+			left -= chunk
+		}
+		dec.inRead += n
+	} else {
+		if uint32(len(dec.inBuffer)) < n {
+			dec.err = io.ErrUnexpectedEOF
+			return
+		}
+		dec.inBuffer = dec.inBuffer[n:]
+	}
+}
+
+// skipUnknownFields skips over the static fields of a forward compatible
+// container which are unknown to its type. If they consist solely of offsets,
+// they are taken to be those of unknown dynamic fields appended by a newer
+// schema: the first one bounds the content of the last known dynamic field, and
+// the unknown content following it is skipped when the slot is done.
+func (dec *Decoder) skipUnknownFields(n uint32) {
+	var (
+		offsets = n%4 == 0
+		prev    = dec.table[len(dec.table)-1]
+		first   = dec.length
+	)
+	for left := n; left > 0; {
+		// Skip the remainder in one go if it can't be offsets any more
+		if !offsets {
+			dec.skip(left)
+			return
+		}
+		var word []byte
+		if dec.inReader != nil {
+			if _, dec.err = io.ReadFull(dec.inReader, dec.buf[:4]); dec.err != nil {
+				return
+			}
+			dec.inRead += 4
+			word = dec.buf[:4]
+		} else {
+			if len(dec.inBuffer) < 4 {
+				dec.err = io.ErrUnexpectedEOF
+				return
+			}
+			word, dec.inBuffer = dec.inBuffer[:4], dec.inBuffer[4:]
+		}
+		left -= 4
+
+		offset := binary.LittleEndian.Uint32(word)
+		if offset < prev || offset > dec.length {
+			offsets = false
+			continue
+		}
+		if left+4 == n {
+			first = offset
+		}
+		prev = offset
+	}
+	if offsets {
+		dec.table = append(dec.table, first)
+	}
+}
+
+// slotRead returns the number of bytes consumed from the current data slot.
+func (dec *Decoder) slotRead() uint32 {
+	if dec.inReader != nil {
+		return dec.inRead
+	}
+	if len(dec.inBuffer) > 0 {
+		return uint32(bufferAddr(dec.inBuffer) - dec.inBufPtr)
+	}
+	return uint32(dec.inBufEnd - dec.inBufPtr)
+}
+
+// decodeOffset decodes the next uint32 as an offset, validates it and appends
+// it to the offset table of the current data slot.
+func (dec *Decoder) decodeOffset(list bool) uint32 {
+	if dec.err != nil {
+		return 0
+	}
 	var offset uint32
 	if dec.inReader != nil {
 		if _, dec.err = io.ReadFull(dec.inReader, dec.buf[:4]); dec.err != nil {
-			return
+			return 0
 		}
 		offset = binary.LittleEndian.Uint32(dec.buf[:4])
 		dec.inRead += 4
 	} else {
 		if len(dec.inBuffer) < 4 {
 			dec.err = io.ErrUnexpectedEOF
-			return
+			return 0
 		}
 		offset = binary.LittleEndian.Uint32(dec.inBuffer)
 		dec.inBuffer = dec.inBuffer[4:]
 	}
 	if offset > dec.length {
 		dec.err = fmt.Errorf("%w: decoded %d, message length %d", ErrOffsetBeyondCapacity, offset, dec.length)
-		return
-	}
-	if dec.offsets == nil && !list && dec.offset != offset {
-		dec.err = fmt.Errorf("%w: decoded %d, type expects %d", ErrFirstOffsetMismatch, offset, dec.offset)
-		return
-	}
-	if dec.offsets != nil && dec.offset > offset {
-		dec.err = fmt.Errorf("%w: decoded %d, previous was %d", ErrBadOffsetProgression, offset, dec.offset)
-		return
+		return 0
+	}
+	// The first offset of an object must point right after its static section,
+	// so that no gap can be smuggled in between the static and dynamic sections.
+	// Subsequent offsets must never point backwards.
+	if dec.tableBeg == len(dec.table) {
+		// In forward compatible mode, permit a longer static section than known
+		// (unknown fields appended by a newer schema), it's skipped later. In
+		// relaxed mode, permit the same, but record it as padding.
+		if !list && dec.first != offset {
+			err := fmt.Errorf("%w: decoded %d, type expects %d", ErrFirstOffsetMismatch, offset, dec.first)
+			switch {
+			case offset < dec.first || (!dec.forward && !dec.relaxed):
+				dec.err = err
+				return 0
+			case !dec.forward:
+				dec.warnings = append(dec.warnings, newDecodeError(err, dec.Consumed()-4, dec.traceField()))
+			}
+		}
+	} else if prev := dec.table[len(dec.table)-1]; prev > offset {
+		dec.err = fmt.Errorf("%w: decoded %d, previous was %d", ErrBadOffsetProgression, offset, prev)
+		return 0
 	}
-	dec.offset = offset
-	dec.offsets = append(dec.offsets, offset)
+	dec.table = append(dec.table, offset)
+	return offset
 }
 
-// retrieveSize retrieves the length of the nest dynamic item based on the seen
-// and cached offsets.
+// retrieveSize retrieves the length of the next dynamic item of the current data
+// slot based on its offset table.
 func (dec *Decoder) retrieveSize() uint32 {
-	// If sizes aren't yet available, pre-compute them all. The reason we use a
-	// reverse order is to permit popping them off without thrashing the slice.
-	if len(dec.sizes) == 0 {
-		// Expand the sizes slice to required capacity
-		items := len(dec.offsets)
-		if cap(dec.sizes) < items {
-			dec.sizes = dec.sizes[:cap(dec.sizes)]
-			dec.sizes = append(dec.sizes, make([]uint32, items-len(dec.sizes))...)
-		} else {
-			dec.sizes = dec.sizes[:items]
+	size := dec.peekSize()
+	dec.tableNext++
+	return size
+}
+
+// peekSize is analogous to retrieveSize, but does not move on to the next item.
+func (dec *Decoder) peekSize() uint32 {
+	// Content without a decoded offset can only be requested by asymmetric type
+	// definitions (or decoding into the wrong slot), but it must not crash
+	if dec.tableNext >= len(dec.table) {
+		if dec.err == nil {
+			dec.err = fmt.Errorf("%w: dynamic item %d of slot", ErrMissingOffset, dec.tableNext-dec.tableBeg)
 		}
-		// Compute all the sizes we'll need in reverse order (so we can pop them
-		// off like a stack without ruining the buffer pointer)
-		for i := 0; i < items; i++ {
-			if i < items-1 {
-				dec.sizes[items-1-i] = dec.offsets[i+1] - dec.offsets[i]
+		return 0
+	}
+	// In forward compatible mode, the static section might be longer than what
+	// the type knows about. Skip any unknown static fields (or offsets of unknown
+	// dynamic fields) before reading the first dynamic content. In relaxed mode,
+	// skip the padding the same way.
+	if (dec.forward || dec.relaxed) && dec.tableNext == dec.tableBeg {
+		if read := dec.slotRead(); read < dec.table[dec.tableNext] {
+			if dec.forward {
+				dec.skipUnknownFields(dec.table[dec.tableNext] - read)
 			} else {
-				dec.sizes[0] = dec.length - dec.offsets[i]
+				dec.skip(dec.table[dec.tableNext] - read)
 			}
 		}
-		// Nuke out the offsets to avoid leaving junk in the state
-		dec.offsets = dec.offsets[:0]
 	}
-	// Retrieve the next item's size and pop it off the size stack
-	size := dec.sizes[len(dec.sizes)-1]
-	dec.sizes = dec.sizes[:len(dec.sizes)-1]
-	return size
+	// The size of an item spans until the next offset, or until the end of the
+	// data slot for the last one. The offsets are validated as they are decoded,
+	// but never let a broken invariant wrap the size around.
+	start, end := dec.table[dec.tableNext], dec.length
+	if dec.tableNext+1 < len(dec.table) {
+		end = dec.table[dec.tableNext+1]
+	}
+	if end < start {
+		if dec.err == nil {
+			dec.err = fmt.Errorf("%w: item spans from %d to %d", ErrOffsetUnderflow, start, end)
+		}
+		return 0
+	}
+	return end - start
+}
+
+// decodeSlot is the decoding state of a data slot, suspended while decoding a
+// nested one.
+type decodeSlot struct {
+	length    uint32  // Length of the data slot
+	first     uint32  // Offset expected to start the dynamic section of the slot
+	inRead    uint32  // Bytes consumed from the slot (streaming mode)
+	inBufPtr  uintptr // Starting pointer of the slot (buffered mode)
+	tableBeg  int     // Index of the slot's first offset in the table
+	tableNext int     // Index of the slot's next offset to decode content of
 }
 
 // descendIntoSlot starts the decoding of a data slot with a new length. For the
 // static objects, the length is used to enforce that all data is consumed. For
 // the dynamic objects, the length is used to decode the last dynamic item.
+//
+// Decoding is done in two passes over each slot: the static section is read
+// first, appending all the offsets into the offset table, and the dynamic
+// contents second, sized by the table entries. Nested slots append their own
+// offsets after the outer ones, which are only needed again once the nested
+// slot is done and its entries are dropped.
 func (dec *Decoder) descendIntoSlot(length uint32) {
-	dec.lengths = append(dec.lengths, dec.length)
+	dec.slots = append(dec.slots, decodeSlot{
+		length:    dec.length,
+		first:     dec.first,
+		inRead:    dec.inRead,
+		inBufPtr:  dec.inBufPtr,
+		tableBeg:  dec.tableBeg,
+		tableNext: dec.tableNext,
+	})
+	if len(dec.slots) > dec.depth && dec.err == nil {
+		dec.err = fmt.Errorf("%w: depth %d, max %d", ErrMaxDepthExceeded, len(dec.slots), dec.depth)
+	}
 	dec.length = length
+	dec.first = 0 // random offset, will be ignored or set by startDynamics
+	dec.tableBeg = len(dec.table)
+	dec.tableNext = len(dec.table)
 
 	if dec.inReader != nil {
-		dec.inReads = append(dec.inReads, dec.inRead)
 		dec.inRead = 0
 	} else {
-		dec.inBufPtrs = append(dec.inBufPtrs, dec.inBufPtr)
 		if len(dec.inBuffer) > 0 {
-			dec.inBufPtr = uintptr(unsafe.Pointer(&dec.inBuffer[0]))
+			dec.inBufPtr = bufferAddr(dec.inBuffer)
 		} else {
 			dec.inBufPtr = dec.inBufEnd // can only happen for bad input
 		}
 	}
-	dec.startDynamics(0) // random offset, will be ignored
 }
 
 // ascendFromSlot is the counterpart of descendIntoSlot that enforces the read
 // bytes and restores the previously suspended decoding state.
 func (dec *Decoder) ascendFromSlot() {
-	dec.flushDynamics()
-
 	// For static objects, enforce that the data they read actually corresponds
 	// to the data they should have read. Whilst this does not apply to dynamic
 	// objects in the current SSZ spec (they will always read all or error with
 	// a different issue), there's no reason not to check them for future cases.
+	slot := dec.slots[len(dec.slots)-1]
+	dec.slots = dec.slots[:len(dec.slots)-1]
+
 	if dec.inReader != nil {
+		if dec.forward && dec.err == nil && dec.inRead < dec.length {
+			dec.skip(dec.length - dec.inRead) // unknown trailing fields
+		}
 		if dec.inRead != dec.length {
 			if dec.err == nil {
 				dec.err = fmt.Errorf("%w: data size %d, object consumed %d", ErrObjectSlotSizeMismatch, dec.length, dec.inRead)
 			}
 		}
-		dec.inRead += dec.inReads[len(dec.inReads)-1] // track the sub-reads, don't discard!
-		dec.inReads = dec.inReads[:len(dec.inReads)-1]
+		dec.inRead += slot.inRead // track the sub-reads, don't discard!
 	} else {
 		var read uint32
 		if len(dec.inBuffer) > 0 {
-			read = uint32(uintptr(unsafe.Pointer(&dec.inBuffer[0])) - dec.inBufPtr)
+			read = uint32(bufferAddr(dec.inBuffer) - dec.inBufPtr)
 		} else {
 			read = uint32(dec.inBufEnd - dec.inBufPtr)
 		}
+		if dec.forward && dec.err == nil && read < dec.length {
+			dec.skip(dec.length - read) // unknown trailing fields
+			read = dec.length
+		}
 		if read != dec.length {
 			if dec.err == nil {
 				dec.err = fmt.Errorf("%w: data size %d, object consumed %d", ErrObjectSlotSizeMismatch, dec.length, read)
 			}
 		}
-		dec.inBufPtr = dec.inBufPtrs[len(dec.inBufPtrs)-1]
-		dec.inBufPtrs = dec.inBufPtrs[:len(dec.inBufPtrs)-1]
+		dec.inBufPtr = slot.inBufPtr
 	}
+	// Drop the slot's offsets (or any leftovers from partial decodes) and resume
+	// the outer slot where it was left off
+	dec.table = dec.table[:dec.tableBeg]
 
-	dec.length = dec.lengths[len(dec.lengths)-1]
-	dec.lengths = dec.lengths[:len(dec.lengths)-1]
+	dec.length = slot.length
+	dec.first = slot.first
+	dec.tableBeg = slot.tableBeg
+	dec.tableNext = slot.tableNext
 }
 
-// startDynamics marks the item being decoded as a dynamic type, setting the starting
-// offset for the dynamic fields.
+// startDynamics marks the data slot being decoded as a dynamic type, setting the
+// starting offset expected for the dynamic fields.
 func (dec *Decoder) startDynamics(offset uint32) {
-	dec.offset = offset
-
-	// Try to reuse older computed size slices to avoid allocations
-	n := len(dec.sizess)
-
-	if cap(dec.sizess) > n {
-		dec.sizess = dec.sizess[:n+1]
-		dec.sizes, dec.sizess[n] = dec.sizess[n], dec.sizes
-	} else {
-		dec.sizess = append(dec.sizess, dec.sizes)
-		dec.sizes = nil
-	}
-}
-
-// flushDynamics marks the end of the dynamic fields, decoding anything queued up and
-// restoring any previous states for outer call continuation.
-func (dec *Decoder) flushDynamics() {
-	// Clear out any leftovers from partial dynamic decodes
-	dec.offsets = dec.offsets[:0]
-	dec.sizes = dec.sizes[:0]
-
-	// Restore the previous state, but swap in the current slice as a future memcache
-	last := len(dec.sizess) - 1
-
-	dec.sizes, dec.sizess[last] = dec.sizess[last], dec.sizes
-	dec.sizess = dec.sizess[:last]
+	dec.first = offset
 }
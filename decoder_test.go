@@ -0,0 +1,1228 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/random"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// Tests that decoding less or more data than requested will result in a failure.
+func TestDecodeMissized(t *testing.T) {
+	obj := new(testMissizedType)
+
+	blob := make([]byte, obj.SizeSSZ()+1)
+	if err := ssz.DecodeFromBytes(blob, obj); !errors.Is(err, ssz.ErrObjectSlotSizeMismatch) {
+		t.Errorf("decode from bytes error mismatch: have %v, want %v", err, ssz.ErrObjectSlotSizeMismatch)
+	}
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), obj, uint32(len(blob))); !errors.Is(err, ssz.ErrObjectSlotSizeMismatch) {
+		t.Errorf("decode from stream error mismatch: have %v, want %v", err, ssz.ErrObjectSlotSizeMismatch)
+	}
+
+	blob = make([]byte, obj.SizeSSZ()-1)
+	if err := ssz.DecodeFromBytes(blob, obj); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("decode from bytes error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), obj, uint32(len(blob))); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("decode from stream error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+type testMissizedType struct {
+	A, B uint64
+}
+
+func (t *testMissizedType) SizeSSZ() uint32 { return 16 }
+
+func (t *testMissizedType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.A)
+	ssz.DefineUint64(codec, &t.B)
+}
+
+// Tests that decoding an empty dynamic list via a non-empty container with an
+// empty counter offset is rejected.
+func TestZeroCounterOffset(t *testing.T) {
+	inSSZ, err := hex.DecodeString("30303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030fc01000030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030fe010000303000000000")
+	if err != nil {
+		panic(err)
+	}
+	err = ssz.DecodeFromBytes(inSSZ, new(types.ExecutionPayload))
+	if !errors.Is(err, ssz.ErrZeroCounterOffset) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, ssz.ErrZeroCounterOffset)
+	}
+}
+
+// Tests that decoding a boolean with an invalid encoding is rejected.
+func TestInvalidBoolean(t *testing.T) {
+	inSSZ, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		panic(err)
+	}
+	err = ssz.DecodeFromBytes(inSSZ, new(types.Validator))
+	if !errors.Is(err, ssz.ErrInvalidBoolean) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, ssz.ErrInvalidBoolean)
+	}
+}
+
+// Tests that slices of interface-typed items can be decoded via item factories.
+func TestSliceOfObjectsFactory(t *testing.T) {
+	obj := &testFactoryType{
+		Statics:  []ssz.StaticObject{&types.Withdrawal{Index: 1}, &types.Withdrawal{Index: 2}},
+		Dynamics: []ssz.DynamicObject{&types.ExecutionPayload{BlockNumber: 3, ExtraData: []byte{0x04}}},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	dec := new(testFactoryType)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if len(dec.Statics) != 2 || dec.Statics[1].(*types.Withdrawal).Index != 2 {
+		t.Errorf("static items mismatch: have %v", dec.Statics)
+	}
+	if len(dec.Dynamics) != 1 || dec.Dynamics[0].(*types.ExecutionPayload).BlockNumber != 3 {
+		t.Errorf("dynamic items mismatch: have %v", dec.Dynamics)
+	}
+	if ssz.HashSequential(obj) != ssz.HashSequential(dec) {
+		t.Errorf("hash mismatch after round trip")
+	}
+}
+
+// Tests that decoding into reused slices of interface-typed items replaces the
+// nil, typed nil and differently typed items via the factories.
+func TestSliceOfObjectsFactoryReuse(t *testing.T) {
+	obj := &testFactoryType{
+		Statics:  []ssz.StaticObject{&types.Withdrawal{Index: 1}, &types.Withdrawal{Index: 2}, &types.Withdrawal{Index: 3}, &types.Withdrawal{Index: 4}},
+		Dynamics: []ssz.DynamicObject{&types.ExecutionPayload{BlockNumber: 5}, &types.ExecutionPayload{BlockNumber: 6}, &types.ExecutionPayload{BlockNumber: 7}},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	reused := &types.Withdrawal{}
+	dec := &testFactoryType{
+		Statics:  []ssz.StaticObject{nil, (*types.Withdrawal)(nil), new(types.Checkpoint), reused},
+		Dynamics: []ssz.DynamicObject{(*types.ExecutionPayload)(nil), new(types.ExecutionPayloadCapella), nil},
+	}
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	for i, item := range dec.Statics {
+		if w, ok := item.(*types.Withdrawal); !ok || w == nil || w.Index != uint64(i+1) {
+			t.Errorf("static item %d mismatch: have %#v", i, item)
+		}
+	}
+	if dec.Statics[3] != reused {
+		t.Errorf("matching static item not reused")
+	}
+	for i, item := range dec.Dynamics {
+		if p, ok := item.(*types.ExecutionPayload); !ok || p == nil || p.BlockNumber != uint64(i+5) {
+			t.Errorf("dynamic item %d mismatch: have %#v", i, item)
+		}
+	}
+	if ssz.HashSequential(obj) != ssz.HashSequential(dec) {
+		t.Errorf("hash mismatch after reuse round trip")
+	}
+	// Factories creating zero sized static items must be rejected, not crash
+	empty := &testZeroFactoryType{Statics: []ssz.StaticObject{&types.Withdrawal{}}}
+	blob = make([]byte, ssz.Size(empty))
+	if err := ssz.EncodeToBytes(blob, empty); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	err := ssz.DecodeFromBytes(blob, new(testZeroFactoryType))
+	if !errors.Is(err, ssz.ErrZeroSizeItem) {
+		t.Errorf("decoding error mismatch: have %v, want %v", err, ssz.ErrZeroSizeItem)
+	}
+	var derr *ssz.DecodeError
+	if !errors.As(err, &derr) || derr.Kind != ssz.KindZeroSizeItem {
+		t.Errorf("decoding error kind mismatch: have %v, want %v", err, ssz.KindZeroSizeItem)
+	}
+}
+
+type testFactoryType struct {
+	Statics  []ssz.StaticObject
+	Dynamics []ssz.DynamicObject
+}
+
+func (t *testFactoryType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8
+	}
+	return 8 + ssz.SizeSliceOfStaticObjects(t.Statics) + ssz.SizeSliceOfDynamicObjects(t.Dynamics)
+}
+
+func (t *testFactoryType) DefineSSZ(codec *ssz.Codec) {
+	newStatic := func() ssz.StaticObject { return new(types.Withdrawal) }
+	newDynamic := func() ssz.DynamicObject { return new(types.ExecutionPayload) }
+
+	ssz.DefineSliceOfStaticObjectsOffsetFunc(codec, &t.Statics, 16, newStatic)
+	ssz.DefineSliceOfDynamicObjectsOffsetFunc(codec, &t.Dynamics, 16, newDynamic)
+	ssz.DefineSliceOfStaticObjectsContentFunc(codec, &t.Statics, 16, newStatic)
+	ssz.DefineSliceOfDynamicObjectsContentFunc(codec, &t.Dynamics, 16, newDynamic)
+}
+
+type testZeroFactoryType struct {
+	Statics []ssz.StaticObject
+}
+
+func (t *testZeroFactoryType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeSliceOfStaticObjects(t.Statics)
+}
+
+func (t *testZeroFactoryType) DefineSSZ(codec *ssz.Codec) {
+	newStatic := func() ssz.StaticObject { return new(testEmptyStatic) }
+
+	ssz.DefineSliceOfStaticObjectsOffsetFunc(codec, &t.Statics, 16, newStatic)
+	ssz.DefineSliceOfStaticObjectsContentFunc(codec, &t.Statics, 16, newStatic)
+}
+
+type testEmptyStatic struct{}
+
+func (t *testEmptyStatic) SizeSSZ() uint32 { return 0 }
+
+func (t *testEmptyStatic) DefineSSZ(codec *ssz.Codec) {}
+
+// puregoBuild is set if the tests are built with the purego tag, where some of
+// the zero-alloc guarantees do not hold.
+var puregoBuild bool
+
+// Tests that decoding into a previously decoded object reuses all its memory,
+// including nested dynamic objects and bitlists, without any allocations.
+func TestDecodeReuseNoAllocs(t *testing.T) {
+	newAttestation := func(bits byte) *types.Attestation {
+		return &types.Attestation{
+			AggregationBits: bitfield.Bitlist{bits, 0x01},
+			Data: &types.AttestationData{
+				Source: new(types.Checkpoint),
+				Target: new(types.Checkpoint),
+			},
+		}
+	}
+	obj := &types.BeaconBlockBody{
+		Eth1Data:     new(types.Eth1Data),
+		Attestations: []*types.Attestation{newAttestation(0x0f), newAttestation(0xf0)},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	dec := new(types.BeaconBlockBody)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	attestation := dec.Attestations[1]
+
+	allocs := testing.AllocsPerRun(10, func() {
+		if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+			t.Fatalf("failed to redecode object: %v", err)
+		}
+	})
+	if allocs != 0 && !puregoBuild {
+		t.Errorf("allocations mismatch: have %v, want 0", allocs)
+	}
+	if dec.Attestations[1] != attestation {
+		t.Errorf("nested object not reused")
+	}
+	if ssz.HashSequential(obj) != ssz.HashSequential(dec) {
+		t.Errorf("hash mismatch after reuse round trip")
+	}
+}
+
+// Tests that a huge declared list length in a stream, not backed by actual data,
+// does not trigger a huge allocation up front.
+func TestDecodeUnbackedStreamList(t *testing.T) {
+	blob := []byte{0x04, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	before := stats.TotalAlloc
+
+	err := ssz.DecodeFromStream(bytes.NewReader(blob), new(testBigListType), 4+8*(1<<26))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	runtime.ReadMemStats(&stats)
+	if allocated := stats.TotalAlloc - before; allocated > 1<<20 {
+		t.Errorf("allocated too much memory: have %d bytes, want < %d", allocated, 1<<20)
+	}
+}
+
+type testBigListType struct {
+	Items []uint64
+}
+
+func (t *testBigListType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeSliceOfUint64s(t.Items)
+}
+
+func (t *testBigListType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfUint64sOffset(codec, &t.Items, 1<<26)
+	ssz.DefineSliceOfUint64sContent(codec, &t.Items, 1<<26)
+}
+
+// Tests that padding between the static and dynamic sections of an object is
+// rejected, even if the decoder was previously used for a valid object.
+func TestDecodeOffsetGap(t *testing.T) {
+	valid := []byte{0x04, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	gapped := []byte{0x05, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+
+	for i := 0; i < 2; i++ {
+		if err := ssz.DecodeFromBytes(valid, new(testBigListType)); err != nil {
+			t.Fatalf("run %d: failed to decode valid object: %v", i, err)
+		}
+		if err := ssz.DecodeFromBytes(gapped, new(testBigListType)); !errors.Is(err, ssz.ErrFirstOffsetMismatch) {
+			t.Errorf("run %d: decode from bytes error mismatch: have %v, want %v", i, err, ssz.ErrFirstOffsetMismatch)
+		}
+		if err := ssz.DecodeFromStream(bytes.NewReader(gapped), new(testBigListType), uint32(len(gapped))); !errors.Is(err, ssz.ErrFirstOffsetMismatch) {
+			t.Errorf("run %d: decode from stream error mismatch: have %v, want %v", i, err, ssz.ErrFirstOffsetMismatch)
+		}
+	}
+}
+
+// Tests that padding between the static and dynamic sections of an object is
+// accepted but recorded in relaxed mode, and that strict mode overrides it.
+func TestDecodeRelaxFirstOffset(t *testing.T) {
+	valid := []byte{0x04, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	gapped := []byte{0x05, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+
+	cfg := &ssz.DecoderConfig{RelaxFirstOffset: true}
+	for _, stream := range []bool{false, true} {
+		obj := new(testBigListType)
+
+		var (
+			warns []error
+			err   error
+		)
+		if stream {
+			warns, err = ssz.DecodeFromStreamWithWarnings(bytes.NewReader(gapped), obj, uint32(len(gapped)), cfg)
+		} else {
+			warns, err = ssz.DecodeFromBytesWithWarnings(gapped, obj, cfg)
+		}
+		if err != nil {
+			t.Fatalf("stream %v: failed to decode padded object: %v", stream, err)
+		}
+		if !reflect.DeepEqual(obj.Items, []uint64{1}) {
+			t.Errorf("stream %v: decoded items mismatch: have %v, want %v", stream, obj.Items, []uint64{1})
+		}
+		if len(warns) != 1 {
+			t.Fatalf("stream %v: warning count mismatch: have %d, want %d", stream, len(warns), 1)
+		}
+		var derr *ssz.DecodeError
+		if !errors.As(warns[0], &derr) || derr.Kind != ssz.KindFirstOffsetMismatch || derr.Offset != 0 {
+			t.Errorf("stream %v: warning mismatch: have %v", stream, warns[0])
+		}
+		// Ensure warnings are not carried over into the next decoding run
+		warns, err = ssz.DecodeFromBytesWithWarnings(valid, new(testBigListType), cfg)
+		if err != nil {
+			t.Fatalf("stream %v: failed to decode valid object: %v", stream, err)
+		}
+		if len(warns) != 0 {
+			t.Errorf("stream %v: unexpected warnings: %v", stream, warns)
+		}
+	}
+	// Ensure offsets pointing into the static section are still rejected
+	overlap := []byte{0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	if err := ssz.DecodeFromBytesWithConfig(overlap, new(testBigListType), cfg); !errors.Is(err, ssz.ErrFirstOffsetMismatch) {
+		t.Errorf("overlap error mismatch: have %v, want %v", err, ssz.ErrFirstOffsetMismatch)
+	}
+	// Ensure strict mode overrides the relaxation
+	strict := &ssz.DecoderConfig{RelaxFirstOffset: true, Strict: true}
+	if err := ssz.DecodeFromBytesWithConfig(gapped, new(testBigListType), strict); !errors.Is(err, ssz.ErrFirstOffsetMismatch) {
+		t.Errorf("strict error mismatch: have %v, want %v", err, ssz.ErrFirstOffsetMismatch)
+	}
+}
+
+// Tests that strict mode rejects inputs that decode fine, but would re-encode
+// into a different blob.
+func TestDecodeStrict(t *testing.T) {
+	blob := []byte{0x04, 0x00, 0x00, 0x00, 0x01, 0x00}
+
+	if err := ssz.DecodeFromBytes(blob, new(testTrimmingType)); err != nil {
+		t.Errorf("failed to decode in lenient mode: %v", err)
+	}
+	cfg := &ssz.DecoderConfig{Strict: true}
+	if err := ssz.DecodeFromBytesWithConfig(blob, new(testTrimmingType), cfg); !errors.Is(err, ssz.ErrNonCanonicalEncoding) {
+		t.Errorf("decode from bytes error mismatch: have %v, want %v", err, ssz.ErrNonCanonicalEncoding)
+	}
+	if err := ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), new(testTrimmingType), uint32(len(blob)), cfg); !errors.Is(err, ssz.ErrNonCanonicalEncoding) {
+		t.Errorf("decode from stream error mismatch: have %v, want %v", err, ssz.ErrNonCanonicalEncoding)
+	}
+}
+
+// testTrimmingType is a type whose decoder drops trailing zero bytes from the
+// blob, making the decoding lossy (i.e. non-canonical inputs are accepted).
+type testTrimmingType struct {
+	Blob []byte
+}
+
+func (t *testTrimmingType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeDynamicBytes(t.Blob)
+}
+
+func (t *testTrimmingType) DefineSSZ(codec *ssz.Codec) {
+	codec.DefineEncoder(func(enc *ssz.Encoder) {
+		ssz.EncodeDynamicBytesOffset(enc, t.Blob)
+		ssz.EncodeDynamicBytesContent(enc, t.Blob)
+	})
+	codec.DefineDecoder(func(dec *ssz.Decoder) {
+		ssz.DecodeDynamicBytesOffset(dec, &t.Blob)
+		ssz.DecodeDynamicBytesContent(dec, &t.Blob, 32)
+		t.Blob = bytes.TrimRight(t.Blob, "\x00")
+	})
+	codec.DefineHasher(func(has *ssz.Hasher) {
+		ssz.HashDynamicBytes(has, t.Blob, 32)
+	})
+}
+
+// Tests that the decoder correctly reports its progress through the input, both
+// in buffered and streaming mode.
+func TestDecodeProgress(t *testing.T) {
+	blob := make([]byte, 16)
+
+	obj := new(testProgressType)
+	if err := ssz.DecodeFromBytes(blob, obj); err != nil {
+		t.Fatalf("failed to decode from bytes: %v", err)
+	}
+	if obj.consumed != [2]uint32{0, 8} || obj.remaining != [2]uint32{16, 8} {
+		t.Errorf("buffered progress mismatch: consumed %v, remaining %v", obj.consumed, obj.remaining)
+	}
+	obj = new(testProgressType)
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), obj, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to decode from stream: %v", err)
+	}
+	if obj.consumed != [2]uint32{0, 8} || obj.remaining != [2]uint32{16, 8} {
+		t.Errorf("streaming progress mismatch: consumed %v, remaining %v", obj.consumed, obj.remaining)
+	}
+}
+
+type testProgressType struct {
+	A, B uint64
+
+	consumed  [2]uint32
+	remaining [2]uint32
+}
+
+func (t *testProgressType) SizeSSZ() uint32 { return 16 }
+
+func (t *testProgressType) DefineSSZ(codec *ssz.Codec) {
+	codec.DefineDecoder(func(dec *ssz.Decoder) {
+		t.consumed[0], t.remaining[0] = dec.Consumed(), dec.Remaining()
+		ssz.DecodeUint64(dec, &t.A)
+		t.consumed[1], t.remaining[1] = dec.Consumed(), dec.Remaining()
+		ssz.DecodeUint64(dec, &t.B)
+	})
+}
+
+// Tests that unknown static and dynamic fields can be skipped over.
+func TestDecodeSkipFields(t *testing.T) {
+	blob := []byte{
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // A
+		0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // unknown static
+		0x14, 0x00, 0x00, 0x00, // unknown dynamic offset
+		0xff, 0xee, // unknown dynamic content
+	}
+	obj := new(testSkipType)
+	if err := ssz.DecodeFromBytes(blob, obj); err != nil || obj.A != 1 {
+		t.Errorf("failed to decode from bytes: %v, A %d", err, obj.A)
+	}
+	obj = new(testSkipType)
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), obj, uint32(len(blob))); err != nil || obj.A != 1 {
+		t.Errorf("failed to decode from stream: %v, A %d", err, obj.A)
+	}
+}
+
+type testSkipType struct {
+	A uint64
+}
+
+func (t *testSkipType) SizeSSZ(fixed bool) uint32 { return 20 }
+
+func (t *testSkipType) DefineSSZ(codec *ssz.Codec) {
+	codec.DefineDecoder(func(dec *ssz.Decoder) {
+		ssz.DecodeUint64(dec, &t.A)
+		ssz.DecodeSkipStatic(dec, 8)
+		ssz.DecodeSkipDynamicOffset(dec)
+		ssz.DecodeSkipDynamicContent(dec)
+	})
+}
+
+// Tests that containers with unknown trailing fields can be decoded in forward
+// compatible mode, but are rejected otherwise.
+func TestDecodeForwardCompatible(t *testing.T) {
+	cfg := &ssz.DecoderConfig{ForwardCompatible: true}
+
+	// Static container with an unknown trailing field
+	blob := []byte{
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // A
+		0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // B
+		0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // unknown
+	}
+	if err := ssz.DecodeFromBytes(blob, new(testMissizedType)); !errors.Is(err, ssz.ErrObjectSlotSizeMismatch) {
+		t.Errorf("static decode error mismatch: have %v, want %v", err, ssz.ErrObjectSlotSizeMismatch)
+	}
+	static := new(testMissizedType)
+	if err := ssz.DecodeFromBytesWithConfig(blob, static, cfg); err != nil || static.A != 1 || static.B != 2 {
+		t.Errorf("failed to decode static from bytes: %v, A %d, B %d", err, static.A, static.B)
+	}
+	static = new(testMissizedType)
+	if err := ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), static, uint32(len(blob)), cfg); err != nil || static.A != 1 || static.B != 2 {
+		t.Errorf("failed to decode static from stream: %v, A %d, B %d", err, static.A, static.B)
+	}
+	// Dynamic container with an unknown trailing static field
+	blob = []byte{
+		0x0c, 0x00, 0x00, 0x00, // Items offset
+		0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // unknown
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Items
+	}
+	if err := ssz.DecodeFromBytes(blob, new(testBigListType)); !errors.Is(err, ssz.ErrFirstOffsetMismatch) {
+		t.Errorf("dynamic decode error mismatch: have %v, want %v", err, ssz.ErrFirstOffsetMismatch)
+	}
+	dynamic := new(testBigListType)
+	if err := ssz.DecodeFromBytesWithConfig(blob, dynamic, cfg); err != nil || len(dynamic.Items) != 1 || dynamic.Items[0] != 1 {
+		t.Errorf("failed to decode dynamic from bytes: %v, items %v", err, dynamic.Items)
+	}
+	dynamic = new(testBigListType)
+	if err := ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), dynamic, uint32(len(blob)), cfg); err != nil || len(dynamic.Items) != 1 || dynamic.Items[0] != 1 {
+		t.Errorf("failed to decode dynamic from stream: %v, items %v", err, dynamic.Items)
+	}
+	// Dynamic container with an unknown trailing dynamic field, whose offset must
+	// bound the content of the last known field
+	blob = []byte{
+		0x08, 0x00, 0x00, 0x00, // Items offset
+		0x10, 0x00, 0x00, 0x00, // unknown offset
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Items
+		0xff, 0xee, // unknown content
+	}
+	dynamic = new(testBigListType)
+	if err := ssz.DecodeFromBytesWithConfig(blob, dynamic, cfg); err != nil || len(dynamic.Items) != 1 || dynamic.Items[0] != 1 {
+		t.Errorf("failed to decode dynamic with unknown content from bytes: %v, items %v", err, dynamic.Items)
+	}
+	dynamic = new(testBigListType)
+	if err := ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), dynamic, uint32(len(blob)), cfg); err != nil || len(dynamic.Items) != 1 || dynamic.Items[0] != 1 {
+		t.Errorf("failed to decode dynamic with unknown content from stream: %v, items %v", err, dynamic.Items)
+	}
+}
+
+// Tests that in resync mode, list elements failing to decode are skipped over,
+// reporting their indices, whilst the rest of the object is decoded.
+func TestDecodeResyncElements(t *testing.T) {
+	// Create a state with a corrupt validator in a list of static objects
+	state := new(types.BeaconState)
+	random.Fill(rand.New(rand.NewSource(1)), state)
+	state.Validators = []*types.Validator{{Pubkey: [48]byte{1}}, {Pubkey: [48]byte{2}}, {Pubkey: [48]byte{3}}}
+
+	stateBlob := make([]byte, ssz.Size(state))
+	if err := ssz.EncodeToBytes(stateBlob, state); err != nil {
+		t.Fatalf("failed to encode state: %v", err)
+	}
+	validator := make([]byte, ssz.Size(state.Validators[1]))
+	ssz.EncodeToBytes(validator, state.Validators[1])
+	stateBlob[bytes.Index(stateBlob, validator)+88] = 2 // slashed flag
+
+	// Create a block body with a corrupt attestation in a list of dynamic objects
+	body := new(types.BeaconBlockBody)
+	random.Fill(rand.New(rand.NewSource(1)), body)
+	body.Attestations = make([]*types.Attestation, 3)
+	for i := range body.Attestations {
+		body.Attestations[i] = &types.Attestation{
+			AggregationBits: bitfield.Bitlist{byte(i), 0x01},
+			Data: &types.AttestationData{
+				Slot:   types.Slot(i),
+				Source: new(types.Checkpoint),
+				Target: new(types.Checkpoint),
+			},
+		}
+	}
+	bodyBlob := make([]byte, ssz.Size(body))
+	if err := ssz.EncodeToBytes(bodyBlob, body); err != nil {
+		t.Fatalf("failed to encode body: %v", err)
+	}
+	attestation := make([]byte, ssz.Size(body.Attestations[1]))
+	ssz.EncodeToBytes(attestation, body.Attestations[1])
+	bodyBlob[bytes.Index(bodyBlob, attestation)+len(attestation)-1] = 0 // bitlist sentinel
+
+	tests := []struct {
+		blob  []byte
+		obj   func() ssz.Object
+		check func(ssz.Object) bool
+		want  error
+	}{
+		{stateBlob, func() ssz.Object { return new(types.BeaconState) }, func(obj ssz.Object) bool {
+			have := obj.(*types.BeaconState)
+			return len(have.Validators) == 3 && *have.Validators[2] == *state.Validators[2] && have.Slot == state.Slot
+		}, ssz.ErrInvalidBoolean},
+		{bodyBlob, func() ssz.Object { return new(types.BeaconBlockBody) }, func(obj ssz.Object) bool {
+			have := obj.(*types.BeaconBlockBody)
+			return len(have.Attestations) == 3 && have.Attestations[2].Data.Slot == 2 && bytes.Equal(have.Graffiti[:], body.Graffiti[:])
+		}, ssz.ErrJunkInBitlist},
+	}
+	for i, tt := range tests {
+		for _, stream := range []bool{false, true} {
+			decode := func(cfg *ssz.DecoderConfig) (ssz.Object, error) {
+				obj := tt.obj()
+				if stream {
+					return obj, ssz.DecodeFromStreamWithConfig(bytes.NewReader(tt.blob), obj, uint32(len(tt.blob)), cfg)
+				}
+				return obj, ssz.DecodeFromBytesWithConfig(tt.blob, obj, cfg)
+			}
+			// Without resyncing, the corrupt element should fail decoding
+			if _, err := decode(nil); !errors.Is(err, tt.want) {
+				t.Errorf("test %d, stream %v: error mismatch: have %v, want %v", i, stream, err, tt.want)
+			}
+			// With resyncing, only the corrupt element should be reported
+			obj, err := decode(&ssz.DecoderConfig{ResyncElements: true})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("test %d, stream %v: resync error mismatch: have %v, want %v", i, stream, err, tt.want)
+			}
+			var eerr *ssz.ElementError
+			if !errors.As(err, &eerr) || eerr.Index != 1 {
+				t.Errorf("test %d, stream %v: element error mismatch: have %v", i, stream, err)
+			}
+			if !tt.check(obj) {
+				t.Errorf("test %d, stream %v: remaining elements not decoded", i, stream)
+			}
+		}
+	}
+}
+
+// Tests that in truncating mode, lists and blobs exceeding their limits are cut
+// back to the limit with a warning, and the rest of the object is decoded.
+func TestDecodeTruncateOversized(t *testing.T) {
+	obj := &testTruncateType{
+		Blob:    []byte{1, 2, 3, 4},
+		Nums:    []uint64{1, 2, 3},
+		Roots:   [][32]byte{{1}, {2}, {3}},
+		Checks:  []*types.Checkpoint{{Epoch: 1}, {Epoch: 2}, {Epoch: 3}},
+		Blobs:   [][]byte{{1}, {2, 2}, {3, 3, 3}},
+		Attests: []*types.Attestation{newTestAttestation(1), newTestAttestation(2), newTestAttestation(3)},
+		Name:    "truncated",
+		limit:   16,
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	want := &testTruncateType{
+		Blob:    obj.Blob[:2],
+		Nums:    obj.Nums[:2],
+		Roots:   obj.Roots[:2],
+		Checks:  obj.Checks[:2],
+		Blobs:   obj.Blobs[:2],
+		Attests: obj.Attests[:2],
+		Name:    obj.Name[:2],
+		limit:   2,
+	}
+	for _, stream := range []bool{false, true} {
+		var warnings []error
+		decode := func(cfg *ssz.DecoderConfig) (*testTruncateType, error) {
+			var (
+				obj = &testTruncateType{limit: 2}
+				err error
+			)
+			if stream {
+				warnings, err = ssz.DecodeFromStreamWithWarnings(bytes.NewReader(blob), obj, uint32(len(blob)), cfg)
+			} else {
+				warnings, err = ssz.DecodeFromBytesWithWarnings(blob, obj, cfg)
+			}
+			return obj, err
+		}
+		// By default (and in strict mode), oversized fields should be rejected
+		if _, err := decode(nil); !errors.Is(err, ssz.ErrMaxLengthExceeded) {
+			t.Errorf("stream %v: error mismatch: have %v, want %v", stream, err, ssz.ErrMaxLengthExceeded)
+		}
+		if _, err := decode(&ssz.DecoderConfig{TruncateOversized: true, Strict: true}); !errors.Is(err, ssz.ErrMaxLengthExceeded) {
+			t.Errorf("stream %v: strict error mismatch: have %v, want %v", stream, err, ssz.ErrMaxLengthExceeded)
+		}
+		// In truncating mode, all fields should be cut back with a warning each
+		cfg := &ssz.DecoderConfig{TruncateOversized: true}
+		have, err := decode(cfg)
+		if err != nil {
+			t.Fatalf("stream %v: failed to decode oversized object: %v", stream, err)
+		}
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("stream %v: truncated object mismatch: have %+v, want %+v", stream, have, want)
+		}
+		if len(warnings) != 7 {
+			t.Errorf("stream %v: warning count mismatch: have %d, want %d", stream, len(warnings), 7)
+		} else if !errors.Is(warnings[1], ssz.ErrMaxItemsExceeded) {
+			t.Errorf("stream %v: warning mismatch: have %v, want %v", stream, warnings[1], ssz.ErrMaxItemsExceeded)
+		}
+	}
+}
+
+// testTruncateType is a type with all kinds of limited fields, the limits being
+// configurable to create oversized encodings.
+type testTruncateType struct {
+	Blob    []byte
+	Nums    []uint64
+	Roots   [][32]byte
+	Checks  []*types.Checkpoint
+	Blobs   [][]byte
+	Attests []*types.Attestation
+	Name    string
+
+	limit uint64
+}
+
+func (t *testTruncateType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 7 * 4
+	}
+	return 7*4 + ssz.SizeDynamicBytes(t.Blob) + ssz.SizeSliceOfUint64s(t.Nums) +
+		ssz.SizeSliceOfStaticBytes(t.Roots) + ssz.SizeSliceOfStaticObjects(t.Checks) +
+		ssz.SizeSliceOfDynamicBytes(t.Blobs) + ssz.SizeSliceOfDynamicObjects(t.Attests) +
+		ssz.SizeDynamicString(t.Name)
+}
+
+func (t *testTruncateType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicBytesOffset(codec, &t.Blob, t.limit)
+	ssz.DefineSliceOfUint64sOffset(codec, &t.Nums, t.limit)
+	ssz.DefineSliceOfStaticBytesOffset(codec, &t.Roots, t.limit)
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Checks, t.limit)
+	ssz.DefineSliceOfDynamicBytesOffset(codec, &t.Blobs, t.limit, 16)
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &t.Attests, t.limit)
+	ssz.DefineDynamicStringOffset(codec, &t.Name, t.limit)
+
+	ssz.DefineDynamicBytesContent(codec, &t.Blob, t.limit)
+	ssz.DefineSliceOfUint64sContent(codec, &t.Nums, t.limit)
+	ssz.DefineSliceOfStaticBytesContent(codec, &t.Roots, t.limit)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Checks, t.limit)
+	ssz.DefineSliceOfDynamicBytesContent(codec, &t.Blobs, t.limit, 16)
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &t.Attests, t.limit)
+	ssz.DefineDynamicStringContent(codec, &t.Name, t.limit)
+}
+
+// newTestAttestation creates a minimal attestation for the given slot.
+func newTestAttestation(slot uint64) *types.Attestation {
+	return &types.Attestation{
+		AggregationBits: bitfield.Bitlist{0x01},
+		Data: &types.AttestationData{
+			Slot:   types.Slot(slot),
+			Source: new(types.Checkpoint),
+			Target: new(types.Checkpoint),
+		},
+	}
+}
+
+// Tests that pathologically deep nesting is rejected by default, but can be
+// permitted by raising the maximum depth.
+func TestDecodeMaxDepth(t *testing.T) {
+	// Every level of the chain nests a list and an item, i.e. 2 levels deep
+	chain := func(n int) *testDeepType {
+		obj := new(testDeepType)
+		for i := 0; i < n; i++ {
+			obj = &testDeepType{Children: []*testDeepType{obj}}
+		}
+		return obj
+	}
+	tests := []struct {
+		levels int
+		depth  int
+		fail   bool
+	}{
+		{15, 0, false}, // 31 levels
+		{16, 0, true},  // 33 levels
+		{16, 33, false},
+		{3, 6, true}, // 7 levels
+	}
+	for i, tt := range tests {
+		obj := chain(tt.levels)
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("test %d: failed to encode object: %v", i, err)
+		}
+		cfg := &ssz.DecoderConfig{MaxDepth: tt.depth}
+		for _, stream := range []bool{false, true} {
+			var err error
+			if stream {
+				err = ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), new(testDeepType), uint32(len(blob)), cfg)
+			} else {
+				err = ssz.DecodeFromBytesWithConfig(blob, new(testDeepType), cfg)
+			}
+			if tt.fail && !errors.Is(err, ssz.ErrMaxDepthExceeded) {
+				t.Errorf("test %d, stream %v: error mismatch: have %v, want %v", i, stream, err, ssz.ErrMaxDepthExceeded)
+			}
+			if !tt.fail && err != nil {
+				t.Errorf("test %d, stream %v: failed to decode: %v", i, stream, err)
+			}
+		}
+	}
+}
+
+// testDeepType is a recursive type, permitting arbitrarily deep nesting.
+type testDeepType struct {
+	Children []*testDeepType
+}
+
+func (t *testDeepType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeSliceOfDynamicObjects(t.Children)
+}
+
+func (t *testDeepType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &t.Children, 1)
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &t.Children, 1)
+}
+
+// Tests that decoding dynamic content without a decoded offset (i.e. a decoder
+// asymmetric with its encoder) is rejected instead of crashing.
+func TestDecodeMissingOffset(t *testing.T) {
+	obj := &testMissingOffsetType{A: []byte{1}, B: []byte{2, 3}}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if err := ssz.DecodeFromBytes(blob, new(testMissingOffsetType)); !errors.Is(err, ssz.ErrMissingOffset) {
+		t.Errorf("buffer error mismatch: have %v, want %v", err, ssz.ErrMissingOffset)
+	}
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), new(testMissingOffsetType), uint32(len(blob))); !errors.Is(err, ssz.ErrMissingOffset) {
+		t.Errorf("stream error mismatch: have %v, want %v", err, ssz.ErrMissingOffset)
+	}
+}
+
+// testMissingOffsetType is a type whose decoder forgets the offset of its second
+// dynamic field.
+type testMissingOffsetType struct {
+	A []byte
+	B []byte
+}
+
+func (t *testMissingOffsetType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8
+	}
+	return 8 + ssz.SizeDynamicBytes(t.A) + ssz.SizeDynamicBytes(t.B)
+}
+
+func (t *testMissingOffsetType) DefineSSZ(codec *ssz.Codec) {
+	codec.DefineEncoder(func(enc *ssz.Encoder) {
+		ssz.EncodeDynamicBytesOffset(enc, t.A)
+		ssz.EncodeDynamicBytesOffset(enc, t.B)
+		ssz.EncodeDynamicBytesContent(enc, t.A)
+		ssz.EncodeDynamicBytesContent(enc, t.B)
+	})
+	codec.DefineDecoder(func(dec *ssz.Decoder) {
+		ssz.DecodeDynamicBytesOffset(dec, &t.A)
+		ssz.DecodeStaticBytes(dec, new([4]byte))
+		ssz.DecodeDynamicBytesContent(dec, &t.A, 8)
+		ssz.DecodeDynamicBytesContent(dec, &t.B, 8)
+	})
+	codec.DefineHasher(func(has *ssz.Hasher) {
+		ssz.HashDynamicBytes(has, t.A, 8)
+		ssz.HashDynamicBytes(has, t.B, 8)
+	})
+}
+
+// Fuzzes the offset handling of the decoder with malformed offset tables (e.g.
+// wrapping around, decreasing, pointing beyond the data), checking that they
+// never crash the decoder and are always rejected with a classified error.
+func FuzzDecodeOffsets(f *testing.F) {
+	valid := &testTruncateType{
+		Blob:    []byte{1, 2},
+		Nums:    []uint64{1},
+		Roots:   [][32]byte{{1}},
+		Checks:  []*types.Checkpoint{{Epoch: 1}},
+		Blobs:   [][]byte{{1}, {2}},
+		Attests: []*types.Attestation{newTestAttestation(1)},
+		Name:    "a",
+		limit:   4,
+	}
+	seed := make([]byte, ssz.Size(valid))
+	if err := ssz.EncodeToBytes(seed, valid); err != nil {
+		f.Fatalf("failed to encode seed: %v", err)
+	}
+	f.Add(seed)
+	for _, offset := range []uint32{0, 3, 27, 29, 0x7fffffff, 0xfffffffc, 0xffffffff} {
+		for field := 0; field < 7; field++ {
+			blob := bytes.Clone(seed)
+			binary.LittleEndian.PutUint32(blob[4*field:], offset)
+			f.Add(blob)
+		}
+	}
+	f.Fuzz(func(t *testing.T, blob []byte) {
+		for _, fresh := range []func() ssz.Object{
+			func() ssz.Object { return &testTruncateType{limit: 4} },
+			func() ssz.Object { return new(testMissingOffsetType) },
+			func() ssz.Object { return new(testDeepType) },
+		} {
+			errs := []error{
+				ssz.DecodeFromBytes(blob, fresh()),
+				ssz.DecodeFromStream(bytes.NewReader(blob), fresh(), uint32(len(blob))),
+			}
+			for _, err := range errs {
+				var derr *ssz.DecodeError
+				if err != nil && (!errors.As(err, &derr) || derr.Kind == ssz.KindUnknown) {
+					t.Fatalf("unclassified decoding error: %v", err)
+				}
+			}
+		}
+	})
+}
+
+// Tests that decoding large lists concurrently produces the same results as the
+// sequential decoder, both for valid and invalid inputs.
+func TestDecodeConcurrent(t *testing.T) {
+	obj := &testConcurrentType{
+		Validators: make([]*types.Validator, 2000),
+		Nested:     []*testBigListType{},
+	}
+	for i := range obj.Validators {
+		obj.Validators[i] = &types.Validator{EffectiveBalance: uint64(i), Slashed: i%2 == 0}
+		obj.Validators[i].Pubkey[0] = byte(i)
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	cfg := &ssz.DecoderConfig{Concurrent: true}
+
+	dec := new(testConcurrentType)
+	if err := ssz.DecodeFromBytesWithConfig(blob, dec, cfg); err != nil {
+		t.Fatalf("failed to decode object concurrently: %v", err)
+	}
+	if !reflect.DeepEqual(dec.Validators, obj.Validators) {
+		t.Errorf("concurrently decoded validators mismatch")
+	}
+	// Corrupt a validator in the middle and ensure the same error is reported
+	blob[16+1500*121+88] = 0x02 // Slashed flag of validator #1500
+
+	want := ssz.DecodeFromBytes(blob, new(testConcurrentType))
+	have := ssz.DecodeFromBytesWithConfig(blob, new(testConcurrentType), cfg)
+
+	var wantErr, haveErr *ssz.DecodeError
+	if !errors.As(want, &wantErr) || !errors.As(have, &haveErr) {
+		t.Fatalf("decode error type mismatch: have %v, want %v", have, want)
+	}
+	if haveErr.Kind != ssz.KindInvalidBoolean || haveErr.Offset != wantErr.Offset {
+		t.Errorf("decode error mismatch: have %v, want %v", have, want)
+	}
+}
+
+// Tests that decoding large lists concurrently behaves the same way as the
+// sequential decoder under every decoder option.
+func TestDecodeConcurrentConfigs(t *testing.T) {
+	obj := &testConcurrentDataType{Items: make([]*types.AttestationData, 1000)}
+	for i := range obj.Items {
+		obj.Items[i] = &types.AttestationData{
+			Slot:   types.Slot(i),
+			Source: &types.Checkpoint{Epoch: uint64(i)},
+			Target: &types.Checkpoint{Epoch: uint64(2 * i)},
+		}
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	configs := []ssz.DecoderConfig{
+		{},
+		{Strict: true},
+		{ForwardCompatible: true},
+		{RelaxFirstOffset: true},
+		{ResyncElements: true},
+		{TruncateOversized: true},
+		{StopList: func(index uint32, item ssz.Object) bool { return index == 900 }},
+	}
+	for _, depth := range []int{1, 2, 3, 4} {
+		configs = append(configs, ssz.DecoderConfig{MaxDepth: depth})
+	}
+	for _, budget := range []uint64{1 << 10, 1 << 16, 1 << 17, 1 << 18, 1 << 20} {
+		configs = append(configs, ssz.DecoderConfig{MaxAlloc: budget})
+	}
+	for i, cfg := range configs {
+		// Cap the list below its length if oversized lists are truncated
+		limit := uint64(len(obj.Items))
+		if cfg.TruncateOversized {
+			limit = 800
+		}
+		seq := &testConcurrentDataType{limit: limit}
+		seqWarns, seqErr := ssz.DecodeFromBytesWithWarnings(blob, seq, &cfg)
+
+		cfg.Concurrent = true
+		conc := &testConcurrentDataType{limit: limit}
+		concWarns, concErr := ssz.DecodeFromBytesWithWarnings(blob, conc, &cfg)
+
+		switch {
+		case (seqErr == nil) != (concErr == nil):
+			t.Errorf("config %d: error mismatch: have %v, want %v", i, concErr, seqErr)
+		case seqErr != nil:
+			var seqDecErr, concDecErr *ssz.DecodeError
+			if !errors.As(seqErr, &seqDecErr) || !errors.As(concErr, &concDecErr) || seqDecErr.Kind != concDecErr.Kind {
+				t.Errorf("config %d: error mismatch: have %v, want %v", i, concErr, seqErr)
+			}
+		default:
+			if !reflect.DeepEqual(conc, seq) {
+				t.Errorf("config %d: decoded object mismatch", i)
+			}
+			if len(concWarns) != len(seqWarns) {
+				t.Errorf("config %d: warnings mismatch: have %v, want %v", i, concWarns, seqWarns)
+			}
+		}
+	}
+}
+
+// testConcurrentDataType is a container with a list of static objects large
+// enough to be decoded concurrently, needing allocations within the items.
+type testConcurrentDataType struct {
+	Items []*types.AttestationData
+	limit uint64
+}
+
+func (t *testConcurrentDataType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeSliceOfStaticObjects(t.Items)
+}
+
+func (t *testConcurrentDataType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Items, t.limit)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Items, t.limit)
+}
+
+// Tests that lists and vectors of static binary blobs are decoded identically
+// from buffers (bulk copied) and streams (read item by item), and that a short
+// input is rejected without reading past its end.
+func TestStaticBytesListsDecoding(t *testing.T) {
+	array := new(testZeroArrayType)
+	for i := range array.Roots {
+		array.Roots[i][0], array.Roots[i][31] = byte(i), byte(i+1)
+	}
+	checked := &testZeroCheckedType{Root: array.Root[:], Roots: array.Roots[:]}
+	roots := &testRootsType{Slot: 1, Roots: array.Roots[:]}
+
+	for _, obj := range []ssz.Object{array, checked, roots} {
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("%T: failed to encode object: %v", obj, err)
+		}
+		fromBytes := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(ssz.Object)
+		if err := ssz.DecodeFromBytes(blob, fromBytes); err != nil {
+			t.Fatalf("%T: failed to decode from bytes: %v", obj, err)
+		}
+		if !reflect.DeepEqual(fromBytes, obj) {
+			t.Errorf("%T: bytes decoded object mismatch: have %+v, want %+v", obj, fromBytes, obj)
+		}
+		fromStream := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(ssz.Object)
+		if err := ssz.DecodeFromStream(bytes.NewReader(blob), fromStream, uint32(len(blob))); err != nil {
+			t.Fatalf("%T: failed to decode from stream: %v", obj, err)
+		}
+		if !reflect.DeepEqual(fromStream, obj) {
+			t.Errorf("%T: stream decoded object mismatch: have %+v, want %+v", obj, fromStream, obj)
+		}
+	}
+	blob := make([]byte, ssz.Size(array))
+	if err := ssz.DecodeFromBytes(blob[:len(blob)-1], new(testZeroArrayType)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+// Ensure the staticness assertions compile for correctly declared types.
+var (
+	_ = ssz.AssertStatic[*types.Withdrawal]
+	_ = ssz.AssertDynamic[*types.ExecutionPayload]
+)
+
+// Tests that lists of objects can be cut short by a predicate, skipping over the
+// remaining items and decoding the rest of the object.
+func TestDecodeStopList(t *testing.T) {
+	obj := &testTruncateType{
+		Blob:    []byte{1, 2, 3, 4},
+		Nums:    []uint64{1, 2, 3},
+		Roots:   [][32]byte{{1}, {2}, {3}},
+		Checks:  []*types.Checkpoint{{Epoch: 1}, {Epoch: 2}, {Epoch: 3}},
+		Blobs:   [][]byte{{1}, {2, 2}, {3, 3, 3}},
+		Attests: []*types.Attestation{newTestAttestation(1), newTestAttestation(2), newTestAttestation(3)},
+		Name:    "stopped",
+		limit:   16,
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	stop := func(index uint32, item ssz.Object) bool {
+		switch item := item.(type) {
+		case *types.Checkpoint:
+			return item.Epoch == 2
+		case *types.Attestation:
+			return item.Data.Slot == 1
+		}
+		return false
+	}
+	want := &testTruncateType{
+		Blob:    obj.Blob,
+		Nums:    obj.Nums,
+		Roots:   obj.Roots,
+		Checks:  obj.Checks[:2],
+		Blobs:   obj.Blobs,
+		Attests: obj.Attests[:1],
+		Name:    obj.Name,
+		limit:   16,
+	}
+	for _, stream := range []bool{false, true} {
+		decode := func(cfg *ssz.DecoderConfig) (*testTruncateType, error) {
+			// Prefill the lists to check that the stale items are dropped
+			obj := &testTruncateType{
+				Checks:  make([]*types.Checkpoint, 3),
+				Attests: make([]*types.Attestation, 3),
+				limit:   16,
+			}
+			if stream {
+				return obj, ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), obj, uint32(len(blob)), cfg)
+			}
+			return obj, ssz.DecodeFromBytesWithConfig(blob, obj, cfg)
+		}
+		have, err := decode(&ssz.DecoderConfig{StopList: stop})
+		if err != nil {
+			t.Fatalf("stream %v: failed to decode object: %v", stream, err)
+		}
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("stream %v: stopped object mismatch: have %+v, want %+v", stream, have, want)
+		}
+		// In strict mode, the predicate should be ignored
+		have, err = decode(&ssz.DecoderConfig{StopList: stop, Strict: true})
+		if err != nil {
+			t.Fatalf("stream %v: failed to decode strict object: %v", stream, err)
+		}
+		if !reflect.DeepEqual(have, obj) {
+			t.Errorf("stream %v: strict object mismatch: have %+v, want %+v", stream, have, obj)
+		}
+	}
+}
+
+// Tests that lists of static objects decoded in one go via their flat decoders
+// match the ones decoded field by field.
+func TestFlatDecoding(t *testing.T) {
+	obj := &testFlatListsType{
+		Withdrawals: []*types.Withdrawal{
+			{Index: 1, Validator: 2, Address: types.Address{3}, Amount: 4},
+			{Index: 5, Validator: 6, Address: types.Address{7}, Amount: 8},
+		},
+		Variations: []*types.FlatVariation{
+			{Slot: 1, Kind: 2, Flags: 3, Count: 4, Address: types.Address{5}, Roots: [2]types.Hash{{6}, {7}}},
+			{Slot: 8, Kind: 9, Flags: 10, Count: 11, Address: types.Address{12}, Roots: [2]types.Hash{{13}, {14}}},
+		},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	// Decode into a partially prefilled object to check item reuse too
+	flat := &testFlatListsType{Withdrawals: []*types.Withdrawal{{Index: 9}}}
+	if err := ssz.DecodeFromBytes(blob, flat); err != nil {
+		t.Fatalf("failed to decode flat object: %v", err)
+	}
+	if !reflect.DeepEqual(flat, obj) {
+		t.Errorf("flat object mismatch: have %+v, want %+v", flat, obj)
+	}
+	// Tracing needs the items decoded field by field, which must match
+	plain := new(testFlatListsType)
+	if err := ssz.DecodeFromBytesWithConfig(blob, plain, &ssz.DecoderConfig{OnField: func([]int, uint32, uint32) {}}); err != nil {
+		t.Fatalf("failed to decode traced object: %v", err)
+	}
+	if !reflect.DeepEqual(plain, obj) {
+		t.Errorf("traced object mismatch: have %+v, want %+v", plain, obj)
+	}
+	// Flat decoding failures must be reported, and modes needing the items to be
+	// decoded one by one must not use the flat decoders
+	failing := &testFlatFailingListType{Items: []*testFlatFailingItem{{Value: 1}, {Value: 2}}}
+	blob = make([]byte, ssz.Size(failing))
+	if err := ssz.EncodeToBytes(blob, failing); err != nil {
+		t.Fatalf("failed to encode failing object: %v", err)
+	}
+	if err := ssz.DecodeFromBytes(blob, new(testFlatFailingListType)); !errors.Is(err, errTestFlatDecode) {
+		t.Errorf("flat decoding error mismatch: have %v, want %v", err, errTestFlatDecode)
+	}
+	for _, cfg := range []*ssz.DecoderConfig{{Strict: true}, {ResyncElements: true}, {TruncateOversized: true}} {
+		dec := new(testFlatFailingListType)
+		if err := ssz.DecodeFromBytesWithConfig(blob, dec, cfg); err != nil {
+			t.Errorf("config %+v: failed to decode object: %v", *cfg, err)
+			continue
+		}
+		if !reflect.DeepEqual(dec, failing) {
+			t.Errorf("config %+v: object mismatch: have %+v, want %+v", *cfg, dec, failing)
+		}
+	}
+}
+
+// errTestFlatDecode is the error returned by testFlatFailingItem's flat decoder.
+var errTestFlatDecode = errors.New("flat decoding failed")
+
+// testFlatFailingItem is a static object whose flat decoder always fails.
+type testFlatFailingItem struct {
+	Value uint64
+}
+
+func (t *testFlatFailingItem) SizeSSZ() uint32 { return 8 }
+
+func (t *testFlatFailingItem) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Value)
+}
+
+func (*testFlatFailingItem) DecodeFlatSSZ(blob []byte, objs []*testFlatFailingItem) error {
+	return errTestFlatDecode
+}
+
+type testFlatFailingListType struct {
+	Items []*testFlatFailingItem
+}
+
+func (t *testFlatFailingListType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeSliceOfStaticObjects(t.Items)
+}
+
+func (t *testFlatFailingListType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Items, 16)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Items, 16)
+}
+
+// testFlatListsType is a container with lists of static objects implementing
+// ssz.FlatDecoder.
+type testFlatListsType struct {
+	Withdrawals []*types.Withdrawal
+	Variations  []*types.FlatVariation
+}
+
+func (t *testFlatListsType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4 + 4
+	}
+	return 4 + 4 + ssz.SizeSliceOfStaticObjects(t.Withdrawals) + ssz.SizeSliceOfStaticObjects(t.Variations)
+}
+
+func (t *testFlatListsType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Withdrawals, 16)
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Variations, 16)
+
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Withdrawals, 16)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Variations, 16)
+}
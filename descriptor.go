@@ -0,0 +1,588 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/holiman/uint256"
+)
+
+// FieldKind is the ssz type of a container field, named after the Define method
+// that the field would be passed to in generated code.
+type FieldKind uint8
+
+const (
+	FieldBool                      FieldKind = iota + 1 // DefineBool
+	FieldUint8                                          // DefineUint8
+	FieldUint16                                         // DefineUint16
+	FieldUint32                                         // DefineUint32
+	FieldUint64                                         // DefineUint64
+	FieldUint256                                        // DefineUint256
+	FieldUint256BigInt                                  // DefineUint256BigInt
+	FieldStaticBytes                                    // DefineStaticBytes
+	FieldCheckedStaticBytes                             // DefineCheckedStaticBytes (Size bytes)
+	FieldDynamicBytes                                   // DefineDynamicBytes{Offset,Content} (MaxSize bytes)
+	FieldArrayOfBits                                    // DefineArrayOfBits (Size bits)
+	FieldSliceOfBits                                    // DefineSliceOfBits{Offset,Content} (MaxItems bits)
+	FieldArrayOfUint64s                                 // DefineArrayOfUint64s
+	FieldSliceOfUint64s                                 // DefineSliceOfUint64s{Offset,Content} (MaxItems)
+	FieldArrayOfStaticBytes                             // DefineArrayOfStaticBytes
+	FieldCheckedArrayOfStaticBytes                      // DefineCheckedArrayOfStaticBytes (Size items)
+	FieldSliceOfStaticBytes                             // DefineSliceOfStaticBytes{Offset,Content} (MaxItems)
+	FieldSliceOfDynamicBytes                            // DefineSliceOfDynamicBytes{Offset,Content} (MaxItems, MaxSize)
+	FieldStaticObject                                   // DefineStaticObject
+	FieldDynamicObject                                  // DefineDynamicObject{Offset,Content}
+	FieldSliceOfStaticObjects                           // DefineSliceOfStaticObjects{Offset,Content} (MaxItems)
+	FieldSliceOfDynamicObjects                          // DefineSliceOfDynamicObjects{Offset,Content} (MaxItems)
+)
+
+// dynamic returns whether the field kind is encoded as an offset in the static
+// part of the container, with its content following later.
+func (kind FieldKind) dynamic() bool {
+	switch kind {
+	case FieldDynamicBytes, FieldSliceOfBits, FieldSliceOfUint64s, FieldSliceOfStaticBytes,
+		FieldSliceOfDynamicBytes, FieldDynamicObject, FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects:
+		return true
+	default:
+		return false
+	}
+}
+
+// FieldDescriptor is the ssz schema of a single struct field. Only the sizes and
+// limits needed by the field's kind (see the FieldKind constants) must be set.
+type FieldDescriptor struct {
+	Name     string    // Name of the Go struct field
+	Kind     FieldKind // Type of the field in ssz terms
+	Size     uint64    // Static size of checked fields (bytes, bits or items)
+	MaxItems uint64    // Maximum number of items (or bits) of dynamic lists
+	MaxSize  uint64    // Maximum number of bytes of dynamic blobs
+}
+
+// Descriptor is a compact table describing the ssz schema of a container type,
+// interpreted via reflection by a small runtime walker. It is an alternative to
+// generating and instantiating code for every field of every type, trading some
+// CPU for a dramatically smaller binary when hundreds of types are needed.
+//
+// Descriptors are immutable after creation and safe for concurrent use. They
+// are meant to be created by sszgen (-mode=descriptor) as package variables and
+// used from the SizeSSZ and DefineSSZ methods of the described type:
+//
+//	func (obj *Withdrawal) DefineSSZ(codec *ssz.Codec) {
+//		descriptorWithdrawal.Define(codec, obj)
+//	}
+type Descriptor struct {
+	fields  []descriptorField // Fields resolved against the struct type
+	dynamic bool              // Whether any of the fields is dynamic
+}
+
+// descriptorField is a field descriptor resolved against its Go struct type.
+type descriptorField struct {
+	FieldDescriptor
+
+	index int          // Index of the field within the struct
+	elem  reflect.Type // Object type for object fields (without the pointer)
+}
+
+// NewDescriptor creates a descriptor for the type of obj (a pointer to a struct,
+// typically a typed nil) from a list of field schemas, in encoding order.
+//
+// Since descriptors are created from generated code on package initialization,
+// a schema not matching the Go type is a programming error and panics.
+func NewDescriptor(obj Object, fields ...FieldDescriptor) *Descriptor {
+	typ := reflect.TypeOf(obj)
+	if typ.Kind() != reflect.Pointer || typ.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("ssz: descriptor type %v is not a pointer to a struct", typ))
+	}
+	typ = typ.Elem()
+
+	desc := new(Descriptor)
+	for _, field := range fields {
+		sfield, ok := typ.FieldByName(field.Name)
+		if !ok || len(sfield.Index) != 1 {
+			panic(fmt.Sprintf("ssz: descriptor field %v.%s not found", typ, field.Name))
+		}
+		resolved := descriptorField{FieldDescriptor: field, index: sfield.Index[0]}
+		if !resolved.resolve(sfield.Type) {
+			panic(fmt.Sprintf("ssz: descriptor field %v.%s of type %v cannot be of kind %d", typ, field.Name, sfield.Type, field.Kind))
+		}
+		desc.fields = append(desc.fields, resolved)
+		desc.dynamic = desc.dynamic || field.Kind.dynamic()
+	}
+	return desc
+}
+
+// resolve checks that a Go type can be handled as the field's kind and caches
+// any type information needed at runtime.
+func (f *descriptorField) resolve(typ reflect.Type) bool {
+	switch f.Kind {
+	case FieldBool:
+		return convertible[bool](typ)
+	case FieldUint8:
+		return convertible[uint8](typ)
+	case FieldUint16:
+		return convertible[uint16](typ)
+	case FieldUint32:
+		return convertible[uint32](typ)
+	case FieldUint64:
+		return convertible[uint64](typ)
+	case FieldUint256:
+		return typ == reflect.TypeOf((*uint256.Int)(nil))
+	case FieldUint256BigInt:
+		return typ == reflect.TypeOf((*big.Int)(nil))
+	case FieldStaticBytes, FieldArrayOfBits:
+		return typ.Kind() == reflect.Array && typ.Elem().Kind() == reflect.Uint8
+	case FieldCheckedStaticBytes, FieldDynamicBytes, FieldSliceOfBits:
+		return convertible[[]byte](typ)
+	case FieldArrayOfUint64s:
+		return typ.Kind() == reflect.Array && typ.Elem() == reflect.TypeOf(uint64(0))
+	case FieldSliceOfUint64s:
+		return convertible[[]uint64](typ)
+	case FieldArrayOfStaticBytes:
+		return typ.Kind() == reflect.Array && typ.Elem().Kind() == reflect.Array && typ.Elem().Elem().Kind() == reflect.Uint8
+	case FieldCheckedArrayOfStaticBytes, FieldSliceOfStaticBytes:
+		if typ.Kind() != reflect.Slice {
+			return false
+		}
+		_, ok := staticBytesSlice(reflect.New(reflect.SliceOf(typ.Elem())).Interface())
+		return ok
+	case FieldSliceOfDynamicBytes:
+		return convertible[[][]byte](typ)
+	case FieldStaticObject, FieldDynamicObject:
+		if typ.Kind() != reflect.Pointer {
+			return false
+		}
+		f.elem = typ.Elem()
+		return implements(f.Kind, typ)
+	case FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects:
+		if typ.Kind() != reflect.Slice || typ.Elem().Kind() != reflect.Pointer {
+			return false
+		}
+		f.elem = typ.Elem().Elem()
+		return implements(f.Kind, typ.Elem())
+	default:
+		return false
+	}
+}
+
+// convertible returns whether a pointer to typ can be converted into a pointer
+// to T (i.e. typ is T or a named type with T as its underlying type).
+func convertible[T any](typ reflect.Type) bool {
+	return reflect.PointerTo(typ).ConvertibleTo(reflect.TypeOf((*T)(nil)))
+}
+
+// implements returns whether an object type implements the ssz interface that
+// the field kind requires.
+func implements(kind FieldKind, typ reflect.Type) bool {
+	switch kind {
+	case FieldStaticObject, FieldSliceOfStaticObjects:
+		return typ.Implements(reflect.TypeOf((*StaticObject)(nil)).Elem())
+	default:
+		return typ.Implements(reflect.TypeOf((*DynamicObject)(nil)).Elem())
+	}
+}
+
+// fieldPtr converts an addressable field into a pointer to its underlying type,
+// so it can be passed to the typed Define methods.
+func fieldPtr[T any](v reflect.Value) *T {
+	return v.Addr().Convert(reflect.TypeOf((*T)(nil))).Interface().(*T)
+}
+
+// Define defines how an object of the described type is encoded/decoded. It is
+// meant to be called from the DefineSSZ method of the described type.
+func (d *Descriptor) Define(c *Codec, obj Object) {
+	val := reflect.ValueOf(obj).Elem()
+
+	// Define the static data (fields and dynamic offsets)
+	for i := range d.fields {
+		d.fields[i].define(c, val.Field(d.fields[i].index), false)
+	}
+	// Define the dynamic data (fields), hashed at the offset position
+	if !d.dynamic || (c.enc == nil && c.dec == nil) {
+		return
+	}
+	for i := range d.fields {
+		if d.fields[i].Kind.dynamic() {
+			d.fields[i].define(c, val.Field(d.fields[i].index), true)
+		}
+	}
+}
+
+// SizeDynamic returns the size of the dynamic part of an object of the described
+// type. It is meant to be added to the static size in the SizeSSZ method of the
+// described type when not requesting the fixed size only.
+func (d *Descriptor) SizeDynamic(obj Object) uint32 {
+	val := reflect.ValueOf(obj).Elem()
+
+	var size uint64
+	for i := range d.fields {
+		v := val.Field(d.fields[i].index)
+
+		switch d.fields[i].Kind {
+		case FieldDynamicBytes, FieldSliceOfBits:
+			size += uint64(v.Len())
+		case FieldSliceOfUint64s:
+			size += uint64(v.Len()) * 8
+		case FieldSliceOfStaticBytes:
+			size += uint64(v.Len()) * uint64(v.Type().Elem().Len())
+		case FieldSliceOfDynamicBytes:
+			for j := 0; j < v.Len(); j++ {
+				size += 4 + uint64(v.Index(j).Len()) // 4-byte offset + dynamic data later
+			}
+		case FieldDynamicObject:
+			size += uint64(v.Interface().(DynamicObject).SizeSSZ(false))
+		case FieldSliceOfStaticObjects:
+			if v.Len() > 0 {
+				size += uint64(v.Len()) * uint64(v.Index(0).Interface().(StaticObject).SizeSSZ())
+			}
+		case FieldSliceOfDynamicObjects:
+			for j := 0; j < v.Len(); j++ {
+				size += 4 + uint64(v.Index(j).Interface().(DynamicObject).SizeSSZ(false)) // 4-byte offset + dynamic data later
+			}
+		}
+	}
+	return checkedSize(size)
+}
+
+// define runs the static (or offset) definition of a field, or the dynamic one
+// if content is set.
+func (f *descriptorField) define(c *Codec, v reflect.Value, content bool) {
+	switch f.Kind {
+	case FieldBool:
+		DefineBool(c, fieldPtr[bool](v))
+	case FieldUint8:
+		DefineUint8(c, fieldPtr[uint8](v))
+	case FieldUint16:
+		DefineUint16(c, fieldPtr[uint16](v))
+	case FieldUint32:
+		DefineUint32(c, fieldPtr[uint32](v))
+	case FieldUint64:
+		DefineUint64(c, fieldPtr[uint64](v))
+	case FieldUint256:
+		DefineUint256(c, v.Addr().Interface().(**uint256.Int))
+	case FieldUint256BigInt:
+		DefineUint256BigInt(c, v.Addr().Interface().(**big.Int))
+
+	case FieldStaticBytes:
+		blob := v.Slice(0, v.Len()).Bytes()
+		if c.fmt != nil {
+			c.fmt.line(v.Addr().Interface(), formatStaticBytes(blob))
+			return
+		}
+		DefineCheckedStaticBytes(c, &blob, uint64(len(blob)))
+	case FieldCheckedStaticBytes:
+		DefineCheckedStaticBytes(c, fieldPtr[[]byte](v), f.Size)
+	case FieldDynamicBytes:
+		if content {
+			DefineDynamicBytesContent(c, fieldPtr[[]byte](v), f.MaxSize)
+		} else {
+			DefineDynamicBytesOffset(c, fieldPtr[[]byte](v), f.MaxSize)
+		}
+
+	case FieldArrayOfBits:
+		bits := v.Slice(0, v.Len()).Bytes()
+		if c.fmt != nil {
+			c.fmt.line(v.Addr().Interface(), fmt.Sprintf("%s (%d bits)", formatBytes(bits), f.Size))
+			return
+		}
+		DefineCheckedArrayOfBits(c, &bits, f.Size)
+	case FieldSliceOfBits:
+		if content {
+			DefineSliceOfBitsContent(c, fieldPtr[[]byte](v), f.MaxItems)
+		} else {
+			DefineSliceOfBitsOffset(c, fieldPtr[[]byte](v), f.MaxItems)
+		}
+
+	case FieldArrayOfUint64s:
+		nums := v.Slice(0, v.Len()).Interface().([]uint64)
+		switch {
+		case c.enc != nil:
+			c.enc.encodeUint64s(nums)
+			if c.hashing() {
+				c.has.hashUint64s(nums)
+			}
+		case c.dec != nil:
+			c.dec.decodeUint64s(nums)
+		case c.fmt != nil:
+			c.fmt.line(v.Addr().Interface(), formatUint64s(nums))
+		default:
+			c.has.hashUint64s(nums)
+		}
+	case FieldSliceOfUint64s:
+		if content {
+			DefineSliceOfUint64sContent(c, fieldPtr[[]uint64](v), f.MaxItems)
+		} else {
+			DefineSliceOfUint64sOffset(c, fieldPtr[[]uint64](v), f.MaxItems)
+		}
+
+	case FieldArrayOfStaticBytes:
+		f.defineArrayOfStaticBytes(c, v)
+	case FieldCheckedArrayOfStaticBytes, FieldSliceOfStaticBytes:
+		f.defineSliceOfStaticBytes(c, v, content)
+	case FieldSliceOfDynamicBytes:
+		if content {
+			DefineSliceOfDynamicBytesContent(c, fieldPtr[[][]byte](v), f.MaxItems, f.MaxSize)
+		} else {
+			DefineSliceOfDynamicBytesOffset(c, fieldPtr[[][]byte](v), f.MaxItems, f.MaxSize)
+		}
+
+	case FieldStaticObject:
+		f.defineStaticObject(c, v)
+	case FieldDynamicObject:
+		if content {
+			f.defineDynamicObjectContent(c, v)
+		} else {
+			f.defineDynamicObjectOffset(c, v)
+		}
+	case FieldSliceOfStaticObjects:
+		f.defineSliceOfStaticObjects(c, v, content)
+	case FieldSliceOfDynamicObjects:
+		f.defineSliceOfDynamicObjects(c, v, content)
+	}
+}
+
+// defineArrayOfStaticBytes defines a static array of static binary blobs, item
+// by item, since the array and item lengths are only known at runtime.
+func (f *descriptorField) defineArrayOfStaticBytes(c *Codec, v reflect.Value) {
+	item := func(i int) []byte {
+		blob := v.Index(i)
+		return blob.Slice(0, blob.Len()).Bytes()
+	}
+	if c.enc != nil {
+		for i := 0; i < v.Len(); i++ {
+			EncodeCheckedStaticBytes(c.enc, item(i))
+		}
+		if !c.hashing() {
+			return
+		}
+	}
+	switch {
+	case c.dec != nil:
+		for i := 0; i < v.Len(); i++ {
+			blob := item(i)
+			DecodeCheckedStaticBytes(c.dec, &blob, uint64(len(blob)))
+		}
+	case c.fmt != nil:
+		c.fmt.items(v.Addr().Interface(), v.Len(), "", func(i int) string { return formatStaticBytes(item(i)) })
+	default:
+		c.has.descendLayer()
+		for i := 0; i < v.Len(); i++ {
+			c.has.hashBytes(item(i))
+		}
+		c.has.ascendLayer(0)
+	}
+}
+
+// defineSliceOfStaticBytes defines a checked static array or a dynamic slice of
+// static binary blobs via the typed Define methods.
+func (f *descriptorField) defineSliceOfStaticBytes(c *Codec, v reflect.Value, content bool) {
+	blobs, _ := staticBytesSlice(v.Addr().Convert(reflect.PointerTo(reflect.SliceOf(v.Type().Elem()))).Interface())
+	blobs(c, &f.FieldDescriptor, content)
+}
+
+// staticBytesSlice maps a pointer to a slice of byte arrays to a function which
+// defines it as a field, or returns false if the array length is not one of the
+// lengths supported by the typed Define methods.
+func staticBytesSlice(ptr any) (func(c *Codec, f *FieldDescriptor, content bool), bool) {
+	switch blobs := ptr.(type) {
+	case *[][4]byte:
+		return func(c *Codec, f *FieldDescriptor, content bool) { defineSliceOfStaticBytes(c, blobs, f, content) }, true
+	case *[][20]byte:
+		return func(c *Codec, f *FieldDescriptor, content bool) { defineSliceOfStaticBytes(c, blobs, f, content) }, true
+	case *[][31]byte:
+		return func(c *Codec, f *FieldDescriptor, content bool) { defineSliceOfStaticBytes(c, blobs, f, content) }, true
+	case *[][32]byte:
+		return func(c *Codec, f *FieldDescriptor, content bool) { defineSliceOfStaticBytes(c, blobs, f, content) }, true
+	case *[][48]byte:
+		return func(c *Codec, f *FieldDescriptor, content bool) { defineSliceOfStaticBytes(c, blobs, f, content) }, true
+	case *[][64]byte:
+		return func(c *Codec, f *FieldDescriptor, content bool) { defineSliceOfStaticBytes(c, blobs, f, content) }, true
+	case *[][96]byte:
+		return func(c *Codec, f *FieldDescriptor, content bool) { defineSliceOfStaticBytes(c, blobs, f, content) }, true
+	case *[][256]byte:
+		return func(c *Codec, f *FieldDescriptor, content bool) { defineSliceOfStaticBytes(c, blobs, f, content) }, true
+	case *[][131072]byte:
+		return func(c *Codec, f *FieldDescriptor, content bool) { defineSliceOfStaticBytes(c, blobs, f, content) }, true
+	default:
+		return nil, false
+	}
+}
+
+// defineSliceOfStaticBytes is the typed version of staticBytesSlice's returned
+// definition functions.
+func defineSliceOfStaticBytes[T commonBytesLengths](c *Codec, blobs *[]T, f *FieldDescriptor, content bool) {
+	switch {
+	case f.Kind == FieldCheckedArrayOfStaticBytes:
+		DefineCheckedArrayOfStaticBytes(c, blobs, f.Size)
+	case content:
+		DefineSliceOfStaticBytesContent(c, blobs, f.MaxItems)
+	default:
+		DefineSliceOfStaticBytesOffset(c, blobs, f.MaxItems)
+	}
+}
+
+// defineStaticObject defines a static ssz object, allocating it via reflection
+// if it's missing when decoding.
+func (f *descriptorField) defineStaticObject(c *Codec, v reflect.Value) {
+	switch {
+	case c.enc != nil:
+		EncodeStaticObject(c.enc, v.Interface().(StaticObject))
+	case c.dec != nil:
+		dec := c.dec
+		if dec.err != nil {
+			return
+		}
+		if v.IsNil() {
+			v.Set(allocObjectOf(dec, f.elem))
+		}
+		obj := v.Interface().(StaticObject)
+		if dec.tracer != nil {
+			dec.traceStatic(obj.SizeSSZ())
+		}
+		dec.traceDescend()
+		obj.DefineSSZ(dec.codec)
+		dec.traceAscend()
+	case c.fmt != nil:
+		c.fmt.object(v.Addr().Interface(), v.Interface().(Object))
+	default:
+		HashStaticObject(c.has, v.Interface().(StaticObject))
+	}
+}
+
+// defineDynamicObjectOffset defines the offset of a dynamic ssz object.
+func (f *descriptorField) defineDynamicObjectOffset(c *Codec, v reflect.Value) {
+	switch {
+	case c.enc != nil:
+		EncodeDynamicObjectOffset(c.enc, v.Interface().(DynamicObject))
+		if c.hashing() {
+			c.has.reserveChunk()
+		}
+	case c.dec != nil:
+		c.dec.traceOffset()
+		c.dec.decodeOffset(false)
+	case c.fmt != nil:
+		c.fmt.object(v.Addr().Interface(), v.Interface().(Object))
+	default:
+		HashDynamicObject(c.has, v.Interface().(DynamicObject))
+	}
+}
+
+// defineDynamicObjectContent defines the content of a dynamic ssz object,
+// allocating it via reflection if it's missing when decoding.
+func (f *descriptorField) defineDynamicObjectContent(c *Codec, v reflect.Value) {
+	if c.enc != nil {
+		EncodeDynamicObjectContent(c.enc, v.Interface().(DynamicObject))
+		if c.hashing() {
+			c.has.fillChunk()
+		}
+		return
+	}
+	dec := c.dec
+	if dec.err != nil {
+		return
+	}
+	dec.traceDynamic()
+
+	// Descend into a new data slot to track/verify a new sub-length
+	dec.descendIntoSlot(dec.retrieveSize())
+	defer dec.ascendFromSlot()
+
+	if v.IsNil() {
+		v.Set(allocObjectOf(dec, f.elem))
+	}
+	obj := v.Interface().(DynamicObject)
+
+	dec.traceDescend()
+	dec.startDynamics(obj.SizeSSZ(true))
+	obj.DefineSSZ(dec.codec)
+	dec.traceAscend()
+}
+
+// defineSliceOfStaticObjects defines a dynamic slice of static ssz objects via
+// the factory based Define methods, operating on an interface typed copy of
+// the slice which is stored back into the field after decoding.
+func (f *descriptorField) defineSliceOfStaticObjects(c *Codec, v reflect.Value, content bool) {
+	// Decoding an offset doesn't need the items, avoid collecting them
+	var objects []StaticObject
+	if c.dec == nil || content {
+		objects = collectObjects[StaticObject](v, c.dec != nil)
+	}
+	if c.fmt != nil {
+		c.fmt.objects(v.Addr().Interface(), len(objects), formatLimit(f.MaxItems), func(i int) Object { return objects[i] })
+		return
+	}
+	if !content {
+		DefineSliceOfStaticObjectsOffsetFunc(c, &objects, f.MaxItems, nil)
+		return
+	}
+	var newItem func() StaticObject
+	if c.dec != nil {
+		newItem = func() StaticObject { return allocObjectOf(c.dec, f.elem).Interface().(StaticObject) }
+	}
+	DefineSliceOfStaticObjectsContentFunc(c, &objects, f.MaxItems, newItem)
+	if c.dec != nil {
+		storeObjects(v, objects)
+	}
+}
+
+// defineSliceOfDynamicObjects defines a dynamic slice of dynamic ssz objects via
+// the factory based Define methods, operating on an interface typed copy of
+// the slice which is stored back into the field after decoding.
+func (f *descriptorField) defineSliceOfDynamicObjects(c *Codec, v reflect.Value, content bool) {
+	// Decoding an offset doesn't need the items, avoid collecting them
+	var objects []DynamicObject
+	if c.dec == nil || content {
+		objects = collectObjects[DynamicObject](v, c.dec != nil)
+	}
+	if c.fmt != nil {
+		c.fmt.objects(v.Addr().Interface(), len(objects), formatLimit(f.MaxItems), func(i int) Object { return objects[i] })
+		return
+	}
+	if !content {
+		DefineSliceOfDynamicObjectsOffsetFunc(c, &objects, f.MaxItems, nil)
+		return
+	}
+	var newItem func() DynamicObject
+	if c.dec != nil {
+		newItem = func() DynamicObject { return allocObjectOf(c.dec, f.elem).Interface().(DynamicObject) }
+	}
+	DefineSliceOfDynamicObjectsContentFunc(c, &objects, f.MaxItems, newItem)
+	if c.dec != nil {
+		storeObjects(v, objects)
+	}
+}
+
+// collectObjects copies a slice of object pointers into an interface typed one.
+// When decoding, nil pointers are converted into nil interfaces so they get
+// allocated by the factory.
+func collectObjects[T Object](v reflect.Value, decoding bool) []T {
+	objects := make([]T, v.Len())
+	for i := range objects {
+		if item := v.Index(i); !decoding || !item.IsNil() {
+			objects[i] = item.Interface().(T)
+		}
+	}
+	return objects
+}
+
+// storeObjects copies an interface typed slice of objects back into a slice of
+// object pointers, reusing its backing array if large enough. Items missing due
+// to a decoding failure are stored as nil pointers.
+func storeObjects[T Object](v reflect.Value, objects []T) {
+	if v.Cap() >= len(objects) {
+		v.SetLen(len(objects))
+	} else {
+		v.Set(reflect.MakeSlice(v.Type(), len(objects), len(objects)))
+	}
+	for i, obj := range objects {
+		if any(obj) == nil {
+			v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+		} else {
+			v.Index(i).Set(reflect.ValueOf(obj))
+		}
+	}
+}
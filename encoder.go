@@ -5,13 +5,16 @@
 package ssz
 
 import (
+	"encoding"
 	"encoding/binary"
+	"fmt"
 	"io"
+	"math"
 	"math/big"
-	"unsafe"
+	"runtime"
 
 	"github.com/holiman/uint256"
-	"github.com/prysmaticlabs/go-bitfield"
+	"golang.org/x/sync/errgroup"
 )
 
 // Some helpers to avoid occasional allocations
@@ -24,8 +27,10 @@ var (
 // Encoder is a wrapper around an io.Writer or a []byte buffer to implement SSZ
 // encoding in a streaming or buffered way. It has the following behaviors:
 //
-//  1. The encoder does not buffer, simply writes to the wrapped output stream
-//     directly. If you need buffering (and flushing), that is up to you.
+//  1. The encoder stages small writes (static fields and offsets) internally
+//     and writes them out in larger chunks, but large fields are written to
+//     the wrapped output stream directly. If you need further buffering, that
+//     is up to you.
 //
 //  2. The encoder does not return errors that were hit during writing to the
 //     underlying output stream from individual encoding methods. Since there
@@ -66,8 +71,12 @@ var (
 //     aggressively enough (neither does it allow explicitly directing it to),
 //     and in such tight loops, extra calls matter on performance.
 type Encoder struct {
-	outWriter io.Writer // Underlying output stream to write into (streaming mode)
-	outBuffer []byte    // Underlying output stream to write into (buffered mode)
+	outWriter io.Writer   // Underlying output stream to write into (streaming mode)
+	outBuffer []byte      // Underlying output stream to write into (buffered mode)
+	flusher   flushWriter // Output stream wrapper for flush callbacks (streaming mode)
+	stager    stageWriter // Output stream wrapper for batching small writes (streaming mode)
+
+	workers *errgroup.Group // Background encoders for large fields (buffered mode, concurrent)
 
 	err   error  // Any write error to halt future encoding calls
 	codec *Codec // Self-referencing to pass DefineSSZ calls through (API trick)
@@ -180,11 +189,23 @@ func EncodeUint256(enc *Encoder, n *uint256.Int) {
 	}
 }
 
-// EncodeUint256BigInt serializes a big.Ing as uint256.
+// EncodeUint256Checked serializes a uint256. The value is not validated, that is
+// only done when decoding.
+func EncodeUint256Checked(enc *Encoder, n *uint256.Int) {
+	EncodeUint256(enc, n)
+}
+
+// EncodeUint256BigInt serializes a big.Int as uint256.
 //
 // Note, a nil pointer is serialized as zero.
-// Note, an overflow will be silently dropped.
+// Note, a negative or larger than 256 bit value will halt encoding with an error.
 func EncodeUint256BigInt(enc *Encoder, n *big.Int) {
+	if n != nil && (n.Sign() < 0 || n.BitLen() > 256) {
+		if enc.err == nil {
+			enc.err = fmt.Errorf("%w: %v", ErrUint256Overflow, n)
+		}
+		n = nil // keep the buffered output position consistent
+	}
 	if enc.outWriter != nil {
 		if enc.err != nil {
 			return
@@ -207,6 +228,24 @@ func EncodeUint256BigInt(enc *Encoder, n *big.Int) {
 	}
 }
 
+// EncodeUint256Bytes serializes a big-endian 32 byte array as uint256.
+func EncodeUint256Bytes(enc *Encoder, n *[32]byte) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		for i := 0; i < 32; i++ {
+			enc.buf[i] = n[31-i]
+		}
+		_, enc.err = enc.outWriter.Write(enc.buf[:32])
+	} else {
+		for i := 0; i < 32; i++ {
+			enc.outBuffer[i] = n[31-i]
+		}
+		enc.outBuffer = enc.outBuffer[32:]
+	}
+}
+
 // EncodeStaticBytes serializes a static binary blob.
 //
 // The blob is passed by pointer to avoid high stack copy costs and a potential
@@ -216,13 +255,9 @@ func EncodeStaticBytes[T commonBytesLengths](enc *Encoder, blob *T) {
 		if enc.err != nil {
 			return
 		}
-		// The code below should have used `*blob[:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		_, enc.err = enc.outWriter.Write(unsafe.Slice(&(*blob)[0], len(*blob)))
+		_, enc.err = enc.outWriter.Write(arrayBytes(blob))
 	} else {
-		// The code below should have used `blob[:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		copy(enc.outBuffer, unsafe.Slice(&(*blob)[0], len(*blob)))
+		copy(enc.outBuffer, arrayBytes(blob))
 		enc.outBuffer = enc.outBuffer[len(*blob):]
 	}
 }
@@ -240,6 +275,21 @@ func EncodeCheckedStaticBytes(enc *Encoder, blob []byte) {
 	}
 }
 
+// encodeZeroBytes serializes size zero bytes, without allocating a blob for them.
+func (enc *Encoder) encodeZeroBytes(size uint64) {
+	if enc.outWriter != nil {
+		var zeroes [32]byte
+		for size > 0 && enc.err == nil {
+			n := min(size, uint64(len(zeroes)))
+			_, enc.err = enc.outWriter.Write(zeroes[:n])
+			size -= n
+		}
+	} else {
+		clear(enc.outBuffer[:size])
+		enc.outBuffer = enc.outBuffer[size:]
+	}
+}
+
 // EncodeDynamicBytesOffset serializes a dynamic binary blob.
 func EncodeDynamicBytesOffset(enc *Encoder, blob []byte) {
 	if enc.outWriter != nil {
@@ -252,7 +302,7 @@ func EncodeDynamicBytesOffset(enc *Encoder, blob []byte) {
 		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
 		enc.outBuffer = enc.outBuffer[4:]
 	}
-	enc.offset += uint32(len(blob))
+	enc.advanceOffset(uint64(len(blob)))
 }
 
 // EncodeDynamicBytesContent is the lazy data writer for EncodeDynamicBytesOffset.
@@ -263,17 +313,87 @@ func EncodeDynamicBytesContent(enc *Encoder, blob []byte) {
 		}
 		_, enc.err = enc.outWriter.Write(blob)
 	} else {
+		if enc.workers != nil && len(blob) >= concurrencyThreshold {
+			enc.encodeConcurrent(uint64(len(blob)), func(enc *Encoder) {
+				copy(enc.outBuffer, blob)
+			})
+			return
+		}
 		copy(enc.outBuffer, blob)
 		enc.outBuffer = enc.outBuffer[len(blob):]
 	}
 }
 
+// EncodeDynamicStringOffset serializes a dynamic binary blob held in a string.
+func EncodeDynamicStringOffset[T ~string](enc *Encoder, str T) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	enc.advanceOffset(uint64(len(str)))
+}
+
+// EncodeDynamicStringContent is the lazy data writer for EncodeDynamicStringOffset.
+func EncodeDynamicStringContent[T ~string](enc *Encoder, str T) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		_, enc.err = enc.outWriter.Write(stringBytes(string(str)))
+	} else {
+		copy(enc.outBuffer, string(str))
+		enc.outBuffer = enc.outBuffer[len(str):]
+	}
+}
+
 // EncodeStaticObject serializes a static ssz object.
 func EncodeStaticObject(enc *Encoder, obj StaticObject) {
 	if enc.err != nil {
 		return
 	}
-	obj.DefineSSZ(enc.codec)
+	enc.defineObject(obj)
+}
+
+// EncodeStaticObjectPointer serializes an optional static ssz object, encoding
+// the zero object if nil.
+func EncodeStaticObjectPointer[T newableStaticObject[U], U any](enc *Encoder, obj T) {
+	if obj == nil {
+		obj = T(new(U))
+	}
+	EncodeStaticObject(enc, obj)
+}
+
+// EncodeStaticBinaryMarshaler serializes an opaque binary marshaler as a static
+// binary blob, validating that its size matches the expected one.
+func EncodeStaticBinaryMarshaler(enc *Encoder, v encoding.BinaryMarshaler, size uint64) {
+	blob, err := v.MarshalBinary()
+	if err == nil && uint64(len(blob)) != size {
+		err = fmt.Errorf("%w: marshaled %d bytes, want %d", ErrMarshaledSizeMismatch, len(blob), size)
+	}
+	if err != nil {
+		if enc.err == nil {
+			enc.err = err
+		}
+		if enc.outWriter == nil {
+			enc.outBuffer = enc.outBuffer[size:] // keep the buffered output position consistent
+		}
+		return
+	}
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		_, enc.err = enc.outWriter.Write(blob)
+	} else {
+		copy(enc.outBuffer, blob)
+		enc.outBuffer = enc.outBuffer[size:]
+	}
 }
 
 // EncodeDynamicObjectOffset serializes a dynamic ssz object.
@@ -288,7 +408,7 @@ func EncodeDynamicObjectOffset(enc *Encoder, obj DynamicObject) {
 		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
 		enc.outBuffer = enc.outBuffer[4:]
 	}
-	enc.offset += obj.SizeSSZ(false)
+	enc.advanceOffset(uint64(sizeOnFork(obj, enc.codec.fork, false)))
 }
 
 // EncodeDynamicObjectContent is the lazy data writer for EncodeDynamicObjectOffset.
@@ -296,8 +416,14 @@ func EncodeDynamicObjectContent(enc *Encoder, obj DynamicObject) {
 	if enc.err != nil {
 		return
 	}
-	enc.offsetDynamics(obj.SizeSSZ(true))
-	obj.DefineSSZ(enc.codec)
+	if enc.workers != nil {
+		if size := sizeOnFork(obj, enc.codec.fork, false); size >= concurrencyThreshold {
+			enc.encodeDynamicObjectConcurrent(size, obj)
+			return
+		}
+	}
+	enc.offsetDynamics(sizeOnFork(obj, enc.codec.fork, true))
+	enc.defineObject(obj)
 }
 
 // EncodeArrayOfBits serializes a static array of (packed) bits.
@@ -306,19 +432,28 @@ func EncodeArrayOfBits[T commonBitsLengths](enc *Encoder, bits *T) {
 		if enc.err != nil {
 			return
 		}
-		// The code below should have used `*bits[:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		_, enc.err = enc.outWriter.Write(unsafe.Slice(&(*bits)[0], len(*bits)))
+		_, enc.err = enc.outWriter.Write(arrayBytes(bits))
 	} else {
-		// The code below should have used `*bits[:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		copy(enc.outBuffer, unsafe.Slice(&(*bits)[0], len(*bits)))
+		copy(enc.outBuffer, arrayBytes(bits))
 		enc.outBuffer = enc.outBuffer[len(*bits):]
 	}
 }
 
+// EncodeCheckedArrayOfBits serializes a static array of (packed) bits.
+func EncodeCheckedArrayOfBits[T ~[]byte](enc *Encoder, bits T) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		_, enc.err = enc.outWriter.Write(bits)
+	} else {
+		copy(enc.outBuffer, bits)
+		enc.outBuffer = enc.outBuffer[len(bits):]
+	}
+}
+
 // EncodeSliceOfBitsOffset serializes a dynamic slice of (packed) bits.
-func EncodeSliceOfBitsOffset(enc *Encoder, bits bitfield.Bitlist) {
+func EncodeSliceOfBitsOffset[T ~[]byte](enc *Encoder, bits T) {
 	if enc.outWriter != nil {
 		if enc.err != nil {
 			return
@@ -329,19 +464,19 @@ func EncodeSliceOfBitsOffset(enc *Encoder, bits bitfield.Bitlist) {
 		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
 		enc.outBuffer = enc.outBuffer[4:]
 	}
-	enc.offset += uint32(len(bits))
+	enc.advanceOffset(uint64(len(bits)))
 }
 
 // EncodeSliceOfBitsContent is the lazy data writer for EncodeSliceOfBitsOffset.
-func EncodeSliceOfBitsContent(enc *Encoder, bits bitfield.Bitlist) {
+func EncodeSliceOfBitsContent[T ~[]byte](enc *Encoder, bits T) {
 	if enc.outWriter != nil {
 		if enc.err != nil {
 			return
 		}
-		_, enc.err = enc.outWriter.Write(bits) // bitfield.Bitlist already has the length bit set
+		_, enc.err = enc.outWriter.Write(bits) // bitlists already have the length bit set
 	} else {
 		copy(enc.outBuffer, bits)
-		enc.outBuffer = enc.outBuffer[len(bits):] // bitfield.Bitlist already has the length bit set
+		enc.outBuffer = enc.outBuffer[len(bits):] // bitlists already have the length bit set
 	}
 }
 
@@ -351,26 +486,7 @@ func EncodeSliceOfBitsContent(enc *Encoder, bits bitfield.Bitlist) {
 // escaping to the heap (and incurring an allocation) when passing it to the
 // output stream.
 func EncodeArrayOfUint64s[T commonUint64sLengths](enc *Encoder, ns *T) {
-	// The code below should have used `*blob[:]`, alas Go's generics compiler
-	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	nums := unsafe.Slice(&(*ns)[0], len(*ns))
-
-	// Internally this method is essentially calling EncodeUint64 on all numbers
-	// in a loop. Practically, we've inlined that call to make things a *lot* faster.
-	if enc.outWriter != nil {
-		for _, n := range nums {
-			if enc.err != nil {
-				return
-			}
-			binary.LittleEndian.PutUint64(enc.buf[:8], n)
-			_, enc.err = enc.outWriter.Write(enc.buf[:8])
-		}
-	} else {
-		for _, n := range nums {
-			binary.LittleEndian.PutUint64(enc.outBuffer, n)
-			enc.outBuffer = enc.outBuffer[8:]
-		}
-	}
+	enc.encodeUint64s(arrayUint64s(ns))
 }
 
 // EncodeSliceOfUint64sOffset serializes a dynamic slice of uint64s.
@@ -387,13 +503,17 @@ func EncodeSliceOfUint64sOffset[T ~uint64](enc *Encoder, ns []T) {
 		enc.outBuffer = enc.outBuffer[4:]
 	}
 	if items := len(ns); items > 0 {
-		enc.offset += uint32(items * 8)
+		enc.advanceOffset(uint64(items) * 8)
 	}
 }
 
 // EncodeSliceOfUint64sContent is the lazy data writer for EncodeSliceOfUint64sOffset.
 func EncodeSliceOfUint64sContent[T ~uint64](enc *Encoder, ns []T) {
 	if enc.outWriter != nil {
+		if blob, ok := uint64sBytes(ns); ok {
+			enc.encodeRawBytes(blob)
+			return
+		}
 		for _, n := range ns {
 			if enc.err != nil {
 				return
@@ -402,6 +522,17 @@ func EncodeSliceOfUint64sContent[T ~uint64](enc *Encoder, ns []T) {
 			_, enc.err = enc.outWriter.Write(enc.buf[:8])
 		}
 	} else {
+		if enc.workers != nil && uint64(len(ns))*8 >= concurrencyThreshold {
+			enc.encodeConcurrentSplit(len(ns), 8, func(enc *Encoder, from, to int) {
+				EncodeSliceOfUint64sContent(enc, ns[from:to])
+			})
+			return
+		}
+		if blob, ok := uint64sBytes(ns); ok {
+			copy(enc.outBuffer, blob)
+			enc.outBuffer = enc.outBuffer[len(blob):]
+			return
+		}
 		for _, n := range ns {
 			binary.LittleEndian.PutUint64(enc.outBuffer, (uint64)(n))
 			enc.outBuffer = enc.outBuffer[8:]
@@ -416,9 +547,7 @@ func EncodeSliceOfUint64sContent[T ~uint64](enc *Encoder, ns []T) {
 // from escaping to the heap (and incurring an allocation) when passing it to
 // the output stream.
 func EncodeArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](enc *Encoder, blobs *T) {
-	// The code below should have used `(*blobs)[:]`, alas Go's generics compiler
-	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	EncodeUnsafeArrayOfStaticBytes(enc, unsafe.Slice(&(*blobs)[0], len(*blobs)))
+	EncodeUnsafeArrayOfStaticBytes(enc, arrayItems[T, U](blobs))
 }
 
 // EncodeUnsafeArrayOfStaticBytes serializes a static array of static binary
@@ -432,15 +561,11 @@ func EncodeUnsafeArrayOfStaticBytes[T commonBytesLengths](enc *Encoder, blobs []
 			if enc.err != nil {
 				return
 			}
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, enc.err = enc.outWriter.Write(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			_, enc.err = enc.outWriter.Write(arrayBytes(&blobs[i]))
 		}
 	} else {
 		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			copy(enc.outBuffer, unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			copy(enc.outBuffer, arrayBytes(&blobs[i]))
 			enc.outBuffer = enc.outBuffer[len(blobs[i]):]
 		}
 	}
@@ -457,15 +582,11 @@ func EncodeCheckedArrayOfStaticBytes[T commonBytesLengths](enc *Encoder, blobs [
 			if enc.err != nil {
 				return
 			}
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, enc.err = enc.outWriter.Write(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			_, enc.err = enc.outWriter.Write(arrayBytes(&blobs[i]))
 		}
 	} else {
 		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			copy(enc.outBuffer, unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			copy(enc.outBuffer, arrayBytes(&blobs[i]))
 			enc.outBuffer = enc.outBuffer[len(blobs[i]):]
 		}
 	}
@@ -484,7 +605,7 @@ func EncodeSliceOfStaticBytesOffset[T commonBytesLengths](enc *Encoder, blobs []
 		enc.outBuffer = enc.outBuffer[4:]
 	}
 	if items := len(blobs); items > 0 {
-		enc.offset += uint32(items * len(blobs[0]))
+		enc.advanceOffset(uint64(items) * uint64(len(blobs[0])))
 	}
 }
 
@@ -498,20 +619,57 @@ func EncodeSliceOfStaticBytesContent[T commonBytesLengths](enc *Encoder, blobs [
 			if enc.err != nil {
 				return
 			}
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			_, enc.err = enc.outWriter.Write(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			_, enc.err = enc.outWriter.Write(arrayBytes(&blobs[i]))
 		}
 	} else {
 		for i := 0; i < len(blobs); i++ { // don't range loop, T might be an array, copy is expensive
-			// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-			// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-			copy(enc.outBuffer, unsafe.Slice(&blobs[i][0], len(blobs[i])))
+			copy(enc.outBuffer, arrayBytes(&blobs[i]))
 			enc.outBuffer = enc.outBuffer[len(blobs[i]):]
 		}
 	}
 }
 
+// EncodeCheckedSliceOfStaticBytesOffset serializes a dynamic slice of static binary
+// blobs.
+func EncodeCheckedSliceOfStaticBytesOffset(enc *Encoder, blobs [][]byte) {
+	if enc.outWriter != nil {
+		if enc.err != nil {
+			return
+		}
+		binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
+		_, enc.err = enc.outWriter.Write(enc.buf[:4])
+	} else {
+		binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
+		enc.outBuffer = enc.outBuffer[4:]
+	}
+	var size uint64
+	for _, blob := range blobs {
+		size += uint64(len(blob))
+	}
+	enc.advanceOffset(size)
+}
+
+// EncodeCheckedSliceOfStaticBytesContent is the lazy data writer for
+// EncodeCheckedSliceOfStaticBytesOffset.
+//
+// Note, a blob of a different length than size will halt encoding with an error.
+func EncodeCheckedSliceOfStaticBytesContent(enc *Encoder, blobs [][]byte, size uint64) {
+	for i, blob := range blobs {
+		if uint64(len(blob)) != size && enc.err == nil {
+			enc.err = fmt.Errorf("%w: item %d has %d bytes, want %d", ErrStaticBytesSizeMismatch, i, len(blob), size)
+		}
+		if enc.outWriter != nil {
+			if enc.err != nil {
+				return
+			}
+			_, enc.err = enc.outWriter.Write(blob)
+		} else {
+			copy(enc.outBuffer, blob)
+			enc.outBuffer = enc.outBuffer[len(blob):]
+		}
+	}
+}
+
 // EncodeSliceOfDynamicBytesOffset serializes a dynamic slice of dynamic binary blobs.
 func EncodeSliceOfDynamicBytesOffset(enc *Encoder, blobs [][]byte) {
 	if enc.outWriter != nil {
@@ -525,7 +683,7 @@ func EncodeSliceOfDynamicBytesOffset(enc *Encoder, blobs [][]byte) {
 		enc.outBuffer = enc.outBuffer[4:]
 	}
 	for _, blob := range blobs {
-		enc.offset += uint32(4 + len(blob))
+		enc.advanceOffset(4 + uint64(len(blob)))
 	}
 }
 
@@ -547,14 +705,14 @@ func EncodeSliceOfDynamicBytesContent(enc *Encoder, blobs [][]byte) {
 			binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
 			_, enc.err = enc.outWriter.Write(enc.buf[:4])
 
-			enc.offset += uint32(len(blob))
+			enc.advanceOffset(uint64(len(blob)))
 		}
 	} else {
 		for _, blob := range blobs {
 			binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
 			enc.outBuffer = enc.outBuffer[4:]
 
-			enc.offset += uint32(len(blob))
+			enc.advanceOffset(uint64(len(blob)))
 		}
 	}
 	// Inline:
@@ -590,20 +748,34 @@ func EncodeSliceOfStaticObjectsOffset[T StaticObject](enc *Encoder, objects []T)
 		enc.outBuffer = enc.outBuffer[4:]
 	}
 	if items := len(objects); items > 0 {
-		enc.offset += uint32(items) * objects[0].SizeSSZ()
+		enc.advanceOffset(uint64(items) * uint64(objects[0].SizeSSZ()))
 	}
 }
 
 // EncodeSliceOfStaticObjectsContent is the lazy data writer for EncodeSliceOfStaticObjectsOffset.
 func EncodeSliceOfStaticObjectsContent[T StaticObject](enc *Encoder, objects []T) {
+	if enc.workers != nil && len(objects) > 0 {
+		if size := uint64(objects[0].SizeSSZ()); uint64(len(objects))*size >= concurrencyThreshold {
+			enc.encodeConcurrentSplit(len(objects), size, func(enc *Encoder, from, to int) {
+				EncodeSliceOfStaticObjectsContent(enc, objects[from:to])
+			})
+			return
+		}
+	}
 	for _, obj := range objects {
 		if enc.err != nil {
 			return
 		}
-		obj.DefineSSZ(enc.codec)
+		enc.defineObject(obj)
 	}
 }
 
+// EncodeSliceOfCheckedStaticObjectsContent is the lazy data writer for EncodeSliceOfStaticObjectsOffset.
+// The items are not validated, that is only done when decoding.
+func EncodeSliceOfCheckedStaticObjectsContent[T StaticObject](enc *Encoder, objects []T) {
+	EncodeSliceOfStaticObjectsContent(enc, objects)
+}
+
 // EncodeSliceOfDynamicObjectsOffset serializes a dynamic slice of dynamic ssz objects.
 func EncodeSliceOfDynamicObjectsOffset[T DynamicObject](enc *Encoder, objects []T) {
 	if enc.outWriter != nil {
@@ -617,7 +789,7 @@ func EncodeSliceOfDynamicObjectsOffset[T DynamicObject](enc *Encoder, objects []
 		enc.outBuffer = enc.outBuffer[4:]
 	}
 	for _, obj := range objects {
-		enc.offset += 4 + obj.SizeSSZ(false)
+		enc.advanceOffset(4 + uint64(sizeOnFork(obj, enc.codec.fork, false)))
 	}
 }
 
@@ -638,14 +810,14 @@ func EncodeSliceOfDynamicObjectsContent[T DynamicObject](enc *Encoder, objects [
 			binary.LittleEndian.PutUint32(enc.buf[:4], enc.offset)
 			_, enc.err = enc.outWriter.Write(enc.buf[:4])
 
-			enc.offset += obj.SizeSSZ(false)
+			enc.advanceOffset(uint64(sizeOnFork(obj, enc.codec.fork, false)))
 		}
 	} else {
 		for _, obj := range objects {
 			binary.LittleEndian.PutUint32(enc.outBuffer, enc.offset)
 			enc.outBuffer = enc.outBuffer[4:]
 
-			enc.offset += obj.SizeSSZ(false)
+			enc.advanceOffset(uint64(sizeOnFork(obj, enc.codec.fork, false)))
 		}
 	}
 	// Inline:
@@ -657,8 +829,151 @@ func EncodeSliceOfDynamicObjectsContent[T DynamicObject](enc *Encoder, objects [
 		if enc.err != nil {
 			return
 		}
-		enc.offsetDynamics(obj.SizeSSZ(true))
+		if enc.workers != nil {
+			if size := sizeOnFork(obj, enc.codec.fork, false); size >= concurrencyThreshold {
+				enc.encodeDynamicObjectConcurrent(size, obj)
+				continue
+			}
+		}
+		enc.offsetDynamics(sizeOnFork(obj, enc.codec.fork, true))
+		enc.defineObject(obj)
+	}
+}
+
+// defineObject serializes a nested ssz object via its schema. If the encoder also
+// feeds the hasher, the fields are hashed in a layer of their own, merkleizing
+// the object into a single chunk.
+func (enc *Encoder) defineObject(obj Object) {
+	if !enc.codec.hashing() {
+		obj.DefineSSZ(enc.codec)
+		return
+	}
+	enc.codec.has.descendLayer()
+	obj.DefineSSZ(enc.codec)
+	if enc.codec.hashing() {
+		enc.codec.has.ascendLayer(0)
+	}
+}
+
+// encodeUint64s serializes a static array of uint64s, already converted into a
+// plain slice view.
+func (enc *Encoder) encodeUint64s(nums []uint64) {
+	// Internally this method is essentially calling EncodeUint64 on all numbers
+	// in a loop. Practically, we've inlined that call to make things a *lot* faster.
+	//
+	// On little endian platforms, the memory of the numbers is already in the
+	// ssz encoding, so it's copied over in one go.
+	if blob, ok := uint64sBytes(nums); ok {
+		if enc.outWriter != nil {
+			enc.encodeRawBytes(blob)
+		} else {
+			copy(enc.outBuffer, blob)
+			enc.outBuffer = enc.outBuffer[len(blob):]
+		}
+		return
+	}
+	if enc.outWriter != nil {
+		for _, n := range nums {
+			if enc.err != nil {
+				return
+			}
+			binary.LittleEndian.PutUint64(enc.buf[:8], n)
+			_, enc.err = enc.outWriter.Write(enc.buf[:8])
+		}
+	} else {
+		for _, n := range nums {
+			binary.LittleEndian.PutUint64(enc.outBuffer, n)
+			enc.outBuffer = enc.outBuffer[8:]
+		}
+	}
+}
+
+// encodeRawBytes writes an already encoded blob to the output stream. If flush
+// callbacks were requested, the blob is split along the flush interval, so the
+// callbacks can still apply backpressure within large fields.
+func (enc *Encoder) encodeRawBytes(blob []byte) {
+	chunk := len(blob)
+	if enc.flusher.w != nil && enc.flusher.interval > 0 && enc.flusher.interval < uint64(chunk) {
+		chunk = int(enc.flusher.interval)
+	}
+	for len(blob) > 0 && enc.err == nil {
+		n := min(chunk, len(blob))
+		_, enc.err = enc.outWriter.Write(blob[:n])
+		blob = blob[n:]
+	}
+}
+
+// advanceOffset moves the dynamic field offset tracker forward by the size of the
+// field just encoded. If the offset would not fit into the 4 bytes allotted for
+// it by SSZ, encoding is aborted instead of emitting corrupt offsets.
+func (enc *Encoder) advanceOffset(size uint64) {
+	if next := uint64(enc.offset) + size; next <= math.MaxUint32 {
+		enc.offset = uint32(next)
+		return
+	}
+	enc.overflowOffset(size)
+}
+
+// overflowOffset is the slow path of advanceOffset, split out to keep the former
+// inlineable.
+func (enc *Encoder) overflowOffset(size uint64) {
+	if enc.err == nil {
+		enc.err = fmt.Errorf("%w: offset %d + %d bytes overflows 4 bytes", ErrObjectTooLarge, enc.offset, size)
+	}
+	enc.offset = math.MaxUint32
+}
+
+// encodeConcurrent carves the next size bytes out of the output buffer and fills
+// them via the given method on a background encoder. The caller must wait for
+// the workers to finish before the output can be used.
+func (enc *Encoder) encodeConcurrent(size uint64, fill func(enc *Encoder)) {
+	buf := enc.outBuffer[:size]
+	enc.outBuffer = enc.outBuffer[size:]
+
+	enc.workers.Go(func() error {
+		codec := encoderPool.Get().(*Codec)
+		defer encoderPool.Put(codec)
+
+		codec.fork = enc.codec.fork
+		codec.enc.outBuffer, codec.enc.err = buf, nil
+		fill(codec.enc)
+		codec.enc.outBuffer = nil
+
+		return codec.enc.err
+	})
+}
+
+// encodeDynamicObjectConcurrent carves the next size bytes out of the output
+// buffer and fills them with the dynamic object on a background encoder.
+//
+// The closure is built out of line on purpose: capturing the object in the
+// callers would move their (loop) variables to the heap, allocating even when
+// encoding sequentially.
+//
+//go:noinline
+func (enc *Encoder) encodeDynamicObjectConcurrent(size uint32, obj DynamicObject) {
+	enc.encodeConcurrent(uint64(size), func(enc *Encoder) {
+		enc.offsetDynamics(sizeOnFork(obj, enc.codec.fork, true))
 		obj.DefineSSZ(enc.codec)
+	})
+}
+
+// encodeConcurrentSplit carves the next items*size bytes out of the output buffer
+// and fills them via the given method on multiple background encoders, each of
+// them handling a consecutive range of items.
+func (enc *Encoder) encodeConcurrentSplit(items int, size uint64, fill func(enc *Encoder, from, to int)) {
+	// Split across a higher thread count than cores to avoid starvation if some
+	// workers are slower than others (or are busy with other fields)
+	var (
+		splits  = min(4*runtime.NumCPU(), items)
+		subtask = (items + splits - 1) / splits
+	)
+	for from := 0; from < items; from += subtask {
+		from, to := from, min(from+subtask, items) // Take care, closure
+
+		enc.encodeConcurrent(uint64(to-from)*size, func(enc *Encoder) {
+			fill(enc, from, to)
+		})
 	}
 }
 
@@ -667,3 +982,84 @@ func EncodeSliceOfDynamicObjectsContent[T DynamicObject](enc *Encoder, objects [
 func (enc *Encoder) offsetDynamics(offset uint32) {
 	enc.offset = offset
 }
+
+// flushWriter is an output stream wrapper invoking a callback every time a given
+// number of bytes were written through it.
+type flushWriter struct {
+	w        io.Writer                  // Underlying output stream to write into
+	interval uint64                     // Number of bytes between flush callbacks
+	onFlush  func(written uint64) error // Callback to invoke on flush
+
+	written uint64 // Total number of bytes written
+	flushed uint64 // Number of bytes written at the last flush
+}
+
+// Write implements io.Writer, forwarding the data to the wrapped stream and
+// invoking the flush callback if enough data accumulated since the last one.
+func (w *flushWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.written += uint64(n)
+
+	if err == nil && w.interval > 0 && w.written-w.flushed >= w.interval {
+		err = w.flush()
+	}
+	return n, err
+}
+
+// flush invokes the flush callback if any data was written since the last one.
+func (w *flushWriter) flush() error {
+	if w.written == w.flushed {
+		return nil
+	}
+	w.flushed = w.written
+	return w.onFlush(w.written)
+}
+
+// stageSize is the number of bytes the stream encoder collects before writing
+// them out to the underlying output stream.
+const stageSize = 4096
+
+// stageWriter is an output stream wrapper collecting the many small writes of the
+// encoder (static fields and offsets) into larger chunks, cutting down the number
+// of calls into the underlying writer (e.g. syscalls on network connections).
+// Writes too large to be worth staging are forwarded directly.
+type stageWriter struct {
+	w     io.Writer       // Underlying output stream to write into
+	limit int             // Number of bytes to collect before writing them out
+	buf   [stageSize]byte // Staging buffer for small writes (not pointer, alloc free)
+	n     int             // Number of bytes currently staged
+}
+
+// Write implements io.Writer, staging the data until enough accumulates to be
+// written out in one go.
+func (w *stageWriter) Write(p []byte) (int, error) {
+	size := len(p)
+	for len(p) > 0 {
+		if w.n == 0 && len(p) >= w.limit {
+			n, err := w.w.Write(p)
+			return size - len(p) + n, err
+		}
+		n := copy(w.buf[w.n:w.limit], p)
+		w.n += n
+		p = p[n:]
+
+		if w.n == w.limit {
+			if err := w.flush(); err != nil {
+				return size - len(p) - n, err
+			}
+		}
+	}
+	return size, nil
+}
+
+// flush writes out any staged data to the underlying output stream.
+func (w *stageWriter) flush() error {
+	if w.n == 0 {
+		return nil
+	}
+	n := w.n
+	w.n = 0
+
+	_, err := w.w.Write(w.buf[:n])
+	return err
+}
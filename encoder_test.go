@@ -0,0 +1,334 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that encoding more data than available space will result in a failure.
+func TestEncodeOversized(t *testing.T) {
+	obj := new(testMissizedType)
+
+	blob := make([]byte, obj.SizeSSZ()-1)
+	if err := ssz.EncodeToBytes(blob, obj); !errors.Is(err, ssz.ErrBufferTooSmall) {
+		t.Errorf("encode to bytes error mismatch: have %v, want %v", err, ssz.ErrBufferTooSmall)
+	}
+	if err := ssz.EncodeToStream(&testEncodeOversizedStream{blob}, obj); err == nil {
+		t.Errorf("encode to stream error mismatch: have nil, want stream full") // wonky, but should be fine
+	}
+}
+
+type testEncodeOversizedStream struct {
+	sink []byte
+}
+
+func (s *testEncodeOversizedStream) Write(p []byte) (n int, err error) {
+	// Keep writing until space runs out, then reject it
+	copy(s.sink, p)
+
+	n = len(p)
+	if len(s.sink) < len(p) {
+		n = len(s.sink)
+	}
+	s.sink = s.sink[n:]
+	if n < len(p) {
+		err = errors.New("stream full")
+	}
+	return n, err
+}
+
+// Tests that objects too large to be addressed by SSZ's 4 byte offsets are
+// rejected instead of being encoded with wrapped around offsets.
+func TestEncodeObjectTooLarge(t *testing.T) {
+	obj := &testHugeType{A: new(testHugeFieldType), B: new(testHugeFieldType)}
+
+	var out bytes.Buffer
+	if err := ssz.EncodeToStream(&out, obj); !errors.Is(err, ssz.ErrObjectTooLarge) {
+		t.Errorf("stream encoding error mismatch: have %v, want %v", err, ssz.ErrObjectTooLarge)
+	}
+	if out.Len() > 8 {
+		t.Errorf("wrapped offset emitted: %x", out.Bytes())
+	}
+}
+
+// testHugeType is a dynamic object with two fields claiming to be 3GB each.
+type testHugeType struct {
+	A *testHugeFieldType
+	B *testHugeFieldType
+}
+
+func (t *testHugeType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(8)
+	if !fixed {
+		size += ssz.SizeDynamicObject(t.A)
+		size += ssz.SizeDynamicObject(t.B)
+	}
+	return size
+}
+
+func (t *testHugeType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicObjectOffset(codec, &t.A)
+	ssz.DefineDynamicObjectOffset(codec, &t.B)
+	ssz.DefineDynamicObjectContent(codec, &t.A)
+	ssz.DefineDynamicObjectContent(codec, &t.B)
+}
+
+// testHugeFieldType pretends to be a 3GB dynamic object, without actually having
+// to allocate all that memory.
+type testHugeFieldType struct{}
+
+func (t *testHugeFieldType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 0
+	}
+	return 3 << 30
+}
+
+func (t *testHugeFieldType) DefineSSZ(codec *ssz.Codec) {
+	panic("content should not be encoded")
+}
+
+// Tests that the streaming encoder invokes the flush callback at the configured
+// intervals, and that the callback can abort encoding.
+func TestEncodeFlushCallback(t *testing.T) {
+	obj := &testBigListType{Items: make([]uint64, 100)}
+	for i := range obj.Items {
+		obj.Items[i] = uint64(i)
+	}
+	want := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(want, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	var (
+		out     = new(bytes.Buffer)
+		flushes []uint64
+	)
+	cfg := &ssz.EncoderConfig{
+		FlushInterval: 100,
+		OnFlush: func(written uint64) error {
+			if written != uint64(out.Len()) {
+				t.Errorf("flush %d: written mismatch: have %d, want %d", len(flushes), written, out.Len())
+			}
+			flushes = append(flushes, written)
+			return nil
+		},
+	}
+	if err := ssz.EncodeToStreamWithConfig(out, obj, cfg); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("encoding mismatch: have %x, want %x", out.Bytes(), want)
+	}
+	if len(flushes) < len(want)/100 {
+		t.Errorf("too few flushes: have %d, want at least %d", len(flushes), len(want)/100)
+	}
+	for i := 1; i < len(flushes)-1; i++ {
+		if flushes[i]-flushes[i-1] < 100 {
+			t.Errorf("flush %d: interval too short: %d bytes", i, flushes[i]-flushes[i-1])
+		}
+	}
+	if last := flushes[len(flushes)-1]; last != uint64(len(want)) {
+		t.Errorf("final flush mismatch: have %d, want %d", last, len(want))
+	}
+	// Ensure a failing callback aborts encoding
+	failure := errors.New("backpressure")
+
+	out.Reset()
+	cfg.OnFlush = func(written uint64) error { return failure }
+	if err := ssz.EncodeToStreamWithConfig(out, obj, cfg); !errors.Is(err, failure) {
+		t.Errorf("callback error mismatch: have %v, want %v", err, failure)
+	}
+	if out.Len() >= len(want) {
+		t.Errorf("encoding not aborted: %d bytes written", out.Len())
+	}
+}
+
+// commonPrefix returns the common prefix in two byte slices.
+func commonPrefix(a []byte, b []byte) []byte {
+	var prefix []byte
+
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		prefix = append(prefix, a[0])
+		a, b = a[1:], b[1:]
+	}
+	return prefix
+}
+
+// Tests that encoding large fields concurrently produces the same output as the
+// sequential encoder.
+func TestEncodeConcurrent(t *testing.T) {
+	obj := &testConcurrentType{
+		Blob:       make([]byte, 100000),
+		Items:      make([]uint64, 20000),
+		Validators: make([]*types.Validator, 1000),
+		Nested:     []*testBigListType{{Items: make([]uint64, 10)}, {Items: make([]uint64, 10000)}},
+	}
+	for i := range obj.Blob {
+		obj.Blob[i] = byte(i)
+	}
+	for i := range obj.Items {
+		obj.Items[i] = uint64(i)
+	}
+	for i := range obj.Validators {
+		obj.Validators[i] = &types.Validator{EffectiveBalance: uint64(i), Slashed: i%2 == 0}
+		obj.Validators[i].Pubkey[0] = byte(i)
+	}
+	for i := range obj.Nested[1].Items {
+		obj.Nested[1].Items[i] = uint64(3 * i)
+	}
+	want := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(want, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	have := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytesConcurrent(have, obj); err != nil {
+		t.Fatalf("failed to encode object concurrently: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		prefix := commonPrefix(have, want)
+		t.Fatalf("concurrent encoding mismatch: common prefix %d, have left %x, want left %x", len(prefix), have[len(prefix):], want[len(prefix):])
+	}
+}
+
+type testConcurrentType struct {
+	Blob       []byte
+	Items      []uint64
+	Validators []*types.Validator
+	Nested     []*testBigListType
+}
+
+func (t *testConcurrentType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(16)
+	if !fixed {
+		size += ssz.SizeDynamicBytes(t.Blob)
+		size += ssz.SizeSliceOfUint64s(t.Items)
+		size += ssz.SizeSliceOfStaticObjects(t.Validators)
+		size += ssz.SizeSliceOfDynamicObjects(t.Nested)
+	}
+	return size
+}
+
+func (t *testConcurrentType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicBytesOffset(codec, &t.Blob, 1<<20)
+	ssz.DefineSliceOfUint64sOffset(codec, &t.Items, 1<<20)
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Validators, 1<<20)
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &t.Nested, 16)
+
+	ssz.DefineDynamicBytesContent(codec, &t.Blob, 1<<20)
+	ssz.DefineSliceOfUint64sContent(codec, &t.Items, 1<<20)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Validators, 1<<20)
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &t.Nested, 16)
+}
+
+// testGwei is a named uint64 to check that typed uint64 slices are also packed.
+type testGwei uint64
+
+type testBalancesType struct {
+	Balances []testGwei
+	Amounts  [8192]uint64
+}
+
+func (t *testBalancesType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4 + 8192*8
+	}
+	return 4 + 8192*8 + ssz.SizeSliceOfUint64s(t.Balances)
+}
+
+func (t *testBalancesType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfUint64sOffset(codec, &t.Balances, 1<<20)
+	ssz.DefineArrayOfUint64s(codec, &t.Amounts)
+	ssz.DefineSliceOfUint64sContent(codec, &t.Balances, 1<<20)
+}
+
+// Tests that uint64 lists and vectors are encoded into little endian numbers,
+// whether the platform packs them item by item or copies their memory directly.
+func TestUint64sEncoding(t *testing.T) {
+	obj := &testBalancesType{Balances: make([]testGwei, 50000)}
+	for i := range obj.Balances {
+		obj.Balances[i] = testGwei(uint64(i) * 0x0102030405060708)
+	}
+	for i := range obj.Amounts {
+		obj.Amounts[i] = uint64(i) << 56
+	}
+	want := binary.LittleEndian.AppendUint32(nil, 4+8192*8)
+	for _, n := range obj.Amounts {
+		want = binary.LittleEndian.AppendUint64(want, n)
+	}
+	for _, n := range obj.Balances {
+		want = binary.LittleEndian.AppendUint64(want, uint64(n))
+	}
+	have := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(have, obj); err != nil {
+		t.Fatalf("failed to encode to bytes: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("buffer encoding mismatch: common prefix %d", len(commonPrefix(have, want)))
+	}
+	if err := ssz.EncodeToBytesConcurrent(have, obj); err != nil {
+		t.Fatalf("failed to encode to bytes concurrently: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("concurrent encoding mismatch: common prefix %d", len(commonPrefix(have, want)))
+	}
+	stream := new(bytes.Buffer)
+	if err := ssz.EncodeToStream(stream, obj); err != nil {
+		t.Fatalf("failed to encode to stream: %v", err)
+	}
+	if !bytes.Equal(stream.Bytes(), want) {
+		t.Errorf("stream encoding mismatch: common prefix %d", len(commonPrefix(stream.Bytes(), want)))
+	}
+}
+
+// countingWriter is an io.Writer counting the number of calls made into it and
+// optionally failing after a given number of them.
+type countingWriter struct {
+	out   bytes.Buffer
+	calls int
+	fail  int // Number of calls to fail after (0 = never)
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.calls++
+	if w.fail > 0 && w.calls > w.fail {
+		return 0, errors.New("write failed")
+	}
+	return w.out.Write(p)
+}
+
+// Tests that the small writes of the stream encoder are staged and written out
+// in bulk, and that write errors are still surfaced.
+func TestEncodeStagedWrites(t *testing.T) {
+	header := &types.ExecutionPayloadHeader{BlockNumber: 1, ExtraData: []byte{0x01, 0x02, 0x03}}
+	list := &testBigListType{Items: make([]uint64, 8192)}
+
+	for _, obj := range []ssz.Object{header, list} {
+		want := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(want, obj); err != nil {
+			t.Fatalf("%T: failed to encode object: %v", obj, err)
+		}
+		out := new(countingWriter)
+		if err := ssz.EncodeToStream(out, obj); err != nil {
+			t.Fatalf("%T: failed to stream encode object: %v", obj, err)
+		}
+		if !bytes.Equal(out.out.Bytes(), want) {
+			t.Errorf("%T: encoding mismatch: have %x, want %x", obj, out.out.Bytes(), want)
+		}
+		if limit := len(want)/4096 + 2; out.calls > limit {
+			t.Errorf("%T: too many writes: have %d, want at most %d", obj, out.calls, limit)
+		}
+	}
+	// Ensure write errors on staged data are still reported
+	if err := ssz.EncodeToStream(&countingWriter{fail: 1}, list); err == nil {
+		t.Errorf("write error not reported")
+	}
+}
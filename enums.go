@@ -0,0 +1,144 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Enum is implemented by named integer types that only admit a subset of their
+// backing type's values (e.g. protocol versions or message kinds). Enums are
+// encoded and hashed exactly like their backing uints, but the decoder rejects
+// any value the type does not consider valid:
+//
+//	type Version uint8
+//
+//	func (v Version) Valid() bool { return v <= VersionLatest }
+type Enum interface {
+	Valid() bool
+}
+
+// DefineEnumUint8 defines the next field as an enum backed by a uint8.
+func DefineEnumUint8[T interface {
+	~uint8
+	Enum
+}](c *Codec, n *T) {
+	if c.enc != nil {
+		EncodeEnumUint8(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeEnumUint8(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatEnum(uint64(*n), (*n).Valid()))
+		return
+	}
+	HashEnumUint8(c.has, *n)
+}
+
+// DefineEnumUint64 defines the next field as an enum backed by a uint64.
+func DefineEnumUint64[T interface {
+	~uint64
+	Enum
+}](c *Codec, n *T) {
+	if c.enc != nil {
+		EncodeEnumUint64(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeEnumUint64(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatEnum(uint64(*n), (*n).Valid()))
+		return
+	}
+	HashEnumUint64(c.has, *n)
+}
+
+// EncodeEnumUint8 serializes an enum backed by a uint8.
+//
+// Note, an invalid value will be serialized, but halts encoding with an error.
+func EncodeEnumUint8[T interface {
+	~uint8
+	Enum
+}](enc *Encoder, n T) {
+	if !n.Valid() && enc.err == nil {
+		enc.err = fmt.Errorf("%w: %d", ErrInvalidEnum, uint8(n))
+	}
+	EncodeUint8(enc, n)
+}
+
+// EncodeEnumUint64 serializes an enum backed by a uint64.
+//
+// Note, an invalid value will be serialized, but halts encoding with an error.
+func EncodeEnumUint64[T interface {
+	~uint64
+	Enum
+}](enc *Encoder, n T) {
+	if !n.Valid() && enc.err == nil {
+		enc.err = fmt.Errorf("%w: %d", ErrInvalidEnum, uint64(n))
+	}
+	EncodeUint64(enc, n)
+}
+
+// DecodeEnumUint8 parses an enum backed by a uint8.
+func DecodeEnumUint8[T interface {
+	~uint8
+	Enum
+}](dec *Decoder, n *T) {
+	if dec.err != nil {
+		return
+	}
+	DecodeUint8(dec, n)
+	if dec.err == nil && !(*n).Valid() {
+		dec.err = fmt.Errorf("%w: found %d", ErrInvalidEnum, uint8(*n))
+	}
+}
+
+// DecodeEnumUint64 parses an enum backed by a uint64.
+func DecodeEnumUint64[T interface {
+	~uint64
+	Enum
+}](dec *Decoder, n *T) {
+	if dec.err != nil {
+		return
+	}
+	DecodeUint64(dec, n)
+	if dec.err == nil && !(*n).Valid() {
+		dec.err = fmt.Errorf("%w: found %d", ErrInvalidEnum, uint64(*n))
+	}
+}
+
+// HashEnumUint8 hashes an enum backed by a uint8.
+func HashEnumUint8[T interface {
+	~uint8
+	Enum
+}](h *Hasher, n T) {
+	HashUint8(h, n)
+}
+
+// HashEnumUint64 hashes an enum backed by a uint64.
+func HashEnumUint64[T interface {
+	~uint64
+	Enum
+}](h *Hasher, n T) {
+	HashUint64(h, n)
+}
+
+// formatEnum renders an enum value for the formatter, flagging invalid ones.
+func formatEnum(n uint64, valid bool) string {
+	if !valid {
+		return strconv.FormatUint(n, 10) + " (invalid)"
+	}
+	return strconv.FormatUint(n, 10)
+}
@@ -0,0 +1,85 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// testEnumVersion and testEnumKind are enums backed by a uint8 and a uint64,
+// and testEnumType is a container holding them.
+type testEnumVersion uint8
+
+func (v testEnumVersion) Valid() bool { return v >= 1 && v <= 3 }
+
+type testEnumKind uint64
+
+func (k testEnumKind) Valid() bool { return k == 0 || k == 1<<40 }
+
+type testEnumType struct {
+	Version testEnumVersion
+	Kind    testEnumKind
+}
+
+func (t *testEnumType) SizeSSZ() uint32 { return 1 + 8 }
+
+func (t *testEnumType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineEnumUint8(codec, &t.Version)
+	ssz.DefineEnumUint64(codec, &t.Kind)
+}
+
+// Tests that enum fields are encoded like plain uints, but invalid values are
+// rejected both when encoding and when decoding.
+func TestEnums(t *testing.T) {
+	valid := &testEnumType{Version: 2, Kind: 1 << 40}
+
+	blob := make([]byte, ssz.Size(valid))
+	if err := ssz.EncodeToBytes(blob, valid); err != nil {
+		t.Fatalf("failed to encode valid enums: %v", err)
+	}
+	if want := []byte{2, 0, 0, 0, 0, 0, 1, 0, 0}; !bytes.Equal(blob, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", blob, want)
+	}
+	decoded := new(testEnumType)
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode valid enums: %v", err)
+	}
+	if *decoded != *valid {
+		t.Errorf("decoded mismatch: have %+v, want %+v", decoded, valid)
+	}
+	// Ensure invalid values are rejected in both encoding and decoding
+	for _, invalid := range []*testEnumType{{Version: 4, Kind: 0}, {Version: 1, Kind: 1}} {
+		if err := ssz.EncodeToBytes(make([]byte, 9), invalid); !errors.Is(err, ssz.ErrInvalidEnum) {
+			t.Errorf("%+v: encode error mismatch: have %v, want %v", invalid, err, ssz.ErrInvalidEnum)
+		}
+		if err := ssz.EncodeToStream(io.Discard, invalid); !errors.Is(err, ssz.ErrInvalidEnum) {
+			t.Errorf("%+v: stream encode error mismatch: have %v, want %v", invalid, err, ssz.ErrInvalidEnum)
+		}
+		blob := []byte{byte(invalid.Version), 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.LittleEndian.PutUint64(blob[1:], uint64(invalid.Kind))
+
+		for _, stream := range []bool{false, true} {
+			var err error
+			if stream {
+				err = ssz.DecodeFromStream(bytes.NewReader(blob), new(testEnumType), uint32(len(blob)))
+			} else {
+				err = ssz.DecodeFromBytes(blob, new(testEnumType))
+			}
+			if !errors.Is(err, ssz.ErrInvalidEnum) {
+				t.Errorf("%+v: stream %v: decode error mismatch: have %v, want %v", invalid, stream, err, ssz.ErrInvalidEnum)
+			}
+			var derr *ssz.DecodeError
+			if errors.As(err, &derr) && derr.Kind != ssz.KindInvalidEnum {
+				t.Errorf("%+v: stream %v: error kind mismatch: have %v, want %v", invalid, stream, derr.Kind, ssz.KindInvalidEnum)
+			}
+		}
+	}
+}
@@ -4,7 +4,12 @@
 
 package ssz
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
 
 // ErrBufferTooSmall is returned from encoding if the provided output byte buffer
 // is too small to hold the encoding of the object.
@@ -23,6 +28,15 @@ var ErrBadOffsetProgression = errors.New("ssz: offset smaller than previous")
 // than the total capacity allowed by the decoder (i.e. message size)
 var ErrOffsetBeyondCapacity = errors.New("ssz: offset beyond capacity")
 
+// ErrMissingOffset is returned from decoding if the content of a dynamic field
+// is requested without its offset having been decoded (i.e. the decoder of the
+// type is asymmetric with its encoder).
+var ErrMissingOffset = errors.New("ssz: dynamic content without offset")
+
+// ErrOffsetUnderflow is returned from decoding if the size of a dynamic field
+// computed from its offsets would wrap around (i.e. it ends before it starts).
+var ErrOffsetUnderflow = errors.New("ssz: offset arithmetic underflow")
+
 // ErrMaxLengthExceeded is returned when the size calculated for a dynamic type
 // is larger than permitted.
 var ErrMaxLengthExceeded = errors.New("ssz: maximum item size exceeded")
@@ -47,14 +61,55 @@ var ErrBadCounterOffset = errors.New("ssz: counter offset not multiple of 4-byte
 // be decoded, but the list's total length is not divisible by the item size.
 var ErrDynamicStaticsIndivisible = errors.New("ssz: list of fixed objects not divisible")
 
+// ErrZeroSizeItem is returned when a list of static objects is to be decoded,
+// but the item factory creates objects of zero size.
+var ErrZeroSizeItem = errors.New("ssz: list of fixed objects with zero item size")
+
 // ErrObjectSlotSizeMismatch is returned from decoding if an object's slot in the
 // ssz stream contains more data than the object cares to consume.
 var ErrObjectSlotSizeMismatch = errors.New("ssz: object didn't consume all designated data")
 
+// ErrObjectTooLarge is returned when an object is larger than what the 4 byte
+// offsets of SSZ can address.
+var ErrObjectTooLarge = errors.New("ssz: object too large")
+
+// ErrExcessData is returned if a stream contains more data than the length of
+// the message it was supposed to carry (see ExactReader).
+var ErrExcessData = errors.New("ssz: excess data after message")
+
+// ErrMarshaledSizeMismatch is returned from encoding and checked hashing if an
+// opaque binary type marshals into a different number of bytes than its declared
+// static size.
+var ErrMarshaledSizeMismatch = errors.New("ssz: marshaled size mismatch")
+
+// ErrStaticBytesSizeMismatch is returned from encoding and hashing if a plain byte
+// slice in a checked static binary field has a different length than its size.
+var ErrStaticBytesSizeMismatch = errors.New("ssz: static bytes size mismatch")
+
+// ErrDynamicBytesSizeMismatch is returned from encoding or decoding if a bounded
+// or exact dynamic binary field is outside of its application size constraints.
+var ErrDynamicBytesSizeMismatch = errors.New("ssz: dynamic bytes size mismatch")
+
+// ErrNilPointer is returned from encoding if a required field held in a pointer
+// (e.g. via DefineCheckedUint64Pointer) is nil.
+var ErrNilPointer = errors.New("ssz: nil pointer in required field")
+
+// ErrUint256Overflow is returned from encoding if a big.Int is negative or does
+// not fit into 256 bits.
+var ErrUint256Overflow = errors.New("ssz: value out of uint256 range")
+
 // ErrInvalidBoolean is returned from decoding if a boolean slot contains some
 // other byte than 0x00 or 0x01.
 var ErrInvalidBoolean = errors.New("ssz: invalid boolean")
 
+// ErrInvalidEnum is returned from encoding or decoding if an enum field holds a
+// value its type does not consider valid.
+var ErrInvalidEnum = errors.New("ssz: invalid enum value")
+
+// ErrUnorderedKeys is returned from encoding or decoding if the entries of an
+// ordered map are not sorted by strictly ascending keys.
+var ErrUnorderedKeys = errors.New("ssz: ordered map keys not strictly ascending")
+
 // ErrJunkInBitvector is returned from decoding if the high (unused) bits of a
 // bitvector contains junk, instead of being all 0.
 var ErrJunkInBitvector = errors.New("ssz: junk in bitvector unused bits")
@@ -62,3 +117,212 @@ var ErrJunkInBitvector = errors.New("ssz: junk in bitvector unused bits")
 // ErrJunkInBitlist is returned from decoding if the high (unused) bits of a
 // bitlist contains junk, instead of being all 0.
 var ErrJunkInBitlist = errors.New("ssz: junk in bitlist unused bits")
+
+// ErrMaxDepthExceeded is returned from decoding if the data is nested deeper than
+// the configured (or default) maximum depth.
+var ErrMaxDepthExceeded = errors.New("ssz: maximum nesting depth exceeded")
+
+// ErrMaxAllocExceeded is returned from decoding if the memory that would need
+// to be allocated for the decoded data exceeds the configured budget.
+var ErrMaxAllocExceeded = errors.New("ssz: maximum allocation exceeded")
+
+// ErrNonCanonicalEncoding is returned from decoding in strict mode if the input
+// would not round-trip byte-for-byte when re-encoded.
+var ErrNonCanonicalEncoding = errors.New("ssz: non-canonical encoding")
+
+// ErrUnsupportedContentType is returned from the HTTP helpers if a request or
+// response body is not declared to be SSZ encoded.
+var ErrUnsupportedContentType = errors.New("ssz: unsupported content type")
+
+// ErrBodyTooLarge is returned from the HTTP helpers if a request or response
+// body exceeds the configured size limit.
+var ErrBodyTooLarge = errors.New("ssz: http body too large")
+
+// ErrUnknownField is returned when addressing a field of an object by a name
+// it does not define.
+var ErrUnknownField = errors.New("ssz: unknown field")
+
+// ErrInvalidSchema is returned from the runtime schema methods if the schema is
+// malformed (e.g. missing sizes or nested schemas).
+var ErrInvalidSchema = errors.New("ssz: invalid schema")
+
+// ErrSchemaMismatch is returned from the runtime schema methods if a value tree
+// does not match the schema it's being encoded or hashed with.
+var ErrSchemaMismatch = errors.New("ssz: value does not match schema")
+
+// ErrInvalidProof is returned if a merkle proof is malformed or does not hash
+// up to the expected root.
+var ErrInvalidProof = errors.New("ssz: invalid merkle proof")
+
+// ErrInvalidGeneralizedIndex is returned if a generalized index is zero, or if
+// composing generalized indices would overflow 64 bits.
+var ErrInvalidGeneralizedIndex = errors.New("ssz: invalid generalized index")
+
+// ErrUnprovenPath is returned when accessing a field of a partial view that is
+// not covered by the proof it was reconstructed from.
+var ErrUnprovenPath = errors.New("ssz: path not proven")
+
+// ErrTreeNodeNotFound is returned if a generalized index points outside of a
+// backing tree (e.g. below a leaf).
+var ErrTreeNodeNotFound = errors.New("ssz: tree node not found")
+
+// ErrInvalidItem is returned from decoding if a list item is rejected by the
+// validator of its list.
+var ErrInvalidItem = errors.New("ssz: list item failed validation")
+
+// ErrInvalidValue is returned from decoding if a field is rejected by its
+// validator (e.g. a uint256 above its maximum).
+var ErrInvalidValue = errors.New("ssz: value failed validation")
+
+// ErrSelfCheckFailed is returned from Verify if a platform dependent fast path
+// of the codec does not match its portable definition on the current platform.
+var ErrSelfCheckFailed = errors.New("ssz: platform self-check failed")
+
+// ErrMissingVersionHeader is returned when decoding a versioned blob that does not
+// start with a version header (e.g. one written via plain encoding).
+var ErrMissingVersionHeader = errors.New("ssz: missing version header")
+
+// ErrIncompatibleLayout is returned when decoding a versioned blob written by an
+// incompatible codec version or with a different schema fingerprint.
+var ErrIncompatibleLayout = errors.New("ssz: incompatible encoding layout")
+
+// ErrInvalidJSON is returned when a JSON value does not match the JSON mapping
+// of the ssz field it is decoded into (e.g. a number instead of a string).
+var ErrInvalidJSON = errors.New("ssz: invalid json value")
+
+// ErrorKind is a numeric classification of decoding failures, useful to handle
+// specific malformations programmatically (e.g. in metrics or peer scoring).
+type ErrorKind uint64
+
+const (
+	KindUnknown                   ErrorKind = iota // Error not originating from the ssz package (e.g. stream failure)
+	KindUnexpectedEOF                              // Input ended before the object was fully decoded
+	KindFirstOffsetMismatch                        // See ErrFirstOffsetMismatch
+	KindBadOffsetProgression                       // See ErrBadOffsetProgression
+	KindOffsetBeyondCapacity                       // See ErrOffsetBeyondCapacity
+	KindMaxLengthExceeded                          // See ErrMaxLengthExceeded
+	KindMaxItemsExceeded                           // See ErrMaxItemsExceeded
+	KindShortCounterOffset                         // See ErrShortCounterOffset
+	KindZeroCounterOffset                          // See ErrZeroCounterOffset
+	KindBadCounterOffset                           // See ErrBadCounterOffset
+	KindDynamicStaticsIndivisible                  // See ErrDynamicStaticsIndivisible
+	KindObjectSlotSizeMismatch                     // See ErrObjectSlotSizeMismatch
+	KindInvalidBoolean                             // See ErrInvalidBoolean
+	KindJunkInBitvector                            // See ErrJunkInBitvector
+	KindJunkInBitlist                              // See ErrJunkInBitlist
+	KindMaxAllocExceeded                           // See ErrMaxAllocExceeded
+	KindNonCanonicalEncoding                       // See ErrNonCanonicalEncoding
+	KindObjectTooLarge                             // See ErrObjectTooLarge
+	KindInvalidEnum                                // See ErrInvalidEnum
+	KindUnorderedKeys                              // See ErrUnorderedKeys
+	KindMaxDepthExceeded                           // See ErrMaxDepthExceeded
+	KindMissingOffset                              // See ErrMissingOffset
+	KindOffsetUnderflow                            // See ErrOffsetUnderflow
+	KindDynamicBytesSizeMismatch                   // See ErrDynamicBytesSizeMismatch
+	KindInvalidItem                                // See ErrInvalidItem
+	KindInvalidValue                               // See ErrInvalidValue
+	KindZeroSizeItem                               // See ErrZeroSizeItem
+)
+
+// errorKinds maps the error kinds to the sentinel errors they stand for.
+var errorKinds = [...]error{
+	KindUnknown:                   nil,
+	KindUnexpectedEOF:             io.ErrUnexpectedEOF,
+	KindFirstOffsetMismatch:       ErrFirstOffsetMismatch,
+	KindBadOffsetProgression:      ErrBadOffsetProgression,
+	KindOffsetBeyondCapacity:      ErrOffsetBeyondCapacity,
+	KindMaxLengthExceeded:         ErrMaxLengthExceeded,
+	KindMaxItemsExceeded:          ErrMaxItemsExceeded,
+	KindShortCounterOffset:        ErrShortCounterOffset,
+	KindZeroCounterOffset:         ErrZeroCounterOffset,
+	KindBadCounterOffset:          ErrBadCounterOffset,
+	KindDynamicStaticsIndivisible: ErrDynamicStaticsIndivisible,
+	KindObjectSlotSizeMismatch:    ErrObjectSlotSizeMismatch,
+	KindInvalidBoolean:            ErrInvalidBoolean,
+	KindJunkInBitvector:           ErrJunkInBitvector,
+	KindJunkInBitlist:             ErrJunkInBitlist,
+	KindMaxAllocExceeded:          ErrMaxAllocExceeded,
+	KindNonCanonicalEncoding:      ErrNonCanonicalEncoding,
+	KindObjectTooLarge:            ErrObjectTooLarge,
+	KindInvalidEnum:               ErrInvalidEnum,
+	KindUnorderedKeys:             ErrUnorderedKeys,
+	KindMaxDepthExceeded:          ErrMaxDepthExceeded,
+	KindMissingOffset:             ErrMissingOffset,
+	KindOffsetUnderflow:           ErrOffsetUnderflow,
+	KindDynamicBytesSizeMismatch:  ErrDynamicBytesSizeMismatch,
+	KindInvalidItem:               ErrInvalidItem,
+	KindInvalidValue:              ErrInvalidValue,
+	KindZeroSizeItem:              ErrZeroSizeItem,
+}
+
+// String implements fmt.Stringer, returning the sentinel error's message.
+func (k ErrorKind) String() string {
+	if k == KindUnknown || int(k) >= len(errorKinds) {
+		return "unknown"
+	}
+	return errorKinds[k].Error()
+}
+
+// DecodeError is the error returned by the top level decoding methods, wrapping
+// the underlying failure with some structured metadata. The underlying error is
+// retained, so errors.Is checks against the sentinel errors keep working.
+type DecodeError struct {
+	Kind   ErrorKind // Classification of the failure
+	Field  []int     // Path of the field being decoded (only if a FieldTracer is set)
+	Offset uint32    // Position in the input where the failure was detected
+	Detail string    // Failure specifics on top of the kind (may be empty)
+
+	err error // Underlying error for unwrapping
+}
+
+// newDecodeError wraps an error hit during decoding at the given position.
+func newDecodeError(err error, offset uint32, field []int) *DecodeError {
+	kind := KindUnknown
+	for i := 1; i < len(errorKinds); i++ {
+		if errors.Is(err, errorKinds[i]) {
+			kind = ErrorKind(i)
+			break
+		}
+	}
+	detail := err.Error()
+	if kind != KindUnknown {
+		detail = strings.TrimPrefix(strings.TrimPrefix(detail, errorKinds[kind].Error()), ": ")
+	}
+	return &DecodeError{
+		Kind:   kind,
+		Field:  field,
+		Offset: offset,
+		Detail: detail,
+		err:    err,
+	}
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	if e.Field != nil {
+		return fmt.Sprintf("%v (offset %d, field %v)", e.err, e.Offset, e.Field)
+	}
+	return fmt.Sprintf("%v (offset %d)", e.err, e.Offset)
+}
+
+// Unwrap returns the underlying error.
+func (e *DecodeError) Unwrap() error {
+	return e.err
+}
+
+// ElementError is a failure to decode a single element of a list of objects,
+// which was skipped over in resync mode (see DecoderConfig.ResyncElements).
+type ElementError struct {
+	Index uint32       // Position of the element within its list
+	Err   *DecodeError // Failure hit while decoding the element
+}
+
+// Error implements the error interface.
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
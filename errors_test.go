@@ -0,0 +1,72 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// Tests that decoding failures are reported as structured errors, while still
+// matching the sentinel errors.
+func TestDecodeErrorTaxonomy(t *testing.T) {
+	obj := &types.Attestation{
+		AggregationBits: bitfield.Bitlist{0x0f, 0x01},
+		Data: &types.AttestationData{
+			Source: new(types.Checkpoint),
+			Target: new(types.Checkpoint),
+		},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	blob[0]++ // corrupt the aggregation bits offset
+
+	for _, stream := range []bool{false, true} {
+		cfg := &ssz.DecoderConfig{OnField: func([]int, uint32, uint32) {}}
+
+		var err error
+		if stream {
+			err = ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), new(types.Attestation), uint32(len(blob)), cfg)
+		} else {
+			err = ssz.DecodeFromBytesWithConfig(blob, new(types.Attestation), cfg)
+		}
+		if !errors.Is(err, ssz.ErrFirstOffsetMismatch) {
+			t.Fatalf("stream %v: error mismatch: have %v, want %v", stream, err, ssz.ErrFirstOffsetMismatch)
+		}
+		var derr *ssz.DecodeError
+		if !errors.As(err, &derr) {
+			t.Fatalf("stream %v: error not a DecodeError: %T", stream, err)
+		}
+		if derr.Kind != ssz.KindFirstOffsetMismatch {
+			t.Errorf("stream %v: kind mismatch: have %v, want %v", stream, derr.Kind, ssz.KindFirstOffsetMismatch)
+		}
+		if derr.Offset != 4 {
+			t.Errorf("stream %v: offset mismatch: have %d, want %d", stream, derr.Offset, 4)
+		}
+		if !reflect.DeepEqual(derr.Field, []int{0}) {
+			t.Errorf("stream %v: field mismatch: have %v, want %v", stream, derr.Field, []int{0})
+		}
+		if derr.Detail == "" {
+			t.Errorf("stream %v: missing failure details", stream)
+		}
+	}
+	// Errors not originating from the ssz package should be classified unknown
+	err := ssz.DecodeFromStream(iotest.ErrReader(io.ErrClosedPipe), new(types.Attestation), uint32(len(blob)))
+
+	var derr *ssz.DecodeError
+	if !errors.As(err, &derr) || derr.Kind != ssz.KindUnknown || !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("stream failure mismatch: have %v", err)
+	}
+}
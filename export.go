@@ -0,0 +1,190 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// EncodeField serializes a single top level field of an object into a stream,
+// addressed by its Go name. Static fields are emitted as their encoding within
+// the static section, dynamic fields as their content region (i.e. without the
+// offset pointing to it), which is the standalone encoding of the field's value.
+//
+// This allows state sync servers to stream selected portions of large objects
+// (e.g. the validator registry of a beacon state); clients can reassemble the
+// container by concatenating the static fields and the offsets derived from the
+// sizes of the dynamic regions, followed by the regions themselves.
+//
+// Note, the field's position is only known after encoding the preceding ones,
+// so the entire object is encoded into memory before the field is emitted.
+func EncodeField(w io.Writer, obj Object, name string) error {
+	index, err := fieldIndex(obj, name)
+	if err != nil {
+		return err
+	}
+	blob, err := EncodeAppend(nil, obj)
+	if err != nil {
+		return err
+	}
+	// Locate the field's region by tracing a decoding of the encoded object
+	regions, err := traceFields(blob, reflect.New(reflect.TypeOf(obj).Elem()).Interface().(Object))
+	if err != nil {
+		return err
+	}
+	if index >= len(regions) {
+		return fmt.Errorf("%w: %s: not traced by the decoder", ErrUnknownField, name)
+	}
+	region := regions[index]
+
+	_, err = w.Write(blob[region.offset : region.offset+region.size])
+	return err
+}
+
+// Patch replaces a single top level field, addressed by its Go name, within the
+// encoding of an object, returning the patched encoding. The value is the new
+// encoding of the field in the format emitted by EncodeField: exactly the field
+// size for static fields, and the content region for dynamic ones, in which
+// case the offsets of the subsequent dynamic fields are fixed up.
+//
+// The object is only used as the descriptor of the encoded type: it is decoded
+// into (overwriting its contents) to locate the field, and once again to check
+// that the patched encoding is valid. The input data is never modified.
+func Patch(data []byte, obj Object, name string, value []byte) ([]byte, error) {
+	index, err := fieldIndex(obj, name)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := traceFields(data, obj)
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(regions) {
+		return nil, fmt.Errorf("%w: %s: not traced by the decoder", ErrUnknownField, name)
+	}
+	region := regions[index]
+
+	var patched []byte
+	if !region.dynamic {
+		if uint32(len(value)) != region.size {
+			return nil, fmt.Errorf("%w: field %s: have %d bytes, want %d", ErrStaticBytesSizeMismatch, name, len(value), region.size)
+		}
+		patched = append([]byte(nil), data...)
+		copy(patched[region.offset:], value)
+	} else {
+		size := uint64(len(data)) - uint64(region.size) + uint64(len(value))
+		if size > math.MaxUint32 {
+			return nil, fmt.Errorf("%w: patched size %d bytes", ErrObjectTooLarge, size)
+		}
+		patched = make([]byte, 0, size)
+		patched = append(patched, data[:region.offset]...)
+		patched = append(patched, value...)
+		patched = append(patched, data[region.offset+region.size:]...)
+
+		// Shift the offsets of all the dynamic fields after the patched one
+		var slot uint32
+		for i, r := range regions {
+			if i > index && r.dynamic {
+				offset := binary.LittleEndian.Uint32(patched[slot:])
+				binary.LittleEndian.PutUint32(patched[slot:], offset+uint32(len(value))-region.size)
+			}
+			if r.dynamic {
+				slot += 4
+			} else {
+				slot += r.size
+			}
+		}
+	}
+	if err := DecodeFromBytes(patched, obj); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+// DecodeFromBytesWithRaw decodes an object from a byte buffer, additionally
+// returning the raw encoding of a single top level field, addressed by its Go
+// name (e.g. the Message of a signed envelope), in the format emitted by
+// EncodeField. This allows verifying signatures over the original bytes of a
+// sub-object within the same decoding pass, without re-encoding it.
+//
+// The returned bytes are a copy, they do not alias the input buffer. Types that
+// always need the raw bytes of a dynamic field can capture them on their own
+// via DefineDynamicObjectRawContent instead.
+func DecodeFromBytesWithRaw(blob []byte, obj Object, name string) ([]byte, error) {
+	index, err := fieldIndex(obj, name)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := traceFields(blob, obj)
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(regions) {
+		return nil, fmt.Errorf("%w: %s: not traced by the decoder", ErrUnknownField, name)
+	}
+	region := regions[index]
+	return append([]byte(nil), blob[region.offset:region.offset+region.size]...), nil
+}
+
+// fieldRegion is the position of a top level field within an encoding.
+type fieldRegion struct {
+	offset  uint32 // Position of the field's data (content region if dynamic)
+	size    uint32 // Number of bytes the field's data spans
+	dynamic bool   // Whether the field is dynamic (data located via an offset)
+}
+
+// fieldIndex resolves the position of a top level field of an object, addressed
+// by its Go name.
+func fieldIndex(obj Object, name string) (int, error) {
+	typ := reflect.TypeOf(obj)
+	if typ.Kind() != reflect.Pointer || typ.Elem().Kind() != reflect.Struct {
+		return 0, fmt.Errorf("%w: %s: %s is not a struct", ErrUnknownField, name, typ)
+	}
+	for i, field := range fieldNames(obj) {
+		if field == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s.%s", ErrUnknownField, typ.Elem().Name(), name)
+}
+
+// fieldNames returns the Go names of the top level fields of an object, in the
+// order of their definition. Fields not resolvable to a struct field (e.g. the
+// fields of asymmetric codecs) are named by their position.
+func fieldNames(obj Object) []string {
+	f := new(formatter)
+	f.codec = &Codec{fmt: f}
+	f.body(obj)
+
+	return f.fields
+}
+
+// traceFields decodes an encoding into the object, collecting the regions of all
+// its top level fields, in definition order.
+func traceFields(blob []byte, obj Object) ([]fieldRegion, error) {
+	var (
+		static  = StaticSize(obj)
+		regions []fieldRegion
+	)
+	cfg := &DecoderConfig{
+		OnField: func(path []int, offset uint32, size uint32) {
+			if len(path) != 1 {
+				return
+			}
+			for len(regions) <= path[0] {
+				regions = append(regions, fieldRegion{})
+			}
+			regions[path[0]] = fieldRegion{offset: offset, size: size, dynamic: offset >= static}
+		},
+	}
+	if err := DecodeFromBytesWithConfig(blob, obj, cfg); err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
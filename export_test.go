@@ -0,0 +1,199 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that single top level fields can be exported from an object, and that
+// the container can be reassembled from them.
+func TestEncodeField(t *testing.T) {
+	obj := &types.ExecutionPayload{
+		BlockNumber:   1,
+		ExtraData:     []byte{0x02, 0x03},
+		BaseFeePerGas: uint256.NewInt(4),
+		BlockHash:     types.Hash{0x05},
+		Transactions:  [][]byte{{0x06}, {0x07, 0x08}},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	export := func(name string) []byte {
+		buf := new(bytes.Buffer)
+		if err := ssz.EncodeField(buf, obj, name); err != nil {
+			t.Fatalf("failed to export field %s: %v", name, err)
+		}
+		return buf.Bytes()
+	}
+	// Ensure static fields are exported as is
+	if have, want := export("BlockNumber"), binary.LittleEndian.AppendUint64(nil, 1); !bytes.Equal(have, want) {
+		t.Errorf("static field mismatch: have %x, want %x", have, want)
+	}
+	if have, want := export("BlockHash"), obj.BlockHash[:]; !bytes.Equal(have, want) {
+		t.Errorf("static array mismatch: have %x, want %x", have, want)
+	}
+	// Ensure dynamic fields are exported as their standalone content
+	if have, want := export("ExtraData"), obj.ExtraData; !bytes.Equal(have, want) {
+		t.Errorf("dynamic field mismatch: have %x, want %x", have, want)
+	}
+	if have, want := export("Transactions"), []byte{8, 0, 0, 0, 9, 0, 0, 0, 0x06, 0x07, 0x08}; !bytes.Equal(have, want) {
+		t.Errorf("dynamic list mismatch: have %x, want %x", have, want)
+	}
+	// Ensure the container can be reassembled from its fields
+	var (
+		statics  []byte
+		contents []byte
+	)
+	dynamics := map[string]bool{"ExtraData": true, "Transactions": true}
+	for _, name := range []string{
+		"ParentHash", "FeeRecipient", "StateRoot", "ReceiptsRoot", "LogsBloom", "PrevRandao",
+		"BlockNumber", "GasLimit", "GasUsed", "Timestamp", "ExtraData", "BaseFeePerGas",
+		"BlockHash", "Transactions",
+	} {
+		field := export(name)
+		if dynamics[name] {
+			statics = binary.LittleEndian.AppendUint32(statics, ssz.StaticSize(obj)+uint32(len(contents)))
+			contents = append(contents, field...)
+		} else {
+			statics = append(statics, field...)
+		}
+	}
+	if have := append(statics, contents...); !bytes.Equal(have, blob) {
+		t.Errorf("reassembled mismatch: have %x, want %x", have, blob)
+	}
+	// Ensure unknown fields are rejected
+	if err := ssz.EncodeField(io.Discard, obj, "Withdrawals"); !errors.Is(err, ssz.ErrUnknownField) {
+		t.Errorf("unknown field error mismatch: have %v, want %v", err, ssz.ErrUnknownField)
+	}
+}
+
+// Tests that single top level fields can be patched within an encoding, fixing
+// up the offsets of the subsequent dynamic fields.
+func TestPatch(t *testing.T) {
+	obj := &types.ExecutionPayload{
+		BlockNumber:   1,
+		ExtraData:     []byte{0x02, 0x03},
+		BaseFeePerGas: uint256.NewInt(4),
+		Transactions:  [][]byte{{0x06}, {0x07, 0x08}},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	original := bytes.Clone(blob)
+
+	tests := []struct {
+		name  string
+		value []byte
+		apply func(obj *types.ExecutionPayload)
+	}{
+		{"GasLimit", binary.LittleEndian.AppendUint64(nil, 9), func(obj *types.ExecutionPayload) { obj.GasLimit = 9 }},
+		{"ExtraData", []byte{0x0a, 0x0b, 0x0c, 0x0d}, func(obj *types.ExecutionPayload) { obj.ExtraData = []byte{0x0a, 0x0b, 0x0c, 0x0d} }},
+		{"ExtraData", []byte{}, func(obj *types.ExecutionPayload) { obj.ExtraData = []byte{} }},
+		{"Transactions", []byte{4, 0, 0, 0, 0x0e}, func(obj *types.ExecutionPayload) { obj.Transactions = [][]byte{{0x0e}} }},
+	}
+	for i, tt := range tests {
+		patched, err := ssz.Patch(blob, new(types.ExecutionPayload), tt.name, tt.value)
+		if err != nil {
+			t.Fatalf("test %d: failed to patch %s: %v", i, tt.name, err)
+		}
+		want := &types.ExecutionPayload{
+			BlockNumber:   obj.BlockNumber,
+			ExtraData:     obj.ExtraData,
+			BaseFeePerGas: obj.BaseFeePerGas,
+			Transactions:  obj.Transactions,
+		}
+		tt.apply(want)
+
+		wantBlob := make([]byte, ssz.Size(want))
+		if err := ssz.EncodeToBytes(wantBlob, want); err != nil {
+			t.Fatalf("test %d: failed to encode expected object: %v", i, err)
+		}
+		if !bytes.Equal(patched, wantBlob) {
+			t.Errorf("test %d: patched encoding mismatch: have %x, want %x", i, patched, wantBlob)
+		}
+	}
+	if !bytes.Equal(blob, original) {
+		t.Errorf("input modified by patching")
+	}
+	// Ensure invalid patches are rejected
+	if _, err := ssz.Patch(blob, new(types.ExecutionPayload), "GasLimit", []byte{1}); !errors.Is(err, ssz.ErrStaticBytesSizeMismatch) {
+		t.Errorf("static size error mismatch: have %v, want %v", err, ssz.ErrStaticBytesSizeMismatch)
+	}
+	if _, err := ssz.Patch(blob, new(types.ExecutionPayload), "ExtraData", make([]byte, 33)); !errors.Is(err, ssz.ErrMaxLengthExceeded) {
+		t.Errorf("limit error mismatch: have %v, want %v", err, ssz.ErrMaxLengthExceeded)
+	}
+	if _, err := ssz.Patch(blob, new(types.ExecutionPayload), "Unknown", nil); !errors.Is(err, ssz.ErrUnknownField) {
+		t.Errorf("unknown field error mismatch: have %v, want %v", err, ssz.ErrUnknownField)
+	}
+}
+
+// Tests that the raw encoding of a top level field can be retrieved along with
+// decoding the object, for both static and dynamic fields.
+func TestDecodeFromBytesWithRaw(t *testing.T) {
+	signed := &types.SignedBLSToExecutionChange{
+		Message: &types.BLSToExecutionChange{
+			ValidatorIndex:     1,
+			FromBLSPubKey:      [48]byte{0x02},
+			ToExecutionAddress: [20]byte{0x03},
+		},
+		Signature: [96]byte{0x04},
+	}
+	blob := make([]byte, ssz.Size(signed))
+	if err := ssz.EncodeToBytes(blob, signed); err != nil {
+		t.Fatalf("failed to encode signed message: %v", err)
+	}
+	want := make([]byte, ssz.Size(signed.Message))
+	if err := ssz.EncodeToBytes(want, signed.Message); err != nil {
+		t.Fatalf("failed to encode message: %v", err)
+	}
+	decoded := new(types.SignedBLSToExecutionChange)
+	raw, err := ssz.DecodeFromBytesWithRaw(blob, decoded, "Message")
+	if err != nil {
+		t.Fatalf("failed to decode signed message: %v", err)
+	}
+	if !bytes.Equal(raw, want) {
+		t.Errorf("static raw bytes mismatch: have %x, want %x", raw, want)
+	}
+	if !reflect.DeepEqual(decoded, signed) {
+		t.Errorf("decoded mismatch: have %+v, want %+v", decoded, signed)
+	}
+	// Retrieve a dynamic field too, which must not alias the input
+	obj := &testRawPayloadType{
+		Slot:    1,
+		Payload: &types.ExecutionPayload{ExtraData: []byte{0x02}, BaseFeePerGas: uint256.NewInt(3)},
+	}
+	blob = make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	want = make([]byte, ssz.Size(obj.Payload))
+	if err := ssz.EncodeToBytes(want, obj.Payload); err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	raw, err = ssz.DecodeFromBytesWithRaw(blob, new(testRawPayloadType), "Payload")
+	if err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	clear(blob)
+	if !bytes.Equal(raw, want) {
+		t.Errorf("dynamic raw bytes mismatch: have %x, want %x", raw, want)
+	}
+	// Ensure unknown fields are rejected
+	if _, err := ssz.DecodeFromBytesWithRaw(blob, new(types.SignedBLSToExecutionChange), "Unknown"); !errors.Is(err, ssz.ErrUnknownField) {
+		t.Errorf("unknown field error mismatch: have %v, want %v", err, ssz.ErrUnknownField)
+	}
+}
@@ -0,0 +1,114 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import "strconv"
+
+// Fork is an Ethereum consensus fork, used by monolithic types (a single Go type
+// covering the variants of a container across all forks) to decide which of
+// their fork-conditional fields are part of the encoding.
+type Fork uint8
+
+const (
+	ForkUnknown Fork = iota // Fork not known, use the newest layout of objects
+
+	ForkPhase0
+	ForkAltair
+	ForkBellatrix
+	ForkCapella
+	ForkDeneb
+	ForkElectra
+	ForkFulu
+
+	ForkFuture // Use this for fields not yet shipped on any fork
+)
+
+// forkNames are the human readable names of the known forks.
+var forkNames = [...]string{
+	ForkUnknown:   "unknown",
+	ForkPhase0:    "phase0",
+	ForkAltair:    "altair",
+	ForkBellatrix: "bellatrix",
+	ForkCapella:   "capella",
+	ForkDeneb:     "deneb",
+	ForkElectra:   "electra",
+	ForkFulu:      "fulu",
+	ForkFuture:    "future",
+}
+
+// String implements fmt.Stringer, returning the lowercase name of the fork as
+// used in the consensus specs and in the ssz-fork struct tags.
+func (f Fork) String() string {
+	if int(f) < len(forkNames) {
+		return forkNames[f]
+	}
+	return "fork(" + strconv.Itoa(int(f)) + ")"
+}
+
+// ForkFilter is the presence condition of a fork-conditional field: it is part
+// of the encoding from (and including) the Added fork, until (but excluding)
+// the Removed fork. Zero values mean no lower or upper bound respectively.
+type ForkFilter struct {
+	Added   Fork
+	Removed Fork
+}
+
+// Active reports whether a field with this filter is present on the given fork.
+// If the fork is unknown, the newest layout is used, i.e. fields that are ever
+// removed are absent and all others present.
+func (f ForkFilter) Active(fork Fork) bool {
+	if fork == ForkUnknown {
+		return f.Removed == ForkUnknown
+	}
+	return fork >= f.Added && (f.Removed == ForkUnknown || fork < f.Removed)
+}
+
+// ForkedObject is implemented by dynamic monolithic types, whose layout depends
+// on the fork they are encoded on. The codec uses the fork aware sizes when an
+// operation is run on a specific fork (e.g. EncodeToBytesOnFork), and SizeSSZ
+// otherwise, which must be the size on ForkUnknown.
+type ForkedObject interface {
+	DynamicObject
+
+	// SizeSSZOnFork returns either the static size of the object on the given
+	// fork if fixed == true, or the total size otherwise.
+	SizeSSZOnFork(fork Fork, fixed bool) uint32
+}
+
+// Fork returns the fork the codec is operating on, or ForkUnknown if it was not
+// invoked on a specific one.
+func (c *Codec) Fork() Fork {
+	return c.fork
+}
+
+// OnFork reports whether a fork-conditional field with the given filter is part
+// of the object on the fork the codec is operating on. If it is not, the field
+// must not be defined, and OnFork zeroes it out when decoding, so no stale data
+// from a previous fork is left in the object:
+//
+//	if ssz.OnFork(codec, &obj.BlobGasUsed, ssz.ForkFilter{Added: ssz.ForkDeneb}) {
+//		ssz.DefineUint64(codec, &obj.BlobGasUsed)
+//	}
+func OnFork[T any](c *Codec, field *T, filter ForkFilter) bool {
+	if filter.Active(c.fork) {
+		return true
+	}
+	if c.dec != nil {
+		var zero T
+		*field = zero
+	}
+	return false
+}
+
+// sizeOnFork returns the static or total size of a dynamic object on a specific
+// fork, falling back to the fork independent size for non-monolithic types.
+func sizeOnFork(obj DynamicObject, fork Fork, fixed bool) uint32 {
+	if fork != ForkUnknown {
+		if forked, ok := obj.(ForkedObject); ok {
+			return forked.SizeSSZOnFork(fork, fixed)
+		}
+	}
+	return obj.SizeSSZ(fixed)
+}
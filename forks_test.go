@@ -0,0 +1,126 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that monolithic types encode, decode, hash and size exactly like the
+// dedicated types of the fork they are operated on, including when nested.
+func TestForkMonolith(t *testing.T) {
+	var (
+		withdrawals = []*types.Withdrawal{{Index: 1, Validator: 2, Address: types.Address{0x03}, Amount: 4}}
+		changes     = []*types.SignedBLSToExecutionChange{{Message: &types.BLSToExecutionChange{ValidatorIndex: 5}}}
+		commitments = [][48]byte{{0x06}}
+	)
+	monolith := &types.BeaconBlockBodyMonolith{
+		Graffiti:      [32]byte{0x07},
+		Eth1Data:      new(types.Eth1Data),
+		SyncAggregate: new(types.SyncAggregate),
+		ExecutionPayload: &types.ExecutionPayloadMonolith{
+			BlockNumber:   8,
+			ExtraData:     []byte{0x09},
+			BaseFeePerGas: uint256.NewInt(10),
+			Transactions:  [][]byte{{0x0b}},
+			Withdrawals:   withdrawals,
+			BlobGasUsed:   12,
+			ExcessBlobGas: 13,
+		},
+		BlsToExecutionChanges: changes,
+		BlobKzgCommitments:    commitments,
+	}
+	payload := monolith.ExecutionPayload
+
+	tests := []struct {
+		fork ssz.Fork
+		body ssz.DynamicObject
+	}{
+		{ssz.ForkBellatrix, &types.BeaconBlockBodyBellatrix{
+			Graffiti: monolith.Graffiti, Eth1Data: monolith.Eth1Data, SyncAggregate: monolith.SyncAggregate,
+			ExecutionPayload: &types.ExecutionPayload{
+				BlockNumber: payload.BlockNumber, ExtraData: payload.ExtraData, BaseFeePerGas: payload.BaseFeePerGas,
+				Transactions: payload.Transactions,
+			},
+		}},
+		{ssz.ForkCapella, &types.BeaconBlockBodyCapella{
+			Graffiti: monolith.Graffiti, Eth1Data: monolith.Eth1Data, SyncAggregate: monolith.SyncAggregate,
+			ExecutionPayload: &types.ExecutionPayloadCapella{
+				BlockNumber: payload.BlockNumber, ExtraData: payload.ExtraData, BaseFeePerGas: payload.BaseFeePerGas,
+				Transactions: payload.Transactions, Withdrawals: withdrawals,
+			},
+			BlsToExecutionChanges: changes,
+		}},
+		{ssz.ForkDeneb, &types.BeaconBlockBodyDeneb{
+			Graffiti: monolith.Graffiti, Eth1Data: monolith.Eth1Data, SyncAggregate: monolith.SyncAggregate,
+			ExecutionPayload: &types.ExecutionPayloadDeneb{
+				BlockNumber: payload.BlockNumber, ExtraData: payload.ExtraData, BaseFeePerGas: payload.BaseFeePerGas,
+				Transactions: payload.Transactions, Withdrawals: withdrawals,
+				BlobGasUsed: payload.BlobGasUsed, ExcessBlobGas: payload.ExcessBlobGas,
+			},
+			BlsToExecutionChanges: changes,
+			BlobKzgCommitments:    commitments,
+		}},
+	}
+	for _, tt := range tests {
+		want := make([]byte, ssz.Size(tt.body))
+		if err := ssz.EncodeToBytes(want, tt.body); err != nil {
+			t.Fatalf("%v: failed to encode fork body: %v", tt.fork, err)
+		}
+		if size := ssz.SizeOnFork(monolith, tt.fork); size != uint32(len(want)) {
+			t.Errorf("%v: size mismatch: have %d, want %d", tt.fork, size, len(want))
+		}
+		have := make([]byte, len(want))
+		if err := ssz.EncodeToBytesOnFork(have, monolith, tt.fork); err != nil {
+			t.Fatalf("%v: failed to encode monolith: %v", tt.fork, err)
+		}
+		if !bytes.Equal(have, want) {
+			t.Errorf("%v: encoding mismatch:\nhave %x\nwant %x", tt.fork, have, want)
+		}
+		buf := new(bytes.Buffer)
+		if err := ssz.EncodeToStreamOnFork(buf, monolith, tt.fork); err != nil {
+			t.Fatalf("%v: failed to stream monolith: %v", tt.fork, err)
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("%v: stream encoding mismatch:\nhave %x\nwant %x", tt.fork, buf.Bytes(), want)
+		}
+		if have, want := ssz.HashSequentialOnFork(monolith, tt.fork), ssz.HashSequential(tt.body); have != want {
+			t.Errorf("%v: root mismatch: have %x, want %x", tt.fork, have, want)
+		}
+		if have, want := ssz.HashConcurrentOnFork(monolith, tt.fork), ssz.HashSequential(tt.body); have != want {
+			t.Errorf("%v: concurrent root mismatch: have %x, want %x", tt.fork, have, want)
+		}
+		// Decode into a fully populated monolith, the fields absent from the
+		// fork must be cleared, not left over
+		decoded := &types.BeaconBlockBodyMonolith{
+			BlobKzgCommitments: commitments,
+			ExecutionPayload:   &types.ExecutionPayloadMonolith{Withdrawals: withdrawals, BlobGasUsed: 1},
+		}
+		if err := ssz.DecodeFromBytesOnFork(want, decoded, tt.fork); err != nil {
+			t.Fatalf("%v: failed to decode monolith: %v", tt.fork, err)
+		}
+		if tt.fork < ssz.ForkDeneb && (decoded.BlobKzgCommitments != nil || decoded.ExecutionPayload.BlobGasUsed != 0) {
+			t.Errorf("%v: deneb fields not cleared", tt.fork)
+		}
+		if tt.fork < ssz.ForkCapella && decoded.ExecutionPayload.Withdrawals != nil {
+			t.Errorf("%v: capella fields not cleared", tt.fork)
+		}
+		if err := ssz.DecodeFromStreamOnFork(bytes.NewReader(want), decoded, uint32(len(want)), tt.fork); err != nil {
+			t.Fatalf("%v: failed to stream decode monolith: %v", tt.fork, err)
+		}
+		if have := ssz.HashSequentialOnFork(decoded, tt.fork); have != ssz.HashSequential(tt.body) {
+			t.Errorf("%v: decoded root mismatch: have %x, want %x", tt.fork, have, ssz.HashSequential(tt.body))
+		}
+	}
+	// Without a fork, monoliths use their newest layout
+	if have, want := ssz.HashSequential(monolith), ssz.HashSequentialOnFork(monolith, ssz.ForkDeneb); have != want {
+		t.Errorf("unknown fork root mismatch: have %x, want %x", have, want)
+	}
+}
@@ -0,0 +1,284 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	bitops "math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/holiman/uint256"
+)
+
+const (
+	// formatBytesLimit is the byte length above which blobs are truncated when
+	// formatting an object.
+	formatBytesLimit = 32
+
+	// formatItemsLimit is the number of list items above which lists are
+	// truncated when formatting an object.
+	formatItemsLimit = 8
+)
+
+// Format returns a human-readable, multi-line rendering of an ssz object, for
+// logs and test failure output. Fields are printed with their Go names, long
+// binary blobs and lists are truncated and dynamic fields are annotated with
+// their lengths versus their limits.
+//
+// The rendering is driven by the object's schema definition, so it is not meant
+// to be parsed back. Types using asymmetric codecs cannot be rendered field by
+// field and will only be annotated as such.
+func Format(obj Object) string {
+	f := new(formatter)
+	f.codec = &Codec{fmt: f}
+
+	f.nested(obj)
+	return strings.TrimSuffix(f.out.String(), "\n")
+}
+
+// formatter is the rendering state of a Format run, threaded through the Define
+// calls of the objects being printed.
+type formatter struct {
+	codec  *Codec          // Self-referencing to pass DefineSSZ calls through (API trick)
+	out    strings.Builder // Rendered output accumulated so far
+	frames []formatFrame   // Stack of objects being rendered, innermost last
+	fields []string        // Names of the top level fields, in definition order
+}
+
+// formatFrame is the field name resolution state of a single object.
+type formatFrame struct {
+	names map[uintptr]string // Field addresses mapped to their names
+	field int                // Number of fields rendered so far
+}
+
+// body renders the fields of an object between curly braces, on new lines one
+// level deeper than the current one.
+func (f *formatter) body(obj Object) {
+	frame := formatFrame{names: make(map[uintptr]string)}
+
+	val := reflect.ValueOf(obj)
+	if val.Kind() == reflect.Pointer && val.Elem().Kind() == reflect.Struct {
+		val = val.Elem()
+		for i := 0; i < val.NumField(); i++ {
+			addr := val.Field(i).UnsafeAddr()
+			if _, ok := frame.names[addr]; !ok {
+				frame.names[addr] = val.Type().Field(i).Name
+			}
+		}
+	}
+	f.out.WriteString(" {\n")
+	f.frames = append(f.frames, frame)
+	obj.DefineSSZ(f.codec)
+	f.frames = f.frames[:len(f.frames)-1]
+
+	f.indent()
+	f.out.WriteString("}\n")
+}
+
+// indent writes the leading whitespace of a line at the current depth.
+func (f *formatter) indent() {
+	for i := 0; i < len(f.frames); i++ {
+		f.out.WriteString("  ")
+	}
+}
+
+// name resolves the name of the field being rendered from its address, falling
+// back to its position within the object if the address is unknown.
+func (f *formatter) name(ptr any) string {
+	frame := &f.frames[len(f.frames)-1]
+	defer func() { frame.field++ }()
+
+	name := "#" + strconv.Itoa(frame.field)
+	if val := reflect.ValueOf(ptr); val.Kind() == reflect.Pointer || val.Kind() == reflect.Slice {
+		if known, ok := frame.names[val.Pointer()]; ok {
+			name = known
+		}
+	}
+	if len(f.frames) == 1 {
+		f.fields = append(f.fields, name)
+	}
+	return name
+}
+
+// line renders a single field with an already formatted value.
+func (f *formatter) line(ptr any, value string) {
+	f.indent()
+	f.out.WriteString(f.name(ptr))
+	f.out.WriteString(": ")
+	f.out.WriteString(value)
+	f.out.WriteString("\n")
+}
+
+// note renders a free form comment line.
+func (f *formatter) note(text string) {
+	f.indent()
+	f.out.WriteString("// ")
+	f.out.WriteString(text)
+	f.out.WriteString("\n")
+}
+
+// object renders a field containing a nested ssz object.
+func (f *formatter) object(ptr any, obj Object) {
+	f.indent()
+	f.out.WriteString(f.name(ptr))
+	f.out.WriteString(": ")
+	f.nested(obj)
+}
+
+// nested renders a nested ssz object after its field name or list index.
+func (f *formatter) nested(obj Object) {
+	if val := reflect.ValueOf(obj); obj == nil || (val.Kind() == reflect.Pointer && val.IsNil()) {
+		f.out.WriteString("nil\n")
+		return
+	}
+	f.out.WriteString(formatType(obj))
+	f.body(obj)
+}
+
+// items renders a field containing a list of leaf values, one per line, with
+// the list truncated after a few items.
+func (f *formatter) items(ptr any, count int, limit string, item func(i int) string) {
+	f.indent()
+	f.out.WriteString(f.name(ptr))
+	fmt.Fprintf(&f.out, ": (%d%s items) [", count, limit)
+	if count == 0 {
+		f.out.WriteString("]\n")
+		return
+	}
+	f.out.WriteString("\n")
+	f.frames = append(f.frames, formatFrame{})
+	for i := 0; i < count && i < formatItemsLimit; i++ {
+		f.indent()
+		fmt.Fprintf(&f.out, "%d: %s\n", i, item(i))
+	}
+	if count > formatItemsLimit {
+		f.indent()
+		fmt.Fprintf(&f.out, "... %d more\n", count-formatItemsLimit)
+	}
+	f.frames = f.frames[:len(f.frames)-1]
+
+	f.indent()
+	f.out.WriteString("]\n")
+}
+
+// objects renders a field containing a list of ssz objects, with the list
+// truncated after a few items.
+func (f *formatter) objects(ptr any, count int, limit string, item func(i int) Object) {
+	f.indent()
+	f.out.WriteString(f.name(ptr))
+	fmt.Fprintf(&f.out, ": (%d%s items) [", count, limit)
+	if count == 0 {
+		f.out.WriteString("]\n")
+		return
+	}
+	f.out.WriteString("\n")
+	f.frames = append(f.frames, formatFrame{})
+	for i := 0; i < count && i < formatItemsLimit; i++ {
+		f.indent()
+		fmt.Fprintf(&f.out, "%d: ", i)
+		f.nested(item(i))
+	}
+	if count > formatItemsLimit {
+		f.indent()
+		fmt.Fprintf(&f.out, "... %d more\n", count-formatItemsLimit)
+	}
+	f.frames = f.frames[:len(f.frames)-1]
+
+	f.indent()
+	f.out.WriteString("]\n")
+}
+
+// formatType returns the name of an ssz object's type, without the pointer.
+func formatType(obj Object) string {
+	return strings.TrimPrefix(reflect.TypeOf(obj).String(), "*")
+}
+
+// formatLimit returns the limit annotation of a dynamic field, empty for the
+// unbounded progressive ones.
+func formatLimit(limit uint64) string {
+	if limit == progressiveMaxItems {
+		return ""
+	}
+	return "/" + strconv.FormatUint(limit, 10)
+}
+
+// formatBytes returns the hex form of a binary blob, truncated in the middle
+// if it's too long to be readable.
+func formatBytes(blob []byte) string {
+	if len(blob) <= formatBytesLimit {
+		return "0x" + hex.EncodeToString(blob)
+	}
+	return "0x" + hex.EncodeToString(blob[:16]) + "..." + hex.EncodeToString(blob[len(blob)-4:])
+}
+
+// formatStaticBytes returns the hex form of a static binary blob, annotated with
+// its size if it was truncated.
+func formatStaticBytes(blob []byte) string {
+	if len(blob) <= formatBytesLimit {
+		return formatBytes(blob)
+	}
+	return fmt.Sprintf("%s (%d bytes)", formatBytes(blob), len(blob))
+}
+
+// formatBitlist returns the hex form of a bitlist, annotated with its length in
+// bits and its limit.
+func formatBitlist(bits []byte, limit uint64) string {
+	if len(bits) == 0 || bits[len(bits)-1] == 0 {
+		return fmt.Sprintf("%s (invalid bitlist)", formatBytes(bits))
+	}
+	size := (len(bits)-1)<<3 + bitops.Len8(bits[len(bits)-1]) - 1
+	return fmt.Sprintf("%s (%d%s bits)", formatBytes(bits), size, formatLimit(limit))
+}
+
+// formatUint64s returns the decimal form of a list of uint64s, truncated after
+// a few items.
+func formatUint64s[T ~uint64](ns []T) string {
+	var out strings.Builder
+	out.WriteString("[")
+	for i := 0; i < len(ns) && i < formatItemsLimit; i++ {
+		if i > 0 {
+			out.WriteString(" ")
+		}
+		out.WriteString(strconv.FormatUint(uint64(ns[i]), 10))
+	}
+	if len(ns) > formatItemsLimit {
+		fmt.Fprintf(&out, " ... %d more", len(ns)-formatItemsLimit)
+	}
+	out.WriteString("]")
+	return out.String()
+}
+
+// formatBinaryMarshaler returns the hex form of an opaque type's binary form.
+func formatBinaryMarshaler(v encoding.BinaryMarshaler) string {
+	if val := reflect.ValueOf(v); val.Kind() == reflect.Pointer && val.IsNil() {
+		return "nil"
+	}
+	blob, err := v.MarshalBinary()
+	if err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return formatStaticBytes(blob)
+}
+
+// formatUint256 returns the decimal form of a uint256, nil being zero.
+func formatUint256(n *uint256.Int) string {
+	if n == nil {
+		return "0"
+	}
+	return n.Dec()
+}
+
+// formatBigInt returns the decimal form of a big.Int, nil being zero.
+func formatBigInt(n *big.Int) string {
+	if n == nil {
+		return "0"
+	}
+	return n.String()
+}
@@ -0,0 +1,51 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that objects are pretty printed with their field names, truncated blobs
+// and lists, and dynamic lengths against their limits.
+func TestFormat(t *testing.T) {
+	obj := &types.ExecutionPayload{
+		FeeRecipient:  types.Address{0x01},
+		BlockNumber:   2,
+		ExtraData:     []byte{0x03, 0x04},
+		BaseFeePerGas: uint256.NewInt(5),
+		Transactions:  make([][]byte, 10),
+	}
+	out := ssz.Format(obj)
+	for _, want := range []string{
+		"consensus_spec_tests.ExecutionPayload {\n",
+		"\n  FeeRecipient: 0x0100000000000000000000000000000000000000\n",
+		"\n  LogsBloom: 0x00000000000000000000000000000000...00000000 (256 bytes)\n",
+		"\n  BlockNumber: 2\n",
+		"\n  ExtraData: 0x0304 (2/32 bytes)\n",
+		"\n  BaseFeePerGas: 5\n",
+		"\n  Transactions: (10/1048576 items) [\n",
+		"\n    7: 0x (0/1073741824 bytes)\n    ... 2 more\n  ]\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("formatted output missing %q:\n%s", want, out)
+		}
+	}
+	att := &types.IndexedAttestation{AttestationIndices: []uint64{1, 2, 3}}
+	out = ssz.Format(att)
+	for _, want := range []string{
+		"\n  AttestationIndices: [1 2 3] (3/2048 items)\n",
+		"\n  Data: nil\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("formatted output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -4,6 +4,16 @@
 
 package ssz
 
+import "encoding"
+
+// newableObject is a generic type whose purpose is to enforce that the ssz.Object
+// is specifically implemented on a struct pointer. That is needed to allow to
+// instantiate new structs via `new` when parsing.
+type newableObject[U any] interface {
+	Object
+	*U
+}
+
 // newableStaticObject is a generic type whose purpose is to enforce that the
 // ssz.StaticObject is specifically implemented on a struct pointer. That is
 // needed to allow to instantiate new structs via `new` when parsing.
@@ -20,6 +30,16 @@ type newableDynamicObject[U any] interface {
 	*U
 }
 
+// newableBinaryMarshaler is a generic type whose purpose is to enforce that the
+// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler are implemented on a
+// pointer. That is needed to allow to instantiate new values via `new` when
+// parsing.
+type newableBinaryMarshaler[U any] interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	*U
+}
+
 // commonBytesLengths is a generic type whose purpose is to permit that fixed-
 // sized binary blobs can be passed to different methods. Although a slice of
 // the array would work for simple cases, there are scenarios when a new array
@@ -30,8 +50,8 @@ type newableDynamicObject[U any] interface {
 // generics compiler that it cannot represent arrays of arbitrary sizes with
 // one shorthand notation.
 type commonBytesLengths interface {
-	// fork | address | verkle-stem | hash | pubkey | committee | signature | bloom
-	~[4]byte | ~[20]byte | ~[31]byte | ~[32]byte | ~[48]byte | ~[64]byte | ~[96]byte | ~[256]byte
+	// fork | address | verkle-stem | hash | pubkey | committee | signature | bloom | blob
+	~[4]byte | ~[20]byte | ~[31]byte | ~[32]byte | ~[48]byte | ~[64]byte | ~[96]byte | ~[256]byte | ~[131072]byte
 }
 
 // commonUint64sLengths is a generic type whose purpose is to permit that fixed-
@@ -58,8 +78,8 @@ type commonUint64sLengths interface {
 // generics compiler that it cannot represent arrays of arbitrary sizes with
 // one shorthand notation.
 type commonBitsLengths interface {
-	// justification
-	~[1]byte
+	// justification | committee | sync committee
+	~[1]byte | ~[8]byte | ~[64]byte
 }
 
 // commonBytesArrayLengths is a generic type whose purpose is to permit that
@@ -5,8 +5,10 @@ go 1.21
 require (
 	github.com/golang/snappy v0.0.4
 	github.com/holiman/uint256 v1.3.0
+	github.com/klauspost/compress v1.17.9
 	github.com/prysmaticlabs/go-bitfield v0.0.0-20240618144021-706c95b2dd15
 	github.com/prysmaticlabs/gohashtree v0.0.4-beta
+	golang.org/x/sync v0.7.0
 	golang.org/x/tools v0.22.0
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -14,5 +16,4 @@ require (
 require (
 	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
 	golang.org/x/mod v0.18.0 // indirect
-	golang.org/x/sync v0.7.0 // indirect
 )
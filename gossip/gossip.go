@@ -0,0 +1,138 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package gossip implements the SSZ helpers needed by eth2 gossipsub: snappy
+// block compression of the message payloads and the message-id computation.
+//
+// Pubsub implementations compute the id of a message before handing it to the
+// validators, both of which need the decompressed payload. Message caches the
+// decompressed payload, so the two steps only decompress the data once.
+package gossip
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/karalabe/ssz"
+)
+
+// MaxPayloadSize is the maximum size of a decompressed gossip payload.
+const MaxPayloadSize = 10 * 1024 * 1024
+
+var (
+	// MessageDomainInvalidSnappy is the domain used for the message-id of gossip
+	// messages that fail to decompress.
+	MessageDomainInvalidSnappy = [4]byte{0x00, 0x00, 0x00, 0x00}
+
+	// MessageDomainValidSnappy is the domain used for the message-id of gossip
+	// messages that decompress correctly.
+	MessageDomainValidSnappy = [4]byte{0x01, 0x00, 0x00, 0x00}
+)
+
+// ErrPayloadTooLarge is returned if a gossip payload would decompress into more
+// than MaxPayloadSize bytes.
+var ErrPayloadTooLarge = errors.New("gossip: payload too large")
+
+// MessageID is the 20 byte id of a gossip message.
+type MessageID [20]byte
+
+// Message is a snappy compressed gossip message received on a topic.
+//
+// The payload is decompressed lazily on first use and retained, so computing
+// the message-id and decoding the message can both use it. Message is safe for
+// concurrent use.
+type Message struct {
+	Topic string // Topic the message was received on
+	Data  []byte // Snappy compressed SSZ payload
+
+	once    sync.Once
+	payload []byte // Decompressed payload, nil if decompression failed
+	err     error  // Decompression failure, if any
+}
+
+// NewMessage creates a gossip message out of a topic and compressed payload.
+func NewMessage(topic string, data []byte) *Message {
+	return &Message{Topic: topic, Data: data}
+}
+
+// Payload returns the decompressed SSZ payload of the message.
+func (m *Message) Payload() ([]byte, error) {
+	m.once.Do(func() {
+		m.payload, m.err = decompress(m.Data)
+	})
+	return m.payload, m.err
+}
+
+// ID computes the message-id of the message, as defined since altair:
+//
+//	SHA256(domain + uint_to_bytes(len(topic)) + topic + payload)[:20]
+//
+// If the message decompresses correctly, the domain is MessageDomainValidSnappy
+// and the payload is the decompressed one, otherwise the domain is the invalid
+// one and the payload is the raw data.
+func (m *Message) ID() MessageID {
+	var (
+		domain  = MessageDomainValidSnappy
+		payload []byte
+		err     error
+	)
+	if payload, err = m.Payload(); err != nil {
+		domain, payload = MessageDomainInvalidSnappy, m.Data
+	}
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(m.Topic)))
+
+	hasher := sha256.New()
+	hasher.Write(domain[:])
+	hasher.Write(length[:])
+	hasher.Write([]byte(m.Topic))
+	hasher.Write(payload)
+
+	var id MessageID
+	copy(id[:], hasher.Sum(nil))
+	return id
+}
+
+// Decode decompresses the message payload (if not yet done) and decodes it into
+// the given object.
+func (m *Message) Decode(obj ssz.Object) error {
+	payload, err := m.Payload()
+	if err != nil {
+		return err
+	}
+	return ssz.DecodeFromBytes(payload, obj)
+}
+
+// Encode serializes an object and snappy compresses it into a gossip payload.
+func Encode(obj ssz.Object) ([]byte, error) {
+	size, err := ssz.SizeChecked(obj)
+	if err != nil {
+		return nil, err
+	}
+	if size > MaxPayloadSize {
+		return nil, fmt.Errorf("%w: %d bytes, limit %d", ErrPayloadTooLarge, size, MaxPayloadSize)
+	}
+	blob := make([]byte, size)
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, blob), nil
+}
+
+// decompress snappy decompresses a gossip payload, rejecting it without doing
+// any work if it would exceed the maximum payload size.
+func decompress(data []byte) ([]byte, error) {
+	size, err := snappy.DecodedLen(data)
+	if err != nil {
+		return nil, err
+	}
+	if size > MaxPayloadSize {
+		return nil, fmt.Errorf("%w: %d bytes, limit %d", ErrPayloadTooLarge, size, MaxPayloadSize)
+	}
+	return snappy.Decode(nil, data)
+}
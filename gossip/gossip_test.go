@@ -0,0 +1,91 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package gossip_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/gossip"
+	"github.com/karalabe/ssz/sszcommon"
+)
+
+// testMessageID is a naive reference implementation of the message-id.
+func testMessageID(domain [4]byte, topic string, payload []byte) gossip.MessageID {
+	blob := append([]byte{}, domain[:]...)
+	blob = binary.LittleEndian.AppendUint64(blob, uint64(len(topic)))
+	blob = append(blob, topic...)
+	blob = append(blob, payload...)
+
+	hash := sha256.Sum256(blob)
+	return gossip.MessageID(hash[:20])
+}
+
+// Tests that a gossip message round trips and that its id is computed over the
+// decompressed payload.
+func TestMessage(t *testing.T) {
+	topic := "/eth2/6a95a1a9/voluntary_exit/ssz_snappy"
+	exit := &sszcommon.SignedVoluntaryExit{
+		Message:   &sszcommon.VoluntaryExit{Epoch: 1, ValidatorIndex: 2},
+		Signature: sszcommon.BLSSignature{0x03},
+	}
+	data, err := gossip.Encode(exit)
+	if err != nil {
+		t.Fatalf("failed to encode message: %v", err)
+	}
+	msg := gossip.NewMessage(topic, data)
+
+	payload, err := msg.Payload()
+	if err != nil {
+		t.Fatalf("failed to decompress message: %v", err)
+	}
+	blob := make([]byte, ssz.Size(exit))
+	ssz.EncodeToBytes(blob, exit)
+	if !bytes.Equal(payload, blob) {
+		t.Fatalf("payload mismatch: have %x, want %x", payload, blob)
+	}
+	if have, want := msg.ID(), testMessageID(gossip.MessageDomainValidSnappy, topic, blob); have != want {
+		t.Errorf("message id mismatch: have %x, want %x", have, want)
+	}
+	dec := new(sszcommon.SignedVoluntaryExit)
+	if err := msg.Decode(dec); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	if *dec.Message != *exit.Message || dec.Signature != exit.Signature {
+		t.Errorf("decoded message mismatch: have %+v, want %+v", dec, exit)
+	}
+	// Ensure the payload is only decompressed once
+	if again, _ := msg.Payload(); &again[0] != &payload[0] {
+		t.Errorf("payload decompressed multiple times")
+	}
+}
+
+// Tests that invalid and oversized payloads are rejected, but still get their
+// message-id computed over the raw data.
+func TestMessageInvalid(t *testing.T) {
+	topic := "/eth2/6a95a1a9/beacon_block/ssz_snappy"
+
+	junk := []byte{0xff, 0xff, 0xff}
+	msg := gossip.NewMessage(topic, junk)
+	if err := msg.Decode(new(sszcommon.SignedVoluntaryExit)); err == nil {
+		t.Errorf("junk payload decoded")
+	}
+	if have, want := msg.ID(), testMessageID(gossip.MessageDomainInvalidSnappy, topic, junk); have != want {
+		t.Errorf("junk message id mismatch: have %x, want %x", have, want)
+	}
+	huge := snappy.Encode(nil, make([]byte, gossip.MaxPayloadSize+1))
+	msg = gossip.NewMessage(topic, huge)
+	if _, err := msg.Payload(); !errors.Is(err, gossip.ErrPayloadTooLarge) {
+		t.Errorf("huge payload error mismatch: have %v, want %v", err, gossip.ErrPayloadTooLarge)
+	}
+	if have, want := msg.ID(), testMessageID(gossip.MessageDomainInvalidSnappy, topic, huge); have != want {
+		t.Errorf("huge message id mismatch: have %x, want %x", have, want)
+	}
+}
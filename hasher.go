@@ -6,15 +6,14 @@ package ssz
 
 import (
 	"crypto/sha256"
+	"encoding"
 	"encoding/binary"
+	"fmt"
 	"math/big"
 	bitops "math/bits"
 	"runtime"
-	"unsafe"
 
 	"github.com/holiman/uint256"
-	"github.com/prysmaticlabs/go-bitfield"
-	"github.com/prysmaticlabs/gohashtree"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -44,6 +43,17 @@ func init() {
 	}
 }
 
+// hashChunksPortable hashes the chunks two at a time, writing the digests into
+// the first argument (which may alias the chunks), using the standard library.
+func hashChunksPortable(digests [][32]byte, chunks [][32]byte) {
+	var buf [64]byte
+	for i := 0; i < len(chunks)/2; i++ {
+		copy(buf[:32], chunks[2*i][:])
+		copy(buf[32:], chunks[2*i+1][:])
+		digests[i] = sha256.Sum256(buf[:])
+	}
+}
+
 // Hasher is an SSZ Merkle Hash Root computer.
 type Hasher struct {
 	threads bool // Whether threaded hashing is allowed or not
@@ -52,8 +62,17 @@ type Hasher struct {
 	groups []groupStats // Hashing progress tracking for the chunk groups
 	layer  int          // Layer depth being hasher now
 
+	subtrees [][32]byte // Stashed subtree roots of progressive lists being hashed
+
+	holds    []int           // Layers not to collapse until ascending out of them
+	reserved []reservedChunk // Placeholder chunks waiting for out of order roots
+
+	tree *treeRecorder // Merkle tree being assembled instead of hashed (tree mode)
+
 	codec  *Codec // Self-referencing to pass DefineSSZ calls through (API trick)
 	bitbuf []byte // Bitlist conversion buffer
+
+	err error // First value that could not be merkleized (hashed as zero)
 }
 
 // groupStats is a metadata structure tracking the stats of a same-level group
@@ -64,6 +83,14 @@ type groupStats struct {
 	chunks int // Number of chunks in this group
 }
 
+// reservedChunk is a placeholder in the chunk list for a value whose root is only
+// computed later on (i.e. a dynamic field when encoding and hashing in one pass,
+// since its content is visited after all the static fields).
+type reservedChunk struct {
+	layer int // Layer the placeholder was inserted into
+	index int // Position of the placeholder in the chunk list
+}
+
 // HashBool hashes a boolean.
 func HashBool[T ~bool](h *Hasher, v T) {
 	if !v {
@@ -112,11 +139,27 @@ func HashUint256(h *Hasher, n *uint256.Int) {
 	h.insertChunk(buffer, 0)
 }
 
+// HashUint256Checked hashes a uint256. The value is not validated, that is only
+// done when decoding.
+//
+// Note, a nil pointer is hashed as zero.
+func HashUint256Checked(h *Hasher, n *uint256.Int) {
+	HashUint256(h, n)
+}
+
 // HashUint256BigInt hashes a big.Int as uint256.
 //
 // Note, a nil pointer is hashed as zero.
+// Note, negative or overflowing values are hashed as zero too, and reported as
+// an ErrUint256Overflow by the checked hashing methods (e.g. HashSequentialChecked).
 func HashUint256BigInt(h *Hasher, n *big.Int) {
 	var buffer [32]byte
+	if n != nil && (n.Sign() < 0 || n.BitLen() > 256) {
+		if h.err == nil {
+			h.err = fmt.Errorf("%w: %v", ErrUint256Overflow, n)
+		}
+		n = nil // hash as zero to keep the tree layout consistent
+	}
 	if n != nil {
 		var bufint uint256.Int // No pointer, alloc free
 		bufint.SetFromBig(n)
@@ -125,14 +168,21 @@ func HashUint256BigInt(h *Hasher, n *big.Int) {
 	h.insertChunk(buffer, 0)
 }
 
+// HashUint256Bytes hashes a big-endian 32 byte array as uint256.
+func HashUint256Bytes(h *Hasher, n *[32]byte) {
+	var buffer [32]byte
+	for i := 0; i < 32; i++ {
+		buffer[i] = n[31-i]
+	}
+	h.insertChunk(buffer, 0)
+}
+
 // HashStaticBytes hashes a static binary blob.
 //
 // The blob is passed by pointer to avoid high stack copy costs and a potential
 // escape to the heap.
 func HashStaticBytes[T commonBytesLengths](h *Hasher, blob *T) {
-	// The code below should have used `blob[:]`, alas Go's generics compiler
-	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	h.hashBytes(unsafe.Slice(&(*blob)[0], len(*blob)))
+	h.hashBytes(arrayBytes(blob))
 }
 
 // HashCheckedStaticBytes hashes a static binary blob.
@@ -144,7 +194,14 @@ func HashCheckedStaticBytes(h *Hasher, blob []byte) {
 func HashDynamicBytes(h *Hasher, blob []byte, maxSize uint64) {
 	h.descendMixinLayer()
 	h.insertBlobChunks(blob)
-	h.ascendMixinLayer(uint64(len(blob)), (maxSize+31)/32)
+	h.ascendMixinLayer(uint64(len(blob)), ChunkCount(maxSize))
+}
+
+// HashDynamicString hashes a dynamic binary blob held in a string.
+func HashDynamicString[T ~string](h *Hasher, str T, maxSize uint64) {
+	h.descendMixinLayer()
+	h.insertBlobChunks(stringBytes(string(str)))
+	h.ascendMixinLayer(uint64(len(str)), ChunkCount(maxSize))
 }
 
 // HashStaticObject hashes a static ssz object.
@@ -154,6 +211,34 @@ func HashStaticObject(h *Hasher, obj StaticObject) {
 	h.ascendLayer(0)
 }
 
+// HashStaticObjectPointer hashes an optional static ssz object, hashing the zero
+// object if nil.
+func HashStaticObjectPointer[T newableStaticObject[U], U any](h *Hasher, obj T) {
+	if obj == nil {
+		obj = T(new(U))
+	}
+	HashStaticObject(h, obj)
+}
+
+// HashStaticBinaryMarshaler hashes an opaque binary marshaler as a static binary
+// blob.
+//
+// Note, a marshaling error or size mismatch is recorded for the checked hashers
+// and the blob is hashed as zero.
+func HashStaticBinaryMarshaler(h *Hasher, v encoding.BinaryMarshaler, size uint64) {
+	blob, err := v.MarshalBinary()
+	if err == nil && uint64(len(blob)) != size {
+		err = fmt.Errorf("%w: %T marshaled %d bytes, want %d", ErrMarshaledSizeMismatch, v, len(blob), size)
+	}
+	if err != nil {
+		if h.err == nil {
+			h.err = err
+		}
+		blob = make([]byte, size) // hash as zero to keep the tree layout consistent
+	}
+	h.hashBytes(blob)
+}
+
 // HashDynamicObject hashes a dynamic ssz object.
 func HashDynamicObject(h *Hasher, obj DynamicObject) {
 	h.descendLayer()
@@ -163,13 +248,16 @@ func HashDynamicObject(h *Hasher, obj DynamicObject) {
 
 // HashArrayOfBits hashes a static array of (packed) bits.
 func HashArrayOfBits[T commonBitsLengths](h *Hasher, bits *T) {
-	// The code below should have used `*bits[:]`, alas Go's generics compiler
-	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	h.hashBytes(unsafe.Slice(&(*bits)[0], len(*bits)))
+	h.hashBytes(arrayBytes(bits))
+}
+
+// HashCheckedArrayOfBits hashes a static array of (packed) bits.
+func HashCheckedArrayOfBits[T ~[]byte](h *Hasher, bits T) {
+	h.hashBytes(bits)
 }
 
 // HashSliceOfBits hashes a dynamic slice of (packed) bits.
-func HashSliceOfBits(h *Hasher, bits bitfield.Bitlist, maxBits uint64) {
+func HashSliceOfBits[T ~[]byte](h *Hasher, bits T, maxBits uint64) {
 	// Parse the bit-list into a hashable representation
 	var (
 		msb  = uint8(bitops.Len8(bits[len(bits)-1])) - 1
@@ -203,29 +291,7 @@ func HashSliceOfBits(h *Hasher, bits bitfield.Bitlist, maxBits uint64) {
 // escaping to the heap (and incurring an allocation) when passing it to the
 // hasher.
 func HashArrayOfUint64s[T commonUint64sLengths](h *Hasher, ns *T) {
-	// The code below should have used `*blob[:]`, alas Go's generics compiler
-	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	nums := unsafe.Slice(&(*ns)[0], len(*ns))
-	h.descendLayer()
-
-	var buffer [32]byte
-	for len(nums) > 4 {
-		binary.LittleEndian.PutUint64(buffer[:], nums[0])
-		binary.LittleEndian.PutUint64(buffer[8:], nums[1])
-		binary.LittleEndian.PutUint64(buffer[16:], nums[2])
-		binary.LittleEndian.PutUint64(buffer[24:], nums[3])
-
-		h.insertChunk(buffer, 0)
-		nums = nums[4:]
-	}
-	if len(nums) > 0 {
-		buffer = [32]byte{}
-		for i := 0; i < len(nums); i++ {
-			binary.LittleEndian.PutUint64(buffer[i<<3:], nums[i])
-		}
-		h.insertChunk(buffer, 0)
-	}
-	h.ascendLayer(0)
+	h.hashUint64s(arrayUint64s(ns))
 }
 
 // HashSliceOfUint64s hashes a dynamic slice of uint64s.
@@ -259,18 +325,14 @@ func HashSliceOfUint64s[T ~uint64](h *Hasher, ns []T, maxItems uint64) {
 // from escaping to the heap (and incurring an allocation) when passing it to
 // the output stream.
 func HashArrayOfStaticBytes[T commonBytesArrayLengths[U], U commonBytesLengths](h *Hasher, blobs *T) {
-	// The code below should have used `(*blobs)[:]`, alas Go's generics compiler
-	// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-	HashUnsafeArrayOfStaticBytes(h, unsafe.Slice(&(*blobs)[0], len(*blobs)))
+	HashUnsafeArrayOfStaticBytes(h, arrayItems[T, U](blobs))
 }
 
 // HashUnsafeArrayOfStaticBytes hashes a static array of static binary blobs.
 func HashUnsafeArrayOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T) {
 	h.descendLayer()
 	for i := 0; i < len(blobs); i++ {
-		// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		h.hashBytes(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+		h.hashBytes(arrayBytes(&blobs[i]))
 	}
 	h.ascendLayer(0)
 }
@@ -279,9 +341,7 @@ func HashUnsafeArrayOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T) {
 func HashCheckedArrayOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T) {
 	h.descendLayer()
 	for i := 0; i < len(blobs); i++ {
-		// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		h.hashBytes(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+		h.hashBytes(arrayBytes(&blobs[i]))
 	}
 	h.ascendLayer(0)
 }
@@ -290,9 +350,16 @@ func HashCheckedArrayOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T) {
 func HashSliceOfStaticBytes[T commonBytesLengths](h *Hasher, blobs []T, maxItems uint64) {
 	h.descendMixinLayer()
 	for i := 0; i < len(blobs); i++ {
-		// The code below should have used `blobs[i][:]`, alas Go's generics compiler
-		// is missing that (i.e. a bug): https://github.com/golang/go/issues/51740
-		h.hashBytes(unsafe.Slice(&blobs[i][0], len(blobs[i])))
+		h.hashBytes(arrayBytes(&blobs[i]))
+	}
+	h.ascendMixinLayer(uint64(len(blobs)), maxItems)
+}
+
+// HashCheckedSliceOfStaticBytes hashes a dynamic slice of static binary blobs.
+func HashCheckedSliceOfStaticBytes(h *Hasher, blobs [][]byte, maxItems uint64) {
+	h.descendMixinLayer()
+	for _, blob := range blobs {
+		h.hashBytes(blob)
 	}
 	h.ascendMixinLayer(uint64(len(blobs)), maxItems)
 }
@@ -303,7 +370,7 @@ func HashSliceOfDynamicBytes(h *Hasher, blobs [][]byte, maxItems uint64, maxSize
 	for _, blob := range blobs {
 		h.descendMixinLayer()
 		h.insertBlobChunks(blob)
-		h.ascendMixinLayer(uint64(len(blob)), (maxSize+31)/32)
+		h.ascendMixinLayer(uint64(len(blob)), ChunkCount(maxSize))
 	}
 	h.ascendMixinLayer(uint64(len(blobs)), maxItems)
 }
@@ -314,7 +381,7 @@ func HashSliceOfStaticObjects[T StaticObject](h *Hasher, objects []T, maxItems u
 	defer h.ascendMixinLayer(uint64(len(objects)), maxItems)
 
 	// If threading is disabled, or hashing nothing, do it sequentially
-	if !h.threads || len(objects) == 0 || len(objects)*int(Size(objects[0])) < concurrencyThreshold {
+	if !h.threads || len(objects) == 0 || uint64(len(objects))*uint64(Size(objects[0])) < concurrencyThreshold {
 		for _, obj := range objects {
 			h.descendLayer()
 			obj.DefineSSZ(h.codec)
@@ -346,6 +413,7 @@ func HashSliceOfStaticObjects[T StaticObject](h *Hasher, objects []T, maxItems u
 			codec := hasherPool.Get().(*Codec)
 			defer hasherPool.Put(codec)
 			defer codec.has.Reset()
+			codec.fork = h.codec.fork
 			codec.has.threads = true
 
 			for i := worker * subtask; i < (worker+1)*subtask && i < len(objects); i++ {
@@ -357,11 +425,13 @@ func HashSliceOfStaticObjects[T StaticObject](h *Hasher, objects []T, maxItems u
 
 			resultChunks[worker] = codec.has.chunks[0]
 			resultDepths[worker] = codec.has.groups[0].depth
-			return nil
+			return codec.has.err
 		})
 	}
 	// Wait for all the hashers to finish and aggregate the results
-	workers.Wait()
+	if err := workers.Wait(); err != nil && h.err == nil {
+		h.err = err
+	}
 	for i := 0; i < len(resultChunks); i++ {
 		h.insertChunk(resultChunks[i], resultDepths[i])
 	}
@@ -378,6 +448,31 @@ func HashSliceOfDynamicObjects[T DynamicObject](h *Hasher, objects []T, maxItems
 	h.ascendMixinLayer(uint64(len(objects)), maxItems)
 }
 
+// hashUint64s hashes a static array of uint64s, already converted into a plain
+// slice view.
+func (h *Hasher) hashUint64s(nums []uint64) {
+	h.descendLayer()
+
+	var buffer [32]byte
+	for len(nums) > 4 {
+		binary.LittleEndian.PutUint64(buffer[:], nums[0])
+		binary.LittleEndian.PutUint64(buffer[8:], nums[1])
+		binary.LittleEndian.PutUint64(buffer[16:], nums[2])
+		binary.LittleEndian.PutUint64(buffer[24:], nums[3])
+
+		h.insertChunk(buffer, 0)
+		nums = nums[4:]
+	}
+	if len(nums) > 0 {
+		buffer = [32]byte{}
+		for i := 0; i < len(nums); i++ {
+			binary.LittleEndian.PutUint64(buffer[i<<3:], nums[i])
+		}
+		h.insertChunk(buffer, 0)
+	}
+	h.ascendLayer(0)
+}
+
 // hashBytes either appends the blob to the hasher's scratch space if it's small
 // enough to fit into a single chunk, or chunks it up and merkleizes it first.
 func (h *Hasher) hashBytes(blob []byte) {
@@ -394,8 +489,36 @@ func (h *Hasher) hashBytes(blob []byte) {
 	h.ascendLayer(0)
 }
 
+// hashZeroBytes is the equivalent of hashBytes for a blob of size zero bytes,
+// without allocating it.
+func (h *Hasher) hashZeroBytes(size uint64) {
+	if size <= 32 {
+		h.insertChunk([32]byte{}, 0)
+		return
+	}
+	h.descendLayer()
+	for i := uint64(0); i < (size+31)/32; i++ {
+		h.insertChunk([32]byte{}, 0)
+	}
+	h.ascendLayer(0)
+}
+
+// hashZeroArrayOfBytes is the equivalent of HashCheckedArrayOfStaticBytes for an
+// array of items, each being a blob of size zero bytes, without allocating them.
+func (h *Hasher) hashZeroArrayOfBytes(items uint64, size uint64) {
+	h.descendLayer()
+	for i := uint64(0); i < items; i++ {
+		h.hashZeroBytes(size)
+	}
+	h.ascendLayer(0)
+}
+
 // insertChunk adds a chunk to the accumulators, collapsing matching pairs.
 func (h *Hasher) insertChunk(chunk [32]byte, depth int) {
+	if h.tree != nil {
+		h.tree.insert(NewTreeLeaf(chunk))
+		return
+	}
 	// Insert the chunk into the accumulator
 	h.chunks = append(h.chunks, chunk)
 
@@ -413,9 +536,10 @@ func (h *Hasher) insertChunk(chunk [32]byte, depth int) {
 		})
 		return
 	}
-	// Leaf counter incremented, if not yet enough for a hashing round, return
+	// Leaf counter incremented, if not yet enough for a hashing round (or the
+	// layer is held back from collapsing), return
 	group := h.groups[groups-1]
-	if group.chunks != hasherBatch {
+	if group.chunks != hasherBatch || h.held() {
 		return
 	}
 	for {
@@ -423,7 +547,7 @@ func (h *Hasher) insertChunk(chunk [32]byte, depth int) {
 		// them one by one, so can't all of a sudden overshoot. Hash the next batch
 		// of chunks and update the trackers.
 		chunks := len(h.chunks)
-		gohashtree.HashChunks(h.chunks[chunks-hasherBatch:], h.chunks[chunks-hasherBatch:])
+		hashChunks(h.chunks[chunks-hasherBatch:], h.chunks[chunks-hasherBatch:])
 		h.chunks = h.chunks[:chunks-hasherBatch/2]
 
 		group.depth++
@@ -470,12 +594,19 @@ func (h *Hasher) insertBlobChunks(blob []byte) {
 // descendLayer starts a new hashing layer, acting as a barrier to prevent the
 // chunks from being collapsed into previous pending ones.
 func (h *Hasher) descendLayer() {
+	if h.tree != nil {
+		h.tree.descend()
+	}
 	h.layer++
 }
 
 // descendMixinLayer is similar to descendLayer, but actually descends two at the
 // same time, using the outer for mixing in a list length during ascent.
 func (h *Hasher) descendMixinLayer() {
+	if h.tree != nil {
+		h.tree.descend()
+		h.tree.descend()
+	}
 	h.layer += 2
 }
 
@@ -483,6 +614,11 @@ func (h *Hasher) descendMixinLayer() {
 // collapsing anything unblocked. The capacity param controls how many chunks
 // a dynamic list is expected to be composed of at maximum (0 == only balance).
 func (h *Hasher) ascendLayer(capacity uint64) {
+	if h.tree != nil {
+		h.tree.ascend(capacity)
+		h.layer--
+		return
+	}
 	// Before even considering extending the layer to capacity, balance any
 	// partial sub-tries to their completion.
 	h.balanceLayer()
@@ -502,12 +638,15 @@ func (h *Hasher) ascendLayer(capacity uint64) {
 		h.chunks = append(h.chunks, hasherZeroCache[group.depth])
 
 		chunks := len(h.chunks)
-		gohashtree.HashChunks(h.chunks[chunks-2:], h.chunks[chunks-2:])
+		hashChunks(h.chunks[chunks-2:], h.chunks[chunks-2:])
 		h.chunks = h.chunks[:chunks-1]
 
 		h.groups[groups-1].depth++
 	}
 	// Ascend from the previous hashing layer
+	if h.held() {
+		h.holds = h.holds[:len(h.holds)-1]
+	}
 	h.layer--
 
 	chunks := len(h.chunks)
@@ -520,6 +659,47 @@ func (h *Hasher) ascendLayer(capacity uint64) {
 	h.insertChunk(root, 0)
 }
 
+// holdLayer prevents the chunks of the current layer from being collapsed until
+// it is ascended out of, keeping their positions stable to be patched up later.
+func (h *Hasher) holdLayer() {
+	if !h.held() {
+		h.holds = append(h.holds, h.layer)
+	}
+}
+
+// held returns whether the chunks of the current layer are held back from being
+// collapsed.
+func (h *Hasher) held() bool {
+	holds := len(h.holds)
+	return holds > 0 && h.holds[holds-1] == h.layer
+}
+
+// reserveChunk inserts a placeholder chunk into the current layer, to be replaced
+// via fillChunk once the root of the value it stands for is known.
+func (h *Hasher) reserveChunk() {
+	h.holdLayer()
+	h.reserved = append(h.reserved, reservedChunk{layer: h.layer, index: len(h.chunks)})
+	h.insertChunk([32]byte{}, 0)
+}
+
+// fillChunk removes the last chunk of the current layer and stores it into the
+// earliest placeholder still reserved in the layer.
+func (h *Hasher) fillChunk() {
+	// Placeholders of deeper layers are all filled before ascending out of them,
+	// so the ones reserved in the current layer are always the trailing ones.
+	i := len(h.reserved)
+	for i > 0 && h.reserved[i-1].layer == h.layer {
+		i--
+	}
+	slot := h.reserved[i]
+	h.reserved = append(h.reserved[:i], h.reserved[i+1:]...)
+
+	chunks := len(h.chunks)
+	h.chunks[slot.index] = h.chunks[chunks-1]
+	h.chunks = h.chunks[:chunks-1]
+	h.groups[len(h.groups)-1].chunks--
+}
+
 // balanceLayer can be used to take a partial hashing result of an unbalanced
 // trie and append enough empty chunks (virtually) at the end to collapse it
 // down to a single root.
@@ -546,7 +726,7 @@ func (h *Hasher) balanceLayer() {
 			group.chunks++
 		}
 		chunks := len(h.chunks)
-		gohashtree.HashChunks(h.chunks[chunks-int(group.chunks):], h.chunks[chunks-int(group.chunks):])
+		hashChunks(h.chunks[chunks-int(group.chunks):], h.chunks[chunks-int(group.chunks):])
 		h.chunks = h.chunks[:chunks-int(group.chunks)>>1]
 
 		group.depth++
@@ -591,5 +771,11 @@ func (h *Hasher) ascendMixinLayer(size uint64, chunks uint64) {
 func (h *Hasher) Reset() {
 	h.chunks = h.chunks[:0]
 	h.groups = h.groups[:0]
+	h.subtrees = h.subtrees[:0]
+	h.holds = h.holds[:0]
+	h.reserved = h.reserved[:0]
+	h.layer = 0
 	h.threads = false
+	h.tree = nil
+	h.err = nil
 }
@@ -0,0 +1,20 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build !(amd64 || arm64) || purego || tinygo
+
+package ssz
+
+// hashChunks hashes the chunks two at a time, writing the digests into the first
+// argument (which may alias the chunks). It uses the standard library's hashing
+// for platforms without SIMD assembly (e.g. wasm) and for the purego build.
+func hashChunks(digests [][32]byte, chunks [][32]byte) {
+	hashChunksPortable(digests, chunks)
+}
+
+// hashChunksAccelerated would be the SIMD accelerated hashing, but there is none
+// on this platform, so it is the standard library's one.
+func hashChunksAccelerated(digests [][32]byte, chunks [][32]byte) {
+	hashChunksPortable(digests, chunks)
+}
@@ -0,0 +1,26 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build (amd64 || arm64) && !purego && !tinygo
+
+package ssz
+
+import "github.com/prysmaticlabs/gohashtree"
+
+// hashChunks hashes the chunks two at a time, writing the digests into the first
+// argument (which may alias the chunks). It uses SIMD accelerated hashing, unless
+// the platform self-check disabled it.
+func hashChunks(digests [][32]byte, chunks [][32]byte) {
+	if !fastHashing {
+		hashChunksPortable(digests, chunks)
+		return
+	}
+	gohashtree.HashChunks(digests, chunks)
+}
+
+// hashChunksAccelerated is the SIMD accelerated hashing, regardless of whether
+// the platform self-check disabled it or not.
+func hashChunksAccelerated(digests [][32]byte, chunks [][32]byte) {
+	gohashtree.HashChunks(digests, chunks)
+}
@@ -0,0 +1,42 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// EncodeToHex serializes the object into a 0x prefixed hex string, as commonly
+// used by JSON-RPC APIs and debugging tools.
+func EncodeToHex(obj Object) (string, error) {
+	size, err := SizeChecked(obj)
+	if err != nil {
+		return "", err
+	}
+	blob := make([]byte, size)
+	if err := EncodeToBytes(blob, obj); err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(blob), nil
+}
+
+// DecodeFromHex parses a hex string into the object. The 0x prefix is optional.
+func DecodeFromHex(s string, obj Object) error {
+	return DecodeFromHexWithConfig(s, obj, nil)
+}
+
+// DecodeFromHexWithConfig is analogous to DecodeFromHex, but allows the caller
+// to customize the decoding behavior via a config.
+func DecodeFromHexWithConfig(s string, obj Object, cfg *DecoderConfig) error {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	blob, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return DecodeFromBytesWithConfig(blob, obj, cfg)
+}
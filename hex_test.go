@@ -0,0 +1,47 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that objects round trip through hex strings, with or without prefix.
+func TestHexHelpers(t *testing.T) {
+	obj := &types.Withdrawal{Index: 1, Validator: 2, Address: types.Address{0x03}, Amount: 4}
+
+	str, err := ssz.EncodeToHex(obj)
+	if err != nil {
+		t.Fatalf("failed to encode to hex: %v", err)
+	}
+	blob := make([]byte, ssz.Size(obj))
+	ssz.EncodeToBytes(blob, obj)
+	if want := "0x" + hex.EncodeToString(blob); str != want {
+		t.Fatalf("hex encoding mismatch: have %s, want %s", str, want)
+	}
+	for _, input := range []string{str, str[2:], "0X" + strings.ToUpper(str[2:])} {
+		dec := new(types.Withdrawal)
+		if err := ssz.DecodeFromHex(input, dec); err != nil {
+			t.Errorf("failed to decode %s: %v", input, err)
+			continue
+		}
+		if *dec != *obj {
+			t.Errorf("decoded object mismatch: have %+v, want %+v", dec, obj)
+		}
+	}
+	if err := ssz.DecodeFromHex(str[:len(str)-1], new(types.Withdrawal)); err == nil {
+		t.Errorf("odd length hex decoded")
+	}
+	if err := ssz.DecodeFromHex(str[:len(str)-2], new(types.Withdrawal)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("short hex error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
@@ -0,0 +1,302 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ContentType is the media type of SSZ encoded HTTP bodies in the beacon API.
+	ContentType = "application/octet-stream"
+
+	// ContentTypeJSON is the media type of JSON encoded HTTP bodies, which the
+	// negotiating helpers fall back to for clients not accepting SSZ.
+	ContentTypeJSON = "application/json"
+
+	// ConsensusVersionHeader is the HTTP header carrying the fork name that the
+	// SSZ encoded body of a beacon API request or response belongs to.
+	ConsensusVersionHeader = "Eth-Consensus-Version"
+)
+
+// HTTPConfig can be used to customize the reading of SSZ encoded HTTP bodies. The
+// config is only read, so it may be shared by concurrently served requests.
+type HTTPConfig struct {
+	// MaxSize is an optional limit on the size of the body. Bodies declaring a
+	// larger Content-Length are rejected without being read, bodies of unknown
+	// length are cut off at the limit. If zero, the size is only bounded by
+	// what the 4 byte SSZ offsets can address.
+	MaxSize uint32
+
+	// Decoder is an optional config to customize the decoding of the body.
+	Decoder *DecoderConfig
+}
+
+// MaxSizer is an optional interface for dynamic objects to declare the maximum
+// size of their encoding (i.e. with all their lists filled to their limits).
+// The HTTP and compress helpers use it to reject oversized bodies and inputs
+// without reading them.
+type MaxSizer interface {
+	MaxSizeSSZ() uint32
+}
+
+// WriteResponse sets the SSZ content headers of an HTTP response (along with
+// the consensus version, if non-empty) and streams the object into its body.
+//
+// Note, since the headers are sent before the body, a failure while encoding
+// (i.e. a write error) can only be signalled by cutting the response short.
+func WriteResponse(w http.ResponseWriter, obj Object, version string) error {
+	size, err := SizeChecked(obj)
+	if err != nil {
+		return err
+	}
+	header := w.Header()
+	header.Set("Content-Type", ContentType)
+	header.Set("Content-Length", strconv.FormatUint(uint64(size), 10))
+	if version != "" {
+		header.Set(ConsensusVersionHeader, version)
+	}
+	return EncodeToStream(w, obj)
+}
+
+// NewRequest creates an HTTP request with the object SSZ encoded into its body
+// and the SSZ content headers set (along with the consensus version, if non-
+// empty).
+func NewRequest(ctx context.Context, method string, url string, obj Object, version string) (*http.Request, error) {
+	size, err := SizeChecked(obj)
+	if err != nil {
+		return nil, err
+	}
+	blob := make([]byte, size)
+	if err := EncodeToBytes(blob, obj); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(blob))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", ContentType)
+	if version != "" {
+		req.Header.Set(ConsensusVersionHeader, version)
+	}
+	return req, nil
+}
+
+// ReadRequest decodes the SSZ encoded body of an HTTP request into an object,
+// returning the consensus version declared by the client (empty if none).
+func ReadRequest(r *http.Request, obj Object) (string, error) {
+	return ReadRequestWithConfig(r, obj, nil)
+}
+
+// ReadRequestWithConfig is analogous to ReadRequest, but allows the caller to
+// limit the body size and customize the decoding behavior via a config.
+func ReadRequestWithConfig(r *http.Request, obj Object, cfg *HTTPConfig) (string, error) {
+	return readBody(r.Header, r.ContentLength, r.Body, obj, cfg)
+}
+
+// ReadResponse decodes the SSZ encoded body of an HTTP response into an object,
+// returning the consensus version declared by the server (empty if none).
+//
+// The caller remains responsible for checking the status code and for closing
+// the response body.
+func ReadResponse(res *http.Response, obj Object) (string, error) {
+	return ReadResponseWithConfig(res, obj, nil)
+}
+
+// ReadResponseWithConfig is analogous to ReadResponse, but allows the caller to
+// limit the body size and customize the decoding behavior via a config.
+func ReadResponseWithConfig(res *http.Response, obj Object, cfg *HTTPConfig) (string, error) {
+	return readBody(res.Header, res.ContentLength, res.Body, obj, cfg)
+}
+
+// readBody validates the content headers of an HTTP body and decodes it into an
+// object, streaming it if its length is known, or buffering it (up to the size
+// limit) otherwise.
+func readBody(header http.Header, length int64, body io.Reader, obj Object, cfg *HTTPConfig) (string, error) {
+	if media, _, err := mime.ParseMediaType(header.Get("Content-Type")); err != nil || media != ContentType {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedContentType, header.Get("Content-Type"))
+	}
+	var (
+		limit = bodyLimit(obj, cfg)
+		dcfg  *DecoderConfig
+	)
+	if cfg != nil {
+		dcfg = cfg.Decoder
+	}
+	if length > limit {
+		return "", fmt.Errorf("%w: %d bytes, limit %d", ErrBodyTooLarge, length, limit)
+	}
+	if length >= 0 {
+		if err := DecodeFromStreamWithConfig(body, obj, uint32(length), dcfg); err != nil {
+			return "", err
+		}
+	} else {
+		blob, err := io.ReadAll(io.LimitReader(body, limit+1))
+		if err != nil {
+			return "", err
+		}
+		if int64(len(blob)) > limit {
+			return "", fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, limit)
+		}
+		if err := DecodeFromBytesWithConfig(blob, obj, dcfg); err != nil {
+			return "", err
+		}
+	}
+	return header.Get(ConsensusVersionHeader), nil
+}
+
+// bodyLimit returns the maximum size of an SSZ encoded body to decode into the
+// object: the configured limit, tightened by the size of static objects and the
+// declared maximum size of dynamic ones (see MaxSizer).
+func bodyLimit(obj Object, cfg *HTTPConfig) int64 {
+	limit := int64(math.MaxUint32)
+	if cfg != nil && cfg.MaxSize != 0 {
+		limit = int64(cfg.MaxSize)
+	}
+	switch v := obj.(type) {
+	case StaticObject:
+		limit = min(limit, int64(v.SizeSSZ()))
+	case MaxSizer:
+		limit = min(limit, int64(v.MaxSizeSSZ()))
+	}
+	return limit
+}
+
+// PrefersSSZ reports whether the Accept header of an HTTP request prefers SSZ
+// encoded bodies over JSON ones, taking quality values and wildcards into
+// account. Ties and requests without an Accept header resolve to JSON, which
+// is the beacon API's default.
+func PrefersSSZ(header http.Header) bool {
+	sszQ, jsonQ, wildQ := -1.0, -1.0, -1.0
+	for _, value := range header.Values("Accept") {
+		for _, part := range strings.Split(value, ",") {
+			media, params, err := mime.ParseMediaType(part)
+			if err != nil {
+				continue
+			}
+			q := 1.0
+			if param, ok := params["q"]; ok {
+				if q, err = strconv.ParseFloat(param, 64); err != nil {
+					continue
+				}
+			}
+			switch media {
+			case ContentType:
+				sszQ = max(sszQ, q)
+			case ContentTypeJSON:
+				jsonQ = max(jsonQ, q)
+			case "*/*", "application/*":
+				wildQ = max(wildQ, q)
+			}
+		}
+	}
+	// Explicitly listed media types take precedence over wildcards
+	if sszQ < 0 {
+		sszQ = wildQ
+	}
+	if jsonQ < 0 {
+		jsonQ = wildQ
+	}
+	return sszQ > 0 && sszQ > jsonQ
+}
+
+// WriteNegotiatedResponse is analogous to WriteResponse, but encodes the object
+// as SSZ or as JSON, whichever the client prefers (see PrefersSSZ). The JSON
+// form is the object's encoding/json marshalling, types needing the beacon API
+// conventions (e.g. quoted integers) should implement json.Marshaler.
+func WriteNegotiatedResponse(w http.ResponseWriter, r *http.Request, obj Object, version string) error {
+	header := w.Header()
+	header.Add("Vary", "Accept")
+
+	if PrefersSSZ(r.Header) {
+		return WriteResponse(w, obj, version)
+	}
+	header.Set("Content-Type", ContentTypeJSON)
+	if version != "" {
+		header.Set(ConsensusVersionHeader, version)
+	}
+	return json.NewEncoder(w).Encode(obj)
+}
+
+// ReadNegotiatedRequest is analogous to ReadRequestWithConfig, but also accepts
+// JSON encoded bodies, as declared by the request's Content-Type. SSZ bodies are
+// limited as in ReadRequestWithConfig, JSON ones only by the configured limit.
+func ReadNegotiatedRequest(r *http.Request, obj Object, cfg *HTTPConfig) (string, error) {
+	if media, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || media != ContentTypeJSON {
+		return readBody(r.Header, r.ContentLength, r.Body, obj, cfg)
+	}
+	limit := int64(math.MaxUint32)
+	if cfg != nil && cfg.MaxSize != 0 {
+		limit = int64(cfg.MaxSize)
+	}
+	if r.ContentLength > limit {
+		return "", fmt.Errorf("%w: %d bytes, limit %d", ErrBodyTooLarge, r.ContentLength, limit)
+	}
+	blob, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(blob)) > limit {
+		return "", fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, limit)
+	}
+	if err := json.Unmarshal(blob, obj); err != nil {
+		return "", err
+	}
+	return r.Header.Get(ConsensusVersionHeader), nil
+}
+
+// NewHTTPHandler creates an HTTP handler around a typed request handler. The
+// request body is decoded into a new object of type T (SSZ or JSON, see
+// ReadNegotiatedRequest), and the returned object is written in the format
+// preferred by the client (see WriteNegotiatedResponse), tagged with the
+// consensus version of the request. A nil response results in 204 No Content.
+//
+// Bodies with an unsupported content type are rejected with 415, oversized ones
+// with 413 and undecodable ones with 400. Errors returned by the handler result
+// in 500, unless they implement an HTTPStatus() int method choosing the code.
+func NewHTTPHandler[T newableObject[U], U any](cfg *HTTPConfig, fn func(r *http.Request, req T) (Object, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := T(new(U))
+
+		version, err := ReadNegotiatedRequest(r, req, cfg)
+		switch {
+		case errors.Is(err, ErrUnsupportedContentType):
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+			return
+		case errors.Is(err, ErrBodyTooLarge):
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res, err := fn(r, req)
+		if err != nil {
+			code := http.StatusInternalServerError
+
+			var status interface{ HTTPStatus() int }
+			if errors.As(err, &status) {
+				code = status.HTTPStatus()
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+		if res == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		WriteNegotiatedResponse(w, r, res, version)
+	})
+}
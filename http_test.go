@@ -0,0 +1,330 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the HTTP helpers can transfer SSZ objects between a beacon API
+// server and client, enforcing the content type and size limits.
+func TestHTTPHelpers(t *testing.T) {
+	payload := &types.ExecutionPayloadCapella{
+		BlockNumber:   1,
+		ExtraData:     []byte("ssz"),
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  [][]byte{{0x01, 0x02}},
+		Withdrawals:   []*types.Withdrawal{{Index: 1, Validator: 2, Amount: 3}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			ssz.WriteResponse(w, payload, "capella")
+			return
+		}
+		obj := new(types.ExecutionPayloadCapella)
+		version, err := ssz.ReadRequestWithConfig(r, obj, &ssz.HTTPConfig{MaxSize: 1024})
+		switch {
+		case errors.Is(err, ssz.ErrUnsupportedContentType):
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+		case errors.Is(err, ssz.ErrBodyTooLarge):
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		case err != nil:
+			w.WriteHeader(http.StatusBadRequest)
+		case version != "capella" || ssz.HashSequential(obj) != ssz.HashSequential(payload):
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+
+	// Retrieve the object from the server and check that it's intact
+	res, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("failed to retrieve object: %v", err)
+	}
+	obj := new(types.ExecutionPayloadCapella)
+	version, err := ssz.ReadResponse(res, obj)
+	res.Body.Close()
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if version != "capella" {
+		t.Errorf("consensus version mismatch: have %q, want %q", version, "capella")
+	}
+	if have, want := ssz.HashSequential(obj), ssz.HashSequential(payload); have != want {
+		t.Errorf("response object mismatch: have %#x, want %#x", have, want)
+	}
+	// Submit the object to the server and check the various rejections
+	submit := func(obj ssz.Object, contentType string, chunked bool) int {
+		req, err := ssz.NewRequest(context.Background(), http.MethodPost, server.URL, obj, "capella")
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if chunked {
+			req.Body, req.ContentLength = io.NopCloser(iotest.OneByteReader(req.Body)), -1
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to submit object: %v", err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+	if code := submit(payload, "", false); code != http.StatusOK {
+		t.Errorf("sized request status mismatch: have %d, want %d", code, http.StatusOK)
+	}
+	if code := submit(payload, "", true); code != http.StatusOK {
+		t.Errorf("chunked request status mismatch: have %d, want %d", code, http.StatusOK)
+	}
+	if code := submit(payload, "application/json", false); code != http.StatusUnsupportedMediaType {
+		t.Errorf("json request status mismatch: have %d, want %d", code, http.StatusUnsupportedMediaType)
+	}
+	huge := &types.ExecutionPayloadCapella{BaseFeePerGas: new(uint256.Int), ExtraData: make([]byte, 32), Transactions: [][]byte{make([]byte, 1024)}}
+	if code := submit(huge, "", false); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized request status mismatch: have %d, want %d", code, http.StatusRequestEntityTooLarge)
+	}
+	if code := submit(huge, "", true); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized chunked request status mismatch: have %d, want %d", code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+// Tests that the Accept header negotiation between SSZ and JSON honors quality
+// values and wildcards.
+func TestHTTPPrefersSSZ(t *testing.T) {
+	tests := []struct {
+		accept string
+		ssz    bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/octet-stream", true},
+		{"application/octet-stream;q=1.0,application/json;q=0.9", true},
+		{"application/json, application/octet-stream", false},
+		{"application/json;q=0.5, application/octet-stream", true},
+		{"application/octet-stream;q=0.5, */*", false},
+		{"application/json;q=0.5, */*", true},
+		{"application/octet-stream;q=0", false},
+		{"text/html, application/octet-stream;q=0.1", true},
+	}
+	for i, tt := range tests {
+		header := make(http.Header)
+		if tt.accept != "" {
+			header.Set("Accept", tt.accept)
+		}
+		if have := ssz.PrefersSSZ(header); have != tt.ssz {
+			t.Errorf("test %d (%q): preference mismatch: have %v, want %v", i, tt.accept, have, tt.ssz)
+		}
+	}
+}
+
+// Tests that typed HTTP handlers decode SSZ and JSON requests, respond in the
+// negotiated format and map failures to status codes.
+func TestHTTPHandler(t *testing.T) {
+	handler := ssz.NewHTTPHandler(&ssz.HTTPConfig{MaxSize: 4096}, func(r *http.Request, req *types.ExecutionPayloadCapella) (ssz.Object, error) {
+		switch req.BlockNumber {
+		case 0:
+			return nil, nil
+		case 13:
+			return nil, testHTTPStatusError(http.StatusTeapot)
+		default:
+			req.BlockNumber++
+			return req, nil
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	payload := &types.ExecutionPayloadCapella{
+		BlockNumber:   1,
+		ExtraData:     []byte("ssz"),
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  [][]byte{{0x01, 0x02}},
+		Withdrawals:   []*types.Withdrawal{{Index: 1, Validator: 2, Amount: 3}},
+	}
+	want := *payload
+	want.BlockNumber++
+
+	post := func(obj ssz.Object, asJSON bool, accept string) *http.Response {
+		req, err := ssz.NewRequest(context.Background(), http.MethodPost, server.URL, obj, "capella")
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		if asJSON {
+			blob, err := json.Marshal(obj)
+			if err != nil {
+				t.Fatalf("failed to marshal request: %v", err)
+			}
+			req.Body, req.ContentLength = io.NopCloser(bytes.NewReader(blob)), int64(len(blob))
+			req.Header.Set("Content-Type", ssz.ContentTypeJSON)
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to submit request: %v", err)
+		}
+		t.Cleanup(func() { res.Body.Close() })
+		return res
+	}
+	// Submit the object in both formats, requesting the response in both formats
+	for _, asJSON := range []bool{false, true} {
+		res := post(payload, asJSON, ssz.ContentType)
+		obj := new(types.ExecutionPayloadCapella)
+		if version, err := ssz.ReadResponse(res, obj); err != nil || version != "capella" {
+			t.Fatalf("json %v: failed to read ssz response: version %q, err %v", asJSON, version, err)
+		}
+		if have, want := ssz.HashSequential(obj), ssz.HashSequential(&want); have != want {
+			t.Errorf("json %v: ssz response mismatch: have %#x, want %#x", asJSON, have, want)
+		}
+		res = post(payload, asJSON, "")
+		if have := res.Header.Get("Content-Type"); have != ssz.ContentTypeJSON {
+			t.Fatalf("json %v: content type mismatch: have %q, want %q", asJSON, have, ssz.ContentTypeJSON)
+		}
+		obj = new(types.ExecutionPayloadCapella)
+		if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+			t.Fatalf("json %v: failed to read json response: %v", asJSON, err)
+		}
+		if have, want := ssz.HashSequential(obj), ssz.HashSequential(&want); have != want {
+			t.Errorf("json %v: json response mismatch: have %#x, want %#x", asJSON, have, want)
+		}
+	}
+	// Ensure failures and empty responses are mapped to status codes
+	huge := &types.ExecutionPayloadCapella{BaseFeePerGas: new(uint256.Int), Transactions: [][]byte{make([]byte, 4096)}}
+	tests := []struct {
+		obj    ssz.Object
+		asJSON bool
+		code   int
+	}{
+		{&types.ExecutionPayloadCapella{BaseFeePerGas: new(uint256.Int)}, false, http.StatusNoContent},
+		{&types.ExecutionPayloadCapella{BlockNumber: 13, BaseFeePerGas: new(uint256.Int)}, false, http.StatusTeapot},
+		{huge, false, http.StatusRequestEntityTooLarge},
+		{huge, true, http.StatusRequestEntityTooLarge},
+		{&types.Withdrawal{}, false, http.StatusBadRequest},
+	}
+	for i, tt := range tests {
+		if res := post(tt.obj, tt.asJSON, ""); res.StatusCode != tt.code {
+			t.Errorf("test %d: status mismatch: have %d, want %d", i, res.StatusCode, tt.code)
+		}
+	}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("ssz"))
+	req.Header.Set("Content-Type", "text/plain")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to submit request: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("content type status mismatch: have %d, want %d", res.StatusCode, http.StatusUnsupportedMediaType)
+	}
+}
+
+// Tests that a handler with a decoder config can serve requests concurrently.
+// Run with -race to catch any per-request state leaking into the shared config.
+func TestHTTPHandlerConcurrent(t *testing.T) {
+	cfg := &ssz.HTTPConfig{Decoder: &ssz.DecoderConfig{RelaxFirstOffset: true, TruncateOversized: true}}
+	handler := ssz.NewHTTPHandler(cfg, func(r *http.Request, req *types.ExecutionPayloadCapella) (ssz.Object, error) {
+		req.BlockNumber++
+		return req, nil
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var pend sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		pend.Add(1)
+		go func(number uint64) {
+			defer pend.Done()
+
+			payload := &types.ExecutionPayloadCapella{BlockNumber: number, BaseFeePerGas: new(uint256.Int)}
+			req, err := ssz.NewRequest(context.Background(), http.MethodPost, server.URL, payload, "capella")
+			if err != nil {
+				t.Errorf("request %d: failed to create request: %v", number, err)
+				return
+			}
+			req.Header.Set("Accept", ssz.ContentType)
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("request %d: failed to submit request: %v", number, err)
+				return
+			}
+			defer res.Body.Close()
+
+			obj := new(types.ExecutionPayloadCapella)
+			if _, err := ssz.ReadResponse(res, obj); err != nil {
+				t.Errorf("request %d: failed to read response: %v", number, err)
+				return
+			}
+			if obj.BlockNumber != number+1 {
+				t.Errorf("request %d: block number mismatch: have %d, want %d", number, obj.BlockNumber, number+1)
+			}
+		}(uint64(i))
+	}
+	pend.Wait()
+}
+
+// testHTTPStatusError is an error choosing the HTTP status code of a response.
+type testHTTPStatusError int
+
+func (e testHTTPStatusError) Error() string { return http.StatusText(int(e)) }
+
+func (e testHTTPStatusError) HTTPStatus() int { return int(e) }
+
+// Tests that SSZ bodies are limited to the size of static objects and to the
+// declared maximum size of dynamic ones.
+func TestHTTPBodyLimits(t *testing.T) {
+	read := func(blob []byte, obj ssz.Object) error {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(blob))
+		req.Header.Set("Content-Type", ssz.ContentType)
+		_, err := ssz.ReadRequest(req, obj)
+		return err
+	}
+	if err := read(make([]byte, 41), new(types.Checkpoint)); !errors.Is(err, ssz.ErrBodyTooLarge) {
+		t.Errorf("static limit error mismatch: have %v, want %v", err, ssz.ErrBodyTooLarge)
+	}
+	if err := read([]byte{4, 0, 0, 0, 1, 2, 3, 4}, new(testMaxSizedType)); err != nil {
+		t.Errorf("failed to read body within limit: %v", err)
+	}
+	if err := read([]byte{4, 0, 0, 0, 1, 2, 3, 4, 5}, new(testMaxSizedType)); !errors.Is(err, ssz.ErrBodyTooLarge) {
+		t.Errorf("dynamic limit error mismatch: have %v, want %v", err, ssz.ErrBodyTooLarge)
+	}
+}
+
+// testMaxSizedType is a dynamic container declaring its maximum size.
+type testMaxSizedType struct {
+	Blob []byte
+}
+
+func (t *testMaxSizedType) MaxSizeSSZ() uint32 { return 8 }
+
+func (t *testMaxSizedType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(4)
+	if !fixed {
+		size += ssz.SizeDynamicBytes(t.Blob)
+	}
+	return size
+}
+
+func (t *testMaxSizedType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineDynamicBytesOffset(codec, &t.Blob, 4)
+	ssz.DefineDynamicBytesContent(codec, &t.Blob, 4)
+}
@@ -0,0 +1,699 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	"github.com/holiman/uint256"
+)
+
+// JSONField is a view onto a single field of an ssz object, converting it to and
+// from the JSON mapping of the ssz spec: integers as decimal strings, binary
+// blobs and bitfields as 0x prefixed hex strings, lists and vectors as arrays
+// and containers as objects. Decoding enforces the same sizes and limits as the
+// ssz decoder does.
+//
+// The views are used by the JSON methods emitted by the code generator (with
+// -families json), each of them named after the Define method of the field.
+type JSONField interface {
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// jsonField is a JSONField implemented via a pair of closures.
+type jsonField struct {
+	marshal   func() ([]byte, error)
+	unmarshal func(data []byte) error
+}
+
+func (f *jsonField) MarshalJSON() ([]byte, error)    { return f.marshal() }
+func (f *jsonField) UnmarshalJSON(data []byte) error { return f.unmarshal(data) }
+
+// JSONBool creates the JSON view of a boolean, mapped to a JSON boolean.
+func JSONBool[T ~bool](v *T) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return strconv.AppendBool(nil, bool(*v)), nil
+		},
+		unmarshal: func(data []byte) error {
+			switch string(data) {
+			case "true":
+				*v = true
+			case "false":
+				*v = false
+			default:
+				return fmt.Errorf("%w: boolean %s", ErrInvalidJSON, data)
+			}
+			return nil
+		},
+	}
+}
+
+// JSONUint8 creates the JSON view of a uint8, mapped to a decimal string.
+func JSONUint8[T ~uint8](n *T) JSONField { return jsonUint(n, 8) }
+
+// JSONUint16 creates the JSON view of a uint16, mapped to a decimal string.
+func JSONUint16[T ~uint16](n *T) JSONField { return jsonUint(n, 16) }
+
+// JSONUint32 creates the JSON view of a uint32, mapped to a decimal string.
+func JSONUint32[T ~uint32](n *T) JSONField { return jsonUint(n, 32) }
+
+// JSONUint64 creates the JSON view of a uint64, mapped to a decimal string.
+func JSONUint64[T ~uint64](n *T) JSONField { return jsonUint(n, 64) }
+
+// JSONEnumUint8 creates the JSON view of an enum backed by a uint8, mapped to a
+// decimal string. Values not valid for the enum are rejected.
+func JSONEnumUint8[T interface {
+	~uint8
+	Enum
+}](n *T) JSONField {
+	return jsonEnum(n, 8)
+}
+
+// JSONEnumUint64 creates the JSON view of an enum backed by a uint64, mapped to
+// a decimal string. Values not valid for the enum are rejected.
+func JSONEnumUint64[T interface {
+	~uint64
+	Enum
+}](n *T) JSONField {
+	return jsonEnum(n, 64)
+}
+
+// jsonUint creates the JSON view of an unsigned integer with the given bit size.
+func jsonUint[T ~uint8 | ~uint16 | ~uint32 | ~uint64](n *T, bits int) *jsonField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonDecimal(uint64(*n)), nil
+		},
+		unmarshal: func(data []byte) error {
+			v, err := parseJSONDecimal(data, bits)
+			if err != nil {
+				return err
+			}
+			*n = T(v)
+			return nil
+		},
+	}
+}
+
+// jsonEnum creates the JSON view of an enum backed by an unsigned integer with
+// the given bit size.
+func jsonEnum[T interface {
+	~uint8 | ~uint64
+	Enum
+}](n *T, bits int) JSONField {
+	field := jsonUint(n, bits)
+	field.unmarshal = func(data []byte) error {
+		v, err := parseJSONDecimal(data, bits)
+		if err != nil {
+			return err
+		}
+		if !T(v).Valid() {
+			return fmt.Errorf("%w: %d", ErrInvalidEnum, v)
+		}
+		*n = T(v)
+		return nil
+	}
+	return field
+}
+
+// JSONUint256 creates the JSON view of a uint256, mapped to a decimal string. A
+// nil value is encoded as zero.
+func JSONUint256(n **uint256.Int) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			if *n == nil {
+				return []byte(`"0"`), nil
+			}
+			return []byte(`"` + (*n).Dec() + `"`), nil
+		},
+		unmarshal: func(data []byte) error {
+			s, err := parseJSONString(data)
+			if err != nil {
+				return err
+			}
+			v, err := uint256.FromDecimal(s)
+			if err != nil {
+				return fmt.Errorf("%w: uint256 %s: %v", ErrInvalidJSON, data, err)
+			}
+			*n = v
+			return nil
+		},
+	}
+}
+
+// JSONUint256BigInt creates the JSON view of a big.Int holding a uint256, mapped
+// to a decimal string. A nil value is encoded as zero.
+func JSONUint256BigInt(n **big.Int) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			if *n == nil {
+				return []byte(`"0"`), nil
+			}
+			if (*n).Sign() < 0 || (*n).BitLen() > 256 {
+				return nil, fmt.Errorf("%w: %v", ErrUint256Overflow, *n)
+			}
+			return []byte(`"` + (*n).String() + `"`), nil
+		},
+		unmarshal: func(data []byte) error {
+			s, err := parseJSONString(data)
+			if err != nil {
+				return err
+			}
+			v, ok := new(big.Int).SetString(s, 10)
+			if !ok || s[0] == '+' || s[0] == '-' {
+				return fmt.Errorf("%w: uint256 %s", ErrInvalidJSON, data)
+			}
+			if v.BitLen() > 256 {
+				return fmt.Errorf("%w: %v", ErrUint256Overflow, v)
+			}
+			*n = v
+			return nil
+		},
+	}
+}
+
+// JSONStaticBytes creates the JSON view of a static binary blob, mapped to a hex
+// string of exactly the blob's size.
+func JSONStaticBytes[T commonBytesLengths](blob *T) JSONField {
+	return jsonStaticBytes(arrayBytes(blob))
+}
+
+// JSONCheckedStaticBytes creates the JSON view of a static binary blob held in a
+// byte slice, mapped to a hex string of exactly size bytes.
+func JSONCheckedStaticBytes(blob *[]byte, size uint64) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			if uint64(len(*blob)) != size {
+				return nil, fmt.Errorf("%w: %d bytes, want %d", ErrStaticBytesSizeMismatch, len(*blob), size)
+			}
+			return jsonHex(*blob), nil
+		},
+		unmarshal: func(data []byte) error {
+			v, err := parseJSONHex(data)
+			if err != nil {
+				return err
+			}
+			if uint64(len(v)) != size {
+				return fmt.Errorf("%w: %d bytes, want %d", ErrStaticBytesSizeMismatch, len(v), size)
+			}
+			*blob = v
+			return nil
+		},
+	}
+}
+
+// JSONDynamicBytes creates the JSON view of a dynamic binary blob, mapped to a
+// hex string of at most maxSize bytes.
+func JSONDynamicBytes(blob *[]byte, maxSize uint64) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonHex(*blob), nil
+		},
+		unmarshal: func(data []byte) error {
+			v, err := parseJSONHex(data)
+			if err != nil {
+				return err
+			}
+			if uint64(len(v)) > maxSize {
+				return fmt.Errorf("%w: decoded %d, max %d", ErrMaxLengthExceeded, len(v), maxSize)
+			}
+			*blob = v
+			return nil
+		},
+	}
+}
+
+// JSONStaticBytesPointer creates the JSON view of a pointer to a static binary
+// blob, mapped to a hex string of exactly the blob's size. A nil pointer is
+// encoded as a zero blob.
+func JSONStaticBytesPointer[T commonBytesLengths](blob **T) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			if *blob == nil {
+				return jsonHex(arrayBytes(new(T))), nil
+			}
+			return jsonHex(arrayBytes(*blob)), nil
+		},
+		unmarshal: func(data []byte) error {
+			v := new(T)
+			if err := jsonStaticBytes(arrayBytes(v)).UnmarshalJSON(data); err != nil {
+				return err
+			}
+			*blob = v
+			return nil
+		},
+	}
+}
+
+// JSONDynamicString creates the JSON view of a dynamic string, mapped to the hex
+// string of its bytes (the same as the byte list it is encoded as), of at most
+// maxSize bytes.
+func JSONDynamicString[T ~string](str *T, maxSize uint64) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonHex([]byte(*str)), nil
+		},
+		unmarshal: func(data []byte) error {
+			var blob []byte
+			if err := JSONDynamicBytes(&blob, maxSize).UnmarshalJSON(data); err != nil {
+				return err
+			}
+			*str = T(blob)
+			return nil
+		},
+	}
+}
+
+// JSONArrayOfBits creates the JSON view of a static array of (packed) bits, i.e.
+// a bitvector, mapped to the hex string of its ssz encoding.
+func JSONArrayOfBits[T commonBitsLengths](bits *T, size uint64) JSONField {
+	bitvector := arrayBytes(bits)
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonHex(bitvector), nil
+		},
+		unmarshal: func(data []byte) error {
+			v, err := parseJSONHex(data)
+			if err != nil {
+				return err
+			}
+			if len(v) != len(bitvector) {
+				return fmt.Errorf("%w: %d bytes, want %d", ErrStaticBytesSizeMismatch, len(v), len(bitvector))
+			}
+			for i := size; i < uint64(len(v)<<3); i++ {
+				if v[i>>3]&(1<<(i&0x7)) > 0 {
+					return fmt.Errorf("%w: bit %d set, size %d bits", ErrJunkInBitvector, i+1, size)
+				}
+			}
+			copy(bitvector, v)
+			return nil
+		},
+	}
+}
+
+// JSONSliceOfBits creates the JSON view of a dynamic slice of (packed) bits, i.e.
+// a bitlist, mapped to the hex string of its ssz encoding (with the length bit).
+func JSONSliceOfBits[T ~[]byte](bitlist *T, maxBits uint64) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonHex(*bitlist), nil
+		},
+		unmarshal: func(data []byte) error {
+			v, err := parseJSONHex(data)
+			if err != nil {
+				return err
+			}
+			if len(v) == 0 || v[len(v)-1] == 0 {
+				return fmt.Errorf("%w: missing length bit", ErrJunkInBitlist)
+			}
+			if size := uint64((len(v)-1)<<3 + bits.Len8(v[len(v)-1]) - 1); size > maxBits {
+				return fmt.Errorf("%w: decoded %d bits, max %d bits", ErrMaxLengthExceeded, size, maxBits)
+			}
+			*bitlist = v
+			return nil
+		},
+	}
+}
+
+// JSONArrayOfUint64s creates the JSON view of a static array of uint64s, mapped
+// to an array of decimal strings.
+func JSONArrayOfUint64s[T commonUint64sLengths](ns *T) JSONField {
+	nums := arrayUint64s(ns)
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonArray(len(nums), func(i int) ([]byte, error) {
+				return jsonDecimal(nums[i]), nil
+			})
+		},
+		unmarshal: func(data []byte) error {
+			items, err := parseJSONArray(data, uint64(len(nums)), true)
+			if err != nil {
+				return err
+			}
+			for i, item := range items {
+				if nums[i], err = parseJSONDecimal(item, 64); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// JSONSliceOfUint64s creates the JSON view of a dynamic slice of uint64s, mapped
+// to an array of at most maxItems decimal strings.
+func JSONSliceOfUint64s[T ~uint64](ns *[]T, maxItems uint64) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonArray(len(*ns), func(i int) ([]byte, error) {
+				return jsonDecimal(uint64((*ns)[i])), nil
+			})
+		},
+		unmarshal: func(data []byte) error {
+			items, err := parseJSONArray(data, maxItems, false)
+			if err != nil {
+				return err
+			}
+			nums := make([]T, len(items))
+			for i, item := range items {
+				n, err := parseJSONDecimal(item, 64)
+				if err != nil {
+					return err
+				}
+				nums[i] = T(n)
+			}
+			*ns = nums
+			return nil
+		},
+	}
+}
+
+// JSONUnsafeArrayOfStaticBytes creates the JSON view of a static array of static
+// binary blobs, mapped to an array of hex strings.
+func JSONUnsafeArrayOfStaticBytes[T commonBytesLengths](blobs []T) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonStaticBytesArray(blobs)
+		},
+		unmarshal: func(data []byte) error {
+			items, err := parseJSONArray(data, uint64(len(blobs)), true)
+			if err != nil {
+				return err
+			}
+			return parseJSONStaticBytesArray(items, blobs)
+		},
+	}
+}
+
+// JSONCheckedArrayOfStaticBytes creates the JSON view of a static array of static
+// binary blobs held in a slice, mapped to an array of exactly size hex strings.
+func JSONCheckedArrayOfStaticBytes[T commonBytesLengths](blobs *[]T, size uint64) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			if uint64(len(*blobs)) != size {
+				return nil, fmt.Errorf("%w: %d items, want %d", ErrStaticBytesSizeMismatch, len(*blobs), size)
+			}
+			return jsonStaticBytesArray(*blobs)
+		},
+		unmarshal: func(data []byte) error {
+			items, err := parseJSONArray(data, size, true)
+			if err != nil {
+				return err
+			}
+			v := make([]T, len(items))
+			if err := parseJSONStaticBytesArray(items, v); err != nil {
+				return err
+			}
+			*blobs = v
+			return nil
+		},
+	}
+}
+
+// JSONSliceOfStaticBytes creates the JSON view of a dynamic slice of static binary
+// blobs, mapped to an array of at most maxItems hex strings.
+func JSONSliceOfStaticBytes[T commonBytesLengths](blobs *[]T, maxItems uint64) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonStaticBytesArray(*blobs)
+		},
+		unmarshal: func(data []byte) error {
+			items, err := parseJSONArray(data, maxItems, false)
+			if err != nil {
+				return err
+			}
+			v := make([]T, len(items))
+			if err := parseJSONStaticBytesArray(items, v); err != nil {
+				return err
+			}
+			*blobs = v
+			return nil
+		},
+	}
+}
+
+// JSONCheckedSliceOfStaticBytes creates the JSON view of a dynamic slice of static
+// binary blobs held in byte slices, mapped to an array of at most maxItems hex
+// strings of exactly size bytes each.
+func JSONCheckedSliceOfStaticBytes(blobs *[][]byte, maxItems uint64, size uint64) JSONField {
+	return jsonSliceOfBytes(blobs, maxItems, size, true)
+}
+
+// JSONSliceOfDynamicBytes creates the JSON view of a dynamic slice of dynamic
+// binary blobs, mapped to an array of at most maxItems hex strings of at most
+// maxSize bytes each.
+func JSONSliceOfDynamicBytes(blobs *[][]byte, maxItems uint64, maxSize uint64) JSONField {
+	return jsonSliceOfBytes(blobs, maxItems, maxSize, false)
+}
+
+// jsonSliceOfBytes creates the JSON view of a dynamic slice of binary blobs, each
+// of them either of exactly or at most size bytes.
+func jsonSliceOfBytes(blobs *[][]byte, maxItems uint64, size uint64, exact bool) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonArray(len(*blobs), func(i int) ([]byte, error) {
+				if exact && uint64(len((*blobs)[i])) != size {
+					return nil, fmt.Errorf("%w: %d bytes, want %d", ErrStaticBytesSizeMismatch, len((*blobs)[i]), size)
+				}
+				return jsonHex((*blobs)[i]), nil
+			})
+		},
+		unmarshal: func(data []byte) error {
+			items, err := parseJSONArray(data, maxItems, false)
+			if err != nil {
+				return err
+			}
+			v := make([][]byte, len(items))
+			for i, item := range items {
+				if v[i], err = parseJSONHex(item); err != nil {
+					return err
+				}
+				switch {
+				case exact && uint64(len(v[i])) != size:
+					return fmt.Errorf("%w: %d bytes, want %d", ErrStaticBytesSizeMismatch, len(v[i]), size)
+				case !exact && uint64(len(v[i])) > size:
+					return fmt.Errorf("%w: decoded %d, max %d", ErrMaxLengthExceeded, len(v[i]), size)
+				}
+			}
+			*blobs = v
+			return nil
+		},
+	}
+}
+
+// JSONStaticObject creates the JSON view of a static object, mapped via its own
+// JSON methods. A nil object is encoded as an empty one.
+func JSONStaticObject[T newableStaticObject[U], U any](obj *T) JSONField {
+	return jsonObject[T, U](obj)
+}
+
+// JSONDynamicObject creates the JSON view of a dynamic object, mapped via its own
+// JSON methods. A nil object is encoded as an empty one.
+func JSONDynamicObject[T newableDynamicObject[U], U any](obj *T) JSONField {
+	return jsonObject[T, U](obj)
+}
+
+// jsonObject creates the JSON view of an object, allocating it if needed.
+func jsonObject[T interface {
+	Object
+	*U
+}, U any](obj *T) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			if *obj == nil {
+				return json.Marshal(T(new(U)))
+			}
+			return json.Marshal(*obj)
+		},
+		unmarshal: func(data []byte) error {
+			if *obj == nil {
+				*obj = T(new(U))
+			}
+			return json.Unmarshal(data, *obj)
+		},
+	}
+}
+
+// JSONSliceOfStaticObjects creates the JSON view of a dynamic slice of static
+// objects, mapped to an array of at most maxItems JSON objects.
+func JSONSliceOfStaticObjects[T newableStaticObject[U], U any](objects *[]T, maxItems uint64) JSONField {
+	return jsonObjects[T, U](objects, maxItems)
+}
+
+// JSONSliceOfDynamicObjects creates the JSON view of a dynamic slice of dynamic
+// objects, mapped to an array of at most maxItems JSON objects.
+func JSONSliceOfDynamicObjects[T newableDynamicObject[U], U any](objects *[]T, maxItems uint64) JSONField {
+	return jsonObjects[T, U](objects, maxItems)
+}
+
+// jsonObjects creates the JSON view of a slice of objects.
+func jsonObjects[T interface {
+	Object
+	*U
+}, U any](objects *[]T, maxItems uint64) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonArray(len(*objects), func(i int) ([]byte, error) {
+				return jsonObject[T, U](&(*objects)[i]).MarshalJSON()
+			})
+		},
+		unmarshal: func(data []byte) error {
+			items, err := parseJSONArray(data, maxItems, false)
+			if err != nil {
+				return err
+			}
+			v := make([]T, len(items))
+			for i, item := range items {
+				v[i] = T(new(U))
+				if err := json.Unmarshal(item, v[i]); err != nil {
+					return err
+				}
+			}
+			*objects = v
+			return nil
+		},
+	}
+}
+
+// jsonStaticBytes creates the JSON view of a binary blob of fixed size.
+func jsonStaticBytes(blob []byte) JSONField {
+	return &jsonField{
+		marshal: func() ([]byte, error) {
+			return jsonHex(blob), nil
+		},
+		unmarshal: func(data []byte) error {
+			v, err := parseJSONHex(data)
+			if err != nil {
+				return err
+			}
+			if len(v) != len(blob) {
+				return fmt.Errorf("%w: %d bytes, want %d", ErrStaticBytesSizeMismatch, len(v), len(blob))
+			}
+			copy(blob, v)
+			return nil
+		},
+	}
+}
+
+// jsonStaticBytesArray encodes a list of static binary blobs as an array of hex
+// strings.
+func jsonStaticBytesArray[T commonBytesLengths](blobs []T) ([]byte, error) {
+	return jsonArray(len(blobs), func(i int) ([]byte, error) {
+		return jsonHex(arrayBytes(&blobs[i])), nil
+	})
+}
+
+// parseJSONStaticBytesArray decodes an array of hex strings into equally many
+// static binary blobs.
+func parseJSONStaticBytesArray[T commonBytesLengths](items []json.RawMessage, blobs []T) error {
+	for i, item := range items {
+		if err := jsonStaticBytes(arrayBytes(&blobs[i])).UnmarshalJSON(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonDecimal encodes an integer as a JSON string holding its decimal form.
+func jsonDecimal(n uint64) []byte {
+	return strconv.AppendQuote(nil, strconv.FormatUint(n, 10))
+}
+
+// parseJSONDecimal decodes a JSON string holding the decimal form of an unsigned
+// integer of the given bit size.
+func parseJSONDecimal(data []byte, bits int) (uint64, error) {
+	s, err := parseJSONString(data)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(s, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("%w: uint%d %s", ErrInvalidJSON, bits, data)
+	}
+	return n, nil
+}
+
+// jsonHex encodes a binary blob as a JSON string holding its 0x prefixed hex form.
+func jsonHex(blob []byte) []byte {
+	out := make([]byte, 4+2*len(blob))
+	copy(out, `"0x`)
+	hex.Encode(out[3:], blob)
+	out[len(out)-1] = '"'
+	return out
+}
+
+// parseJSONHex decodes a JSON string holding the 0x prefixed hex form of a binary
+// blob.
+func parseJSONHex(data []byte) ([]byte, error) {
+	s, err := parseJSONString(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(s) < 2 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X') {
+		return nil, fmt.Errorf("%w: hex string %s without 0x prefix", ErrInvalidJSON, data)
+	}
+	blob, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: hex string %s: %v", ErrInvalidJSON, data, err)
+	}
+	return blob, nil
+}
+
+// parseJSONString decodes a JSON string.
+func parseJSONString(data []byte) (string, error) {
+	var s string
+	if len(data) == 0 || data[0] != '"' {
+		return "", fmt.Errorf("%w: %s is not a string", ErrInvalidJSON, data)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	return s, nil
+}
+
+// jsonArray encodes a JSON array of items, each of them encoded by the callback.
+func jsonArray(items int, item func(i int) ([]byte, error)) ([]byte, error) {
+	var out bytes.Buffer
+	out.WriteByte('[')
+	for i := 0; i < items; i++ {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		blob, err := item(i)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(blob)
+	}
+	out.WriteByte(']')
+	return out.Bytes(), nil
+}
+
+// parseJSONArray splits a JSON array into its raw items, checking that there are
+// exactly (vectors) or at most (lists) the given number of them.
+func parseJSONArray(data []byte, items uint64, exact bool) ([]json.RawMessage, error) {
+	if len(data) == 0 || data[0] != '[' {
+		return nil, fmt.Errorf("%w: %s is not an array", ErrInvalidJSON, data)
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	switch {
+	case exact && uint64(len(raws)) != items:
+		return nil, fmt.Errorf("%w: %d items, want %d", ErrStaticBytesSizeMismatch, len(raws), items)
+	case !exact && uint64(len(raws)) > items:
+		return nil, fmt.Errorf("%w: decoded %d, max %d", ErrMaxItemsExceeded, len(raws), items)
+	}
+	return raws, nil
+}
@@ -0,0 +1,95 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package kv implements a thin adapter for persisting SSZ objects in key-value
+// stores (e.g. Pebble, Badger, LevelDB), bundling the key namespacing, buffer
+// pooling and optional compression that such glue code usually reimplements.
+//
+// The package does not depend on any store, it only produces keys and values:
+//
+//	blocks := &kv.Table{Prefix: []byte("b"), Compressor: compress.SnappyRaw, MaxSize: 1 << 24}
+//
+//	err := blocks.EncodeFunc(block, func(value []byte) error {
+//		return batch.Set(blocks.Key(root[:]), value, nil) // Pebble copies the value
+//	})
+package kv
+
+import (
+	"bytes"
+	"math"
+	"sync"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/compress"
+)
+
+// Table is a set of objects of the same kind persisted in a key-value store
+// under a shared key prefix. It is safe for concurrent use.
+type Table struct {
+	Prefix     []byte              // Prefix of the keys of the table's objects
+	Compressor compress.Compressor // Optional compression of the stored values
+	MaxSize    uint32              // Maximum decompressed size of dynamic objects (0 = no limit)
+}
+
+// scratchPool is the pool of reusable buffers for values that are only needed
+// until they are handed over to the store.
+var scratchPool = sync.Pool{
+	New: func() any { return new([]byte) },
+}
+
+// Key returns the store key of an object of the table, addressed by its id
+// (e.g. a block root or a slot number).
+func (t *Table) Key(id []byte) []byte {
+	key := make([]byte, 0, len(t.Prefix)+len(id))
+	key = append(key, t.Prefix...)
+	return append(key, id...)
+}
+
+// Encode serializes (and optionally compresses) the object into a freshly
+// allocated value, which the caller may retain.
+func (t *Table) Encode(obj ssz.Object) ([]byte, error) {
+	return t.encode(nil, obj)
+}
+
+// EncodeFunc serializes (and optionally compresses) the object into a pooled
+// buffer, and passes it to the callback for storing. The value must not be
+// retained after the callback returns, so it's suitable for stores copying the
+// values they are given (e.g. Pebble's and LevelDB's batches).
+func (t *Table) EncodeFunc(obj ssz.Object, fn func(value []byte) error) error {
+	scratch := scratchPool.Get().(*[]byte)
+	defer scratchPool.Put(scratch)
+
+	value, err := t.encode((*scratch)[:0], obj)
+	if err != nil {
+		return err
+	}
+	*scratch = value[:0]
+	return fn(value)
+}
+
+// Decode parses (and optionally decompresses) a value retrieved from the store
+// into the object. The value is not retained, so it may be a buffer owned by
+// the store, only valid until the read completes.
+func (t *Table) Decode(value []byte, obj ssz.Object) error {
+	if t.Compressor == nil {
+		return ssz.DecodeFromBytes(value, obj)
+	}
+	limit := t.MaxSize
+	if limit == 0 {
+		limit = math.MaxUint32
+	}
+	return compress.Decode(bytes.NewReader(value), obj, t.Compressor, limit)
+}
+
+// encode appends the serialized (and optionally compressed) object to dst.
+func (t *Table) encode(dst []byte, obj ssz.Object) ([]byte, error) {
+	if t.Compressor == nil {
+		return ssz.EncodeAppend(dst, obj)
+	}
+	buf := bytes.NewBuffer(dst)
+	if err := compress.Encode(buf, obj, t.Compressor); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
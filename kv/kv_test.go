@@ -0,0 +1,83 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package kv_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/compress"
+	"github.com/karalabe/ssz/kv"
+	"github.com/karalabe/ssz/sszcommon"
+)
+
+// Tests that objects round trip through tables with and without compression,
+// both via retained and pooled values.
+func TestTables(t *testing.T) {
+	tables := map[string]*kv.Table{
+		"plain":  {Prefix: []byte("r")},
+		"snappy": {Prefix: []byte("r"), Compressor: compress.SnappyRaw, MaxSize: 1024},
+	}
+	for name, table := range tables {
+		requests := &sszcommon.ExecutionRequests{
+			Withdrawals: []*sszcommon.WithdrawalRequest{{Amount: 1}, {Amount: 2}},
+		}
+		value, err := table.Encode(requests)
+		if err != nil {
+			t.Fatalf("%s: failed to encode object: %v", name, err)
+		}
+		dec := new(sszcommon.ExecutionRequests)
+		if err := table.Decode(value, dec); err != nil {
+			t.Fatalf("%s: failed to decode object: %v", name, err)
+		}
+		if have, want := ssz.HashSequential(dec), ssz.HashSequential(requests); have != want {
+			t.Errorf("%s: decoded object mismatch: have %#x, want %#x", name, have, want)
+		}
+		// Pooled values must match the retained ones
+		for i := 0; i < 3; i++ {
+			err := table.EncodeFunc(requests, func(pooled []byte) error {
+				if !bytes.Equal(pooled, value) {
+					t.Errorf("%s: pooled value mismatch: have %x, want %x", name, pooled, value)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("%s: failed to encode pooled object: %v", name, err)
+			}
+		}
+		// Callback failures must be propagated
+		fail := errors.New("store failure")
+		if err := table.EncodeFunc(requests, func([]byte) error { return fail }); !errors.Is(err, fail) {
+			t.Errorf("%s: callback error mismatch: have %v, want %v", name, err, fail)
+		}
+	}
+}
+
+// Tests that keys are namespaced by the table prefix, without aliasing it.
+func TestTableKeys(t *testing.T) {
+	table := &kv.Table{Prefix: make([]byte, 1, 8)}
+
+	a, b := table.Key([]byte{0x01}), table.Key([]byte{0x02})
+	if !bytes.Equal(a, []byte{0x00, 0x01}) || !bytes.Equal(b, []byte{0x00, 0x02}) {
+		t.Errorf("key mismatch: have %x and %x, want 0001 and 0002", a, b)
+	}
+}
+
+// Tests that decompressing dynamic objects is limited to the table's max size.
+func TestTableMaxSize(t *testing.T) {
+	requests := &sszcommon.ExecutionRequests{
+		Withdrawals: []*sszcommon.WithdrawalRequest{{Amount: 1}, {Amount: 2}},
+	}
+	value, err := (&kv.Table{Compressor: compress.SnappyRaw}).Encode(requests)
+	if err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	table := &kv.Table{Compressor: compress.SnappyRaw, MaxSize: ssz.Size(requests) - 1}
+	if err := table.Decode(value, new(sszcommon.ExecutionRequests)); !errors.Is(err, compress.ErrDecompressedTooLarge) {
+		t.Errorf("limit error mismatch: have %v, want %v", err, compress.ErrDecompressedTooLarge)
+	}
+}
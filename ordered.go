@@ -0,0 +1,201 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// KeyedObject is a static ssz object (typically a 2-field key/value container)
+// that can be stored in an OrderedMap, which keeps the entries sorted by key.
+type KeyedObject[K cmp.Ordered] interface {
+	StaticObject
+
+	// SSZKey returns the key the entry is ordered by within an OrderedMap. It
+	// must be derived from the entry's fields (e.g. return the key field).
+	SSZKey() K
+}
+
+// newableKeyedObject is a generic type whose purpose is to enforce that the
+// KeyedObject is specifically implemented on a struct pointer.
+type newableKeyedObject[K cmp.Ordered, U any] interface {
+	KeyedObject[K]
+	*U
+}
+
+// OrderedMap is a bounded list of key/value entries, kept sorted by strictly
+// ascending keys. SSZ has no map type, so map-like data needs to be modelled as
+// a list of 2-field containers; this helper guarantees that the list has the
+// deterministic ordering required for canonical encodings and stable hashes.
+//
+// Encoding fails with ErrUnorderedKeys if the entries were modified out of order
+// (e.g. a key changed through Items), and so does decoding an unordered list.
+//
+// The zero value is an empty map ready to use.
+type OrderedMap[K cmp.Ordered, T KeyedObject[K], N Bound] struct {
+	items []T
+}
+
+// Len returns the number of entries in the map.
+func (m *OrderedMap[K, T, N]) Len() int {
+	return len(m.items)
+}
+
+// At returns the entry at the given index, in key order.
+func (m *OrderedMap[K, T, N]) At(i int) T {
+	return m.items[i]
+}
+
+// Items returns the entries in the map, in key order. The returned slice must
+// not be appended to nor reordered, and the keys of the entries must not be
+// changed, as that would circumvent the map's limit and ordering.
+func (m *OrderedMap[K, T, N]) Items() []T {
+	return m.items
+}
+
+// Get returns the entry with the given key, if any.
+func (m *OrderedMap[K, T, N]) Get(key K) (T, bool) {
+	if i, ok := m.search(key); ok {
+		return m.items[i], true
+	}
+	var none T
+	return none, false
+}
+
+// Put inserts an entry into the map at the position of its key, replacing any
+// previous entry with the same key. An error is returned if a new entry would
+// exceed the map's limit.
+func (m *OrderedMap[K, T, N]) Put(entry T) error {
+	i, ok := m.search(entry.SSZKey())
+	if ok {
+		m.items[i] = entry
+		return nil
+	}
+	var bound N
+	if limit := bound.Limit(); uint64(len(m.items)+1) > limit {
+		return fmt.Errorf("%w: inserting into %d, max %d", ErrMaxItemsExceeded, len(m.items), limit)
+	}
+	m.items = slices.Insert(m.items, i, entry)
+	return nil
+}
+
+// Delete removes the entry with the given key, reporting whether it existed.
+func (m *OrderedMap[K, T, N]) Delete(key K) bool {
+	i, ok := m.search(key)
+	if ok {
+		m.items = slices.Delete(m.items, i, i+1)
+	}
+	return ok
+}
+
+// Reset removes all entries from the map, retaining its capacity for reuse.
+func (m *OrderedMap[K, T, N]) Reset() {
+	clear(m.items)
+	m.items = m.items[:0]
+}
+
+// search returns the position of the given key in the map, or the position it
+// would need to be inserted at if not present.
+func (m *OrderedMap[K, T, N]) search(key K) (int, bool) {
+	return slices.BinarySearchFunc(m.items, key, func(entry T, key K) int {
+		return cmp.Compare(entry.SSZKey(), key)
+	})
+}
+
+// checkOrderedKeys returns an error if the keys of some entries are not strictly
+// ascending.
+func checkOrderedKeys[K cmp.Ordered, T KeyedObject[K]](items []T) error {
+	for i := 1; i < len(items); i++ {
+		if prev, next := items[i-1].SSZKey(), items[i].SSZKey(); !cmp.Less(prev, next) {
+			return fmt.Errorf("%w: key %v at index %d follows %v", ErrUnorderedKeys, next, i, prev)
+		}
+	}
+	return nil
+}
+
+// DefineOrderedMapOffset defines the next field as a bounded list of key/value
+// entries, sorted by key.
+func DefineOrderedMapOffset[K cmp.Ordered, T newableKeyedObject[K, U], U any, N Bound](c *Codec, m *OrderedMap[K, T, N]) {
+	if c.enc != nil {
+		EncodeOrderedMapOffset(c.enc, m)
+		if c.hashing() {
+			c.has.reserveChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeOrderedMapOffset(c.dec, m)
+		return
+	}
+	var bound N
+	DefineSliceOfStaticObjectsOffset(c, &m.items, bound.Limit())
+}
+
+// DefineOrderedMapContent defines the next field as a bounded list of key/value
+// entries, sorted by key.
+func DefineOrderedMapContent[K cmp.Ordered, T newableKeyedObject[K, U], U any, N Bound](c *Codec, m *OrderedMap[K, T, N]) {
+	var bound N
+	if c.enc != nil {
+		if c.hashing() {
+			c.has.descendMixinLayer()
+		}
+		EncodeOrderedMapContent(c.enc, m)
+		if c.hashing() {
+			c.has.ascendMixinLayer(uint64(len(m.items)), bound.Limit())
+			c.has.fillChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeOrderedMapContent(c.dec, m)
+		return
+	}
+	DefineSliceOfStaticObjectsContent(c, &m.items, bound.Limit())
+}
+
+// EncodeOrderedMapOffset serializes a bounded list of key/value entries.
+//
+// Note, entries out of key order will be serialized, but halt encoding with an
+// error.
+func EncodeOrderedMapOffset[K cmp.Ordered, T KeyedObject[K], N Bound](enc *Encoder, m *OrderedMap[K, T, N]) {
+	if enc.err == nil {
+		enc.err = checkOrderedKeys[K](m.items)
+	}
+	EncodeSliceOfStaticObjectsOffset(enc, m.items)
+}
+
+// EncodeOrderedMapContent serializes a bounded list of key/value entries.
+func EncodeOrderedMapContent[K cmp.Ordered, T KeyedObject[K], N Bound](enc *Encoder, m *OrderedMap[K, T, N]) {
+	EncodeSliceOfStaticObjectsContent(enc, m.items)
+}
+
+// DecodeOrderedMapOffset parses a bounded list of key/value entries.
+func DecodeOrderedMapOffset[K cmp.Ordered, T newableKeyedObject[K, U], U any, N Bound](dec *Decoder, m *OrderedMap[K, T, N]) {
+	DecodeSliceOfStaticObjectsOffset(dec, &m.items)
+}
+
+// DecodeOrderedMapContent parses a bounded list of key/value entries, rejecting
+// them if the keys are not strictly ascending.
+func DecodeOrderedMapContent[K cmp.Ordered, T newableKeyedObject[K, U], U any, N Bound](dec *Decoder, m *OrderedMap[K, T, N]) {
+	var bound N
+	DecodeSliceOfStaticObjectsContent(dec, &m.items, bound.Limit())
+	if dec.err == nil {
+		dec.err = checkOrderedKeys[K](m.items)
+	}
+}
+
+// HashOrderedMap hashes a bounded list of key/value entries.
+func HashOrderedMap[K cmp.Ordered, T KeyedObject[K], N Bound](h *Hasher, m *OrderedMap[K, T, N]) {
+	var bound N
+	HashSliceOfStaticObjects(h, m.items, bound.Limit())
+}
+
+// SizeOrderedMap returns the serialized size of the dynamic part of a bounded
+// list of key/value entries.
+func SizeOrderedMap[K cmp.Ordered, T KeyedObject[K], N Bound](m *OrderedMap[K, T, N]) uint32 {
+	return SizeSliceOfStaticObjects(m.items)
+}
@@ -0,0 +1,145 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Partial is a partial view of a container reconstructed from a multiproof (an
+// SSZ partial). It exposes only the fields covered by the proof, addressed by
+// the same paths as GeneralizedIndex, and errors with ErrUnprovenPath when any
+// other field is accessed. Light clients can use it to work with fragments of
+// large objects (e.g. beacon states) once its root is verified.
+type Partial struct {
+	schema *Schema             // Schema of the container the proof is for
+	nodes  map[uint64][32]byte // Known nodes of the hash tree by generalized index
+}
+
+// NewPartial reconstructs a partial view of a container of the schema from a
+// multiproof. The proof is checked to be well formed, but the root it hashes up
+// to must still be verified against a trusted one via Verify.
+func (s *Schema) NewPartial(proof *Multiproof) (*Partial, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	nodes, err := proof.nodes()
+	if err != nil {
+		return nil, err
+	}
+	return &Partial{schema: s, nodes: nodes}, nil
+}
+
+// Root returns the hash tree root the partial's proof hashes up to.
+func (p *Partial) Root() [32]byte {
+	return p.nodes[1]
+}
+
+// Verify checks that the partial is a view of the container with the given root.
+func (p *Partial) Verify(root [32]byte) error {
+	if have := p.nodes[1]; have != root {
+		return fmt.Errorf("%w: root mismatch: have %#x, want %#x", ErrInvalidProof, have, root)
+	}
+	return nil
+}
+
+// lookup resolves a path and returns the node stored at it, failing if the node
+// is not known from the proof.
+func (p *Partial) lookup(path []any) ([32]byte, SchemaField, int, error) {
+	gindex, field, offset, err := p.schema.resolve(path)
+	if err != nil {
+		return [32]byte{}, field, 0, err
+	}
+	node, ok := p.nodes[gindex]
+	if !ok {
+		return [32]byte{}, field, 0, fmt.Errorf("%w: %v (generalized index %d)", ErrUnprovenPath, path, gindex)
+	}
+	return node, field, offset, nil
+}
+
+// Node returns the hash tree root of the field at the path, which is the chunk
+// itself for basic fields (shared by up to four items for uint64 lists).
+func (p *Partial) Node(path ...any) ([32]byte, error) {
+	node, _, _, err := p.lookup(path)
+	return node, err
+}
+
+// Bool returns the value of the boolean field at the path.
+func (p *Partial) Bool(path ...any) (bool, error) {
+	node, field, _, err := p.lookup(path)
+	if err != nil {
+		return false, err
+	}
+	if field.Kind != FieldBool {
+		return false, fmt.Errorf("%w: %s: not a boolean", ErrSchemaMismatch, field.Name)
+	}
+	return node[0] == 1, nil
+}
+
+// Uint returns the value of the unsigned integer field (of at most 64 bits) at
+// the path, or of a uint64 list item.
+func (p *Partial) Uint(path ...any) (uint64, error) {
+	node, field, offset, err := p.lookup(path)
+	if err != nil {
+		return 0, err
+	}
+	switch field.Kind {
+	case FieldUint8:
+		return uint64(node[0]), nil
+	case FieldUint16:
+		return uint64(binary.LittleEndian.Uint16(node[:])), nil
+	case FieldUint32:
+		return uint64(binary.LittleEndian.Uint32(node[:])), nil
+	case FieldUint64:
+		return binary.LittleEndian.Uint64(node[offset:]), nil
+	default:
+		return 0, fmt.Errorf("%w: %s: not a 64 bit unsigned integer", ErrSchemaMismatch, field.Name)
+	}
+}
+
+// Len returns the number of items of the list (or bytes of the dynamic blob or
+// bits of the bitlist) at the path.
+func (p *Partial) Len(path ...any) (uint64, error) {
+	return p.Uint(append(path[:len(path):len(path)], "__len__")...)
+}
+
+// Bytes returns the content of the binary blob field at the path. All chunks of
+// the blob (and the length of dynamic blobs) must be proven individually, not
+// only the field's root.
+func (p *Partial) Bytes(path ...any) ([]byte, error) {
+	gindex, field, _, err := p.schema.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	limit, _ := field.treeShape()
+
+	var size uint64
+	switch field.Kind {
+	case FieldStaticBytes, FieldCheckedStaticBytes:
+		size = field.Size
+	case FieldDynamicBytes:
+		if size, err = p.Len(path...); err != nil {
+			return nil, err
+		}
+		if size > field.MaxSize {
+			return nil, fmt.Errorf("%w: %s: have %d bytes, max %d", ErrInvalidProof, field.Name, size, field.MaxSize)
+		}
+		gindex <<= 1 // data subtree
+	default:
+		return nil, fmt.Errorf("%w: %s: not a binary blob", ErrSchemaMismatch, field.Name)
+	}
+	depth := treeDepth(limit)
+
+	blob := make([]byte, 0, (size+31)/32*32)
+	for i := uint64(0); i < (size+31)/32; i++ {
+		chunk, ok := p.nodes[gindex<<depth|i]
+		if !ok {
+			return nil, fmt.Errorf("%w: %v (chunk %d)", ErrUnprovenPath, path, i)
+		}
+		blob = append(blob, chunk[:]...)
+	}
+	return blob[:size], nil
+}
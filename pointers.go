@@ -0,0 +1,599 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Schemas sometimes model optional primitive fields as pointers. SSZ has no notion
+// of absent values, so the pointer variants below encode and hash a nil pointer
+// as the zero value and allocate on demand when decoding. The checked variants
+// instead halt encoding with ErrNilPointer if the pointer is nil.
+
+// DefineBoolPointer defines the next field as an optional boolean, encoded and
+// hashed as false if nil, and allocated on demand when decoding.
+func DefineBoolPointer[T ~bool](c *Codec, v **T) {
+	if c.enc != nil {
+		EncodeBoolPointer(c.enc, *v)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeBoolPointer(c.dec, v)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(v, formatBoolPointer(*v))
+		return
+	}
+	HashBoolPointer(c.has, *v)
+}
+
+// DefineCheckedBoolPointer defines the next field as a required boolean held in a
+// pointer, halting encoding with an error if nil.
+func DefineCheckedBoolPointer[T ~bool](c *Codec, v **T) {
+	if c.enc != nil {
+		EncodeCheckedBoolPointer(c.enc, *v)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeCheckedBoolPointer(c.dec, v)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(v, formatBoolPointer(*v))
+		return
+	}
+	HashCheckedBoolPointer(c.has, *v)
+}
+
+// DefineUint8Pointer defines the next field as an optional uint8, encoded and
+// hashed as zero if nil, and allocated on demand when decoding.
+func DefineUint8Pointer[T ~uint8](c *Codec, n **T) {
+	if c.enc != nil {
+		EncodeUint8Pointer(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeUint8Pointer(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUintPointer(*n))
+		return
+	}
+	HashUint8Pointer(c.has, *n)
+}
+
+// DefineCheckedUint8Pointer defines the next field as a required uint8 held in a
+// pointer, halting encoding with an error if nil.
+func DefineCheckedUint8Pointer[T ~uint8](c *Codec, n **T) {
+	if c.enc != nil {
+		EncodeCheckedUint8Pointer(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeCheckedUint8Pointer(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUintPointer(*n))
+		return
+	}
+	HashCheckedUint8Pointer(c.has, *n)
+}
+
+// DefineUint16Pointer defines the next field as an optional uint16, encoded and
+// hashed as zero if nil, and allocated on demand when decoding.
+func DefineUint16Pointer[T ~uint16](c *Codec, n **T) {
+	if c.enc != nil {
+		EncodeUint16Pointer(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeUint16Pointer(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUintPointer(*n))
+		return
+	}
+	HashUint16Pointer(c.has, *n)
+}
+
+// DefineCheckedUint16Pointer defines the next field as a required uint16 held in a
+// pointer, halting encoding with an error if nil.
+func DefineCheckedUint16Pointer[T ~uint16](c *Codec, n **T) {
+	if c.enc != nil {
+		EncodeCheckedUint16Pointer(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeCheckedUint16Pointer(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUintPointer(*n))
+		return
+	}
+	HashCheckedUint16Pointer(c.has, *n)
+}
+
+// DefineUint32Pointer defines the next field as an optional uint32, encoded and
+// hashed as zero if nil, and allocated on demand when decoding.
+func DefineUint32Pointer[T ~uint32](c *Codec, n **T) {
+	if c.enc != nil {
+		EncodeUint32Pointer(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeUint32Pointer(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUintPointer(*n))
+		return
+	}
+	HashUint32Pointer(c.has, *n)
+}
+
+// DefineCheckedUint32Pointer defines the next field as a required uint32 held in a
+// pointer, halting encoding with an error if nil.
+func DefineCheckedUint32Pointer[T ~uint32](c *Codec, n **T) {
+	if c.enc != nil {
+		EncodeCheckedUint32Pointer(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeCheckedUint32Pointer(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUintPointer(*n))
+		return
+	}
+	HashCheckedUint32Pointer(c.has, *n)
+}
+
+// DefineUint64Pointer defines the next field as an optional uint64, encoded and
+// hashed as zero if nil, and allocated on demand when decoding.
+func DefineUint64Pointer[T ~uint64](c *Codec, n **T) {
+	if c.enc != nil {
+		EncodeUint64Pointer(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeUint64Pointer(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUintPointer(*n))
+		return
+	}
+	HashUint64Pointer(c.has, *n)
+}
+
+// DefineCheckedUint64Pointer defines the next field as a required uint64 held in a
+// pointer, halting encoding with an error if nil.
+func DefineCheckedUint64Pointer[T ~uint64](c *Codec, n **T) {
+	if c.enc != nil {
+		EncodeCheckedUint64Pointer(c.enc, *n)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeCheckedUint64Pointer(c.dec, n)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(n, formatUintPointer(*n))
+		return
+	}
+	HashCheckedUint64Pointer(c.has, *n)
+}
+
+// DefineStaticBytesPointer defines the next field as an optional static binary
+// blob, encoded and hashed as zeroes if nil, and allocated on demand when decoding.
+func DefineStaticBytesPointer[T commonBytesLengths](c *Codec, blob **T) {
+	if c.enc != nil {
+		EncodeStaticBytesPointer(c.enc, *blob)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeStaticBytesPointer(c.dec, blob)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(blob, formatStaticBytesPointer(*blob))
+		return
+	}
+	HashStaticBytesPointer(c.has, *blob)
+}
+
+// DefineCheckedStaticBytesPointer defines the next field as a required static
+// binary blob held in a pointer, halting encoding with an error if nil.
+func DefineCheckedStaticBytesPointer[T commonBytesLengths](c *Codec, blob **T) {
+	if c.enc != nil {
+		EncodeCheckedStaticBytesPointer(c.enc, *blob)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeCheckedStaticBytesPointer(c.dec, blob)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(blob, formatStaticBytesPointer(*blob))
+		return
+	}
+	HashCheckedStaticBytesPointer(c.has, *blob)
+}
+
+// EncodeBoolPointer serializes an optional boolean, encoding false if nil.
+func EncodeBoolPointer[T ~bool](enc *Encoder, v *T) {
+	if v == nil {
+		EncodeBool(enc, T(false))
+		return
+	}
+	EncodeBool(enc, *v)
+}
+
+// EncodeCheckedBoolPointer serializes a required boolean held in a pointer.
+//
+// Note, a nil pointer will be serialized as false, but halts encoding with an error.
+func EncodeCheckedBoolPointer[T ~bool](enc *Encoder, v *T) {
+	if v == nil && enc.err == nil {
+		enc.err = fmt.Errorf("%w: boolean", ErrNilPointer)
+	}
+	EncodeBoolPointer(enc, v)
+}
+
+// EncodeUint8Pointer serializes an optional uint8, encoding zero if nil.
+func EncodeUint8Pointer[T ~uint8](enc *Encoder, n *T) {
+	if n == nil {
+		EncodeUint8(enc, T(0))
+		return
+	}
+	EncodeUint8(enc, *n)
+}
+
+// EncodeCheckedUint8Pointer serializes a required uint8 held in a pointer.
+//
+// Note, a nil pointer will be serialized as zero, but halts encoding with an error.
+func EncodeCheckedUint8Pointer[T ~uint8](enc *Encoder, n *T) {
+	if n == nil && enc.err == nil {
+		enc.err = fmt.Errorf("%w: uint8", ErrNilPointer)
+	}
+	EncodeUint8Pointer(enc, n)
+}
+
+// EncodeUint16Pointer serializes an optional uint16, encoding zero if nil.
+func EncodeUint16Pointer[T ~uint16](enc *Encoder, n *T) {
+	if n == nil {
+		EncodeUint16(enc, T(0))
+		return
+	}
+	EncodeUint16(enc, *n)
+}
+
+// EncodeCheckedUint16Pointer serializes a required uint16 held in a pointer.
+//
+// Note, a nil pointer will be serialized as zero, but halts encoding with an error.
+func EncodeCheckedUint16Pointer[T ~uint16](enc *Encoder, n *T) {
+	if n == nil && enc.err == nil {
+		enc.err = fmt.Errorf("%w: uint16", ErrNilPointer)
+	}
+	EncodeUint16Pointer(enc, n)
+}
+
+// EncodeUint32Pointer serializes an optional uint32, encoding zero if nil.
+func EncodeUint32Pointer[T ~uint32](enc *Encoder, n *T) {
+	if n == nil {
+		EncodeUint32(enc, T(0))
+		return
+	}
+	EncodeUint32(enc, *n)
+}
+
+// EncodeCheckedUint32Pointer serializes a required uint32 held in a pointer.
+//
+// Note, a nil pointer will be serialized as zero, but halts encoding with an error.
+func EncodeCheckedUint32Pointer[T ~uint32](enc *Encoder, n *T) {
+	if n == nil && enc.err == nil {
+		enc.err = fmt.Errorf("%w: uint32", ErrNilPointer)
+	}
+	EncodeUint32Pointer(enc, n)
+}
+
+// EncodeUint64Pointer serializes an optional uint64, encoding zero if nil.
+func EncodeUint64Pointer[T ~uint64](enc *Encoder, n *T) {
+	if n == nil {
+		EncodeUint64(enc, T(0))
+		return
+	}
+	EncodeUint64(enc, *n)
+}
+
+// EncodeCheckedUint64Pointer serializes a required uint64 held in a pointer.
+//
+// Note, a nil pointer will be serialized as zero, but halts encoding with an error.
+func EncodeCheckedUint64Pointer[T ~uint64](enc *Encoder, n *T) {
+	if n == nil && enc.err == nil {
+		enc.err = fmt.Errorf("%w: uint64", ErrNilPointer)
+	}
+	EncodeUint64Pointer(enc, n)
+}
+
+// EncodeStaticBytesPointer serializes an optional static binary blob, encoding
+// zeroes if nil.
+func EncodeStaticBytesPointer[T commonBytesLengths](enc *Encoder, blob *T) {
+	if blob == nil {
+		var zero T
+		enc.encodeZeroBytes(uint64(len(zero)))
+		return
+	}
+	EncodeStaticBytes(enc, blob)
+}
+
+// EncodeCheckedStaticBytesPointer serializes a required static binary blob held
+// in a pointer.
+//
+// Note, a nil pointer will be serialized as zeroes, but halts encoding with an
+// error.
+func EncodeCheckedStaticBytesPointer[T commonBytesLengths](enc *Encoder, blob *T) {
+	if blob == nil && enc.err == nil {
+		var zero T
+		enc.err = fmt.Errorf("%w: [%d]byte", ErrNilPointer, len(zero))
+	}
+	EncodeStaticBytesPointer(enc, blob)
+}
+
+// DecodeBoolPointer parses an optional boolean, allocating it if nil.
+func DecodeBoolPointer[T ~bool](dec *Decoder, v **T) {
+	if dec.err != nil {
+		return
+	}
+	if *v == nil {
+		*v = AllocObject[T](dec)
+	}
+	DecodeBool(dec, *v)
+}
+
+// DecodeCheckedBoolPointer parses a required boolean held in a pointer, allocating
+// it if nil.
+func DecodeCheckedBoolPointer[T ~bool](dec *Decoder, v **T) {
+	DecodeBoolPointer(dec, v)
+}
+
+// DecodeUint8Pointer parses an optional uint8, allocating it if nil.
+func DecodeUint8Pointer[T ~uint8](dec *Decoder, n **T) {
+	if dec.err != nil {
+		return
+	}
+	if *n == nil {
+		*n = AllocObject[T](dec)
+	}
+	DecodeUint8(dec, *n)
+}
+
+// DecodeCheckedUint8Pointer parses a required uint8 held in a pointer, allocating
+// it if nil.
+func DecodeCheckedUint8Pointer[T ~uint8](dec *Decoder, n **T) {
+	DecodeUint8Pointer(dec, n)
+}
+
+// DecodeUint16Pointer parses an optional uint16, allocating it if nil.
+func DecodeUint16Pointer[T ~uint16](dec *Decoder, n **T) {
+	if dec.err != nil {
+		return
+	}
+	if *n == nil {
+		*n = AllocObject[T](dec)
+	}
+	DecodeUint16(dec, *n)
+}
+
+// DecodeCheckedUint16Pointer parses a required uint16 held in a pointer, allocating
+// it if nil.
+func DecodeCheckedUint16Pointer[T ~uint16](dec *Decoder, n **T) {
+	DecodeUint16Pointer(dec, n)
+}
+
+// DecodeUint32Pointer parses an optional uint32, allocating it if nil.
+func DecodeUint32Pointer[T ~uint32](dec *Decoder, n **T) {
+	if dec.err != nil {
+		return
+	}
+	if *n == nil {
+		*n = AllocObject[T](dec)
+	}
+	DecodeUint32(dec, *n)
+}
+
+// DecodeCheckedUint32Pointer parses a required uint32 held in a pointer, allocating
+// it if nil.
+func DecodeCheckedUint32Pointer[T ~uint32](dec *Decoder, n **T) {
+	DecodeUint32Pointer(dec, n)
+}
+
+// DecodeUint64Pointer parses an optional uint64, allocating it if nil.
+func DecodeUint64Pointer[T ~uint64](dec *Decoder, n **T) {
+	if dec.err != nil {
+		return
+	}
+	if *n == nil {
+		*n = AllocObject[T](dec)
+	}
+	DecodeUint64(dec, *n)
+}
+
+// DecodeCheckedUint64Pointer parses a required uint64 held in a pointer, allocating
+// it if nil.
+func DecodeCheckedUint64Pointer[T ~uint64](dec *Decoder, n **T) {
+	DecodeUint64Pointer(dec, n)
+}
+
+// DecodeStaticBytesPointer parses an optional static binary blob, allocating it
+// if nil.
+func DecodeStaticBytesPointer[T commonBytesLengths](dec *Decoder, blob **T) {
+	if dec.err != nil {
+		return
+	}
+	if *blob == nil {
+		*blob = AllocObject[T](dec)
+	}
+	DecodeStaticBytes(dec, *blob)
+}
+
+// DecodeCheckedStaticBytesPointer parses a required static binary blob held in
+// a pointer, allocating it if nil.
+func DecodeCheckedStaticBytesPointer[T commonBytesLengths](dec *Decoder, blob **T) {
+	DecodeStaticBytesPointer(dec, blob)
+}
+
+// HashBoolPointer hashes an optional boolean, hashing false if nil.
+func HashBoolPointer[T ~bool](h *Hasher, v *T) {
+	if v == nil {
+		HashBool(h, T(false))
+		return
+	}
+	HashBool(h, *v)
+}
+
+// HashCheckedBoolPointer hashes a required boolean held in a pointer.
+//
+// Note, since hashing cannot fail, a nil pointer is hashed as false.
+func HashCheckedBoolPointer[T ~bool](h *Hasher, v *T) {
+	HashBoolPointer(h, v)
+}
+
+// HashUint8Pointer hashes an optional uint8, hashing zero if nil.
+func HashUint8Pointer[T ~uint8](h *Hasher, n *T) {
+	if n == nil {
+		HashUint8(h, T(0))
+		return
+	}
+	HashUint8(h, *n)
+}
+
+// HashCheckedUint8Pointer hashes a required uint8 held in a pointer.
+//
+// Note, since hashing cannot fail, a nil pointer is hashed as zero.
+func HashCheckedUint8Pointer[T ~uint8](h *Hasher, n *T) {
+	HashUint8Pointer(h, n)
+}
+
+// HashUint16Pointer hashes an optional uint16, hashing zero if nil.
+func HashUint16Pointer[T ~uint16](h *Hasher, n *T) {
+	if n == nil {
+		HashUint16(h, T(0))
+		return
+	}
+	HashUint16(h, *n)
+}
+
+// HashCheckedUint16Pointer hashes a required uint16 held in a pointer.
+//
+// Note, since hashing cannot fail, a nil pointer is hashed as zero.
+func HashCheckedUint16Pointer[T ~uint16](h *Hasher, n *T) {
+	HashUint16Pointer(h, n)
+}
+
+// HashUint32Pointer hashes an optional uint32, hashing zero if nil.
+func HashUint32Pointer[T ~uint32](h *Hasher, n *T) {
+	if n == nil {
+		HashUint32(h, T(0))
+		return
+	}
+	HashUint32(h, *n)
+}
+
+// HashCheckedUint32Pointer hashes a required uint32 held in a pointer.
+//
+// Note, since hashing cannot fail, a nil pointer is hashed as zero.
+func HashCheckedUint32Pointer[T ~uint32](h *Hasher, n *T) {
+	HashUint32Pointer(h, n)
+}
+
+// HashUint64Pointer hashes an optional uint64, hashing zero if nil.
+func HashUint64Pointer[T ~uint64](h *Hasher, n *T) {
+	if n == nil {
+		HashUint64(h, T(0))
+		return
+	}
+	HashUint64(h, *n)
+}
+
+// HashCheckedUint64Pointer hashes a required uint64 held in a pointer.
+//
+// Note, since hashing cannot fail, a nil pointer is hashed as zero.
+func HashCheckedUint64Pointer[T ~uint64](h *Hasher, n *T) {
+	HashUint64Pointer(h, n)
+}
+
+// HashStaticBytesPointer hashes an optional static binary blob, hashing zeroes
+// if nil.
+func HashStaticBytesPointer[T commonBytesLengths](h *Hasher, blob *T) {
+	if blob == nil {
+		blob = new(T)
+	}
+	HashStaticBytes(h, blob)
+}
+
+// HashCheckedStaticBytesPointer hashes a required static binary blob held in a
+// pointer.
+//
+// Note, since hashing cannot fail, a nil pointer is hashed as zeroes.
+func HashCheckedStaticBytesPointer[T commonBytesLengths](h *Hasher, blob *T) {
+	HashStaticBytesPointer(h, blob)
+}
+
+// formatBoolPointer renders an optional boolean for the formatter.
+func formatBoolPointer[T ~bool](v *T) string {
+	if v == nil {
+		return "nil"
+	}
+	return strconv.FormatBool(bool(*v))
+}
+
+// formatUintPointer renders an optional uint for the formatter.
+func formatUintPointer[T ~uint8 | ~uint16 | ~uint32 | ~uint64](n *T) string {
+	if n == nil {
+		return "nil"
+	}
+	return strconv.FormatUint(uint64(*n), 10)
+}
+
+// formatStaticBytesPointer renders an optional static binary blob for the
+// formatter.
+func formatStaticBytesPointer[T commonBytesLengths](blob *T) string {
+	if blob == nil {
+		return "nil"
+	}
+	return formatStaticBytes(arrayBytes(blob))
+}
@@ -0,0 +1,159 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+type testPointersType struct {
+	Flag   *bool
+	Kind   *uint8
+	Port   *uint16
+	Index  *uint32
+	Amount *testGwei
+}
+
+func (t *testPointersType) SizeSSZ() uint32 { return 1 + 1 + 2 + 4 + 8 }
+
+func (t *testPointersType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineBoolPointer(codec, &t.Flag)
+	ssz.DefineUint8Pointer(codec, &t.Kind)
+	ssz.DefineUint16Pointer(codec, &t.Port)
+	ssz.DefineUint32Pointer(codec, &t.Index)
+	ssz.DefineUint64Pointer(codec, &t.Amount)
+}
+
+type testCheckedPointersType struct {
+	Index  *uint32
+	Amount *uint64
+}
+
+func (t *testCheckedPointersType) SizeSSZ() uint32 { return 4 + 8 }
+
+func (t *testCheckedPointersType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineCheckedUint32Pointer(codec, &t.Index)
+	ssz.DefineCheckedUint64Pointer(codec, &t.Amount)
+}
+
+// Tests that primitive pointer fields encode and hash nil as zero, are allocated
+// when decoding, and that the checked variants reject nil pointers.
+func TestPrimitivePointers(t *testing.T) {
+	var (
+		flag   = true
+		kind   = uint8(1)
+		port   = uint16(2)
+		index  = uint32(3)
+		amount = testGwei(4)
+	)
+	set := &testPointersType{Flag: &flag, Kind: &kind, Port: &port, Index: &index, Amount: &amount}
+	want := []byte{1, 1, 2, 0, 3, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0}
+
+	blob := make([]byte, ssz.Size(set))
+	if err := ssz.EncodeToBytes(blob, set); err != nil {
+		t.Fatalf("failed to encode pointers: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Errorf("encoding mismatch: have %x, want %x", blob, want)
+	}
+	for _, stream := range []bool{false, true} {
+		decoded := new(testPointersType)
+		if stream {
+			if err := ssz.DecodeFromStream(bytes.NewReader(blob), decoded, uint32(len(blob))); err != nil {
+				t.Fatalf("failed to stream decode pointers: %v", err)
+			}
+		} else if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+			t.Fatalf("failed to decode pointers: %v", err)
+		}
+		if !reflect.DeepEqual(decoded, set) {
+			t.Errorf("stream %v: decoded mismatch: have %+v, want %+v", stream, decoded, set)
+		}
+	}
+	// Ensure nil pointers are equivalent to zero values
+	unset := new(testPointersType)
+
+	blob = make([]byte, ssz.Size(unset))
+	if err := ssz.EncodeToBytes(blob, unset); err != nil {
+		t.Fatalf("failed to encode nil pointers: %v", err)
+	}
+	if !bytes.Equal(blob, make([]byte, len(want))) {
+		t.Errorf("nil encoding mismatch: have %x, want zeroes", blob)
+	}
+	var zeroes testPointersType
+	if err := ssz.DecodeFromBytes(blob, &zeroes); err != nil {
+		t.Fatalf("failed to decode zero pointers: %v", err)
+	}
+	if zeroes.Amount == nil || *zeroes.Amount != 0 {
+		t.Errorf("zero pointer not allocated: have %v", zeroes.Amount)
+	}
+	if have, want := ssz.HashSequential(unset), ssz.HashSequential(&zeroes); have != want {
+		t.Errorf("nil hash mismatch: have %#x, want %#x", have, want)
+	}
+	// Ensure the checked variants reject nil pointers
+	checked := &testCheckedPointersType{Index: &index}
+	if err := ssz.EncodeToBytes(make([]byte, ssz.Size(checked)), checked); !errors.Is(err, ssz.ErrNilPointer) {
+		t.Errorf("encode error mismatch: have %v, want %v", err, ssz.ErrNilPointer)
+	}
+	if err := ssz.EncodeToStream(io.Discard, checked); !errors.Is(err, ssz.ErrNilPointer) {
+		t.Errorf("stream encode error mismatch: have %v, want %v", err, ssz.ErrNilPointer)
+	}
+	checked.Amount = new(uint64)
+	if err := ssz.EncodeToBytes(make([]byte, ssz.Size(checked)), checked); err != nil {
+		t.Errorf("failed to encode set checked pointers: %v", err)
+	}
+}
+
+// Tests that static binary blobs held in pointers are encoded and hashed as
+// zeroes if nil, and allocated when decoding.
+func TestStaticBytesPointer(t *testing.T) {
+	unset := new(types.CheckpointVariation)
+
+	blob := make([]byte, ssz.Size(unset))
+	if err := ssz.EncodeToBytes(blob, unset); err != nil {
+		t.Fatalf("failed to encode nil pointer: %v", err)
+	}
+	if !bytes.Equal(blob, make([]byte, 40)) {
+		t.Errorf("nil encoding mismatch: have %x, want zeroes", blob)
+	}
+	zero := &types.Checkpoint{}
+	if have, want := ssz.HashSequential(unset), ssz.HashSequential(zero); have != want {
+		t.Errorf("nil hash mismatch: have %#x, want %#x", have, want)
+	}
+	set := &types.CheckpointVariation{Epoch: 1, Root: &types.Hash{0x02}}
+	blob = make([]byte, ssz.Size(set))
+	if err := ssz.EncodeToBytes(blob, set); err != nil {
+		t.Fatalf("failed to encode set pointer: %v", err)
+	}
+	decoded := new(types.CheckpointVariation)
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode set pointer: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, set) {
+		t.Errorf("decoded mismatch: have %+v, want %+v", decoded, set)
+	}
+	// Ensure the checked variant rejects nil pointers
+	if err := ssz.EncodeToStream(io.Discard, new(testCheckedRootType)); !errors.Is(err, ssz.ErrNilPointer) {
+		t.Errorf("checked error mismatch: have %v, want %v", err, ssz.ErrNilPointer)
+	}
+}
+
+// testCheckedRootType is a container with a required static binary blob held in
+// a pointer.
+type testCheckedRootType struct {
+	Root *types.Hash
+}
+
+func (t *testCheckedRootType) SizeSSZ() uint32 { return 32 }
+
+func (t *testCheckedRootType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineCheckedStaticBytesPointer(codec, &t.Root)
+}
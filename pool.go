@@ -0,0 +1,77 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import "io"
+
+// Pool is a facade over the codec that can be shared across goroutines, bundling
+// the settings to use for all operations.
+//
+// The thread-safety model of the package is the following:
+//
+//   - Codec, Encoder, Decoder and Hasher instances are single-goroutine state.
+//     They are only ever handed to DefineSSZ (and the asymmetric Define calls)
+//     for the duration of a single operation and must not be retained or used
+//     from other goroutines.
+//   - Every top level operation (e.g. EncodeToStream, DecodeFromBytes or
+//     HashSequential) takes a private codec state from an internal pool and
+//     returns it when done, so the package level functions, as well as the
+//     methods of a Pool, are safe for concurrent use.
+//   - Objects themselves are not synchronized. Concurrent operations may read
+//     the same object (e.g. encode and hash it at the same time), but must not
+//     decode into an object that is being read or decoded by another one.
+//   - Global registries (e.g. RegisterCustom) are safe for concurrent use, but
+//     are meant to be populated during package initialization.
+//
+// The configs of a Pool (like those passed to the package level functions) are
+// only ever read by the operations, so they may be shared, but must not be
+// modified whilst in use.
+//
+// The zero value is a pool with the default settings, ready to use.
+type Pool struct {
+	// Encoder is an optional config to customize the streaming encoding runs.
+	Encoder *EncoderConfig
+
+	// Decoder is an optional config to customize the decoding runs.
+	Decoder *DecoderConfig
+
+	// Concurrent requests that encoding into byte buffers and hashing should be
+	// done on multiple threads (iff some data segments are large enough to be
+	// worth it). Concurrent decoding is set via the Decoder config.
+	Concurrent bool
+}
+
+// EncodeToStream serializes the object into a data stream with the pool's
+// encoder settings. It is safe for concurrent use.
+func (p *Pool) EncodeToStream(w io.Writer, obj Object) error {
+	return EncodeToStreamWithConfig(w, obj, p.Encoder)
+}
+
+// EncodeToBytes serializes the object into a byte buffer, concurrently if the
+// pool is configured so. It is safe for concurrent use.
+func (p *Pool) EncodeToBytes(buf []byte, obj Object) error {
+	return encodeToBytes(buf, obj, ForkUnknown, p.Concurrent)
+}
+
+// DecodeFromStream parses an object with the given size out of a stream with
+// the pool's decoder settings. It is safe for concurrent use.
+func (p *Pool) DecodeFromStream(r io.Reader, obj Object, size uint32) error {
+	return DecodeFromStreamWithConfig(r, obj, size, p.Decoder)
+}
+
+// DecodeFromBytes parses an object from a byte buffer with the pool's decoder
+// settings. It is safe for concurrent use.
+func (p *Pool) DecodeFromBytes(blob []byte, obj Object) error {
+	return DecodeFromBytesWithConfig(blob, obj, p.Decoder)
+}
+
+// Hash computes the ssz merkle root of the object, concurrently if the pool is
+// configured so. It is safe for concurrent use.
+func (p *Pool) Hash(obj Object) [32]byte {
+	if p.Concurrent {
+		return HashConcurrent(obj)
+	}
+	return HashSequential(obj)
+}
@@ -0,0 +1,84 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that pools can be shared across goroutines, each operation running on
+// its own codec state. Run with the race detector to cover the internal pools
+// and registries.
+func TestPoolConcurrent(t *testing.T) {
+	obj := &testConcurrentType{
+		Blob:       make([]byte, 100000),
+		Items:      make([]uint64, 20000),
+		Validators: make([]*types.Validator, 2000),
+		Nested:     []*testBigListType{{Items: make([]uint64, 10)}, {Items: make([]uint64, 10000)}},
+	}
+	for i := range obj.Validators {
+		obj.Validators[i] = &types.Validator{EffectiveBalance: uint64(i), Slashed: i%2 == 0}
+	}
+	want := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(want, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	root := ssz.HashSequential(obj)
+
+	custom := &testCustomType{Slot: 1, Balance: testWei(*big.NewInt(2))}
+	customRoot := ssz.HashSequential(custom)
+
+	pools := []*ssz.Pool{
+		{},
+		{
+			Encoder:    &ssz.EncoderConfig{FlushInterval: 4096, OnFlush: func(uint64) error { return nil }},
+			Decoder:    &ssz.DecoderConfig{Concurrent: true, RelaxFirstOffset: true, TruncateOversized: true},
+			Concurrent: true,
+		},
+	}
+	for i, pool := range pools {
+		var pend sync.WaitGroup
+		for j := 0; j < 8; j++ {
+			pend.Add(1)
+			go func() {
+				defer pend.Done()
+
+				blob := make([]byte, len(want))
+				if err := pool.EncodeToBytes(blob, obj); err != nil || !bytes.Equal(blob, want) {
+					t.Errorf("pool %d: byte encoding mismatch: %v", i, err)
+				}
+				buf := new(bytes.Buffer)
+				if err := pool.EncodeToStream(buf, obj); err != nil || !bytes.Equal(buf.Bytes(), want) {
+					t.Errorf("pool %d: stream encoding mismatch: %v", i, err)
+				}
+				dec := new(testConcurrentType)
+				if err := pool.DecodeFromBytes(want, dec); err != nil {
+					t.Errorf("pool %d: failed to decode from bytes: %v", i, err)
+				} else if have := pool.Hash(dec); have != root {
+					t.Errorf("pool %d: bytes decoded root mismatch: have %x, want %x", i, have, root)
+				}
+				dec = new(testConcurrentType)
+				if err := pool.DecodeFromStream(bytes.NewReader(want), dec, uint32(len(want))); err != nil {
+					t.Errorf("pool %d: failed to decode from stream: %v", i, err)
+				} else if have := pool.Hash(dec); have != root {
+					t.Errorf("pool %d: stream decoded root mismatch: have %x, want %x", i, have, root)
+				}
+				if have := pool.Hash(obj); have != root {
+					t.Errorf("pool %d: root mismatch: have %x, want %x", i, have, root)
+				}
+				if have := pool.Hash(custom); have != customRoot {
+					t.Errorf("pool %d: custom root mismatch: have %x, want %x", i, have, customRoot)
+				}
+			}()
+		}
+		pend.Wait()
+	}
+}
@@ -0,0 +1,349 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	bitops "math/bits"
+)
+
+// Progressive lists (EIP-7916) are encoded exactly the same way as regular SSZ
+// lists, but they have no maximum capacity and are merkleized into a tree that
+// grows progressively: the first leaf chunk is placed into a subtree of 1, the
+// next 4 into a subtree of 4, the next 16 into a subtree of 16, etc. The root
+// of the subtrees is then chained together as:
+//
+//	root(chunks, n) = hash(root(chunks[n:], 4n), merkleize(chunks[:n], n))
+//
+// Since the encoding is the same, the helpers below only differ from the regular
+// list helpers in the hashing. Existing types are not affected; new containers
+// can adopt the progressive lists field by field.
+
+// progressiveMaxItems is the item limit used when decoding progressive lists.
+// They have no maximum capacity, their size is only bound by the 4 byte offsets.
+const progressiveMaxItems = math.MaxUint64
+
+// DefineProgressiveSliceOfBitsOffset defines the next field as a progressive
+// slice of (packed) bits.
+func DefineProgressiveSliceOfBitsOffset[T ~[]byte](c *Codec, bits *T) {
+	if c.enc != nil {
+		EncodeSliceOfBitsOffset(c.enc, *bits)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeSliceOfBitsOffset(c.dec, bits)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(bits, formatBitlist(*bits, progressiveMaxItems))
+		return
+	}
+	HashProgressiveSliceOfBits(c.has, *bits)
+}
+
+// DefineProgressiveSliceOfBitsContent defines the next field as a progressive
+// slice of (packed) bits.
+func DefineProgressiveSliceOfBitsContent[T ~[]byte](c *Codec, bits *T) {
+	if c.enc != nil {
+		EncodeSliceOfBitsContent(c.enc, *bits)
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfBitsContent(c.dec, bits, progressiveMaxItems)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineProgressiveSliceOfUint64sOffset defines the next field as a progressive
+// slice of uint64s.
+func DefineProgressiveSliceOfUint64sOffset[T ~uint64](c *Codec, ns *[]T) {
+	if c.enc != nil {
+		EncodeSliceOfUint64sOffset(c.enc, *ns)
+		if !c.hashing() {
+			return
+		}
+	}
+	if c.dec != nil {
+		DecodeSliceOfUint64sOffset(c.dec, ns)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.line(ns, fmt.Sprintf("%s (%d items)", formatUint64s(*ns), len(*ns)))
+		return
+	}
+	HashProgressiveSliceOfUint64s(c.has, *ns)
+}
+
+// DefineProgressiveSliceOfUint64sContent defines the next field as a progressive
+// slice of uint64s.
+func DefineProgressiveSliceOfUint64sContent[T ~uint64](c *Codec, ns *[]T) {
+	if c.enc != nil {
+		EncodeSliceOfUint64sContent(c.enc, *ns)
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfUint64sContent(c.dec, ns, progressiveMaxItems)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineProgressiveSliceOfStaticObjectsOffset defines the next field as a
+// progressive slice of static ssz objects.
+func DefineProgressiveSliceOfStaticObjectsOffset[T newableStaticObject[U], U any](c *Codec, objects *[]T) {
+	if c.enc != nil {
+		EncodeSliceOfStaticObjectsOffset(c.enc, *objects)
+		if c.hashing() {
+			c.has.reserveChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfStaticObjectsOffset(c.dec, objects)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.objects(objects, len(*objects), formatLimit(progressiveMaxItems), func(i int) Object { return (*objects)[i] })
+		return
+	}
+	HashProgressiveSliceOfStaticObjects(c.has, *objects)
+}
+
+// DefineProgressiveSliceOfStaticObjectsContent defines the next field as a
+// progressive slice of static ssz objects.
+func DefineProgressiveSliceOfStaticObjectsContent[T newableStaticObject[U], U any](c *Codec, objects *[]T) {
+	if c.enc != nil {
+		if c.hashing() {
+			c.has.descendProgressiveRoots()
+		}
+		EncodeSliceOfStaticObjectsContent(c.enc, *objects)
+		if c.hashing() {
+			c.has.ascendProgressiveRoots(len(*objects))
+			c.has.fillChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfStaticObjectsContent(c.dec, objects, progressiveMaxItems)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// DefineProgressiveSliceOfDynamicObjectsOffset defines the next field as a
+// progressive slice of dynamic ssz objects.
+func DefineProgressiveSliceOfDynamicObjectsOffset[T newableDynamicObject[U], U any](c *Codec, objects *[]T) {
+	if c.enc != nil {
+		EncodeSliceOfDynamicObjectsOffset(c.enc, *objects)
+		if c.hashing() {
+			c.has.reserveChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfDynamicObjectsOffset(c.dec, objects)
+		return
+	}
+	if c.fmt != nil {
+		c.fmt.objects(objects, len(*objects), formatLimit(progressiveMaxItems), func(i int) Object { return (*objects)[i] })
+		return
+	}
+	HashProgressiveSliceOfDynamicObjects(c.has, *objects)
+}
+
+// DefineProgressiveSliceOfDynamicObjectsContent defines the next field as a
+// progressive slice of dynamic ssz objects.
+func DefineProgressiveSliceOfDynamicObjectsContent[T newableDynamicObject[U], U any](c *Codec, objects *[]T) {
+	if c.enc != nil {
+		if c.hashing() {
+			c.has.descendProgressiveRoots()
+		}
+		EncodeSliceOfDynamicObjectsContent(c.enc, *objects)
+		if c.hashing() {
+			c.has.ascendProgressiveRoots(len(*objects))
+			c.has.fillChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeSliceOfDynamicObjectsContent(c.dec, objects, progressiveMaxItems)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// HashProgressiveSliceOfBits hashes a progressive slice of (packed) bits.
+func HashProgressiveSliceOfBits[T ~[]byte](h *Hasher, bits T) {
+	// Parse the bit-list into a hashable representation. Contrary to regular
+	// bitlists, trailing zero bytes cannot be trimmed, since the number of leaf
+	// chunks defines the shape of the progressive tree.
+	var (
+		msb  = uint8(bitops.Len8(bits[len(bits)-1])) - 1
+		size = uint64((len(bits)-1)<<3 + int(msb))
+	)
+	h.bitbuf = append(h.bitbuf[:0], bits...)
+	h.bitbuf[len(h.bitbuf)-1] &^= uint8(1 << msb)
+	h.bitbuf = h.bitbuf[:(size+7)>>3]
+
+	// Merkleize the content into progressively larger subtrees
+	start := h.descendProgressiveLayer()
+	for leaves, blob := 1, h.bitbuf; len(blob) > 0; leaves <<= 2 {
+		chunk := min(len(blob), leaves<<5)
+
+		h.descendLayer()
+		h.insertBlobChunks(blob[:chunk])
+		h.ascendProgressiveSubtree(leaves)
+
+		blob = blob[chunk:]
+	}
+	h.ascendProgressiveLayer(start, size)
+}
+
+// HashProgressiveSliceOfUint64s hashes a progressive slice of uint64s.
+func HashProgressiveSliceOfUint64s[T ~uint64](h *Hasher, ns []T) {
+	start := h.descendProgressiveLayer()
+
+	var buffer [32]byte
+	for leaves, nums := 1, ns; len(nums) > 0; leaves <<= 2 {
+		h.descendLayer()
+		for i := 0; i < leaves && len(nums) > 0; i++ {
+			buffer = [32]byte{}
+			for j := 0; j < 4 && j < len(nums); j++ {
+				binary.LittleEndian.PutUint64(buffer[j<<3:], uint64(nums[j]))
+			}
+			h.insertChunk(buffer, 0)
+			nums = nums[min(4, len(nums)):]
+		}
+		h.ascendProgressiveSubtree(leaves)
+	}
+	h.ascendProgressiveLayer(start, uint64(len(ns)))
+}
+
+// HashProgressiveSliceOfStaticObjects hashes a progressive slice of static ssz
+// objects.
+func HashProgressiveSliceOfStaticObjects[T StaticObject](h *Hasher, objects []T) {
+	start := h.descendProgressiveLayer()
+	for leaves, items := 1, objects; len(items) > 0; leaves <<= 2 {
+		subtree := items[:min(leaves, len(items))]
+
+		h.descendLayer()
+		for _, obj := range subtree {
+			h.descendLayer()
+			obj.DefineSSZ(h.codec)
+			h.ascendLayer(0)
+		}
+		h.ascendProgressiveSubtree(leaves)
+
+		items = items[len(subtree):]
+	}
+	h.ascendProgressiveLayer(start, uint64(len(objects)))
+}
+
+// HashProgressiveSliceOfDynamicObjects hashes a progressive slice of dynamic ssz
+// objects.
+func HashProgressiveSliceOfDynamicObjects[T DynamicObject](h *Hasher, objects []T) {
+	start := h.descendProgressiveLayer()
+	for leaves, items := 1, objects; len(items) > 0; leaves <<= 2 {
+		subtree := items[:min(leaves, len(items))]
+
+		h.descendLayer()
+		for _, obj := range subtree {
+			h.descendLayer()
+			obj.DefineSSZ(h.codec)
+			h.ascendLayer(0)
+		}
+		h.ascendProgressiveSubtree(leaves)
+
+		items = items[len(subtree):]
+	}
+	h.ascendProgressiveLayer(start, uint64(len(objects)))
+}
+
+// descendProgressiveLayer starts hashing a progressive list, returning the marker
+// to pass to ascendProgressiveLayer for assembling the subtrees.
+func (h *Hasher) descendProgressiveLayer() int {
+	h.descendMixinLayer()
+	if h.tree != nil {
+		return len(h.tree.subtrees)
+	}
+	return len(h.subtrees)
+}
+
+// ascendProgressiveSubtree terminates a hashing layer containing the leaves of
+// one of the progressive subtrees, stashing its root away for the final assembly
+// instead of collapsing it with the neighbouring subtrees.
+func (h *Hasher) ascendProgressiveSubtree(leaves int) {
+	h.ascendLayer(uint64(leaves))
+	if h.tree != nil {
+		h.tree.stash()
+		return
+	}
+	h.subtrees = append(h.subtrees, h.chunks[len(h.chunks)-1])
+	h.chunks = h.chunks[:len(h.chunks)-1]
+	h.groups = h.groups[:len(h.groups)-1]
+}
+
+// ascendProgressiveLayer chains together the stashed subtree roots of the list
+// into the progressive tree, mixes in the length and ascends out of the list.
+func (h *Hasher) ascendProgressiveLayer(start int, size uint64) {
+	if h.tree != nil {
+		h.tree.chain(start, size)
+		h.ascendMixinLayer(size, 0)
+		return
+	}
+	if size > 0 {
+		var buffer [64]byte // hash(rest, subtree), rest starting out empty
+		for i := len(h.subtrees) - 1; i >= start; i-- {
+			copy(buffer[32:], h.subtrees[i][:])
+			root := sha256.Sum256(buffer[:])
+			copy(buffer[:32], root[:])
+		}
+		h.insertChunk([32]byte(buffer[:32]), 0)
+	}
+	h.subtrees = h.subtrees[:start]
+	h.ascendMixinLayer(size, 0)
+}
+
+// descendProgressiveRoots starts collecting the roots of the items of a progressive
+// list, hashed one by one by an outside driver (i.e. the encoder, when encoding
+// and hashing in one pass) that cannot split them up into the subtrees itself.
+func (h *Hasher) descendProgressiveRoots() {
+	h.descendLayer()
+	h.holdLayer()
+}
+
+// ascendProgressiveRoots stops collecting the item roots of a progressive list,
+// merkleizing them into the progressive tree and mixing in the item count.
+func (h *Hasher) ascendProgressiveRoots(items int) {
+	chunks := len(h.chunks)
+	roots := append([][32]byte(nil), h.chunks[chunks-items:]...)
+
+	h.chunks = h.chunks[:chunks-items]
+	if items > 0 {
+		h.groups = h.groups[:len(h.groups)-1]
+	}
+	h.holds = h.holds[:len(h.holds)-1]
+	h.layer--
+
+	start := h.descendProgressiveLayer()
+	for leaves := 1; len(roots) > 0; leaves <<= 2 {
+		subtree := roots[:min(leaves, len(roots))]
+
+		h.descendLayer()
+		for _, root := range subtree {
+			h.insertChunk(root, 0)
+		}
+		h.ascendProgressiveSubtree(leaves)
+
+		roots = roots[len(subtree):]
+	}
+	h.ascendProgressiveLayer(start, uint64(items))
+}
@@ -0,0 +1,526 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"crypto/sha256"
+	"fmt"
+	bitops "math/bits"
+	"slices"
+)
+
+// Limits of the proof containers, used when encoding and decoding them.
+const (
+	MaxProofDepth      = 64      // Maximum depth of a proven node (generalized indices are 64 bit)
+	MaxMultiproofNodes = 1 << 24 // Maximum number of proven or helper nodes in a multiproof
+)
+
+// Proof is a merkle proof of a single node of an ssz object's hash tree, with
+// the branch holding the sibling nodes from the proven node up to the root. It
+// is an ssz container itself, so it can be transported in a canonical format:
+//
+//	class Proof(Container):
+//	    index: uint64
+//	    leaf: Bytes32
+//	    branch: List[Bytes32, MaxProofDepth]
+type Proof struct {
+	Index  uint64     // Generalized index of the proven node
+	Leaf   [32]byte   // Proven node
+	Branch [][32]byte // Sibling nodes from the proven one up to the root
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (p *Proof) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(8 + 32 + 4)
+	if fixed {
+		return size
+	}
+	size += SizeSliceOfStaticBytes(p.Branch)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (p *Proof) DefineSSZ(codec *Codec) {
+	// Define the static data (fields and dynamic offsets)
+	DefineUint64(codec, &p.Index)                                   // Field  (0) -  Index -  8 bytes
+	DefineStaticBytes(codec, &p.Leaf)                               // Field  (1) -   Leaf - 32 bytes
+	DefineSliceOfStaticBytesOffset(codec, &p.Branch, MaxProofDepth) // Offset (2) - Branch -  4 bytes
+
+	// Define the dynamic data (fields)
+	DefineSliceOfStaticBytesContent(codec, &p.Branch, MaxProofDepth) // Field  (2) - Branch - ? bytes
+}
+
+// VerifyProof checks that a single node proof is well formed and that it hashes
+// up to the given root.
+func VerifyProof(root [32]byte, proof *Proof) error {
+	return VerifyMultiproof(root, &Multiproof{
+		Indices: []uint64{proof.Index},
+		Leaves:  [][32]byte{proof.Leaf},
+		Hashes:  proof.Branch,
+	})
+}
+
+// ConcatGeneralizedIndices composes the generalized indices of nodes within nested
+// hash trees, each index being relative to the root of the node addressed by the
+// previous one, into a single index relative to the outermost root (e.g. a block
+// root within a historical batch within a beacon state). It is the equivalent of
+// concat_generalized_indices from the consensus specs.
+func ConcatGeneralizedIndices(indices ...uint64) (uint64, error) {
+	gindex := uint64(1)
+	for _, index := range indices {
+		if index == 0 {
+			return 0, fmt.Errorf("%w: zero index", ErrInvalidGeneralizedIndex)
+		}
+		depth := bitops.Len64(index) - 1
+		if bitops.Len64(gindex)+depth > 64 {
+			return 0, fmt.Errorf("%w: composed index overflows 64 bits", ErrInvalidGeneralizedIndex)
+		}
+		gindex = gindex<<depth | (index - 1<<depth)
+	}
+	return gindex, nil
+}
+
+// ConcatProofs composes the proof of a node within a nested hash tree (e.g. a block
+// root within a historical batch) with the proof of that tree's root within an
+// outer one (e.g. the batch's summary root within a beacon state), into a single
+// proof of the node against the outer root.
+//
+// The inner proof is verified to hash up to the proven node of the outer one, so
+// the composite is valid if and only if the outer proof is.
+func ConcatProofs(outer *Proof, inner *Proof) (*Proof, error) {
+	if err := VerifyProof(outer.Leaf, inner); err != nil {
+		return nil, err
+	}
+	index, err := ConcatGeneralizedIndices(outer.Index, inner.Index)
+	if err != nil {
+		return nil, err
+	}
+	branch := make([][32]byte, 0, len(inner.Branch)+len(outer.Branch))
+	branch = append(branch, inner.Branch...)
+	branch = append(branch, outer.Branch...)
+
+	return &Proof{Index: index, Leaf: inner.Leaf, Branch: branch}, nil
+}
+
+// Multiproof is a merkle proof of multiple nodes of an ssz object's hash tree,
+// addressed by generalized indices (1 being the root, 2i and 2i+1 the children
+// of node i). The helper hashes are the sibling nodes needed to reconstruct the
+// root, ordered by descending generalized index as in the consensus specs. It
+// is an ssz container itself, so it can be transported in a canonical format:
+//
+//	class Multiproof(Container):
+//	    indices: List[uint64, MaxMultiproofNodes]
+//	    leaves: List[Bytes32, MaxMultiproofNodes]
+//	    hashes: List[Bytes32, MaxMultiproofNodes]
+type Multiproof struct {
+	Indices []uint64   // Generalized indices of the proven nodes
+	Leaves  [][32]byte // Proven nodes, one for each index
+	Hashes  [][32]byte // Helper nodes needed to reconstruct the root
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (p *Multiproof) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(4 + 4 + 4)
+	if fixed {
+		return size
+	}
+	size += SizeSliceOfUint64s(p.Indices)
+	size += SizeSliceOfStaticBytes(p.Leaves)
+	size += SizeSliceOfStaticBytes(p.Hashes)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (p *Multiproof) DefineSSZ(codec *Codec) {
+	// Define the static data (fields and dynamic offsets)
+	DefineSliceOfUint64sOffset(codec, &p.Indices, MaxMultiproofNodes)    // Offset (0) - Indices - 4 bytes
+	DefineSliceOfStaticBytesOffset(codec, &p.Leaves, MaxMultiproofNodes) // Offset (1) -  Leaves - 4 bytes
+	DefineSliceOfStaticBytesOffset(codec, &p.Hashes, MaxMultiproofNodes) // Offset (2) -  Hashes - 4 bytes
+
+	// Define the dynamic data (fields)
+	DefineSliceOfUint64sContent(codec, &p.Indices, MaxMultiproofNodes)    // Field  (0) - Indices - ? bytes
+	DefineSliceOfStaticBytesContent(codec, &p.Leaves, MaxMultiproofNodes) // Field  (1) -  Leaves - ? bytes
+	DefineSliceOfStaticBytesContent(codec, &p.Hashes, MaxMultiproofNodes) // Field  (2) -  Hashes - ? bytes
+}
+
+// VerifyMultiproof checks that a multiproof is well formed and that it hashes
+// up to the given root.
+func VerifyMultiproof(root [32]byte, proof *Multiproof) error {
+	nodes, err := proof.nodes()
+	if err != nil {
+		return err
+	}
+	if nodes[1] != root {
+		return fmt.Errorf("%w: root mismatch: have %#x, want %#x", ErrInvalidProof, nodes[1], root)
+	}
+	return nil
+}
+
+// VerifyMultiproofBatch checks that a batch of multiproofs (e.g. hundreds of
+// single leaf proofs of validator balances) are well formed and that they all
+// hash up to the given root. The proofs are merged before verification, so any
+// branches they share are only hashed once.
+func VerifyMultiproofBatch(root [32]byte, proofs []*Multiproof) error {
+	nodes, err := mergeMultiproofs(proofs)
+	if err != nil {
+		return err
+	}
+	if nodes[1] != root {
+		return fmt.Errorf("%w: root mismatch: have %#x, want %#x", ErrInvalidProof, nodes[1], root)
+	}
+	return nil
+}
+
+// nodes reconstructs all the nodes of the hash tree that can be derived from the
+// multiproof, keyed by generalized index. The root is always present if the
+// returned error is nil.
+func (p *Multiproof) nodes() (map[uint64][32]byte, error) {
+	return mergeMultiproofs([]*Multiproof{p})
+}
+
+// helpers checks that a multiproof is well formed and returns the generalized
+// indices of its helper hashes.
+func (p *Multiproof) helpers() ([]uint64, error) {
+	if len(p.Indices) == 0 {
+		return nil, fmt.Errorf("%w: no proven nodes", ErrInvalidProof)
+	}
+	if len(p.Indices) != len(p.Leaves) {
+		return nil, fmt.Errorf("%w: have %d leaves for %d indices", ErrInvalidProof, len(p.Leaves), len(p.Indices))
+	}
+	// Reject invalid indices and overlapping ones, since a proven node being the
+	// ancestor of another would make the latter unverified
+	proven := make(map[uint64]struct{}, len(p.Indices))
+	for _, index := range p.Indices {
+		if index == 0 {
+			return nil, fmt.Errorf("%w: invalid generalized index 0", ErrInvalidProof)
+		}
+		if _, ok := proven[index]; ok {
+			return nil, fmt.Errorf("%w: duplicate generalized index %d", ErrInvalidProof, index)
+		}
+		proven[index] = struct{}{}
+	}
+	for _, index := range p.Indices {
+		for parent := index >> 1; parent > 0; parent >>= 1 {
+			if _, ok := proven[parent]; ok {
+				return nil, fmt.Errorf("%w: generalized index %d contains %d", ErrInvalidProof, parent, index)
+			}
+		}
+	}
+	helpers := multiproofHelperIndices(p.Indices)
+	if len(helpers) != len(p.Hashes) {
+		return nil, fmt.Errorf("%w: have %d helper hashes, want %d", ErrInvalidProof, len(p.Hashes), len(helpers))
+	}
+	return helpers, nil
+}
+
+// mergeMultiproofs reconstructs all the nodes of the hash tree that can be derived
+// from a batch of multiproofs, keyed by generalized index. Nodes provided by one
+// proof and derived from another are cross-checked, so the root is only present
+// (with a nil error) if every proof hashes up to it.
+func mergeMultiproofs(proofs []*Multiproof) (map[uint64][32]byte, error) {
+	if len(proofs) == 0 {
+		return nil, fmt.Errorf("%w: no proofs", ErrInvalidProof)
+	}
+	// Insert all the known nodes, rejecting conflicting ones
+	nodes := make(map[uint64][32]byte)
+	insert := func(index uint64, node [32]byte) error {
+		if old, ok := nodes[index]; ok && old != node {
+			return fmt.Errorf("%w: conflicting nodes at generalized index %d", ErrInvalidProof, index)
+		}
+		nodes[index] = node
+		return nil
+	}
+	for _, proof := range proofs {
+		helpers, err := proof.helpers()
+		if err != nil {
+			return nil, err
+		}
+		for i, index := range proof.Indices {
+			if err := insert(index, proof.Leaves[i]); err != nil {
+				return nil, err
+			}
+		}
+		for i, index := range helpers {
+			if err := insert(index, proof.Hashes[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	keys := make([]uint64, 0, len(nodes))
+	for index := range nodes {
+		keys = append(keys, index)
+	}
+	slices.Sort(keys)
+	slices.Reverse(keys)
+
+	// Hash the nodes upwards until reaching the root. Any parent that was already
+	// provided is checked against the derived one, but not re-hashed.
+	var (
+		buf     [64]byte
+		derived = make(map[uint64]struct{})
+	)
+	for i := 0; i < len(keys); i++ {
+		index := keys[i]
+		if index == 1 {
+			continue
+		}
+		if _, ok := derived[index>>1]; ok {
+			continue
+		}
+		sibling, ok := nodes[index^1]
+		if !ok {
+			continue
+		}
+		node := nodes[index]
+		if index&1 == 0 {
+			copy(buf[:32], node[:])
+			copy(buf[32:], sibling[:])
+		} else {
+			copy(buf[:32], sibling[:])
+			copy(buf[32:], node[:])
+		}
+		parent := sha256.Sum256(buf[:])
+		derived[index>>1] = struct{}{}
+
+		if old, ok := nodes[index>>1]; ok {
+			if old != parent {
+				return nil, fmt.Errorf("%w: node mismatch at generalized index %d", ErrInvalidProof, index>>1)
+			}
+			continue
+		}
+		nodes[index>>1] = parent
+		keys = append(keys, index>>1)
+	}
+	if _, ok := nodes[1]; !ok {
+		return nil, fmt.Errorf("%w: root not reachable", ErrInvalidProof)
+	}
+	return nodes, nil
+}
+
+// ProveFromNodes creates a multiproof of the given nodes of a hash tree, looking up
+// the proven and helper nodes by their generalized indices via the callback. It
+// can be used to serve proofs from a tree persisted elsewhere (e.g. exported via
+// HashTree and TreeNode.Walk into a database) without re-merkleizing the object.
+func ProveFromNodes(indices []uint64, lookup func(gindex uint64) ([32]byte, error)) (*Multiproof, error) {
+	proof := &Multiproof{
+		Indices: slices.Clone(indices),
+		Leaves:  make([][32]byte, len(indices)),
+	}
+	for i, index := range indices {
+		node, err := lookup(index)
+		if err != nil {
+			return nil, err
+		}
+		proof.Leaves[i] = node
+	}
+	for _, index := range multiproofHelperIndices(indices) {
+		node, err := lookup(index)
+		if err != nil {
+			return nil, err
+		}
+		proof.Hashes = append(proof.Hashes, node)
+	}
+	return proof, nil
+}
+
+// multiproofHelperIndices returns the generalized indices of the sibling nodes
+// needed to prove a set of nodes, in descending order.
+func multiproofHelperIndices(indices []uint64) []uint64 {
+	var (
+		paths   = make(map[uint64]struct{})
+		helpers = make(map[uint64]struct{})
+	)
+	for _, index := range indices {
+		for ; index > 1; index >>= 1 {
+			paths[index] = struct{}{}
+			helpers[index^1] = struct{}{}
+		}
+	}
+	result := make([]uint64, 0, len(helpers))
+	for index := range helpers {
+		if _, ok := paths[index]; !ok {
+			result = append(result, index)
+		}
+	}
+	slices.Sort(result)
+	slices.Reverse(result)
+	return result
+}
+
+// GeneralizedIndex returns the generalized index of a node within the hash tree
+// of a container of the schema. The path is a list of field names (string) for
+// containers, item indices (int) for vectors and lists, and "__len__" for the
+// length mixin of lists, as in the consensus specs.
+//
+// Items of uint64 vectors and lists are packed four to a chunk, so they share
+// the generalized index of their chunk.
+func (s *Schema) GeneralizedIndex(path ...any) (uint64, error) {
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+	gindex, _, _, err := s.resolve(path)
+	return gindex, err
+}
+
+// resolve walks a path down the hash tree of a container of the schema, returning
+// the generalized index of the node, the schema of the field stored there and
+// the byte offset of the field within the chunk (non-zero only for the items of
+// uint64 lists).
+func (s *Schema) resolve(path []any) (uint64, SchemaField, int, error) {
+	var (
+		gindex = uint64(1)
+		field  = s.field()
+		offset int
+	)
+	for _, elem := range path {
+		limit, mixin := field.treeShape()
+		offset = 0
+
+		var chunk uint64
+		switch elem := elem.(type) {
+		case string:
+			if elem == "__len__" {
+				if !mixin {
+					return 0, field, 0, fmt.Errorf("%w: %s: not a list", ErrSchemaMismatch, field.Name)
+				}
+				gindex, field = gindex<<1|1, SchemaField{Name: field.Name, Kind: FieldUint64}
+				continue
+			}
+			if field.Kind != FieldStaticObject && field.Kind != FieldDynamicObject {
+				return 0, field, 0, fmt.Errorf("%w: %s: not a container", ErrSchemaMismatch, field.Name)
+			}
+			index := slices.IndexFunc(field.Schema.Fields, func(f SchemaField) bool { return f.Name == elem })
+			if index < 0 {
+				return 0, field, 0, fmt.Errorf("%w: %s: unknown field %q", ErrSchemaMismatch, field.Name, elem)
+			}
+			chunk, field = uint64(index), field.Schema.Fields[index]
+
+		case int:
+			items := field.Size
+			if mixin {
+				items = field.MaxItems
+			}
+			if elem < 0 || uint64(elem) >= items {
+				return 0, field, 0, fmt.Errorf("%w: %s: index %d out of bounds", ErrSchemaMismatch, field.Name, elem)
+			}
+			switch field.Kind {
+			case FieldArrayOfUint64s, FieldSliceOfUint64s:
+				chunk, offset = uint64(elem)/4, (elem%4)*8
+				field = SchemaField{Name: field.Name, Kind: FieldUint64}
+			case FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes, FieldSliceOfStaticBytes:
+				chunk = uint64(elem)
+				field = SchemaField{Name: field.Name, Kind: FieldStaticBytes, Size: field.ItemSize}
+			case FieldSliceOfDynamicBytes:
+				chunk = uint64(elem)
+				field = SchemaField{Name: field.Name, Kind: FieldDynamicBytes, MaxSize: field.MaxSize}
+			case FieldSliceOfStaticObjects:
+				chunk = uint64(elem)
+				field = SchemaField{Name: field.Name, Kind: FieldStaticObject, Schema: field.Schema}
+			case FieldSliceOfDynamicObjects:
+				chunk = uint64(elem)
+				field = SchemaField{Name: field.Name, Kind: FieldDynamicObject, Schema: field.Schema}
+			default:
+				return 0, field, 0, fmt.Errorf("%w: %s: not a vector or list", ErrSchemaMismatch, field.Name)
+			}
+		default:
+			return 0, field, 0, fmt.Errorf("%w: invalid path element %v", ErrSchemaMismatch, elem)
+		}
+		// Descend into the data subtree of lists and then to the chunk
+		if mixin {
+			gindex <<= 1
+		}
+		depth := treeDepth(limit)
+		if bitops.Len64(gindex)+depth > 64 {
+			return 0, field, 0, fmt.Errorf("%w: generalized index overflow", ErrSchemaMismatch)
+		}
+		gindex = gindex<<depth | chunk
+	}
+	return gindex, field, offset, nil
+}
+
+// treeShape returns the number of leaf chunks the hash tree of a field has room
+// for (1 for basic types) and whether a length is mixed into the tree's root.
+func (f SchemaField) treeShape() (uint64, bool) {
+	switch f.Kind {
+	case FieldStaticBytes, FieldCheckedStaticBytes:
+		return ChunkCount(f.Size), false
+	case FieldDynamicBytes:
+		return ChunkCount(f.MaxSize), true
+	case FieldArrayOfBits:
+		return (f.Size + 255) / 256, false
+	case FieldSliceOfBits:
+		return (f.MaxItems + 255) / 256, true
+	case FieldArrayOfUint64s:
+		return (f.Size + 3) / 4, false
+	case FieldSliceOfUint64s:
+		return (f.MaxItems + 3) / 4, true
+	case FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes:
+		return f.Size, false
+	case FieldSliceOfStaticBytes, FieldSliceOfDynamicBytes, FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects:
+		return f.MaxItems, true
+	case FieldStaticObject, FieldDynamicObject:
+		return uint64(len(f.Schema.Fields)), false
+	default:
+		return 1, false
+	}
+}
+
+// packed returns whether the hash tree of a field is built from the raw content
+// of the field (binary blobs, bitfields, uint64 lists), as opposed to the roots
+// of its items or fields.
+func (f SchemaField) packed() bool {
+	switch f.Kind {
+	case FieldStaticBytes, FieldCheckedStaticBytes, FieldDynamicBytes, FieldArrayOfBits,
+		FieldSliceOfBits, FieldArrayOfUint64s, FieldSliceOfUint64s:
+		return true
+	default:
+		return false
+	}
+}
+
+// treeDepth returns the depth of a balanced binary tree with room for the given
+// number of leaf chunks.
+func treeDepth(chunks uint64) int {
+	if chunks <= 1 {
+		return 0
+	}
+	return bitops.Len64(chunks - 1)
+}
+
+// treeNode computes a node of a balanced binary tree of the given depth built
+// from the chunks (padded with zero chunks). The generalized index is relative
+// to the root of the tree and must not be deeper than its leaves.
+func treeNode(chunks [][32]byte, depth int, gindex uint64) [32]byte {
+	level := bitops.Len64(gindex) - 1
+
+	first := (gindex - 1<<level) << (depth - level)
+	if first >= uint64(len(chunks)) {
+		return hasherZeroCache[depth-level]
+	}
+	if level == depth {
+		return chunks[first]
+	}
+	var buf [64]byte
+
+	left := treeNode(chunks, depth, gindex<<1)
+	right := treeNode(chunks, depth, gindex<<1|1)
+	copy(buf[:32], left[:])
+	copy(buf[32:], right[:])
+
+	return sha256.Sum256(buf[:])
+}
+
+// packChunks splits a binary blob into 32 byte chunks, zero padding the last.
+func packChunks(blob []byte) [][32]byte {
+	chunks := make([][32]byte, (len(blob)+31)/32)
+	for i := range chunks {
+		copy(chunks[i][:], blob[i*32:])
+	}
+	return chunks
+}
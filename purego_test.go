@@ -0,0 +1,11 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build purego
+
+package ssz_test
+
+func init() {
+	puregoBuild = true
+}
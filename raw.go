@@ -0,0 +1,83 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"bytes"
+	"io"
+)
+
+// Some applications need to forward a sub-object exactly as it was received
+// (e.g. relaying an execution payload to the execution layer). Re-encoding it
+// is wasteful, and risks byte differences if the original was not canonical.
+// The raw content variant below captures the object's encoding while decoding
+// it, without any extra pass over the input.
+
+// DefineDynamicObjectRawContent defines the next field as a dynamic ssz object,
+// additionally retaining the exact bytes it was decoded from in raw. The field's
+// offset is defined via DefineDynamicObjectOffset as usual.
+//
+// Encoding always serializes the object itself, raw is ignored.
+func DefineDynamicObjectRawContent[T newableDynamicObject[U], U any](c *Codec, obj *T, raw *[]byte) {
+	if c.enc != nil {
+		EncodeDynamicObjectRawContent(c.enc, *obj)
+		if c.hashing() {
+			c.has.fillChunk()
+		}
+		return
+	}
+	if c.dec != nil {
+		DecodeDynamicObjectRawContent(c.dec, obj, raw)
+		return
+	}
+	// No hashing, done at the offset position
+}
+
+// EncodeDynamicObjectRawContent is the lazy data writer for EncodeDynamicObjectOffset,
+// serializing the object itself, not any raw bytes captured when decoding it.
+func EncodeDynamicObjectRawContent(enc *Encoder, obj DynamicObject) {
+	EncodeDynamicObjectContent(enc, obj)
+}
+
+// DecodeDynamicObjectRawContent is the lazy data reader of DecodeDynamicObjectOffset,
+// additionally retaining the exact bytes of the object in raw. The raw slice is
+// reused if it has enough capacity, and it never aliases the input buffer.
+func DecodeDynamicObjectRawContent[T newableDynamicObject[U], U any](dec *Decoder, obj *T, raw *[]byte) {
+	if dec.err != nil {
+		return
+	}
+	size := dec.peekSize()
+	if dec.err != nil {
+		return
+	}
+	// Allocate the capture buffer upfront, charging it against the budget
+	if uint32(cap(*raw)) < size {
+		*raw = dec.allocBytes(uint64(size))
+		if dec.err != nil {
+			return
+		}
+	}
+	*raw = (*raw)[:0]
+
+	// In streaming mode, the bytes are gone once consumed, so tee them into the
+	// capture buffer while decoding. In buffered mode, they can be copied after.
+	if dec.inReader != nil {
+		capture := bytes.NewBuffer(*raw)
+
+		reader := dec.inReader
+		dec.inReader = io.TeeReader(reader, capture)
+		DecodeDynamicObjectContent(dec, obj)
+		dec.inReader = reader
+
+		*raw = capture.Bytes()
+	} else {
+		input := dec.inBuffer
+		DecodeDynamicObjectContent(dec, obj)
+
+		if dec.err == nil {
+			*raw = append(*raw, input[:size]...)
+		}
+	}
+}
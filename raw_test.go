@@ -0,0 +1,96 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// testRawPayloadType is a container retaining the raw encoding of its dynamic
+// payload, to forward verbatim.
+type testRawPayloadType struct {
+	Slot       uint64
+	Payload    *types.ExecutionPayload
+	PayloadRaw []byte `ssz:"-"`
+}
+
+func (t *testRawPayloadType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(12)
+	if !fixed {
+		size += ssz.SizeDynamicObject(t.Payload)
+	}
+	return size
+}
+
+func (t *testRawPayloadType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &t.Slot)
+	ssz.DefineDynamicObjectOffset(codec, &t.Payload)
+
+	ssz.DefineDynamicObjectRawContent(codec, &t.Payload, &t.PayloadRaw)
+}
+
+// Tests that the raw bytes of dynamic objects are captured while decoding, both
+// from buffers and streams, and that they do not alias the input.
+func TestDynamicObjectRawContent(t *testing.T) {
+	obj := &testRawPayloadType{
+		Slot: 1,
+		Payload: &types.ExecutionPayload{
+			BlockNumber:   2,
+			ExtraData:     []byte{0x03, 0x04},
+			BaseFeePerGas: uint256.NewInt(5),
+			Transactions:  [][]byte{{0x06}, {0x07, 0x08}},
+		},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	want := make([]byte, ssz.Size(obj.Payload))
+	if err := ssz.EncodeToBytes(want, obj.Payload); err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	// Decode the object from buffers and streams, checking the captured bytes
+	input := bytes.Clone(blob)
+
+	buffered := new(testRawPayloadType)
+	if err := ssz.DecodeFromBytes(input, buffered); err != nil {
+		t.Fatalf("failed to decode from buffer: %v", err)
+	}
+	streamed := new(testRawPayloadType)
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), streamed, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to decode from stream: %v", err)
+	}
+	clear(input)
+
+	for mode, dec := range map[string]*testRawPayloadType{"buffered": buffered, "streamed": streamed} {
+		if !bytes.Equal(dec.PayloadRaw, want) {
+			t.Errorf("%s: raw bytes mismatch: have %x, want %x", mode, dec.PayloadRaw, want)
+		}
+		if !reflect.DeepEqual(dec.Payload, obj.Payload) {
+			t.Errorf("%s: decoded payload mismatch: have %+v, want %+v", mode, dec.Payload, obj.Payload)
+		}
+		// Re-encoding must ignore the captured bytes
+		dec.PayloadRaw = []byte{0xff}
+		if have := make([]byte, ssz.Size(dec)); ssz.EncodeToBytes(have, dec) != nil || !bytes.Equal(have, blob) {
+			t.Errorf("%s: re-encoding mismatch: have %x, want %x", mode, have, blob)
+		}
+	}
+	// Ensure the capture buffer is reused when decoding into the same object
+	buffered.PayloadRaw = make([]byte, 0, len(want))
+	reused := buffered.PayloadRaw[:1]
+
+	if err := ssz.DecodeFromBytes(blob, buffered); err != nil {
+		t.Fatalf("failed to re-decode from buffer: %v", err)
+	}
+	if &reused[0] != &buffered.PayloadRaw[0] {
+		t.Errorf("capture buffer not reused")
+	}
+}
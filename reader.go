@@ -0,0 +1,75 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"io"
+)
+
+// ExactReader is a reader adapter delivering exactly a given number of bytes
+// from an underlying stream, so that framing bugs of callers (e.g. a wrong
+// length prefix) are diagnosed precisely: if the stream ends early, reads fail
+// with io.ErrUnexpectedEOF; if it contains more data, Verify fails with
+// ErrExcessData.
+//
+// Unlike io.LimitReader, running out of data before the limit is an error, even
+// at the boundary of a read.
+type ExactReader struct {
+	r    io.Reader // Underlying stream to read from
+	left uint32    // Number of bytes still to be read
+}
+
+// NewExactReader creates a reader delivering exactly length bytes from r.
+func NewExactReader(r io.Reader, length uint32) *ExactReader {
+	return &ExactReader{r: r, left: length}
+}
+
+// Read implements io.Reader, returning io.EOF once the length is reached, or
+// io.ErrUnexpectedEOF if the underlying stream ends before it.
+func (r *ExactReader) Read(p []byte) (int, error) {
+	if r.left == 0 {
+		return 0, io.EOF
+	}
+	if uint32(len(p)) > r.left {
+		p = p[:r.left]
+	}
+	n, err := r.r.Read(p)
+	r.left -= uint32(n)
+
+	if err == io.EOF && r.left > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// Remaining returns the number of bytes still to be read.
+func (r *ExactReader) Remaining() uint32 {
+	return r.left
+}
+
+// Verify checks that the whole length was read and that the underlying stream
+// has no more data, returning io.ErrUnexpectedEOF or ErrExcessData otherwise.
+//
+// Note, checking for excess data consumes a byte from the underlying stream if
+// available, and blocks until the stream is either closed or sends more data.
+func (r *ExactReader) Verify() error {
+	if r.left > 0 {
+		return fmt.Errorf("%w: %d bytes unread", io.ErrUnexpectedEOF, r.left)
+	}
+	var probe [1]byte
+	for {
+		n, err := r.r.Read(probe[:])
+		if n > 0 {
+			return ErrExcessData
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
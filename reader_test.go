@@ -0,0 +1,50 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the exact reader distinguishes streams ending too early from ones
+// carrying excess data.
+func TestExactReader(t *testing.T) {
+	tests := []struct {
+		data   []byte
+		length uint32
+		read   error
+		verify error
+	}{
+		{[]byte{1, 2, 3, 4}, 4, nil, nil},
+		{[]byte{1, 2, 3}, 4, io.ErrUnexpectedEOF, io.ErrUnexpectedEOF},
+		{[]byte{1, 2, 3, 4, 5}, 4, nil, ssz.ErrExcessData},
+		{nil, 0, nil, nil},
+	}
+	for i, tt := range tests {
+		r := ssz.NewExactReader(iotest.OneByteReader(bytes.NewReader(tt.data)), tt.length)
+		blob, err := io.ReadAll(r)
+		if !errors.Is(err, tt.read) {
+			t.Errorf("test %d: read error mismatch: have %v, want %v", i, err, tt.read)
+		}
+		if err == nil && !bytes.Equal(blob, tt.data[:tt.length]) {
+			t.Errorf("test %d: data mismatch: have %x, want %x", i, blob, tt.data[:tt.length])
+		}
+		if err := r.Verify(); !errors.Is(err, tt.verify) {
+			t.Errorf("test %d: verify error mismatch: have %v, want %v", i, err, tt.verify)
+		}
+	}
+	// Streams ending at a field boundary should also be reported as truncated
+	blob := make([]byte, ssz.Size(new(types.Checkpoint)))
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob[:8]), new(types.Checkpoint), uint32(len(blob))); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated stream error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
@@ -0,0 +1,52 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Uint64Root computes the ssz merkle root of a standalone uint64, which is the
+// little endian number padded to a single chunk.
+func Uint64Root(n uint64) [32]byte {
+	var root [32]byte
+	binary.LittleEndian.PutUint64(root[:], n)
+	return root
+}
+
+// BytesRoot computes the ssz merkle root of a standalone dynamic binary blob with
+// the given maximum size in bytes (i.e. a ByteList[limit]), chunking the blob,
+// padding it to the limit and mixing in its length.
+func BytesRoot(blob []byte, limit uint64) ([32]byte, error) {
+	if uint64(len(blob)) > limit {
+		return [32]byte{}, fmt.Errorf("%w: have %d bytes, max %d", ErrMaxLengthExceeded, len(blob), limit)
+	}
+	codec := hasherPool.Get().(*Codec)
+	defer hasherPool.Put(codec)
+	defer codec.has.Reset()
+
+	HashDynamicBytes(codec.has, blob, limit)
+	return codec.has.chunks[0], nil
+}
+
+// ListRoot computes the ssz merkle root of a standalone list of composite items
+// with the given maximum number of items (i.e. a List[T, limit]) from the roots
+// of the items, padding them to the limit and mixing in their count.
+func ListRoot(roots [][32]byte, limit uint64) ([32]byte, error) {
+	if uint64(len(roots)) > limit {
+		return [32]byte{}, fmt.Errorf("%w: have %d items, max %d", ErrMaxItemsExceeded, len(roots), limit)
+	}
+	codec := hasherPool.Get().(*Codec)
+	defer hasherPool.Put(codec)
+	defer codec.has.Reset()
+
+	codec.has.descendMixinLayer()
+	for _, root := range roots {
+		codec.has.insertChunk(root, 0)
+	}
+	codec.has.ascendMixinLayer(uint64(len(roots)), limit)
+	return codec.has.chunks[0], nil
+}
@@ -0,0 +1,814 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/holiman/uint256"
+)
+
+// Schema is the runtime ssz schema of a container type. It allows tools dealing
+// with arbitrary containers (e.g. explorers or fuzzers) to encode, decode and
+// hash them without any Go types or generated code, operating on generic value
+// trees instead.
+//
+// A value tree is a map[string]any keyed by field name, where the type of each
+// value depends on the kind of the field:
+//
+//   - FieldBool: bool
+//   - FieldUint8, FieldUint16, FieldUint32, FieldUint64: uint8, uint16, uint32, uint64
+//   - FieldUint256: *uint256.Int
+//   - FieldUint256BigInt: *big.Int
+//   - FieldStaticBytes, FieldCheckedStaticBytes, FieldDynamicBytes: []byte
+//   - FieldArrayOfBits, FieldSliceOfBits: []byte (packed bits, bitlists with the length bit)
+//   - FieldArrayOfUint64s, FieldSliceOfUint64s: []uint64
+//   - FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes: [][]byte
+//   - FieldSliceOfStaticBytes, FieldSliceOfDynamicBytes: [][]byte
+//   - FieldStaticObject, FieldDynamicObject: map[string]any
+//   - FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects: []map[string]any
+//
+// When encoding or hashing, missing fields are treated as zero values, but fields
+// not in the schema or of the wrong type are rejected.
+type Schema struct {
+	Name   string        // Name of the container type (informational only)
+	Fields []SchemaField // Fields of the container, in encoding order
+}
+
+// SchemaField is the ssz schema of a single container field. Only the sizes and
+// limits needed by the field's kind (see the FieldKind constants) must be set,
+// but contrary to FieldDescriptor, static sizes must always be set since there
+// is no Go type to derive them from.
+type SchemaField struct {
+	Name     string    // Name of the field, used as the key in the value tree
+	Kind     FieldKind // Type of the field in ssz terms
+	Size     uint64    // Static size of the field (bytes, bits or items)
+	ItemSize uint64    // Byte size of the items of static binary blob arrays and lists
+	MaxItems uint64    // Maximum number of items (or bits) of dynamic lists
+	MaxSize  uint64    // Maximum number of bytes of dynamic blobs
+	Schema   *Schema   // Schema of nested objects, or of the items of object lists
+}
+
+// Decode parses a container of the schema from a byte buffer into a value tree.
+func (s *Schema) Decode(blob []byte) (map[string]any, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	obj := newSchemaObject(s)
+	if err := DecodeFromBytes(blob, obj.object()); err != nil {
+		return nil, err
+	}
+	return obj.store(), nil
+}
+
+// DecodeFromStream parses a container of the schema with the given size out of
+// a stream into a value tree.
+func (s *Schema) DecodeFromStream(r io.Reader, size uint32) (map[string]any, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	obj := newSchemaObject(s)
+	if err := DecodeFromStream(r, obj.object(), size); err != nil {
+		return nil, err
+	}
+	return obj.store(), nil
+}
+
+// Encode serializes a value tree as a container of the schema.
+func (s *Schema) Encode(value map[string]any) ([]byte, error) {
+	obj, err := s.load(value)
+	if err != nil {
+		return nil, err
+	}
+	size, err := SizeChecked(obj)
+	if err != nil {
+		return nil, err
+	}
+	blob := make([]byte, size)
+	if err := EncodeToBytes(blob, obj); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// EncodeToStream serializes a value tree as a container of the schema into a
+// data stream.
+func (s *Schema) EncodeToStream(w io.Writer, value map[string]any) error {
+	obj, err := s.load(value)
+	if err != nil {
+		return err
+	}
+	return EncodeToStream(w, obj)
+}
+
+// Hash computes the ssz merkle root of a value tree as a container of the schema.
+func (s *Schema) Hash(value map[string]any) ([32]byte, error) {
+	obj, err := s.load(value)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return HashSequential(obj), nil
+}
+
+// Size returns the serialized size of a value tree as a container of the schema.
+func (s *Schema) Size(value map[string]any) (uint32, error) {
+	obj, err := s.load(value)
+	if err != nil {
+		return 0, err
+	}
+	return SizeChecked(obj)
+}
+
+// load validates the schema and converts a value tree into a codec object.
+func (s *Schema) load(value map[string]any) (Object, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	obj := newSchemaObject(s)
+	if err := obj.load(value); err != nil {
+		return nil, err
+	}
+	return obj.object(), nil
+}
+
+// validate checks that the schema (and all nested ones) is well formed.
+func (s *Schema) validate() error {
+	return s.validateNested(make(map[*Schema]bool), nil)
+}
+
+// validateNested is the recursive version of validate, tracking the schemas
+// already checked to support self referencing ones via lists, and the chain
+// of directly nested objects to reject infinitely sized ones.
+func (s *Schema) validateNested(done map[*Schema]bool, nesting []*Schema) error {
+	for _, parent := range nesting {
+		if parent == s {
+			return fmt.Errorf("%w: %s: infinitely nested", ErrInvalidSchema, s.Name)
+		}
+	}
+	if done[s] {
+		return nil
+	}
+	done[s] = true
+
+	names := make(map[string]bool)
+	for _, f := range s.Fields {
+		if f.Name == "" || names[f.Name] {
+			return fmt.Errorf("%w: %s: missing or duplicate field name %q", ErrInvalidSchema, s.Name, f.Name)
+		}
+		names[f.Name] = true
+
+		switch f.Kind {
+		case FieldBool, FieldUint8, FieldUint16, FieldUint32, FieldUint64, FieldUint256, FieldUint256BigInt,
+			FieldDynamicBytes, FieldSliceOfBits, FieldSliceOfUint64s, FieldSliceOfDynamicBytes:
+
+		case FieldStaticBytes, FieldCheckedStaticBytes, FieldArrayOfBits, FieldArrayOfUint64s:
+			if f.Size == 0 {
+				return fmt.Errorf("%w: %s.%s: missing static size", ErrInvalidSchema, s.Name, f.Name)
+			}
+		case FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes, FieldSliceOfStaticBytes:
+			if f.ItemSize == 0 || (f.Kind != FieldSliceOfStaticBytes && f.Size == 0) {
+				return fmt.Errorf("%w: %s.%s: missing static size", ErrInvalidSchema, s.Name, f.Name)
+			}
+		case FieldStaticObject, FieldDynamicObject, FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects:
+			if f.Schema == nil {
+				return fmt.Errorf("%w: %s.%s: missing object schema", ErrInvalidSchema, s.Name, f.Name)
+			}
+			static := f.Kind == FieldStaticObject || f.Kind == FieldSliceOfStaticObjects
+			if static != f.Schema.static() {
+				return fmt.Errorf("%w: %s.%s: object staticness mismatch", ErrInvalidSchema, s.Name, f.Name)
+			}
+			// Lists may be empty, so only directly nested objects can recurse infinitely
+			chain := []*Schema(nil)
+			if f.Kind == FieldStaticObject || f.Kind == FieldDynamicObject {
+				chain = append(nesting[:len(nesting):len(nesting)], s)
+			}
+			if err := f.Schema.validateNested(done, chain); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: %s.%s: unknown field kind %d", ErrInvalidSchema, s.Name, f.Name, f.Kind)
+		}
+	}
+	return nil
+}
+
+// static returns whether all the fields of the schema are static.
+func (s *Schema) static() bool {
+	for _, f := range s.Fields {
+		if f.Kind.dynamic() {
+			return false
+		}
+	}
+	return true
+}
+
+// staticSize returns the size of the static part of a container of the schema.
+func (s *Schema) staticSize() uint64 {
+	var size uint64
+	for _, f := range s.Fields {
+		switch f.Kind {
+		case FieldBool, FieldUint8:
+			size += 1
+		case FieldUint16:
+			size += 2
+		case FieldUint32:
+			size += 4
+		case FieldUint64:
+			size += 8
+		case FieldUint256, FieldUint256BigInt:
+			size += 32
+		case FieldStaticBytes, FieldCheckedStaticBytes:
+			size += f.Size
+		case FieldArrayOfBits:
+			size += (f.Size + 7) / 8
+		case FieldArrayOfUint64s:
+			size += f.Size * 8
+		case FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes:
+			size += f.Size * f.ItemSize
+		case FieldStaticObject:
+			size += f.Schema.staticSize()
+		default:
+			size += 4 // offset of dynamic fields
+		}
+	}
+	return size
+}
+
+// schemaObject is a container of a runtime schema, holding the field values in
+// typed slots so they can be passed by pointer to the Define methods.
+type schemaObject struct {
+	schema *Schema
+	slots  []any
+}
+
+// staticSchemaObject is a schemaObject with a static schema, wrapped to satisfy
+// the StaticObject interface instead of the DynamicObject one.
+type staticSchemaObject struct {
+	*schemaObject
+}
+
+// schemaBlob is an item of a slice of static binary blobs, wrapped into a static
+// object so that blobs of any size can use the object list Define methods (a
+// list of blobs is hashed the same way as a list of single blob containers).
+type schemaBlob struct {
+	blob []byte
+	size uint64
+}
+
+// newSchemaObject creates a zero valued container of the given schema.
+func newSchemaObject(schema *Schema) *schemaObject {
+	obj := &schemaObject{schema: schema, slots: make([]any, len(schema.Fields))}
+	for i, f := range schema.Fields {
+		switch f.Kind {
+		case FieldBool:
+			obj.slots[i] = new(bool)
+		case FieldUint8:
+			obj.slots[i] = new(uint8)
+		case FieldUint16:
+			obj.slots[i] = new(uint16)
+		case FieldUint32:
+			obj.slots[i] = new(uint32)
+		case FieldUint64:
+			obj.slots[i] = new(uint64)
+		case FieldUint256:
+			obj.slots[i] = new(*uint256.Int)
+		case FieldUint256BigInt:
+			obj.slots[i] = new(*big.Int)
+		case FieldStaticBytes, FieldCheckedStaticBytes:
+			blob := make([]byte, f.Size)
+			obj.slots[i] = &blob
+		case FieldArrayOfBits:
+			bits := make([]byte, (f.Size+7)/8)
+			obj.slots[i] = &bits
+		case FieldDynamicBytes:
+			obj.slots[i] = new([]byte)
+		case FieldSliceOfBits:
+			obj.slots[i] = &[]byte{0x01} // empty bitlist with the length bit
+		case FieldArrayOfUint64s:
+			nums := make([]uint64, f.Size)
+			obj.slots[i] = &nums
+		case FieldSliceOfUint64s:
+			obj.slots[i] = new([]uint64)
+		case FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes:
+			blobs := make([][]byte, f.Size)
+			for j := range blobs {
+				blobs[j] = make([]byte, f.ItemSize)
+			}
+			obj.slots[i] = &blobs
+		case FieldSliceOfStaticBytes, FieldSliceOfDynamicBytes:
+			obj.slots[i] = new([][]byte)
+		case FieldStaticObject, FieldDynamicObject:
+			obj.slots[i] = newSchemaObject(f.Schema)
+		case FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects:
+			obj.slots[i] = new([]*schemaObject)
+		}
+	}
+	return obj
+}
+
+// object returns the container as a static or dynamic ssz object, depending on
+// its schema.
+func (obj *schemaObject) object() Object {
+	if obj.schema.static() {
+		return staticSchemaObject{obj}
+	}
+	return obj
+}
+
+// load fills the slots of the container from a value tree, checking that the
+// values match the schema.
+func (obj *schemaObject) load(value map[string]any) error {
+	for name := range value {
+		if obj.field(name) < 0 {
+			return fmt.Errorf("%w: %s: unknown field %q", ErrSchemaMismatch, obj.schema.Name, name)
+		}
+	}
+	for i, f := range obj.schema.Fields {
+		v, ok := value[f.Name]
+		if !ok || v == nil {
+			continue
+		}
+		mismatch := func(want string) error {
+			return fmt.Errorf("%w: %s.%s: have %T, want %s", ErrSchemaMismatch, obj.schema.Name, f.Name, v, want)
+		}
+		switch f.Kind {
+		case FieldBool:
+			if !loadSlot[bool](obj.slots[i], v) {
+				return mismatch("bool")
+			}
+		case FieldUint8:
+			if !loadSlot[uint8](obj.slots[i], v) {
+				return mismatch("uint8")
+			}
+		case FieldUint16:
+			if !loadSlot[uint16](obj.slots[i], v) {
+				return mismatch("uint16")
+			}
+		case FieldUint32:
+			if !loadSlot[uint32](obj.slots[i], v) {
+				return mismatch("uint32")
+			}
+		case FieldUint64:
+			if !loadSlot[uint64](obj.slots[i], v) {
+				return mismatch("uint64")
+			}
+		case FieldUint256:
+			if !loadSlot[*uint256.Int](obj.slots[i], v) {
+				return mismatch("*uint256.Int")
+			}
+		case FieldUint256BigInt:
+			if !loadSlot[*big.Int](obj.slots[i], v) {
+				return mismatch("*big.Int")
+			}
+		case FieldStaticBytes, FieldCheckedStaticBytes, FieldArrayOfBits:
+			blob, ok := v.([]byte)
+			if !ok {
+				return mismatch("[]byte")
+			}
+			if want := len(*obj.slots[i].(*[]byte)); len(blob) != want {
+				return mismatch(fmt.Sprintf("%d bytes", want))
+			}
+			*obj.slots[i].(*[]byte) = blob
+		case FieldDynamicBytes, FieldSliceOfBits:
+			if !loadSlot[[]byte](obj.slots[i], v) {
+				return mismatch("[]byte")
+			}
+		case FieldArrayOfUint64s:
+			nums, ok := v.([]uint64)
+			if !ok || uint64(len(nums)) != f.Size {
+				return mismatch(fmt.Sprintf("[]uint64 of %d items", f.Size))
+			}
+			*obj.slots[i].(*[]uint64) = nums
+		case FieldSliceOfUint64s:
+			if !loadSlot[[]uint64](obj.slots[i], v) {
+				return mismatch("[]uint64")
+			}
+		case FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes, FieldSliceOfStaticBytes:
+			blobs, ok := v.([][]byte)
+			if !ok || (f.Kind != FieldSliceOfStaticBytes && uint64(len(blobs)) != f.Size) {
+				return mismatch(fmt.Sprintf("[][]byte of %d items", f.Size))
+			}
+			for _, blob := range blobs {
+				if uint64(len(blob)) != f.ItemSize {
+					return mismatch(fmt.Sprintf("[][]byte of %d byte items", f.ItemSize))
+				}
+			}
+			*obj.slots[i].(*[][]byte) = blobs
+		case FieldSliceOfDynamicBytes:
+			if !loadSlot[[][]byte](obj.slots[i], v) {
+				return mismatch("[][]byte")
+			}
+		case FieldStaticObject, FieldDynamicObject:
+			fields, ok := v.(map[string]any)
+			if !ok {
+				return mismatch("map[string]any")
+			}
+			if err := obj.slots[i].(*schemaObject).load(fields); err != nil {
+				return err
+			}
+		case FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects:
+			items, ok := v.([]map[string]any)
+			if !ok {
+				return mismatch("[]map[string]any")
+			}
+			objects := make([]*schemaObject, len(items))
+			for j, item := range items {
+				objects[j] = newSchemaObject(f.Schema)
+				if err := objects[j].load(item); err != nil {
+					return err
+				}
+			}
+			*obj.slots[i].(*[]*schemaObject) = objects
+		}
+	}
+	return nil
+}
+
+// loadSlot stores a value into a typed slot, returning false if the value is not
+// of the slot's type.
+func loadSlot[T any](slot any, v any) bool {
+	val, ok := v.(T)
+	if ok {
+		*slot.(*T) = val
+	}
+	return ok
+}
+
+// store converts the slots of the container into a value tree.
+func (obj *schemaObject) store() map[string]any {
+	value := make(map[string]any, len(obj.slots))
+	for i, f := range obj.schema.Fields {
+		switch slot := obj.slots[i].(type) {
+		case *schemaObject:
+			value[f.Name] = slot.store()
+		case *[]*schemaObject:
+			items := make([]map[string]any, len(*slot))
+			for j, item := range *slot {
+				items[j] = item.store()
+			}
+			value[f.Name] = items
+		default:
+			value[f.Name] = slotValue(slot)
+		}
+	}
+	return value
+}
+
+// slotValue dereferences a typed slot of a leaf field.
+func slotValue(slot any) any {
+	switch slot := slot.(type) {
+	case *bool:
+		return *slot
+	case *uint8:
+		return *slot
+	case *uint16:
+		return *slot
+	case *uint32:
+		return *slot
+	case *uint64:
+		return *slot
+	case **uint256.Int:
+		return *slot
+	case **big.Int:
+		return *slot
+	case *[]byte:
+		return *slot
+	case *[]uint64:
+		return *slot
+	case *[][]byte:
+		return *slot
+	default:
+		panic(fmt.Sprintf("unsupported slot type: %T", slot))
+	}
+}
+
+// field returns the index of a field by name, or -1 if it's unknown.
+func (obj *schemaObject) field(name string) int {
+	for i, f := range obj.schema.Fields {
+		if f.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *schemaObject) SizeSSZ(fixed bool) uint32 {
+	size := obj.schema.staticSize()
+	if fixed {
+		return checkedSize(size)
+	}
+	for i, f := range obj.schema.Fields {
+		switch f.Kind {
+		case FieldDynamicBytes, FieldSliceOfBits:
+			size += uint64(len(*obj.slots[i].(*[]byte)))
+		case FieldSliceOfUint64s:
+			size += uint64(len(*obj.slots[i].(*[]uint64))) * 8
+		case FieldSliceOfStaticBytes:
+			size += uint64(len(*obj.slots[i].(*[][]byte))) * f.ItemSize
+		case FieldSliceOfDynamicBytes:
+			for _, blob := range *obj.slots[i].(*[][]byte) {
+				size += 4 + uint64(len(blob)) // 4-byte offset + dynamic data later
+			}
+		case FieldDynamicObject:
+			size += uint64(obj.slots[i].(*schemaObject).SizeSSZ(false))
+		case FieldSliceOfStaticObjects:
+			size += uint64(len(*obj.slots[i].(*[]*schemaObject))) * f.Schema.staticSize()
+		case FieldSliceOfDynamicObjects:
+			for _, item := range *obj.slots[i].(*[]*schemaObject) {
+				size += 4 + uint64(item.SizeSSZ(false)) // 4-byte offset + dynamic data later
+			}
+		}
+	}
+	return checkedSize(size)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj staticSchemaObject) SizeSSZ() uint32 {
+	return checkedSize(obj.schema.staticSize())
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *schemaObject) DefineSSZ(c *Codec) {
+	// Define the static data (fields and dynamic offsets)
+	for i := range obj.schema.Fields {
+		obj.define(c, i, false)
+	}
+	// Define the dynamic data (fields), hashed at the offset position
+	if c.enc == nil && c.dec == nil {
+		return
+	}
+	for i, f := range obj.schema.Fields {
+		if f.Kind.dynamic() {
+			obj.define(c, i, true)
+		}
+	}
+}
+
+// define runs the static (or offset) definition of a field, or the dynamic one
+// if content is set.
+func (obj *schemaObject) define(c *Codec, i int, content bool) {
+	f := &obj.schema.Fields[i]
+
+	switch slot := obj.slots[i].(type) {
+	case *bool:
+		DefineBool(c, slot)
+	case *uint8:
+		DefineUint8(c, slot)
+	case *uint16:
+		DefineUint16(c, slot)
+	case *uint32:
+		DefineUint32(c, slot)
+	case *uint64:
+		DefineUint64(c, slot)
+	case **uint256.Int:
+		DefineUint256(c, slot)
+	case **big.Int:
+		DefineUint256BigInt(c, slot)
+
+	case *[]byte:
+		switch f.Kind {
+		case FieldStaticBytes, FieldCheckedStaticBytes:
+			DefineCheckedStaticBytes(c, slot, f.Size)
+		case FieldArrayOfBits:
+			DefineCheckedArrayOfBits(c, slot, f.Size)
+		case FieldDynamicBytes:
+			if content {
+				DefineDynamicBytesContent(c, slot, f.MaxSize)
+			} else {
+				DefineDynamicBytesOffset(c, slot, f.MaxSize)
+			}
+		case FieldSliceOfBits:
+			if content {
+				DefineSliceOfBitsContent(c, slot, f.MaxItems)
+			} else {
+				DefineSliceOfBitsOffset(c, slot, f.MaxItems)
+			}
+		}
+	case *[]uint64:
+		switch {
+		case f.Kind == FieldSliceOfUint64s && content:
+			DefineSliceOfUint64sContent(c, slot, f.MaxItems)
+		case f.Kind == FieldSliceOfUint64s:
+			DefineSliceOfUint64sOffset(c, slot, f.MaxItems)
+		case c.enc != nil:
+			c.enc.encodeUint64s(*slot)
+		case c.dec != nil:
+			c.dec.decodeUint64s(*slot)
+		case c.fmt != nil:
+			c.fmt.line(slot, formatUint64s(*slot))
+		default:
+			c.has.hashUint64s(*slot)
+		}
+	case *[][]byte:
+		switch f.Kind {
+		case FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes:
+			defineArrayOfBlobs(c, *slot, f.ItemSize)
+		case FieldSliceOfStaticBytes:
+			defineSliceOfBlobs(c, slot, f, content)
+		case FieldSliceOfDynamicBytes:
+			if content {
+				DefineSliceOfDynamicBytesContent(c, slot, f.MaxItems, f.MaxSize)
+			} else {
+				DefineSliceOfDynamicBytesOffset(c, slot, f.MaxItems, f.MaxSize)
+			}
+		}
+	case *schemaObject:
+		if f.Kind == FieldStaticObject {
+			defineStaticSchemaObject(c, slot)
+		} else {
+			defineDynamicSchemaObject(c, slot, content)
+		}
+	case *[]*schemaObject:
+		if f.Kind == FieldSliceOfStaticObjects {
+			defineSliceOfStaticSchemaObjects(c, slot, f, content)
+		} else {
+			defineSliceOfDynamicSchemaObjects(c, slot, f, content)
+		}
+	}
+}
+
+// defineArrayOfBlobs defines a static array of static binary blobs, item by item,
+// since the item lengths are only known at runtime.
+func defineArrayOfBlobs(c *Codec, blobs [][]byte, size uint64) {
+	switch {
+	case c.enc != nil:
+		for _, blob := range blobs {
+			EncodeCheckedStaticBytes(c.enc, blob)
+		}
+	case c.dec != nil:
+		for i := range blobs {
+			DecodeCheckedStaticBytes(c.dec, &blobs[i], size)
+		}
+	case c.fmt != nil:
+		c.fmt.items(&blobs, len(blobs), "", func(i int) string { return formatStaticBytes(blobs[i]) })
+	default:
+		c.has.descendLayer()
+		for _, blob := range blobs {
+			c.has.hashBytes(blob)
+		}
+		c.has.ascendLayer(0)
+	}
+}
+
+// defineSliceOfBlobs defines a dynamic slice of static binary blobs via the object
+// list Define methods, operating on a wrapped copy of the slice which is stored
+// back into the slot after decoding.
+func defineSliceOfBlobs(c *Codec, blobs *[][]byte, f *SchemaField, content bool) {
+	if c.fmt != nil {
+		c.fmt.items(blobs, len(*blobs), formatLimit(f.MaxItems), func(i int) string { return formatStaticBytes((*blobs)[i]) })
+		return
+	}
+	items := make([]StaticObject, len(*blobs))
+	for i, blob := range *blobs {
+		items[i] = &schemaBlob{blob: blob, size: f.ItemSize}
+	}
+	if !content {
+		DefineSliceOfStaticObjectsOffsetFunc(c, &items, f.MaxItems, nil)
+		return
+	}
+	DefineSliceOfStaticObjectsContentFunc(c, &items, f.MaxItems, func() StaticObject {
+		return &schemaBlob{size: f.ItemSize}
+	})
+	if c.dec != nil {
+		*blobs = make([][]byte, len(items))
+		for i, item := range items {
+			if item != nil {
+				(*blobs)[i] = item.(*schemaBlob).blob
+			}
+		}
+	}
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (b *schemaBlob) SizeSSZ() uint32 {
+	return uint32(b.size)
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (b *schemaBlob) DefineSSZ(c *Codec) {
+	DefineCheckedStaticBytes(c, &b.blob, b.size)
+}
+
+// defineStaticSchemaObject defines a nested static container.
+func defineStaticSchemaObject(c *Codec, obj *schemaObject) {
+	switch {
+	case c.enc != nil:
+		EncodeStaticObject(c.enc, staticSchemaObject{obj})
+	case c.dec != nil:
+		dec := c.dec
+		if dec.err != nil {
+			return
+		}
+		if dec.tracer != nil {
+			dec.traceStatic(staticSchemaObject{obj}.SizeSSZ())
+		}
+		dec.traceDescend()
+		obj.DefineSSZ(dec.codec)
+		dec.traceAscend()
+	case c.fmt != nil:
+		c.fmt.object(obj, staticSchemaObject{obj})
+	default:
+		HashStaticObject(c.has, staticSchemaObject{obj})
+	}
+}
+
+// defineDynamicSchemaObject defines the offset of a nested dynamic container, or
+// its content if content is set.
+func defineDynamicSchemaObject(c *Codec, obj *schemaObject, content bool) {
+	switch {
+	case c.enc != nil && content:
+		EncodeDynamicObjectContent(c.enc, obj)
+	case c.enc != nil:
+		EncodeDynamicObjectOffset(c.enc, obj)
+	case c.dec != nil && content:
+		dec := c.dec
+		if dec.err != nil {
+			return
+		}
+		dec.traceDynamic()
+
+		// Descend into a new data slot to track/verify a new sub-length
+		dec.descendIntoSlot(dec.retrieveSize())
+		defer dec.ascendFromSlot()
+
+		dec.traceDescend()
+		dec.startDynamics(obj.SizeSSZ(true))
+		obj.DefineSSZ(dec.codec)
+		dec.traceAscend()
+	case c.dec != nil:
+		c.dec.traceOffset()
+		c.dec.decodeOffset(false)
+	case c.fmt != nil:
+		c.fmt.object(obj, obj)
+	default:
+		HashDynamicObject(c.has, obj)
+	}
+}
+
+// defineSliceOfStaticSchemaObjects defines a dynamic slice of static containers
+// via the factory based Define methods, operating on an interface typed copy of
+// the slice which is stored back into the slot after decoding.
+func defineSliceOfStaticSchemaObjects(c *Codec, slot *[]*schemaObject, f *SchemaField, content bool) {
+	objects := make([]StaticObject, len(*slot))
+	for i, obj := range *slot {
+		objects[i] = staticSchemaObject{obj}
+	}
+	if c.fmt != nil {
+		c.fmt.objects(slot, len(objects), formatLimit(f.MaxItems), func(i int) Object { return objects[i] })
+		return
+	}
+	if !content {
+		DefineSliceOfStaticObjectsOffsetFunc(c, &objects, f.MaxItems, nil)
+		return
+	}
+	DefineSliceOfStaticObjectsContentFunc(c, &objects, f.MaxItems, func() StaticObject {
+		return staticSchemaObject{newSchemaObject(f.Schema)}
+	})
+	if c.dec != nil {
+		*slot = make([]*schemaObject, len(objects))
+		for i, obj := range objects {
+			if obj != nil {
+				(*slot)[i] = obj.(staticSchemaObject).schemaObject
+			} else {
+				(*slot)[i] = newSchemaObject(f.Schema)
+			}
+		}
+	}
+}
+
+// defineSliceOfDynamicSchemaObjects defines a dynamic slice of dynamic containers
+// via the factory based Define methods, operating on an interface typed copy of
+// the slice which is stored back into the slot after decoding.
+func defineSliceOfDynamicSchemaObjects(c *Codec, slot *[]*schemaObject, f *SchemaField, content bool) {
+	objects := make([]DynamicObject, len(*slot))
+	for i, obj := range *slot {
+		objects[i] = obj
+	}
+	if c.fmt != nil {
+		c.fmt.objects(slot, len(objects), formatLimit(f.MaxItems), func(i int) Object { return objects[i] })
+		return
+	}
+	if !content {
+		DefineSliceOfDynamicObjectsOffsetFunc(c, &objects, f.MaxItems, nil)
+		return
+	}
+	DefineSliceOfDynamicObjectsContentFunc(c, &objects, f.MaxItems, func() DynamicObject {
+		return newSchemaObject(f.Schema)
+	})
+	if c.dec != nil {
+		*slot = make([]*schemaObject, len(objects))
+		for i, obj := range objects {
+			if obj != nil {
+				(*slot)[i] = obj.(*schemaObject)
+			} else {
+				(*slot)[i] = newSchemaObject(f.Schema)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// EncodeSized serializes the object into a data stream, prefixed by the size of
+// the encoding as a uvarint. This allows storing dynamic objects (e.g. in key-
+// value stores, or back to back in a file) without persisting their lengths
+// separately. Use DecodeSized to parse them back.
+func EncodeSized(w io.Writer, obj Object) error {
+	return EncodeSizedWithConfig(w, obj, nil)
+}
+
+// EncodeSizedWithConfig is analogous to EncodeSized, but allows the caller to
+// customize the encoding behavior via a config.
+func EncodeSizedWithConfig(w io.Writer, obj Object, cfg *EncoderConfig) error {
+	size, err := SizeChecked(obj)
+	if err != nil {
+		return err
+	}
+	var prefix [binary.MaxVarintLen32]byte
+	if _, err := w.Write(prefix[:binary.PutUvarint(prefix[:], uint64(size))]); err != nil {
+		return err
+	}
+	return EncodeToStreamWithConfig(w, obj, cfg)
+}
+
+// DecodeSized parses an object from a data stream, prefixed by the size of the
+// encoding as a uvarint, as written by EncodeSized. Only the prefix and the
+// object are consumed from the stream, so multiple sized objects can be read
+// back to back.
+func DecodeSized(r io.Reader, obj Object) error {
+	return DecodeSizedWithConfig(r, obj, nil)
+}
+
+// DecodeSizedWithConfig is analogous to DecodeSized, but allows the caller to
+// customize the decoding behavior via a config.
+func DecodeSizedWithConfig(r io.Reader, obj Object, cfg *DecoderConfig) error {
+	size, err := binary.ReadUvarint(&byteReader{r: r})
+	if err != nil {
+		return err
+	}
+	if size > math.MaxUint32 {
+		return fmt.Errorf("%w: size prefix %d bytes", ErrObjectTooLarge, size)
+	}
+	return DecodeFromStreamWithConfig(r, obj, uint32(size), cfg)
+}
+
+// byteReader is an io.ByteReader over an arbitrary stream, reading the bytes one
+// by one, so nothing past the size prefix is consumed.
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+// ReadByte implements io.ByteReader, reading the next byte from the stream.
+func (r *byteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(r.r, r.buf[:]); err != nil {
+		return 0, err
+	}
+	return r.buf[0], nil
+}
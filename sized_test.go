@@ -0,0 +1,54 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that size prefixed encodings can be read back to back, and that corrupt
+// prefixes are rejected.
+func TestSizedEncoding(t *testing.T) {
+	objs := []*types.ExecutionPayload{
+		{BlockNumber: 1, BaseFeePerGas: uint256.NewInt(2)},
+		{BlockNumber: 3, ExtraData: []byte{0x04}, BaseFeePerGas: uint256.NewInt(5), Transactions: [][]byte{make([]byte, 200)}},
+	}
+	buf := new(bytes.Buffer)
+	for i, obj := range objs {
+		if err := ssz.EncodeSized(buf, obj); err != nil {
+			t.Fatalf("object %d: failed to encode: %v", i, err)
+		}
+	}
+	for i, obj := range objs {
+		decoded := new(types.ExecutionPayload)
+		if err := ssz.DecodeSized(buf, decoded); err != nil {
+			t.Fatalf("object %d: failed to decode: %v", i, err)
+		}
+		if !reflect.DeepEqual(decoded, obj) {
+			t.Errorf("object %d: decoded mismatch: have %+v, want %+v", i, decoded, obj)
+		}
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unconsumed data left: %d bytes", buf.Len())
+	}
+	// Ensure missing, truncated and oversized prefixes are rejected
+	if err := ssz.DecodeSized(bytes.NewReader(nil), new(types.ExecutionPayload)); !errors.Is(err, io.EOF) {
+		t.Errorf("missing prefix error mismatch: have %v, want %v", err, io.EOF)
+	}
+	if err := ssz.DecodeSized(bytes.NewReader([]byte{0x80}), new(types.ExecutionPayload)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated prefix error mismatch: have %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if err := ssz.DecodeSized(bytes.NewReader([]byte{0x80, 0x80, 0x80, 0x80, 0x10}), new(types.ExecutionPayload)); !errors.Is(err, ssz.ErrObjectTooLarge) {
+		t.Errorf("oversized prefix error mismatch: have %v, want %v", err, ssz.ErrObjectTooLarge)
+	}
+}
@@ -4,24 +4,62 @@
 
 package ssz
 
-import "github.com/prysmaticlabs/go-bitfield"
+import (
+	"fmt"
+	"math"
+)
+
+// Constants of the SSZ spec, exported so that code computing sizes, limits or
+// proofs alongside the library does not need to hardcode them.
+const (
+	BytesPerChunk = 32 // Number of bytes in a leaf (chunk) of a hash tree
+	OffsetSize    = 4  // Number of bytes of a dynamic field's offset in a container
+)
+
+// ChunkCount returns the number of hash tree leaves (chunks) needed to pack size
+// bytes of data (e.g. the limit of a byte list's tree is ChunkCount(maxSize)).
+func ChunkCount(size uint64) uint64 {
+	return (size + BytesPerChunk - 1) / BytesPerChunk
+}
+
+// sizeOverflow is the panic value raised by the size helpers if a size does not
+// fit into 4 bytes. Since sizes cannot return errors, the top level encoders
+// recover it and return its error instead of emitting a malformed stream.
+type sizeOverflow struct {
+	err error
+}
+
+// checkedSize converts a size computed in 64 bits into the 32 bit SSZ size, or
+// panics with a sizeOverflow if it does not fit.
+func checkedSize(size uint64) uint32 {
+	if size > math.MaxUint32 {
+		panic(sizeOverflow{fmt.Errorf("%w: size %d bytes", ErrObjectTooLarge, size)})
+	}
+	return uint32(size)
+}
 
 // SizeDynamicBytes returns the serialized size of the dynamic part of a dynamic
 // blob.
 func SizeDynamicBytes(blobs []byte) uint32 {
-	return uint32(len(blobs))
+	return checkedSize(uint64(len(blobs)))
+}
+
+// SizeDynamicString returns the serialized size of the dynamic part of a dynamic
+// binary blob held in a string.
+func SizeDynamicString[T ~string](str T) uint32 {
+	return checkedSize(uint64(len(str)))
 }
 
 // SizeSliceOfBits returns the serialized size of the dynamic part of a slice of
 // bits.
-func SizeSliceOfBits(bits bitfield.Bitlist) uint32 {
-	return uint32(len(bits))
+func SizeSliceOfBits[T ~[]byte](bits T) uint32 {
+	return checkedSize(uint64(len(bits)))
 }
 
 // SizeSliceOfUint64s returns the serialized size of the dynamic part of a dynamic
 // list of uint64s.
 func SizeSliceOfUint64s[T ~uint64](ns []T) uint32 {
-	return uint32(len(ns)) * 8
+	return checkedSize(uint64(len(ns)) * 8)
 }
 
 // SizeDynamicObject returns the serialized size of the dynamic part of a dynamic
@@ -30,23 +68,39 @@ func SizeDynamicObject[T DynamicObject](obj T) uint32 {
 	return obj.SizeSSZ(false)
 }
 
+// SizeDynamicObjectOnFork returns the serialized size of the dynamic part of a
+// dynamic object on a specific fork, if the object is a monolithic one.
+func SizeDynamicObjectOnFork[T DynamicObject](fork Fork, obj T) uint32 {
+	return sizeOnFork(obj, fork, false)
+}
+
 // SizeSliceOfStaticBytes returns the serialized size of the dynamic part of a dynamic
 // list of static blobs.
 func SizeSliceOfStaticBytes[T commonBytesLengths](blobs []T) uint32 {
 	if len(blobs) == 0 {
 		return 0
 	}
-	return uint32(len(blobs) * len(blobs[0]))
+	return checkedSize(uint64(len(blobs)) * uint64(len(blobs[0])))
+}
+
+// SizeCheckedSliceOfStaticBytes returns the serialized size of the dynamic part of
+// a dynamic list of static blobs.
+func SizeCheckedSliceOfStaticBytes(blobs [][]byte) uint32 {
+	var size uint64
+	for _, blob := range blobs {
+		size += uint64(len(blob))
+	}
+	return checkedSize(size)
 }
 
 // SizeSliceOfDynamicBytes returns the serialized size of the dynamic part of a dynamic
 // list of dynamic blobs.
 func SizeSliceOfDynamicBytes(blobs [][]byte) uint32 {
-	var size uint32
+	var size uint64
 	for _, blob := range blobs {
-		size += uint32(4 + len(blob)) // 4-byte offset + dynamic data later
+		size += OffsetSize + uint64(len(blob)) // offset + dynamic data later
 	}
-	return size
+	return checkedSize(size)
 }
 
 // SizeSliceOfStaticObjects returns the serialized size of the dynamic part of a dynamic
@@ -55,15 +109,26 @@ func SizeSliceOfStaticObjects[T StaticObject](objects []T) uint32 {
 	if len(objects) == 0 {
 		return 0
 	}
-	return uint32(len(objects)) * objects[0].SizeSSZ()
+	return checkedSize(uint64(len(objects)) * uint64(objects[0].SizeSSZ()))
 }
 
 // SizeSliceOfDynamicObjects returns the serialized size of the dynamic part of
 // a dynamic list of dynamic objects.
 func SizeSliceOfDynamicObjects[T DynamicObject](objects []T) uint32 {
-	var size uint32
+	var size uint64
+	for _, obj := range objects {
+		size += OffsetSize + uint64(obj.SizeSSZ(false)) // offset + dynamic data later
+	}
+	return checkedSize(size)
+}
+
+// SizeSliceOfDynamicObjectsOnFork returns the serialized size of the dynamic part
+// of a dynamic list of dynamic objects on a specific fork, if the objects are
+// monolithic ones.
+func SizeSliceOfDynamicObjectsOnFork[T DynamicObject](fork Fork, objects []T) uint32 {
+	var size uint64
 	for _, obj := range objects {
-		size += 4 + obj.SizeSSZ(false) // 4-byte offset + dynamic data later
+		size += OffsetSize + uint64(sizeOnFork(obj, fork, false)) // offset + dynamic data later
 	}
-	return size
+	return checkedSize(size)
 }
@@ -0,0 +1,98 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// Tests that the size helpers detect sizes overflowing 4 bytes and that the
+// encoders report them as errors.
+func TestSizeOverflow(t *testing.T) {
+	obj := &testHugeListType{Items: []*testHugeFieldType{new(testHugeFieldType), new(testHugeFieldType)}}
+
+	if err := ssz.EncodeToBytes(nil, obj); !errors.Is(err, ssz.ErrObjectTooLarge) {
+		t.Errorf("buffer encoding error mismatch: have %v, want %v", err, ssz.ErrObjectTooLarge)
+	}
+	if err := ssz.EncodeToStream(io.Discard, obj); !errors.Is(err, ssz.ErrObjectTooLarge) {
+		t.Errorf("stream encoding error mismatch: have %v, want %v", err, ssz.ErrObjectTooLarge)
+	}
+	if _, err := ssz.SizeChecked(obj); !errors.Is(err, ssz.ErrObjectTooLarge) {
+		t.Errorf("checked sizing error mismatch: have %v, want %v", err, ssz.ErrObjectTooLarge)
+	}
+	if size, err := ssz.SizeChecked(&testHugeListType{Items: obj.Items[:1]}); err != nil || size != 4+4+3<<30 {
+		t.Errorf("checked sizing mismatch: have %d, %v, want %d", size, err, 4+4+3<<30)
+	}
+}
+
+// testHugeListType is a dynamic object with a list of items claiming to be 3GB
+// each.
+type testHugeListType struct {
+	Items []*testHugeFieldType
+}
+
+func (t *testHugeListType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(4)
+	if !fixed {
+		size += ssz.SizeSliceOfDynamicObjects(t.Items)
+	}
+	return size
+}
+
+func (t *testHugeListType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &t.Items, 16)
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &t.Items, 16)
+}
+
+// Tests that the exported spec constants and helpers match the library internals.
+func TestSpecConstants(t *testing.T) {
+	for size, want := range map[uint64]uint64{0: 0, 1: 1, 32: 1, 33: 2, 256: 8} {
+		if have := ssz.ChunkCount(size); have != want {
+			t.Errorf("chunk count of %d bytes mismatch: have %d, want %d", size, have, want)
+		}
+	}
+	if have := new(testBigListType).SizeSSZ(true); have != ssz.OffsetSize {
+		t.Errorf("offset size mismatch: have %d, want %d", have, ssz.OffsetSize)
+	}
+	// A byte list with a limit of one chunk hashes as the length mixed into its data
+	blob := []byte{0x01, 0x02}
+	var chunk [ssz.BytesPerChunk]byte
+	copy(chunk[:], blob)
+	var length [ssz.BytesPerChunk]byte
+	length[0] = byte(len(blob))
+	want := sha256.Sum256(append(chunk[:], length[:]...))
+	have, err := ssz.BytesRoot(blob, ssz.BytesPerChunk)
+	if err != nil {
+		t.Fatalf("failed to hash byte list: %v", err)
+	}
+	if have != want {
+		t.Errorf("byte list root mismatch: have %#x, want %#x", have, want)
+	}
+}
+
+// Tests that the package level size helpers work uniformly across static and
+// dynamic objects.
+func TestSizeHelpers(t *testing.T) {
+	static := &types.Withdrawal{Index: 1}
+	if have, want := ssz.Size(static), uint32(44); have != want {
+		t.Errorf("static size mismatch: have %d, want %d", have, want)
+	}
+	if have, want := ssz.StaticSize(static), uint32(44); have != want {
+		t.Errorf("static static size mismatch: have %d, want %d", have, want)
+	}
+	dynamic := &types.ExecutionPayload{ExtraData: []byte{0x01, 0x02}}
+	if have, want := ssz.Size(dynamic), uint32(510); have != want {
+		t.Errorf("dynamic size mismatch: have %d, want %d", have, want)
+	}
+	if have, want := ssz.StaticSize(dynamic), uint32(508); have != want {
+		t.Errorf("dynamic static size mismatch: have %d, want %d", have, want)
+	}
+}
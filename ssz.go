@@ -8,8 +8,12 @@ package ssz
 import (
 	"fmt"
 	"io"
+	"math"
+	"runtime"
+	"slices"
 	"sync"
-	"unsafe"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // Object defines the methods a type needs to implement to be used as a ssz
@@ -46,6 +50,35 @@ type DynamicObject interface {
 	SizeSSZ(fixed bool) uint32
 }
 
+// FlatDecoder is an optional interface for static objects to decode lists of
+// themselves in one go from their back-to-back encodings, reading the fields
+// straight out of the input instead of defining them one by one via the Codec.
+// This saves the per item dispatch overhead on large lists of small objects
+// (e.g. withdrawals). The code generator implements it for containers of plain
+// integers and byte arrays if requested via -flat.
+//
+// The method is invoked on a nil receiver, with len(objs) * SizeSSZ() bytes of
+// input and all the items already allocated. Any returned error aborts decoding.
+// It's only used when decoding from a buffer with no per item hooks (e.g. tracing)
+// and no Strict, ResyncElements or TruncateOversized mode configured.
+type FlatDecoder[T any] interface {
+	DecodeFlatSSZ(blob []byte, objs []T) error
+}
+
+// AssertStatic is a no-op helper that fails compilation if T is not a static ssz
+// object (e.g. it implements the dynamic SizeSSZ signature by mistake). It is
+// meant to be instantiated next to the type definition:
+//
+//	var _ = ssz.AssertStatic[*Withdrawal]
+func AssertStatic[T StaticObject]() {}
+
+// AssertDynamic is a no-op helper that fails compilation if T is not a dynamic
+// ssz object (e.g. it implements the static SizeSSZ signature by mistake). It is
+// meant to be instantiated next to the type definition:
+//
+//	var _ = ssz.AssertDynamic[*ExecutionPayload]
+func AssertDynamic[T DynamicObject]() {}
+
 // encoderPool is a pool of SSZ encoders to reuse some tiny internal helpers
 // without hitting Go's GC constantly.
 var encoderPool = sync.Pool{
@@ -76,24 +109,96 @@ var hasherPool = sync.Pool{
 	},
 }
 
+// EncoderConfig contains optional settings to customize the behavior of a
+// streaming encoding run. The zero value (or a nil config) is the default.
+type EncoderConfig struct {
+	// FlushInterval is the number of bytes after which OnFlush is invoked. If
+	// zero, OnFlush is only invoked once, after the object is fully encoded.
+	FlushInterval uint64
+
+	// OnFlush is an optional callback invoked with the total number of bytes
+	// written so far, every time at least FlushInterval new bytes have been
+	// written into the stream and once more at the end for any remainder. It
+	// allows the caller to interleave flushing compressors or hashers, or to
+	// apply backpressure while encoding very large objects. Returning an error
+	// aborts encoding.
+	OnFlush func(written uint64) error
+}
+
 // EncodeToStream serializes the object into a data stream. Do not use this
 // method with a bytes.Buffer to write into a []byte slice, as that will do
 // double the byte copying. For that use case, use EncodeToBytes instead.
 func EncodeToStream(w io.Writer, obj Object) error {
+	return EncodeToStreamWithConfig(w, obj, nil)
+}
+
+// EncodeToStreamWithConfig is analogous to EncodeToStream, but allows the
+// caller to customize the encoding behavior via a config.
+func EncodeToStreamWithConfig(w io.Writer, obj Object, cfg *EncoderConfig) (err error) {
+	return encodeToStream(w, obj, cfg, ForkUnknown, nil)
+}
+
+// EncodeToStreamOnFork is analogous to EncodeToStream, but encodes monolithic
+// objects with the layout of a specific fork.
+func EncodeToStreamOnFork(w io.Writer, obj Object, fork Fork) error {
+	return encodeToStream(w, obj, nil, fork, nil)
+}
+
+// encodeToStream is the internal implementation of EncodeToStreamWithConfig,
+// encoding monolithic objects on the requested fork. If a hasher is given, the
+// encoder feeds it with every field it serializes too.
+func encodeToStream(w io.Writer, obj Object, cfg *EncoderConfig, fork Fork, has *Hasher) (err error) {
 	codec := encoderPool.Get().(*Codec)
 	defer encoderPool.Put(codec)
-
-	codec.enc.outWriter, codec.enc.err = w, nil
+	codec.fork = fork
+	codec.has = has
+
+	// Convert any size overflows detected by the size helpers into errors
+	defer func() {
+		if r := recover(); r != nil {
+			overflow, ok := r.(sizeOverflow)
+			if !ok {
+				panic(r)
+			}
+			codec.enc.outWriter, codec.enc.flusher = nil, flushWriter{}
+			codec.enc.stager.w, codec.enc.stager.n = nil, 0
+			codec.has = nil
+			err = overflow.err
+		}
+	}()
+
+	if cfg != nil && cfg.OnFlush != nil {
+		codec.enc.flusher = flushWriter{w: w, interval: cfg.FlushInterval, onFlush: cfg.OnFlush}
+		w = &codec.enc.flusher
+	}
+	// Stage small writes, but never past the flush interval
+	codec.enc.stager.w, codec.enc.stager.limit = w, stageSize
+	if interval := codec.enc.flusher.interval; interval > 0 && interval < stageSize {
+		codec.enc.stager.limit = int(interval)
+	}
+	codec.enc.outWriter, codec.enc.err = &codec.enc.stager, nil
 	switch v := obj.(type) {
 	case StaticObject:
 		v.DefineSSZ(codec)
 	case DynamicObject:
-		codec.enc.offsetDynamics(v.SizeSSZ(true))
+		codec.enc.offsetDynamics(sizeOnFork(v, fork, true))
 		v.DefineSSZ(codec)
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
+	if codec.enc.err == nil {
+		codec.enc.err = codec.enc.stager.flush()
+	}
+	codec.enc.stager.w, codec.enc.stager.n = nil, 0
+
+	if codec.enc.flusher.w != nil {
+		if codec.enc.err == nil {
+			codec.enc.err = codec.enc.flusher.flush()
+		}
+		codec.enc.flusher = flushWriter{}
+	}
 	codec.enc.outWriter = nil
+	codec.has = nil
 	return codec.enc.err
 }
 
@@ -102,36 +207,243 @@ func EncodeToStream(w io.Writer, obj Object) error {
 // would double the memory use for the temporary buffer. For that use case, use
 // EncodeToStream instead.
 func EncodeToBytes(buf []byte, obj Object) error {
-	// Sanity check that we have enough space to serialize into
-	if size := Size(obj); int(size) > len(buf) {
-		return fmt.Errorf("%w: buffer %d bytes, object %d bytes", ErrBufferTooSmall, len(buf), size)
+	return encodeToBytes(buf, obj, ForkUnknown, false)
+}
+
+// EncodeToBytesOnFork is analogous to EncodeToBytes, but encodes monolithic
+// objects with the layout of a specific fork.
+func EncodeToBytesOnFork(buf []byte, obj Object, fork Fork) error {
+	return encodeToBytes(buf, obj, fork, false)
+}
+
+// EncodeToBytesConcurrent serializes the object into a byte buffer, encoding the
+// large fields (e.g. the validator registry of a beacon state) on potentially
+// multiple concurrent threads. Since the position of every field in the output
+// is known upfront, each thread writes directly into its own region of the
+// buffer. This is useful for very large objects, but will place a bigger load
+// on your CPU and GC.
+func EncodeToBytesConcurrent(buf []byte, obj Object) error {
+	return encodeToBytes(buf, obj, ForkUnknown, true)
+}
+
+// EncodeAppend serializes the object, appending it to the end of the buffer and
+// returning the extended buffer, growing it if needed (i.e. the MarshalSSZTo
+// semantics of other libraries). This allows encoding multiple objects into one
+// buffer without intermediate copies. The offsets of every object are relative
+// to its own start, so each appended encoding is standalone.
+//
+// On failure, the original buffer is returned, with any partially encoded data
+// beyond its length.
+func EncodeAppend(dst []byte, obj Object) ([]byte, error) {
+	size, err := SizeChecked(obj)
+	if err != nil {
+		return dst, err
+	}
+	start := len(dst)
+
+	dst = slices.Grow(dst, int(size))[:start+int(size)]
+	if err := encodeToBytes(dst[start:], obj, ForkUnknown, false); err != nil {
+		return dst[:start], err
 	}
+	return dst, nil
+}
+
+// encodeToBytes is the internal implementation of EncodeToBytes, optionally
+// encoding monolithic objects on the requested fork and optionally offloading
+// large fields onto background threads.
+func encodeToBytes(buf []byte, obj Object, fork Fork, threads bool) (err error) {
 	codec := encoderPool.Get().(*Codec)
 	defer encoderPool.Put(codec)
+	codec.fork = fork
+
+	// Convert any size overflows detected by the size helpers into errors
+	defer func() {
+		if r := recover(); r != nil {
+			overflow, ok := r.(sizeOverflow)
+			if !ok {
+				panic(r)
+			}
+			codec.enc.outBuffer, codec.enc.workers = nil, nil
+			err = overflow.err
+		}
+	}()
+	// Sanity check that we have enough space to serialize into
+	if size := SizeOnFork(obj, fork); uint64(size) > uint64(len(buf)) {
+		return fmt.Errorf("%w: buffer %d bytes, object %d bytes", ErrBufferTooSmall, len(buf), size)
+	}
 
 	codec.enc.outBuffer, codec.enc.err = buf, nil
+	if threads {
+		codec.enc.workers = new(errgroup.Group)
+		codec.enc.workers.SetLimit(runtime.NumCPU())
+	}
 	switch v := obj.(type) {
 	case StaticObject:
 		v.DefineSSZ(codec)
 	case DynamicObject:
-		codec.enc.offsetDynamics(v.SizeSSZ(true))
+		codec.enc.offsetDynamics(sizeOnFork(v, fork, true))
 		v.DefineSSZ(codec)
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
+	if threads {
+		if err := codec.enc.workers.Wait(); err != nil && codec.enc.err == nil {
+			codec.enc.err = err
+		}
+		codec.enc.workers = nil
+	}
 	codec.enc.outBuffer = nil
 	return codec.enc.err
 }
 
+// DefaultMaxDepth is the maximum nesting depth of dynamic data permitted during
+// decoding if not configured otherwise, deep enough for any sane type.
+const DefaultMaxDepth = 32
+
+// DecoderConfig contains optional settings to customize the behavior of a
+// decoding run. The zero value (or a nil config) is the default behavior.
+type DecoderConfig struct {
+	// Allocator is an optional memory source for the byte slices and objects
+	// that need to be created during decoding. If nil, Go's allocator is used.
+	Allocator Allocator
+
+	// MaxAlloc is an optional limit on the number of bytes the decoder may
+	// allocate for new byte slices, list backing arrays and objects. Memory
+	// already held by the object being decoded into is reused and is not
+	// charged. If zero, allocations are not limited.
+	MaxAlloc uint64
+
+	// MaxDepth is an optional limit on the nesting depth of the dynamic data
+	// being decoded, guarding against crafted messages with pathologically deep
+	// nesting (e.g. recursive types) exhausting the stack. The outermost object
+	// is one level deep, and every dynamic object or list nested into it adds a
+	// level (e.g. the items of a list field of a container are 3 levels deep).
+	// If zero, DefaultMaxDepth is used.
+	MaxDepth int
+
+	// Strict requests that the decoder reject any encoding which would not
+	// round-trip byte-for-byte. The standard checks already reject padding,
+	// non-minimal offsets and junk in bitfields; on top, strict mode verifies
+	// that the decoded object re-encodes to the same size as its input (which
+	// catches asymmetric encoder/decoder definitions) and overrides any of the
+	// leniency options that would permit non-canonical inputs.
+	Strict bool
+
+	// ForwardCompatible permits decoding containers that have more fields than
+	// the Go type knows about, as long as they are appended at the tail (e.g.
+	// newer fork objects decoded by older code). The unknown fields are skipped.
+	//
+	// Since SSZ is not self-describing, unknown fields can only be detected by
+	// the excess space they take up: for static containers decoded at the top
+	// level, any data beyond the known fields is skipped; for dynamic ones, any
+	// data between the known static fields and the first dynamic content is
+	// skipped. If that data consists solely of valid offsets, they are taken to
+	// be those of unknown dynamic fields: the first one bounds the content of
+	// the last known dynamic field and the unknown content is skipped. Unknown
+	// dynamic fields mixed with unknown static ones cannot be told apart, their
+	// content is attributed to the last known dynamic field.
+	//
+	// This option is ignored in strict mode.
+	ForwardCompatible bool
+
+	// RelaxFirstOffset permits decoding containers whose first offset points
+	// past the end of their static section, as emitted by some older encoders
+	// that pad it. The padding is skipped and each occurrence is recorded as a
+	// warning, returned by the WithWarnings decoders, instead of failing with
+	// ErrFirstOffsetMismatch. This is meant for ingesting archival data.
+	//
+	// This option is ignored in strict mode.
+	RelaxFirstOffset bool
+
+	// ResyncElements permits decoding lists of objects that contain some corrupt
+	// elements (e.g. when ingesting slightly damaged archival dumps). Since the
+	// bounds of each element are known upfront (from the item size or from the
+	// offsets), an element failing to decode is skipped over and decoding goes
+	// on with the next one. The failures are returned joined together, each as
+	// an *ElementError, whilst the skipped elements are left partially decoded.
+	//
+	// Failures outside of list elements (e.g. bad offsets of the list itself),
+	// and running out of the MaxAlloc budget still halt decoding. Concurrent
+	// decoding is disabled in this mode.
+	ResyncElements bool
+
+	// TruncateOversized permits decoding lists and binary blobs exceeding their
+	// maximum item count or size (e.g. for analytics over data violating newer
+	// limits). Only the items up to the limit are kept, the rest are skipped,
+	// and each occurrence is recorded as a warning, returned by the WithWarnings
+	// decoders, instead of failing with ErrMaxItemsExceeded or with
+	// ErrMaxLengthExceeded. Bitlists are never truncated, as that would need
+	// their length bit to be moved.
+	//
+	// This option is ignored in strict mode.
+	TruncateOversized bool
+
+	// StopList is an optional predicate invoked after every decoded item of the
+	// lists of objects, at any nesting level (e.g. to search the attestations of
+	// encoded blocks). If it returns true, the items decoded so far (including
+	// the current one) are kept, the rest of the list is skipped over unparsed,
+	// and decoding goes on with the fields after the list.
+	//
+	// This option is ignored in strict mode. Concurrent decoding is disabled if
+	// it's set.
+	StopList func(index uint32, item Object) bool
+
+	// Concurrent permits decoding large lists of static objects (e.g. the
+	// validator registry of a beacon state) on multiple threads. Since the
+	// items are of fixed size, their positions in the input are known upfront.
+	// This is useful for very large objects, but will place a bigger load on
+	// your CPU and GC.
+	//
+	// This option is ignored when decoding from a stream, or if an Allocator,
+	// OnField tracer or StopList predicate is configured. Any nested allocations
+	// within the decoded items are not charged against MaxAlloc.
+	Concurrent bool
+
+	// OnField is an optional callback invoked for every field as the decoder
+	// walks the structure, useful for debugging or annotating SSZ blobs.
+	OnField FieldTracer
+}
+
 // DecodeFromStream parses an object with the given size out of a stream. Do not
 // use this method with a bytes.Buffer to read from a []byte slice, as that will
 // double the byte copying. For that use case, use DecodeFromBytes instead.
 func DecodeFromStream(r io.Reader, obj Object, size uint32) error {
+	return DecodeFromStreamWithConfig(r, obj, size, nil)
+}
+
+// DecodeFromStreamWithConfig is analogous to DecodeFromStream, but allows the
+// caller to customize the decoding behavior via a config.
+func DecodeFromStreamWithConfig(r io.Reader, obj Object, size uint32, cfg *DecoderConfig) error {
+	_, err := DecodeFromStreamWithWarnings(r, obj, size, cfg)
+	return err
+}
+
+// DecodeFromStreamWithWarnings is analogous to DecodeFromStreamWithConfig, but
+// also returns the non-canonical encodings that were accepted whilst decoding
+// (see RelaxFirstOffset and TruncateOversized), each as a *DecodeError.
+func DecodeFromStreamWithWarnings(r io.Reader, obj Object, size uint32, cfg *DecoderConfig) ([]error, error) {
+	return decodeFromStream(r, obj, size, cfg, ForkUnknown)
+}
+
+// DecodeFromStreamOnFork is analogous to DecodeFromStream, but decodes monolithic
+// objects with the layout of a specific fork.
+func DecodeFromStreamOnFork(r io.Reader, obj Object, size uint32, fork Fork) error {
+	_, err := decodeFromStream(r, obj, size, nil, fork)
+	return err
+}
+
+// decodeFromStream is the internal implementation of DecodeFromStreamWithWarnings,
+// decoding monolithic objects on the requested fork.
+func decodeFromStream(r io.Reader, obj Object, size uint32, cfg *DecoderConfig, fork Fork) ([]error, error) {
 	// Retrieve a new decoder codec and set its data source
 	codec := decoderPool.Get().(*Codec)
 	defer decoderPool.Put(codec)
+	codec.fork = fork
 
-	codec.dec.inReader = r
+	codec.dec.inExact = ExactReader{r: r, left: size}
+	codec.dec.inReader = &codec.dec.inExact
+	codec.dec.inRead = 0
+	codec.dec.configure(cfg)
 
 	// Start a decoding round with length enforcement in place
 	codec.dec.descendIntoSlot(size)
@@ -140,21 +452,54 @@ func DecodeFromStream(r io.Reader, obj Object, size uint32) error {
 	case StaticObject:
 		v.DefineSSZ(codec)
 	case DynamicObject:
-		codec.dec.startDynamics(v.SizeSSZ(true))
+		codec.dec.startDynamics(sizeOnFork(v, fork, true))
 		v.DefineSSZ(codec)
-		codec.dec.flushDynamics()
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
 	codec.dec.ascendFromSlot()
 
-	// Retrieve any errors, zero out the source and return
-	err := codec.dec.err
+	// Running out of the declared size at a read boundary is still a truncation
+	if codec.dec.err == io.EOF {
+		codec.dec.err = io.ErrUnexpectedEOF
+	}
+	// In strict mode, ensure the object would re-encode into the same size
+	if codec.dec.strict && codec.dec.err == nil {
+		if have := SizeOnFork(obj, fork); have != size {
+			codec.dec.err = fmt.Errorf("%w: decoded %d bytes, re-encodes to %d bytes", ErrNonCanonicalEncoding, size, have)
+		}
+	}
+	// Retrieve any errors and warnings, zero out the source and return
+	err := codec.dec.decodeError()
+	warnings := codec.dec.warnings
 
 	codec.dec.inReader = nil
+	codec.dec.inExact = ExactReader{}
 	codec.dec.err = nil
+	codec.dec.configure(nil)
 
-	return err
+	return warnings, err
+}
+
+// DecodeNew allocates a new object of type T and parses it out of a stream with
+// the given size, for callers knowing the type statically, but not having an
+// instance to decode into (e.g. RPC handlers).
+func DecodeNew[T newableObject[U], U any](r io.Reader, size uint32) (T, error) {
+	obj := T(new(U))
+	if err := DecodeFromStream(r, obj, size); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// DecodeNewFromBytes is analogous to DecodeNew, but parses the new object from a
+// byte buffer.
+func DecodeNewFromBytes[T newableObject[U], U any](blob []byte) (T, error) {
+	obj := T(new(U))
+	if err := DecodeFromBytes(blob, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
 }
 
 // DecodeFromBytes parses an object from a byte buffer. Do not use this method
@@ -162,16 +507,50 @@ func DecodeFromStream(r io.Reader, obj Object, size uint32) error {
 // would double the memory use for the temporary buffer. For that use case, use
 // DecodeFromStream instead.
 func DecodeFromBytes(blob []byte, obj Object) error {
+	return DecodeFromBytesWithConfig(blob, obj, nil)
+}
+
+// DecodeFromBytesWithConfig is analogous to DecodeFromBytes, but allows the
+// caller to customize the decoding behavior via a config.
+func DecodeFromBytesWithConfig(blob []byte, obj Object, cfg *DecoderConfig) error {
+	_, err := DecodeFromBytesWithWarnings(blob, obj, cfg)
+	return err
+}
+
+// DecodeFromBytesWithWarnings is analogous to DecodeFromBytesWithConfig, but
+// also returns the non-canonical encodings that were accepted whilst decoding
+// (see RelaxFirstOffset and TruncateOversized), each as a *DecodeError.
+func DecodeFromBytesWithWarnings(blob []byte, obj Object, cfg *DecoderConfig) ([]error, error) {
+	return decodeFromBytes(blob, obj, cfg, ForkUnknown)
+}
+
+// DecodeFromBytesOnFork is analogous to DecodeFromBytes, but decodes monolithic
+// objects with the layout of a specific fork.
+func DecodeFromBytesOnFork(blob []byte, obj Object, fork Fork) error {
+	_, err := decodeFromBytes(blob, obj, nil, fork)
+	return err
+}
+
+// decodeFromBytes is the internal implementation of DecodeFromBytesWithWarnings,
+// decoding monolithic objects on the requested fork.
+func decodeFromBytes(blob []byte, obj Object, cfg *DecoderConfig, fork Fork) ([]error, error) {
 	// Reject decoding from an empty slice
 	if len(blob) == 0 {
-		return io.ErrUnexpectedEOF
+		return nil, newDecodeError(io.ErrUnexpectedEOF, 0, nil)
+	}
+	// Reject decoding from a slice not addressable by 4 byte offsets
+	if uint64(len(blob)) > math.MaxUint32 {
+		return nil, newDecodeError(fmt.Errorf("%w: %d bytes", ErrObjectTooLarge, len(blob)), 0, nil)
 	}
 	// Retrieve a new decoder codec and set its data source
 	codec := decoderPool.Get().(*Codec)
 	defer decoderPool.Put(codec)
+	codec.fork = fork
 
 	codec.dec.inBuffer = blob
-	codec.dec.inBufEnd = uintptr(unsafe.Pointer(&blob[0])) + uintptr(len(blob))
+	codec.dec.inBufBeg = bufferAddr(blob)
+	codec.dec.inBufEnd = codec.dec.inBufBeg + uintptr(len(blob))
+	codec.dec.configure(cfg)
 
 	// Start a decoding round with length enforcement in place
 	codec.dec.descendIntoSlot(uint32(len(blob)))
@@ -180,32 +559,88 @@ func DecodeFromBytes(blob []byte, obj Object) error {
 	case StaticObject:
 		v.DefineSSZ(codec)
 	case DynamicObject:
-		codec.dec.startDynamics(v.SizeSSZ(true))
+		codec.dec.startDynamics(sizeOnFork(v, fork, true))
 		v.DefineSSZ(codec)
-		codec.dec.flushDynamics()
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
 	codec.dec.ascendFromSlot()
 
-	// Retrieve any errors, zero out the source and return
-	err := codec.dec.err
+	// In strict mode, ensure the object would re-encode into the same size
+	if codec.dec.strict && codec.dec.err == nil {
+		if have := SizeOnFork(obj, fork); have != uint32(len(blob)) {
+			codec.dec.err = fmt.Errorf("%w: decoded %d bytes, re-encodes to %d bytes", ErrNonCanonicalEncoding, len(blob), have)
+		}
+	}
+	// Retrieve any errors and warnings, zero out the source and return
+	err := codec.dec.decodeError()
+	warnings := codec.dec.warnings
 
+	codec.dec.inBufBeg = 0
 	codec.dec.inBufEnd = 0
 	codec.dec.inBuffer = nil
 	codec.dec.err = nil
+	codec.dec.configure(nil)
 
-	return err
+	return warnings, err
 }
 
 // HashSequential computes the ssz merkle root of the object on a single thread.
 // This is useful for processing small objects with stable runtime and O(1) GC
 // guarantees.
+//
+// Note, values that cannot be merkleized (e.g. big.Int uint256 fields out of
+// range) are hashed as zero. Use HashSequentialChecked to detect them.
 func HashSequential(obj Object) [32]byte {
+	return HashSequentialOnFork(obj, ForkUnknown)
+}
+
+// HashSequentialOnFork is analogous to HashSequential, but hashes monolithic
+// objects with the layout of a specific fork.
+func HashSequentialOnFork(obj Object, fork Fork) [32]byte {
+	root, _ := hashObject(obj, fork, false)
+	return root
+}
+
+// HashSequentialChecked is analogous to HashSequential, but returns an error if
+// the object contains values that cannot be merkleized.
+func HashSequentialChecked(obj Object) ([32]byte, error) {
+	return hashObject(obj, ForkUnknown, false)
+}
+
+// HashConcurrent computes the ssz merkle root of the object on potentially multiple
+// concurrent threads (iff some data segments are large enough to be worth it). This
+// is useful for processing large objects, but will place a bigger load on your CPU
+// and GC; and might be more variable timing wise depending on other load.
+//
+// Note, values that cannot be merkleized (e.g. big.Int uint256 fields out of
+// range) are hashed as zero. Use HashConcurrentChecked to detect them.
+func HashConcurrent(obj Object) [32]byte {
+	return HashConcurrentOnFork(obj, ForkUnknown)
+}
+
+// HashConcurrentOnFork is analogous to HashConcurrent, but hashes monolithic
+// objects with the layout of a specific fork.
+func HashConcurrentOnFork(obj Object, fork Fork) [32]byte {
+	root, _ := hashObject(obj, fork, true)
+	return root
+}
+
+// HashConcurrentChecked is analogous to HashConcurrent, but returns an error if
+// the object contains values that cannot be merkleized.
+func HashConcurrentChecked(obj Object) ([32]byte, error) {
+	return hashObject(obj, ForkUnknown, true)
+}
+
+// hashObject computes the ssz merkle root of the object on a specific fork, along
+// with the first error encountered while hashing.
+func hashObject(obj Object, fork Fork, threads bool) ([32]byte, error) {
 	codec := hasherPool.Get().(*Codec)
 	defer hasherPool.Put(codec)
 	defer codec.has.Reset()
+	codec.fork = fork
 
+	codec.has.threads = threads
 	codec.has.descendLayer()
 	obj.DefineSSZ(codec)
 	codec.has.ascendLayer(0)
@@ -213,40 +648,126 @@ func HashSequential(obj Object) [32]byte {
 	if len(codec.has.chunks) != 1 {
 		panic(fmt.Sprintf("unfinished hashing: left %v", codec.has.groups))
 	}
-	return codec.has.chunks[0]
+	return codec.has.chunks[0], codec.has.err
 }
 
-// HashConcurrent computes the ssz merkle root of the object on potentially multiple
-// concurrent threads (iff some data segments are large enough to be worth it). This
-// is useful for processing large objects, but will place a bigger load on your CPU
-// and GC; and might be more variable timing wise depending on other load.
-func HashConcurrent(obj Object) [32]byte {
+// HashTree computes the full ssz merkle tree of the object, returning its root
+// node. The tree can be used to create proofs of any of its nodes (TreeNode.Prove),
+// or exported node by node with their generalized indices (TreeNode.Walk) to be
+// persisted and served later without re-merkleizing the object.
+//
+// Note, the tree holds every chunk and branch of the object, so it takes a few
+// times the memory of the object itself. Branch hashes are computed lazily, the
+// first time they are needed.
+func HashTree(obj Object) *TreeNode {
 	codec := hasherPool.Get().(*Codec)
 	defer hasherPool.Put(codec)
 	defer codec.has.Reset()
+	codec.fork = ForkUnknown
 
-	codec.has.threads = true
+	codec.has.tree = &treeRecorder{layers: make([][]*TreeNode, 1)} // root goes into the base layer
 	codec.has.descendLayer()
 	obj.DefineSSZ(codec)
 	codec.has.ascendLayer(0)
 
+	if layers := codec.has.tree.layers; len(layers) != 1 || len(layers[0]) != 1 {
+		panic(fmt.Sprintf("unfinished tree: left %d layers", len(layers)))
+	}
+	return codec.has.tree.layers[0][0]
+}
+
+// EncodeAndHash serializes the object into a data stream and computes its ssz
+// merkle root at the same time, returning the number of bytes written and the
+// root. The object is walked only once, the hasher being fed with the fields
+// as they are encoded.
+//
+// Note, types defining a dedicated hasher via DefineHasher are still walked a
+// second time by it. If encoding fails, the returned root is zero.
+func EncodeAndHash(w io.Writer, obj Object) (uint32, [32]byte, error) {
+	codec := hasherPool.Get().(*Codec)
+	defer hasherPool.Put(codec)
+	defer codec.has.Reset()
+	codec.fork = ForkUnknown
+
+	counter := &countingWriter{w: w}
+
+	codec.has.descendLayer()
+	if err := encodeToStream(counter, obj, nil, ForkUnknown, codec.has); err != nil {
+		return uint32(counter.n), [32]byte{}, err
+	}
+	codec.has.ascendLayer(0)
+
 	if len(codec.has.chunks) != 1 {
 		panic(fmt.Sprintf("unfinished hashing: left %v", codec.has.groups))
 	}
-	return codec.has.chunks[0]
+	return uint32(counter.n), codec.has.chunks[0], codec.has.err
+}
+
+// countingWriter is an io.Writer tracking the number of bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n uint64
+}
+
+// Write implements io.Writer, forwarding the data to the wrapped writer.
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += uint64(n)
+	return n, err
 }
 
 // Size retrieves the size of a ssz object, independent if it's a static or a
 // dynamic one.
+//
+// Note, if the size helpers detect that the object is too large to be encoded
+// (i.e. its size overflows 4 bytes), this method panics. Use SizeChecked to get
+// the condition reported as an ErrObjectTooLarge error instead, the same way the
+// encoding methods do.
 func Size(obj Object) uint32 {
+	return SizeOnFork(obj, ForkUnknown)
+}
+
+// SizeOnFork is analogous to Size, but sizes monolithic objects with the layout
+// of a specific fork.
+func SizeOnFork(obj Object, fork Fork) uint32 {
 	var size uint32
 	switch v := obj.(type) {
 	case StaticObject:
 		size = v.SizeSSZ()
 	case DynamicObject:
-		size = v.SizeSSZ(false)
+		size = sizeOnFork(v, fork, false)
 	default:
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
 	return size
 }
+
+// StaticSize retrieves the size of the static section of a ssz object, i.e. the
+// size of its static fields and the offsets of its dynamic ones. For static
+// objects this is the same as Size, for dynamic ones it is the minimum size of
+// the encoding, independent of the object's contents.
+func StaticSize(obj Object) uint32 {
+	switch v := obj.(type) {
+	case StaticObject:
+		return v.SizeSSZ()
+	case DynamicObject:
+		return v.SizeSSZ(true)
+	default:
+		panic(fmt.Sprintf("unsupported type: %T", obj))
+	}
+}
+
+// SizeChecked is analogous to Size, but it reports objects too large to be
+// encoded as an ErrObjectTooLarge error instead of panicking.
+func SizeChecked(obj Object) (size uint32, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			overflow, ok := r.(sizeOverflow)
+			if !ok {
+				panic(r)
+			}
+			err = overflow.err
+		}
+	}()
+	return Size(obj), nil
+}
@@ -0,0 +1,216 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/descriptors"
+	"github.com/karalabe/ssz/tests/testtypes/random"
+)
+
+// Tests that encoding and hashing in one go produces the same results as the
+// individual operations, for all the field kinds hashed out of encoding order.
+func TestEncodeAndHash(t *testing.T) {
+	list := &testBigListType{Items: make([]uint64, 100)}
+	for i := range list.Items {
+		list.Items[i] = uint64(i)
+	}
+	state := new(types.BeaconState)
+	random.Fill(rand.New(rand.NewSource(1)), state)
+
+	described := new(descriptors.BeaconStateCapella)
+	random.Fill(rand.New(rand.NewSource(1)), described)
+
+	tests := []ssz.Object{
+		list,
+		state,
+		described,
+		&testFactoryType{
+			Statics:  []ssz.StaticObject{&types.Withdrawal{Index: 1}, &types.Withdrawal{Index: 2}},
+			Dynamics: []ssz.DynamicObject{&types.ExecutionPayload{ExtraData: []byte{0x01}}, &types.ExecutionPayload{BlockNumber: 2}},
+		},
+		&testRawPayloadType{Slot: 1, Payload: &types.ExecutionPayload{Transactions: [][]byte{{0x02}}}},
+		&testMixedContainer{
+			Payload: &testSplitPayload{Head: new(types.Checkpoint), Withdrawals: []*testSplitWithdrawal{{Index: 1}}},
+			Items:   []*testSplitWithdrawal{{Index: 2}, {Index: 3}},
+		},
+	}
+	for i, obj := range tests {
+		want := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(want, obj); err != nil {
+			t.Fatalf("test %d: failed to encode object: %v", i, err)
+		}
+		out := new(bytes.Buffer)
+		size, root, err := ssz.EncodeAndHash(out, obj)
+		if err != nil {
+			t.Fatalf("test %d: failed to encode and hash object: %v", i, err)
+		}
+		if !bytes.Equal(out.Bytes(), want) {
+			t.Errorf("test %d: encoding mismatch: have %x, want %x", i, out.Bytes(), want)
+		}
+		if size != uint32(len(want)) {
+			t.Errorf("test %d: size mismatch: have %d, want %d", i, size, len(want))
+		}
+		if have := ssz.HashSequential(obj); root != have {
+			t.Errorf("test %d: root mismatch: have %x, want %x", i, root, have)
+		}
+	}
+	// Ensure encoding failures are propagated
+	if _, _, err := ssz.EncodeAndHash(&testEncodeOversizedStream{make([]byte, 16)}, list); err == nil {
+		t.Errorf("encode and hash error mismatch: have nil, want stream full")
+	}
+}
+
+// Tests that encoding and hashing in one go walks the object only once, neither
+// running a separate hashing pass, nor sizing it after encoding.
+func TestEncodeAndHashSinglePass(t *testing.T) {
+	obj := &testCountingType{
+		Items:   []*testCountingItem{{Value: 1}, {Value: 2}, {Value: 3}},
+		Payload: &types.ExecutionPayload{ExtraData: []byte{0x01}},
+	}
+	size, root, err := ssz.EncodeAndHash(io.Discard, obj)
+	if err != nil {
+		t.Fatalf("failed to encode and hash object: %v", err)
+	}
+	if obj.defines != 1 || obj.sizes != 0 {
+		t.Errorf("object traversal mismatch: have %d walks and %d sizings, want 1 and 0", obj.defines, obj.sizes)
+	}
+	for i, item := range obj.Items {
+		if item.defines != 1 {
+			t.Errorf("item %d traversal mismatch: have %d walks, want 1", i, item.defines)
+		}
+	}
+	if want := ssz.Size(obj); size != want {
+		t.Errorf("size mismatch: have %d, want %d", size, want)
+	}
+	if want := ssz.HashSequential(obj); root != want {
+		t.Errorf("root mismatch: have %x, want %x", root, want)
+	}
+}
+
+// testCountingType is a dynamic type counting how many times it is walked and
+// fully sized.
+type testCountingType struct {
+	Items   []*testCountingItem
+	Payload *types.ExecutionPayload
+
+	defines int
+	sizes   int
+}
+
+func (t *testCountingType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 8
+	}
+	t.sizes++
+	return 8 + ssz.SizeSliceOfStaticObjects(t.Items) + ssz.SizeDynamicObject(t.Payload)
+}
+
+func (t *testCountingType) DefineSSZ(codec *ssz.Codec) {
+	t.defines++
+
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Items, 4)
+	ssz.DefineDynamicObjectOffset(codec, &t.Payload)
+
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Items, 4)
+	ssz.DefineDynamicObjectContent(codec, &t.Payload)
+}
+
+// testCountingItem is a static type counting how many times it is walked.
+type testCountingItem struct {
+	Value uint64
+
+	defines int
+}
+
+func (t *testCountingItem) SizeSSZ() uint32 { return 8 }
+
+func (t *testCountingItem) DefineSSZ(codec *ssz.Codec) {
+	t.defines++
+	ssz.DefineUint64(codec, &t.Value)
+}
+
+// Tests that objects can be decoded into freshly allocated instances, knowing
+// only their type.
+func TestDecodeNew(t *testing.T) {
+	want := &types.ExecutionPayload{BlockNumber: 1, ExtraData: []byte{0x02}, BaseFeePerGas: uint256.NewInt(3)}
+
+	blob := make([]byte, ssz.Size(want))
+	if err := ssz.EncodeToBytes(blob, want); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	have, err := ssz.DecodeNew[*types.ExecutionPayload](bytes.NewReader(blob), uint32(len(blob)))
+	if err != nil {
+		t.Fatalf("failed to stream decode new object: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("stream decoded mismatch: have %+v, want %+v", have, want)
+	}
+	have, err = ssz.DecodeNewFromBytes[*types.ExecutionPayload](blob)
+	if err != nil {
+		t.Fatalf("failed to decode new object: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("decoded mismatch: have %+v, want %+v", have, want)
+	}
+	// Ensure failures don't leak partially decoded objects
+	if obj, err := ssz.DecodeNewFromBytes[*types.Withdrawal](blob[:10]); err == nil || obj != nil {
+		t.Errorf("truncated decode mismatch: have %v, %v, want nil, error", obj, err)
+	}
+}
+
+// Tests that appending multiple objects into the same buffer produces the same
+// standalone encodings as encoding them one by one.
+func TestEncodeAppend(t *testing.T) {
+	objs := []ssz.Object{
+		&types.Withdrawal{Index: 1, Amount: 2},
+		&types.ExecutionPayload{BlockNumber: 3, ExtraData: []byte{0x04}, BaseFeePerGas: uint256.NewInt(5)},
+		&types.ExecutionPayload{Transactions: [][]byte{{0x06}, {0x07, 0x08}}, BaseFeePerGas: new(uint256.Int)},
+	}
+	var (
+		buf  = []byte{0xff}
+		want = []byte{0xff}
+		err  error
+	)
+	for i, obj := range objs {
+		if buf, err = ssz.EncodeAppend(buf, obj); err != nil {
+			t.Fatalf("object %d: failed to append: %v", i, err)
+		}
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("object %d: failed to encode: %v", i, err)
+		}
+		want = append(want, blob...)
+	}
+	if !bytes.Equal(buf, want) {
+		t.Fatalf("appended encoding mismatch: have %x, want %x", buf, want)
+	}
+	// Ensure every appended encoding decodes standalone
+	pos := 1
+	for i, obj := range objs {
+		size := int(ssz.Size(obj))
+		decoded := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(ssz.Object)
+		if err := ssz.DecodeFromBytes(buf[pos:pos+size], decoded); err != nil {
+			t.Fatalf("object %d: failed to decode: %v", i, err)
+		}
+		if !reflect.DeepEqual(decoded, obj) {
+			t.Errorf("object %d: decoded mismatch: have %+v, want %+v", i, decoded, obj)
+		}
+		pos += size
+	}
+	// Ensure failures leave the buffer length untouched
+	failing := &testBoundedType{Graffiti: []byte{0x01}}
+	if have, err := ssz.EncodeAppend(buf, failing); err == nil || len(have) != len(buf) {
+		t.Errorf("failed append mismatch: have %d bytes, %v, want %d bytes, error", len(have), err, len(buf))
+	}
+}
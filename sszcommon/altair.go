@@ -0,0 +1,80 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon
+
+//go:generate go run -cover ../cmd/sszgen -type SyncAggregate -out gen_sync_aggregate_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SyncCommittee -out gen_sync_committee_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockBodyAltair -out gen_beacon_block_body_altair_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockAltair -out gen_beacon_block_altair_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedBeaconBlockAltair -out gen_signed_beacon_block_altair_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconStateAltair -out gen_beacon_state_altair_ssz.go
+
+// SyncAggregate is the aggregated signature of the sync committee.
+type SyncAggregate struct {
+	SyncCommitteeBits      [64]byte `ssz-size:"512" ssz:"bits"`
+	SyncCommitteeSignature BLSSignature
+}
+
+// SyncCommittee is the set of validators in the sync committee.
+type SyncCommittee struct {
+	Pubkeys         [512][48]byte
+	AggregatePubkey BLSPubkey
+}
+
+// BeaconBlockBodyAltair is the body of an altair beacon block.
+type BeaconBlockBodyAltair struct {
+	RandaoReveal      BLSSignature
+	Eth1Data          *Eth1Data
+	Graffiti          Hash
+	ProposerSlashings []*ProposerSlashing    `ssz-max:"16"`
+	AttesterSlashings []*AttesterSlashing    `ssz-max:"2"`
+	Attestations      []*Attestation         `ssz-max:"128"`
+	Deposits          []*Deposit             `ssz-max:"16"`
+	VoluntaryExits    []*SignedVoluntaryExit `ssz-max:"16"`
+	SyncAggregate     *SyncAggregate
+}
+
+// BeaconBlockAltair is an altair beacon block.
+type BeaconBlockAltair struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    Hash
+	StateRoot     Hash
+	Body          *BeaconBlockBodyAltair
+}
+
+// SignedBeaconBlockAltair is an altair beacon block signed by its proposer.
+type SignedBeaconBlockAltair struct {
+	Message   *BeaconBlockAltair
+	Signature BLSSignature
+}
+
+// BeaconStateAltair is the altair beacon state.
+type BeaconStateAltair struct {
+	GenesisTime                 uint64
+	GenesisValidatorsRoot       Hash
+	Slot                        uint64
+	Fork                        *Fork
+	LatestBlockHeader           *BeaconBlockHeader
+	BlockRoots                  [8192][32]byte
+	StateRoots                  [8192][32]byte
+	HistoricalRoots             [][32]byte `ssz-max:"16777216"`
+	Eth1Data                    *Eth1Data
+	Eth1DataVotes               []*Eth1Data `ssz-max:"2048"`
+	Eth1DepositIndex            uint64
+	Validators                  []*Validator `ssz-max:"1099511627776"`
+	Balances                    []uint64     `ssz-max:"1099511627776"`
+	RandaoMixes                 [65536][32]byte
+	Slashings                   [8192]uint64
+	PreviousEpochParticipation  []byte  `ssz-max:"1099511627776"`
+	CurrentEpochParticipation   []byte  `ssz-max:"1099511627776"`
+	JustificationBits           [1]byte `ssz-size:"4" ssz:"bits"`
+	PreviousJustifiedCheckpoint *Checkpoint
+	CurrentJustifiedCheckpoint  *Checkpoint
+	FinalizedCheckpoint         *Checkpoint
+	InactivityScores            []uint64 `ssz-max:"1099511627776"`
+	CurrentSyncCommittee        *SyncCommittee
+	NextSyncCommittee           *SyncCommittee
+}
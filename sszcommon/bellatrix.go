@@ -0,0 +1,109 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon
+
+import "github.com/holiman/uint256"
+
+//go:generate go run -cover ../cmd/sszgen -type ExecutionPayload -out gen_execution_payload_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type ExecutionPayloadHeader -out gen_execution_payload_header_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockBodyBellatrix -out gen_beacon_block_body_bellatrix_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockBellatrix -out gen_beacon_block_bellatrix_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedBeaconBlockBellatrix -out gen_signed_beacon_block_bellatrix_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconStateBellatrix -out gen_beacon_state_bellatrix_ssz.go
+
+// ExecutionPayload is the bellatrix execution layer block.
+type ExecutionPayload struct {
+	ParentHash    Hash
+	FeeRecipient  Address
+	StateRoot     Hash
+	ReceiptsRoot  Hash
+	LogsBloom     LogsBloom
+	PrevRandao    Hash
+	BlockNumber   uint64
+	GasLimit      uint64
+	GasUsed       uint64
+	Timestamp     uint64
+	ExtraData     []byte `ssz-max:"32"`
+	BaseFeePerGas *uint256.Int
+	BlockHash     Hash
+	Transactions  [][]byte `ssz-max:"1048576,1073741824"`
+}
+
+// ExecutionPayloadHeader is the bellatrix execution layer block header, with
+// the transactions replaced by their root.
+type ExecutionPayloadHeader struct {
+	ParentHash       Hash
+	FeeRecipient     Address
+	StateRoot        Hash
+	ReceiptsRoot     Hash
+	LogsBloom        LogsBloom
+	PrevRandao       Hash
+	BlockNumber      uint64
+	GasLimit         uint64
+	GasUsed          uint64
+	Timestamp        uint64
+	ExtraData        []byte `ssz-max:"32"`
+	BaseFeePerGas    *uint256.Int
+	BlockHash        Hash
+	TransactionsRoot Hash
+}
+
+// BeaconBlockBodyBellatrix is the body of a bellatrix beacon block.
+type BeaconBlockBodyBellatrix struct {
+	RandaoReveal      BLSSignature
+	Eth1Data          *Eth1Data
+	Graffiti          Hash
+	ProposerSlashings []*ProposerSlashing    `ssz-max:"16"`
+	AttesterSlashings []*AttesterSlashing    `ssz-max:"2"`
+	Attestations      []*Attestation         `ssz-max:"128"`
+	Deposits          []*Deposit             `ssz-max:"16"`
+	VoluntaryExits    []*SignedVoluntaryExit `ssz-max:"16"`
+	SyncAggregate     *SyncAggregate
+	ExecutionPayload  *ExecutionPayload
+}
+
+// BeaconBlockBellatrix is a bellatrix beacon block.
+type BeaconBlockBellatrix struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    Hash
+	StateRoot     Hash
+	Body          *BeaconBlockBodyBellatrix
+}
+
+// SignedBeaconBlockBellatrix is a bellatrix beacon block signed by its proposer.
+type SignedBeaconBlockBellatrix struct {
+	Message   *BeaconBlockBellatrix
+	Signature BLSSignature
+}
+
+// BeaconStateBellatrix is the bellatrix beacon state.
+type BeaconStateBellatrix struct {
+	GenesisTime                  uint64
+	GenesisValidatorsRoot        Hash
+	Slot                         uint64
+	Fork                         *Fork
+	LatestBlockHeader            *BeaconBlockHeader
+	BlockRoots                   [8192][32]byte
+	StateRoots                   [8192][32]byte
+	HistoricalRoots              [][32]byte `ssz-max:"16777216"`
+	Eth1Data                     *Eth1Data
+	Eth1DataVotes                []*Eth1Data `ssz-max:"2048"`
+	Eth1DepositIndex             uint64
+	Validators                   []*Validator `ssz-max:"1099511627776"`
+	Balances                     []uint64     `ssz-max:"1099511627776"`
+	RandaoMixes                  [65536][32]byte
+	Slashings                    [8192]uint64
+	PreviousEpochParticipation   []byte  `ssz-max:"1099511627776"`
+	CurrentEpochParticipation    []byte  `ssz-max:"1099511627776"`
+	JustificationBits            [1]byte `ssz-size:"4" ssz:"bits"`
+	PreviousJustifiedCheckpoint  *Checkpoint
+	CurrentJustifiedCheckpoint   *Checkpoint
+	FinalizedCheckpoint          *Checkpoint
+	InactivityScores             []uint64 `ssz-max:"1099511627776"`
+	CurrentSyncCommittee         *SyncCommittee
+	NextSyncCommittee            *SyncCommittee
+	LatestExecutionPayloadHeader *ExecutionPayloadHeader
+}
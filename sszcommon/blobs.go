@@ -0,0 +1,38 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon
+
+//go:generate go run -cover ../cmd/sszgen -type BlobIdentifier -out gen_blob_identifier_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BlobSidecar -out gen_blob_sidecar_ssz.go
+
+// Blob is a 131072 byte (4096 field elements) blob of data (EIP-4844).
+type Blob [131072]byte
+
+// KZGCommitment is a 48 byte KZG commitment to a blob.
+type KZGCommitment [48]byte
+
+// KZGProof is a 48 byte KZG proof of a blob.
+type KZGProof [48]byte
+
+// BlobIdentifier is the (block root, index) pair identifying a blob sidecar in
+// the req/resp protocol.
+type BlobIdentifier struct {
+	BlockRoot Hash
+	Index     uint64
+}
+
+// BlobSidecar is a blob gossiped alongside its beacon block, along with the
+// proof of its commitment's inclusion in the block body.
+//
+// Note, the sidecar is 131 KB, so it is better passed around by pointer and
+// encoded into a reused buffer, than instantiated on the stack.
+type BlobSidecar struct {
+	Index                       uint64
+	Blob                        Blob
+	KZGCommitment               KZGCommitment
+	KZGProof                    KZGProof
+	SignedBlockHeader           *SignedBeaconBlockHeader
+	KZGCommitmentInclusionProof [17][32]byte
+}
@@ -0,0 +1,144 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz/sszexec"
+)
+
+//go:generate go run -cover ../cmd/sszgen -type BLSToExecutionChange -out gen_bls_to_execution_change_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedBLSToExecutionChange -out gen_signed_bls_to_execution_change_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type HistoricalSummary -out gen_historical_summary_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type ExecutionPayloadCapella -out gen_execution_payload_capella_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type ExecutionPayloadHeaderCapella -out gen_execution_payload_header_capella_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockBodyCapella -out gen_beacon_block_body_capella_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockCapella -out gen_beacon_block_capella_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedBeaconBlockCapella -out gen_signed_beacon_block_capella_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconStateCapella -out gen_beacon_state_capella_ssz.go
+
+// Withdrawal is a withdrawal from the beacon chain to the execution layer.
+type Withdrawal = sszexec.Withdrawal
+
+// BLSToExecutionChange is a request to change a validator's withdrawal
+// credentials from a BLS key to an execution address.
+type BLSToExecutionChange struct {
+	ValidatorIndex     uint64
+	FromBLSPubkey      BLSPubkey
+	ToExecutionAddress Address
+}
+
+// SignedBLSToExecutionChange is a credential change signed by the BLS key.
+type SignedBLSToExecutionChange struct {
+	Message   *BLSToExecutionChange
+	Signature BLSSignature
+}
+
+// HistoricalSummary is a summary of a batch of block and state roots.
+type HistoricalSummary struct {
+	BlockSummaryRoot Hash
+	StateSummaryRoot Hash
+}
+
+// ExecutionPayloadCapella is the capella execution layer block.
+type ExecutionPayloadCapella struct {
+	ParentHash    Hash
+	FeeRecipient  Address
+	StateRoot     Hash
+	ReceiptsRoot  Hash
+	LogsBloom     LogsBloom
+	PrevRandao    Hash
+	BlockNumber   uint64
+	GasLimit      uint64
+	GasUsed       uint64
+	Timestamp     uint64
+	ExtraData     []byte `ssz-max:"32"`
+	BaseFeePerGas *uint256.Int
+	BlockHash     Hash
+	Transactions  [][]byte      `ssz-max:"1048576,1073741824"`
+	Withdrawals   []*Withdrawal `ssz-max:"16"`
+}
+
+// ExecutionPayloadHeaderCapella is the capella execution layer block header,
+// with the transactions and withdrawals replaced by their roots.
+type ExecutionPayloadHeaderCapella struct {
+	ParentHash       Hash
+	FeeRecipient     Address
+	StateRoot        Hash
+	ReceiptsRoot     Hash
+	LogsBloom        LogsBloom
+	PrevRandao       Hash
+	BlockNumber      uint64
+	GasLimit         uint64
+	GasUsed          uint64
+	Timestamp        uint64
+	ExtraData        []byte `ssz-max:"32"`
+	BaseFeePerGas    *uint256.Int
+	BlockHash        Hash
+	TransactionsRoot Hash
+	WithdrawalsRoot  Hash
+}
+
+// BeaconBlockBodyCapella is the body of a capella beacon block.
+type BeaconBlockBodyCapella struct {
+	RandaoReveal          BLSSignature
+	Eth1Data              *Eth1Data
+	Graffiti              Hash
+	ProposerSlashings     []*ProposerSlashing    `ssz-max:"16"`
+	AttesterSlashings     []*AttesterSlashing    `ssz-max:"2"`
+	Attestations          []*Attestation         `ssz-max:"128"`
+	Deposits              []*Deposit             `ssz-max:"16"`
+	VoluntaryExits        []*SignedVoluntaryExit `ssz-max:"16"`
+	SyncAggregate         *SyncAggregate
+	ExecutionPayload      *ExecutionPayloadCapella
+	BLSToExecutionChanges []*SignedBLSToExecutionChange `ssz-max:"16"`
+}
+
+// BeaconBlockCapella is a capella beacon block.
+type BeaconBlockCapella struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    Hash
+	StateRoot     Hash
+	Body          *BeaconBlockBodyCapella
+}
+
+// SignedBeaconBlockCapella is a capella beacon block signed by its proposer.
+type SignedBeaconBlockCapella struct {
+	Message   *BeaconBlockCapella
+	Signature BLSSignature
+}
+
+// BeaconStateCapella is the capella beacon state.
+type BeaconStateCapella struct {
+	GenesisTime                  uint64
+	GenesisValidatorsRoot        Hash
+	Slot                         uint64
+	Fork                         *Fork
+	LatestBlockHeader            *BeaconBlockHeader
+	BlockRoots                   [8192][32]byte
+	StateRoots                   [8192][32]byte
+	HistoricalRoots              [][32]byte `ssz-max:"16777216"`
+	Eth1Data                     *Eth1Data
+	Eth1DataVotes                []*Eth1Data `ssz-max:"2048"`
+	Eth1DepositIndex             uint64
+	Validators                   []*Validator `ssz-max:"1099511627776"`
+	Balances                     []uint64     `ssz-max:"1099511627776"`
+	RandaoMixes                  [65536][32]byte
+	Slashings                    [8192]uint64
+	PreviousEpochParticipation   []byte  `ssz-max:"1099511627776"`
+	CurrentEpochParticipation    []byte  `ssz-max:"1099511627776"`
+	JustificationBits            [1]byte `ssz-size:"4" ssz:"bits"`
+	PreviousJustifiedCheckpoint  *Checkpoint
+	CurrentJustifiedCheckpoint   *Checkpoint
+	FinalizedCheckpoint          *Checkpoint
+	InactivityScores             []uint64 `ssz-max:"1099511627776"`
+	CurrentSyncCommittee         *SyncCommittee
+	NextSyncCommittee            *SyncCommittee
+	LatestExecutionPayloadHeader *ExecutionPayloadHeaderCapella
+	NextWithdrawalIndex          uint64
+	NextWithdrawalValidatorIndex uint64
+	HistoricalSummaries          []*HistoricalSummary `ssz-max:"16777216"`
+}
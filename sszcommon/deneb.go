@@ -0,0 +1,120 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon
+
+import "github.com/holiman/uint256"
+
+//go:generate go run -cover ../cmd/sszgen -type ExecutionPayloadDeneb -out gen_execution_payload_deneb_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type ExecutionPayloadHeaderDeneb -out gen_execution_payload_header_deneb_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockBodyDeneb -out gen_beacon_block_body_deneb_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockDeneb -out gen_beacon_block_deneb_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedBeaconBlockDeneb -out gen_signed_beacon_block_deneb_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconStateDeneb -out gen_beacon_state_deneb_ssz.go
+
+// ExecutionPayloadDeneb is the deneb execution layer block.
+type ExecutionPayloadDeneb struct {
+	ParentHash    Hash
+	FeeRecipient  Address
+	StateRoot     Hash
+	ReceiptsRoot  Hash
+	LogsBloom     LogsBloom
+	PrevRandao    Hash
+	BlockNumber   uint64
+	GasLimit      uint64
+	GasUsed       uint64
+	Timestamp     uint64
+	ExtraData     []byte `ssz-max:"32"`
+	BaseFeePerGas *uint256.Int
+	BlockHash     Hash
+	Transactions  [][]byte      `ssz-max:"1048576,1073741824"`
+	Withdrawals   []*Withdrawal `ssz-max:"16"`
+	BlobGasUsed   uint64
+	ExcessBlobGas uint64
+}
+
+// ExecutionPayloadHeaderDeneb is the deneb execution layer block header, with
+// the transactions and withdrawals replaced by their roots.
+type ExecutionPayloadHeaderDeneb struct {
+	ParentHash       Hash
+	FeeRecipient     Address
+	StateRoot        Hash
+	ReceiptsRoot     Hash
+	LogsBloom        LogsBloom
+	PrevRandao       Hash
+	BlockNumber      uint64
+	GasLimit         uint64
+	GasUsed          uint64
+	Timestamp        uint64
+	ExtraData        []byte `ssz-max:"32"`
+	BaseFeePerGas    *uint256.Int
+	BlockHash        Hash
+	TransactionsRoot Hash
+	WithdrawalsRoot  Hash
+	BlobGasUsed      uint64
+	ExcessBlobGas    uint64
+}
+
+// BeaconBlockBodyDeneb is the body of a deneb beacon block.
+type BeaconBlockBodyDeneb struct {
+	RandaoReveal          BLSSignature
+	Eth1Data              *Eth1Data
+	Graffiti              Hash
+	ProposerSlashings     []*ProposerSlashing    `ssz-max:"16"`
+	AttesterSlashings     []*AttesterSlashing    `ssz-max:"2"`
+	Attestations          []*Attestation         `ssz-max:"128"`
+	Deposits              []*Deposit             `ssz-max:"16"`
+	VoluntaryExits        []*SignedVoluntaryExit `ssz-max:"16"`
+	SyncAggregate         *SyncAggregate
+	ExecutionPayload      *ExecutionPayloadDeneb
+	BLSToExecutionChanges []*SignedBLSToExecutionChange `ssz-max:"16"`
+	BlobKZGCommitments    []KZGCommitment               `ssz-max:"4096"`
+}
+
+// BeaconBlockDeneb is a deneb beacon block.
+type BeaconBlockDeneb struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    Hash
+	StateRoot     Hash
+	Body          *BeaconBlockBodyDeneb
+}
+
+// SignedBeaconBlockDeneb is a deneb beacon block signed by its proposer.
+type SignedBeaconBlockDeneb struct {
+	Message   *BeaconBlockDeneb
+	Signature BLSSignature
+}
+
+// BeaconStateDeneb is the deneb beacon state.
+type BeaconStateDeneb struct {
+	GenesisTime                  uint64
+	GenesisValidatorsRoot        Hash
+	Slot                         uint64
+	Fork                         *Fork
+	LatestBlockHeader            *BeaconBlockHeader
+	BlockRoots                   [8192][32]byte
+	StateRoots                   [8192][32]byte
+	HistoricalRoots              [][32]byte `ssz-max:"16777216"`
+	Eth1Data                     *Eth1Data
+	Eth1DataVotes                []*Eth1Data `ssz-max:"2048"`
+	Eth1DepositIndex             uint64
+	Validators                   []*Validator `ssz-max:"1099511627776"`
+	Balances                     []uint64     `ssz-max:"1099511627776"`
+	RandaoMixes                  [65536][32]byte
+	Slashings                    [8192]uint64
+	PreviousEpochParticipation   []byte  `ssz-max:"1099511627776"`
+	CurrentEpochParticipation    []byte  `ssz-max:"1099511627776"`
+	JustificationBits            [1]byte `ssz-size:"4" ssz:"bits"`
+	PreviousJustifiedCheckpoint  *Checkpoint
+	CurrentJustifiedCheckpoint   *Checkpoint
+	FinalizedCheckpoint          *Checkpoint
+	InactivityScores             []uint64 `ssz-max:"1099511627776"`
+	CurrentSyncCommittee         *SyncCommittee
+	NextSyncCommittee            *SyncCommittee
+	LatestExecutionPayloadHeader *ExecutionPayloadHeaderDeneb
+	NextWithdrawalIndex          uint64
+	NextWithdrawalValidatorIndex uint64
+	HistoricalSummaries          []*HistoricalSummary `ssz-max:"16777216"`
+}
@@ -0,0 +1,172 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon
+
+import (
+	"github.com/karalabe/ssz/bitfield"
+	"github.com/karalabe/ssz/sszexec"
+)
+
+//go:generate go run -cover ../cmd/sszgen -type AttestationElectra -out gen_attestation_electra_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type IndexedAttestationElectra -out gen_indexed_attestation_electra_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type AttesterSlashingElectra -out gen_attester_slashing_electra_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type AggregateAndProofElectra -out gen_aggregate_and_proof_electra_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedAggregateAndProofElectra -out gen_signed_aggregate_and_proof_electra_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type PendingDeposit -out gen_pending_deposit_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type PendingPartialWithdrawal -out gen_pending_partial_withdrawal_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type PendingConsolidation -out gen_pending_consolidation_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockBodyElectra -out gen_beacon_block_body_electra_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockElectra -out gen_beacon_block_electra_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedBeaconBlockElectra -out gen_signed_beacon_block_electra_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconStateElectra -out gen_beacon_state_electra_ssz.go
+
+// DepositRequest is a deposit request made on the execution layer (EIP-6110).
+type DepositRequest = sszexec.DepositRequest
+
+// WithdrawalRequest is a withdrawal or exit request triggered from the
+// execution layer (EIP-7002).
+type WithdrawalRequest = sszexec.WithdrawalRequest
+
+// ConsolidationRequest is a validator consolidation request triggered from
+// the execution layer (EIP-7251).
+type ConsolidationRequest = sszexec.ConsolidationRequest
+
+// ExecutionRequests is the list of requests from the execution layer carried
+// by an electra beacon block (EIP-7685).
+type ExecutionRequests = sszexec.ExecutionRequests
+
+// AttestationElectra is an aggregated vote of multiple committees (EIP-7549).
+type AttestationElectra struct {
+	AggregationBits bitfield.Bitlist `ssz-max:"131072"`
+	Data            *AttestationData
+	Signature       BLSSignature
+	CommitteeBits   [8]byte `ssz-size:"64" ssz:"bits"`
+}
+
+// IndexedAttestationElectra is an electra attestation with its attesters
+// resolved to indices.
+type IndexedAttestationElectra struct {
+	AttestingIndices []uint64 `ssz-max:"131072"`
+	Data             *AttestationData
+	Signature        BLSSignature
+}
+
+// AttesterSlashingElectra is a proof of two conflicting electra attestations.
+type AttesterSlashingElectra struct {
+	Attestation1 *IndexedAttestationElectra
+	Attestation2 *IndexedAttestationElectra
+}
+
+// AggregateAndProofElectra is an aggregated electra attestation along with the
+// proof of the aggregator's selection.
+type AggregateAndProofElectra struct {
+	AggregatorIndex uint64
+	Aggregate       *AttestationElectra
+	SelectionProof  BLSSignature
+}
+
+// SignedAggregateAndProofElectra is an electra aggregate and proof signed by
+// the aggregator.
+type SignedAggregateAndProofElectra struct {
+	Message   *AggregateAndProofElectra
+	Signature BLSSignature
+}
+
+// PendingDeposit is a deposit queued up in the beacon state for processing.
+type PendingDeposit struct {
+	Pubkey                BLSPubkey
+	WithdrawalCredentials Hash
+	Amount                uint64
+	Signature             BLSSignature
+	Slot                  uint64
+}
+
+// PendingPartialWithdrawal is a partial withdrawal queued up in the beacon
+// state for processing.
+type PendingPartialWithdrawal struct {
+	ValidatorIndex    uint64
+	Amount            uint64
+	WithdrawableEpoch uint64
+}
+
+// PendingConsolidation is a consolidation queued up in the beacon state for
+// processing.
+type PendingConsolidation struct {
+	SourceIndex uint64
+	TargetIndex uint64
+}
+
+// BeaconBlockBodyElectra is the body of an electra beacon block.
+type BeaconBlockBodyElectra struct {
+	RandaoReveal          BLSSignature
+	Eth1Data              *Eth1Data
+	Graffiti              Hash
+	ProposerSlashings     []*ProposerSlashing        `ssz-max:"16"`
+	AttesterSlashings     []*AttesterSlashingElectra `ssz-max:"1"`
+	Attestations          []*AttestationElectra      `ssz-max:"8"`
+	Deposits              []*Deposit                 `ssz-max:"16"`
+	VoluntaryExits        []*SignedVoluntaryExit     `ssz-max:"16"`
+	SyncAggregate         *SyncAggregate
+	ExecutionPayload      *ExecutionPayloadDeneb
+	BLSToExecutionChanges []*SignedBLSToExecutionChange `ssz-max:"16"`
+	BlobKZGCommitments    []KZGCommitment               `ssz-max:"4096"`
+	ExecutionRequests     *ExecutionRequests
+}
+
+// BeaconBlockElectra is an electra beacon block.
+type BeaconBlockElectra struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    Hash
+	StateRoot     Hash
+	Body          *BeaconBlockBodyElectra
+}
+
+// SignedBeaconBlockElectra is an electra beacon block signed by its proposer.
+type SignedBeaconBlockElectra struct {
+	Message   *BeaconBlockElectra
+	Signature BLSSignature
+}
+
+// BeaconStateElectra is the electra beacon state.
+type BeaconStateElectra struct {
+	GenesisTime                   uint64
+	GenesisValidatorsRoot         Hash
+	Slot                          uint64
+	Fork                          *Fork
+	LatestBlockHeader             *BeaconBlockHeader
+	BlockRoots                    [8192][32]byte
+	StateRoots                    [8192][32]byte
+	HistoricalRoots               [][32]byte `ssz-max:"16777216"`
+	Eth1Data                      *Eth1Data
+	Eth1DataVotes                 []*Eth1Data `ssz-max:"2048"`
+	Eth1DepositIndex              uint64
+	Validators                    []*Validator `ssz-max:"1099511627776"`
+	Balances                      []uint64     `ssz-max:"1099511627776"`
+	RandaoMixes                   [65536][32]byte
+	Slashings                     [8192]uint64
+	PreviousEpochParticipation    []byte  `ssz-max:"1099511627776"`
+	CurrentEpochParticipation     []byte  `ssz-max:"1099511627776"`
+	JustificationBits             [1]byte `ssz-size:"4" ssz:"bits"`
+	PreviousJustifiedCheckpoint   *Checkpoint
+	CurrentJustifiedCheckpoint    *Checkpoint
+	FinalizedCheckpoint           *Checkpoint
+	InactivityScores              []uint64 `ssz-max:"1099511627776"`
+	CurrentSyncCommittee          *SyncCommittee
+	NextSyncCommittee             *SyncCommittee
+	LatestExecutionPayloadHeader  *ExecutionPayloadHeaderDeneb
+	NextWithdrawalIndex           uint64
+	NextWithdrawalValidatorIndex  uint64
+	HistoricalSummaries           []*HistoricalSummary `ssz-max:"16777216"`
+	DepositRequestsStartIndex     uint64
+	DepositBalanceToConsume       uint64
+	ExitBalanceToConsume          uint64
+	EarliestExitEpoch             uint64
+	ConsolidationBalanceToConsume uint64
+	EarliestConsolidationEpoch    uint64
+	PendingDeposits               []*PendingDeposit           `ssz-max:"134217728"`
+	PendingPartialWithdrawals     []*PendingPartialWithdrawal `ssz-max:"134217728"`
+	PendingConsolidations         []*PendingConsolidation     `ssz-max:"262144"`
+}
@@ -0,0 +1,23 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 4d3525b4b475b853d70f96611fc534d2d4edef242e9859e4715736efafa79eb4
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheAttestationData = 8 + 8 + 32 + (*Checkpoint)(nil).SizeSSZ() + (*Checkpoint)(nil).SizeSSZ()
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *AttestationData) SizeSSZ() uint32 {
+	return staticSizeCacheAttestationData
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *AttestationData) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Slot)                 // Field  (0) -            Slot -  8 bytes
+	ssz.DefineUint64(codec, &obj.Index)                // Field  (1) -           Index -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.BeaconBlockRoot) // Field  (2) - BeaconBlockRoot - 32 bytes
+	ssz.DefineStaticObject(codec, &obj.Source)         // Field  (3) -          Source -  ? bytes (Checkpoint)
+	ssz.DefineStaticObject(codec, &obj.Target)         // Field  (4) -          Target -  ? bytes (Checkpoint)
+}
@@ -0,0 +1,33 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 1728f990ae978b077615c4fc5c59365ccee70d402fe4f392ac21a7ea188a3d19
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheAttestationElectra = 4 + (*AttestationData)(nil).SizeSSZ() + 96 + 8
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *AttestationElectra) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheAttestationElectra)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfBits(obj.AggregationBits)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *AttestationElectra) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfBitsOffset(codec, &obj.AggregationBits, 131072) // Offset (0) - AggregationBits -  4 bytes
+	ssz.DefineStaticObject(codec, &obj.Data)                         // Field  (1) -            Data -  ? bytes (AttestationData)
+	ssz.DefineStaticBytes(codec, &obj.Signature)                     // Field  (2) -       Signature - 96 bytes
+	ssz.DefineArrayOfBits(codec, &obj.CommitteeBits, 64)             // Field  (3) -   CommitteeBits -  8 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfBitsContent(codec, &obj.AggregationBits, 131072) // Field  (0) - AggregationBits - ? bytes
+}
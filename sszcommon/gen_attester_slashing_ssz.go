@@ -0,0 +1,30 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 4dcca7a959afad885042208ea55250fee1f03a4f66b4e834b9c3e1913ac861e7
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *AttesterSlashing) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(4 + 4)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.Attestation1)
+	size += ssz.SizeDynamicObject(obj.Attestation2)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *AttesterSlashing) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicObjectOffset(codec, &obj.Attestation1) // Offset (0) - Attestation1 - 4 bytes
+	ssz.DefineDynamicObjectOffset(codec, &obj.Attestation2) // Offset (1) - Attestation2 - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.Attestation1) // Field  (0) - Attestation1 - ? bytes
+	ssz.DefineDynamicObjectContent(codec, &obj.Attestation2) // Field  (1) - Attestation2 - ? bytes
+}
@@ -0,0 +1,17 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 184e1385c63eedd195f34f8a9b5f7260914910f1f8b311f1f5c66db5a925940a
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *BlobIdentifier) SizeSSZ() uint32 {
+	return 32 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BlobIdentifier) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.BlockRoot) // Field  (0) - BlockRoot - 32 bytes
+	ssz.DefineUint64(codec, &obj.Index)          // Field  (1) -     Index -  8 bytes
+}
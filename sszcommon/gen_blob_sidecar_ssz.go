@@ -0,0 +1,24 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 730162f57e0c5bb59bb95cb78118fae12eda38f0782d5f4188e78a6413330479
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheBlobSidecar = 8 + 131072 + 48 + 48 + (*SignedBeaconBlockHeader)(nil).SizeSSZ() + 17*32
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *BlobSidecar) SizeSSZ() uint32 {
+	return staticSizeCacheBlobSidecar
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BlobSidecar) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Index)                                           // Field  (0) -                       Index -      8 bytes
+	ssz.DefineStaticBytes(codec, &obj.Blob)                                       // Field  (1) -                        Blob - 131072 bytes
+	ssz.DefineStaticBytes(codec, &obj.KZGCommitment)                              // Field  (2) -               KZGCommitment -     48 bytes
+	ssz.DefineStaticBytes(codec, &obj.KZGProof)                                   // Field  (3) -                    KZGProof -     48 bytes
+	ssz.DefineStaticObject(codec, &obj.SignedBlockHeader)                         // Field  (4) -           SignedBlockHeader -      ? bytes (SignedBeaconBlockHeader)
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.KZGCommitmentInclusionProof[:]) // Field  (5) - KZGCommitmentInclusionProof -    544 bytes
+}
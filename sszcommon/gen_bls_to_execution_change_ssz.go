@@ -0,0 +1,18 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 355e27072859392d6f1d71f2fc024f41e637385d6def8d01d7014af933afba0b
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *BLSToExecutionChange) SizeSSZ() uint32 {
+	return 8 + 48 + 20
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BLSToExecutionChange) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.ValidatorIndex)          // Field  (0) -     ValidatorIndex -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.FromBLSPubkey)      // Field  (1) -      FromBLSPubkey - 48 bytes
+	ssz.DefineStaticBytes(codec, &obj.ToExecutionAddress) // Field  (2) - ToExecutionAddress - 20 bytes
+}
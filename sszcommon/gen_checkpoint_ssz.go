@@ -0,0 +1,17 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: ff2d92e9deb64ea97461fa0cfaddf2ff1a1ca1fe1ab09ed0de7d8c8a43cb23fe
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Checkpoint) SizeSSZ() uint32 {
+	return 8 + 32
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Checkpoint) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Epoch)     // Field  (0) - Epoch -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.Root) // Field  (1) -  Root - 32 bytes
+}
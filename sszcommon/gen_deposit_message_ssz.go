@@ -0,0 +1,18 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: bd21c69ea8601393b32ee03e2f08f84328f8551b780e2d5796aa5d2b92523756
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *DepositMessage) SizeSSZ() uint32 {
+	return 48 + 32 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *DepositMessage) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.Pubkey)                // Field  (0) -                Pubkey - 48 bytes
+	ssz.DefineStaticBytes(codec, &obj.WithdrawalCredentials) // Field  (1) - WithdrawalCredentials - 32 bytes
+	ssz.DefineUint64(codec, &obj.Amount)                     // Field  (2) -                Amount -  8 bytes
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 0242a489f6a8b11bdc4b45b1963a736e8d908ea67ab99c8565f9716fc28830ee
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheDeposit = 33*32 + (*DepositData)(nil).SizeSSZ()
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Deposit) SizeSSZ() uint32 {
+	return staticSizeCacheDeposit
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Deposit) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.Proof[:]) // Field  (0) - Proof - 1056 bytes
+	ssz.DefineStaticObject(codec, &obj.Data)                // Field  (1) -  Data -    ? bytes (DepositData)
+}
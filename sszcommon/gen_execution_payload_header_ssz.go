@@ -0,0 +1,40 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 0b81646ab953a4c3e09ea9cc4f110c47598b565f7542184273a5c6a078b2ad19
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ExecutionPayloadHeader) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(32 + 20 + 32 + 32 + 256 + 32 + 8 + 8 + 8 + 8 + 4 + 32 + 32 + 32)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(obj.ExtraData)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ExecutionPayloadHeader) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineStaticBytes(codec, &obj.ParentHash)           // Field  ( 0) -       ParentHash -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.FeeRecipient)         // Field  ( 1) -     FeeRecipient -  20 bytes
+	ssz.DefineStaticBytes(codec, &obj.StateRoot)            // Field  ( 2) -        StateRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ReceiptsRoot)         // Field  ( 3) -     ReceiptsRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.LogsBloom)            // Field  ( 4) -        LogsBloom - 256 bytes
+	ssz.DefineStaticBytes(codec, &obj.PrevRandao)           // Field  ( 5) -       PrevRandao -  32 bytes
+	ssz.DefineUint64(codec, &obj.BlockNumber)               // Field  ( 6) -      BlockNumber -   8 bytes
+	ssz.DefineUint64(codec, &obj.GasLimit)                  // Field  ( 7) -         GasLimit -   8 bytes
+	ssz.DefineUint64(codec, &obj.GasUsed)                   // Field  ( 8) -          GasUsed -   8 bytes
+	ssz.DefineUint64(codec, &obj.Timestamp)                 // Field  ( 9) -        Timestamp -   8 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.ExtraData, 32) // Offset (10) -        ExtraData -   4 bytes
+	ssz.DefineUint256(codec, &obj.BaseFeePerGas)            // Field  (11) -    BaseFeePerGas -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.BlockHash)            // Field  (12) -        BlockHash -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.TransactionsRoot)     // Field  (13) - TransactionsRoot -  32 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.ExtraData, 32) // Field  (10) -        ExtraData - ? bytes
+}
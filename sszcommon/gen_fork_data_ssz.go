@@ -0,0 +1,17 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 5f39ee6fc39d4c05f4a29fcccf78f0a7e10905d2b00561d18bd1de528ba2041c
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *ForkData) SizeSSZ() uint32 {
+	return 4 + 32
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ForkData) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.CurrentVersion)        // Field  (0) -        CurrentVersion -  4 bytes
+	ssz.DefineStaticBytes(codec, &obj.GenesisValidatorsRoot) // Field  (1) - GenesisValidatorsRoot - 32 bytes
+}
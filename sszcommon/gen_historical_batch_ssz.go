@@ -0,0 +1,17 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 8944cfa78e610296c91ccb6778b2bd065b46678297e6988a75383d88845f41ba
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *HistoricalBatch) SizeSSZ() uint32 {
+	return 8192*32 + 8192*32
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *HistoricalBatch) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.BlockRoots[:]) // Field  (0) - BlockRoots - 262144 bytes
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.StateRoots[:]) // Field  (1) - StateRoots - 262144 bytes
+}
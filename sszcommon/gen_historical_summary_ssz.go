@@ -0,0 +1,17 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: ed9929b5bc05e4886f2990e8fea6752e97a6936e5addae5d0df5426bdac3c960
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *HistoricalSummary) SizeSSZ() uint32 {
+	return 32 + 32
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *HistoricalSummary) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.BlockSummaryRoot) // Field  (0) - BlockSummaryRoot - 32 bytes
+	ssz.DefineStaticBytes(codec, &obj.StateSummaryRoot) // Field  (1) - StateSummaryRoot - 32 bytes
+}
@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 008a6899c5474174f456a6d0998984bb02b420dc254c3e3f9d4c5db51670fad9
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheIndexedAttestation = 4 + (*AttestationData)(nil).SizeSSZ() + 96
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *IndexedAttestation) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheIndexedAttestation)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfUint64s(obj.AttestingIndices)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *IndexedAttestation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfUint64sOffset(codec, &obj.AttestingIndices, 2048) // Offset (0) - AttestingIndices -  4 bytes
+	ssz.DefineStaticObject(codec, &obj.Data)                           // Field  (1) -             Data -  ? bytes (AttestationData)
+	ssz.DefineStaticBytes(codec, &obj.Signature)                       // Field  (2) -        Signature - 96 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfUint64sContent(codec, &obj.AttestingIndices, 2048) // Field  (0) - AttestingIndices - ? bytes
+}
@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 1f10659cde7e528b8e8eae9a47b69b9ed3b43c1881560fa50290f0aec7ba9696
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheLightClientBootstrapCapella = 4 + (*SyncCommittee)(nil).SizeSSZ() + 5*32
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *LightClientBootstrapCapella) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheLightClientBootstrapCapella)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.Header)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *LightClientBootstrapCapella) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicObjectOffset(codec, &obj.Header)                            // Offset (0) -                     Header -   4 bytes
+	ssz.DefineStaticObject(codec, &obj.CurrentSyncCommittee)                     // Field  (1) -       CurrentSyncCommittee -   ? bytes (SyncCommittee)
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.CurrentSyncCommitteeBranch[:]) // Field  (2) - CurrentSyncCommitteeBranch - 160 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.Header) // Field  (0) -                     Header - ? bytes
+}
@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: d78cb8d0820272310127c21355efa16f1c0d561b6042c9245f9b61b21d85c168
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheLightClientBootstrapDeneb = 4 + (*SyncCommittee)(nil).SizeSSZ() + 5*32
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *LightClientBootstrapDeneb) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheLightClientBootstrapDeneb)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.Header)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *LightClientBootstrapDeneb) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicObjectOffset(codec, &obj.Header)                            // Offset (0) -                     Header -   4 bytes
+	ssz.DefineStaticObject(codec, &obj.CurrentSyncCommittee)                     // Field  (1) -       CurrentSyncCommittee -   ? bytes (SyncCommittee)
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.CurrentSyncCommitteeBranch[:]) // Field  (2) - CurrentSyncCommitteeBranch - 160 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.Header) // Field  (0) -                     Header - ? bytes
+}
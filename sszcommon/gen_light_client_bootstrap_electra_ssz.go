@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 7adcd72e21e6fad0bf1efb754b4ff3095937faf5b8ef71d3d5ec1c874aab5565
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheLightClientBootstrapElectra = 4 + (*SyncCommittee)(nil).SizeSSZ() + 6*32
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *LightClientBootstrapElectra) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheLightClientBootstrapElectra)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.Header)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *LightClientBootstrapElectra) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicObjectOffset(codec, &obj.Header)                            // Offset (0) -                     Header -   4 bytes
+	ssz.DefineStaticObject(codec, &obj.CurrentSyncCommittee)                     // Field  (1) -       CurrentSyncCommittee -   ? bytes (SyncCommittee)
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.CurrentSyncCommitteeBranch[:]) // Field  (2) - CurrentSyncCommitteeBranch - 192 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.Header) // Field  (0) -                     Header - ? bytes
+}
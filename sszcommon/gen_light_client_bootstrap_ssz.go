@@ -0,0 +1,21 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 0f4436363b91b4e8a73b7030efbd389f16ac5cc2c28c3a7cfccbf6ce62a1f10e
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheLightClientBootstrap = (*LightClientHeader)(nil).SizeSSZ() + (*SyncCommittee)(nil).SizeSSZ() + 5*32
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *LightClientBootstrap) SizeSSZ() uint32 {
+	return staticSizeCacheLightClientBootstrap
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *LightClientBootstrap) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &obj.Header)                                   // Field  (0) -                     Header -   ? bytes (LightClientHeader)
+	ssz.DefineStaticObject(codec, &obj.CurrentSyncCommittee)                     // Field  (1) -       CurrentSyncCommittee -   ? bytes (SyncCommittee)
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.CurrentSyncCommitteeBranch[:]) // Field  (2) - CurrentSyncCommitteeBranch - 160 bytes
+}
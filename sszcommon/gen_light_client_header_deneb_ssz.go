@@ -0,0 +1,32 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: c8ecef11f773091afcd815cc1da6c349d2705c438203cd10ada02d84b1a0038f
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheLightClientHeaderDeneb = (*BeaconBlockHeader)(nil).SizeSSZ() + 4 + 4*32
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *LightClientHeaderDeneb) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheLightClientHeaderDeneb)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.Execution)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *LightClientHeaderDeneb) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineStaticObject(codec, &obj.Beacon)                        // Field  (0) -          Beacon -   ? bytes (BeaconBlockHeader)
+	ssz.DefineDynamicObjectOffset(codec, &obj.Execution)              // Offset (1) -       Execution -   4 bytes
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.ExecutionBranch[:]) // Field  (2) - ExecutionBranch - 128 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.Execution) // Field  (1) -       Execution - ? bytes
+}
@@ -0,0 +1,19 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 80614d87eeda1b6615d317864b3133a540e6dc358587253f29f5c7ed019d59c8
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheLightClientHeader = (*BeaconBlockHeader)(nil).SizeSSZ()
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *LightClientHeader) SizeSSZ() uint32 {
+	return staticSizeCacheLightClientHeader
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *LightClientHeader) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &obj.Beacon) // Field  (0) - Beacon - ? bytes (BeaconBlockHeader)
+}
@@ -0,0 +1,38 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: ff4aa9feca1808d243524a93e3f047e8b9883080baf6f69e4b6437af2237c7e7
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheLightClientUpdateDeneb = 4 + (*SyncCommittee)(nil).SizeSSZ() + 5*32 + 4 + 6*32 + (*SyncAggregate)(nil).SizeSSZ() + 8
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *LightClientUpdateDeneb) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheLightClientUpdateDeneb)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.AttestedHeader)
+	size += ssz.SizeDynamicObject(obj.FinalizedHeader)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *LightClientUpdateDeneb) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicObjectOffset(codec, &obj.AttestedHeader)                 // Offset (0) -          AttestedHeader -   4 bytes
+	ssz.DefineStaticObject(codec, &obj.NextSyncCommittee)                     // Field  (1) -       NextSyncCommittee -   ? bytes (SyncCommittee)
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.NextSyncCommitteeBranch[:]) // Field  (2) - NextSyncCommitteeBranch - 160 bytes
+	ssz.DefineDynamicObjectOffset(codec, &obj.FinalizedHeader)                // Offset (3) -         FinalizedHeader -   4 bytes
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.FinalityBranch[:])          // Field  (4) -          FinalityBranch - 192 bytes
+	ssz.DefineStaticObject(codec, &obj.SyncAggregate)                         // Field  (5) -           SyncAggregate -   ? bytes (SyncAggregate)
+	ssz.DefineUint64(codec, &obj.SignatureSlot)                               // Field  (6) -           SignatureSlot -   8 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.AttestedHeader)  // Field  (0) -          AttestedHeader - ? bytes
+	ssz.DefineDynamicObjectContent(codec, &obj.FinalizedHeader) // Field  (3) -         FinalizedHeader - ? bytes
+}
@@ -0,0 +1,38 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 0d0373034c023743b635414a9a4d844866ed18b1654536604759ecb4edf9a416
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheLightClientUpdateElectra = 4 + (*SyncCommittee)(nil).SizeSSZ() + 6*32 + 4 + 7*32 + (*SyncAggregate)(nil).SizeSSZ() + 8
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *LightClientUpdateElectra) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheLightClientUpdateElectra)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.AttestedHeader)
+	size += ssz.SizeDynamicObject(obj.FinalizedHeader)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *LightClientUpdateElectra) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicObjectOffset(codec, &obj.AttestedHeader)                 // Offset (0) -          AttestedHeader -   4 bytes
+	ssz.DefineStaticObject(codec, &obj.NextSyncCommittee)                     // Field  (1) -       NextSyncCommittee -   ? bytes (SyncCommittee)
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.NextSyncCommitteeBranch[:]) // Field  (2) - NextSyncCommitteeBranch - 192 bytes
+	ssz.DefineDynamicObjectOffset(codec, &obj.FinalizedHeader)                // Offset (3) -         FinalizedHeader -   4 bytes
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.FinalityBranch[:])          // Field  (4) -          FinalityBranch - 224 bytes
+	ssz.DefineStaticObject(codec, &obj.SyncAggregate)                         // Field  (5) -           SyncAggregate -   ? bytes (SyncAggregate)
+	ssz.DefineUint64(codec, &obj.SignatureSlot)                               // Field  (6) -           SignatureSlot -   8 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.AttestedHeader)  // Field  (0) -          AttestedHeader - ? bytes
+	ssz.DefineDynamicObjectContent(codec, &obj.FinalizedHeader) // Field  (3) -         FinalizedHeader - ? bytes
+}
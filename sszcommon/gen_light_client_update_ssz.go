@@ -0,0 +1,25 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 8798fc61eb13d9e4ce4ff63310ef57eac27dc56249af2ca2b6070051ae288e02
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheLightClientUpdate = (*LightClientHeader)(nil).SizeSSZ() + (*SyncCommittee)(nil).SizeSSZ() + 5*32 + (*LightClientHeader)(nil).SizeSSZ() + 6*32 + (*SyncAggregate)(nil).SizeSSZ() + 8
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *LightClientUpdate) SizeSSZ() uint32 {
+	return staticSizeCacheLightClientUpdate
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *LightClientUpdate) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &obj.AttestedHeader)                        // Field  (0) -          AttestedHeader -   ? bytes (LightClientHeader)
+	ssz.DefineStaticObject(codec, &obj.NextSyncCommittee)                     // Field  (1) -       NextSyncCommittee -   ? bytes (SyncCommittee)
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.NextSyncCommitteeBranch[:]) // Field  (2) - NextSyncCommitteeBranch - 160 bytes
+	ssz.DefineStaticObject(codec, &obj.FinalizedHeader)                       // Field  (3) -         FinalizedHeader -   ? bytes (LightClientHeader)
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.FinalityBranch[:])          // Field  (4) -          FinalityBranch - 192 bytes
+	ssz.DefineStaticObject(codec, &obj.SyncAggregate)                         // Field  (5) -           SyncAggregate -   ? bytes (SyncAggregate)
+	ssz.DefineUint64(codec, &obj.SignatureSlot)                               // Field  (6) -           SignatureSlot -   8 bytes
+}
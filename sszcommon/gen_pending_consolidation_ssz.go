@@ -0,0 +1,17 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 3a811489c45b558592953125ed6fbd4e2eb706c4fd7c61d8aa5b81579d855c5a
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *PendingConsolidation) SizeSSZ() uint32 {
+	return 8 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *PendingConsolidation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.SourceIndex) // Field  (0) - SourceIndex - 8 bytes
+	ssz.DefineUint64(codec, &obj.TargetIndex) // Field  (1) - TargetIndex - 8 bytes
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 38b14f76c5f5c15271dc6a80ac418800368c0483ce396d288f777203695b394e
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *PendingDeposit) SizeSSZ() uint32 {
+	return 48 + 32 + 8 + 96 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *PendingDeposit) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.Pubkey)                // Field  (0) -                Pubkey - 48 bytes
+	ssz.DefineStaticBytes(codec, &obj.WithdrawalCredentials) // Field  (1) - WithdrawalCredentials - 32 bytes
+	ssz.DefineUint64(codec, &obj.Amount)                     // Field  (2) -                Amount -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.Signature)             // Field  (3) -             Signature - 96 bytes
+	ssz.DefineUint64(codec, &obj.Slot)                       // Field  (4) -                  Slot -  8 bytes
+}
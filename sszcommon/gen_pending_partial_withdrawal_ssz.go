@@ -0,0 +1,18 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: eaa9a7c7dee8ea0e773a4ea7be9635a0bc4429367c1bb3fbb9ec46f793c12c04
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *PendingPartialWithdrawal) SizeSSZ() uint32 {
+	return 8 + 8 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *PendingPartialWithdrawal) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.ValidatorIndex)    // Field  (0) -    ValidatorIndex - 8 bytes
+	ssz.DefineUint64(codec, &obj.Amount)            // Field  (1) -            Amount - 8 bytes
+	ssz.DefineUint64(codec, &obj.WithdrawableEpoch) // Field  (2) - WithdrawableEpoch - 8 bytes
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: bebc4f456c6496fd927ce97b9a3f6f6b5b33c5350c43d09033f344fd4cdd6174
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheProposerSlashing = (*SignedBeaconBlockHeader)(nil).SizeSSZ() + (*SignedBeaconBlockHeader)(nil).SizeSSZ()
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *ProposerSlashing) SizeSSZ() uint32 {
+	return staticSizeCacheProposerSlashing
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ProposerSlashing) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &obj.SignedHeader1) // Field  (0) - SignedHeader1 - ? bytes (SignedBeaconBlockHeader)
+	ssz.DefineStaticObject(codec, &obj.SignedHeader2) // Field  (1) - SignedHeader2 - ? bytes (SignedBeaconBlockHeader)
+}
@@ -0,0 +1,28 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: b30f4c9c0ac0ebba16012dc404c35ffbc0795a4c4479079e5d9e0578a67fb47a
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *SignedAggregateAndProof) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(4 + 96)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.Message)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SignedAggregateAndProof) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicObjectOffset(codec, &obj.Message) // Offset (0) -   Message -  4 bytes
+	ssz.DefineStaticBytes(codec, &obj.Signature)       // Field  (1) - Signature - 96 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.Message) // Field  (0) -   Message - ? bytes
+}
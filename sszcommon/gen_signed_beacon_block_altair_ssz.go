@@ -0,0 +1,28 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 20d360da41b1d689d3cf9a80a2144779bd647fbf4eec8207696267faf0ff0782
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *SignedBeaconBlockAltair) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(4 + 96)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.Message)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SignedBeaconBlockAltair) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicObjectOffset(codec, &obj.Message) // Offset (0) -   Message -  4 bytes
+	ssz.DefineStaticBytes(codec, &obj.Signature)       // Field  (1) - Signature - 96 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.Message) // Field  (0) -   Message - ? bytes
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 0a4759ee5385445c463ba8b5272568984264d325c77a3e0bf724f9c823cdb653
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheSignedBeaconBlockHeader = (*BeaconBlockHeader)(nil).SizeSSZ() + 96
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SignedBeaconBlockHeader) SizeSSZ() uint32 {
+	return staticSizeCacheSignedBeaconBlockHeader
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SignedBeaconBlockHeader) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &obj.Message)  // Field  (0) -   Message -  ? bytes (BeaconBlockHeader)
+	ssz.DefineStaticBytes(codec, &obj.Signature) // Field  (1) - Signature - 96 bytes
+}
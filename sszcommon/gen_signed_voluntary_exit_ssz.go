@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: eac0127c39b553b820497c64da1c887310963f7ee1c1129ee011fe61b48266ea
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheSignedVoluntaryExit = (*VoluntaryExit)(nil).SizeSSZ() + 96
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SignedVoluntaryExit) SizeSSZ() uint32 {
+	return staticSizeCacheSignedVoluntaryExit
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SignedVoluntaryExit) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticObject(codec, &obj.Message)  // Field  (0) -   Message -  ? bytes (VoluntaryExit)
+	ssz.DefineStaticBytes(codec, &obj.Signature) // Field  (1) - Signature - 96 bytes
+}
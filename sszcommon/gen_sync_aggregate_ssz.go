@@ -0,0 +1,17 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 43ed17c90854847886c63936d9a0c91b39b02cda434edb88a8677599134e5e39
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SyncAggregate) SizeSSZ() uint32 {
+	return 64 + 96
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SyncAggregate) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineArrayOfBits(codec, &obj.SyncCommitteeBits, 512) // Field  (0) -      SyncCommitteeBits - 64 bytes
+	ssz.DefineStaticBytes(codec, &obj.SyncCommitteeSignature) // Field  (1) - SyncCommitteeSignature - 96 bytes
+}
@@ -0,0 +1,17 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 68419f39bb5fe02382d863df62062640df60e835cab46503559f8385e77ed743
+
+package sszcommon
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SyncCommittee) SizeSSZ() uint32 {
+	return 512*48 + 48
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SyncCommittee) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.Pubkeys[:]) // Field  (0) -         Pubkeys - 24576 bytes
+	ssz.DefineStaticBytes(codec, &obj.AggregatePubkey)        // Field  (1) - AggregatePubkey -    48 bytes
+}
@@ -0,0 +1,300 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon
+
+import (
+	"fmt"
+
+	"github.com/karalabe/ssz"
+)
+
+//go:generate go run -cover ../cmd/sszgen -type LightClientHeader -out gen_light_client_header_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientBootstrap -out gen_light_client_bootstrap_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientUpdate -out gen_light_client_update_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientHeaderCapella -out gen_light_client_header_capella_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientBootstrapCapella -out gen_light_client_bootstrap_capella_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientUpdateCapella -out gen_light_client_update_capella_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientHeaderDeneb -out gen_light_client_header_deneb_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientBootstrapDeneb -out gen_light_client_bootstrap_deneb_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientUpdateDeneb -out gen_light_client_update_deneb_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientBootstrapElectra -out gen_light_client_bootstrap_electra_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type LightClientUpdateElectra -out gen_light_client_update_electra_ssz.go
+
+// Generalized indices of the beacon state fields proven to light clients, from
+// Altair through Deneb.
+const (
+	FinalizedRootGeneralizedIndex        = 105 // state.finalized_checkpoint.root
+	CurrentSyncCommitteeGeneralizedIndex = 54  // state.current_sync_committee
+	NextSyncCommitteeGeneralizedIndex    = 55  // state.next_sync_committee
+)
+
+// Generalized indices of the beacon state fields proven to light clients from
+// Electra, whose states outgrew 32 fields, deepening all proofs by one level.
+const (
+	FinalizedRootGeneralizedIndexElectra        = 169 // state.finalized_checkpoint.root
+	CurrentSyncCommitteeGeneralizedIndexElectra = 86  // state.current_sync_committee
+	NextSyncCommitteeGeneralizedIndexElectra    = 87  // state.next_sync_committee
+)
+
+// ExecutionPayloadGeneralizedIndex is the generalized index of the execution
+// payload within the beacon block bodies from Capella, proving the execution
+// payload header of light client headers against the block body root.
+const ExecutionPayloadGeneralizedIndex = 25 // body.execution_payload
+
+// LightClientHeader is a beacon block header as tracked by light clients. This is
+// the Altair layout, without the execution payload header added in Capella.
+type LightClientHeader struct {
+	Beacon *BeaconBlockHeader
+}
+
+// LightClientBootstrap is the initial state of a light client, proving the sync
+// committee of the period of a trusted block.
+type LightClientBootstrap struct {
+	Header                     *LightClientHeader
+	CurrentSyncCommittee       *SyncCommittee
+	CurrentSyncCommitteeBranch [5][32]byte
+}
+
+// LightClientUpdate advances a light client to a newer attested (and optionally
+// finalized) header, and may prove the sync committee of the next period.
+type LightClientUpdate struct {
+	AttestedHeader          *LightClientHeader
+	NextSyncCommittee       *SyncCommittee
+	NextSyncCommitteeBranch [5][32]byte
+	FinalizedHeader         *LightClientHeader
+	FinalityBranch          [6][32]byte
+	SyncAggregate           *SyncAggregate
+	SignatureSlot           uint64
+}
+
+// LightClientHeaderCapella is the capella light client header, extended with the
+// execution payload header of the block, proven against its body root.
+type LightClientHeaderCapella struct {
+	Beacon          *BeaconBlockHeader
+	Execution       *ExecutionPayloadHeaderCapella
+	ExecutionBranch [4][32]byte
+}
+
+// LightClientBootstrapCapella is the capella light client bootstrap.
+type LightClientBootstrapCapella struct {
+	Header                     *LightClientHeaderCapella
+	CurrentSyncCommittee       *SyncCommittee
+	CurrentSyncCommitteeBranch [5][32]byte
+}
+
+// LightClientUpdateCapella is the capella light client update.
+type LightClientUpdateCapella struct {
+	AttestedHeader          *LightClientHeaderCapella
+	NextSyncCommittee       *SyncCommittee
+	NextSyncCommitteeBranch [5][32]byte
+	FinalizedHeader         *LightClientHeaderCapella
+	FinalityBranch          [6][32]byte
+	SyncAggregate           *SyncAggregate
+	SignatureSlot           uint64
+}
+
+// LightClientHeaderDeneb is the deneb light client header, with the deneb layout
+// of the execution payload header. It is used unchanged in Electra.
+type LightClientHeaderDeneb struct {
+	Beacon          *BeaconBlockHeader
+	Execution       *ExecutionPayloadHeaderDeneb
+	ExecutionBranch [4][32]byte
+}
+
+// LightClientBootstrapDeneb is the deneb light client bootstrap.
+type LightClientBootstrapDeneb struct {
+	Header                     *LightClientHeaderDeneb
+	CurrentSyncCommittee       *SyncCommittee
+	CurrentSyncCommitteeBranch [5][32]byte
+}
+
+// LightClientUpdateDeneb is the deneb light client update.
+type LightClientUpdateDeneb struct {
+	AttestedHeader          *LightClientHeaderDeneb
+	NextSyncCommittee       *SyncCommittee
+	NextSyncCommitteeBranch [5][32]byte
+	FinalizedHeader         *LightClientHeaderDeneb
+	FinalityBranch          [6][32]byte
+	SyncAggregate           *SyncAggregate
+	SignatureSlot           uint64
+}
+
+// LightClientBootstrapElectra is the electra light client bootstrap, with the
+// sync committee branch deepened by the larger beacon state.
+type LightClientBootstrapElectra struct {
+	Header                     *LightClientHeaderDeneb
+	CurrentSyncCommittee       *SyncCommittee
+	CurrentSyncCommitteeBranch [6][32]byte
+}
+
+// LightClientUpdateElectra is the electra light client update, with the sync
+// committee and finality branches deepened by the larger beacon state.
+type LightClientUpdateElectra struct {
+	AttestedHeader          *LightClientHeaderDeneb
+	NextSyncCommittee       *SyncCommittee
+	NextSyncCommitteeBranch [6][32]byte
+	FinalizedHeader         *LightClientHeaderDeneb
+	FinalityBranch          [7][32]byte
+	SyncAggregate           *SyncAggregate
+	SignatureSlot           uint64
+}
+
+// lightClientBootstraps is the set of light client bootstrap containers across
+// the forks.
+type lightClientBootstraps interface {
+	*LightClientBootstrap | *LightClientBootstrapCapella | *LightClientBootstrapDeneb | *LightClientBootstrapElectra
+}
+
+// lightClientUpdates is the set of light client update containers across the
+// forks.
+type lightClientUpdates interface {
+	*LightClientUpdate | *LightClientUpdateCapella | *LightClientUpdateDeneb | *LightClientUpdateElectra
+}
+
+// VerifyBootstrap checks that the bootstrap's header matches the trusted block
+// root, and that its sync committee is proven against the header's state root.
+// From Capella, the header's execution payload header is verified too.
+func VerifyBootstrap[T lightClientBootstraps](bootstrap T, trustedBlockRoot [32]byte) error {
+	var (
+		header    any
+		committee *SyncCommittee
+		branch    [][32]byte
+		gindex    uint64
+	)
+	switch b := any(bootstrap).(type) {
+	case *LightClientBootstrap:
+		header, committee, branch, gindex = b.Header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch[:], CurrentSyncCommitteeGeneralizedIndex
+	case *LightClientBootstrapCapella:
+		header, committee, branch, gindex = b.Header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch[:], CurrentSyncCommitteeGeneralizedIndex
+	case *LightClientBootstrapDeneb:
+		header, committee, branch, gindex = b.Header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch[:], CurrentSyncCommitteeGeneralizedIndex
+	case *LightClientBootstrapElectra:
+		header, committee, branch, gindex = b.Header, b.CurrentSyncCommittee, b.CurrentSyncCommitteeBranch[:], CurrentSyncCommitteeGeneralizedIndexElectra
+	}
+	beacon, err := verifyLightClientHeader(header)
+	if err != nil {
+		return err
+	}
+	if root := ssz.HashSequential(beacon); root != trustedBlockRoot {
+		return fmt.Errorf("%w: header root %x, trusted %x", ssz.ErrInvalidProof, root, trustedBlockRoot)
+	}
+	return ssz.VerifyProof(beacon.StateRoot, &ssz.Proof{
+		Index:  gindex,
+		Leaf:   ssz.HashSequential(committee),
+		Branch: branch,
+	})
+}
+
+// VerifyNextSyncCommittee checks that the update's next sync committee is proven
+// against the state root of its attested header. From Capella, the attested
+// header's execution payload header is verified too.
+func VerifyNextSyncCommittee[T lightClientUpdates](update T) error {
+	var (
+		header    any
+		committee *SyncCommittee
+		branch    [][32]byte
+		gindex    uint64
+	)
+	switch u := any(update).(type) {
+	case *LightClientUpdate:
+		header, committee, branch, gindex = u.AttestedHeader, u.NextSyncCommittee, u.NextSyncCommitteeBranch[:], NextSyncCommitteeGeneralizedIndex
+	case *LightClientUpdateCapella:
+		header, committee, branch, gindex = u.AttestedHeader, u.NextSyncCommittee, u.NextSyncCommitteeBranch[:], NextSyncCommitteeGeneralizedIndex
+	case *LightClientUpdateDeneb:
+		header, committee, branch, gindex = u.AttestedHeader, u.NextSyncCommittee, u.NextSyncCommitteeBranch[:], NextSyncCommitteeGeneralizedIndex
+	case *LightClientUpdateElectra:
+		header, committee, branch, gindex = u.AttestedHeader, u.NextSyncCommittee, u.NextSyncCommitteeBranch[:], NextSyncCommitteeGeneralizedIndexElectra
+	}
+	attested, err := verifyLightClientHeader(header)
+	if err != nil {
+		return err
+	}
+	return ssz.VerifyProof(attested.StateRoot, &ssz.Proof{
+		Index:  gindex,
+		Leaf:   ssz.HashSequential(committee),
+		Branch: branch,
+	})
+}
+
+// VerifyFinality checks that the update's finalized header is proven against the
+// state root of its attested header. As in the consensus specs, a genesis slot
+// finalized header is proven as an empty root. From Capella, the execution
+// payload headers of both headers are verified too.
+func VerifyFinality[T lightClientUpdates](update T) error {
+	var (
+		attestedHeader  any
+		finalizedHeader any
+		branch          [][32]byte
+		gindex          uint64
+	)
+	switch u := any(update).(type) {
+	case *LightClientUpdate:
+		attestedHeader, finalizedHeader, branch, gindex = u.AttestedHeader, u.FinalizedHeader, u.FinalityBranch[:], FinalizedRootGeneralizedIndex
+	case *LightClientUpdateCapella:
+		attestedHeader, finalizedHeader, branch, gindex = u.AttestedHeader, u.FinalizedHeader, u.FinalityBranch[:], FinalizedRootGeneralizedIndex
+	case *LightClientUpdateDeneb:
+		attestedHeader, finalizedHeader, branch, gindex = u.AttestedHeader, u.FinalizedHeader, u.FinalityBranch[:], FinalizedRootGeneralizedIndex
+	case *LightClientUpdateElectra:
+		attestedHeader, finalizedHeader, branch, gindex = u.AttestedHeader, u.FinalizedHeader, u.FinalityBranch[:], FinalizedRootGeneralizedIndexElectra
+	}
+	attested, err := verifyLightClientHeader(attestedHeader)
+	if err != nil {
+		return err
+	}
+	finalized, err := verifyLightClientHeader(finalizedHeader)
+	if err != nil {
+		return err
+	}
+	var leaf [32]byte
+	if finalized.Slot != 0 {
+		leaf = ssz.HashSequential(finalized)
+	}
+	return ssz.VerifyProof(attested.StateRoot, &ssz.Proof{
+		Index:  gindex,
+		Leaf:   leaf,
+		Branch: branch,
+	})
+}
+
+// verifyLightClientHeader returns the beacon block header of a light client header
+// of any fork. From Capella, it also checks that the execution payload header is
+// proven against the block body root, except for genesis slot headers, which are
+// empty placeholders in updates without finality.
+func verifyLightClientHeader(header any) (*BeaconBlockHeader, error) {
+	var (
+		beacon    *BeaconBlockHeader
+		execution ssz.Object
+		branch    [][32]byte
+	)
+	switch h := header.(type) {
+	case *LightClientHeader:
+		return h.Beacon, nil
+	case *LightClientHeaderCapella:
+		beacon, branch = h.Beacon, h.ExecutionBranch[:]
+		if h.Execution != nil {
+			execution = h.Execution
+		}
+	case *LightClientHeaderDeneb:
+		beacon, branch = h.Beacon, h.ExecutionBranch[:]
+		if h.Execution != nil {
+			execution = h.Execution
+		}
+	}
+	if beacon.Slot == 0 {
+		return beacon, nil
+	}
+	if execution == nil {
+		return nil, fmt.Errorf("%w: missing execution payload header", ssz.ErrInvalidProof)
+	}
+	err := ssz.VerifyProof(beacon.BodyRoot, &ssz.Proof{
+		Index:  ExecutionPayloadGeneralizedIndex,
+		Leaf:   ssz.HashSequential(execution),
+		Branch: branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return beacon, nil
+}
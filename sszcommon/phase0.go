@@ -0,0 +1,235 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon
+
+import "github.com/karalabe/ssz/bitfield"
+
+//go:generate go run -cover ../cmd/sszgen -type Checkpoint -out gen_checkpoint_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type Fork -out gen_fork_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type ForkData -out gen_fork_data_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type Eth1Data -out gen_eth1_data_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockHeader -out gen_beacon_block_header_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedBeaconBlockHeader -out gen_signed_beacon_block_header_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type AttestationData -out gen_attestation_data_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type Attestation -out gen_attestation_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type IndexedAttestation -out gen_indexed_attestation_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type PendingAttestation -out gen_pending_attestation_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type AttesterSlashing -out gen_attester_slashing_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type ProposerSlashing -out gen_proposer_slashing_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type DepositData -out gen_deposit_data_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type DepositMessage -out gen_deposit_message_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type Deposit -out gen_deposit_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type VoluntaryExit -out gen_voluntary_exit_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedVoluntaryExit -out gen_signed_voluntary_exit_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type Validator -out gen_validator_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type HistoricalBatch -out gen_historical_batch_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type AggregateAndProof -out gen_aggregate_and_proof_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedAggregateAndProof -out gen_signed_aggregate_and_proof_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlockBody -out gen_beacon_block_body_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconBlock -out gen_beacon_block_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type SignedBeaconBlock -out gen_signed_beacon_block_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type BeaconState -out gen_beacon_state_ssz.go
+
+// Checkpoint is a (epoch, root) pair used by the finality gadget.
+type Checkpoint struct {
+	Epoch uint64
+	Root  Hash
+}
+
+// Fork is the fork schedule information of a beacon state.
+type Fork struct {
+	PreviousVersion Version
+	CurrentVersion  Version
+	Epoch           uint64
+}
+
+// ForkData is the container hashed to compute fork digests and domains.
+type ForkData struct {
+	CurrentVersion        Version
+	GenesisValidatorsRoot Hash
+}
+
+// Eth1Data is a vote on the state of the deposit contract.
+type Eth1Data struct {
+	DepositRoot  Hash
+	DepositCount uint64
+	BlockHash    Hash
+}
+
+// BeaconBlockHeader is the header of a beacon block, with the body replaced by
+// its root.
+type BeaconBlockHeader struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    Hash
+	StateRoot     Hash
+	BodyRoot      Hash
+}
+
+// SignedBeaconBlockHeader is a beacon block header signed by its proposer.
+type SignedBeaconBlockHeader struct {
+	Message   *BeaconBlockHeader
+	Signature BLSSignature
+}
+
+// AttestationData is the vote cast by an attestation.
+type AttestationData struct {
+	Slot            uint64
+	Index           uint64
+	BeaconBlockRoot Hash
+	Source          *Checkpoint
+	Target          *Checkpoint
+}
+
+// Attestation is an aggregated vote of a committee.
+type Attestation struct {
+	AggregationBits bitfield.Bitlist `ssz-max:"2048"`
+	Data            *AttestationData
+	Signature       BLSSignature
+}
+
+// IndexedAttestation is an attestation with its attesters resolved to indices.
+type IndexedAttestation struct {
+	AttestingIndices []uint64 `ssz-max:"2048"`
+	Data             *AttestationData
+	Signature        BLSSignature
+}
+
+// PendingAttestation is an attestation awaiting processing in a phase0 state.
+type PendingAttestation struct {
+	AggregationBits bitfield.Bitlist `ssz-max:"2048"`
+	Data            *AttestationData
+	InclusionDelay  uint64
+	ProposerIndex   uint64
+}
+
+// AttesterSlashing is a proof of two conflicting attestations.
+type AttesterSlashing struct {
+	Attestation1 *IndexedAttestation
+	Attestation2 *IndexedAttestation
+}
+
+// ProposerSlashing is a proof of two conflicting block proposals.
+type ProposerSlashing struct {
+	SignedHeader1 *SignedBeaconBlockHeader
+	SignedHeader2 *SignedBeaconBlockHeader
+}
+
+// DepositData is the data of a deposit made into the deposit contract.
+type DepositData struct {
+	Pubkey                BLSPubkey
+	WithdrawalCredentials Hash
+	Amount                uint64
+	Signature             BLSSignature
+}
+
+// DepositMessage is the signed part of a deposit.
+type DepositMessage struct {
+	Pubkey                BLSPubkey
+	WithdrawalCredentials Hash
+	Amount                uint64
+}
+
+// Deposit is a deposit along with its merkle proof in the deposit contract.
+type Deposit struct {
+	Proof [33][32]byte
+	Data  *DepositData
+}
+
+// VoluntaryExit is a request of a validator to exit.
+type VoluntaryExit struct {
+	Epoch          uint64
+	ValidatorIndex uint64
+}
+
+// SignedVoluntaryExit is a voluntary exit signed by the exiting validator.
+type SignedVoluntaryExit struct {
+	Message   *VoluntaryExit
+	Signature BLSSignature
+}
+
+// Validator is a validator record in the beacon state.
+type Validator struct {
+	Pubkey                     BLSPubkey
+	WithdrawalCredentials      Hash
+	EffectiveBalance           uint64
+	Slashed                    bool
+	ActivationEligibilityEpoch uint64
+	ActivationEpoch            uint64
+	ExitEpoch                  uint64
+	WithdrawableEpoch          uint64
+}
+
+// HistoricalBatch is a batch of block and state roots.
+type HistoricalBatch struct {
+	BlockRoots [8192][32]byte
+	StateRoots [8192][32]byte
+}
+
+// AggregateAndProof is an aggregated attestation along with the proof of the
+// aggregator's selection.
+type AggregateAndProof struct {
+	AggregatorIndex uint64
+	Aggregate       *Attestation
+	SelectionProof  BLSSignature
+}
+
+// SignedAggregateAndProof is an aggregate and proof signed by the aggregator.
+type SignedAggregateAndProof struct {
+	Message   *AggregateAndProof
+	Signature BLSSignature
+}
+
+// BeaconBlockBody is the body of a phase0 beacon block.
+type BeaconBlockBody struct {
+	RandaoReveal      BLSSignature
+	Eth1Data          *Eth1Data
+	Graffiti          Hash
+	ProposerSlashings []*ProposerSlashing    `ssz-max:"16"`
+	AttesterSlashings []*AttesterSlashing    `ssz-max:"2"`
+	Attestations      []*Attestation         `ssz-max:"128"`
+	Deposits          []*Deposit             `ssz-max:"16"`
+	VoluntaryExits    []*SignedVoluntaryExit `ssz-max:"16"`
+}
+
+// BeaconBlock is a phase0 beacon block.
+type BeaconBlock struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    Hash
+	StateRoot     Hash
+	Body          *BeaconBlockBody
+}
+
+// SignedBeaconBlock is a phase0 beacon block signed by its proposer.
+type SignedBeaconBlock struct {
+	Message   *BeaconBlock
+	Signature BLSSignature
+}
+
+// BeaconState is the phase0 beacon state.
+type BeaconState struct {
+	GenesisTime                 uint64
+	GenesisValidatorsRoot       Hash
+	Slot                        uint64
+	Fork                        *Fork
+	LatestBlockHeader           *BeaconBlockHeader
+	BlockRoots                  [8192][32]byte
+	StateRoots                  [8192][32]byte
+	HistoricalRoots             [][32]byte `ssz-max:"16777216"`
+	Eth1Data                    *Eth1Data
+	Eth1DataVotes               []*Eth1Data `ssz-max:"2048"`
+	Eth1DepositIndex            uint64
+	Validators                  []*Validator `ssz-max:"1099511627776"`
+	Balances                    []uint64     `ssz-max:"1099511627776"`
+	RandaoMixes                 [65536][32]byte
+	Slashings                   [8192]uint64
+	PreviousEpochAttestations   []*PendingAttestation `ssz-max:"4096"`
+	CurrentEpochAttestations    []*PendingAttestation `ssz-max:"4096"`
+	JustificationBits           [1]byte               `ssz-size:"4" ssz:"bits"`
+	PreviousJustifiedCheckpoint *Checkpoint
+	CurrentJustifiedCheckpoint  *Checkpoint
+	FinalizedCheckpoint         *Checkpoint
+}
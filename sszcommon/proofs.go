@@ -0,0 +1,96 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon
+
+import (
+	"fmt"
+
+	"github.com/karalabe/ssz"
+)
+
+// SlotsPerHistoricalRoot is the number of recent block roots retained by beacon
+// states, and also the number of block roots batched into a historical summary.
+const SlotsPerHistoricalRoot = 8192
+
+// HistoricalSummariesLimit is the maximum number of historical summaries a beacon
+// state may accumulate.
+const HistoricalSummariesLimit = 16777216
+
+// Field indices of the beacon states' fields that historical proofs go through.
+const (
+	stateBlockRootsField          = 5
+	stateHistoricalSummariesField = 27
+)
+
+// beaconStates is the set of beacon state containers across the forks.
+type beaconStates interface {
+	*BeaconState | *BeaconStateAltair | *BeaconStateBellatrix | *BeaconStateCapella | *BeaconStateDeneb | *BeaconStateElectra
+}
+
+// historicalBeaconStates is the set of beacon state containers that accumulate
+// historical summaries (Capella and later).
+type historicalBeaconStates interface {
+	*BeaconStateCapella | *BeaconStateDeneb | *BeaconStateElectra
+}
+
+// stateFieldGeneralizedIndex returns the generalized index of a top level field
+// of a beacon state of the given fork.
+func stateFieldGeneralizedIndex[T beaconStates](field uint64) uint64 {
+	// All states up to Deneb have 21-28 fields, Electra has 37
+	var state T
+	if _, ok := any(state).(*BeaconStateElectra); ok {
+		return 64 + field
+	}
+	return 32 + field
+}
+
+// BlockRootGeneralizedIndex returns the generalized index of the root of the block
+// at the given slot within the block_roots vector of a beacon state of the given
+// fork. States only retain the roots of the last SlotsPerHistoricalRoot slots, so
+// the slot must be within that range of the state's own slot; older blocks can
+// be proven via HistoricalBlockRootGeneralizedIndex.
+func BlockRootGeneralizedIndex[T beaconStates](slot uint64) uint64 {
+	return stateFieldGeneralizedIndex[T](stateBlockRootsField)<<13 | slot%SlotsPerHistoricalRoot
+}
+
+// HistoricalSummaryGeneralizedIndex returns the generalized index of the block
+// summary root of a historical summary within a beacon state of the given fork.
+// The block summary root is the root of the block_roots vector of the batch of
+// slots it summarizes.
+func HistoricalSummaryGeneralizedIndex[T historicalBeaconStates](summary uint64) (uint64, error) {
+	if summary >= HistoricalSummariesLimit {
+		return 0, fmt.Errorf("%w: historical summary %d beyond limit %d", ssz.ErrInvalidGeneralizedIndex, summary, uint64(HistoricalSummariesLimit))
+	}
+	return ssz.ConcatGeneralizedIndices(
+		stateFieldGeneralizedIndex[T](stateHistoricalSummariesField), // historical_summaries list
+		2<<24|summary, // item in the data subtree of the list
+		2,             // block_summary_root field of the summary
+	)
+}
+
+// HistoricalBlockRootGeneralizedIndex returns the generalized index of the root of
+// the block at the given slot within a beacon state of the given fork, going
+// through the historical summary covering the slot. The summary index of a slot
+// is (slot - capellaSlot) / SlotsPerHistoricalRoot, where capellaSlot is the
+// first slot of the network's Capella fork.
+//
+// Only the block summary root is stored in the state, so the proof needs to be
+// assembled from a proof of the block root within the summarized block_roots
+// vector (e.g. from an archive of historical batches) and one of the summary
+// within the state, see ssz.ConcatProofs.
+func HistoricalBlockRootGeneralizedIndex[T historicalBeaconStates](summary uint64, slot uint64) (uint64, error) {
+	outer, err := HistoricalSummaryGeneralizedIndex[T](summary)
+	if err != nil {
+		return 0, err
+	}
+	return ssz.ConcatGeneralizedIndices(outer, SummaryBlockRootGeneralizedIndex(slot))
+}
+
+// SummaryBlockRootGeneralizedIndex returns the generalized index of the root of
+// the block at the given slot within the block_roots vector summarized by the
+// block summary root of a historical summary.
+func SummaryBlockRootGeneralizedIndex(slot uint64) uint64 {
+	return SlotsPerHistoricalRoot + slot%SlotsPerHistoricalRoot
+}
@@ -0,0 +1,502 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszcommon_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/bitfield"
+	"github.com/karalabe/ssz/sszcommon"
+	"github.com/karalabe/ssz/sszexec"
+	"github.com/karalabe/ssz/ssztest"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// newTestPayload creates a deneb execution payload with some content.
+func newTestPayload() *sszcommon.ExecutionPayloadDeneb {
+	return &sszcommon.ExecutionPayloadDeneb{
+		ParentHash:    sszcommon.Hash{0x01},
+		FeeRecipient:  sszcommon.Address{0x02},
+		BlockNumber:   3,
+		GasLimit:      30_000_000,
+		ExtraData:     []byte("ssz"),
+		BaseFeePerGas: uint256.NewInt(7),
+		Transactions:  [][]byte{{0x02, 0x03}, {}, {0x04}},
+		Withdrawals: []*sszcommon.Withdrawal{
+			{Index: 1, ValidatorIndex: 2, Address: sszcommon.Address{0x03}, Amount: 4},
+		},
+		BlobGasUsed: 131072,
+	}
+}
+
+// newTestAttestationData creates an attestation vote with some content.
+func newTestAttestationData() *sszcommon.AttestationData {
+	return &sszcommon.AttestationData{
+		Slot:   1,
+		Source: &sszcommon.Checkpoint{Epoch: 1, Root: sszcommon.Hash{0x01}},
+		Target: &sszcommon.Checkpoint{Epoch: 2, Root: sszcommon.Hash{0x02}},
+	}
+}
+
+// Tests that the maintained deneb containers are wire and hash compatible with
+// the test types validated against the consensus spec tests.
+func TestDenebCompatibility(t *testing.T) {
+	bits := bitfield.NewBitlist(100)
+	bits.SetBitAt(42, true)
+
+	body := &sszcommon.BeaconBlockBodyDeneb{
+		RandaoReveal: sszcommon.BLSSignature{0x01},
+		Eth1Data:     &sszcommon.Eth1Data{DepositCount: 1},
+		Graffiti:     sszcommon.Hash{'s', 's', 'z'},
+		Attestations: []*sszcommon.Attestation{
+			{AggregationBits: bits, Data: newTestAttestationData()},
+		},
+		VoluntaryExits: []*sszcommon.SignedVoluntaryExit{
+			{Message: &sszcommon.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}},
+		},
+		SyncAggregate:      &sszcommon.SyncAggregate{SyncCommitteeBits: [64]byte{0xff}},
+		ExecutionPayload:   newTestPayload(),
+		BlobKZGCommitments: []sszcommon.KZGCommitment{{0x01}, {0x02}},
+	}
+	blob := make([]byte, ssz.Size(body))
+	if err := ssz.EncodeToBytes(blob, body); err != nil {
+		t.Fatalf("failed to encode body: %v", err)
+	}
+	ref := new(types.BeaconBlockBodyDeneb)
+	if err := ssz.DecodeFromBytes(blob, ref); err != nil {
+		t.Fatalf("failed to decode body into test type: %v", err)
+	}
+	if have, want := ssz.HashSequential(body), ssz.HashSequential(ref); have != want {
+		t.Fatalf("hash mismatch: have %#x, want %#x", have, want)
+	}
+	reblob := make([]byte, ssz.Size(ref))
+	if err := ssz.EncodeToBytes(reblob, ref); err != nil {
+		t.Fatalf("failed to encode test type: %v", err)
+	}
+	if !bytes.Equal(blob, reblob) {
+		t.Fatalf("re-encoding mismatch: have %x, want %x", reblob, blob)
+	}
+}
+
+// Tests that the electra containers round trip through the codec.
+func TestElectraRoundTrip(t *testing.T) {
+	bits := bitfield.NewBitlist(2048)
+	bits.SetBitAt(1000, true)
+
+	block := &sszcommon.SignedBeaconBlockElectra{
+		Message: &sszcommon.BeaconBlockElectra{
+			Slot:          1,
+			ProposerIndex: 2,
+			Body: &sszcommon.BeaconBlockBodyElectra{
+				Eth1Data: new(sszcommon.Eth1Data),
+				Attestations: []*sszcommon.AttestationElectra{
+					{AggregationBits: bits, Data: newTestAttestationData(), CommitteeBits: [8]byte{0x05}},
+				},
+				SyncAggregate:    new(sszcommon.SyncAggregate),
+				ExecutionPayload: newTestPayload(),
+				ExecutionRequests: &sszcommon.ExecutionRequests{
+					Deposits: []*sszcommon.DepositRequest{
+						{Pubkey: sszcommon.BLSPubkey{0x01}, Amount: 32_000_000_000, Index: 1},
+					},
+					Withdrawals: []*sszcommon.WithdrawalRequest{
+						{SourceAddress: sszcommon.Address{0x01}, ValidatorPubkey: sszcommon.BLSPubkey{0x02}},
+					},
+					Consolidations: []*sszcommon.ConsolidationRequest{
+						{SourceAddress: sszcommon.Address{0x01}, SourcePubkey: sszcommon.BLSPubkey{0x02}, TargetPubkey: sszcommon.BLSPubkey{0x03}},
+					},
+				},
+			},
+		},
+		Signature: sszcommon.BLSSignature{0x01},
+	}
+	blob := make([]byte, ssz.Size(block))
+	if err := ssz.EncodeToBytes(blob, block); err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	dec := new(sszcommon.SignedBeaconBlockElectra)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+	reblob := make([]byte, ssz.Size(dec))
+	if err := ssz.EncodeToBytes(reblob, dec); err != nil {
+		t.Fatalf("failed to re-encode block: %v", err)
+	}
+	if !bytes.Equal(blob, reblob) {
+		t.Fatalf("re-encoding mismatch: have %x, want %x", reblob, blob)
+	}
+	if have, want := ssz.HashSequential(dec), ssz.HashSequential(block); have != want {
+		t.Fatalf("hash mismatch: have %#x, want %#x", have, want)
+	}
+	if have, want := ssz.HashConcurrent(dec), ssz.HashSequential(block); have != want {
+		t.Fatalf("concurrent hash mismatch: have %#x, want %#x", have, want)
+	}
+	// Ensure the byte aligned committee bits survived the round trip
+	if bits := dec.Message.Body.Attestations[0].CommitteeBits; bits != [8]byte{0x05} {
+		t.Fatalf("committee bits mismatch: have %x", bits)
+	}
+}
+
+// testMerkleize is a naive reference merkleizer of a blob of data, padded to a
+// power of two number of chunks.
+func testMerkleize(blob []byte) [32]byte {
+	chunks := make([][32]byte, (len(blob)+31)/32)
+	for i := range chunks {
+		copy(chunks[i][:], blob[i*32:])
+	}
+	for len(chunks)&(len(chunks)-1) != 0 {
+		chunks = append(chunks, [32]byte{})
+	}
+	for len(chunks) > 1 {
+		for i := 0; i < len(chunks)/2; i++ {
+			chunks[i] = sha256.Sum256(append(chunks[2*i][:], chunks[2*i+1][:]...))
+		}
+		chunks = chunks[:len(chunks)/2]
+	}
+	return chunks[0]
+}
+
+// Tests that blob sidecars round trip through the codec and that the large
+// static blob is merkleized correctly.
+func TestBlobSidecar(t *testing.T) {
+	sidecar := &sszcommon.BlobSidecar{
+		Index:         3,
+		KZGCommitment: sszcommon.KZGCommitment{0x01},
+		KZGProof:      sszcommon.KZGProof{0x02},
+		SignedBlockHeader: &sszcommon.SignedBeaconBlockHeader{
+			Message: &sszcommon.BeaconBlockHeader{Slot: 1, ProposerIndex: 2},
+		},
+	}
+	for i := range sidecar.Blob {
+		sidecar.Blob[i] = byte(i)
+	}
+	for i := range sidecar.KZGCommitmentInclusionProof {
+		sidecar.KZGCommitmentInclusionProof[i] = [32]byte{byte(i)}
+	}
+	blob := make([]byte, ssz.Size(sidecar))
+	if err := ssz.EncodeToBytes(blob, sidecar); err != nil {
+		t.Fatalf("failed to encode sidecar: %v", err)
+	}
+	dec := new(sszcommon.BlobSidecar)
+	if err := ssz.DecodeFromBytes(blob, dec); err != nil {
+		t.Fatalf("failed to decode sidecar: %v", err)
+	}
+	if dec.Index != sidecar.Index || dec.Blob != sidecar.Blob || dec.KZGCommitmentInclusionProof != sidecar.KZGCommitmentInclusionProof {
+		t.Fatalf("decoded sidecar mismatch")
+	}
+	// Assemble the root of the sidecar from its fields and compare it
+	var fields []byte
+
+	index := make([]byte, 32)
+	binary.LittleEndian.PutUint64(index, sidecar.Index)
+	fields = append(fields, index...)
+
+	root := testMerkleize(sidecar.Blob[:])
+	fields = append(fields, root[:]...)
+	root = testMerkleize(sidecar.KZGCommitment[:])
+	fields = append(fields, root[:]...)
+	root = testMerkleize(sidecar.KZGProof[:])
+	fields = append(fields, root[:]...)
+	root = ssz.HashSequential(sidecar.SignedBlockHeader)
+	fields = append(fields, root[:]...)
+
+	var proof []byte
+	for _, node := range sidecar.KZGCommitmentInclusionProof {
+		proof = append(proof, node[:]...)
+	}
+	root = testMerkleize(proof)
+	fields = append(fields, root[:]...)
+
+	want := testMerkleize(fields)
+	if have := ssz.HashSequential(dec); have != want {
+		t.Fatalf("sequential hash mismatch: have %#x, want %#x", have, want)
+	}
+	if have := ssz.HashConcurrent(dec); have != want {
+		t.Fatalf("concurrent hash mismatch: have %#x, want %#x", have, want)
+	}
+}
+
+// Tests that block roots can be proven against beacon states, both directly via
+// the recent block roots and via the historical summaries.
+func TestHistoricalProofs(t *testing.T) {
+	const slot = 3*sszcommon.SlotsPerHistoricalRoot + 5
+
+	// Assemble a historical batch with the block in it, and a state summarizing it
+	batch := new(sszcommon.HistoricalBatch)
+	batch.BlockRoots[slot%sszcommon.SlotsPerHistoricalRoot] = [32]byte{0x01}
+
+	summary, err := ssz.HashTree(batch).Get(2)
+	if err != nil {
+		t.Fatalf("failed to retrieve block summary root: %v", err)
+	}
+	state := ssztest.Populate(new(sszcommon.BeaconStateDeneb))
+	state.BlockRoots[slot%sszcommon.SlotsPerHistoricalRoot] = [32]byte{0x01}
+	state.HistoricalSummaries = []*sszcommon.HistoricalSummary{{}, {BlockSummaryRoot: summary.Root()}, {}}
+
+	tree, root := ssz.HashTree(state), ssz.HashSequential(state)
+
+	// Prove the block root via the recent block roots of the state
+	recent := sszcommon.BlockRootGeneralizedIndex[*sszcommon.BeaconStateDeneb](slot)
+	proof, err := tree.Prove(recent)
+	if err != nil {
+		t.Fatalf("failed to prove recent block root: %v", err)
+	}
+	if proof.Leaves[0] != [32]byte{0x01} {
+		t.Errorf("recent block root mismatch: have %x", proof.Leaves[0])
+	}
+	// Prove the block root via the historical summary, composing the proofs
+	outerIndex, err := sszcommon.HistoricalSummaryGeneralizedIndex[*sszcommon.BeaconStateDeneb](1)
+	if err != nil {
+		t.Fatalf("failed to compute summary index: %v", err)
+	}
+	outer, err := tree.Prove(outerIndex)
+	if err != nil {
+		t.Fatalf("failed to prove historical summary: %v", err)
+	}
+	innerIndex := sszcommon.SummaryBlockRootGeneralizedIndex(slot)
+	inner, err := summary.Prove(innerIndex)
+	if err != nil {
+		t.Fatalf("failed to prove summarized block root: %v", err)
+	}
+	composite, err := ssz.ConcatProofs(
+		&ssz.Proof{Index: outerIndex, Leaf: outer.Leaves[0], Branch: outer.Hashes},
+		&ssz.Proof{Index: innerIndex, Leaf: inner.Leaves[0], Branch: inner.Hashes},
+	)
+	if err != nil {
+		t.Fatalf("failed to compose proofs: %v", err)
+	}
+	if err := ssz.VerifyProof(root, composite); err != nil {
+		t.Errorf("failed to verify composite proof: %v", err)
+	}
+	index, err := sszcommon.HistoricalBlockRootGeneralizedIndex[*sszcommon.BeaconStateDeneb](1, slot)
+	if err != nil {
+		t.Fatalf("failed to compute historical block root index: %v", err)
+	}
+	if composite.Index != index || composite.Leaf != [32]byte{0x01} {
+		t.Errorf("composite proof mismatch: have index %d, leaf %x, want index %d", composite.Index, composite.Leaf, index)
+	}
+	// Proofs of different summaries should not compose
+	if _, err := ssz.ConcatProofs(
+		&ssz.Proof{Index: outerIndex, Leaf: [32]byte{}, Branch: outer.Hashes},
+		&ssz.Proof{Index: innerIndex, Leaf: inner.Leaves[0], Branch: inner.Hashes},
+	); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("mismatching composition error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	// Electra states are deeper, check that they are accounted for
+	electra := ssztest.Populate(new(sszcommon.BeaconStateElectra))
+	electra.BlockRoots[slot%sszcommon.SlotsPerHistoricalRoot] = [32]byte{0x02}
+
+	proof, err = ssz.HashTree(electra).Prove(sszcommon.BlockRootGeneralizedIndex[*sszcommon.BeaconStateElectra](slot))
+	if err != nil {
+		t.Fatalf("failed to prove electra block root: %v", err)
+	}
+	if proof.Leaves[0] != [32]byte{0x02} {
+		t.Errorf("electra block root mismatch: have %x", proof.Leaves[0])
+	}
+}
+
+// Tests that generalized indices compose as in the consensus specs.
+func TestConcatGeneralizedIndices(t *testing.T) {
+	tests := []struct {
+		indices []uint64
+		want    uint64
+		fail    bool
+	}{
+		{nil, 1, false},
+		{[]uint64{1}, 1, false},
+		{[]uint64{5, 1}, 5, false},
+		{[]uint64{2, 3}, 5, false},
+		{[]uint64{37, 8192 + 5}, 37<<13 | 5, false},
+		{[]uint64{3, 0}, 0, true},
+		{[]uint64{1 << 40, 1 << 30}, 0, true},
+	}
+	for i, tt := range tests {
+		have, err := ssz.ConcatGeneralizedIndices(tt.indices...)
+		if tt.fail {
+			if !errors.Is(err, ssz.ErrInvalidGeneralizedIndex) {
+				t.Errorf("test %d: error mismatch: have %v, want %v", i, err, ssz.ErrInvalidGeneralizedIndex)
+			}
+			continue
+		}
+		if err != nil || have != tt.want {
+			t.Errorf("test %d: index mismatch: have %d (%v), want %d", i, have, err, tt.want)
+		}
+	}
+}
+
+// Tests that light client bootstraps and updates assembled from a beacon state's
+// proofs verify, and that tampered ones are rejected.
+func TestLightClientProofs(t *testing.T) {
+	finalized := &sszcommon.BeaconBlockHeader{Slot: 64, ProposerIndex: 1}
+
+	state := ssztest.Populate(new(sszcommon.BeaconStateAltair))
+	state.FinalizedCheckpoint.Root = ssz.HashSequential(finalized)
+
+	tree := ssz.HashTree(state)
+	branch := func(gindex uint64) [][32]byte {
+		proof, err := tree.Prove(gindex)
+		if err != nil {
+			t.Fatalf("failed to prove gindex %d: %v", gindex, err)
+		}
+		return proof.Hashes
+	}
+	header := &sszcommon.BeaconBlockHeader{Slot: 96, StateRoot: ssz.HashSequential(state)}
+
+	// Bootstraps should be verified against the trusted block root
+	bootstrap := &sszcommon.LightClientBootstrap{
+		Header:               &sszcommon.LightClientHeader{Beacon: header},
+		CurrentSyncCommittee: state.CurrentSyncCommittee,
+	}
+	copy(bootstrap.CurrentSyncCommitteeBranch[:], branch(sszcommon.CurrentSyncCommitteeGeneralizedIndex))
+
+	if err := sszcommon.VerifyBootstrap(bootstrap, ssz.HashSequential(header)); err != nil {
+		t.Errorf("failed to verify bootstrap: %v", err)
+	}
+	if err := sszcommon.VerifyBootstrap(bootstrap, [32]byte{}); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("untrusted bootstrap error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	// Updates should prove the next sync committee and finality
+	update := &sszcommon.LightClientUpdate{
+		AttestedHeader:    &sszcommon.LightClientHeader{Beacon: header},
+		NextSyncCommittee: state.NextSyncCommittee,
+		FinalizedHeader:   &sszcommon.LightClientHeader{Beacon: finalized},
+		SyncAggregate:     new(sszcommon.SyncAggregate),
+		SignatureSlot:     97,
+	}
+	copy(update.NextSyncCommitteeBranch[:], branch(sszcommon.NextSyncCommitteeGeneralizedIndex))
+	copy(update.FinalityBranch[:], branch(sszcommon.FinalizedRootGeneralizedIndex))
+
+	if err := sszcommon.VerifyNextSyncCommittee(update); err != nil {
+		t.Errorf("failed to verify next sync committee: %v", err)
+	}
+	if err := sszcommon.VerifyFinality(update); err != nil {
+		t.Errorf("failed to verify finality: %v", err)
+	}
+	// The containers should round trip through their encodings
+	blob, err := ssz.EncodeAppend(nil, update)
+	if err != nil {
+		t.Fatalf("failed to encode update: %v", err)
+	}
+	decoded := new(sszcommon.LightClientUpdate)
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode update: %v", err)
+	}
+	if err := sszcommon.VerifyFinality(decoded); err != nil {
+		t.Errorf("failed to verify decoded finality: %v", err)
+	}
+	// Tampered updates should be rejected
+	decoded.FinalizedHeader.Beacon.Slot++
+	if err := sszcommon.VerifyFinality(decoded); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("tampered finality error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	decoded.NextSyncCommittee.AggregatePubkey[0]++
+	if err := sszcommon.VerifyNextSyncCommittee(decoded); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("tampered sync committee error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+}
+
+// Tests that electra light client bootstraps and updates verify against the
+// deeper state proofs, and that their execution payload headers are proven
+// against the block body roots.
+func TestLightClientProofsElectra(t *testing.T) {
+	// Assemble a block body with an execution payload, and its header
+	body := ssztest.Populate(new(sszcommon.BeaconBlockBodyElectra))
+	body.ExecutionPayload.Transactions, body.ExecutionPayload.Withdrawals = nil, nil
+
+	payload := body.ExecutionPayload
+	execution := &sszcommon.ExecutionPayloadHeaderDeneb{
+		ParentHash: payload.ParentHash, FeeRecipient: payload.FeeRecipient, StateRoot: payload.StateRoot,
+		ReceiptsRoot: payload.ReceiptsRoot, LogsBloom: payload.LogsBloom, PrevRandao: payload.PrevRandao,
+		BlockNumber: payload.BlockNumber, GasLimit: payload.GasLimit, GasUsed: payload.GasUsed,
+		Timestamp: payload.Timestamp, ExtraData: payload.ExtraData, BaseFeePerGas: payload.BaseFeePerGas,
+		BlockHash: payload.BlockHash, BlobGasUsed: payload.BlobGasUsed, ExcessBlobGas: payload.ExcessBlobGas,
+	}
+	var err error
+	if execution.TransactionsRoot, err = ssz.ListRoot(nil, 1048576); err != nil {
+		t.Fatalf("failed to compute transactions root: %v", err)
+	}
+	if execution.WithdrawalsRoot, err = sszexec.WithdrawalsRoot(nil); err != nil {
+		t.Fatalf("failed to compute withdrawals root: %v", err)
+	}
+	proof, err := ssz.HashTree(body).Prove(sszcommon.ExecutionPayloadGeneralizedIndex)
+	if err != nil {
+		t.Fatalf("failed to prove execution payload: %v", err)
+	}
+	var executionBranch [4][32]byte
+	copy(executionBranch[:], proof.Hashes)
+
+	// Assemble a state finalizing a block with the same body
+	finalized := &sszcommon.BeaconBlockHeader{Slot: 64, ProposerIndex: 1, BodyRoot: ssz.HashSequential(body)}
+
+	state := ssztest.Populate(new(sszcommon.BeaconStateElectra))
+	state.FinalizedCheckpoint.Root = ssz.HashSequential(finalized)
+
+	tree := ssz.HashTree(state)
+	branch := func(gindex uint64) [][32]byte {
+		proof, err := tree.Prove(gindex)
+		if err != nil {
+			t.Fatalf("failed to prove gindex %d: %v", gindex, err)
+		}
+		return proof.Hashes
+	}
+	header := &sszcommon.LightClientHeaderDeneb{
+		Beacon:          &sszcommon.BeaconBlockHeader{Slot: 96, StateRoot: ssz.HashSequential(state), BodyRoot: ssz.HashSequential(body)},
+		Execution:       execution,
+		ExecutionBranch: executionBranch,
+	}
+	// Bootstraps should be verified against the trusted block root
+	bootstrap := &sszcommon.LightClientBootstrapElectra{
+		Header:               header,
+		CurrentSyncCommittee: state.CurrentSyncCommittee,
+	}
+	copy(bootstrap.CurrentSyncCommitteeBranch[:], branch(sszcommon.CurrentSyncCommitteeGeneralizedIndexElectra))
+
+	if err := sszcommon.VerifyBootstrap(bootstrap, ssz.HashSequential(header.Beacon)); err != nil {
+		t.Errorf("failed to verify bootstrap: %v", err)
+	}
+	// Updates should prove the next sync committee and finality
+	update := &sszcommon.LightClientUpdateElectra{
+		AttestedHeader:    header,
+		NextSyncCommittee: state.NextSyncCommittee,
+		FinalizedHeader:   &sszcommon.LightClientHeaderDeneb{Beacon: finalized, Execution: execution, ExecutionBranch: executionBranch},
+		SyncAggregate:     new(sszcommon.SyncAggregate),
+		SignatureSlot:     97,
+	}
+	copy(update.NextSyncCommitteeBranch[:], branch(sszcommon.NextSyncCommitteeGeneralizedIndexElectra))
+	copy(update.FinalityBranch[:], branch(sszcommon.FinalizedRootGeneralizedIndexElectra))
+
+	if err := sszcommon.VerifyNextSyncCommittee(update); err != nil {
+		t.Errorf("failed to verify next sync committee: %v", err)
+	}
+	if err := sszcommon.VerifyFinality(update); err != nil {
+		t.Errorf("failed to verify finality: %v", err)
+	}
+	// The containers should round trip through their encodings
+	blob, err := ssz.EncodeAppend(nil, update)
+	if err != nil {
+		t.Fatalf("failed to encode update: %v", err)
+	}
+	decoded := new(sszcommon.LightClientUpdateElectra)
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode update: %v", err)
+	}
+	if err := sszcommon.VerifyFinality(decoded); err != nil {
+		t.Errorf("failed to verify decoded finality: %v", err)
+	}
+	// Tampered execution payload headers should be rejected
+	decoded.AttestedHeader.Execution.BlockNumber++
+	if err := sszcommon.VerifyNextSyncCommittee(decoded); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("tampered execution error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	decoded.AttestedHeader.Execution = nil
+	if err := sszcommon.VerifyFinality(decoded); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("missing execution error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+}
@@ -0,0 +1,36 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package sszcommon contains the mainline Ethereum consensus containers across
+// the forks, along with their maintained SSZ codec methods.
+//
+// The package is optional, it is not imported by the ssz library itself. It is
+// meant to save downstream projects from copying the container definitions of
+// the consensus specs (and keeping them in sync) in their own code. The types
+// use the mainnet preset for all list and vector bounds.
+//
+// Containers that changed across forks are suffixed with the name of the fork
+// they were introduced in (e.g. BeaconBlockBodyDeneb); the phase0 versions are
+// left unsuffixed.
+package sszcommon
+
+import "github.com/karalabe/ssz/sszexec"
+
+// Hash is a 32 byte hash or merkle root.
+type Hash = sszexec.Hash
+
+// Address is a 20 byte execution layer account address.
+type Address = sszexec.Address
+
+// LogsBloom is the 256 byte bloom filter of an execution block's logs.
+type LogsBloom [256]byte
+
+// BLSPubkey is a 48 byte compressed BLS12-381 public key.
+type BLSPubkey = sszexec.BLSPubkey
+
+// BLSSignature is a 96 byte compressed BLS12-381 signature.
+type BLSSignature = sszexec.BLSSignature
+
+// Version is a 4 byte fork version.
+type Version [4]byte
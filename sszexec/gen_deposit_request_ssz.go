@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: ae1c671b6709c1647ac22ec8c171078094de9654f041499750b94eb39e36944f
+
+package sszexec
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *DepositRequest) SizeSSZ() uint32 {
+	return 48 + 32 + 8 + 96 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *DepositRequest) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.Pubkey)                // Field  (0) -                Pubkey - 48 bytes
+	ssz.DefineStaticBytes(codec, &obj.WithdrawalCredentials) // Field  (1) - WithdrawalCredentials - 32 bytes
+	ssz.DefineUint64(codec, &obj.Amount)                     // Field  (2) -                Amount -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.Signature)             // Field  (3) -             Signature - 96 bytes
+	ssz.DefineUint64(codec, &obj.Index)                      // Field  (4) -                 Index -  8 bytes
+}
@@ -0,0 +1,18 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: ea5acd3ae470f490316fb43ebcc26e0748953558abd2df2e02086687fc818896
+
+package sszexec
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *WithdrawalRequest) SizeSSZ() uint32 {
+	return 20 + 48 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *WithdrawalRequest) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.SourceAddress)   // Field  (0) -   SourceAddress - 20 bytes
+	ssz.DefineStaticBytes(codec, &obj.ValidatorPubkey) // Field  (1) - ValidatorPubkey - 48 bytes
+	ssz.DefineUint64(codec, &obj.Amount)               // Field  (2) -          Amount -  8 bytes
+}
@@ -0,0 +1,19 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 85fd6397814cb04498bfdbc83199874de7444492f3364ff5c1e84907f25a594c
+
+package sszexec
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Withdrawal) SizeSSZ() uint32 {
+	return 8 + 8 + 20 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Withdrawal) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Index)          // Field  (0) -          Index -  8 bytes
+	ssz.DefineUint64(codec, &obj.ValidatorIndex) // Field  (1) - ValidatorIndex -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.Address)   // Field  (2) -        Address - 20 bytes
+	ssz.DefineUint64(codec, &obj.Amount)         // Field  (3) -         Amount -  8 bytes
+}
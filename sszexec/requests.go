@@ -0,0 +1,125 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszexec
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/karalabe/ssz"
+)
+
+// Request types of the execution layer requests (EIP-7685).
+const (
+	DepositRequestType       = 0x00
+	WithdrawalRequestType    = 0x01
+	ConsolidationRequestType = 0x02
+)
+
+// ErrInvalidRequests is returned if a list of typed execution requests cannot
+// be parsed into ExecutionRequests.
+var ErrInvalidRequests = errors.New("sszexec: invalid execution requests")
+
+// EncodeRequestsList flattens the execution requests into the list of typed
+// requests exchanged over the engine API: the request type byte followed by the
+// concatenated ssz encodings of the requests of that type. Types without any
+// requests are omitted.
+func EncodeRequestsList(requests *ExecutionRequests) [][]byte {
+	var list [][]byte
+	if len(requests.Deposits) > 0 {
+		list = append(list, encodeRequests(DepositRequestType, requests.Deposits))
+	}
+	if len(requests.Withdrawals) > 0 {
+		list = append(list, encodeRequests(WithdrawalRequestType, requests.Withdrawals))
+	}
+	if len(requests.Consolidations) > 0 {
+		list = append(list, encodeRequests(ConsolidationRequestType, requests.Consolidations))
+	}
+	return list
+}
+
+// encodeRequests serializes a list of requests of a single type, prefixed by the
+// request type.
+func encodeRequests[T ssz.StaticObject](kind byte, requests []T) []byte {
+	blob := make([]byte, 1, 1+len(requests)*int(requests[0].SizeSSZ()))
+	blob[0] = kind
+	for _, request := range requests {
+		blob, _ = ssz.EncodeAppend(blob, request) // static objects cannot fail
+	}
+	return blob
+}
+
+// DecodeRequestsList parses the list of typed requests exchanged over the engine
+// API back into execution requests. As required by the consensus specs, the types
+// must be strictly ascending and none of the requests may be empty.
+func DecodeRequestsList(list [][]byte) (*ExecutionRequests, error) {
+	var (
+		requests = new(ExecutionRequests)
+		last     = -1
+	)
+	for _, blob := range list {
+		if len(blob) < 2 {
+			return nil, fmt.Errorf("%w: empty request data", ErrInvalidRequests)
+		}
+		if int(blob[0]) <= last {
+			return nil, fmt.Errorf("%w: request type %d after %d", ErrInvalidRequests, blob[0], last)
+		}
+		last = int(blob[0])
+
+		var err error
+		switch blob[0] {
+		case DepositRequestType:
+			requests.Deposits, err = decodeRequests[*DepositRequest](blob[1:], MaxDepositRequestsPerPayload)
+		case WithdrawalRequestType:
+			requests.Withdrawals, err = decodeRequests[*WithdrawalRequest](blob[1:], MaxWithdrawalRequestsPerPayload)
+		case ConsolidationRequestType:
+			requests.Consolidations, err = decodeRequests[*ConsolidationRequest](blob[1:], MaxConsolidationRequestsPerPayload)
+		default:
+			err = fmt.Errorf("%w: unknown request type %d", ErrInvalidRequests, blob[0])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return requests, nil
+}
+
+// decodeRequests parses the concatenated ssz encodings of requests of a single
+// type, checking they are of whole items and within the list limit.
+func decodeRequests[T interface {
+	ssz.StaticObject
+	*U
+}, U any](blob []byte, limit int) ([]T, error) {
+	size := int(T(nil).SizeSSZ())
+	if len(blob)%size != 0 {
+		return nil, fmt.Errorf("%w: request data of %d bytes not multiple of %d", ErrInvalidRequests, len(blob), size)
+	}
+	if len(blob)/size > limit {
+		return nil, fmt.Errorf("%w: %d requests, max %d", ErrInvalidRequests, len(blob)/size, limit)
+	}
+	requests := make([]T, len(blob)/size)
+	for i := range requests {
+		requests[i] = T(new(U))
+		if err := ssz.DecodeFromBytes(blob[i*size:(i+1)*size], requests[i]); err != nil {
+			return nil, err
+		}
+	}
+	return requests, nil
+}
+
+// RequestsHash computes the commitment to the execution requests stored in the
+// requests_hash field of the execution block header (EIP-7685): the sha256 of
+// the concatenated sha256 hashes of the non-empty typed requests.
+func RequestsHash(requests *ExecutionRequests) [32]byte {
+	hasher := sha256.New()
+	for _, blob := range EncodeRequestsList(requests) {
+		hash := sha256.Sum256(blob)
+		hasher.Write(hash[:])
+	}
+	var hash [32]byte
+	hasher.Sum(hash[:0])
+	return hash
+}
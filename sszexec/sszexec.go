@@ -0,0 +1,126 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package sszexec contains the consensus containers crossing over to and from
+// the execution layer: withdrawals (EIP-4895) and the deposit, withdrawal and
+// consolidation requests (EIP-6110, EIP-7002, EIP-7251), along with their SSZ
+// codec methods and the roots and hashes execution clients need to agree on.
+//
+// The package is meant for execution layer codebases that only need these few
+// containers, without depending on the full consensus type sets of sszcommon.
+// The sszcommon package aliases the types from here, so values can be passed
+// between the two packages without conversions.
+package sszexec
+
+import (
+	"fmt"
+
+	"github.com/karalabe/ssz"
+)
+
+//go:generate go run -cover ../cmd/sszgen -type Withdrawal -out gen_withdrawal_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type DepositRequest -out gen_deposit_request_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type WithdrawalRequest -out gen_withdrawal_request_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type ConsolidationRequest -out gen_consolidation_request_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type ExecutionRequests -out gen_execution_requests_ssz.go
+
+// Mainnet limits of the execution payload and execution requests lists.
+const (
+	MaxWithdrawalsPerPayload           = 16
+	MaxDepositRequestsPerPayload       = 8192
+	MaxWithdrawalRequestsPerPayload    = 16
+	MaxConsolidationRequestsPerPayload = 2
+)
+
+// Hash is a 32 byte hash or merkle root.
+type Hash [32]byte
+
+// Address is a 20 byte execution layer account address.
+type Address [20]byte
+
+// BLSPubkey is a 48 byte compressed BLS12-381 public key.
+type BLSPubkey [48]byte
+
+// BLSSignature is a 96 byte compressed BLS12-381 signature.
+type BLSSignature [96]byte
+
+// Withdrawal is a withdrawal from the beacon chain to the execution layer.
+type Withdrawal struct {
+	Index          uint64
+	ValidatorIndex uint64
+	Address        Address
+	Amount         uint64
+}
+
+// DepositRequest is a deposit request made on the execution layer (EIP-6110).
+type DepositRequest struct {
+	Pubkey                BLSPubkey
+	WithdrawalCredentials Hash
+	Amount                uint64
+	Signature             BLSSignature
+	Index                 uint64
+}
+
+// WithdrawalRequest is a withdrawal or exit request triggered from the
+// execution layer (EIP-7002). A zero amount requests a full exit.
+type WithdrawalRequest struct {
+	SourceAddress   Address
+	ValidatorPubkey BLSPubkey
+	Amount          uint64
+}
+
+// ConsolidationRequest is a validator consolidation request triggered from
+// the execution layer (EIP-7251).
+type ConsolidationRequest struct {
+	SourceAddress Address
+	SourcePubkey  BLSPubkey
+	TargetPubkey  BLSPubkey
+}
+
+// ExecutionRequests is the list of requests from the execution layer carried
+// by an electra beacon block (EIP-7685). Its ssz merkle root (ssz.HashSequential)
+// is the execution_requests root of the beacon block body.
+type ExecutionRequests struct {
+	Deposits       []*DepositRequest       `ssz-max:"8192"`
+	Withdrawals    []*WithdrawalRequest    `ssz-max:"16"`
+	Consolidations []*ConsolidationRequest `ssz-max:"2"`
+}
+
+// WithdrawalsRoot computes the ssz merkle root of the withdrawals of a payload,
+// i.e. the withdrawals_root of the execution payload header. Note, this is not
+// the withdrawals trie root of the execution block header.
+func WithdrawalsRoot(withdrawals []*Withdrawal) ([32]byte, error) {
+	return listRoot(withdrawals, MaxWithdrawalsPerPayload)
+}
+
+// DepositRequestsRoot computes the ssz merkle root of the deposit requests of a
+// payload, as merkleized within ExecutionRequests.
+func DepositRequestsRoot(requests []*DepositRequest) ([32]byte, error) {
+	return listRoot(requests, MaxDepositRequestsPerPayload)
+}
+
+// WithdrawalRequestsRoot computes the ssz merkle root of the withdrawal requests
+// of a payload, as merkleized within ExecutionRequests.
+func WithdrawalRequestsRoot(requests []*WithdrawalRequest) ([32]byte, error) {
+	return listRoot(requests, MaxWithdrawalRequestsPerPayload)
+}
+
+// ConsolidationRequestsRoot computes the ssz merkle root of the consolidation
+// requests of a payload, as merkleized within ExecutionRequests.
+func ConsolidationRequestsRoot(requests []*ConsolidationRequest) ([32]byte, error) {
+	return listRoot(requests, MaxConsolidationRequestsPerPayload)
+}
+
+// listRoot computes the ssz merkle root of a list of static containers with the
+// given maximum number of items.
+func listRoot[T ssz.StaticObject](items []T, limit uint64) ([32]byte, error) {
+	if uint64(len(items)) > limit {
+		return [32]byte{}, fmt.Errorf("%w: have %d items, max %d", ssz.ErrMaxItemsExceeded, len(items), limit)
+	}
+	roots := make([][32]byte, len(items))
+	for i, item := range items {
+		roots[i] = ssz.HashSequential(item)
+	}
+	return ssz.ListRoot(roots, limit)
+}
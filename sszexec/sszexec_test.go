@@ -0,0 +1,126 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszexec_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/sszcommon"
+	"github.com/karalabe/ssz/sszexec"
+)
+
+// newTestRequests creates a set of execution requests with some content.
+func newTestRequests() *sszexec.ExecutionRequests {
+	return &sszexec.ExecutionRequests{
+		Deposits: []*sszexec.DepositRequest{
+			{Pubkey: sszexec.BLSPubkey{0x01}, Amount: 32_000_000_000, Index: 1},
+			{Pubkey: sszexec.BLSPubkey{0x02}, Amount: 1_000_000_000, Index: 2},
+		},
+		Consolidations: []*sszexec.ConsolidationRequest{
+			{SourceAddress: sszexec.Address{0x03}, SourcePubkey: sszexec.BLSPubkey{0x04}, TargetPubkey: sszexec.BLSPubkey{0x05}},
+		},
+	}
+}
+
+// Tests that the list root helpers match the roots merkleized within the
+// containers embedding the lists.
+func TestListRoots(t *testing.T) {
+	requests := newTestRequests()
+	requests.Withdrawals = []*sszexec.WithdrawalRequest{{SourceAddress: sszexec.Address{0x06}}}
+
+	tree := ssz.HashTree(requests)
+	for i, fn := range []func() ([32]byte, error){
+		func() ([32]byte, error) { return sszexec.DepositRequestsRoot(requests.Deposits) },
+		func() ([32]byte, error) { return sszexec.WithdrawalRequestsRoot(requests.Withdrawals) },
+		func() ([32]byte, error) { return sszexec.ConsolidationRequestsRoot(requests.Consolidations) },
+	} {
+		root, err := fn()
+		if err != nil {
+			t.Fatalf("field %d: failed to compute root: %v", i, err)
+		}
+		node, err := tree.Get(uint64(4 + i))
+		if err != nil {
+			t.Fatalf("field %d: failed to retrieve subtree: %v", i, err)
+		}
+		if root != node.Root() {
+			t.Errorf("field %d: root mismatch: have %x, want %x", i, root, node.Root())
+		}
+	}
+	// The withdrawals root is the one stored in execution payload headers
+	payload := &sszcommon.ExecutionPayloadCapella{
+		Withdrawals: []*sszexec.Withdrawal{
+			{Index: 1, ValidatorIndex: 2, Address: sszexec.Address{0x03}, Amount: 4},
+		},
+	}
+	root, err := sszexec.WithdrawalsRoot(payload.Withdrawals)
+	if err != nil {
+		t.Fatalf("failed to compute withdrawals root: %v", err)
+	}
+	node, err := ssz.HashTree(payload).Get(16 + 14)
+	if err != nil {
+		t.Fatalf("failed to retrieve withdrawals subtree: %v", err)
+	}
+	if root != node.Root() {
+		t.Errorf("withdrawals root mismatch: have %x, want %x", root, node.Root())
+	}
+	// Oversized lists must be rejected
+	if _, err := sszexec.ConsolidationRequestsRoot(make([]*sszexec.ConsolidationRequest, 3)); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Errorf("oversized list error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+}
+
+// Tests that execution requests round trip through the typed requests list of
+// the engine API, and that the requests hash commits to that list.
+func TestRequestsList(t *testing.T) {
+	requests := newTestRequests()
+
+	list := sszexec.EncodeRequestsList(requests)
+	if len(list) != 2 {
+		t.Fatalf("typed requests count mismatch: have %d, want %d", len(list), 2)
+	}
+	if list[0][0] != sszexec.DepositRequestType || len(list[0]) != 1+2*192 {
+		t.Errorf("deposit requests mismatch: type %d, %d bytes", list[0][0], len(list[0]))
+	}
+	if list[1][0] != sszexec.ConsolidationRequestType || len(list[1]) != 1+116 {
+		t.Errorf("consolidation requests mismatch: type %d, %d bytes", list[1][0], len(list[1]))
+	}
+	decoded, err := sszexec.DecodeRequestsList(list)
+	if err != nil {
+		t.Fatalf("failed to decode requests list: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, requests) {
+		t.Errorf("decoded requests mismatch: have %+v, want %+v", decoded, requests)
+	}
+	// Cross check the requests hash against a naive implementation
+	var hashes []byte
+	for _, blob := range list {
+		hash := sha256.Sum256(blob)
+		hashes = append(hashes, hash[:]...)
+	}
+	if have, want := sszexec.RequestsHash(requests), sha256.Sum256(hashes); have != want {
+		t.Errorf("requests hash mismatch: have %x, want %x", have, want)
+	}
+	if have, want := sszexec.RequestsHash(new(sszexec.ExecutionRequests)), sha256.Sum256(nil); have != want {
+		t.Errorf("empty requests hash mismatch: have %x, want %x", have, want)
+	}
+	// Malformed lists must be rejected
+	for i, bad := range [][][]byte{
+		{{sszexec.DepositRequestType}}, // empty request data
+		{list[1], list[0]},             // descending types
+		{list[0], list[0]},             // duplicate types
+		{{0x03, 0x00}},                 // unknown type
+		{list[0][:len(list[0])-1]},     // partial request
+		{append([]byte{sszexec.ConsolidationRequestType}, bytes.Repeat(list[1][1:], 3)...)}, // oversized list
+	} {
+		if _, err := sszexec.DecodeRequestsList(bad); !errors.Is(err, sszexec.ErrInvalidRequests) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, sszexec.ErrInvalidRequests)
+		}
+	}
+}
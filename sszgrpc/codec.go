@@ -0,0 +1,63 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package sszgrpc implements a gRPC codec carrying SSZ payloads, so services can
+// exchange consensus objects over gRPC without double-encoding them into protobuf.
+//
+// The codec satisfies grpc's encoding.Codec interface structurally, so this
+// package does not depend on grpc. Register it in the services and clients
+// that should negotiate it (the content-subtype is "ssz"):
+//
+//	func init() {
+//		encoding.RegisterCodec(sszgrpc.Codec{})
+//	}
+//
+// Clients then opt into it per call or connection via grpc.CallContentSubtype.
+// Messages must be types implementing ssz.Object, e.g. the generated ones.
+package sszgrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/karalabe/ssz"
+)
+
+// Name is the name the codec registers under, used as the gRPC content-subtype
+// (i.e. the content-type of the payloads is "application/grpc+ssz").
+const Name = "ssz"
+
+// ErrUnsupportedType is returned if a message does not implement ssz.Object.
+var ErrUnsupportedType = errors.New("sszgrpc: message is not an ssz object")
+
+// Codec is the gRPC codec (de)serializing messages as SSZ. The zero value uses
+// the default decoder settings; as payloads arrive from the network, consider
+// bounding the allocations via a config (see ssz.DecoderConfig.MaxAlloc).
+type Codec struct {
+	Decoder *ssz.DecoderConfig // Optional config to customize the decoding runs
+}
+
+// Marshal serializes a message into a freshly allocated buffer, as gRPC may
+// retain it until the message is sent.
+func (c Codec) Marshal(v any) ([]byte, error) {
+	obj, ok := v.(ssz.Object)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+	}
+	return ssz.EncodeAppend(nil, obj)
+}
+
+// Unmarshal parses a received payload into a message.
+func (c Codec) Unmarshal(data []byte, v any) error {
+	obj, ok := v.(ssz.Object)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+	}
+	return ssz.DecodeFromBytesWithConfig(data, obj, c.Decoder)
+}
+
+// Name returns the name the codec registers under.
+func (c Codec) Name() string {
+	return Name
+}
@@ -0,0 +1,70 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sszgrpc_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/sszcommon"
+	"github.com/karalabe/ssz/sszgrpc"
+)
+
+// grpcCodec is grpc's encoding.Codec interface, which the codec must satisfy.
+type grpcCodec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+	Name() string
+}
+
+var _ grpcCodec = sszgrpc.Codec{}
+
+// Tests that messages round trip through the codec, and that non-ssz messages
+// are rejected.
+func TestCodec(t *testing.T) {
+	codecs := map[string]sszgrpc.Codec{
+		"default":    {},
+		"configured": {Decoder: &ssz.DecoderConfig{MaxAlloc: 1 << 20}},
+	}
+	for name, codec := range codecs {
+		if have := codec.Name(); have != "ssz" {
+			t.Errorf("%s: name mismatch: have %s, want ssz", name, have)
+		}
+		requests := &sszcommon.ExecutionRequests{
+			Withdrawals: []*sszcommon.WithdrawalRequest{{Amount: 1}, {Amount: 2}},
+		}
+		blob, err := codec.Marshal(requests)
+		if err != nil {
+			t.Fatalf("%s: failed to marshal message: %v", name, err)
+		}
+		// gRPC unmarshals concurrently, which must be safe even with a config
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				dec := new(sszcommon.ExecutionRequests)
+				if err := codec.Unmarshal(blob, dec); err != nil {
+					t.Errorf("%s: failed to unmarshal message: %v", name, err)
+					return
+				}
+				if have, want := ssz.HashSequential(dec), ssz.HashSequential(requests); have != want {
+					t.Errorf("%s: message mismatch: have %#x, want %#x", name, have, want)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if _, err := codec.Marshal("not an object"); !errors.Is(err, sszgrpc.ErrUnsupportedType) {
+			t.Errorf("%s: marshal error mismatch: have %v, want %v", name, err, sszgrpc.ErrUnsupportedType)
+		}
+		if err := codec.Unmarshal(blob, new(int)); !errors.Is(err, sszgrpc.ErrUnsupportedType) {
+			t.Errorf("%s: unmarshal error mismatch: have %v, want %v", name, err, sszgrpc.ErrUnsupportedType)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+//go:build !purego && !race
+
+package ssztest
+
+// ZeroAllocGuaranteed reports whether the zero-allocation guarantees of the
+// codec hold in the current build. They are waived in purego builds (which use
+// reflection) and in race builds (where sync.Pool randomly drops items).
+const ZeroAllocGuaranteed = true
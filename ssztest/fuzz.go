@@ -0,0 +1,129 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssztest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// FuzzAllocFactor is the maximum number of bytes the decoder may allocate per
+// input byte in the bounded memory invariant of the fuzzers. The Go form of an
+// object is usually larger than its encoding (e.g. a 24 byte slice header for
+// a 4 byte offset), but it must never be disproportionate to the input.
+const FuzzAllocFactor = 64
+
+// fuzzAllocSlack is the memory the decoder may allocate on top of the budget
+// proportional to the input, so that tiny inputs are not flagged.
+const fuzzAllocSlack = 4096
+
+var (
+	registry     = make(map[string]func() ssz.Object)
+	registryLock sync.Mutex
+)
+
+// Register adds a type to the set of types fuzzed by FuzzRegistered, under a
+// unique name. It is meant to be called from the init functions of test files.
+func Register[T ssz.Object](name string, fresh func() T) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("ssztest: type %q already registered", name))
+	}
+	registry[name] = func() ssz.Object { return fresh() }
+}
+
+// FuzzInvariants runs a fuzzer decoding arbitrary inputs into fresh objects, and
+// checks the structural invariants of the decoder on every one of them:
+//
+//   - Decoding never panics
+//   - Decoding never allocates more than FuzzAllocFactor bytes per input byte
+//   - Buffered and streamed decoding agree on whether an input is valid
+//   - Valid inputs decode, re-encode and decode again into the same bytes and
+//     hashes (i.e. they are a fixpoint of the codec)
+//
+// Inputs larger than maxSize are skipped, if it is non-zero.
+func FuzzInvariants[T ssz.Object](f *testing.F, fresh func() T, maxSize uint64) {
+	f.Add(populatedEncoding(f, fresh()))
+
+	f.Fuzz(func(t *testing.T, blob []byte) {
+		assertInvariants(t, blob, fresh, maxSize)
+	})
+}
+
+// FuzzRegistered is analogous to FuzzInvariants, but it fuzzes all the types
+// added via Register at once. The first byte of every input selects the type
+// to decode the rest into.
+func FuzzRegistered(f *testing.F, maxSize uint64) {
+	registryLock.Lock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	registryLock.Unlock()
+
+	if len(names) == 0 {
+		f.Skip("no types registered")
+	}
+	if len(names) > math.MaxUint8+1 {
+		f.Fatalf("too many types registered: have %d, max %d", len(names), math.MaxUint8+1)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		f.Add(append([]byte{byte(i)}, populatedEncoding(f, registry[name]())...))
+	}
+	f.Fuzz(func(t *testing.T, blob []byte) {
+		if len(blob) == 0 {
+			return
+		}
+		assertInvariants(t, blob[1:], registry[names[int(blob[0])%len(names)]], maxSize)
+	})
+}
+
+// populatedEncoding encodes a populated object, to seed the fuzzers with.
+func populatedEncoding(f *testing.F, obj ssz.Object) []byte {
+	obj = Populate(obj)
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		f.Fatalf("failed to encode seed: %v", err)
+	}
+	return blob
+}
+
+// assertInvariants fails the test if decoding the blob violates any of the
+// structural invariants checked by FuzzInvariants.
+func assertInvariants[T ssz.Object](t *testing.T, blob []byte, fresh func() T, maxSize uint64) {
+	t.Helper()
+
+	// Empty inputs are rejected upfront by buffered decoding, nothing to check
+	if len(blob) == 0 || (maxSize != 0 && uint64(len(blob)) > maxSize) {
+		return
+	}
+	cfg := &ssz.DecoderConfig{MaxAlloc: FuzzAllocFactor*uint64(len(blob)) + fuzzAllocSlack}
+
+	bufferErr := ssz.DecodeFromBytesWithConfig(blob, fresh(), cfg)
+	streamErr := ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), fresh(), uint32(len(blob)), cfg)
+
+	for _, err := range []error{bufferErr, streamErr} {
+		if errors.Is(err, ssz.ErrMaxAllocExceeded) {
+			t.Fatalf("decoding %d bytes exceeded memory budget of %d bytes: %v", len(blob), cfg.MaxAlloc, err)
+		}
+	}
+	if (bufferErr == nil) != (streamErr == nil) {
+		t.Fatalf("buffer/stream decoding disagree: buffer %v, stream %v", bufferErr, streamErr)
+	}
+	if bufferErr == nil {
+		assertCanonical(t, blob, fresh)
+	}
+}
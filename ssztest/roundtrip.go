@@ -0,0 +1,147 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssztest
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// Populate allocates all the nil pointers within the object (recursively into
+// structs and arrays), so that a zero object with nested containers can be
+// encoded. Slices are left empty. The object itself is returned for chaining.
+func Populate[T ssz.Object](obj T) T {
+	populate(reflect.ValueOf(obj))
+	return obj
+}
+
+// populate is the recursive reflection walker of Populate.
+func populate(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		populate(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Field(i); field.CanSet() {
+				populate(field)
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			populate(v.Index(i))
+		}
+	}
+}
+
+// AssertRoundTrip fails the test if the encoding of the object does not survive
+// a decode and re-encode round trip via both buffers and streams, or if the size
+// or the hashes of the decoded objects are inconsistent with the original. The
+// fresh method must return new objects of the same type to decode into.
+func AssertRoundTrip[T ssz.Object](t testing.TB, obj T, fresh func() T) {
+	t.Helper()
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	stream := new(bytes.Buffer)
+	if err := ssz.EncodeToStream(stream, obj); err != nil {
+		t.Fatalf("failed to stream encode object: %v", err)
+	}
+	if !bytes.Equal(stream.Bytes(), blob) {
+		t.Fatalf("stream encoding mismatch: have %x, want %x", stream.Bytes(), blob)
+	}
+	assertCanonical(t, blob, fresh)
+
+	decoded := fresh()
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if have, want := ssz.HashSequential(decoded), ssz.HashSequential(obj); have != want {
+		t.Fatalf("decoded hash mismatch: have %#x, want %#x", have, want)
+	}
+}
+
+// FuzzRoundTrip runs a fuzzer decoding arbitrary inputs into fresh objects, and
+// fails if any accepted input does not re-encode into itself, or if the sizes or
+// the hashes are inconsistent. Inputs larger than maxSize are skipped, as they
+// can never be valid (zero disables the limit). The encoding of the populated
+// zero object is used as the seed input.
+func FuzzRoundTrip[T ssz.Object](f *testing.F, fresh func() T, maxSize uint64) {
+	obj := Populate(fresh())
+
+	seed := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(seed, obj); err != nil {
+		f.Fatalf("failed to encode seed: %v", err)
+	}
+	f.Add(seed)
+
+	f.Fuzz(func(t *testing.T, blob []byte) {
+		if maxSize != 0 && uint64(len(blob)) > maxSize {
+			return
+		}
+		if err := ssz.DecodeFromBytes(blob, fresh()); err != nil {
+			return
+		}
+		assertCanonical(t, blob, fresh)
+	})
+}
+
+// assertCanonical fails the test if a valid encoding cannot be decoded via both
+// buffers and streams, or if the decoded objects do not re-encode into the same
+// bytes, or if their sizes or hashes are inconsistent.
+func assertCanonical[T ssz.Object](t testing.TB, blob []byte, fresh func() T) {
+	t.Helper()
+
+	obj := fresh()
+	if err := ssz.DecodeFromBytes(blob, obj); err != nil {
+		t.Fatalf("failed to decode buffer: %v", err)
+	}
+	if size := ssz.Size(obj); size != uint32(len(blob)) {
+		t.Fatalf("size mismatch: have %d, want %d", size, len(blob))
+	}
+	have := make([]byte, len(blob))
+	if err := ssz.EncodeToBytes(have, obj); err != nil {
+		t.Fatalf("failed to re-encode buffer: %v", err)
+	}
+	if !bytes.Equal(have, blob) {
+		t.Fatalf("re-encoded buffer mismatch: have %x, want %x", have, blob)
+	}
+	streamed := fresh()
+	if err := ssz.DecodeFromStream(bytes.NewReader(blob), streamed, uint32(len(blob))); err != nil {
+		t.Fatalf("failed to decode stream: %v", err)
+	}
+	stream := new(bytes.Buffer)
+	if err := ssz.EncodeToStream(stream, streamed); err != nil {
+		t.Fatalf("failed to re-encode stream: %v", err)
+	}
+	if !bytes.Equal(stream.Bytes(), blob) {
+		t.Fatalf("re-encoded stream mismatch: have %x, want %x", stream.Bytes(), blob)
+	}
+	hash := ssz.HashSequential(obj)
+	if have := ssz.HashConcurrent(obj); have != hash {
+		t.Fatalf("sequential/concurrent hash mismatch: sequential %#x, concurrent %#x", hash, have)
+	}
+	if have := ssz.HashSequential(streamed); have != hash {
+		t.Fatalf("buffer/stream decoded hash mismatch: buffer %#x, stream %#x", hash, have)
+	}
+	stream.Reset()
+	size, root, err := ssz.EncodeAndHash(stream, obj)
+	if err != nil {
+		t.Fatalf("failed to encode and hash: %v", err)
+	}
+	if !bytes.Equal(stream.Bytes(), blob) || size != uint32(len(blob)) {
+		t.Fatalf("encode and hash output mismatch: have %x (size %d), want %x", stream.Bytes(), size, blob)
+	}
+	if root != hash {
+		t.Fatalf("encode and hash root mismatch: have %#x, want %#x", root, hash)
+	}
+}
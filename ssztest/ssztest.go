@@ -0,0 +1,84 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package ssztest contains test helpers to guard the allocation guarantees of
+// the ssz codec on user types, and to check their encodings round trip (these
+// are also used by the tests emitted by sszgen).
+//
+// The codec guarantees that the following operations do not allocate, as long
+// as the types are defined via the Define* methods (generated or hand written):
+//
+//   - Encoding into a buffer via ssz.EncodeToBytes
+//   - Encoding into a stream via ssz.EncodeToStream
+//   - Decoding via ssz.DecodeFromBytes into an object previously decoded from
+//     an encoding of the same shape (i.e. all memory is reused)
+//   - Hashing via ssz.HashSequential
+//
+// The concurrent variants and the various configurable modes (e.g. tracing or
+// custom allocators) may allocate. The guarantees are also waived in purego
+// and race builds, in which case the assertions are skipped.
+package ssztest
+
+import (
+	"io"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// allocRuns is the number of times a function is run to average its allocs.
+const allocRuns = 10
+
+// AssertZeroAlloc fails the test if the function allocates any memory. It is
+// run once to warm up any caches and pools before measuring.
+func AssertZeroAlloc(t testing.TB, fn func()) {
+	t.Helper()
+
+	if !ZeroAllocGuaranteed {
+		return
+	}
+	if allocs := testing.AllocsPerRun(allocRuns, fn); allocs != 0 {
+		t.Errorf("allocations mismatch: have %v, want 0", allocs)
+	}
+}
+
+// AssertEncodeZeroAlloc fails the test if encoding the object into a buffer or
+// a stream allocates any memory.
+func AssertEncodeZeroAlloc(t testing.TB, obj ssz.Object) {
+	t.Helper()
+
+	buf := make([]byte, ssz.Size(obj))
+	AssertZeroAlloc(t, func() {
+		if err := ssz.EncodeToBytes(buf, obj); err != nil {
+			t.Fatalf("failed to encode object: %v", err)
+		}
+	})
+	AssertZeroAlloc(t, func() {
+		if err := ssz.EncodeToStream(io.Discard, obj); err != nil {
+			t.Fatalf("failed to encode object: %v", err)
+		}
+	})
+}
+
+// AssertDecodeZeroAlloc fails the test if re-decoding the blob into the object
+// allocates any memory. The object is decoded into once before measuring, so
+// it does not need to be pre-populated.
+func AssertDecodeZeroAlloc(t testing.TB, blob []byte, obj ssz.Object) {
+	t.Helper()
+
+	AssertZeroAlloc(t, func() {
+		if err := ssz.DecodeFromBytes(blob, obj); err != nil {
+			t.Fatalf("failed to decode object: %v", err)
+		}
+	})
+}
+
+// AssertHashZeroAlloc fails the test if hashing the object allocates memory.
+func AssertHashZeroAlloc(t testing.TB, obj ssz.Object) {
+	t.Helper()
+
+	AssertZeroAlloc(t, func() {
+		ssz.HashSequential(obj)
+	})
+}
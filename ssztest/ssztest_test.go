@@ -0,0 +1,78 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssztest_test
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/benchmarks"
+	"github.com/karalabe/ssz/sszcommon"
+	"github.com/karalabe/ssz/ssztest"
+	"github.com/karalabe/ssz/vectors"
+)
+
+// recordingTB is a test handle that records failures instead of reporting them.
+type recordingTB struct {
+	testing.TB
+	failed bool
+}
+
+func (t *recordingTB) Helper() {}
+
+func (t *recordingTB) Errorf(format string, args ...any) {
+	t.failed = true
+}
+
+// allocSink forces the allocations in the tests to escape to the heap.
+var allocSink []byte
+
+// Tests that the allocation guard detects allocating functions.
+func TestAssertZeroAlloc(t *testing.T) {
+	if !ssztest.ZeroAllocGuaranteed {
+		t.Skip("zero allocation guarantees waived in this build")
+	}
+	rec := &recordingTB{TB: t}
+	ssztest.AssertZeroAlloc(rec, func() {})
+	if rec.failed {
+		t.Errorf("non-allocating function reported as allocating")
+	}
+	ssztest.AssertZeroAlloc(rec, func() { allocSink = make([]byte, 32) })
+	if !rec.failed {
+		t.Errorf("allocating function not reported")
+	}
+}
+
+// Tests that the hot encode, decode and hash paths do not allocate on mainnet
+// shaped blocks and states, covering most field types of the codec.
+func TestZeroAllocGuarantees(t *testing.T) {
+	corpora, err := benchmarks.Corpora(256)
+	if err != nil {
+		t.Fatalf("failed to create corpora: %v", err)
+	}
+	for _, corpus := range corpora {
+		t.Run(corpus.Name, func(t *testing.T) {
+			ssztest.AssertEncodeZeroAlloc(t, corpus.Obj)
+			ssztest.AssertDecodeZeroAlloc(t, corpus.Blob, corpus.New())
+			ssztest.AssertHashZeroAlloc(t, corpus.Obj)
+		})
+	}
+}
+
+func init() {
+	ssztest.Register("basics", func() *vectors.Basics { return new(vectors.Basics) })
+	ssztest.Register("lists", func() *vectors.Lists { return new(vectors.Lists) })
+	ssztest.Register("nested", func() *vectors.Nested { return new(vectors.Nested) })
+}
+
+// Fuzzes the decoder's structural invariants over a few synthetic types with
+// all kinds of fields.
+func FuzzRegisteredTypes(f *testing.F) {
+	ssztest.FuzzRegistered(f, 1<<16)
+}
+
+// Fuzzes the decoder's structural invariants over a mainnet block.
+func FuzzInvariantsBlock(f *testing.F) {
+	ssztest.FuzzInvariants(f, func() *sszcommon.SignedBeaconBlockDeneb { return new(sszcommon.SignedBeaconBlockDeneb) }, 1<<20)
+}
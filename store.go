@@ -0,0 +1,150 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	bitops "math/bits"
+	"sync"
+)
+
+// NodeStore is a persistent storage of merkle tree nodes (e.g. backed by a key-
+// value database like Pebble or RocksDB), used to serve proofs of objects long
+// after they were hashed.
+//
+// Nodes are content addressed: every branch node is stored as its two children,
+// keyed by its own hash. This allows the trees of many versions of an object
+// (e.g. historical beacon states) to share all their unchanged subtrees. Stores
+// addressing nodes by generalized index can use ProveFromNodes instead.
+type NodeStore interface {
+	// Get retrieves the children of a branch node by its hash, or reports that
+	// the node is not a known branch (e.g. it's a leaf).
+	Get(root [32]byte) (left [32]byte, right [32]byte, ok bool, err error)
+
+	// Put stores the children of a branch node, keyed by its hash.
+	Put(root [32]byte, left [32]byte, right [32]byte) error
+}
+
+// hasherZeroDepths maps the roots of the all-zero subtrees to their depths, so
+// they can be expanded without being stored.
+var hasherZeroDepths = make(map[[32]byte]int, len(hasherZeroCache))
+
+func init() {
+	for depth, root := range hasherZeroCache {
+		hasherZeroDepths[root] = depth
+	}
+}
+
+// HashToStore computes the full ssz merkle tree of the object and writes it into
+// the node store, returning the merkle root to later request proofs against.
+func HashToStore(store NodeStore, obj Object) ([32]byte, error) {
+	tree := HashTree(obj)
+	if err := StoreTree(store, tree); err != nil {
+		return [32]byte{}, err
+	}
+	return tree.Root(), nil
+}
+
+// StoreTree writes all the branch nodes of a merkle tree into the node store.
+// Subtrees already present in the store (e.g. unchanged since a previously
+// stored version of the object) and all-zero subtrees are not written again.
+func StoreTree(store NodeStore, node *TreeNode) error {
+	if node.Leaf() {
+		return nil
+	}
+	if _, ok := treeZeroSet[node]; ok {
+		return nil
+	}
+	root := node.Root()
+	if _, ok := hasherZeroDepths[root]; ok {
+		return nil
+	}
+	if _, _, ok, err := store.Get(root); err != nil || ok {
+		return err
+	}
+	// Store the children first, so a present node always has its whole subtree
+	// available, even if storing is interrupted midway
+	if err := StoreTree(store, node.Left()); err != nil {
+		return err
+	}
+	if err := StoreTree(store, node.Right()); err != nil {
+		return err
+	}
+	return store.Put(root, node.Left().Root(), node.Right().Root())
+}
+
+// ProveFromStore creates a multiproof of the given nodes of the tree with the
+// given root, resolving the nodes by walking the tree in the node store.
+func ProveFromStore(store NodeStore, root [32]byte, indices ...uint64) (*Multiproof, error) {
+	return ProveFromNodes(indices, func(gindex uint64) ([32]byte, error) {
+		return storeNode(store, root, gindex)
+	})
+}
+
+// storeNode resolves a node of the tree with the given root from the node store,
+// addressed by its generalized index.
+func storeNode(store NodeStore, root [32]byte, gindex uint64) ([32]byte, error) {
+	if gindex == 0 {
+		return [32]byte{}, fmt.Errorf("%w: %d", ErrTreeNodeNotFound, gindex)
+	}
+	node := root
+	for bit := bitops.Len64(gindex) - 2; bit >= 0; bit-- {
+		left, right, ok, err := store.Get(node)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		if !ok {
+			depth, zero := hasherZeroDepths[node]
+			if !zero || depth == 0 {
+				return [32]byte{}, fmt.Errorf("%w: %d", ErrTreeNodeNotFound, gindex)
+			}
+			left, right = hasherZeroCache[depth-1], hasherZeroCache[depth-1]
+		}
+		if gindex>>bit&1 == 0 {
+			node = left
+		} else {
+			node = right
+		}
+	}
+	return node, nil
+}
+
+// MemoryNodeStore is an in-memory NodeStore, mostly useful for testing and for
+// caching the trees of a handful of objects. It is safe for concurrent use.
+//
+// The zero value is an empty store ready to use.
+type MemoryNodeStore struct {
+	nodes map[[32]byte][2][32]byte
+	lock  sync.RWMutex
+}
+
+// Get implements NodeStore, retrieving the children of a branch node.
+func (s *MemoryNodeStore) Get(root [32]byte) ([32]byte, [32]byte, bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	children, ok := s.nodes[root]
+	return children[0], children[1], ok, nil
+}
+
+// Put implements NodeStore, storing the children of a branch node.
+func (s *MemoryNodeStore) Put(root [32]byte, left [32]byte, right [32]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.nodes == nil {
+		s.nodes = make(map[[32]byte][2][32]byte)
+	}
+	s.nodes[root] = [2][32]byte{left, right}
+	return nil
+}
+
+// Len returns the number of branch nodes in the store.
+func (s *MemoryNodeStore) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.nodes)
+}
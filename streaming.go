@@ -0,0 +1,75 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"fmt"
+	"io"
+)
+
+// StreamingHasher computes the ssz merkle root of an object from its encoding,
+// accepting the encoded bytes incrementally as they arrive (e.g. chunk by chunk
+// while downloading a state via req/resp). The bytes are decoded on the fly, so
+// only the object is ever held in memory, never its encoding.
+//
+// The hasher must be finalized via Sum, otherwise its background decoder leaks.
+type StreamingHasher struct {
+	pipe *io.PipeWriter // Input side of the background decoder
+	done chan struct{}  // Closed when the background decoder terminates
+
+	root [32]byte // Merkle root of the decoded object
+	err  error    // Any decoding error (or trailing data)
+}
+
+// NewStreamingHasher creates a hasher for the encoding of an object of the given
+// size. The object acts as the descriptor of the expected type: it is decoded
+// into (overwriting its contents), so it must not be accessed until Sum returns.
+func NewStreamingHasher(obj Object, size uint32) *StreamingHasher {
+	reader, writer := io.Pipe()
+	h := &StreamingHasher{
+		pipe: writer,
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(h.done)
+
+		if h.err = DecodeFromStream(reader, obj, size); h.err != nil {
+			reader.CloseWithError(h.err)
+			return
+		}
+		// Object fully decoded, reject any further writes
+		reader.CloseWithError(fmt.Errorf("%w: more than %d bytes written", ErrExcessData, size))
+		h.root = HashSequential(obj)
+	}()
+	return h
+}
+
+// NewStreamingHasher creates a hasher for the encoding of a container of the
+// schema with the given size.
+func (s *Schema) NewStreamingHasher(size uint32) (*StreamingHasher, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return NewStreamingHasher(newSchemaObject(s).object(), size), nil
+}
+
+// Write implements io.Writer, feeding the next chunk of the encoding into the
+// hasher. It blocks until the chunk is consumed by the decoder, and fails if the
+// encoding is invalid or longer than the declared size.
+func (h *StreamingHasher) Write(p []byte) (int, error) {
+	return h.pipe.Write(p)
+}
+
+// Sum finalizes the hasher, returning the merkle root of the object, or an error
+// if the encoding was invalid or shorter than the declared size.
+func (h *StreamingHasher) Sum() ([32]byte, error) {
+	h.pipe.Close()
+	<-h.done
+
+	if h.err != nil {
+		return [32]byte{}, h.err
+	}
+	return h.root, nil
+}
@@ -0,0 +1,140 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var updateAPI = flag.Bool("update-api", false, "update the stable API listing")
+
+// apiListing is the file containing the exported function signatures of the
+// ssz package that are guaranteed to remain stable.
+const apiListing = "testdata/api.txt"
+
+// parseAPI collects the signatures of the exported package level functions of
+// the ssz package, keyed by function name.
+func parseAPI(t *testing.T) map[string]string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "..", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("failed to parse ssz package: %v", err)
+	}
+	api := make(map[string]string)
+	for _, file := range pkgs["ssz"].Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() {
+				continue
+			}
+			fn = &ast.FuncDecl{Name: fn.Name, Type: fn.Type} // strip docs and body
+
+			var sig bytes.Buffer
+			if err := printer.Fprint(&sig, fset, fn); err != nil {
+				t.Fatalf("failed to print %s: %v", fn.Name.Name, err)
+			}
+			api[fn.Name.Name] = sig.String()
+		}
+	}
+	return api
+}
+
+// Tests that every primitive the codec can define has its exported encoder,
+// decoder, hasher and sizer siblings, so types with asymmetric codecs can use
+// everything the symmetric ones can.
+func TestAPISymmetry(t *testing.T) {
+	api := parseAPI(t)
+
+	require := func(name string, from string) {
+		if _, ok := api[name]; !ok {
+			t.Errorf("%s has no %s sibling", from, name)
+		}
+	}
+	for name := range api {
+		switch {
+		case strings.HasPrefix(name, "Define"):
+			kind := strings.TrimPrefix(name, "Define")
+
+			// Progressive lists and factory based decoders share the regular
+			// list encoders (and decoders for the former)
+			plain := strings.TrimPrefix(kind, "Progressive")
+			require("Encode"+strings.TrimSuffix(plain, "Func"), name)
+			require("Decode"+plain, name)
+
+			// Dynamic fields are hashed and sized once, at the offset position
+			if strings.HasSuffix(strings.TrimSuffix(kind, "Func"), "Content") {
+				continue
+			}
+			field := strings.TrimSuffix(strings.TrimSuffix(kind, "Func"), "Offset")
+			require("Hash"+field, name)
+
+			if strings.HasSuffix(strings.TrimSuffix(kind, "Func"), "Offset") {
+				require("Size"+strings.TrimPrefix(field, "Progressive"), name)
+			}
+		case strings.HasPrefix(name, "Decode"):
+			kind := strings.TrimPrefix(name, "Decode")
+			if strings.HasPrefix(kind, "From") || strings.HasPrefix(kind, "New") || strings.HasPrefix(kind, "Skip") {
+				continue // top level entrypoints and decoder-only helpers
+			}
+			require("Encode"+strings.TrimSuffix(kind, "Func"), name)
+		}
+	}
+}
+
+// Tests that the signatures of the stable API did not change. New functions
+// may be added freely, but existing ones must not be removed or modified. Run
+// with -update-api to accept additions into the listing.
+func TestAPICompatibility(t *testing.T) {
+	api := parseAPI(t)
+
+	if *updateAPI {
+		sigs := make([]string, 0, len(api))
+		for _, sig := range api {
+			sigs = append(sigs, sig)
+		}
+		sort.Strings(sigs)
+		if err := os.WriteFile(apiListing, []byte(strings.Join(sigs, "\n")+"\n"), 0644); err != nil {
+			t.Fatalf("failed to update API listing: %v", err)
+		}
+		return
+	}
+	blob, err := os.ReadFile(apiListing)
+	if err != nil {
+		t.Fatalf("failed to read API listing: %v", err)
+	}
+	stable := make(map[string]bool)
+	for _, want := range strings.Split(strings.TrimSpace(string(blob)), "\n") {
+		stable[want] = true
+
+		name := strings.TrimPrefix(want, "func ")
+		name = name[:strings.IndexAny(name, "[(")]
+
+		have, ok := api[name]
+		switch {
+		case !ok:
+			t.Errorf("stable function %s removed", name)
+		case have != want:
+			t.Errorf("stable function %s changed:\nhave: %s\nwant: %s", name, have, want)
+		}
+	}
+	for name, sig := range api {
+		if !stable[sig] {
+			t.Logf("function %s not yet in the stable API listing", name)
+		}
+	}
+}
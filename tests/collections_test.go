@@ -0,0 +1,453 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+type testMax2 struct{}
+
+func (testMax2) Limit() uint64 { return 2 }
+
+type testLen8 struct{}
+
+func (testLen8) Limit() uint64 { return 8 }
+
+type testLen8192 struct{}
+
+func (testLen8192) Limit() uint64 { return 8192 }
+
+// Tests that lists reject mutations that would exceed their limits.
+func TestListLimit(t *testing.T) {
+	var list ssz.List[uint64, testMax2]
+	if err := list.Append(1, 2); err != nil {
+		t.Fatalf("failed to append within limit: %v", err)
+	}
+	if err := list.Append(3); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Errorf("append error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+	if list.Len() != 2 || list.At(1) != 2 {
+		t.Errorf("list content mismatch: have %v", list.Items())
+	}
+}
+
+// Tests that the generic collections encode and hash the same way as the raw
+// slices and arrays they wrap.
+func TestCollectionsEquivalence(t *testing.T) {
+	generic := new(testCollectionsType)
+	generic.Withdrawals.Append(&types.Withdrawal{Index: 1}, &types.Withdrawal{Index: 2})
+	generic.Roots.Set(3, [32]byte{0x03})
+	generic.Slashings.Set(5, 5)
+
+	raw := &testCollectionsRawType{
+		Withdrawals: generic.Withdrawals.Items(),
+	}
+	raw.Roots[3] = [32]byte{0x03}
+	raw.Slashings[5] = 5
+
+	blob := make([]byte, ssz.Size(generic))
+	if err := ssz.EncodeToBytes(blob, generic); err != nil {
+		t.Fatalf("failed to encode generic collections: %v", err)
+	}
+	want := make([]byte, ssz.Size(raw))
+	if err := ssz.EncodeToBytes(want, raw); err != nil {
+		t.Fatalf("failed to encode raw collections: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Fatalf("encoding mismatch")
+	}
+	if have, want := ssz.HashSequential(generic), ssz.HashSequential(raw); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	decoded := new(testCollectionsType)
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode generic collections: %v", err)
+	}
+	if have, want := ssz.HashSequential(decoded), ssz.HashSequential(raw); have != want {
+		t.Errorf("decoded hash mismatch: have %x, want %x", have, want)
+	}
+}
+
+type testCollectionsType struct {
+	Withdrawals ssz.List[*types.Withdrawal, testMax2]
+	Roots       ssz.Vector[[32]byte, testLen8]
+	Slashings   ssz.Vector[uint64, testLen8192]
+}
+
+func (t *testCollectionsType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(4 + 8*32 + 8192*8)
+	if fixed {
+		return size
+	}
+	return size + ssz.SizeSliceOfStaticObjects(t.Withdrawals.Items())
+}
+func (t *testCollectionsType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineListOfStaticObjectsOffset(codec, &t.Withdrawals)
+	ssz.DefineVectorOfStaticBytes(codec, &t.Roots)
+	ssz.DefineVectorOfUint64s(codec, &t.Slashings)
+	ssz.DefineListOfStaticObjectsContent(codec, &t.Withdrawals)
+}
+
+type testCollectionsRawType struct {
+	Withdrawals []*types.Withdrawal
+	Roots       [8][32]byte
+	Slashings   [8192]uint64
+}
+
+func (t *testCollectionsRawType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(4 + 8*32 + 8192*8)
+	if fixed {
+		return size
+	}
+	return size + ssz.SizeSliceOfStaticObjects(t.Withdrawals)
+}
+func (t *testCollectionsRawType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Withdrawals, 2)
+	ssz.DefineArrayOfStaticBytes[[8][32]byte, [32]byte](codec, &t.Roots)
+	ssz.DefineArrayOfUint64s(codec, &t.Slashings)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Withdrawals, 2)
+}
+
+// Tests that vectors of static objects are merkleized as fixed-size composite
+// vectors (no length mixin, padded to the vector size).
+func TestVectorOfStaticObjectsHash(t *testing.T) {
+	obj := new(testVectorObjectsType)
+	for i := 0; i < 3; i++ {
+		obj.Checkpoints.Set(i, &types.Checkpoint{Epoch: uint64(i)})
+	}
+	var leaves [4][32]byte
+	for i := 0; i < 3; i++ {
+		leaves[i] = ssz.HashSequential(obj.Checkpoints.At(i))
+	}
+	left := sha256.Sum256(append(leaves[0][:], leaves[1][:]...))
+	right := sha256.Sum256(append(leaves[2][:], leaves[3][:]...))
+	want := sha256.Sum256(append(left[:], right[:]...))
+
+	if have := ssz.HashSequential(obj); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	decoded := new(testVectorObjectsType)
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if decoded.Checkpoints.At(2).Epoch != 2 {
+		t.Errorf("decoded item mismatch: have %v", decoded.Checkpoints.At(2))
+	}
+}
+
+type testLen3 struct{}
+
+func (testLen3) Limit() uint64 { return 3 }
+
+type testVectorObjectsType struct {
+	Checkpoints ssz.Vector[*types.Checkpoint, testLen3]
+}
+
+func (t *testVectorObjectsType) SizeSSZ() uint32 { return 3 * 40 }
+func (t *testVectorObjectsType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineVectorOfStaticObjects(codec, &t.Checkpoints)
+}
+
+type testBalanceEntry struct {
+	Index  uint64
+	Amount uint64
+}
+
+func (e *testBalanceEntry) SSZKey() uint64  { return e.Index }
+func (e *testBalanceEntry) SizeSSZ() uint32 { return 16 }
+func (e *testBalanceEntry) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &e.Index)
+	ssz.DefineUint64(codec, &e.Amount)
+}
+
+type testOrderedMapType struct {
+	Balances ssz.OrderedMap[uint64, *testBalanceEntry, testLen3]
+}
+
+func (t *testOrderedMapType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeOrderedMap(&t.Balances)
+}
+func (t *testOrderedMapType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineOrderedMapOffset(codec, &t.Balances)
+	ssz.DefineOrderedMapContent(codec, &t.Balances)
+}
+
+type testOrderedMapRawType struct {
+	Balances []*testBalanceEntry
+}
+
+func (t *testOrderedMapRawType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeSliceOfStaticObjects(t.Balances)
+}
+func (t *testOrderedMapRawType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &t.Balances, 3)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &t.Balances, 3)
+}
+
+// Tests that ordered maps keep their entries sorted by key regardless of the
+// insertion order, and encode and hash as the equivalent sorted list.
+func TestOrderedMap(t *testing.T) {
+	obj := new(testOrderedMapType)
+	for _, index := range []uint64{7, 3, 5} {
+		if err := obj.Balances.Put(&testBalanceEntry{Index: index, Amount: index * 10}); err != nil {
+			t.Fatalf("failed to insert entry %d: %v", index, err)
+		}
+	}
+	if err := obj.Balances.Put(&testBalanceEntry{Index: 5, Amount: 1}); err != nil {
+		t.Fatalf("failed to replace entry: %v", err)
+	}
+	if err := obj.Balances.Put(&testBalanceEntry{Index: 1}); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Errorf("insert error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+	if entry, ok := obj.Balances.Get(5); !ok || entry.Amount != 1 {
+		t.Errorf("replaced entry mismatch: have %v, %v", entry, ok)
+	}
+	if _, ok := obj.Balances.Get(4); ok {
+		t.Errorf("missing entry found")
+	}
+	raw := &testOrderedMapRawType{Balances: []*testBalanceEntry{
+		{Index: 3, Amount: 30}, {Index: 5, Amount: 1}, {Index: 7, Amount: 70},
+	}}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode ordered map: %v", err)
+	}
+	want := make([]byte, ssz.Size(raw))
+	if err := ssz.EncodeToBytes(want, raw); err != nil {
+		t.Fatalf("failed to encode raw list: %v", err)
+	}
+	if !bytes.Equal(blob, want) {
+		t.Fatalf("encoding mismatch: have %x, want %x", blob, want)
+	}
+	if have, want := ssz.HashSequential(obj), ssz.HashSequential(raw); have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	decoded := new(testOrderedMapType)
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode ordered map: %v", err)
+	}
+	if entry, ok := decoded.Balances.Get(7); !ok || entry.Amount != 70 {
+		t.Errorf("decoded entry mismatch: have %v, %v", entry, ok)
+	}
+	if !decoded.Balances.Delete(3) || decoded.Balances.Delete(3) || decoded.Balances.Len() != 2 {
+		t.Errorf("delete mismatch: have %d entries", decoded.Balances.Len())
+	}
+	// Ensure unordered entries are rejected both when encoding and decoding
+	obj.Balances.Items()[0].Index = 9
+	if err := ssz.EncodeToBytes(make([]byte, ssz.Size(obj)), obj); !errors.Is(err, ssz.ErrUnorderedKeys) {
+		t.Errorf("encode error mismatch: have %v, want %v", err, ssz.ErrUnorderedKeys)
+	}
+	raw.Balances[0].Index = 5 // duplicate key
+	if err := ssz.EncodeToBytes(want, raw); err != nil {
+		t.Fatalf("failed to encode raw list: %v", err)
+	}
+	err := ssz.DecodeFromBytes(want, new(testOrderedMapType))
+	if !errors.Is(err, ssz.ErrUnorderedKeys) {
+		t.Errorf("decode error mismatch: have %v, want %v", err, ssz.ErrUnorderedKeys)
+	}
+	var derr *ssz.DecodeError
+	if errors.As(err, &derr) && derr.Kind != ssz.KindUnorderedKeys {
+		t.Errorf("error kind mismatch: have %v, want %v", derr.Kind, ssz.KindUnorderedKeys)
+	}
+}
+
+// Tests that progressive lists encode like regular lists, but are merkleized
+// into the progressive tree structure of EIP-7916.
+func TestProgressiveListHash(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5, 6, 21, 22, 85, 86, 100} {
+		obj := &testProgressiveType{
+			Bits:    make([]byte, n/8+1),
+			Nums:    make([]uint64, n),
+			Objects: make([]*types.Withdrawal, n),
+			Nested:  make([]*testProgressiveNestedType, n),
+		}
+		obj.Bits[n/8] |= 1 << (n % 8)
+		for i := 0; i < n; i++ {
+			if i%3 == 0 {
+				obj.Bits[i/8] |= 1 << (i % 8)
+			}
+			obj.Nums[i] = uint64(i + 1)
+			obj.Objects[i] = &types.Withdrawal{Index: uint64(i), Amount: uint64(2 * i)}
+			obj.Nested[i] = &testProgressiveNestedType{Nums: obj.Nums[:i]}
+		}
+		// Assemble the expected root from the individual leaves
+		var bits [][32]byte
+		for i := 0; i < (n+255)/256; i++ {
+			var chunk [32]byte
+			copy(chunk[:], obj.Bits[i*32:])
+			if i == n/256 {
+				chunk[(n%256)/8] &^= 1 << (n % 8) // strip the length bit
+			}
+			bits = append(bits, chunk)
+		}
+		var nums [][32]byte
+		for i := 0; i < n; i += 4 {
+			var chunk [32]byte
+			for j := i; j < i+4 && j < n; j++ {
+				binary.LittleEndian.PutUint64(chunk[(j-i)*8:], obj.Nums[j])
+			}
+			nums = append(nums, chunk)
+		}
+		var objects, nested [][32]byte
+		for i := 0; i < n; i++ {
+			objects = append(objects, ssz.HashSequential(obj.Objects[i]))
+			nested = append(nested, ssz.HashSequential(obj.Nested[i]))
+		}
+		want := testMerkleize([][32]byte{
+			testMixinLength(testMerkleizeProgressive(bits, 1), n),
+			testMixinLength(testMerkleizeProgressive(nums, 1), n),
+			testMixinLength(testMerkleizeProgressive(objects, 1), n),
+			testMixinLength(testMerkleizeProgressive(nested, 1), n),
+		}, 4)
+		if have := ssz.HashSequential(obj); have != want {
+			t.Errorf("%d items: hash mismatch: have %x, want %x", n, have, want)
+		}
+		// Ensure the encoding round-trips
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("%d items: failed to encode object: %v", n, err)
+		}
+		decoded := new(testProgressiveType)
+		if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+			t.Fatalf("%d items: failed to decode object: %v", n, err)
+		}
+		if have := ssz.HashSequential(decoded); have != want {
+			t.Errorf("%d items: decoded hash mismatch: have %x, want %x", n, have, want)
+		}
+	}
+}
+
+// testMerkleize is a naive reference implementation of the SSZ merkleization of
+// a list of chunks, padded to the given power of two leaf count.
+func testMerkleize(chunks [][32]byte, leaves int) [32]byte {
+	layer := make([][32]byte, leaves)
+	copy(layer, chunks)
+	for len(layer) > 1 {
+		next := make([][32]byte, len(layer)/2)
+		for i := range next {
+			next[i] = sha256.Sum256(append(layer[2*i][:], layer[2*i+1][:]...))
+		}
+		layer = next
+	}
+	return layer[0]
+}
+
+// testMerkleizeProgressive is a naive reference implementation of the EIP-7916
+// progressive merkleization.
+func testMerkleizeProgressive(chunks [][32]byte, leaves int) [32]byte {
+	if len(chunks) == 0 {
+		return [32]byte{}
+	}
+	rest := testMerkleizeProgressive(chunks[min(leaves, len(chunks)):], leaves*4)
+	subtree := testMerkleize(chunks[:min(leaves, len(chunks))], leaves)
+	return sha256.Sum256(append(rest[:], subtree[:]...))
+}
+
+// testMixinLength mixes a list length into a merkle root.
+func testMixinLength(root [32]byte, length int) [32]byte {
+	var buf [64]byte
+	copy(buf[:], root[:])
+	binary.LittleEndian.PutUint64(buf[32:], uint64(length))
+	return sha256.Sum256(buf[:])
+}
+
+type testProgressiveType struct {
+	Bits    []byte
+	Nums    []uint64
+	Objects []*types.Withdrawal
+	Nested  []*testProgressiveNestedType
+}
+
+func (t *testProgressiveType) SizeSSZ(fixed bool) uint32 {
+	size := uint32(16)
+	if !fixed {
+		size += ssz.SizeSliceOfBits(t.Bits)
+		size += ssz.SizeSliceOfUint64s(t.Nums)
+		size += ssz.SizeSliceOfStaticObjects(t.Objects)
+		size += ssz.SizeSliceOfDynamicObjects(t.Nested)
+	}
+	return size
+}
+
+func (t *testProgressiveType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineProgressiveSliceOfBitsOffset(codec, &t.Bits)
+	ssz.DefineProgressiveSliceOfUint64sOffset(codec, &t.Nums)
+	ssz.DefineProgressiveSliceOfStaticObjectsOffset(codec, &t.Objects)
+	ssz.DefineProgressiveSliceOfDynamicObjectsOffset(codec, &t.Nested)
+
+	ssz.DefineProgressiveSliceOfBitsContent(codec, &t.Bits)
+	ssz.DefineProgressiveSliceOfUint64sContent(codec, &t.Nums)
+	ssz.DefineProgressiveSliceOfStaticObjectsContent(codec, &t.Objects)
+	ssz.DefineProgressiveSliceOfDynamicObjectsContent(codec, &t.Nested)
+}
+
+type testProgressiveNestedType struct {
+	Nums []uint64
+}
+
+func (t *testProgressiveNestedType) SizeSSZ(fixed bool) uint32 {
+	if fixed {
+		return 4
+	}
+	return 4 + ssz.SizeSliceOfUint64s(t.Nums)
+}
+
+func (t *testProgressiveNestedType) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineProgressiveSliceOfUint64sOffset(codec, &t.Nums)
+	ssz.DefineProgressiveSliceOfUint64sContent(codec, &t.Nums)
+}
+
+// Tests that encoding and hashing the collections in one go produces the same
+// results as the individual operations.
+func TestCollectionsEncodeAndHash(t *testing.T) {
+	progressive := &testProgressiveType{Bits: []byte{0x0d}}
+	for i := 0; i < 22; i++ {
+		progressive.Nums = append(progressive.Nums, uint64(i))
+		progressive.Objects = append(progressive.Objects, &types.Withdrawal{Index: uint64(i)})
+		progressive.Nested = append(progressive.Nested, &testProgressiveNestedType{Nums: progressive.Nums[:i]})
+	}
+	ordered := new(testOrderedMapType)
+	ordered.Balances.Put(&testBalanceEntry{Index: 1, Amount: 2})
+	ordered.Balances.Put(&testBalanceEntry{Index: 3, Amount: 4})
+
+	for i, obj := range []ssz.Object{progressive, ordered, &testVectorObjectsType{}} {
+		want := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(want, obj); err != nil {
+			t.Fatalf("test %d: failed to encode object: %v", i, err)
+		}
+		out := new(bytes.Buffer)
+		size, root, err := ssz.EncodeAndHash(out, obj)
+		if err != nil {
+			t.Fatalf("test %d: failed to encode and hash object: %v", i, err)
+		}
+		if !bytes.Equal(out.Bytes(), want) {
+			t.Errorf("test %d: encoding mismatch: have %x, want %x", i, out.Bytes(), want)
+		}
+		if size != uint32(len(want)) {
+			t.Errorf("test %d: size mismatch: have %d, want %d", i, size, len(want))
+		}
+		if have := ssz.HashSequential(obj); root != have {
+			t.Errorf("test %d: root mismatch: have %x, want %x", i, root, have)
+		}
+	}
+}
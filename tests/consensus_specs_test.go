@@ -17,6 +17,7 @@ import (
 
 	"github.com/golang/snappy"
 	"github.com/karalabe/ssz"
+	common "github.com/karalabe/ssz/sszcommon"
 	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
 	"gopkg.in/yaml.v3"
 )
@@ -120,6 +121,14 @@ func testConsensusSpecBasicType[T newableObject[U], U any](t *testing.T, kind st
 				t.Fatalf("re-encoded bytes mismatch: have %x, want %x, common prefix %d, have left %x, want left %x",
 					blob, inSSZ, len(prefix), bin[len(prefix):], inSSZ[len(prefix):])
 			}
+			if err := ssz.EncodeToBytesConcurrent(bin, obj); err != nil {
+				t.Fatalf("failed to re-encode SSZ buffer concurrently: %v", err)
+			}
+			if !bytes.Equal(bin, inSSZ) {
+				prefix := commonPrefix(bin, inSSZ)
+				t.Fatalf("concurrently re-encoded bytes mismatch: have %x, want %x, common prefix %d, have left %x, want left %x",
+					bin, inSSZ, len(prefix), bin[len(prefix):], inSSZ[len(prefix):])
+			}
 			// Encoder/decoder seems to work, check if the size reported by the
 			// encoded object actually matches the encoded stream
 			if size := ssz.Size(obj); size != uint32(len(inSSZ)) {
@@ -220,7 +229,11 @@ func TestConsensusSpecs(t *testing.T) {
 	testConsensusSpecType[*types.Withdrawal](t, "Withdrawal")
 
 	// Add some API variations to test different codec implementations
+	testConsensusSpecType[*types.AttestationDataVariation](t, "AttestationData")
+	testConsensusSpecType[*types.CheckpointVariation](t, "Checkpoint")
 	testConsensusSpecType[*types.ExecutionPayloadVariation](t, "ExecutionPayload", "bellatrix")
+	testConsensusSpecType[*types.ExecutionPayloadHeaderCapellaVariation](t, "ExecutionPayloadHeader", "capella")
+	testConsensusSpecType[*types.ExecutionPayloadHeaderDenebVariation](t, "ExecutionPayloadHeader", "deneb", "eip7594")
 	testConsensusSpecType[*types.HistoricalBatchVariation](t, "HistoricalBatch")
 	testConsensusSpecType[*types.WithdrawalVariation](t, "Withdrawal")
 
@@ -246,6 +259,75 @@ func TestConsensusSpecs(t *testing.T) {
 	//	}
 }
 
+// TestConsensusSpecsCommon runs the encoding/decoding/hashing round for the
+// maintained containers of the sszcommon package.
+func TestConsensusSpecsCommon(t *testing.T) {
+	testConsensusSpecType[*common.AggregateAndProof](t, "AggregateAndProof", "phase0", "altair", "bellatrix", "capella", "deneb")
+	testConsensusSpecType[*common.Attestation](t, "Attestation", "phase0", "altair", "bellatrix", "capella", "deneb")
+	testConsensusSpecType[*common.AttestationData](t, "AttestationData")
+	testConsensusSpecType[*common.AttesterSlashing](t, "AttesterSlashing", "phase0", "altair", "bellatrix", "capella", "deneb")
+	testConsensusSpecType[*common.BeaconBlock](t, "BeaconBlock", "phase0")
+	testConsensusSpecType[*common.BeaconBlockAltair](t, "BeaconBlock", "altair")
+	testConsensusSpecType[*common.BeaconBlockBellatrix](t, "BeaconBlock", "bellatrix")
+	testConsensusSpecType[*common.BeaconBlockCapella](t, "BeaconBlock", "capella")
+	testConsensusSpecType[*common.BeaconBlockDeneb](t, "BeaconBlock", "deneb")
+	testConsensusSpecType[*common.BeaconBlockBody](t, "BeaconBlockBody", "phase0")
+	testConsensusSpecType[*common.BeaconBlockBodyAltair](t, "BeaconBlockBody", "altair")
+	testConsensusSpecType[*common.BeaconBlockBodyBellatrix](t, "BeaconBlockBody", "bellatrix")
+	testConsensusSpecType[*common.BeaconBlockBodyCapella](t, "BeaconBlockBody", "capella")
+	testConsensusSpecType[*common.BeaconBlockBodyDeneb](t, "BeaconBlockBody", "deneb")
+	testConsensusSpecType[*common.BeaconBlockHeader](t, "BeaconBlockHeader")
+	testConsensusSpecType[*common.BeaconState](t, "BeaconState", "phase0")
+	testConsensusSpecType[*common.BeaconStateAltair](t, "BeaconState", "altair")
+	testConsensusSpecType[*common.BeaconStateBellatrix](t, "BeaconState", "bellatrix")
+	testConsensusSpecType[*common.BeaconStateCapella](t, "BeaconState", "capella")
+	testConsensusSpecType[*common.BeaconStateDeneb](t, "BeaconState", "deneb")
+	testConsensusSpecType[*common.BLSToExecutionChange](t, "BLSToExecutionChange")
+	testConsensusSpecType[*common.BlobIdentifier](t, "BlobIdentifier", "deneb")
+	testConsensusSpecType[*common.BlobSidecar](t, "BlobSidecar", "deneb")
+	testConsensusSpecType[*common.Checkpoint](t, "Checkpoint")
+	testConsensusSpecType[*common.Deposit](t, "Deposit")
+	testConsensusSpecType[*common.DepositData](t, "DepositData")
+	testConsensusSpecType[*common.DepositMessage](t, "DepositMessage")
+	testConsensusSpecType[*common.Eth1Data](t, "Eth1Data")
+	testConsensusSpecType[*common.ExecutionPayload](t, "ExecutionPayload", "bellatrix")
+	testConsensusSpecType[*common.ExecutionPayloadHeader](t, "ExecutionPayloadHeader", "bellatrix")
+	testConsensusSpecType[*common.ExecutionPayloadCapella](t, "ExecutionPayload", "capella")
+	testConsensusSpecType[*common.ExecutionPayloadHeaderCapella](t, "ExecutionPayloadHeader", "capella")
+	testConsensusSpecType[*common.ExecutionPayloadDeneb](t, "ExecutionPayload", "deneb")
+	testConsensusSpecType[*common.ExecutionPayloadHeaderDeneb](t, "ExecutionPayloadHeader", "deneb")
+	testConsensusSpecType[*common.Fork](t, "Fork")
+	testConsensusSpecType[*common.ForkData](t, "ForkData")
+	testConsensusSpecType[*common.HistoricalBatch](t, "HistoricalBatch")
+	testConsensusSpecType[*common.HistoricalSummary](t, "HistoricalSummary")
+	testConsensusSpecType[*common.IndexedAttestation](t, "IndexedAttestation", "phase0", "altair", "bellatrix", "capella", "deneb")
+	testConsensusSpecType[*common.PendingAttestation](t, "PendingAttestation")
+	testConsensusSpecType[*common.ProposerSlashing](t, "ProposerSlashing")
+	testConsensusSpecType[*common.SignedAggregateAndProof](t, "SignedAggregateAndProof", "phase0", "altair", "bellatrix", "capella", "deneb")
+	testConsensusSpecType[*common.SignedBeaconBlock](t, "SignedBeaconBlock", "phase0")
+	testConsensusSpecType[*common.SignedBeaconBlockAltair](t, "SignedBeaconBlock", "altair")
+	testConsensusSpecType[*common.SignedBeaconBlockBellatrix](t, "SignedBeaconBlock", "bellatrix")
+	testConsensusSpecType[*common.SignedBeaconBlockCapella](t, "SignedBeaconBlock", "capella")
+	testConsensusSpecType[*common.SignedBeaconBlockDeneb](t, "SignedBeaconBlock", "deneb")
+	testConsensusSpecType[*common.SignedBeaconBlockHeader](t, "SignedBeaconBlockHeader")
+	testConsensusSpecType[*common.SignedBLSToExecutionChange](t, "SignedBLSToExecutionChange")
+	testConsensusSpecType[*common.SignedVoluntaryExit](t, "SignedVoluntaryExit")
+	testConsensusSpecType[*common.SyncAggregate](t, "SyncAggregate")
+	testConsensusSpecType[*common.SyncCommittee](t, "SyncCommittee")
+	testConsensusSpecType[*common.Validator](t, "Validator")
+	testConsensusSpecType[*common.VoluntaryExit](t, "VoluntaryExit")
+	testConsensusSpecType[*common.Withdrawal](t, "Withdrawal")
+
+	// Electra containers are only ran if the spec tests contain them
+	testConsensusSpecType[*common.ConsolidationRequest](t, "ConsolidationRequest")
+	testConsensusSpecType[*common.DepositRequest](t, "DepositRequest")
+	testConsensusSpecType[*common.ExecutionRequests](t, "ExecutionRequests")
+	testConsensusSpecType[*common.PendingConsolidation](t, "PendingConsolidation")
+	testConsensusSpecType[*common.PendingDeposit](t, "PendingDeposit")
+	testConsensusSpecType[*common.PendingPartialWithdrawal](t, "PendingPartialWithdrawal")
+	testConsensusSpecType[*common.WithdrawalRequest](t, "WithdrawalRequest")
+}
+
 // newableObject is a generic type whose purpose is to enforce that ssz.Object
 // is specifically implemented on a struct pointer. That's needed to allow us
 // to instantiate new structs via `new` when parsing.
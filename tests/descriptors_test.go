@@ -0,0 +1,79 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/descriptors"
+	"github.com/karalabe/ssz/tests/testtypes/random"
+)
+
+// Tests that types generated in descriptor mode are sized, encoded, decoded,
+// hashed and formatted identically to the same types generated as methods.
+func TestDescriptorMode(t *testing.T) {
+	tests := []struct {
+		methods    ssz.Object
+		descriptor ssz.Object
+	}{
+		{new(types.BitsStruct), new(descriptors.BitsStruct)},
+		{new(types.Checkpoint), new(descriptors.Checkpoint)},
+		{new(types.Deposit), new(descriptors.Deposit)},
+		{new(types.HistoricalBatch), new(descriptors.HistoricalBatch)},
+		{new(types.SyncCommittee), new(descriptors.SyncCommittee)},
+		{new(types.Validator), new(descriptors.Validator)},
+		{new(types.AttesterSlashing), new(descriptors.AttesterSlashing)},
+		{new(types.ExecutionPayloadCapella), new(descriptors.ExecutionPayloadCapella)},
+		{new(types.BeaconBlockBodyCapella), new(descriptors.BeaconBlockBodyCapella)},
+		{new(types.BeaconStateCapella), new(descriptors.BeaconStateCapella)},
+	}
+	for _, tt := range tests {
+		t.Run(reflect.TypeOf(tt.methods).Elem().Name(), func(t *testing.T) {
+			random.Fill(rand.New(rand.NewSource(1)), tt.methods)
+
+			blob := make([]byte, ssz.Size(tt.methods))
+			if err := ssz.EncodeToBytes(blob, tt.methods); err != nil {
+				t.Fatalf("failed to encode methods object: %v", err)
+			}
+			// Decode the blob into the descriptor type both from bytes and stream
+			// and check that they are identical to the original
+			stream := reflect.New(reflect.TypeOf(tt.descriptor).Elem()).Interface().(ssz.Object)
+			if err := ssz.DecodeFromBytes(blob, tt.descriptor); err != nil {
+				t.Fatalf("failed to decode descriptor object: %v", err)
+			}
+			if err := ssz.DecodeFromStream(bytes.NewReader(blob), stream, uint32(len(blob))); err != nil {
+				t.Fatalf("failed to stream decode descriptor object: %v", err)
+			}
+			for _, obj := range []ssz.Object{tt.descriptor, stream} {
+				if size := ssz.Size(obj); size != uint32(len(blob)) {
+					t.Errorf("size mismatch: have %d, want %d", size, len(blob))
+				}
+				var buf bytes.Buffer
+				if err := ssz.EncodeToStream(&buf, obj); err != nil {
+					t.Fatalf("failed to encode descriptor object: %v", err)
+				}
+				if !bytes.Equal(buf.Bytes(), blob) {
+					t.Errorf("re-encoded descriptor object mismatch")
+				}
+				if have, want := ssz.HashSequential(obj), ssz.HashSequential(tt.methods); have != want {
+					t.Errorf("sequential hash mismatch: have %#x, want %#x", have, want)
+				}
+				if have, want := ssz.HashConcurrent(obj), ssz.HashSequential(tt.methods); have != want {
+					t.Errorf("concurrent hash mismatch: have %#x, want %#x", have, want)
+				}
+			}
+			have := strings.ReplaceAll(ssz.Format(tt.descriptor), "descriptors.", "consensus_spec_tests.")
+			if want := ssz.Format(tt.methods); have != want {
+				t.Errorf("formatted output mismatch:\nhave:\n%s\nwant:\n%s", have, want)
+			}
+		})
+	}
+}
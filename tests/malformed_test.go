@@ -0,0 +1,115 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/vectors"
+)
+
+// malformedCorpus is the directory containing the known-bad encodings that the
+// decoder must reject. Every hardening fix should add the offending payload.
+var malformedCorpus = filepath.Join("testdata", "malformed")
+
+// malformedKinds maps the error kind names used in the corpus to the kinds the
+// decoder reports.
+var malformedKinds = map[string]ssz.ErrorKind{
+	"UnexpectedEOF":             ssz.KindUnexpectedEOF,
+	"FirstOffsetMismatch":       ssz.KindFirstOffsetMismatch,
+	"BadOffsetProgression":      ssz.KindBadOffsetProgression,
+	"OffsetBeyondCapacity":      ssz.KindOffsetBeyondCapacity,
+	"MaxLengthExceeded":         ssz.KindMaxLengthExceeded,
+	"MaxItemsExceeded":          ssz.KindMaxItemsExceeded,
+	"ShortCounterOffset":        ssz.KindShortCounterOffset,
+	"ZeroCounterOffset":         ssz.KindZeroCounterOffset,
+	"BadCounterOffset":          ssz.KindBadCounterOffset,
+	"DynamicStaticsIndivisible": ssz.KindDynamicStaticsIndivisible,
+	"ObjectSlotSizeMismatch":    ssz.KindObjectSlotSizeMismatch,
+	"InvalidBoolean":            ssz.KindInvalidBoolean,
+	"JunkInBitvector":           ssz.KindJunkInBitvector,
+	"JunkInBitlist":             ssz.KindJunkInBitlist,
+}
+
+// malformedCase is a known-bad encoding from the corpus, along with the kind of
+// error it must be rejected with.
+type malformedCase struct {
+	Name        string `json:"-"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	SSZ         string `json:"ssz"`
+	Kind        string `json:"kind"`
+}
+
+// loadMalformed parses all the known-bad encodings from the corpus.
+func loadMalformed(t *testing.T) []*malformedCase {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(malformedCorpus, "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list malformed corpus: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no malformed cases found in %s", malformedCorpus)
+	}
+	cases := make([]*malformedCase, 0, len(files))
+	for _, file := range files {
+		blob, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read malformed case %s: %v", file, err)
+		}
+		tc := new(malformedCase)
+		if err := json.Unmarshal(blob, tc); err != nil {
+			t.Fatalf("failed to parse malformed case %s: %v", file, err)
+		}
+		tc.Name = strings.TrimSuffix(filepath.Base(file), ".json")
+		cases = append(cases, tc)
+	}
+	return cases
+}
+
+// Tests that all the known-bad encodings from the corpus are rejected by both
+// the buffered and streamed decoders, with the expected kind of error.
+func TestMalformedCorpus(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range loadMalformed(t) {
+		t.Run(tc.Name, func(t *testing.T) {
+			kind, ok := malformedKinds[tc.Kind]
+			if !ok {
+				t.Fatalf("unknown error kind %q", tc.Kind)
+			}
+			blob, err := hex.DecodeString(strings.TrimPrefix(tc.SSZ, "0x"))
+			if err != nil {
+				t.Fatalf("invalid encoding: %v", err)
+			}
+			vector := &vectors.Vector{Type: tc.Type}
+			if vector.New() == nil {
+				t.Fatalf("unknown type %q", tc.Type)
+			}
+			checkKind := func(mode string, err error) {
+				t.Helper()
+
+				var derr *ssz.DecodeError
+				if !errors.As(err, &derr) {
+					t.Fatalf("%s decoding: error mismatch: have %v, want %v", mode, err, kind)
+				}
+				if derr.Kind != kind {
+					t.Errorf("%s decoding: error kind mismatch: have %v, want %v (%v)", mode, derr.Kind, kind, err)
+				}
+			}
+			checkKind("buffered", ssz.DecodeFromBytes(blob, vector.New()))
+			checkKind("streamed", ssz.DecodeFromStream(bytes.NewReader(blob), vector.New(), uint32(len(blob))))
+		})
+	}
+}
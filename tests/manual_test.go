@@ -32,6 +32,17 @@ func BenchmarkMainnetState(b *testing.B) {
 			ssz.EncodeToStream(io.Discard, obj)
 		}
 	})
+	b.Run(fmt.Sprintf("beacon-state/%d-bytes/encode-concurrent", len(blob)), func(b *testing.B) {
+		buf := make([]byte, len(blob))
+
+		b.SetBytes(int64(len(blob)))
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			ssz.EncodeToBytesConcurrent(buf, obj)
+		}
+	})
 	b.Run(fmt.Sprintf("beacon-state/%d-bytes/decode", len(blob)), func(b *testing.B) {
 		obj := new(types.BeaconStateDeneb)
 
@@ -43,6 +54,18 @@ func BenchmarkMainnetState(b *testing.B) {
 			ssz.DecodeFromBytes(blob, obj)
 		}
 	})
+	b.Run(fmt.Sprintf("beacon-state/%d-bytes/decode-concurrent", len(blob)), func(b *testing.B) {
+		obj := new(types.BeaconStateDeneb)
+		cfg := &ssz.DecoderConfig{Concurrent: true}
+
+		b.SetBytes(int64(len(blob)))
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			ssz.DecodeFromBytesWithConfig(blob, obj, cfg)
+		}
+	})
 	b.Run(fmt.Sprintf("beacon-state/%d-bytes/merkleize-sequential", len(blob)), func(b *testing.B) {
 		b.SetBytes(int64(len(blob)))
 		b.ReportAllocs()
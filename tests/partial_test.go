@@ -0,0 +1,187 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/random"
+)
+
+// Tests that partial views reconstructed from multiproofs of a value tree expose
+// the proven fields, verify against the object's root and reject everything else.
+func TestPartial(t *testing.T) {
+	obj := new(types.BeaconState)
+	random.Fill(rand.New(rand.NewSource(1)), obj)
+	obj.Balances = []uint64{1, 2, 3, 4, 5, 6}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	value, err := schemaBeaconState.DecodeValue(blob)
+	if err != nil {
+		t.Fatalf("failed to decode value tree: %v", err)
+	}
+	paths := [][]any{
+		{"Slot"},
+		{"LatestBlockHeader", "StateRoot"},
+		{"Fork", "CurrentVersion"},
+		{"Balances", 5},
+		{"Balances", "__len__"},
+		{"BlockRoots", 17},
+		{"Eth1Data"},
+	}
+	var indices []uint64
+	for _, path := range paths {
+		index, err := schemaBeaconState.GeneralizedIndex(path...)
+		if err != nil {
+			t.Fatalf("failed to resolve path %v: %v", path, err)
+		}
+		indices = append(indices, index)
+	}
+	proof, err := value.Prove(indices...)
+	if err != nil {
+		t.Fatalf("failed to create multiproof: %v", err)
+	}
+	root := ssz.HashSequential(obj)
+	if err := ssz.VerifyMultiproof(root, proof); err != nil {
+		t.Fatalf("failed to verify multiproof: %v", err)
+	}
+	partial, err := schemaBeaconState.NewPartial(proof)
+	if err != nil {
+		t.Fatalf("failed to create partial: %v", err)
+	}
+	if err := partial.Verify(root); err != nil {
+		t.Fatalf("failed to verify partial: %v", err)
+	}
+	// Check that the proven fields are accessible
+	if have, err := partial.Uint("Slot"); err != nil || have != obj.Slot {
+		t.Errorf("slot mismatch: have %d (%v), want %d", have, err, obj.Slot)
+	}
+	if have, err := partial.Bytes("LatestBlockHeader", "StateRoot"); err != nil || !bytes.Equal(have, obj.LatestBlockHeader.StateRoot[:]) {
+		t.Errorf("state root mismatch: have %x (%v), want %x", have, err, obj.LatestBlockHeader.StateRoot)
+	}
+	if have, err := partial.Bytes("Fork", "CurrentVersion"); err != nil || !bytes.Equal(have, obj.Fork.CurrentVersion[:]) {
+		t.Errorf("fork version mismatch: have %x (%v), want %x", have, err, obj.Fork.CurrentVersion)
+	}
+	for i := 4; i < 6; i++ { // same chunk as item 5
+		if have, err := partial.Uint("Balances", i); err != nil || have != obj.Balances[i] {
+			t.Errorf("balance %d mismatch: have %d (%v), want %d", i, have, err, obj.Balances[i])
+		}
+	}
+	if have, err := partial.Len("Balances"); err != nil || have != uint64(len(obj.Balances)) {
+		t.Errorf("balance count mismatch: have %d (%v), want %d", have, err, len(obj.Balances))
+	}
+	if have, err := partial.Bytes("BlockRoots", 17); err != nil || !bytes.Equal(have, obj.BlockRoots[17][:]) {
+		t.Errorf("block root mismatch: have %x (%v), want %x", have, err, obj.BlockRoots[17])
+	}
+	if have, err := partial.Node("Eth1Data"); err != nil || have != ssz.HashSequential(obj.Eth1Data) {
+		t.Errorf("eth1 data root mismatch: have %x (%v), want %x", have, err, ssz.HashSequential(obj.Eth1Data))
+	}
+	// Check that unproven fields are rejected, even if an ancestor is known. Note,
+	// helper nodes are proven too (e.g. the first chunk of balances).
+	if have, err := partial.Uint("Balances", 0); err != nil || have != obj.Balances[0] {
+		t.Errorf("balance 0 mismatch: have %d (%v), want %d", have, err, obj.Balances[0])
+	}
+	unproven := [][]any{
+		{"GenesisTime"},
+		{"LatestBlockHeader", "Slot"},
+		{"Balances", 8},
+		{"Eth1Data", "DepositCount"},
+		{"Validators", "__len__"},
+	}
+	for _, path := range unproven {
+		if _, err := partial.Uint(path...); !errors.Is(err, ssz.ErrUnprovenPath) {
+			t.Errorf("path %v: error mismatch: have %v, want %v", path, err, ssz.ErrUnprovenPath)
+		}
+	}
+	// Check that invalid paths are rejected as schema mismatches
+	invalid := [][]any{
+		{"Unknown"},
+		{"Slot", "Unknown"},
+		{"Slot", "__len__"},
+		{"BlockRoots", 8192},
+		{"Fork", 0},
+		{1.5},
+	}
+	for _, path := range invalid {
+		if _, err := partial.Node(path...); !errors.Is(err, ssz.ErrSchemaMismatch) {
+			t.Errorf("path %v: error mismatch: have %v, want %v", path, err, ssz.ErrSchemaMismatch)
+		}
+	}
+	// Check that tampered proofs are rejected
+	proof.Leaves[0][0]++
+	if err := ssz.VerifyMultiproof(root, proof); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("tampered proof error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	if partial, err := schemaBeaconState.NewPartial(proof); err != nil {
+		t.Errorf("failed to create tampered partial: %v", err)
+	} else if err := partial.Verify(root); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("tampered partial error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	proof.Hashes = proof.Hashes[1:]
+	if _, err := schemaBeaconState.NewPartial(proof); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("truncated proof error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+}
+
+// Tests that binary blobs of a partial view are only accessible if all of their
+// chunks (and the length of dynamic ones) are proven.
+func TestPartialDynamicBytes(t *testing.T) {
+	obj := new(types.ExecutionPayloadCapella)
+	random.Fill(rand.New(rand.NewSource(1)), obj)
+	obj.ExtraData = bytes.Repeat([]byte{0xaa}, 17)
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	value, err := schemaExecutionPayloadCapella.DecodeValue(blob)
+	if err != nil {
+		t.Fatalf("failed to decode value tree: %v", err)
+	}
+	length, _ := schemaExecutionPayloadCapella.GeneralizedIndex("ExtraData", "__len__")
+	logs, _ := schemaExecutionPayloadCapella.GeneralizedIndex("LogsBloom")
+
+	proof, err := value.Prove(logs*8, logs*8+1, logs*8+2, logs*8+3, logs*8+4, logs*8+5, logs*8+6, logs*8+7)
+	if err != nil {
+		t.Fatalf("failed to create multiproof: %v", err)
+	}
+	partial, err := schemaExecutionPayloadCapella.NewPartial(proof)
+	if err != nil {
+		t.Fatalf("failed to create partial: %v", err)
+	}
+	if err := partial.Verify(ssz.HashSequential(obj)); err != nil {
+		t.Fatalf("failed to verify partial: %v", err)
+	}
+	if have, err := partial.Bytes("LogsBloom"); err != nil || !bytes.Equal(have, obj.LogsBloom[:]) {
+		t.Errorf("logs bloom mismatch: have %x (%v), want %x", have, err, obj.LogsBloom)
+	}
+	if _, err := partial.Bytes("ExtraData"); !errors.Is(err, ssz.ErrUnprovenPath) {
+		t.Errorf("extra data error mismatch: have %v, want %v", err, ssz.ErrUnprovenPath)
+	}
+	// Prove the length of the extra data, which needs its single data chunk as a
+	// helper, and retry
+	proof, err = value.Prove(length)
+	if err != nil {
+		t.Fatalf("failed to create multiproof: %v", err)
+	}
+	if partial, err = schemaExecutionPayloadCapella.NewPartial(proof); err != nil {
+		t.Fatalf("failed to create partial: %v", err)
+	}
+	if have, err := partial.Bytes("ExtraData"); err != nil || !bytes.Equal(have, obj.ExtraData) {
+		t.Errorf("extra data mismatch: have %x (%v), want %x", have, err, obj.ExtraData)
+	}
+	// Ensure nodes outside the tree cannot be proven
+	if _, err := value.Prove(logs * 1024); !errors.Is(err, ssz.ErrSchemaMismatch) {
+		t.Errorf("out of tree proof error mismatch: have %v, want %v", err, ssz.ErrSchemaMismatch)
+	}
+}
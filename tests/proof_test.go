@@ -0,0 +1,148 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// Tests that batches of single leaf proofs verify against a shared root, and that
+// any inconsistency between them is detected.
+func TestVerifyMultiproofBatch(t *testing.T) {
+	tree, err := schemaBeaconState.NewTree()
+	if err != nil {
+		t.Fatalf("failed to create tree: %v", err)
+	}
+	if err := tree.SetLen(100, "Balances"); err != nil {
+		t.Fatalf("failed to resize balances: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		if err := tree.SetUint(uint64(i)*1_000_000_000, "Balances", i); err != nil {
+			t.Fatalf("failed to set balance %d: %v", i, err)
+		}
+	}
+	// Create a single leaf proof for every balance chunk and a few more fields
+	var proofs []*ssz.Multiproof
+	for i := 0; i < 100; i += 4 {
+		index, _ := schemaBeaconState.GeneralizedIndex("Balances", i)
+		proof, err := tree.Prove(index)
+		if err != nil {
+			t.Fatalf("failed to prove balance %d: %v", i, err)
+		}
+		proofs = append(proofs, proof)
+	}
+	for _, field := range []string{"Slot", "Balances", "Validators"} {
+		index, _ := schemaBeaconState.GeneralizedIndex(field)
+		proof, err := tree.Prove(index)
+		if err != nil {
+			t.Fatalf("failed to prove %s: %v", field, err)
+		}
+		proofs = append(proofs, proof)
+	}
+	root := tree.Hash()
+	if err := ssz.VerifyMultiproofBatch(root, proofs); err != nil {
+		t.Fatalf("failed to verify batch: %v", err)
+	}
+	for i, proof := range proofs {
+		if err := ssz.VerifyMultiproof(root, proof); err != nil {
+			t.Errorf("proof %d: failed to verify: %v", i, err)
+		}
+	}
+	if err := ssz.VerifyMultiproofBatch([32]byte{}, proofs); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("root mismatch error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	if err := ssz.VerifyMultiproofBatch(root, nil); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("empty batch error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	// Create a balance proof from a different tree, which is self consistent but
+	// conflicts with the balances root proven by another proof of the batch
+	forked := tree.Copy()
+	if err := forked.SetUint(1, "Balances", 99); err != nil {
+		t.Fatalf("failed to set balance: %v", err)
+	}
+	index, _ := schemaBeaconState.GeneralizedIndex("Balances", 99)
+	proof, err := forked.Prove(index)
+	if err != nil {
+		t.Fatalf("failed to prove forked balance: %v", err)
+	}
+	if err := ssz.VerifyMultiproofBatch(root, append(proofs, proof)); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("conflicting batch error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	// Ensure the forked proof is rejected even if batched with only the proof of
+	// the original balances root (i.e. provided nodes are cross-checked)
+	if err := ssz.VerifyMultiproofBatch(root, []*ssz.Multiproof{proofs[len(proofs)-2], proof}); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("cross-check error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+}
+
+// Tests that proofs round trip through their canonical ssz encoding and still
+// verify after decoding.
+func TestProofEncoding(t *testing.T) {
+	tree, err := schemaBeaconState.NewTree()
+	if err != nil {
+		t.Fatalf("failed to create tree: %v", err)
+	}
+	if err := tree.SetUint(42, "Slot"); err != nil {
+		t.Fatalf("failed to set slot: %v", err)
+	}
+	slot, _ := schemaBeaconState.GeneralizedIndex("Slot")
+	fork, _ := schemaBeaconState.GeneralizedIndex("Fork", "Epoch")
+
+	multi, err := tree.Prove(slot, fork)
+	if err != nil {
+		t.Fatalf("failed to create multiproof: %v", err)
+	}
+	single, err := tree.Prove(slot)
+	if err != nil {
+		t.Fatalf("failed to create proof: %v", err)
+	}
+	proof := &ssz.Proof{Index: slot, Leaf: single.Leaves[0], Branch: single.Hashes}
+
+	// Round trip both proof types and verify the decoded ones
+	blob := make([]byte, ssz.Size(multi))
+	if err := ssz.EncodeToBytes(blob, multi); err != nil {
+		t.Fatalf("failed to encode multiproof: %v", err)
+	}
+	decodedMulti := new(ssz.Multiproof)
+	if err := ssz.DecodeFromBytes(blob, decodedMulti); err != nil {
+		t.Fatalf("failed to decode multiproof: %v", err)
+	}
+	if !reflect.DeepEqual(decodedMulti, multi) {
+		t.Errorf("decoded multiproof mismatch: have %+v, want %+v", decodedMulti, multi)
+	}
+	if err := ssz.VerifyMultiproof(tree.Hash(), decodedMulti); err != nil {
+		t.Errorf("failed to verify decoded multiproof: %v", err)
+	}
+	blob = make([]byte, ssz.Size(proof))
+	if err := ssz.EncodeToBytes(blob, proof); err != nil {
+		t.Fatalf("failed to encode proof: %v", err)
+	}
+	if want := 8 + 32 + 4 + 32*len(proof.Branch); len(blob) != want {
+		t.Errorf("proof size mismatch: have %d, want %d", len(blob), want)
+	}
+	decoded := new(ssz.Proof)
+	if err := ssz.DecodeFromBytes(blob, decoded); err != nil {
+		t.Fatalf("failed to decode proof: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, proof) {
+		t.Errorf("decoded proof mismatch: have %+v, want %+v", decoded, proof)
+	}
+	if err := ssz.VerifyProof(tree.Hash(), decoded); err != nil {
+		t.Errorf("failed to verify decoded proof: %v", err)
+	}
+	// Ensure the proof doesn't verify with a truncated branch or a wrong leaf
+	decoded.Branch = decoded.Branch[1:]
+	if err := ssz.VerifyProof(tree.Hash(), decoded); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("truncated proof error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+	proof.Leaf[0]++
+	if err := ssz.VerifyProof(tree.Hash(), proof); !errors.Is(err, ssz.ErrInvalidProof) {
+		t.Errorf("tampered proof error mismatch: have %v, want %v", err, ssz.ErrInvalidProof)
+	}
+}
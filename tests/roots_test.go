@@ -0,0 +1,64 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/random"
+)
+
+// Tests that the standalone root helpers compute the same roots as the fields
+// of generated containers.
+func TestStandaloneRoots(t *testing.T) {
+	obj := new(types.ExecutionPayloadCapella)
+	random.Fill(rand.New(rand.NewSource(1)), obj)
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	value, err := schemaExecutionPayloadCapella.DecodeValue(blob)
+	if err != nil {
+		t.Fatalf("failed to decode value tree: %v", err)
+	}
+	if have, want := ssz.Uint64Root(obj.GasLimit), value.Field("GasLimit").Hash(); have != want {
+		t.Errorf("uint64 root mismatch: have %#x, want %#x", have, want)
+	}
+	for _, extra := range [][]byte{nil, {0x01}, obj.ExtraData} {
+		value.Field("ExtraData").SetBytes(extra)
+		have, err := ssz.BytesRoot(extra, 32)
+		if err != nil {
+			t.Fatalf("failed to compute bytes root: %v", err)
+		}
+		if want := value.Field("ExtraData").Hash(); have != want {
+			t.Errorf("bytes root mismatch for %x: have %#x, want %#x", extra, have, want)
+		}
+	}
+	for n := len(obj.Withdrawals); n >= 0; n-- { // shrink the value tree as we go
+		roots := make([][32]byte, n)
+		for i := range roots {
+			roots[i] = ssz.HashSequential(obj.Withdrawals[i])
+		}
+		have, err := ssz.ListRoot(roots, 16)
+		if err != nil {
+			t.Fatalf("failed to compute list root: %v", err)
+		}
+		value.Field("Withdrawals").SetLen(n)
+		if want := value.Field("Withdrawals").Hash(); have != want {
+			t.Errorf("list root mismatch for %d items: have %#x, want %#x", n, have, want)
+		}
+	}
+	if _, err := ssz.BytesRoot(make([]byte, 33), 32); !errors.Is(err, ssz.ErrMaxLengthExceeded) {
+		t.Errorf("bytes limit error mismatch: have %v, want %v", err, ssz.ErrMaxLengthExceeded)
+	}
+	if _, err := ssz.ListRoot(make([][32]byte, 17), 16); !errors.Is(err, ssz.ErrMaxItemsExceeded) {
+		t.Errorf("list limit error mismatch: have %v, want %v", err, ssz.ErrMaxItemsExceeded)
+	}
+}
@@ -0,0 +1,301 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/random"
+)
+
+// Runtime schemas of some of the consensus types, mirroring the Go structs.
+var (
+	schemaBitsStruct = &ssz.Schema{Name: "BitsStruct", Fields: []ssz.SchemaField{
+		{Name: "A", Kind: ssz.FieldSliceOfBits, MaxItems: 5},
+		{Name: "B", Kind: ssz.FieldArrayOfBits, Size: 2},
+		{Name: "C", Kind: ssz.FieldArrayOfBits, Size: 1},
+		{Name: "D", Kind: ssz.FieldSliceOfBits, MaxItems: 6},
+		{Name: "E", Kind: ssz.FieldArrayOfBits, Size: 8},
+	}}
+	schemaCheckpoint = &ssz.Schema{Name: "Checkpoint", Fields: []ssz.SchemaField{
+		{Name: "Epoch", Kind: ssz.FieldUint64},
+		{Name: "Root", Kind: ssz.FieldStaticBytes, Size: 32},
+	}}
+	schemaAttestationData = &ssz.Schema{Name: "AttestationData", Fields: []ssz.SchemaField{
+		{Name: "Slot", Kind: ssz.FieldUint64},
+		{Name: "Index", Kind: ssz.FieldUint64},
+		{Name: "BeaconBlockHash", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "Source", Kind: ssz.FieldStaticObject, Schema: schemaCheckpoint},
+		{Name: "Target", Kind: ssz.FieldStaticObject, Schema: schemaCheckpoint},
+	}}
+	schemaIndexedAttestation = &ssz.Schema{Name: "IndexedAttestation", Fields: []ssz.SchemaField{
+		{Name: "AttestationIndices", Kind: ssz.FieldSliceOfUint64s, MaxItems: 2048},
+		{Name: "Data", Kind: ssz.FieldStaticObject, Schema: schemaAttestationData},
+		{Name: "Signature", Kind: ssz.FieldStaticBytes, Size: 96},
+	}}
+	schemaAttesterSlashing = &ssz.Schema{Name: "AttesterSlashing", Fields: []ssz.SchemaField{
+		{Name: "Attestation1", Kind: ssz.FieldDynamicObject, Schema: schemaIndexedAttestation},
+		{Name: "Attestation2", Kind: ssz.FieldDynamicObject, Schema: schemaIndexedAttestation},
+	}}
+	schemaDepositData = &ssz.Schema{Name: "DepositData", Fields: []ssz.SchemaField{
+		{Name: "Pubkey", Kind: ssz.FieldStaticBytes, Size: 48},
+		{Name: "WithdrawalCredentials", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "Amount", Kind: ssz.FieldUint64},
+		{Name: "Signature", Kind: ssz.FieldStaticBytes, Size: 96},
+	}}
+	schemaDeposit = &ssz.Schema{Name: "Deposit", Fields: []ssz.SchemaField{
+		{Name: "Proof", Kind: ssz.FieldArrayOfStaticBytes, Size: 33, ItemSize: 32},
+		{Name: "Data", Kind: ssz.FieldStaticObject, Schema: schemaDepositData},
+	}}
+	schemaSyncCommittee = &ssz.Schema{Name: "SyncCommittee", Fields: []ssz.SchemaField{
+		{Name: "PubKeys", Kind: ssz.FieldArrayOfStaticBytes, Size: 512, ItemSize: 48},
+		{Name: "AggregatePubKey", Kind: ssz.FieldStaticBytes, Size: 48},
+	}}
+	schemaValidator = &ssz.Schema{Name: "Validator", Fields: []ssz.SchemaField{
+		{Name: "Pubkey", Kind: ssz.FieldStaticBytes, Size: 48},
+		{Name: "WithdrawalCredentials", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "EffectiveBalance", Kind: ssz.FieldUint64},
+		{Name: "Slashed", Kind: ssz.FieldBool},
+		{Name: "ActivationEligibilityEpoch", Kind: ssz.FieldUint64},
+		{Name: "ActivationEpoch", Kind: ssz.FieldUint64},
+		{Name: "ExitEpoch", Kind: ssz.FieldUint64},
+		{Name: "WithdrawableEpoch", Kind: ssz.FieldUint64},
+	}}
+	schemaWithdrawal = &ssz.Schema{Name: "Withdrawal", Fields: []ssz.SchemaField{
+		{Name: "Index", Kind: ssz.FieldUint64},
+		{Name: "Validator", Kind: ssz.FieldUint64},
+		{Name: "Address", Kind: ssz.FieldStaticBytes, Size: 20},
+		{Name: "Amount", Kind: ssz.FieldUint64},
+	}}
+	schemaExecutionPayloadCapella = &ssz.Schema{Name: "ExecutionPayloadCapella", Fields: []ssz.SchemaField{
+		{Name: "ParentHash", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "FeeRecipient", Kind: ssz.FieldStaticBytes, Size: 20},
+		{Name: "StateRoot", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "ReceiptsRoot", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "LogsBloom", Kind: ssz.FieldStaticBytes, Size: 256},
+		{Name: "PrevRandao", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "BlockNumber", Kind: ssz.FieldUint64},
+		{Name: "GasLimit", Kind: ssz.FieldUint64},
+		{Name: "GasUsed", Kind: ssz.FieldUint64},
+		{Name: "Timestamp", Kind: ssz.FieldUint64},
+		{Name: "ExtraData", Kind: ssz.FieldDynamicBytes, MaxSize: 32},
+		{Name: "BaseFeePerGas", Kind: ssz.FieldUint256},
+		{Name: "BlockHash", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "Transactions", Kind: ssz.FieldSliceOfDynamicBytes, MaxItems: 1048576, MaxSize: 1073741824},
+		{Name: "Withdrawals", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 16, Schema: schemaWithdrawal},
+	}}
+	schemaFork = &ssz.Schema{Name: "Fork", Fields: []ssz.SchemaField{
+		{Name: "PreviousVersion", Kind: ssz.FieldStaticBytes, Size: 4},
+		{Name: "CurrentVersion", Kind: ssz.FieldStaticBytes, Size: 4},
+		{Name: "Epoch", Kind: ssz.FieldUint64},
+	}}
+	schemaBeaconBlockHeader = &ssz.Schema{Name: "BeaconBlockHeader", Fields: []ssz.SchemaField{
+		{Name: "Slot", Kind: ssz.FieldUint64},
+		{Name: "ProposerIndex", Kind: ssz.FieldUint64},
+		{Name: "ParentRoot", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "StateRoot", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "BodyRoot", Kind: ssz.FieldStaticBytes, Size: 32},
+	}}
+	schemaEth1Data = &ssz.Schema{Name: "Eth1Data", Fields: []ssz.SchemaField{
+		{Name: "DepositRoot", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "DepositCount", Kind: ssz.FieldUint64},
+		{Name: "BlockHash", Kind: ssz.FieldStaticBytes, Size: 32},
+	}}
+	schemaPendingAttestation = &ssz.Schema{Name: "PendingAttestation", Fields: []ssz.SchemaField{
+		{Name: "AggregationBits", Kind: ssz.FieldSliceOfBits, MaxItems: 2048},
+		{Name: "Data", Kind: ssz.FieldStaticObject, Schema: schemaAttestationData},
+		{Name: "InclusionDelay", Kind: ssz.FieldUint64},
+		{Name: "ProposerIndex", Kind: ssz.FieldUint64},
+	}}
+	schemaBeaconState = &ssz.Schema{Name: "BeaconState", Fields: []ssz.SchemaField{
+		{Name: "GenesisTime", Kind: ssz.FieldUint64},
+		{Name: "GenesisValidatorsRoot", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "Slot", Kind: ssz.FieldUint64},
+		{Name: "Fork", Kind: ssz.FieldStaticObject, Schema: schemaFork},
+		{Name: "LatestBlockHeader", Kind: ssz.FieldStaticObject, Schema: schemaBeaconBlockHeader},
+		{Name: "BlockRoots", Kind: ssz.FieldArrayOfStaticBytes, Size: 8192, ItemSize: 32},
+		{Name: "StateRoots", Kind: ssz.FieldArrayOfStaticBytes, Size: 8192, ItemSize: 32},
+		{Name: "HistoricalRoots", Kind: ssz.FieldSliceOfStaticBytes, MaxItems: 16777216, ItemSize: 32},
+		{Name: "Eth1Data", Kind: ssz.FieldStaticObject, Schema: schemaEth1Data},
+		{Name: "Eth1DataVotes", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 2048, Schema: schemaEth1Data},
+		{Name: "Eth1DepositIndex", Kind: ssz.FieldUint64},
+		{Name: "Validators", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 1099511627776, Schema: schemaValidator},
+		{Name: "Balances", Kind: ssz.FieldSliceOfUint64s, MaxItems: 1099511627776},
+		{Name: "RandaoMixes", Kind: ssz.FieldArrayOfStaticBytes, Size: 65536, ItemSize: 32},
+		{Name: "Slashings", Kind: ssz.FieldArrayOfUint64s, Size: 8192},
+		{Name: "PreviousEpochAttestations", Kind: ssz.FieldSliceOfDynamicObjects, MaxItems: 4096, Schema: schemaPendingAttestation},
+		{Name: "CurrentEpochAttestations", Kind: ssz.FieldSliceOfDynamicObjects, MaxItems: 4096, Schema: schemaPendingAttestation},
+		{Name: "JustificationBits", Kind: ssz.FieldArrayOfBits, Size: 4},
+		{Name: "PreviousJustifiedCheckpoint", Kind: ssz.FieldStaticObject, Schema: schemaCheckpoint},
+		{Name: "CurrentJustifiedCheckpoint", Kind: ssz.FieldStaticObject, Schema: schemaCheckpoint},
+		{Name: "FinalizedCheckpoint", Kind: ssz.FieldStaticObject, Schema: schemaCheckpoint},
+	}}
+)
+
+// Tests that containers decoded via a runtime schema into a value tree encode
+// and hash identically to the same containers with generated methods.
+func TestSchemaRoundTrip(t *testing.T) {
+	tests := []struct {
+		object ssz.Object
+		schema *ssz.Schema
+	}{
+		{new(types.BitsStruct), schemaBitsStruct},
+		{new(types.Checkpoint), schemaCheckpoint},
+		{new(types.AttestationData), schemaAttestationData},
+		{new(types.IndexedAttestation), schemaIndexedAttestation},
+		{new(types.AttesterSlashing), schemaAttesterSlashing},
+		{new(types.Deposit), schemaDeposit},
+		{new(types.SyncCommittee), schemaSyncCommittee},
+		{new(types.ExecutionPayloadCapella), schemaExecutionPayloadCapella},
+		{new(types.BeaconState), schemaBeaconState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schema.Name, func(t *testing.T) {
+			random.Fill(rand.New(rand.NewSource(1)), tt.object)
+
+			blob := make([]byte, ssz.Size(tt.object))
+			if err := ssz.EncodeToBytes(blob, tt.object); err != nil {
+				t.Fatalf("failed to encode object: %v", err)
+			}
+			value, err := tt.schema.Decode(blob)
+			if err != nil {
+				t.Fatalf("failed to decode value tree: %v", err)
+			}
+			stream, err := tt.schema.DecodeFromStream(bytes.NewReader(blob), uint32(len(blob)))
+			if err != nil {
+				t.Fatalf("failed to stream decode value tree: %v", err)
+			}
+			for _, value := range []map[string]any{value, stream} {
+				if size, err := tt.schema.Size(value); err != nil || size != uint32(len(blob)) {
+					t.Errorf("size mismatch: have %d (%v), want %d", size, err, len(blob))
+				}
+				enc, err := tt.schema.Encode(value)
+				if err != nil {
+					t.Fatalf("failed to encode value tree: %v", err)
+				}
+				if !bytes.Equal(enc, blob) {
+					t.Errorf("re-encoded value tree mismatch")
+				}
+				var buf bytes.Buffer
+				if err := tt.schema.EncodeToStream(&buf, value); err != nil {
+					t.Fatalf("failed to stream encode value tree: %v", err)
+				}
+				if !bytes.Equal(buf.Bytes(), blob) {
+					t.Errorf("stream encoded value tree mismatch")
+				}
+				if have, err := tt.schema.Hash(value); err != nil || have != ssz.HashSequential(tt.object) {
+					t.Errorf("hash mismatch: have %#x (%v), want %#x", have, err, ssz.HashSequential(tt.object))
+				}
+			}
+		})
+	}
+}
+
+// Tests that value trees are built up with the documented Go types.
+func TestSchemaValueTypes(t *testing.T) {
+	obj := &types.AttesterSlashing{
+		Attestation1: &types.IndexedAttestation{
+			AttestationIndices: []uint64{1, 2, 3},
+			Data:               &types.AttestationData{Slot: 4, Source: new(types.Checkpoint), Target: &types.Checkpoint{Epoch: 5}},
+		},
+		Attestation2: &types.IndexedAttestation{
+			Data: &types.AttestationData{Source: new(types.Checkpoint), Target: new(types.Checkpoint)},
+		},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	value, err := schemaAttesterSlashing.Decode(blob)
+	if err != nil {
+		t.Fatalf("failed to decode value tree: %v", err)
+	}
+	att := value["Attestation1"].(map[string]any)
+	if have, want := att["AttestationIndices"], []uint64{1, 2, 3}; !reflect.DeepEqual(have, want) {
+		t.Errorf("indices mismatch: have %v, want %v", have, want)
+	}
+	data := att["Data"].(map[string]any)
+	if have, want := data["Slot"], uint64(4); have != want {
+		t.Errorf("slot mismatch: have %v, want %v", have, want)
+	}
+	if have, want := data["Target"].(map[string]any)["Epoch"], uint64(5); have != want {
+		t.Errorf("epoch mismatch: have %v, want %v", have, want)
+	}
+	if have, want := data["BeaconBlockHash"], make([]byte, 32); !bytes.Equal(have.([]byte), want) {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	// Modify the value tree and ensure the changes are encoded
+	data["Slot"] = uint64(6)
+	obj.Attestation1.Data.Slot = 6
+
+	if have, err := schemaAttesterSlashing.Hash(value); err != nil || have != ssz.HashSequential(obj) {
+		t.Errorf("hash mismatch after update: have %#x (%v), want %#x", have, err, ssz.HashSequential(obj))
+	}
+	// Missing fields are encoded as zero values
+	if have, err := schemaCheckpoint.Hash(map[string]any{}); err != nil || have != ssz.HashSequential(new(types.Checkpoint)) {
+		t.Errorf("empty hash mismatch: have %#x (%v), want %#x", have, err, ssz.HashSequential(new(types.Checkpoint)))
+	}
+}
+
+// Tests that malformed schemas and value trees not matching the schema are
+// rejected.
+func TestSchemaErrors(t *testing.T) {
+	invalid := []*ssz.Schema{
+		{Name: "Unnamed", Fields: []ssz.SchemaField{{Kind: ssz.FieldUint64}}},
+		{Name: "Duplicate", Fields: []ssz.SchemaField{{Name: "A", Kind: ssz.FieldUint64}, {Name: "A", Kind: ssz.FieldUint64}}},
+		{Name: "Unknown", Fields: []ssz.SchemaField{{Name: "A"}}},
+		{Name: "Unsized", Fields: []ssz.SchemaField{{Name: "A", Kind: ssz.FieldStaticBytes}}},
+		{Name: "Itemless", Fields: []ssz.SchemaField{{Name: "A", Kind: ssz.FieldSliceOfStaticBytes, MaxItems: 4}}},
+		{Name: "Schemaless", Fields: []ssz.SchemaField{{Name: "A", Kind: ssz.FieldStaticObject}}},
+		{Name: "Staticness", Fields: []ssz.SchemaField{{Name: "A", Kind: ssz.FieldDynamicObject, Schema: schemaCheckpoint}}},
+	}
+	recursive := &ssz.Schema{Name: "Recursive"}
+	recursive.Fields = []ssz.SchemaField{
+		{Name: "A", Kind: ssz.FieldDynamicBytes, MaxSize: 4},
+		{Name: "B", Kind: ssz.FieldDynamicObject, Schema: recursive},
+	}
+	invalid = append(invalid, recursive)
+
+	for _, schema := range invalid {
+		if _, err := schema.Decode([]byte{0}); !errors.Is(err, ssz.ErrInvalidSchema) {
+			t.Errorf("%s: decode error mismatch: have %v, want %v", schema.Name, err, ssz.ErrInvalidSchema)
+		}
+		if _, err := schema.Encode(nil); !errors.Is(err, ssz.ErrInvalidSchema) {
+			t.Errorf("%s: encode error mismatch: have %v, want %v", schema.Name, err, ssz.ErrInvalidSchema)
+		}
+	}
+	// Self references via lists are fine, since those may be empty
+	tree := &ssz.Schema{Name: "Tree"}
+	tree.Fields = []ssz.SchemaField{
+		{Name: "Value", Kind: ssz.FieldUint64},
+		{Name: "Children", Kind: ssz.FieldSliceOfDynamicObjects, MaxItems: 4, Schema: tree},
+	}
+	value := map[string]any{"Value": uint64(1), "Children": []map[string]any{{"Value": uint64(2)}}}
+	blob, err := tree.Encode(value)
+	if err != nil {
+		t.Fatalf("failed to encode recursive value tree: %v", err)
+	}
+	if _, err := tree.Decode(blob); err != nil {
+		t.Fatalf("failed to decode recursive value tree: %v", err)
+	}
+	// Ensure values not matching the schema are rejected
+	mismatches := []map[string]any{
+		{"Unknown": uint64(1)},
+		{"Epoch": 1},
+		{"Root": make([]byte, 31)},
+		{"Root": [32]byte{}},
+	}
+	for _, value := range mismatches {
+		if _, err := schemaCheckpoint.Encode(value); !errors.Is(err, ssz.ErrSchemaMismatch) {
+			t.Errorf("%v: encode error mismatch: have %v, want %v", value, err, ssz.ErrSchemaMismatch)
+		}
+	}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: c8a0304709c61280a5d976afc06a1072e50d7fff8886423f2288fc1fea5da0a7
 
 package consensus_spec_tests
 
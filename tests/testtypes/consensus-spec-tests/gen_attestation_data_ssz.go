@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 28a2f693b1d7704f1b27fa5c9f25dbcbab35e654443231582456c6fc74e68a44
 
 package consensus_spec_tests
 
@@ -0,0 +1,26 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 51b175cb9de4c5705a0d0c6d74c84554f7a299a932596d2862356786ae1004ca
+
+package consensus_spec_tests
+
+import (
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/sszcommon"
+)
+
+// Cached static size computed on package init.
+var staticSizeCacheAttestationDataVariation = 8 + 8 + 32 + (*sszcommon.Checkpoint)(nil).SizeSSZ() + (*Checkpoint)(nil).SizeSSZ()
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *AttestationDataVariation) SizeSSZ() uint32 {
+	return staticSizeCacheAttestationDataVariation
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *AttestationDataVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Slot)                 // Field  (0) -            Slot -  8 bytes
+	ssz.DefineUint64(codec, &obj.Index)                // Field  (1) -           Index -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.BeaconBlockRoot) // Field  (2) - BeaconBlockRoot - 32 bytes
+	ssz.DefineStaticObject(codec, &obj.Source)         // Field  (3) -          Source -  ? bytes (Checkpoint)
+	ssz.DefineStaticObject(codec, &obj.Target)         // Field  (4) -          Target -  ? bytes (Checkpoint)
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 51b175cb9de4c5705a0d0c6d74c84554f7a299a932596d2862356786ae1004ca
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZAttestationDataVariation checks that AttestationDataVariation encodings round trip.
+func TestSSZAttestationDataVariation(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(AttestationDataVariation)), func() *AttestationDataVariation { return new(AttestationDataVariation) })
+}
+
+// FuzzSSZAttestationDataVariation checks that all accepted AttestationDataVariation encodings round trip.
+func FuzzSSZAttestationDataVariation(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *AttestationDataVariation { return new(AttestationDataVariation) }, uint64(new(AttestationDataVariation).SizeSSZ()))
+}
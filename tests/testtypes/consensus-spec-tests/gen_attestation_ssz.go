@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 888ea6930d55c4cc7b7b91101cfddbe3dd38f87d6840f69ce41577413a98f161
 
 package consensus_spec_tests
 
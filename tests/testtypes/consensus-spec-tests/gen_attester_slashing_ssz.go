@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: dd96fade4116812d37bce851371f2e59110c0e37884815c88841f4c7c3a674ae
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 414204ac74e100df43922c6546d752c3a06a60af809447531f68c290a7769c8e
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: a2e90b4233be096f7915432c3f414f0a71a6b9e7f2b8dcf3fad6f7c7602f125d
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: ba2350f88b9571eee200473b1d1500ba233f62b04d7e59fc84773487555f18e4
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 74680e41f355fc2a1e456aaa36226dfad80b223191db67e7b563dbccad6a67a6
 
 package consensus_spec_tests
 
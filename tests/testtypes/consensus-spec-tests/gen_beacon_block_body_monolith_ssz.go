@@ -0,0 +1,76 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 1306a4b36c77440de7fe425c5c7f2f7a599d306808350688351ccdddb24d1ce3
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise, using the newest layout of the object.
+func (obj *BeaconBlockBodyMonolith) SizeSSZ(fixed bool) uint32 {
+	return obj.SizeSSZOnFork(ssz.ForkUnknown, fixed)
+}
+
+// SizeSSZOnFork returns either the static size of the object on the given fork
+// if fixed == true, or the total size otherwise.
+func (obj *BeaconBlockBodyMonolith) SizeSSZOnFork(fork ssz.Fork, fixed bool) uint32 {
+	var size = uint32(96 + (*Eth1Data)(nil).SizeSSZ() + 32 + 4 + 4 + 4 + 4 + 4 + (*SyncAggregate)(nil).SizeSSZ() + 4)
+	if (ssz.ForkFilter{Added: ssz.ForkCapella}).Active(fork) {
+		size += 4
+	}
+	if (ssz.ForkFilter{Added: ssz.ForkDeneb}).Active(fork) {
+		size += 4
+	}
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfStaticObjects(obj.ProposerSlashings)
+	size += ssz.SizeSliceOfDynamicObjectsOnFork(fork, obj.AttesterSlashings)
+	size += ssz.SizeSliceOfDynamicObjectsOnFork(fork, obj.Attestations)
+	size += ssz.SizeSliceOfStaticObjects(obj.Deposits)
+	size += ssz.SizeSliceOfStaticObjects(obj.VoluntaryExits)
+	size += ssz.SizeDynamicObjectOnFork(fork, obj.ExecutionPayload)
+	if (ssz.ForkFilter{Added: ssz.ForkCapella}).Active(fork) {
+		size += ssz.SizeSliceOfStaticObjects(obj.BlsToExecutionChanges)
+	}
+	if (ssz.ForkFilter{Added: ssz.ForkDeneb}).Active(fork) {
+		size += ssz.SizeSliceOfStaticBytes(obj.BlobKzgCommitments)
+	}
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BeaconBlockBodyMonolith) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineStaticBytes(codec, &obj.RandaoReveal)                         // Field  ( 0) -          RandaoReveal - 96 bytes
+	ssz.DefineStaticObject(codec, &obj.Eth1Data)                            // Field  ( 1) -              Eth1Data -  ? bytes (Eth1Data)
+	ssz.DefineStaticBytes(codec, &obj.Graffiti)                             // Field  ( 2) -              Graffiti - 32 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.ProposerSlashings, 16) // Offset ( 3) -     ProposerSlashings -  4 bytes
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &obj.AttesterSlashings, 2) // Offset ( 4) -     AttesterSlashings -  4 bytes
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &obj.Attestations, 128)    // Offset ( 5) -          Attestations -  4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Deposits, 16)          // Offset ( 6) -              Deposits -  4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.VoluntaryExits, 16)    // Offset ( 7) -        VoluntaryExits -  4 bytes
+	ssz.DefineStaticObject(codec, &obj.SyncAggregate)                       // Field  ( 8) -         SyncAggregate -  ? bytes (SyncAggregate)
+	ssz.DefineDynamicObjectOffset(codec, &obj.ExecutionPayload)             // Offset ( 9) -      ExecutionPayload -  4 bytes
+	if ssz.OnFork(codec, &obj.BlsToExecutionChanges, ssz.ForkFilter{Added: ssz.ForkCapella}) {
+		ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.BlsToExecutionChanges, 16) // Offset (10) - BlsToExecutionChanges -  4 bytes
+	}
+	if ssz.OnFork(codec, &obj.BlobKzgCommitments, ssz.ForkFilter{Added: ssz.ForkDeneb}) {
+		ssz.DefineSliceOfStaticBytesOffset(codec, &obj.BlobKzgCommitments, 4096) // Offset (11) -    BlobKzgCommitments -  4 bytes
+	}
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.ProposerSlashings, 16) // Field  ( 3) -     ProposerSlashings - ? bytes
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &obj.AttesterSlashings, 2) // Field  ( 4) -     AttesterSlashings - ? bytes
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &obj.Attestations, 128)    // Field  ( 5) -          Attestations - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Deposits, 16)          // Field  ( 6) -              Deposits - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.VoluntaryExits, 16)    // Field  ( 7) -        VoluntaryExits - ? bytes
+	ssz.DefineDynamicObjectContent(codec, &obj.ExecutionPayload)             // Field  ( 9) -      ExecutionPayload - ? bytes
+	if ssz.OnFork(codec, &obj.BlsToExecutionChanges, ssz.ForkFilter{Added: ssz.ForkCapella}) {
+		ssz.DefineSliceOfStaticObjectsContent(codec, &obj.BlsToExecutionChanges, 16) // Field  (10) - BlsToExecutionChanges - ? bytes
+	}
+	if ssz.OnFork(codec, &obj.BlobKzgCommitments, ssz.ForkFilter{Added: ssz.ForkDeneb}) {
+		ssz.DefineSliceOfStaticBytesContent(codec, &obj.BlobKzgCommitments, 4096) // Field  (11) -    BlobKzgCommitments - ? bytes
+	}
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 881e2052a00cc2a6e990a408ba82fbb6a8431df250b232d941c389a2a3a72c1f
 
 package consensus_spec_tests
 
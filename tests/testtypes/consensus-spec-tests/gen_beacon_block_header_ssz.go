@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: b07f7187a42f5bd4628e9b88722ce0a8275df93c3d6a96240c45fd46be82c0e5
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 50ca7a0358e1e12adeb8b077d054cedb2e862cf21761d17767898440a3f5231e
 
 package consensus_spec_tests
 
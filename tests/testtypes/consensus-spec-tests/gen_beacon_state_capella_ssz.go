@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: fe844da08bf0a7b8e4771ac5a61078c0aa4fe0ee729e9848754fdf0a44d111b7
 
 package consensus_spec_tests
 
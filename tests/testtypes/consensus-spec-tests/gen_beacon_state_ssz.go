@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 4e8d4f06102772480c465a32b211d54b9741a3f9e72f5096fb028aca87bb7931
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: dd31f94b19b7a81b73584b5142147c76075d012b5f71ef1a0cbe4320bb625171
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: be5c157a5db49ece0c318c2a291c66a16e45ce6563b52b525dd560d3d2ae67aa
 
 package consensus_spec_tests
 
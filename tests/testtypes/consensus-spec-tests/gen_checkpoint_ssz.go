@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: a15dea531d8f12e501c2fe274c5c445eab3fd9c3888e648ab06fc5e2297e2e85
 
 package consensus_spec_tests
 
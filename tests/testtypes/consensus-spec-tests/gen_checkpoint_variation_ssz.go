@@ -0,0 +1,17 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 6c737ae212d26587782602404a7a5c60d042447e545adde8dae0e4e671c8c1be
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *CheckpointVariation) SizeSSZ() uint32 {
+	return 8 + 32
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *CheckpointVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Epoch)            // Field  (0) - Epoch -  8 bytes
+	ssz.DefineStaticBytesPointer(codec, &obj.Root) // Field  (1) -  Root - 32 bytes
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 6c737ae212d26587782602404a7a5c60d042447e545adde8dae0e4e671c8c1be
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZCheckpointVariation checks that CheckpointVariation encodings round trip.
+func TestSSZCheckpointVariation(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(CheckpointVariation)), func() *CheckpointVariation { return new(CheckpointVariation) })
+}
+
+// FuzzSSZCheckpointVariation checks that all accepted CheckpointVariation encodings round trip.
+func FuzzSSZCheckpointVariation(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *CheckpointVariation { return new(CheckpointVariation) }, uint64(new(CheckpointVariation).SizeSSZ()))
+}
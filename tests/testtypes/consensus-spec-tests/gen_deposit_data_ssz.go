@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: cf5c84d3923976ae44f23fbfed276f120cd846ac6b173505512c1cb69036ea2b
 
 package consensus_spec_tests
 
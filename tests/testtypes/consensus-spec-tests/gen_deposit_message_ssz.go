@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 76b41b1db4fdb6a0e1ecc2b096c7d32f3e5be8c5a810111fb28151277ea5d42b
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 3abe07a0a5bb158c42d1ec17c15bb8b6b9f1e7df1218b1a7d9cbe41d2164aafe
 
 package consensus_spec_tests
 
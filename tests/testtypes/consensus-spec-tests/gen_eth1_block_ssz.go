@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 6f3f43f50ff5b66d4ae13390711adfdd94aea941135b138ec3fa8c4c2613113e
 
 package consensus_spec_tests
 
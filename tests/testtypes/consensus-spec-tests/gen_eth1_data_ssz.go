@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 2cc8198174fce79ae76b52ea19aaf17e7bcf18a41390dbd0da44c61679ab1a08
 
 package consensus_spec_tests
 
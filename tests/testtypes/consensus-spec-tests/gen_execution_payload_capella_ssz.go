@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 47d5d3dcce7544c493f7a4046413f28e47ba9db77030a489ede10814cc44dc69
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 431800953663012ef7a38d3bdb519297bebe6e0bbf8b8c465beaff81870a6777
 
 package consensus_spec_tests
 
@@ -0,0 +1,103 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 7ea6841344ba9eceeb6b06b1ebd5d3d51eea7f2fd55fcacacfa80e6991193236
+
+package consensus_spec_tests
+
+import (
+	"encoding/json"
+
+	"github.com/karalabe/ssz"
+)
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ExecutionPayloadFamilies) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(32 + 20 + 32 + 32 + 256 + 32 + 8 + 8 + 8 + 8 + 4 + 32 + 32 + 4 + 4)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(obj.ExtraData)
+	size += ssz.SizeSliceOfDynamicBytes(obj.Transactions)
+	size += ssz.SizeSliceOfStaticObjects(obj.Withdrawals)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ExecutionPayloadFamilies) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineStaticBytes(codec, &obj.ParentHash)                                      // Field  ( 0) -    ParentHash -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.FeeRecipient)                                    // Field  ( 1) -  FeeRecipient -  20 bytes
+	ssz.DefineStaticBytes(codec, &obj.StateRoot)                                       // Field  ( 2) -     StateRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ReceiptsRoot)                                    // Field  ( 3) -  ReceiptsRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.LogsBloom)                                       // Field  ( 4) -     LogsBloom - 256 bytes
+	ssz.DefineStaticBytes(codec, &obj.PrevRandao)                                      // Field  ( 5) -    PrevRandao -  32 bytes
+	ssz.DefineUint64(codec, &obj.BlockNumber)                                          // Field  ( 6) -   BlockNumber -   8 bytes
+	ssz.DefineUint64(codec, &obj.GasLimit)                                             // Field  ( 7) -      GasLimit -   8 bytes
+	ssz.DefineUint64(codec, &obj.GasUsed)                                              // Field  ( 8) -       GasUsed -   8 bytes
+	ssz.DefineUint64(codec, &obj.Timestamp)                                            // Field  ( 9) -     Timestamp -   8 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.ExtraData, 32)                            // Offset (10) -     ExtraData -   4 bytes
+	ssz.DefineUint256(codec, &obj.BaseFeePerGas)                                       // Field  (11) - BaseFeePerGas -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.BlockHash)                                       // Field  (12) -     BlockHash -  32 bytes
+	ssz.DefineSliceOfDynamicBytesOffset(codec, &obj.Transactions, 1048576, 1073741824) // Offset (13) -  Transactions -   4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Withdrawals, 16)                  // Offset (14) -   Withdrawals -   4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.ExtraData, 32)                            // Field  (10) -     ExtraData - ? bytes
+	ssz.DefineSliceOfDynamicBytesContent(codec, &obj.Transactions, 1048576, 1073741824) // Field  (13) -  Transactions - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Withdrawals, 16)                  // Field  (14) -   Withdrawals - ? bytes
+}
+
+// HashTreeRoot computes the ssz merkle root of the object.
+func (obj *ExecutionPayloadFamilies) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashSequentialChecked(obj)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the object with the JSON
+// mapping of its ssz fields.
+func (obj *ExecutionPayloadFamilies) MarshalJSON() ([]byte, error) {
+	return json.Marshal(obj.jsonFields())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the object from the JSON
+// mapping of its ssz fields. Fields missing from the input are left untouched.
+func (obj *ExecutionPayloadFamilies) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, obj.jsonFields())
+}
+
+// jsonFields returns the JSON views of the ssz fields of the object.
+func (obj *ExecutionPayloadFamilies) jsonFields() any {
+	return &struct {
+		ParentHash    ssz.JSONField `json:"parent_hash"`
+		FeeRecipient  ssz.JSONField `json:"fee_recipient"`
+		StateRoot     ssz.JSONField `json:"state_root"`
+		ReceiptsRoot  ssz.JSONField `json:"receipts_root"`
+		LogsBloom     ssz.JSONField `json:"logs_bloom"`
+		PrevRandao    ssz.JSONField `json:"prev_randao"`
+		BlockNumber   ssz.JSONField `json:"block_number"`
+		GasLimit      ssz.JSONField `json:"gas_limit"`
+		GasUsed       ssz.JSONField `json:"gas_used"`
+		Timestamp     ssz.JSONField `json:"timestamp"`
+		ExtraData     ssz.JSONField `json:"extra_data"`
+		BaseFeePerGas ssz.JSONField `json:"base_fee_per_gas"`
+		BlockHash     ssz.JSONField `json:"block_hash"`
+		Transactions  ssz.JSONField `json:"transactions"`
+		Withdrawals   ssz.JSONField `json:"withdrawals"`
+	}{
+		ssz.JSONStaticBytes(&obj.ParentHash),
+		ssz.JSONStaticBytes(&obj.FeeRecipient),
+		ssz.JSONStaticBytes(&obj.StateRoot),
+		ssz.JSONStaticBytes(&obj.ReceiptsRoot),
+		ssz.JSONStaticBytes(&obj.LogsBloom),
+		ssz.JSONStaticBytes(&obj.PrevRandao),
+		ssz.JSONUint64(&obj.BlockNumber),
+		ssz.JSONUint64(&obj.GasLimit),
+		ssz.JSONUint64(&obj.GasUsed),
+		ssz.JSONUint64(&obj.Timestamp),
+		ssz.JSONDynamicBytes(&obj.ExtraData, 32),
+		ssz.JSONUint256(&obj.BaseFeePerGas),
+		ssz.JSONStaticBytes(&obj.BlockHash),
+		ssz.JSONSliceOfDynamicBytes(&obj.Transactions, 1048576, 1073741824),
+		ssz.JSONSliceOfStaticObjects(&obj.Withdrawals, 16),
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 7ea6841344ba9eceeb6b06b1ebd5d3d51eea7f2fd55fcacacfa80e6991193236
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZExecutionPayloadFamilies checks that ExecutionPayloadFamilies encodings round trip.
+func TestSSZExecutionPayloadFamilies(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(ExecutionPayloadFamilies)), func() *ExecutionPayloadFamilies { return new(ExecutionPayloadFamilies) })
+}
+
+// FuzzSSZExecutionPayloadFamilies checks that all accepted ExecutionPayloadFamilies encodings round trip.
+func FuzzSSZExecutionPayloadFamilies(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *ExecutionPayloadFamilies { return new(ExecutionPayloadFamilies) }, uint64(new(ExecutionPayloadFamilies).SizeSSZ(true))+32+1048576*(4+1073741824)+16*uint64((*WithdrawalFamilies)(nil).SizeSSZ()))
+}
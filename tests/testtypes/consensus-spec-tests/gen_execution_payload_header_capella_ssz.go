@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 766f7a4ac5bcb4345a363c52e04f4e26585d1fee6ce8525d431aea72a98e2b8c
 
 package consensus_spec_tests
 
@@ -0,0 +1,41 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 23da41ded70ead9a21cee1ab1ff1c462b74b9fe424137edc722be0738ce3810b
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ExecutionPayloadHeaderCapellaVariation) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(32 + 20 + 32 + 32 + 256 + 32 + 8 + 8 + 8 + 8 + 4 + 32 + 32 + 32 + 32)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(obj.ExecutionPayloadHeader.ExtraData)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ExecutionPayloadHeaderCapellaVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeader.ParentHash)           // Field  ( 0) -       ExecutionPayloadHeader.ParentHash -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeader.FeeRecipient)         // Field  ( 1) -     ExecutionPayloadHeader.FeeRecipient -  20 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeader.StateRoot)            // Field  ( 2) -        ExecutionPayloadHeader.StateRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeader.ReceiptsRoot)         // Field  ( 3) -     ExecutionPayloadHeader.ReceiptsRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeader.LogsBloom)            // Field  ( 4) -        ExecutionPayloadHeader.LogsBloom - 256 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeader.PrevRandao)           // Field  ( 5) -       ExecutionPayloadHeader.PrevRandao -  32 bytes
+	ssz.DefineUint64(codec, &obj.ExecutionPayloadHeader.BlockNumber)               // Field  ( 6) -      ExecutionPayloadHeader.BlockNumber -   8 bytes
+	ssz.DefineUint64(codec, &obj.ExecutionPayloadHeader.GasLimit)                  // Field  ( 7) -         ExecutionPayloadHeader.GasLimit -   8 bytes
+	ssz.DefineUint64(codec, &obj.ExecutionPayloadHeader.GasUsed)                   // Field  ( 8) -          ExecutionPayloadHeader.GasUsed -   8 bytes
+	ssz.DefineUint64(codec, &obj.ExecutionPayloadHeader.Timestamp)                 // Field  ( 9) -        ExecutionPayloadHeader.Timestamp -   8 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.ExecutionPayloadHeader.ExtraData, 32) // Offset (10) -        ExecutionPayloadHeader.ExtraData -   4 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeader.BaseFeePerGas)        // Field  (11) -    ExecutionPayloadHeader.BaseFeePerGas -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeader.BlockHash)            // Field  (12) -        ExecutionPayloadHeader.BlockHash -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeader.TransactionsRoot)     // Field  (13) - ExecutionPayloadHeader.TransactionsRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.WithdrawalRoot)                              // Field  (14) -                          WithdrawalRoot -  32 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.ExecutionPayloadHeader.ExtraData, 32) // Field  (10) -        ExecutionPayloadHeader.ExtraData - ? bytes
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 23da41ded70ead9a21cee1ab1ff1c462b74b9fe424137edc722be0738ce3810b
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZExecutionPayloadHeaderCapellaVariation checks that ExecutionPayloadHeaderCapellaVariation encodings round trip.
+func TestSSZExecutionPayloadHeaderCapellaVariation(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(ExecutionPayloadHeaderCapellaVariation)), func() *ExecutionPayloadHeaderCapellaVariation { return new(ExecutionPayloadHeaderCapellaVariation) })
+}
+
+// FuzzSSZExecutionPayloadHeaderCapellaVariation checks that all accepted ExecutionPayloadHeaderCapellaVariation encodings round trip.
+func FuzzSSZExecutionPayloadHeaderCapellaVariation(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *ExecutionPayloadHeaderCapellaVariation { return new(ExecutionPayloadHeaderCapellaVariation) }, uint64(new(ExecutionPayloadHeaderCapellaVariation).SizeSSZ(true))+32)
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 3d2a6ff64fd34ae21dee77f7b2713ced45a6d2a9694b858e0ec53c138d6d179b
 
 package consensus_spec_tests
 
@@ -0,0 +1,43 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: df597d401d1406a2b581d47fd36f8186dc2561c70cbea9c3177bdbbf810305e8
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ExecutionPayloadHeaderDenebVariation) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(32 + 20 + 32 + 32 + 256 + 32 + 8 + 8 + 8 + 8 + 4 + 32 + 32 + 32 + 32 + 8 + 8)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.ExtraData)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ExecutionPayloadHeaderDenebVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.ParentHash)           // Field  ( 0) -       ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.ParentHash -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.FeeRecipient)         // Field  ( 1) -     ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.FeeRecipient -  20 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.StateRoot)            // Field  ( 2) -        ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.StateRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.ReceiptsRoot)         // Field  ( 3) -     ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.ReceiptsRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.LogsBloom)            // Field  ( 4) -        ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.LogsBloom - 256 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.PrevRandao)           // Field  ( 5) -       ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.PrevRandao -  32 bytes
+	ssz.DefineUint64(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.BlockNumber)               // Field  ( 6) -      ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.BlockNumber -   8 bytes
+	ssz.DefineUint64(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.GasLimit)                  // Field  ( 7) -         ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.GasLimit -   8 bytes
+	ssz.DefineUint64(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.GasUsed)                   // Field  ( 8) -          ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.GasUsed -   8 bytes
+	ssz.DefineUint64(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.Timestamp)                 // Field  ( 9) -        ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.Timestamp -   8 bytes
+	ssz.DefineDynamicBytesOffset(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.ExtraData, 32) // Offset (10) -        ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.ExtraData -   4 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.BaseFeePerGas)        // Field  (11) -    ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.BaseFeePerGas -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.BlockHash)            // Field  (12) -        ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.BlockHash -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.TransactionsRoot)     // Field  (13) - ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.TransactionsRoot -  32 bytes
+	ssz.DefineStaticBytes(codec, &obj.ExecutionPayloadHeaderCapellaVariation.WithdrawalRoot)                              // Field  (14) -                          ExecutionPayloadHeaderCapellaVariation.WithdrawalRoot -  32 bytes
+	ssz.DefineUint64(codec, &obj.BlobGasUsed)                                                                             // Field  (15) -                                                                    BlobGasUsed -   8 bytes
+	ssz.DefineUint64(codec, &obj.ExcessBlobGas)                                                                           // Field  (16) -                                                                  ExcessBlobGas -   8 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.ExtraData, 32) // Field  (10) -        ExecutionPayloadHeaderCapellaVariation.ExecutionPayloadHeader.ExtraData - ? bytes
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: df597d401d1406a2b581d47fd36f8186dc2561c70cbea9c3177bdbbf810305e8
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZExecutionPayloadHeaderDenebVariation checks that ExecutionPayloadHeaderDenebVariation encodings round trip.
+func TestSSZExecutionPayloadHeaderDenebVariation(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(ExecutionPayloadHeaderDenebVariation)), func() *ExecutionPayloadHeaderDenebVariation { return new(ExecutionPayloadHeaderDenebVariation) })
+}
+
+// FuzzSSZExecutionPayloadHeaderDenebVariation checks that all accepted ExecutionPayloadHeaderDenebVariation encodings round trip.
+func FuzzSSZExecutionPayloadHeaderDenebVariation(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *ExecutionPayloadHeaderDenebVariation { return new(ExecutionPayloadHeaderDenebVariation) }, uint64(new(ExecutionPayloadHeaderDenebVariation).SizeSSZ(true))+32)
+}
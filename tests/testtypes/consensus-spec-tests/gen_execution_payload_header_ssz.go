@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 14795b15a03bbb919c71d5d7d1de74f8e880f3584f2bb4fcdec361bff84f9841
 
 package consensus_spec_tests
 
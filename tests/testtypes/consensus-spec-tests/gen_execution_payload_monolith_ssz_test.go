@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 22f00a56dd4026bf66e1750ac1e87b15c7637df7ef973178f88457a3d0d8a13c
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZExecutionPayloadMonolith checks that ExecutionPayloadMonolith encodings round trip.
+func TestSSZExecutionPayloadMonolith(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(ExecutionPayloadMonolith)), func() *ExecutionPayloadMonolith { return new(ExecutionPayloadMonolith) })
+}
+
+// FuzzSSZExecutionPayloadMonolith checks that all accepted ExecutionPayloadMonolith encodings round trip.
+func FuzzSSZExecutionPayloadMonolith(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *ExecutionPayloadMonolith { return new(ExecutionPayloadMonolith) }, uint64(new(ExecutionPayloadMonolith).SizeSSZ(true))+32+1048576*(4+1073741824)+16*uint64((*Withdrawal)(nil).SizeSSZ()))
+}
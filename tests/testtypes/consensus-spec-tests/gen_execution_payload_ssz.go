@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 95488b88796c6308dd3eec9806bcc2755f4ed8ffdc9e71ef7072dd476eed2ea8
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 505c41953be37cb46e74bc36c0f0bf13ee18e9b98baa2a3c2ae9a95bd7fdd111
 
 package consensus_spec_tests
 
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 505c41953be37cb46e74bc36c0f0bf13ee18e9b98baa2a3c2ae9a95bd7fdd111
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZExecutionPayloadVariation checks that ExecutionPayloadVariation encodings round trip.
+func TestSSZExecutionPayloadVariation(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(ExecutionPayloadVariation)), func() *ExecutionPayloadVariation { return new(ExecutionPayloadVariation) })
+}
+
+// FuzzSSZExecutionPayloadVariation checks that all accepted ExecutionPayloadVariation encodings round trip.
+func FuzzSSZExecutionPayloadVariation(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *ExecutionPayloadVariation { return new(ExecutionPayloadVariation) }, uint64(new(ExecutionPayloadVariation).SizeSSZ(true))+32+1048576*(4+1073741824))
+}
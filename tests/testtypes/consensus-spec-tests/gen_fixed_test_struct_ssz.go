@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 985d9011a5f47e4456cedaff3307cd2be200025a7679febc10aff7ba0afbcfb7
 
 package consensus_spec_tests
 
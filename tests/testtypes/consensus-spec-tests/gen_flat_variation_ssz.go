@@ -0,0 +1,46 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 47cc0d5ba2feaaaea8c2e5c40b9348aaade2344043f538ae380003b9b74f7d87
+
+package consensus_spec_tests
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/karalabe/ssz"
+)
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *FlatVariation) SizeSSZ() uint32 {
+	return 8 + 1 + 2 + 4 + 20 + 2*32
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *FlatVariation) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Slot)                      // Field  (0) -    Slot -  8 bytes
+	ssz.DefineUint8(codec, &obj.Kind)                       // Field  (1) -    Kind -  1 bytes
+	ssz.DefineUint16(codec, &obj.Flags)                     // Field  (2) -   Flags -  2 bytes
+	ssz.DefineUint32(codec, &obj.Count)                     // Field  (3) -   Count -  4 bytes
+	ssz.DefineStaticBytes(codec, &obj.Address)              // Field  (4) - Address - 20 bytes
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.Roots[:]) // Field  (5) -   Roots - 64 bytes
+}
+
+// DecodeFlatSSZ decodes a list of objects from their back-to-back encodings in
+// one go, implementing ssz.FlatDecoder.
+func (*FlatVariation) DecodeFlatSSZ(blob []byte, objs []*FlatVariation) error {
+	if len(blob) < len(objs)*99 {
+		return io.ErrUnexpectedEOF
+	}
+	for i, obj := range objs {
+		item := blob[i*99 : (i+1)*99]
+		obj.Slot = Slot(binary.LittleEndian.Uint64(item[0:8]))
+		obj.Kind = item[8]
+		obj.Flags = binary.LittleEndian.Uint16(item[9:11])
+		obj.Count = binary.LittleEndian.Uint32(item[11:15])
+		copy(obj.Address[:], item[15:35])
+		for j := range obj.Roots {
+			copy(obj.Roots[j][:], item[35+32*j:])
+		}
+	}
+	return nil
+}
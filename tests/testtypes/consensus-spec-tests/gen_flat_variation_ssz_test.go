@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 47cc0d5ba2feaaaea8c2e5c40b9348aaade2344043f538ae380003b9b74f7d87
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZFlatVariation checks that FlatVariation encodings round trip.
+func TestSSZFlatVariation(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(FlatVariation)), func() *FlatVariation { return new(FlatVariation) })
+}
+
+// FuzzSSZFlatVariation checks that all accepted FlatVariation encodings round trip.
+func FuzzSSZFlatVariation(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *FlatVariation { return new(FlatVariation) }, uint64(new(FlatVariation).SizeSSZ()))
+}
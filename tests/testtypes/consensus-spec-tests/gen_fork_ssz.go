@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 580fc11284a96634dec8f9e2198e383455f5e4a9c7cfd0bc8a959bad312976b7
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 0a1dc2eb9fb5e172a70566f5f2c2bf87a6bf43ee465298c9d965d9a59b92e6a2
 
 package consensus_spec_tests
 
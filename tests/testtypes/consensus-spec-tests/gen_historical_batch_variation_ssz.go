@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 6ffa63e834d7e595e3944de87acb986411cf3d8c9be2a269619cde5cbc551249
 
 package consensus_spec_tests
 
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 6ffa63e834d7e595e3944de87acb986411cf3d8c9be2a269619cde5cbc551249
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZHistoricalBatchVariation checks that HistoricalBatchVariation encodings round trip.
+func TestSSZHistoricalBatchVariation(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(HistoricalBatchVariation)), func() *HistoricalBatchVariation { return new(HistoricalBatchVariation) })
+}
+
+// FuzzSSZHistoricalBatchVariation checks that all accepted HistoricalBatchVariation encodings round trip.
+func FuzzSSZHistoricalBatchVariation(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *HistoricalBatchVariation { return new(HistoricalBatchVariation) }, uint64(new(HistoricalBatchVariation).SizeSSZ()))
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 85a753454a7c3e5d4dccde05e5e3787cb836c2c5bed7d759f5863f6b06ab72b4
 
 package consensus_spec_tests
 
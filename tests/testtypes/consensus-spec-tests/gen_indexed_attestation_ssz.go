@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 65a0ccecbf2f9affaef61674d8cbd0ac849c28d4255134a9008e1a73c89743a5
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 1087615ad46f2e766ac0bbd5196a7aa8d041b53eda1c15ec0dee2c602292a943
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: b7e62ac2f9a0ba8b9b399f593bf660abe776bff9ec4a3a5cbec64557806aa229
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: ba2006cedc8da979a9c74c9e2c67e95cf94a79594dd1230b2b40f370b90aafe7
 
 package consensus_spec_tests
 
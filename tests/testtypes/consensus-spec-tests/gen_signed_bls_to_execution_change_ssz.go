@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: b5a182a25f175d362836365745b7ec73074210d2984e9d09c8ad8d89951dc4ec
 
 package consensus_spec_tests
 
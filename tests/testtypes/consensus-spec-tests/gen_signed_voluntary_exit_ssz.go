@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: b7b6ca79af2694097f3242f5e2232f0dc01f1b78a13021e1f3348006a049445c
 
 package consensus_spec_tests
 
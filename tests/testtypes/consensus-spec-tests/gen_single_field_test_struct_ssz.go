@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 1b365d7d3f0d9dd0837c5790e8ce5c1a23f5203d7937645bbdc173e5d0e2dfce
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 099805e9a3d813885ee4d7ab96fb6023934f823b72221f5a53fd147f1fe5fe59
 
 package consensus_spec_tests
 
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 2626ac5882cd5ac59d94c74cab580f6352f94a0f25f37899ed4f72d654613654
 
 package consensus_spec_tests
 
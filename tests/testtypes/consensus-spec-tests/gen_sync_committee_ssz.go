@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 9f0c719ac0b9f99ca8a1348bf281b94e3e23e45058ad7e88c9dd5f096e135afc
 
 package consensus_spec_tests
 
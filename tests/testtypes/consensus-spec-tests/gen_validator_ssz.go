@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 574efb90ad30c05504eaac5ef806c952c35e718ed89a2ea1aa10453a54c0dd22
 
 package consensus_spec_tests
 
@@ -0,0 +1,40 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: df36276be2f64be3d4244c6d61a97393fd9528a756cf7ac8b24398ec52b3d0a7
+
+package consensus_spec_tests
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ValidatorVariation) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(48 + 32 + 8 + 1 + 8 + 8 + 4 + 4 + 4 + 8)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(*(*[]byte)(&obj.Graffiti))
+	size += ssz.SizeSliceOfUint64s(*(*[]Balance)(&obj.Balances))
+	size += ssz.SizeSliceOfStaticBytes(*(*[]Credentials)(&obj.Roots))
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ValidatorVariation) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineStaticBytes(codec, &obj.Pubkey)                                   // Field  (0) -                Pubkey - 48 bytes
+	ssz.DefineStaticBytes(codec, &obj.WithdrawalCredentials)                    // Field  (1) - WithdrawalCredentials - 32 bytes
+	ssz.DefineUint64(codec, &obj.EffectiveBalance)                              // Field  (2) -      EffectiveBalance -  8 bytes
+	ssz.DefineBool(codec, &obj.Slashed)                                         // Field  (3) -               Slashed -  1 bytes
+	ssz.DefineUint64(codec, &obj.ActivationEpoch)                               // Field  (4) -       ActivationEpoch -  8 bytes
+	ssz.DefineUint64(codec, &obj.ExitEpoch)                                     // Field  (5) -             ExitEpoch -  8 bytes
+	ssz.DefineDynamicBytesOffset(codec, (*[]byte)(&obj.Graffiti), 32)           // Offset (6) -              Graffiti -  4 bytes
+	ssz.DefineSliceOfUint64sOffset(codec, (*[]Balance)(&obj.Balances), 16)      // Offset (7) -              Balances -  4 bytes
+	ssz.DefineSliceOfStaticBytesOffset(codec, (*[]Credentials)(&obj.Roots), 16) // Offset (8) -                 Roots -  4 bytes
+	ssz.DefineCheckedStaticBytes(codec, (*[]byte)(&obj.Fixed), 8)               // Field  (9) -                 Fixed -  8 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, (*[]byte)(&obj.Graffiti), 32)           // Field  (6) -              Graffiti - ? bytes
+	ssz.DefineSliceOfUint64sContent(codec, (*[]Balance)(&obj.Balances), 16)      // Field  (7) -              Balances - ? bytes
+	ssz.DefineSliceOfStaticBytesContent(codec, (*[]Credentials)(&obj.Roots), 16) // Field  (8) -                 Roots - ? bytes
+}
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: df36276be2f64be3d4244c6d61a97393fd9528a756cf7ac8b24398ec52b3d0a7
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZValidatorVariation checks that ValidatorVariation encodings round trip.
+func TestSSZValidatorVariation(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(ValidatorVariation)), func() *ValidatorVariation { return new(ValidatorVariation) })
+}
+
+// FuzzSSZValidatorVariation checks that all accepted ValidatorVariation encodings round trip.
+func FuzzSSZValidatorVariation(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *ValidatorVariation { return new(ValidatorVariation) }, uint64(new(ValidatorVariation).SizeSSZ(true))+32+16*8+16*32)
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 33b0c0f8a08a893b01aaba64df2a72fca0d7652058dc8096780f78a638fd1d52
 
 package consensus_spec_tests
 
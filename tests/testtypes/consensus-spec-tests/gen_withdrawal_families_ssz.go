@@ -0,0 +1,55 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: a8057767576f454df1dfea26a062a7cfe4227408ab0398289113dbb03a061bf2
+
+package consensus_spec_tests
+
+import (
+	"encoding/json"
+
+	"github.com/karalabe/ssz"
+)
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *WithdrawalFamilies) SizeSSZ() uint32 {
+	return 8 + 8 + 20 + 8
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *WithdrawalFamilies) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineUint64(codec, &obj.Index)        // Field  (0) -     Index -  8 bytes
+	ssz.DefineUint64(codec, &obj.Validator)    // Field  (1) - Validator -  8 bytes
+	ssz.DefineStaticBytes(codec, &obj.Address) // Field  (2) -   Address - 20 bytes
+	ssz.DefineUint64(codec, &obj.Amount)       // Field  (3) -    Amount -  8 bytes
+}
+
+// HashTreeRoot computes the ssz merkle root of the object.
+func (obj *WithdrawalFamilies) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashSequentialChecked(obj)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the object with the JSON
+// mapping of its ssz fields.
+func (obj *WithdrawalFamilies) MarshalJSON() ([]byte, error) {
+	return json.Marshal(obj.jsonFields())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the object from the JSON
+// mapping of its ssz fields. Fields missing from the input are left untouched.
+func (obj *WithdrawalFamilies) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, obj.jsonFields())
+}
+
+// jsonFields returns the JSON views of the ssz fields of the object.
+func (obj *WithdrawalFamilies) jsonFields() any {
+	return &struct {
+		Index     ssz.JSONField `json:"index"`
+		Validator ssz.JSONField `json:"validator_index"`
+		Address   ssz.JSONField `json:"address"`
+		Amount    ssz.JSONField `json:"amount"`
+	}{
+		ssz.JSONUint64(&obj.Index),
+		ssz.JSONUint64(&obj.Validator),
+		ssz.JSONStaticBytes(&obj.Address),
+		ssz.JSONUint64(&obj.Amount),
+	}
+}
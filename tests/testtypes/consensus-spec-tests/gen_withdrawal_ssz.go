@@ -1,8 +1,14 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: d18c6b081765ce59ff5e26ae857dd35f3a507d41060c018d687da6298952315c
 
 package consensus_spec_tests
 
-import "github.com/karalabe/ssz"
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/karalabe/ssz"
+)
 
 // SizeSSZ returns the total size of the static ssz object.
 func (obj *Withdrawal) SizeSSZ() uint32 {
@@ -16,3 +22,19 @@ func (obj *Withdrawal) DefineSSZ(codec *ssz.Codec) {
 	ssz.DefineStaticBytes(codec, &obj.Address) // Field  (2) -   Address - 20 bytes
 	ssz.DefineUint64(codec, &obj.Amount)       // Field  (3) -    Amount -  8 bytes
 }
+
+// DecodeFlatSSZ decodes a list of objects from their back-to-back encodings in
+// one go, implementing ssz.FlatDecoder.
+func (*Withdrawal) DecodeFlatSSZ(blob []byte, objs []*Withdrawal) error {
+	if len(blob) < len(objs)*44 {
+		return io.ErrUnexpectedEOF
+	}
+	for i, obj := range objs {
+		item := blob[i*44 : (i+1)*44]
+		obj.Index = binary.LittleEndian.Uint64(item[0:8])
+		obj.Validator = binary.LittleEndian.Uint64(item[8:16])
+		copy(obj.Address[:], item[16:36])
+		obj.Amount = binary.LittleEndian.Uint64(item[36:44])
+	}
+	return nil
+}
@@ -1,4 +1,5 @@
 // Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 1a1e1ea67ca4f4c55f602357f05d0d20ac63acaa3b50be1640b0b5fd70f0fd58
 
 package consensus_spec_tests
 
@@ -0,0 +1,20 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 1a1e1ea67ca4f4c55f602357f05d0d20ac63acaa3b50be1640b0b5fd70f0fd58
+
+package consensus_spec_tests
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz/ssztest"
+)
+
+// TestSSZWithdrawalVariation checks that WithdrawalVariation encodings round trip.
+func TestSSZWithdrawalVariation(t *testing.T) {
+	ssztest.AssertRoundTrip(t, ssztest.Populate(new(WithdrawalVariation)), func() *WithdrawalVariation { return new(WithdrawalVariation) })
+}
+
+// FuzzSSZWithdrawalVariation checks that all accepted WithdrawalVariation encodings round trip.
+func FuzzSSZWithdrawalVariation(f *testing.F) {
+	ssztest.FuzzRoundTrip(f, func() *WithdrawalVariation { return new(WithdrawalVariation) }, uint64(new(WithdrawalVariation).SizeSSZ()))
+}
@@ -36,7 +36,7 @@ import (
 //go:generate go run -cover ../../../cmd/sszgen -type VoluntaryExit -out gen_voluntary_exit_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type SignedVoluntaryExit -out gen_signed_voluntary_exit_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type Validator -out gen_validator_ssz.go
-//go:generate go run -cover ../../../cmd/sszgen -type Withdrawal -out gen_withdrawal_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type Withdrawal -flat -out gen_withdrawal_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadCapella -out gen_execution_payload_capella_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadHeaderCapella -out gen_execution_payload_header_capella_ssz.go
 //go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadDeneb -out gen_execution_payload_deneb_ssz.go
@@ -6,11 +6,24 @@ package consensus_spec_tests
 
 import (
 	"math/big"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz/sszcommon"
 )
 
-//go:generate go run -cover ../../../cmd/sszgen -type WithdrawalVariation -out gen_withdrawal_variation_ssz.go
-//go:generate go run -cover ../../../cmd/sszgen -type HistoricalBatchVariation -out gen_historical_batch_variation_ssz.go
-//go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadVariation -out gen_execution_payload_variation_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type WithdrawalVariation -out gen_withdrawal_variation_ssz.go -tests gen_withdrawal_variation_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type HistoricalBatchVariation -out gen_historical_batch_variation_ssz.go -tests gen_historical_batch_variation_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadVariation -out gen_execution_payload_variation_ssz.go -tests gen_execution_payload_variation_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadHeaderCapellaVariation -out gen_execution_payload_header_capella_variation_ssz.go -tests gen_execution_payload_header_capella_variation_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadHeaderDenebVariation -out gen_execution_payload_header_deneb_variation_ssz.go -tests gen_execution_payload_header_deneb_variation_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type AttestationDataVariation -out gen_attestation_data_variation_ssz.go -tests gen_attestation_data_variation_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type ValidatorVariation -out gen_validator_variation_ssz.go -tests gen_validator_variation_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type CheckpointVariation -out gen_checkpoint_variation_ssz.go -tests gen_checkpoint_variation_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type FlatVariation -flat -out gen_flat_variation_ssz.go -tests gen_flat_variation_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadMonolith -out gen_execution_payload_monolith_ssz.go -tests gen_execution_payload_monolith_ssz_test.go
+//go:generate go run -cover ../../../cmd/sszgen -type BeaconBlockBodyMonolith -out gen_beacon_block_body_monolith_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type WithdrawalFamilies -families ssz,hash,json -out gen_withdrawal_families_ssz.go
+//go:generate go run -cover ../../../cmd/sszgen -type ExecutionPayloadFamilies -families ssz,hash,json -out gen_execution_payload_families_ssz.go -tests gen_execution_payload_families_ssz_test.go
 
 type WithdrawalVariation struct {
 	Index     uint64
@@ -40,3 +53,142 @@ type ExecutionPayloadVariation struct {
 	BlockHash     Hash
 	Transactions  [][]byte `ssz-max:"1048576,1073741824"`
 }
+
+// ExecutionPayloadHeaderCapellaVariation extends the previous fork's header via
+// struct embedding (flattened fields).
+type ExecutionPayloadHeaderCapellaVariation struct {
+	ExecutionPayloadHeader
+	WithdrawalRoot [32]byte
+}
+
+// ExecutionPayloadHeaderDenebVariation extends the previous fork's header via
+// nested struct embedding (flattened fields).
+type ExecutionPayloadHeaderDenebVariation struct {
+	ExecutionPayloadHeaderCapellaVariation
+	BlobGasUsed   uint64
+	ExcessBlobGas uint64
+}
+
+// CheckpointVariation holds its static binary blob behind a pointer, allocated
+// on demand when decoding.
+type CheckpointVariation struct {
+	Epoch uint64
+	Root  *Hash
+}
+
+// FlatVariation mixes all the field kinds supported by the generated flat list
+// decoders: plain and named integers, byte arrays and arrays of them.
+type FlatVariation struct {
+	Slot    Slot
+	Kind    uint8
+	Flags   uint16
+	Count   uint32
+	Address Address
+	Roots   [2]Hash
+}
+
+// AttestationDataVariation mixes primitives and containers from another package
+// with local ones.
+type AttestationDataVariation struct {
+	Slot            Slot
+	Index           uint64
+	BeaconBlockRoot sszcommon.Hash
+	Source          *sszcommon.Checkpoint
+	Target          *Checkpoint
+}
+
+// Epoch is a named type over another named type, Gwei and Balance are chained
+// aliases of a basic type, and the rest are named and aliased composites.
+type (
+	Epoch       Slot
+	Gwei        = uint64
+	Balance     = Gwei
+	BLSPubkey   [48]byte
+	Credentials = Hash
+	Graffiti    []byte
+	Balances    []Balance
+	RootList    []Credentials
+)
+
+// ValidatorVariation uses named types and alias chains instead of primitives.
+type ValidatorVariation struct {
+	Pubkey                BLSPubkey
+	WithdrawalCredentials Credentials
+	EffectiveBalance      Balance
+	Slashed               bool
+	ActivationEpoch       Epoch
+	ExitEpoch             Epoch
+	Graffiti              Graffiti `ssz-max:"32"`
+	Balances              Balances `ssz-max:"16"`
+	Roots                 RootList `ssz-max:"16"`
+	Fixed                 Graffiti `ssz-size:"8"`
+}
+
+// ExecutionPayloadMonolith is the execution payload of all forks since bellatrix
+// as a single type, with the fields added by later forks tagged as such.
+type ExecutionPayloadMonolith struct {
+	ParentHash    Hash
+	FeeRecipient  Address
+	StateRoot     Hash
+	ReceiptsRoot  Hash
+	LogsBloom     LogsBloom
+	PrevRandao    Hash
+	BlockNumber   uint64
+	GasLimit      uint64
+	GasUsed       uint64
+	Timestamp     uint64
+	ExtraData     []byte `ssz-max:"32"`
+	BaseFeePerGas *uint256.Int
+	BlockHash     Hash
+	Transactions  [][]byte      `ssz-max:"1048576,1073741824"`
+	Withdrawals   []*Withdrawal `ssz-max:"16" ssz-fork:"capella"`
+	BlobGasUsed   uint64        `ssz-fork:"deneb"`
+	ExcessBlobGas uint64        `ssz-fork:"deneb"`
+}
+
+// BeaconBlockBodyMonolith is the beacon block body of all forks since bellatrix
+// as a single type. Besides its own fork-conditional fields, the execution
+// payload is a monolith too, whose layout follows the body's fork.
+type BeaconBlockBodyMonolith struct {
+	RandaoReveal          [96]byte
+	Eth1Data              *Eth1Data
+	Graffiti              [32]byte
+	ProposerSlashings     []*ProposerSlashing    `ssz-max:"16"`
+	AttesterSlashings     []*AttesterSlashing    `ssz-max:"2"`
+	Attestations          []*Attestation         `ssz-max:"128"`
+	Deposits              []*Deposit             `ssz-max:"16"`
+	VoluntaryExits        []*SignedVoluntaryExit `ssz-max:"16"`
+	SyncAggregate         *SyncAggregate
+	ExecutionPayload      *ExecutionPayloadMonolith
+	BlsToExecutionChanges []*SignedBLSToExecutionChange `ssz-max:"16" ssz-fork:"capella"`
+	BlobKzgCommitments    [][48]byte                    `ssz-max:"4096" ssz-fork:"deneb"`
+}
+
+// WithdrawalFamilies is a withdrawal generated with all method families (ssz, hash
+// and json). JSON names default to the snake_case field names, unless tagged.
+type WithdrawalFamilies struct {
+	Index     uint64
+	Validator uint64 `json:"validator_index"`
+	Address   Address
+	Amount    uint64
+}
+
+// ExecutionPayloadFamilies is a capella execution payload generated with all the
+// method families, nesting other types with JSON methods.
+type ExecutionPayloadFamilies struct {
+	ParentHash    Hash
+	FeeRecipient  Address
+	StateRoot     Hash
+	ReceiptsRoot  Hash
+	LogsBloom     LogsBloom
+	PrevRandao    Hash
+	BlockNumber   uint64
+	GasLimit      uint64
+	GasUsed       uint64
+	Timestamp     uint64
+	ExtraData     []byte `ssz-max:"32"`
+	BaseFeePerGas *uint256.Int
+	BlockHash     Hash
+	Transactions  [][]byte              `ssz-max:"1048576,1073741824"`
+	Withdrawals   []*WithdrawalFamilies `ssz-max:"16"`
+}
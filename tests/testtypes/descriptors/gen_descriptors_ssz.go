@@ -0,0 +1,630 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 27334f1192b9de1d7f923fceb7b0cac31d10c28a613eca9d99968b9af43a3d70
+
+package descriptors
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheAttestation = 4 + (*AttestationData)(nil).SizeSSZ() + 96
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *Attestation) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheAttestation)
+	if fixed {
+		return size
+	}
+	size += descriptorAttestation.SizeDynamic(obj)
+
+	return size
+}
+
+// descriptorAttestation is the ssz schema of Attestation, interpreted at runtime.
+var descriptorAttestation = ssz.NewDescriptor((*Attestation)(nil),
+	ssz.FieldDescriptor{Name: "AggregationBits", Kind: ssz.FieldSliceOfBits, MaxItems: 2048},
+	ssz.FieldDescriptor{Name: "Data", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "Signature", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Attestation) DefineSSZ(codec *ssz.Codec) {
+	descriptorAttestation.Define(codec, obj)
+}
+
+// Cached static size computed on package init.
+var staticSizeCacheAttestationData = 8 + 8 + 32 + (*Checkpoint)(nil).SizeSSZ() + (*Checkpoint)(nil).SizeSSZ()
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *AttestationData) SizeSSZ() uint32 {
+	return staticSizeCacheAttestationData
+}
+
+// descriptorAttestationData is the ssz schema of AttestationData, interpreted at runtime.
+var descriptorAttestationData = ssz.NewDescriptor((*AttestationData)(nil),
+	ssz.FieldDescriptor{Name: "Slot", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Index", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "BeaconBlockHash", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "Source", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "Target", Kind: ssz.FieldStaticObject},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *AttestationData) DefineSSZ(codec *ssz.Codec) {
+	descriptorAttestationData.Define(codec, obj)
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *AttesterSlashing) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(4 + 4)
+	if fixed {
+		return size
+	}
+	size += descriptorAttesterSlashing.SizeDynamic(obj)
+
+	return size
+}
+
+// descriptorAttesterSlashing is the ssz schema of AttesterSlashing, interpreted at runtime.
+var descriptorAttesterSlashing = ssz.NewDescriptor((*AttesterSlashing)(nil),
+	ssz.FieldDescriptor{Name: "Attestation1", Kind: ssz.FieldDynamicObject},
+	ssz.FieldDescriptor{Name: "Attestation2", Kind: ssz.FieldDynamicObject},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *AttesterSlashing) DefineSSZ(codec *ssz.Codec) {
+	descriptorAttesterSlashing.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *BLSToExecutionChange) SizeSSZ() uint32 {
+	return 8 + 48 + 20
+}
+
+// descriptorBLSToExecutionChange is the ssz schema of BLSToExecutionChange, interpreted at runtime.
+var descriptorBLSToExecutionChange = ssz.NewDescriptor((*BLSToExecutionChange)(nil),
+	ssz.FieldDescriptor{Name: "ValidatorIndex", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "FromBLSPubKey", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "ToExecutionAddress", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BLSToExecutionChange) DefineSSZ(codec *ssz.Codec) {
+	descriptorBLSToExecutionChange.Define(codec, obj)
+}
+
+// Cached static size computed on package init.
+var staticSizeCacheBeaconBlockBodyCapella = 96 + (*Eth1Data)(nil).SizeSSZ() + 32 + 4 + 4 + 4 + 4 + 4 + (*SyncAggregate)(nil).SizeSSZ() + 4 + 4
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *BeaconBlockBodyCapella) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheBeaconBlockBodyCapella)
+	if fixed {
+		return size
+	}
+	size += descriptorBeaconBlockBodyCapella.SizeDynamic(obj)
+
+	return size
+}
+
+// descriptorBeaconBlockBodyCapella is the ssz schema of BeaconBlockBodyCapella, interpreted at runtime.
+var descriptorBeaconBlockBodyCapella = ssz.NewDescriptor((*BeaconBlockBodyCapella)(nil),
+	ssz.FieldDescriptor{Name: "RandaoReveal", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "Eth1Data", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "Graffiti", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "ProposerSlashings", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 16},
+	ssz.FieldDescriptor{Name: "AttesterSlashings", Kind: ssz.FieldSliceOfDynamicObjects, MaxItems: 2},
+	ssz.FieldDescriptor{Name: "Attestations", Kind: ssz.FieldSliceOfDynamicObjects, MaxItems: 128},
+	ssz.FieldDescriptor{Name: "Deposits", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 16},
+	ssz.FieldDescriptor{Name: "VoluntaryExits", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 16},
+	ssz.FieldDescriptor{Name: "SyncAggregate", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "ExecutionPayload", Kind: ssz.FieldDynamicObject},
+	ssz.FieldDescriptor{Name: "BlsToExecutionChanges", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 16},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BeaconBlockBodyCapella) DefineSSZ(codec *ssz.Codec) {
+	descriptorBeaconBlockBodyCapella.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *BeaconBlockHeader) SizeSSZ() uint32 {
+	return 8 + 8 + 32 + 32 + 32
+}
+
+// descriptorBeaconBlockHeader is the ssz schema of BeaconBlockHeader, interpreted at runtime.
+var descriptorBeaconBlockHeader = ssz.NewDescriptor((*BeaconBlockHeader)(nil),
+	ssz.FieldDescriptor{Name: "Slot", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "ProposerIndex", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "ParentRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "StateRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "BodyRoot", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BeaconBlockHeader) DefineSSZ(codec *ssz.Codec) {
+	descriptorBeaconBlockHeader.Define(codec, obj)
+}
+
+// Cached static size computed on package init.
+var staticSizeCacheBeaconStateCapella = 8 + 32 + 8 + (*Fork)(nil).SizeSSZ() + (*BeaconBlockHeader)(nil).SizeSSZ() + 8192*32 + 8192*32 + 4 + (*Eth1Data)(nil).SizeSSZ() + 4 + 8 + 4 + 4 + 65536*32 + 8192*8 + 4 + 4 + 1 + (*Checkpoint)(nil).SizeSSZ() + (*Checkpoint)(nil).SizeSSZ() + (*Checkpoint)(nil).SizeSSZ() + 4 + (*SyncCommittee)(nil).SizeSSZ() + (*SyncCommittee)(nil).SizeSSZ() + 4 + 8 + 8 + 4
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *BeaconStateCapella) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheBeaconStateCapella)
+	if fixed {
+		return size
+	}
+	size += descriptorBeaconStateCapella.SizeDynamic(obj)
+
+	return size
+}
+
+// descriptorBeaconStateCapella is the ssz schema of BeaconStateCapella, interpreted at runtime.
+var descriptorBeaconStateCapella = ssz.NewDescriptor((*BeaconStateCapella)(nil),
+	ssz.FieldDescriptor{Name: "GenesisTime", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "GenesisValidatorsRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "Slot", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Fork", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "LatestBlockHeader", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "BlockRoots", Kind: ssz.FieldArrayOfStaticBytes},
+	ssz.FieldDescriptor{Name: "StateRoots", Kind: ssz.FieldArrayOfStaticBytes},
+	ssz.FieldDescriptor{Name: "HistoricalRoots", Kind: ssz.FieldSliceOfStaticBytes, MaxItems: 16777216},
+	ssz.FieldDescriptor{Name: "Eth1Data", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "Eth1DataVotes", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 2048},
+	ssz.FieldDescriptor{Name: "Eth1DepositIndex", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Validators", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 1099511627776},
+	ssz.FieldDescriptor{Name: "Balances", Kind: ssz.FieldSliceOfUint64s, MaxItems: 1099511627776},
+	ssz.FieldDescriptor{Name: "RandaoMixes", Kind: ssz.FieldArrayOfStaticBytes},
+	ssz.FieldDescriptor{Name: "Slashings", Kind: ssz.FieldArrayOfUint64s},
+	ssz.FieldDescriptor{Name: "PreviousEpochParticipation", Kind: ssz.FieldDynamicBytes, MaxSize: 1099511627776},
+	ssz.FieldDescriptor{Name: "CurrentEpochParticipation", Kind: ssz.FieldDynamicBytes, MaxSize: 1099511627776},
+	ssz.FieldDescriptor{Name: "JustificationBits", Kind: ssz.FieldArrayOfBits, Size: 4},
+	ssz.FieldDescriptor{Name: "PreviousJustifiedCheckpoint", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "CurrentJustifiedCheckpoint", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "FinalizedCheckpoint", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "InactivityScores", Kind: ssz.FieldSliceOfUint64s, MaxItems: 1099511627776},
+	ssz.FieldDescriptor{Name: "CurrentSyncCommittee", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "NextSyncCommittee", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "LatestExecutionPayloadHeader", Kind: ssz.FieldDynamicObject},
+	ssz.FieldDescriptor{Name: "NextWithdrawalIndex", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "NextWithdrawalValidatorIndex", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "HistoricalSummaries", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 16777216},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BeaconStateCapella) DefineSSZ(codec *ssz.Codec) {
+	descriptorBeaconStateCapella.Define(codec, obj)
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *BitsStruct) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(4 + 1 + 1 + 4 + 1)
+	if fixed {
+		return size
+	}
+	size += descriptorBitsStruct.SizeDynamic(obj)
+
+	return size
+}
+
+// descriptorBitsStruct is the ssz schema of BitsStruct, interpreted at runtime.
+var descriptorBitsStruct = ssz.NewDescriptor((*BitsStruct)(nil),
+	ssz.FieldDescriptor{Name: "A", Kind: ssz.FieldSliceOfBits, MaxItems: 5},
+	ssz.FieldDescriptor{Name: "B", Kind: ssz.FieldArrayOfBits, Size: 2},
+	ssz.FieldDescriptor{Name: "C", Kind: ssz.FieldArrayOfBits, Size: 1},
+	ssz.FieldDescriptor{Name: "D", Kind: ssz.FieldSliceOfBits, MaxItems: 6},
+	ssz.FieldDescriptor{Name: "E", Kind: ssz.FieldArrayOfBits, Size: 8},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *BitsStruct) DefineSSZ(codec *ssz.Codec) {
+	descriptorBitsStruct.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Checkpoint) SizeSSZ() uint32 {
+	return 8 + 32
+}
+
+// descriptorCheckpoint is the ssz schema of Checkpoint, interpreted at runtime.
+var descriptorCheckpoint = ssz.NewDescriptor((*Checkpoint)(nil),
+	ssz.FieldDescriptor{Name: "Epoch", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Root", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Checkpoint) DefineSSZ(codec *ssz.Codec) {
+	descriptorCheckpoint.Define(codec, obj)
+}
+
+// Cached static size computed on package init.
+var staticSizeCacheDeposit = 33*32 + (*DepositData)(nil).SizeSSZ()
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Deposit) SizeSSZ() uint32 {
+	return staticSizeCacheDeposit
+}
+
+// descriptorDeposit is the ssz schema of Deposit, interpreted at runtime.
+var descriptorDeposit = ssz.NewDescriptor((*Deposit)(nil),
+	ssz.FieldDescriptor{Name: "Proof", Kind: ssz.FieldArrayOfStaticBytes},
+	ssz.FieldDescriptor{Name: "Data", Kind: ssz.FieldStaticObject},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Deposit) DefineSSZ(codec *ssz.Codec) {
+	descriptorDeposit.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *DepositData) SizeSSZ() uint32 {
+	return 48 + 32 + 8 + 96
+}
+
+// descriptorDepositData is the ssz schema of DepositData, interpreted at runtime.
+var descriptorDepositData = ssz.NewDescriptor((*DepositData)(nil),
+	ssz.FieldDescriptor{Name: "Pubkey", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "WithdrawalCredentials", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "Amount", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Signature", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *DepositData) DefineSSZ(codec *ssz.Codec) {
+	descriptorDepositData.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Eth1Data) SizeSSZ() uint32 {
+	return 32 + 8 + 32
+}
+
+// descriptorEth1Data is the ssz schema of Eth1Data, interpreted at runtime.
+var descriptorEth1Data = ssz.NewDescriptor((*Eth1Data)(nil),
+	ssz.FieldDescriptor{Name: "DepositRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "DepositCount", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "BlockHash", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Eth1Data) DefineSSZ(codec *ssz.Codec) {
+	descriptorEth1Data.Define(codec, obj)
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ExecutionPayloadCapella) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(32 + 20 + 32 + 32 + 256 + 32 + 8 + 8 + 8 + 8 + 4 + 32 + 32 + 4 + 4)
+	if fixed {
+		return size
+	}
+	size += descriptorExecutionPayloadCapella.SizeDynamic(obj)
+
+	return size
+}
+
+// descriptorExecutionPayloadCapella is the ssz schema of ExecutionPayloadCapella, interpreted at runtime.
+var descriptorExecutionPayloadCapella = ssz.NewDescriptor((*ExecutionPayloadCapella)(nil),
+	ssz.FieldDescriptor{Name: "ParentHash", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "FeeRecipient", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "StateRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "ReceiptsRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "LogsBloom", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "PrevRandao", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "BlockNumber", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "GasLimit", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "GasUsed", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Timestamp", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "ExtraData", Kind: ssz.FieldDynamicBytes, MaxSize: 32},
+	ssz.FieldDescriptor{Name: "BaseFeePerGas", Kind: ssz.FieldUint256},
+	ssz.FieldDescriptor{Name: "BlockHash", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "Transactions", Kind: ssz.FieldSliceOfDynamicBytes, MaxItems: 1048576, MaxSize: 1073741824},
+	ssz.FieldDescriptor{Name: "Withdrawals", Kind: ssz.FieldSliceOfStaticObjects, MaxItems: 16},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ExecutionPayloadCapella) DefineSSZ(codec *ssz.Codec) {
+	descriptorExecutionPayloadCapella.Define(codec, obj)
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *ExecutionPayloadHeaderCapella) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(32 + 20 + 32 + 32 + 256 + 32 + 8 + 8 + 8 + 8 + 4 + 32 + 32 + 32 + 32)
+	if fixed {
+		return size
+	}
+	size += descriptorExecutionPayloadHeaderCapella.SizeDynamic(obj)
+
+	return size
+}
+
+// descriptorExecutionPayloadHeaderCapella is the ssz schema of ExecutionPayloadHeaderCapella, interpreted at runtime.
+var descriptorExecutionPayloadHeaderCapella = ssz.NewDescriptor((*ExecutionPayloadHeaderCapella)(nil),
+	ssz.FieldDescriptor{Name: "ParentHash", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "FeeRecipient", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "StateRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "ReceiptsRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "LogsBloom", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "PrevRandao", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "BlockNumber", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "GasLimit", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "GasUsed", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Timestamp", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "ExtraData", Kind: ssz.FieldDynamicBytes, MaxSize: 32},
+	ssz.FieldDescriptor{Name: "BaseFeePerGas", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "BlockHash", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "TransactionsRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "WithdrawalRoot", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ExecutionPayloadHeaderCapella) DefineSSZ(codec *ssz.Codec) {
+	descriptorExecutionPayloadHeaderCapella.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Fork) SizeSSZ() uint32 {
+	return 4 + 4 + 8
+}
+
+// descriptorFork is the ssz schema of Fork, interpreted at runtime.
+var descriptorFork = ssz.NewDescriptor((*Fork)(nil),
+	ssz.FieldDescriptor{Name: "PreviousVersion", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "CurrentVersion", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "Epoch", Kind: ssz.FieldUint64},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Fork) DefineSSZ(codec *ssz.Codec) {
+	descriptorFork.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *HistoricalBatch) SizeSSZ() uint32 {
+	return 8192*32 + 8192*32
+}
+
+// descriptorHistoricalBatch is the ssz schema of HistoricalBatch, interpreted at runtime.
+var descriptorHistoricalBatch = ssz.NewDescriptor((*HistoricalBatch)(nil),
+	ssz.FieldDescriptor{Name: "BlockRoots", Kind: ssz.FieldArrayOfStaticBytes},
+	ssz.FieldDescriptor{Name: "StateRoots", Kind: ssz.FieldArrayOfStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *HistoricalBatch) DefineSSZ(codec *ssz.Codec) {
+	descriptorHistoricalBatch.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *HistoricalSummary) SizeSSZ() uint32 {
+	return 32 + 32
+}
+
+// descriptorHistoricalSummary is the ssz schema of HistoricalSummary, interpreted at runtime.
+var descriptorHistoricalSummary = ssz.NewDescriptor((*HistoricalSummary)(nil),
+	ssz.FieldDescriptor{Name: "BlockSummaryRoot", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "StateSummaryRoot", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *HistoricalSummary) DefineSSZ(codec *ssz.Codec) {
+	descriptorHistoricalSummary.Define(codec, obj)
+}
+
+// Cached static size computed on package init.
+var staticSizeCacheIndexedAttestation = 4 + (*AttestationData)(nil).SizeSSZ() + 96
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *IndexedAttestation) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheIndexedAttestation)
+	if fixed {
+		return size
+	}
+	size += descriptorIndexedAttestation.SizeDynamic(obj)
+
+	return size
+}
+
+// descriptorIndexedAttestation is the ssz schema of IndexedAttestation, interpreted at runtime.
+var descriptorIndexedAttestation = ssz.NewDescriptor((*IndexedAttestation)(nil),
+	ssz.FieldDescriptor{Name: "AttestationIndices", Kind: ssz.FieldSliceOfUint64s, MaxItems: 2048},
+	ssz.FieldDescriptor{Name: "Data", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "Signature", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *IndexedAttestation) DefineSSZ(codec *ssz.Codec) {
+	descriptorIndexedAttestation.Define(codec, obj)
+}
+
+// Cached static size computed on package init.
+var staticSizeCacheProposerSlashing = (*SignedBeaconBlockHeader)(nil).SizeSSZ() + (*SignedBeaconBlockHeader)(nil).SizeSSZ()
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *ProposerSlashing) SizeSSZ() uint32 {
+	return staticSizeCacheProposerSlashing
+}
+
+// descriptorProposerSlashing is the ssz schema of ProposerSlashing, interpreted at runtime.
+var descriptorProposerSlashing = ssz.NewDescriptor((*ProposerSlashing)(nil),
+	ssz.FieldDescriptor{Name: "Header1", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "Header2", Kind: ssz.FieldStaticObject},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *ProposerSlashing) DefineSSZ(codec *ssz.Codec) {
+	descriptorProposerSlashing.Define(codec, obj)
+}
+
+// Cached static size computed on package init.
+var staticSizeCacheSignedBLSToExecutionChange = (*BLSToExecutionChange)(nil).SizeSSZ() + 96
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SignedBLSToExecutionChange) SizeSSZ() uint32 {
+	return staticSizeCacheSignedBLSToExecutionChange
+}
+
+// descriptorSignedBLSToExecutionChange is the ssz schema of SignedBLSToExecutionChange, interpreted at runtime.
+var descriptorSignedBLSToExecutionChange = ssz.NewDescriptor((*SignedBLSToExecutionChange)(nil),
+	ssz.FieldDescriptor{Name: "Message", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "Signature", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SignedBLSToExecutionChange) DefineSSZ(codec *ssz.Codec) {
+	descriptorSignedBLSToExecutionChange.Define(codec, obj)
+}
+
+// Cached static size computed on package init.
+var staticSizeCacheSignedBeaconBlockHeader = (*BeaconBlockHeader)(nil).SizeSSZ() + 96
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SignedBeaconBlockHeader) SizeSSZ() uint32 {
+	return staticSizeCacheSignedBeaconBlockHeader
+}
+
+// descriptorSignedBeaconBlockHeader is the ssz schema of SignedBeaconBlockHeader, interpreted at runtime.
+var descriptorSignedBeaconBlockHeader = ssz.NewDescriptor((*SignedBeaconBlockHeader)(nil),
+	ssz.FieldDescriptor{Name: "Header", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "Signature", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SignedBeaconBlockHeader) DefineSSZ(codec *ssz.Codec) {
+	descriptorSignedBeaconBlockHeader.Define(codec, obj)
+}
+
+// Cached static size computed on package init.
+var staticSizeCacheSignedVoluntaryExit = (*VoluntaryExit)(nil).SizeSSZ() + 96
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SignedVoluntaryExit) SizeSSZ() uint32 {
+	return staticSizeCacheSignedVoluntaryExit
+}
+
+// descriptorSignedVoluntaryExit is the ssz schema of SignedVoluntaryExit, interpreted at runtime.
+var descriptorSignedVoluntaryExit = ssz.NewDescriptor((*SignedVoluntaryExit)(nil),
+	ssz.FieldDescriptor{Name: "Exit", Kind: ssz.FieldStaticObject},
+	ssz.FieldDescriptor{Name: "Signature", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SignedVoluntaryExit) DefineSSZ(codec *ssz.Codec) {
+	descriptorSignedVoluntaryExit.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SyncAggregate) SizeSSZ() uint32 {
+	return 64 + 96
+}
+
+// descriptorSyncAggregate is the ssz schema of SyncAggregate, interpreted at runtime.
+var descriptorSyncAggregate = ssz.NewDescriptor((*SyncAggregate)(nil),
+	ssz.FieldDescriptor{Name: "SyncCommiteeBits", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "SyncCommiteeSignature", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SyncAggregate) DefineSSZ(codec *ssz.Codec) {
+	descriptorSyncAggregate.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *SyncCommittee) SizeSSZ() uint32 {
+	return 512*48 + 48
+}
+
+// descriptorSyncCommittee is the ssz schema of SyncCommittee, interpreted at runtime.
+var descriptorSyncCommittee = ssz.NewDescriptor((*SyncCommittee)(nil),
+	ssz.FieldDescriptor{Name: "PubKeys", Kind: ssz.FieldArrayOfStaticBytes},
+	ssz.FieldDescriptor{Name: "AggregatePubKey", Kind: ssz.FieldStaticBytes},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SyncCommittee) DefineSSZ(codec *ssz.Codec) {
+	descriptorSyncCommittee.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Validator) SizeSSZ() uint32 {
+	return 48 + 32 + 8 + 1 + 8 + 8 + 8 + 8
+}
+
+// descriptorValidator is the ssz schema of Validator, interpreted at runtime.
+var descriptorValidator = ssz.NewDescriptor((*Validator)(nil),
+	ssz.FieldDescriptor{Name: "Pubkey", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "WithdrawalCredentials", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "EffectiveBalance", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Slashed", Kind: ssz.FieldBool},
+	ssz.FieldDescriptor{Name: "ActivationEligibilityEpoch", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "ActivationEpoch", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "ExitEpoch", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "WithdrawableEpoch", Kind: ssz.FieldUint64},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Validator) DefineSSZ(codec *ssz.Codec) {
+	descriptorValidator.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *VoluntaryExit) SizeSSZ() uint32 {
+	return 8 + 8
+}
+
+// descriptorVoluntaryExit is the ssz schema of VoluntaryExit, interpreted at runtime.
+var descriptorVoluntaryExit = ssz.NewDescriptor((*VoluntaryExit)(nil),
+	ssz.FieldDescriptor{Name: "Epoch", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "ValidatorIndex", Kind: ssz.FieldUint64},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *VoluntaryExit) DefineSSZ(codec *ssz.Codec) {
+	descriptorVoluntaryExit.Define(codec, obj)
+}
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Withdrawal) SizeSSZ() uint32 {
+	return 8 + 8 + 20 + 8
+}
+
+// descriptorWithdrawal is the ssz schema of Withdrawal, interpreted at runtime.
+var descriptorWithdrawal = ssz.NewDescriptor((*Withdrawal)(nil),
+	ssz.FieldDescriptor{Name: "Index", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Validator", Kind: ssz.FieldUint64},
+	ssz.FieldDescriptor{Name: "Address", Kind: ssz.FieldStaticBytes},
+	ssz.FieldDescriptor{Name: "Amount", Kind: ssz.FieldUint64},
+)
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Withdrawal) DefineSSZ(codec *ssz.Codec) {
+	descriptorWithdrawal.Define(codec, obj)
+}
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *SlotList) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(4)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeSliceOfUint64s(obj.Slots)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *SlotList) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineSliceOfUint64sOffset(codec, &obj.Slots, 16) // Offset (0) - Slots - 4 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineSliceOfUint64sContent(codec, &obj.Slots, 16) // Field  (0) - Slots - ? bytes
+}
@@ -0,0 +1,255 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package descriptors contains copies of consensus types generated in descriptor
+// mode, to be cross checked against their methods generated counterparts.
+package descriptors
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+//go:generate go run -cover ../../../cmd/sszgen -mode descriptor -type Attestation,AttestationData,AttesterSlashing,BLSToExecutionChange,BeaconBlockBodyCapella,BeaconBlockHeader,BeaconStateCapella,BitsStruct,Checkpoint,Deposit,DepositData,Eth1Data,ExecutionPayloadCapella,ExecutionPayloadHeaderCapella,Fork,HistoricalBatch,HistoricalSummary,IndexedAttestation,ProposerSlashing,SignedBLSToExecutionChange,SignedBeaconBlockHeader,SignedVoluntaryExit,SyncAggregate,SyncCommittee,Validator,VoluntaryExit,Withdrawal,SlotList -out gen_descriptors_ssz.go
+
+// Slot is an alias of uint64
+type Slot uint64
+
+// Hash is a standalone mock of go-ethereum;s common.Hash
+type Hash [32]byte
+
+// Address is a standalone mock of go-ethereum's common.Address
+type Address [20]byte
+
+// LogsBloom is a standalone mock of go-ethereum's types.LogsBloom
+type LogsBloom [256]byte
+
+// Roots is a helper type to foce a generator quirk.
+type Roots [8192]Hash
+
+// SlotList is a list of named uint64s, which the descriptor runtime cannot view
+// without unsafe conversions, so it falls back to generated methods.
+type SlotList struct {
+	Slots []Slot `ssz-max:"16"`
+}
+
+type Attestation struct {
+	AggregationBits bitfield.Bitlist `ssz-max:"2048"`
+	Data            *AttestationData
+	Signature       [96]byte
+}
+
+type AttestationData struct {
+	Slot            Slot
+	Index           uint64
+	BeaconBlockHash Hash
+	Source          *Checkpoint
+	Target          *Checkpoint
+}
+
+type AttesterSlashing struct {
+	Attestation1 *IndexedAttestation
+	Attestation2 *IndexedAttestation
+}
+
+type BLSToExecutionChange struct {
+	ValidatorIndex     uint64
+	FromBLSPubKey      [48]byte
+	ToExecutionAddress [20]byte
+}
+
+type BeaconBlockBodyCapella struct {
+	RandaoReveal          [96]byte
+	Eth1Data              *Eth1Data
+	Graffiti              [32]byte
+	ProposerSlashings     []*ProposerSlashing    `ssz-max:"16"`
+	AttesterSlashings     []*AttesterSlashing    `ssz-max:"2"`
+	Attestations          []*Attestation         `ssz-max:"128"`
+	Deposits              []*Deposit             `ssz-max:"16"`
+	VoluntaryExits        []*SignedVoluntaryExit `ssz-max:"16"`
+	SyncAggregate         *SyncAggregate
+	ExecutionPayload      *ExecutionPayloadCapella
+	BlsToExecutionChanges []*SignedBLSToExecutionChange `ssz-max:"16"`
+}
+
+type BeaconBlockHeader struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    Hash
+	StateRoot     Hash
+	BodyRoot      Hash
+}
+
+type BeaconStateCapella struct {
+	GenesisTime                  uint64
+	GenesisValidatorsRoot        [32]byte
+	Slot                         uint64
+	Fork                         *Fork
+	LatestBlockHeader            *BeaconBlockHeader
+	BlockRoots                   [8192][32]byte
+	StateRoots                   [8192][32]byte
+	HistoricalRoots              [][32]byte `ssz-max:"16777216"`
+	Eth1Data                     *Eth1Data
+	Eth1DataVotes                []*Eth1Data `ssz-max:"2048"`
+	Eth1DepositIndex             uint64
+	Validators                   []*Validator `ssz-max:"1099511627776"`
+	Balances                     []uint64     `ssz-max:"1099511627776"`
+	RandaoMixes                  [65536][32]byte
+	Slashings                    [8192]uint64
+	PreviousEpochParticipation   []byte  `ssz-max:"1099511627776"`
+	CurrentEpochParticipation    []byte  `ssz-max:"1099511627776"`
+	JustificationBits            [1]byte `ssz-size:"4" ssz:"bits"`
+	PreviousJustifiedCheckpoint  *Checkpoint
+	CurrentJustifiedCheckpoint   *Checkpoint
+	FinalizedCheckpoint          *Checkpoint
+	InactivityScores             []uint64 `ssz-max:"1099511627776"`
+	CurrentSyncCommittee         *SyncCommittee
+	NextSyncCommittee            *SyncCommittee
+	LatestExecutionPayloadHeader *ExecutionPayloadHeaderCapella
+	NextWithdrawalIndex          uint64
+	NextWithdrawalValidatorIndex uint64
+	HistoricalSummaries          []*HistoricalSummary `ssz-max:"16777216"`
+}
+
+type BitsStruct struct {
+	A bitfield.Bitlist `ssz-max:"5"`
+	B [1]byte          `ssz-size:"2" ssz:"bits"`
+	C [1]byte          `ssz-size:"1" ssz:"bits"`
+	D bitfield.Bitlist `ssz-max:"6"`
+	E [1]byte          `ssz-size:"8" ssz:"bits"`
+}
+
+type Checkpoint struct {
+	Epoch uint64
+	Root  Hash
+}
+
+type Deposit struct {
+	Proof [33][32]byte
+	Data  *DepositData
+}
+
+type DepositData struct {
+	Pubkey                [48]byte
+	WithdrawalCredentials [32]byte
+	Amount                uint64
+	Signature             [96]byte
+}
+
+type Eth1Data struct {
+	DepositRoot  Hash
+	DepositCount uint64
+	BlockHash    Hash
+}
+
+type ExecutionPayloadCapella struct {
+	ParentHash    Hash
+	FeeRecipient  Address
+	StateRoot     Hash
+	ReceiptsRoot  Hash
+	LogsBloom     LogsBloom
+	PrevRandao    Hash
+	BlockNumber   uint64
+	GasLimit      uint64
+	GasUsed       uint64
+	Timestamp     uint64
+	ExtraData     []byte `ssz-max:"32"`
+	BaseFeePerGas *uint256.Int
+	BlockHash     Hash
+	Transactions  [][]byte      `ssz-max:"1048576,1073741824"`
+	Withdrawals   []*Withdrawal `ssz-max:"16"`
+}
+
+type ExecutionPayloadHeaderCapella struct {
+	ParentHash       [32]byte
+	FeeRecipient     [20]byte
+	StateRoot        [32]byte
+	ReceiptsRoot     [32]byte
+	LogsBloom        [256]byte
+	PrevRandao       [32]byte
+	BlockNumber      uint64
+	GasLimit         uint64
+	GasUsed          uint64
+	Timestamp        uint64
+	ExtraData        []byte `ssz-max:"32"`
+	BaseFeePerGas    [32]byte
+	BlockHash        [32]byte
+	TransactionsRoot [32]byte
+	WithdrawalRoot   [32]byte
+}
+
+type Fork struct {
+	PreviousVersion [4]byte
+	CurrentVersion  [4]byte
+	Epoch           uint64
+}
+
+type HistoricalBatch struct {
+	BlockRoots [8192]Hash
+	StateRoots Roots
+}
+
+type HistoricalSummary struct {
+	BlockSummaryRoot [32]byte
+	StateSummaryRoot [32]byte
+}
+
+type IndexedAttestation struct {
+	AttestationIndices []uint64 `ssz-max:"2048"`
+	Data               *AttestationData
+	Signature          [96]byte
+}
+
+type ProposerSlashing struct {
+	Header1 *SignedBeaconBlockHeader
+	Header2 *SignedBeaconBlockHeader
+}
+
+type SignedBLSToExecutionChange struct {
+	Message   *BLSToExecutionChange
+	Signature [96]byte
+}
+
+type SignedBeaconBlockHeader struct {
+	Header    *BeaconBlockHeader
+	Signature [96]byte
+}
+
+type SignedVoluntaryExit struct {
+	Exit      *VoluntaryExit
+	Signature [96]byte
+}
+
+type SyncAggregate struct {
+	SyncCommiteeBits      [64]byte
+	SyncCommiteeSignature [96]byte
+}
+
+type SyncCommittee struct {
+	PubKeys         [512][48]byte
+	AggregatePubKey [48]byte
+}
+
+type Validator struct {
+	Pubkey                     [48]byte
+	WithdrawalCredentials      [32]byte
+	EffectiveBalance           uint64
+	Slashed                    bool
+	ActivationEligibilityEpoch uint64
+	ActivationEpoch            uint64
+	ExitEpoch                  uint64
+	WithdrawableEpoch          uint64
+}
+
+type VoluntaryExit struct {
+	Epoch          uint64
+	ValidatorIndex uint64
+}
+
+type Withdrawal struct {
+	Index     uint64
+	Validator uint64
+	Address   Address
+	Amount    uint64
+}
@@ -0,0 +1,83 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package random fills ssz test types with random data.
+package random
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// Fill populates the value pointed to by obj with random data within the ssz
+// limits of the test types, so that every field of a container gets exercised.
+func Fill(rng *rand.Rand, obj any) {
+	fill(rng, reflect.ValueOf(obj).Elem(), "")
+}
+
+// fill populates a value with random data within the ssz limits declared by
+// the struct tag of the field holding it.
+func fill(rng *rand.Rand, v reflect.Value, tag reflect.StructTag) {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(rng.Intn(2) == 1)
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(rng.Uint64())
+
+	case reflect.Array:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < v.Len(); i++ {
+				fill(rng, v.Index(i), "")
+			}
+			return
+		}
+		rng.Read(v.Slice(0, v.Len()).Bytes())
+
+		// Bitvectors must not have bits set beyond their size
+		if tag.Get("ssz") == "bits" {
+			if bits, _ := strconv.Atoi(tag.Get("ssz-size")); bits%8 != 0 {
+				last := v.Index(v.Len() - 1)
+				last.SetUint(last.Uint() & (1<<(bits%8) - 1))
+			}
+		}
+
+	case reflect.Slice:
+		if v.Type() == reflect.TypeOf(bitfield.Bitlist{}) {
+			limit, _ := strconv.Atoi(tag.Get("ssz-max"))
+			bits := bitfield.NewBitlist(uint64(rng.Intn(limit + 1)))
+			for i := uint64(0); i < bits.Len(); i++ {
+				bits.SetBitAt(i, rng.Intn(2) == 1)
+			}
+			v.Set(reflect.ValueOf(bits))
+			return
+		}
+		items := rng.Intn(4)
+		if limit, err := strconv.Atoi(strings.Split(tag.Get("ssz-max"), ",")[0]); err == nil {
+			items = rng.Intn(min(4, limit+1))
+		}
+		v.Set(reflect.MakeSlice(v.Type(), items, items))
+		for i := 0; i < items; i++ {
+			fill(rng, v.Index(i), "")
+		}
+
+	case reflect.Pointer:
+		if v.Type() == reflect.TypeOf((*uint256.Int)(nil)) {
+			v.Set(reflect.ValueOf(uint256.NewInt(rng.Uint64())))
+			return
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(rng, v.Elem(), "")
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fill(rng, v.Field(i), v.Type().Field(i).Tag)
+		}
+	}
+}
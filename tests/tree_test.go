@@ -0,0 +1,417 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/random"
+)
+
+// Tests that tree-backed containers hash and encode identically to the objects
+// they were decoded from.
+func TestTreeRoundTrip(t *testing.T) {
+	tests := []struct {
+		object ssz.Object
+		schema *ssz.Schema
+	}{
+		{new(types.BitsStruct), schemaBitsStruct},
+		{new(types.AttesterSlashing), schemaAttesterSlashing},
+		{new(types.ExecutionPayloadCapella), schemaExecutionPayloadCapella},
+		{new(types.BeaconState), schemaBeaconState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schema.Name, func(t *testing.T) {
+			random.Fill(rand.New(rand.NewSource(1)), tt.object)
+
+			blob := make([]byte, ssz.Size(tt.object))
+			if err := ssz.EncodeToBytes(blob, tt.object); err != nil {
+				t.Fatalf("failed to encode object: %v", err)
+			}
+			tree, err := tt.schema.DecodeTree(blob)
+			if err != nil {
+				t.Fatalf("failed to decode tree: %v", err)
+			}
+			if have, want := tree.Hash(), ssz.HashSequential(tt.object); have != want {
+				t.Errorf("hash mismatch: have %#x, want %#x", have, want)
+			}
+			enc, err := tree.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode tree: %v", err)
+			}
+			if !bytes.Equal(enc, blob) {
+				t.Errorf("re-encoded tree mismatch")
+			}
+		})
+	}
+}
+
+// Tests that copies of tree-backed containers share unmodified subtrees, and that
+// modifications are reflected in the roots.
+func TestTreeMutation(t *testing.T) {
+	obj := new(types.BeaconState)
+	random.Fill(rand.New(rand.NewSource(1)), obj)
+	obj.Balances = []uint64{1, 2, 3, 4, 5, 6}
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	tree, err := schemaBeaconState.DecodeTree(blob)
+	if err != nil {
+		t.Fatalf("failed to decode tree: %v", err)
+	}
+	root := tree.Hash()
+
+	// Modify a copy of the tree and the object the same way
+	copied := tree.Copy()
+
+	obj.Slot++
+	if err := copied.SetUint(obj.Slot, "Slot"); err != nil {
+		t.Fatalf("failed to set slot: %v", err)
+	}
+	obj.Balances[5] = 32_000_000_000
+	if err := copied.SetUint(obj.Balances[5], "Balances", 5); err != nil {
+		t.Fatalf("failed to set balance: %v", err)
+	}
+	obj.Balances = append(obj.Balances[:2], 0, 0, 0, 0, 0, 0, 0)
+	if err := copied.SetLen(2, "Balances"); err != nil {
+		t.Fatalf("failed to shrink balances: %v", err)
+	}
+	if err := copied.SetLen(9, "Balances"); err != nil {
+		t.Fatalf("failed to grow balances: %v", err)
+	}
+	obj.Validators = append(obj.Validators, new(types.Validator))
+	if err := copied.SetLen(uint64(len(obj.Validators)), "Validators"); err != nil {
+		t.Fatalf("failed to grow validators: %v", err)
+	}
+	obj.Validators[len(obj.Validators)-1].Slashed = true
+	if err := copied.SetBool(true, "Validators", len(obj.Validators)-1, "Slashed"); err != nil {
+		t.Fatalf("failed to slash validator: %v", err)
+	}
+	obj.LatestBlockHeader.StateRoot = [32]byte{0x01}
+	if err := copied.SetBytes(obj.LatestBlockHeader.StateRoot[:], "LatestBlockHeader", "StateRoot"); err != nil {
+		t.Fatalf("failed to set state root: %v", err)
+	}
+	obj.PreviousEpochAttestations = obj.PreviousEpochAttestations[:0]
+	if err := copied.SetLen(0, "PreviousEpochAttestations"); err != nil {
+		t.Fatalf("failed to clear attestations: %v", err)
+	}
+	if have, want := copied.Hash(), ssz.HashSequential(obj); have != want {
+		t.Errorf("modified hash mismatch: have %#x, want %#x", have, want)
+	}
+	if have := tree.Hash(); have != root {
+		t.Errorf("original hash changed: have %#x, want %#x", have, root)
+	}
+	blob = make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if enc, err := copied.Encode(); err != nil || !bytes.Equal(enc, blob) {
+		t.Errorf("modified encoding mismatch: %v", err)
+	}
+	// Ensure unmodified subtrees are shared between the copies
+	for _, field := range []string{"BlockRoots", "RandaoMixes", "Eth1Data"} {
+		index, _ := schemaBeaconState.GeneralizedIndex(field)
+		a, _ := tree.Node().Get(index)
+		b, _ := copied.Node().Get(index)
+		if a != b {
+			t.Errorf("field %s not shared between copies", field)
+		}
+	}
+	// Ensure invalid modifications are rejected
+	invalid := []error{
+		copied.SetUint(1, "Balances", 9),
+		copied.SetUint(1, "LatestBlockHeader"),
+		copied.SetBool(true, "Slot"),
+		copied.SetBytes(make([]byte, 31), "LatestBlockHeader", "StateRoot"),
+		copied.SetLen(2049, "Eth1DataVotes"),
+		copied.SetLen(1, "BlockRoots"),
+	}
+	for i, err := range invalid {
+		if !errors.Is(err, ssz.ErrSchemaMismatch) {
+			t.Errorf("invalid modification %d: error mismatch: have %v, want %v", i, err, ssz.ErrSchemaMismatch)
+		}
+	}
+	if have, want := copied.Hash(), ssz.HashSequential(obj); have != want {
+		t.Errorf("hash changed by invalid modifications: have %#x, want %#x", have, want)
+	}
+}
+
+// Tests that the getters of tree-backed containers return the field values and
+// that proofs created from trees match those created from value trees.
+func TestTreeAccess(t *testing.T) {
+	obj := new(types.BeaconState)
+	random.Fill(rand.New(rand.NewSource(5)), obj)
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	tree, err := schemaBeaconState.DecodeTree(blob)
+	if err != nil {
+		t.Fatalf("failed to decode tree: %v", err)
+	}
+	value, err := schemaBeaconState.DecodeValue(blob)
+	if err != nil {
+		t.Fatalf("failed to decode value tree: %v", err)
+	}
+	if have, err := tree.Uint("GenesisTime"); err != nil || have != obj.GenesisTime {
+		t.Errorf("genesis time mismatch: have %d (%v), want %d", have, err, obj.GenesisTime)
+	}
+	if have, err := tree.Bytes("JustificationBits"); err != nil || !bytes.Equal(have, obj.JustificationBits[:]) {
+		t.Errorf("justification bits mismatch: have %x (%v), want %x", have, err, obj.JustificationBits)
+	}
+	if have, err := tree.Len("Validators"); err != nil || have != uint64(len(obj.Validators)) {
+		t.Errorf("validator count mismatch: have %d (%v), want %d", have, err, len(obj.Validators))
+	}
+	sub, err := tree.Value("Fork")
+	if err != nil {
+		t.Fatalf("failed to retrieve fork: %v", err)
+	}
+	if have, want := sub.Hash(), ssz.HashSequential(obj.Fork); have != want {
+		t.Errorf("fork hash mismatch: have %#x, want %#x", have, want)
+	}
+	// Cross check proofs, including ones through bitlists of attestations
+	var paths [][]any
+	paths = append(paths, []any{"Slot"}, []any{"Validators", "__len__"}, []any{"Slashings", 100})
+	for i := range obj.PreviousEpochAttestations {
+		paths = append(paths, []any{"PreviousEpochAttestations", i, "AggregationBits"})
+	}
+	var indices []uint64
+	for _, path := range paths {
+		index, err := schemaBeaconState.GeneralizedIndex(path...)
+		if err != nil {
+			t.Fatalf("failed to resolve path %v: %v", path, err)
+		}
+		indices = append(indices, index)
+	}
+	have, err := tree.Prove(indices...)
+	if err != nil {
+		t.Fatalf("failed to prove tree: %v", err)
+	}
+	want, err := value.Prove(indices...)
+	if err != nil {
+		t.Fatalf("failed to prove value tree: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("proof mismatch between trees and value trees")
+	}
+	if err := ssz.VerifyMultiproof(tree.Hash(), have); err != nil {
+		t.Errorf("failed to verify tree proof: %v", err)
+	}
+}
+
+// Tests that the merkle trees exported from hashing objects have the same root
+// and nodes as the schema backed trees, and that proofs can be served from the
+// exported nodes alone.
+func TestHashTreeExport(t *testing.T) {
+	tests := []struct {
+		object ssz.Object
+		schema *ssz.Schema
+	}{
+		{new(types.BitsStruct), schemaBitsStruct},
+		{new(types.AttesterSlashing), schemaAttesterSlashing},
+		{new(types.ExecutionPayloadCapella), schemaExecutionPayloadCapella},
+		{new(types.BeaconState), schemaBeaconState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schema.Name, func(t *testing.T) {
+			random.Fill(rand.New(rand.NewSource(1)), tt.object)
+
+			root := ssz.HashTree(tt.object)
+			if have, want := root.Root(), ssz.HashSequential(tt.object); have != want {
+				t.Fatalf("tree root mismatch: have %#x, want %#x", have, want)
+			}
+			blob := make([]byte, ssz.Size(tt.object))
+			if err := ssz.EncodeToBytes(blob, tt.object); err != nil {
+				t.Fatalf("failed to encode object: %v", err)
+			}
+			tree, err := tt.schema.DecodeTree(blob)
+			if err != nil {
+				t.Fatalf("failed to decode tree: %v", err)
+			}
+			var (
+				nodes  = make(map[uint64][32]byte)
+				leaves []uint64
+			)
+			err = root.Walk(func(gindex uint64, node *ssz.TreeNode) error {
+				want, err := tree.Node().Get(gindex)
+				if err != nil {
+					return err
+				}
+				if node.Root() != want.Root() {
+					t.Errorf("node %d mismatch: have %#x, want %#x", gindex, node.Root(), want.Root())
+				}
+				nodes[gindex] = node.Root()
+				if node.Leaf() && len(leaves) < 8 {
+					leaves = append(leaves, gindex)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to walk tree: %v", err)
+			}
+			// Prove a few leaves from the exported nodes only
+			proof, err := ssz.ProveFromNodes(leaves, func(gindex uint64) ([32]byte, error) {
+				node, ok := nodes[gindex]
+				if !ok {
+					return [32]byte{}, ssz.ErrTreeNodeNotFound
+				}
+				return node, nil
+			})
+			if err != nil {
+				t.Fatalf("failed to prove from exported nodes: %v", err)
+			}
+			if err := ssz.VerifyMultiproof(root.Root(), proof); err != nil {
+				t.Errorf("failed to verify proof: %v", err)
+			}
+		})
+	}
+}
+
+// Tests that the merkle trees exported from hashing objects with progressive
+// lists hash up to the same roots as the hasher.
+func TestHashTreeProgressive(t *testing.T) {
+	for _, n := range []int{0, 1, 5, 22, 100} {
+		obj := &testProgressiveType{
+			Bits:    make([]byte, n/8+1),
+			Nums:    make([]uint64, n),
+			Objects: make([]*types.Withdrawal, n),
+			Nested:  make([]*testProgressiveNestedType, n),
+		}
+		obj.Bits[n/8] |= 1 << (n % 8)
+		for i := 0; i < n; i++ {
+			obj.Nums[i] = uint64(i + 1)
+			obj.Objects[i] = &types.Withdrawal{Index: uint64(i)}
+			obj.Nested[i] = &testProgressiveNestedType{Nums: obj.Nums[:i]}
+		}
+		if have, want := ssz.HashTree(obj).Root(), ssz.HashSequential(obj); have != want {
+			t.Errorf("%d items: tree root mismatch: have %#x, want %#x", n, have, want)
+		}
+	}
+}
+
+// Tests that merkle trees can be persisted into node stores, sharing unchanged
+// subtrees between versions, and that proofs can be served from the store.
+func TestNodeStore(t *testing.T) {
+	obj := new(types.BeaconState)
+	random.Fill(rand.New(rand.NewSource(1)), obj)
+
+	store := new(ssz.MemoryNodeStore)
+	oldRoot, err := ssz.HashToStore(store, obj)
+	if err != nil {
+		t.Fatalf("failed to store tree: %v", err)
+	}
+	if want := ssz.HashSequential(obj); oldRoot != want {
+		t.Fatalf("stored root mismatch: have %#x, want %#x", oldRoot, want)
+	}
+	stored := store.Len()
+
+	obj.Slot++
+	newRoot, err := ssz.HashToStore(store, obj)
+	if err != nil {
+		t.Fatalf("failed to store modified tree: %v", err)
+	}
+	if added := store.Len() - stored; added > 16 {
+		t.Errorf("unchanged subtrees stored again: %d new nodes", added)
+	}
+	// Serve proofs of the slot field for both versions from the store
+	slot, err := schemaBeaconState.GeneralizedIndex("Slot")
+	if err != nil {
+		t.Fatalf("failed to resolve slot index: %v", err)
+	}
+	for _, root := range [][32]byte{oldRoot, newRoot} {
+		proof, err := ssz.ProveFromStore(store, root, slot)
+		if err != nil {
+			t.Fatalf("failed to prove from store: %v", err)
+		}
+		if err := ssz.VerifyMultiproof(root, proof); err != nil {
+			t.Errorf("failed to verify stored proof: %v", err)
+		}
+	}
+	want, err := ssz.HashTree(obj).Prove(slot)
+	if err != nil {
+		t.Fatalf("failed to prove from tree: %v", err)
+	}
+	have, _ := ssz.ProveFromStore(store, newRoot, slot)
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("stored proof mismatch: have %+v, want %+v", have, want)
+	}
+	// Ensure nodes in the zero padding of lists are expanded without being stored
+	padding, err := schemaBeaconState.GeneralizedIndex("Balances", 1<<30)
+	if err != nil {
+		t.Fatalf("failed to resolve padding index: %v", err)
+	}
+	proof, err := ssz.ProveFromStore(store, newRoot, padding)
+	if err != nil {
+		t.Fatalf("failed to prove padding from store: %v", err)
+	}
+	if err := ssz.VerifyMultiproof(newRoot, proof); err != nil {
+		t.Errorf("failed to verify padding proof: %v", err)
+	}
+	// Ensure nodes below leaves or of unknown trees are rejected
+	if _, err := ssz.ProveFromStore(store, newRoot, slot*2); !errors.Is(err, ssz.ErrTreeNodeNotFound) {
+		t.Errorf("missing node error mismatch: have %v, want %v", err, ssz.ErrTreeNodeNotFound)
+	}
+	if _, err := ssz.ProveFromStore(store, [32]byte{0x01}, slot); !errors.Is(err, ssz.ErrTreeNodeNotFound) {
+		t.Errorf("unknown root error mismatch: have %v, want %v", err, ssz.ErrTreeNodeNotFound)
+	}
+}
+
+// Tests that the streaming hasher computes the same root from chunks of encoded
+// bytes as hashing the object directly, and that it rejects missized inputs.
+func TestStreamingHasher(t *testing.T) {
+	obj := new(types.BeaconState)
+	random.Fill(rand.New(rand.NewSource(1)), obj)
+
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	want := ssz.HashSequential(obj)
+
+	// Feed the encoding in odd sized chunks, both typed and via the schema
+	schema, err := schemaBeaconState.NewStreamingHasher(uint32(len(blob)))
+	if err != nil {
+		t.Fatalf("failed to create schema hasher: %v", err)
+	}
+	for _, hasher := range []*ssz.StreamingHasher{
+		ssz.NewStreamingHasher(new(types.BeaconState), uint32(len(blob))),
+		schema,
+	} {
+		for i := 0; i < len(blob); i += 1021 {
+			if _, err := hasher.Write(blob[i:min(i+1021, len(blob))]); err != nil {
+				t.Fatalf("failed to write chunk at %d: %v", i, err)
+			}
+		}
+		have, err := hasher.Sum()
+		if err != nil {
+			t.Fatalf("failed to hash stream: %v", err)
+		}
+		if have != want {
+			t.Errorf("streamed root mismatch: have %#x, want %#x", have, want)
+		}
+	}
+	// Truncated and oversized inputs must both be rejected
+	hasher := ssz.NewStreamingHasher(new(types.BeaconState), uint32(len(blob)))
+	hasher.Write(blob[:len(blob)-1])
+	if _, err := hasher.Sum(); err == nil {
+		t.Errorf("truncated stream hashed")
+	}
+	hasher = ssz.NewStreamingHasher(new(types.BeaconState), uint32(len(blob)))
+	if _, err := hasher.Write(append(blob[:len(blob):len(blob)], 0)); !errors.Is(err, ssz.ErrExcessData) {
+		t.Errorf("oversized write error mismatch: have %v, want %v", err, ssz.ErrExcessData)
+	}
+	if _, err := hasher.Sum(); err != nil {
+		t.Errorf("failed to hash stream after rejected trailing data: %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/karalabe/ssz/tests/testtypes/random"
+)
+
+// Tests that value trees decoded from a generated type re-encode and hash to
+// the same as the original, both as a whole and field by field.
+func TestValueRoundTrip(t *testing.T) {
+	tests := []struct {
+		object ssz.Object
+		schema *ssz.Schema
+	}{
+		{new(types.BitsStruct), schemaBitsStruct},
+		{new(types.AttesterSlashing), schemaAttesterSlashing},
+		{new(types.Deposit), schemaDeposit},
+		{new(types.ExecutionPayloadCapella), schemaExecutionPayloadCapella},
+		{new(types.BeaconState), schemaBeaconState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schema.Name, func(t *testing.T) {
+			random.Fill(rand.New(rand.NewSource(1)), tt.object)
+
+			blob := make([]byte, ssz.Size(tt.object))
+			if err := ssz.EncodeToBytes(blob, tt.object); err != nil {
+				t.Fatalf("failed to encode object: %v", err)
+			}
+			value, err := tt.schema.DecodeValue(blob)
+			if err != nil {
+				t.Fatalf("failed to decode value tree: %v", err)
+			}
+			if value.Kind() != ssz.ValueContainer || value.Schema() != tt.schema {
+				t.Fatalf("root kind mismatch: have %v/%v, want %v/%v", value.Kind(), value.Schema(), ssz.ValueContainer, tt.schema)
+			}
+			enc, err := value.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode value tree: %v", err)
+			}
+			if !bytes.Equal(enc, blob) {
+				t.Errorf("re-encoded value tree mismatch")
+			}
+			if size, err := value.Size(); err != nil || size != uint32(len(blob)) {
+				t.Errorf("size mismatch: have %d (%v), want %d", size, err, len(blob))
+			}
+			if have, want := value.Hash(), ssz.HashSequential(tt.object); have != want {
+				t.Errorf("hash mismatch: have %#x, want %#x", have, want)
+			}
+			// Ensure individual fields hash the same as in the Go object
+			obj := reflect.ValueOf(tt.object).Elem()
+			for i, field := range tt.schema.Fields {
+				sub, ok := obj.FieldByName(field.Name).Interface().(ssz.Object)
+				if !ok {
+					continue
+				}
+				if have, want := value.Index(i).Hash(), ssz.HashSequential(sub); have != want {
+					t.Errorf("field %s hash mismatch: have %#x, want %#x", field.Name, have, want)
+				}
+			}
+		})
+	}
+}
+
+// Tests navigating and mutating a value tree.
+func TestValueMutation(t *testing.T) {
+	obj := new(types.ExecutionPayloadCapella)
+	obj.BaseFeePerGas = new(uint256.Int)
+
+	value, err := schemaExecutionPayloadCapella.NewValue()
+	if err != nil {
+		t.Fatalf("failed to create value tree: %v", err)
+	}
+	if have, want := value.Hash(), ssz.HashSequential(obj); have != want {
+		t.Fatalf("zero hash mismatch: have %#x, want %#x", have, want)
+	}
+	// Mutate some leaves and lists in both the object and the value tree
+	obj.GasLimit = 30_000_000
+	if err := value.Field("GasLimit").SetUint(30_000_000); err != nil {
+		t.Fatalf("failed to set gas limit: %v", err)
+	}
+	obj.BaseFeePerGas = uint256.NewInt(7)
+	if err := value.Field("BaseFeePerGas").SetUint256(uint256.NewInt(7)); err != nil {
+		t.Fatalf("failed to set base fee: %v", err)
+	}
+	obj.ExtraData = []byte("ssz")
+	if err := value.Field("ExtraData").SetBytes([]byte("ssz")); err != nil {
+		t.Fatalf("failed to set extra data: %v", err)
+	}
+	obj.Transactions = [][]byte{{0x01}, {}}
+	txs := value.Field("Transactions")
+	if err := txs.SetLen(2); err != nil {
+		t.Fatalf("failed to resize transactions: %v", err)
+	}
+	if err := txs.Index(0).SetBytes([]byte{0x01}); err != nil {
+		t.Fatalf("failed to set transaction: %v", err)
+	}
+	obj.Withdrawals = []*types.Withdrawal{{Index: 1, Amount: 2}}
+	withdrawal, err := value.Field("Withdrawals").Append()
+	if err != nil {
+		t.Fatalf("failed to append withdrawal: %v", err)
+	}
+	withdrawal.Field("Index").SetUint(1)
+	withdrawal.Field("Amount").SetUint(2)
+
+	if have, want := value.Hash(), ssz.HashSequential(obj); have != want {
+		t.Errorf("mutated hash mismatch: have %#x, want %#x", have, want)
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	if enc, err := value.Encode(); err != nil || !bytes.Equal(enc, blob) {
+		t.Errorf("mutated encoding mismatch: %v", err)
+	}
+	// Check some getters and the encoding of sub-nodes
+	if have := value.Field("GasLimit").Uint(); have != 30_000_000 {
+		t.Errorf("gas limit mismatch: have %d, want %d", have, 30_000_000)
+	}
+	if have := value.Field("BaseFeePerGas").Uint256(); !have.Eq(uint256.NewInt(7)) {
+		t.Errorf("base fee mismatch: have %v, want %v", have, 7)
+	}
+	if have := value.Field("Withdrawals").Len(); have != 1 {
+		t.Errorf("withdrawal count mismatch: have %d, want %d", have, 1)
+	}
+	if enc, err := withdrawal.Encode(); err != nil || !bytes.Equal(enc, blob[len(blob)-44:]) {
+		t.Errorf("withdrawal encoding mismatch: have %x (%v), want %x", enc, err, blob[len(blob)-44:])
+	}
+	if enc, err := value.Field("ExtraData").Encode(); err != nil || string(enc) != "ssz" {
+		t.Errorf("extra data encoding mismatch: have %q (%v), want %q", enc, err, "ssz")
+	}
+	// Check that invalid mutations are rejected
+	invalid := []error{
+		value.Field("GasLimit").SetBool(true),
+		value.Field("GasLimit").SetBytes(nil),
+		value.Field("ParentHash").SetBytes(make([]byte, 31)),
+		value.Field("ExtraData").SetBytes(make([]byte, 33)),
+		value.Field("Withdrawals").SetLen(17),
+		value.Field("LogsBloom").SetLen(1),
+	}
+	for i, err := range invalid {
+		if !errors.Is(err, ssz.ErrSchemaMismatch) {
+			t.Errorf("invalid mutation %d: error mismatch: have %v, want %v", i, err, ssz.ErrSchemaMismatch)
+		}
+	}
+	if value.Field("Unknown") != nil || value.Field("GasLimit").Field("Unknown") != nil || txs.Index(2) != nil {
+		t.Errorf("navigation into missing nodes succeeded")
+	}
+}
+
+// Tests that the integer and bitfield setters enforce the node's constraints.
+func TestValueLeafChecks(t *testing.T) {
+	schema := &ssz.Schema{Name: "Leaves", Fields: []ssz.SchemaField{
+		{Name: "U8", Kind: ssz.FieldUint8},
+		{Name: "U16", Kind: ssz.FieldUint16},
+		{Name: "U32", Kind: ssz.FieldUint32},
+		{Name: "Vector", Kind: ssz.FieldArrayOfBits, Size: 4},
+		{Name: "List", Kind: ssz.FieldSliceOfBits, MaxItems: 4},
+	}}
+	value, err := schema.NewValue()
+	if err != nil {
+		t.Fatalf("failed to create value tree: %v", err)
+	}
+	checks := []struct {
+		err  error
+		fail bool
+	}{
+		{value.Field("U8").SetUint(255), false},
+		{value.Field("U8").SetUint(256), true},
+		{value.Field("U16").SetUint(65536), true},
+		{value.Field("U32").SetUint(1 << 32), true},
+		{value.Field("U32").SetUint256(new(uint256.Int).Lsh(uint256.NewInt(1), 64)), true},
+		{value.Field("Vector").SetBytes([]byte{0x0f}), false},
+		{value.Field("Vector").SetBytes([]byte{0x1f}), true},
+		{value.Field("List").SetBytes([]byte{0x1f}), false},
+		{value.Field("List").SetBytes([]byte{0x3f}), true},
+		{value.Field("List").SetBytes([]byte{0x00}), true},
+	}
+	for i, check := range checks {
+		if (check.err != nil) != check.fail {
+			t.Errorf("check %d: error mismatch: have %v, want failure %v", i, check.err, check.fail)
+		}
+	}
+	// Ensure the zero bitlist is a valid empty one
+	zero, _ := schema.NewValue()
+	if have := zero.Field("List").Bytes(); !bytes.Equal(have, []byte{0x01}) {
+		t.Errorf("zero bitlist mismatch: have %x, want %x", have, []byte{0x01})
+	}
+	blob, err := zero.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode zero value: %v", err)
+	}
+	if _, err := schema.DecodeValue(blob); err != nil {
+		t.Errorf("failed to decode zero value: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+// FieldTracer is an optional callback invoked by the decoder for every field it
+// starts decoding, before the field's data is actually parsed. It can be used
+// to dump annotated views of SSZ blobs, or to pinpoint where a malformed input
+// goes wrong (the last reported field before an error is the culprit).
+//
+// The path contains the index of the field within its parent object for every
+// nesting level, items in lists being indexed by their position. The offset is
+// the absolute position of the field's data within the input and size is the
+// number of bytes it spans.
+//
+// The path slice is reused between invocations and must not be retained.
+type FieldTracer func(path []int, offset uint32, size uint32)
+
+// traceFrame is the tracing state of a single object (or list) being decoded.
+type traceFrame struct {
+	field int // Index of the field currently being decoded (-1 before the first)
+	queue int // Start of this frame's section in the pending dynamic field queue
+	next  int // Next entry in the pending dynamic field queue to be decoded
+}
+
+// traceReset prepares the decoder's tracer for a new decoding run.
+func (dec *Decoder) traceReset() {
+	dec.traceFrames = append(dec.traceFrames[:0], traceFrame{field: -1})
+	dec.traceQueue = dec.traceQueue[:0]
+}
+
+// traceStatic reports the next field of the current object as a static one of
+// the given size, positioned at the current decoding position.
+func (dec *Decoder) traceStatic(size uint32) {
+	if dec.tracer == nil || dec.err != nil {
+		return
+	}
+	dec.traceFrames[len(dec.traceFrames)-1].field++
+	dec.traceEmit(size)
+}
+
+// traceOffset steps over the next field of the current object as a dynamic one,
+// queueing its index up to be reported when its content is reached.
+func (dec *Decoder) traceOffset() {
+	if dec.tracer == nil || dec.err != nil {
+		return
+	}
+	frame := &dec.traceFrames[len(dec.traceFrames)-1]
+	frame.field++
+	dec.traceQueue = append(dec.traceQueue, frame.field)
+}
+
+// traceDynamic reports the content of the next queued up dynamic field, sized
+// based on the already decoded offsets.
+func (dec *Decoder) traceDynamic() {
+	if dec.tracer == nil || dec.err != nil {
+		return
+	}
+	frame := &dec.traceFrames[len(dec.traceFrames)-1]
+	if frame.next < len(dec.traceQueue) {
+		frame.field = dec.traceQueue[frame.next]
+		frame.next++
+	}
+	dec.traceEmit(dec.peekSize())
+}
+
+// traceDescend enters the last reported field, so that subsequent fields are
+// reported as its children.
+func (dec *Decoder) traceDescend() {
+	if dec.tracer == nil || dec.err != nil {
+		return
+	}
+	queue := len(dec.traceQueue)
+	dec.traceFrames = append(dec.traceFrames, traceFrame{field: -1, queue: queue, next: queue})
+}
+
+// traceAscend is the counterpart of traceDescend, restoring the parent object's
+// tracing state.
+func (dec *Decoder) traceAscend() {
+	if dec.tracer == nil || dec.err != nil {
+		return
+	}
+	frame := dec.traceFrames[len(dec.traceFrames)-1]
+	dec.traceQueue = dec.traceQueue[:frame.queue]
+	dec.traceFrames = dec.traceFrames[:len(dec.traceFrames)-1]
+}
+
+// traceEmit invokes the user's tracer with the current field path, position
+// and the given size.
+func (dec *Decoder) traceEmit(size uint32) {
+	dec.tracePath = dec.tracePath[:0]
+	for _, frame := range dec.traceFrames {
+		dec.tracePath = append(dec.tracePath, frame.field)
+	}
+	dec.tracer(dec.tracePath, dec.Consumed(), size)
+}
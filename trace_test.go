@@ -0,0 +1,70 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// Tests that the field tracer reports all the fields, with the correct paths,
+// offsets and sizes, both in buffered and streaming mode.
+func TestDecodeFieldTracer(t *testing.T) {
+	obj := &types.Attestation{
+		AggregationBits: bitfield.Bitlist{0x0f, 0x01},
+		Data: &types.AttestationData{
+			Source: new(types.Checkpoint),
+			Target: new(types.Checkpoint),
+		},
+	}
+	blob := make([]byte, ssz.Size(obj))
+	if err := ssz.EncodeToBytes(blob, obj); err != nil {
+		t.Fatalf("failed to encode object: %v", err)
+	}
+	type event struct {
+		path   string
+		offset uint32
+		size   uint32
+	}
+	want := []event{
+		{"[1]", 4, 128},
+		{"[1 0]", 4, 8},
+		{"[1 1]", 12, 8},
+		{"[1 2]", 20, 32},
+		{"[1 3]", 52, 40},
+		{"[1 3 0]", 52, 8},
+		{"[1 3 1]", 60, 32},
+		{"[1 4]", 92, 40},
+		{"[1 4 0]", 92, 8},
+		{"[1 4 1]", 100, 32},
+		{"[2]", 132, 96},
+		{"[0]", 228, 2},
+	}
+	var have []event
+	cfg := &ssz.DecoderConfig{
+		OnField: func(path []int, offset uint32, size uint32) {
+			have = append(have, event{fmt.Sprint(path), offset, size})
+		},
+	}
+	if err := ssz.DecodeFromBytesWithConfig(blob, new(types.Attestation), cfg); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("buffered trace mismatch:\nhave %v\nwant %v", have, want)
+	}
+	have = nil
+	if err := ssz.DecodeFromStreamWithConfig(bytes.NewReader(blob), new(types.Attestation), uint32(len(blob)), cfg); err != nil {
+		t.Fatalf("failed to decode object: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("streaming trace mismatch:\nhave %v\nwant %v", have, want)
+	}
+}
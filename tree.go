@@ -0,0 +1,723 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	bitops "math/bits"
+	"slices"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+)
+
+// TreeNode is an immutable node of a binary merkle tree. Modifying a tree creates
+// new nodes along the path to the root only, sharing all unchanged subtrees with
+// the original, so copies of large trees are free and rehashing a modified tree
+// only touches the modified paths.
+//
+// The hash of branch nodes is computed lazily and cached, so a tree can be freely
+// shared between goroutines.
+type TreeNode struct {
+	left  *TreeNode                // Left child of branch nodes, nil for leaves
+	right *TreeNode                // Right child of branch nodes, nil for leaves
+	root  atomic.Pointer[[32]byte] // Chunk of leaves, cached hash of branches
+}
+
+// treeZeroNodes is a pre-computed table of all-zero subtrees, shared by all the
+// trees to avoid creating (and hashing) empty subtrees over and over again.
+var treeZeroNodes [len(hasherZeroCache)]*TreeNode
+
+// treeZeroSet is the set of shared all-zero subtrees, used to avoid walking into
+// them when exporting trees.
+var treeZeroSet = make(map[*TreeNode]struct{}, len(treeZeroNodes))
+
+func init() {
+	treeZeroNodes[0] = NewTreeLeaf([32]byte{})
+	for i := 1; i < len(treeZeroNodes); i++ {
+		treeZeroNodes[i] = NewTreeBranch(treeZeroNodes[i-1], treeZeroNodes[i-1])
+		treeZeroNodes[i].root.Store(&hasherZeroCache[i])
+	}
+	for _, node := range treeZeroNodes {
+		treeZeroSet[node] = struct{}{}
+	}
+}
+
+// NewTreeLeaf creates a leaf node holding a single chunk.
+func NewTreeLeaf(chunk [32]byte) *TreeNode {
+	n := new(TreeNode)
+	n.root.Store(&chunk)
+	return n
+}
+
+// NewTreeBranch creates a branch node from two children.
+func NewTreeBranch(left, right *TreeNode) *TreeNode {
+	return &TreeNode{left: left, right: right}
+}
+
+// ZeroTreeNode returns the root of an all-zero subtree of the given depth.
+func ZeroTreeNode(depth int) *TreeNode {
+	return treeZeroNodes[depth]
+}
+
+// Leaf returns whether the node is a leaf (i.e. it has no children).
+func (n *TreeNode) Leaf() bool {
+	return n.left == nil
+}
+
+// Left returns the left child of a branch node, or nil for leaves.
+func (n *TreeNode) Left() *TreeNode {
+	return n.left
+}
+
+// Right returns the right child of a branch node, or nil for leaves.
+func (n *TreeNode) Right() *TreeNode {
+	return n.right
+}
+
+// Root returns the chunk of a leaf node, or the merkle root of a branch node.
+func (n *TreeNode) Root() [32]byte {
+	if root := n.root.Load(); root != nil {
+		return *root
+	}
+	var (
+		buf   [64]byte
+		left  = n.left.Root()
+		right = n.right.Root()
+	)
+	copy(buf[:32], left[:])
+	copy(buf[32:], right[:])
+
+	root := sha256.Sum256(buf[:])
+	n.root.Store(&root)
+	return root
+}
+
+// Get returns the node at a generalized index relative to this node.
+func (n *TreeNode) Get(gindex uint64) (*TreeNode, error) {
+	if gindex == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrTreeNodeNotFound, gindex)
+	}
+	node := n
+	for bit := bitops.Len64(gindex) - 2; bit >= 0; bit-- {
+		if node.left == nil {
+			return nil, fmt.Errorf("%w: %d", ErrTreeNodeNotFound, gindex)
+		}
+		if gindex>>bit&1 == 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return node, nil
+}
+
+// Set returns a new tree with the node at a generalized index relative to this
+// node replaced. The original tree is not modified, and all the subtrees not on
+// the path to the replaced node are shared between the two.
+func (n *TreeNode) Set(gindex uint64, node *TreeNode) (*TreeNode, error) {
+	if gindex == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrTreeNodeNotFound, gindex)
+	}
+	return n.set(gindex, bitops.Len64(gindex)-2, node)
+}
+
+// set is the recursive version of Set, descending the bits of the index.
+func (n *TreeNode) set(gindex uint64, bit int, node *TreeNode) (*TreeNode, error) {
+	if bit < 0 {
+		return node, nil
+	}
+	if n.left == nil {
+		return nil, fmt.Errorf("%w: %d", ErrTreeNodeNotFound, gindex)
+	}
+	if gindex>>bit&1 == 0 {
+		left, err := n.left.set(gindex, bit-1, node)
+		if err != nil {
+			return nil, err
+		}
+		return NewTreeBranch(left, n.right), nil
+	}
+	right, err := n.right.set(gindex, bit-1, node)
+	if err != nil {
+		return nil, err
+	}
+	return NewTreeBranch(n.left, right), nil
+}
+
+// Walk calls fn for every node of the tree in pre-order (parents before their
+// children, left before right), along with the node's generalized index relative
+// to this node. If fn returns an error, the walk is aborted and the error is
+// returned.
+//
+// The all-zero subtrees padding lists to their capacity are reported by their
+// roots only: their nodes are implied (see ZeroTreeNode), and walking them would
+// take forever for lists with large limits.
+func (n *TreeNode) Walk(fn func(gindex uint64, node *TreeNode) error) error {
+	return n.walk(1, fn)
+}
+
+// walk is the recursive version of Walk, tracking the generalized index.
+func (n *TreeNode) walk(gindex uint64, fn func(gindex uint64, node *TreeNode) error) error {
+	if err := fn(gindex, n); err != nil {
+		return err
+	}
+	if n.left == nil {
+		return nil
+	}
+	if _, ok := treeZeroSet[n]; ok {
+		return nil
+	}
+	if err := n.left.walk(2*gindex, fn); err != nil {
+		return err
+	}
+	return n.right.walk(2*gindex+1, fn)
+}
+
+// Prove creates a multiproof of the given nodes of the tree, addressed by their
+// generalized indices relative to this node.
+func (n *TreeNode) Prove(indices ...uint64) (*Multiproof, error) {
+	return ProveFromNodes(indices, func(gindex uint64) ([32]byte, error) {
+		node, err := n.Get(gindex)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		return node.Root(), nil
+	})
+}
+
+// newTreeNodes builds a balanced tree of the given depth from its leftmost
+// subtrees, padding it with zero subtrees.
+func newTreeNodes(nodes []*TreeNode, depth int) *TreeNode {
+	if len(nodes) == 0 {
+		return treeZeroNodes[depth]
+	}
+	for level := 0; level < depth; level++ {
+		next := make([]*TreeNode, (len(nodes)+1)/2)
+		for i := range next {
+			right := treeZeroNodes[level]
+			if 2*i+1 < len(nodes) {
+				right = nodes[2*i+1]
+			}
+			next[i] = NewTreeBranch(nodes[2*i], right)
+		}
+		nodes = next
+	}
+	return nodes[0]
+}
+
+// Tree is a tree-backed container of a runtime schema: instead of holding field
+// values, it holds the merkle tree of the container, so hashing it is free and
+// copying it is constant time, with copies sharing all unchanged subtrees.
+//
+// Fields are addressed by the same paths as GeneralizedIndex. A Tree is a mutable
+// handle over an immutable TreeNode, so it's not safe to modify the same Tree
+// concurrently, but its copies and nodes can be used from any goroutine.
+type Tree struct {
+	schema *Schema   // Schema of the container backed by the tree
+	root   *TreeNode // Root node of the backing tree
+}
+
+// NewTree creates a tree-backed zero container of the schema.
+func (s *Schema) NewTree() (*Tree, error) {
+	value, err := s.NewValue()
+	if err != nil {
+		return nil, err
+	}
+	return &Tree{schema: s, root: value.tree()}, nil
+}
+
+// DecodeTree parses a container of the schema from a byte buffer into a tree.
+func (s *Schema) DecodeTree(blob []byte) (*Tree, error) {
+	value, err := s.DecodeValue(blob)
+	if err != nil {
+		return nil, err
+	}
+	return &Tree{schema: s, root: value.tree()}, nil
+}
+
+// Copy creates an independent copy of the tree in constant time, sharing all the
+// nodes with the original until either is modified.
+func (t *Tree) Copy() *Tree {
+	return &Tree{schema: t.schema, root: t.root}
+}
+
+// Node returns the root node of the backing tree.
+func (t *Tree) Node() *TreeNode {
+	return t.root
+}
+
+// Hash returns the ssz merkle root of the container, only hashing the subtrees
+// modified since the last call.
+func (t *Tree) Hash() [32]byte {
+	return t.root.Root()
+}
+
+// Encode serializes the container backed by the tree.
+func (t *Tree) Encode() ([]byte, error) {
+	value, err := t.Value()
+	if err != nil {
+		return nil, err
+	}
+	return value.Encode()
+}
+
+// Prove creates a multiproof of the given nodes of the backing tree, addressed
+// by generalized indices (see GeneralizedIndex).
+func (t *Tree) Prove(indices ...uint64) (*Multiproof, error) {
+	return t.root.Prove(indices...)
+}
+
+// lookup resolves a path in the tree, additionally checking list item indices
+// against the current lengths of the lists.
+func (t *Tree) lookup(path []any) (uint64, SchemaField, int, error) {
+	gindex, field, offset, err := t.schema.resolve(path)
+	if err != nil {
+		return 0, field, 0, err
+	}
+	for i, elem := range path {
+		index, ok := elem.(int)
+		if !ok {
+			continue
+		}
+		if _, mixin := t.fieldAt(path[:i]).treeShape(); !mixin {
+			continue
+		}
+		size, err := t.Len(path[:i]...)
+		if err != nil {
+			return 0, field, 0, err
+		}
+		if uint64(index) >= size {
+			return 0, field, 0, fmt.Errorf("%w: %v: index %d out of bounds (length %d)", ErrSchemaMismatch, path[:i], index, size)
+		}
+	}
+	return gindex, field, offset, nil
+}
+
+// fieldAt returns the schema of the field at an already resolved path.
+func (t *Tree) fieldAt(path []any) SchemaField {
+	_, field, _, _ := t.schema.resolve(path)
+	return field
+}
+
+// Value returns the field at the path (or the whole container if no path is
+// given) as a standalone value tree.
+func (t *Tree) Value(path ...any) (*Value, error) {
+	gindex, field, offset, err := t.lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	node, err := t.root.Get(gindex)
+	if err != nil {
+		return nil, err
+	}
+	return treeValue(field, node, offset)
+}
+
+// Bool returns the value of the boolean field at the path.
+func (t *Tree) Bool(path ...any) (bool, error) {
+	value, err := t.Value(path...)
+	if err != nil {
+		return false, err
+	}
+	if value.kind != ValueBool {
+		return false, value.mismatch("not a boolean")
+	}
+	return value.Bool(), nil
+}
+
+// Uint returns the value of the unsigned integer field at the path, truncated
+// to 64 bits for 256 bit integers.
+func (t *Tree) Uint(path ...any) (uint64, error) {
+	value, err := t.Value(path...)
+	if err != nil {
+		return 0, err
+	}
+	if value.kind != ValueUint {
+		return 0, value.mismatch("not an unsigned integer")
+	}
+	return value.Uint(), nil
+}
+
+// Bytes returns the content of the binary blob or bitfield at the path.
+func (t *Tree) Bytes(path ...any) ([]byte, error) {
+	value, err := t.Value(path...)
+	if err != nil {
+		return nil, err
+	}
+	if value.kind != ValueBytes && value.kind != ValueBits {
+		return nil, value.mismatch("not a binary blob")
+	}
+	return value.Bytes(), nil
+}
+
+// Len returns the number of items of the list (or bytes of the dynamic blob or
+// bits of the bitlist) at the path.
+func (t *Tree) Len(path ...any) (uint64, error) {
+	gindex, _, _, err := t.schema.resolve(append(path[:len(path):len(path)], "__len__"))
+	if err != nil {
+		return 0, err
+	}
+	node, err := t.root.Get(gindex)
+	if err != nil {
+		return 0, err
+	}
+	chunk := node.Root()
+	return binary.LittleEndian.Uint64(chunk[:]), nil
+}
+
+// SetValue replaces the field at the path with the given value tree, which must
+// be of the same type as the field.
+func (t *Tree) SetValue(value *Value, path ...any) error {
+	gindex, field, offset, err := t.lookup(path)
+	if err != nil {
+		return err
+	}
+	if !field.compatible(value.field) {
+		return fmt.Errorf("%w: %s: incompatible value %s", ErrSchemaMismatch, field.Name, value.field.Name)
+	}
+	node := value.tree()
+	if last := len(path) - 1; last >= 0 && t.fieldAt(path[:last]).packed() {
+		// Items of uint64 lists are packed, merge the item into its chunk
+		old, err := t.root.Get(gindex)
+		if err != nil {
+			return err
+		}
+		chunk := old.Root()
+		binary.LittleEndian.PutUint64(chunk[offset:], value.leaf.(uint64))
+		node = NewTreeLeaf(chunk)
+	}
+	root, err := t.root.Set(gindex, node)
+	if err != nil {
+		return err
+	}
+	t.root = root
+	return nil
+}
+
+// SetBool sets the value of the boolean field at the path.
+func (t *Tree) SetBool(b bool, path ...any) error {
+	_, field, _, err := t.lookup(path)
+	if err != nil {
+		return err
+	}
+	value := newValue(field, nil)
+	if err := value.SetBool(b); err != nil {
+		return err
+	}
+	return t.SetValue(value, path...)
+}
+
+// SetUint sets the value of the unsigned integer field at the path, failing if
+// the value does not fit into the field's width.
+func (t *Tree) SetUint(n uint64, path ...any) error {
+	_, field, _, err := t.lookup(path)
+	if err != nil {
+		return err
+	}
+	value := newValue(field, nil)
+	if err := value.SetUint(n); err != nil {
+		return err
+	}
+	return t.SetValue(value, path...)
+}
+
+// SetBytes sets the content of the binary blob or bitfield at the path, failing
+// if the size or limit of the field is not satisfied.
+func (t *Tree) SetBytes(blob []byte, path ...any) error {
+	_, field, _, err := t.lookup(path)
+	if err != nil {
+		return err
+	}
+	value := newValue(field, nil)
+	if err := value.SetBytes(blob); err != nil {
+		return err
+	}
+	return t.SetValue(value, path...)
+}
+
+// SetLen resizes the list at the path, appending zero items or dropping items
+// from the end, failing if the limit of the list would be exceeded.
+func (t *Tree) SetLen(n uint64, path ...any) error {
+	gindex, field, _, err := t.lookup(path)
+	if err != nil {
+		return err
+	}
+	switch field.Kind {
+	case FieldSliceOfUint64s, FieldSliceOfStaticBytes, FieldSliceOfDynamicBytes,
+		FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects:
+	default:
+		return fmt.Errorf("%w: %s: not a list", ErrSchemaMismatch, field.Name)
+	}
+	if n > field.MaxItems {
+		return fmt.Errorf("%w: %s: have %d items, max %d", ErrSchemaMismatch, field.Name, n, field.MaxItems)
+	}
+	size, err := t.Len(path...)
+	if err != nil {
+		return err
+	}
+	// Items beyond the length of lists are zero chunks, whereas the appended items
+	// need to be zero values, so update the data subtree first
+	limit, _ := field.treeShape()
+	depth := treeDepth(limit)
+
+	root := t.root
+	if field.Kind == FieldSliceOfUint64s {
+		// Packed items, zero out any dropped ones (appended are zero already)
+		for i := n; i < size; i++ {
+			node, err := root.Get(gindex<<1<<depth | i/4)
+			if err != nil {
+				return err
+			}
+			chunk := node.Root()
+			binary.LittleEndian.PutUint64(chunk[i%4*8:], 0)
+			if root, err = root.Set(gindex<<1<<depth|i/4, NewTreeLeaf(chunk)); err != nil {
+				return err
+			}
+		}
+	} else {
+		item := ZeroTreeNode(0)
+		if n > size {
+			item = newValue(newValue(field, nil).item(), nil).tree()
+		}
+		for i := min(n, size); i < max(n, size); i++ {
+			if root, err = root.Set(gindex<<1<<depth|i, item); err != nil {
+				return err
+			}
+		}
+	}
+	var chunk [32]byte
+	binary.LittleEndian.PutUint64(chunk[:], n)
+	if root, err = root.Set(gindex<<1|1, NewTreeLeaf(chunk)); err != nil {
+		return err
+	}
+	t.root = root
+	return nil
+}
+
+// compatible returns whether values of two fields have the same type, ignoring
+// their names.
+func (f SchemaField) compatible(other SchemaField) bool {
+	f.Name, other.Name = "", ""
+	return f == other
+}
+
+// tree builds the backing tree of a value node.
+func (v *Value) tree() *TreeNode {
+	switch v.kind {
+	case ValueBool, ValueUint:
+		return NewTreeLeaf(v.chunk())
+	}
+	limit, mixin := v.field.treeShape()
+
+	var nodes []*TreeNode
+	if v.field.packed() {
+		for _, chunk := range v.chunks() {
+			nodes = append(nodes, NewTreeLeaf(chunk))
+		}
+	} else {
+		nodes = make([]*TreeNode, len(v.items))
+		for i, item := range v.items {
+			nodes[i] = item.tree()
+		}
+	}
+	root := newTreeNodes(nodes, treeDepth(limit))
+	if mixin {
+		var chunk [32]byte
+		binary.LittleEndian.PutUint64(chunk[:], v.length())
+		root = NewTreeBranch(root, NewTreeLeaf(chunk))
+	}
+	return root
+}
+
+// chunk returns the chunk of a basic (boolean or unsigned integer) value node.
+func (v *Value) chunk() [32]byte {
+	var chunk [32]byte
+	switch n := v.leaf.(type) {
+	case bool:
+		if n {
+			chunk[0] = 1
+		}
+	case uint8:
+		chunk[0] = n
+	case uint16:
+		binary.LittleEndian.PutUint16(chunk[:], n)
+	case uint32:
+		binary.LittleEndian.PutUint32(chunk[:], n)
+	case uint64:
+		binary.LittleEndian.PutUint64(chunk[:], n)
+	case *uint256.Int:
+		for i := 0; i < 4; i++ {
+			binary.LittleEndian.PutUint64(chunk[8*i:], n[i])
+		}
+	case *big.Int:
+		n.FillBytes(chunk[:])
+		slices.Reverse(chunk[:])
+	}
+	return chunk
+}
+
+// treeValue reconstructs the value of a field from its backing tree. The offset
+// is the position of packed uint64 list items within their chunk.
+func treeValue(field SchemaField, node *TreeNode, offset int) (*Value, error) {
+	v := newValue(field, nil)
+
+	// Basic values are stored directly in a chunk
+	switch v.kind {
+	case ValueBool, ValueUint:
+		chunk := node.Root()
+		switch v.leaf.(type) {
+		case bool:
+			v.leaf = chunk[0] == 1
+		case uint8:
+			v.leaf = chunk[0]
+		case uint16:
+			v.leaf = binary.LittleEndian.Uint16(chunk[:])
+		case uint32:
+			v.leaf = binary.LittleEndian.Uint32(chunk[:])
+		case uint64:
+			v.leaf = binary.LittleEndian.Uint64(chunk[offset:])
+		case *uint256.Int:
+			n := new(uint256.Int)
+			for i := 0; i < 4; i++ {
+				n[i] = binary.LittleEndian.Uint64(chunk[8*i:])
+			}
+			v.leaf = n
+		case *big.Int:
+			slices.Reverse(chunk[:])
+			v.leaf = new(big.Int).SetBytes(chunk[:])
+		}
+		return v, nil
+	}
+	// Split off the length of lists and retrieve the number of chunks or items
+	limit, mixin := field.treeShape()
+
+	size := field.Size
+	if mixin {
+		if node.Leaf() {
+			return nil, fmt.Errorf("%w: %s: missing length mixin", ErrTreeNodeNotFound, field.Name)
+		}
+		chunk := node.Right().Root()
+		size, node = binary.LittleEndian.Uint64(chunk[:]), node.Left()
+
+		limit := field.MaxItems
+		if field.Kind == FieldDynamicBytes {
+			limit = field.MaxSize
+		}
+		if size > limit {
+			return nil, fmt.Errorf("%w: %s: length %d exceeds limit %d", ErrSchemaMismatch, field.Name, size, limit)
+		}
+	}
+	if v.kind == ValueContainer {
+		size = uint64(len(field.Schema.Fields))
+	}
+	depth := treeDepth(limit)
+
+	// Retrieve the packed content of blobs, bitfields and uint64 lists
+	if field.packed() {
+		bytes := size
+		switch field.Kind {
+		case FieldArrayOfBits, FieldSliceOfBits:
+			bytes = (size + 7) / 8
+		case FieldArrayOfUint64s, FieldSliceOfUint64s:
+			bytes = 8 * size
+		}
+		blob := make([]byte, 0, (bytes+31)/32*32)
+		for i := uint64(0); i < (bytes+31)/32; i++ {
+			chunk, err := node.Get(1<<depth | i)
+			if err != nil {
+				return nil, err
+			}
+			root := chunk.Root()
+			blob = append(blob, root[:]...)
+		}
+		blob = blob[:bytes]
+
+		switch {
+		case field.Kind == FieldSliceOfBits:
+			bits := make([]byte, size/8+1)
+			copy(bits, blob)
+			bits[size/8] |= 1 << (size % 8)
+			v.leaf = bits
+		case v.kind == ValueBytes || v.kind == ValueBits:
+			v.leaf = blob
+		default:
+			v.items = make([]*Value, size)
+			for i := range v.items {
+				v.items[i] = newValue(v.item(), binary.LittleEndian.Uint64(blob[8*i:]))
+			}
+		}
+		return v, nil
+	}
+	// Retrieve the items of composite vectors and lists, or fields of containers
+	v.items = make([]*Value, size)
+	for i := range v.items {
+		child, err := node.Get(1<<depth | uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		var item SchemaField
+		if v.kind == ValueContainer {
+			item = field.Schema.Fields[i]
+		} else {
+			item = v.item()
+		}
+		if v.items[i], err = treeValue(item, child, 0); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// treeRecorder assembles the merkle tree of an object from the chunks and layers
+// produced by the hasher, instead of hashing them on the fly.
+type treeRecorder struct {
+	layers   [][]*TreeNode // Subtrees of the layers being assembled, innermost last
+	subtrees []*TreeNode   // Stashed subtrees of progressive lists being assembled
+}
+
+// insert adds a subtree (or leaf) to the innermost layer.
+func (r *treeRecorder) insert(node *TreeNode) {
+	r.layers[len(r.layers)-1] = append(r.layers[len(r.layers)-1], node)
+}
+
+// descend starts a new layer.
+func (r *treeRecorder) descend() {
+	r.layers = append(r.layers, nil)
+}
+
+// ascend terminates the innermost layer, assembling its subtrees into a tree with
+// room for at least capacity leaves (0 == only balance) and adding it as a leaf
+// to the outer layer.
+func (r *treeRecorder) ascend(capacity uint64) {
+	nodes := r.layers[len(r.layers)-1]
+	r.layers = r.layers[:len(r.layers)-1]
+
+	depth := bitops.Len64(max(uint64(len(nodes)), capacity, 1) - 1)
+	r.insert(newTreeNodes(nodes, depth))
+}
+
+// stash moves the last subtree of the innermost layer away, to be chained into a
+// progressive tree later.
+func (r *treeRecorder) stash() {
+	layer := r.layers[len(r.layers)-1]
+	r.subtrees = append(r.subtrees, layer[len(layer)-1])
+	r.layers[len(r.layers)-1] = layer[:len(layer)-1]
+}
+
+// chain assembles the stashed subtrees since start into a progressive tree and
+// adds it to the innermost layer (unless the list is empty).
+func (r *treeRecorder) chain(start int, size uint64) {
+	if size > 0 {
+		rest := treeZeroNodes[0]
+		for i := len(r.subtrees) - 1; i >= start; i-- {
+			rest = NewTreeBranch(rest, r.subtrees[i])
+		}
+		r.insert(rest)
+	}
+	r.subtrees = r.subtrees[:start]
+}
@@ -0,0 +1,590 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	bitops "math/bits"
+	"slices"
+
+	"github.com/holiman/uint256"
+)
+
+// ValueKind is the type of a node in a Value tree.
+type ValueKind uint8
+
+const (
+	ValueBool      ValueKind = iota + 1 // Boolean leaf (FieldBool)
+	ValueUint                           // Unsigned integer leaf of 8 to 256 bits (FieldUint*)
+	ValueBytes                          // Static or dynamic binary blob leaf (Field*Bytes)
+	ValueBits                           // Bitvector or bitlist leaf (Field*OfBits)
+	ValueVector                         // Static list of uint64s or binary blobs (FieldArrayOf*)
+	ValueList                           // Dynamic list of uint64s, binary blobs or containers (FieldSliceOf*)
+	ValueContainer                      // Nested container (FieldStaticObject, FieldDynamicObject)
+)
+
+// Value is a node in a generic tree of ssz values, analogous to the generic
+// values of encoding/json, but typed by a runtime Schema. It allows tools to
+// navigate, inspect and modify arbitrary containers without any Go types, and
+// to encode or hash any node of the tree.
+//
+// Getters called on a node of the wrong kind return zero values, navigation on
+// the wrong kind returns nil, and setters return ErrSchemaMismatch. Mutations
+// are validated against the schema (sizes, limits, integer widths), so a tree
+// can always be encoded.
+type Value struct {
+	field SchemaField // Schema of the field the value is stored in
+	kind  ValueKind   // Node type derived from the field schema
+
+	leaf  any      // Value of leaf nodes, typed as in the value trees of Schema
+	items []*Value // Fields of containers, or items of vectors and lists
+}
+
+// NewValue creates a zero value tree of the schema.
+func (s *Schema) NewValue() (*Value, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return newValue(s.field(), nil), nil
+}
+
+// DecodeValue parses a container of the schema from a byte buffer into a value
+// tree.
+func (s *Schema) DecodeValue(blob []byte) (*Value, error) {
+	tree, err := s.Decode(blob)
+	if err != nil {
+		return nil, err
+	}
+	return newValue(s.field(), tree), nil
+}
+
+// field returns a synthetic field schema holding a container of the schema.
+func (s *Schema) field() SchemaField {
+	if s.static() {
+		return SchemaField{Name: s.Name, Kind: FieldStaticObject, Schema: s}
+	}
+	return SchemaField{Name: s.Name, Kind: FieldDynamicObject, Schema: s}
+}
+
+// newValue creates a value node of the given field schema from a value tree as
+// used by Schema (nil being the zero value).
+func newValue(field SchemaField, tree any) *Value {
+	v := &Value{field: field}
+	switch field.Kind {
+	case FieldBool:
+		v.kind, v.leaf = ValueBool, false
+	case FieldUint8:
+		v.kind, v.leaf = ValueUint, uint8(0)
+	case FieldUint16:
+		v.kind, v.leaf = ValueUint, uint16(0)
+	case FieldUint32:
+		v.kind, v.leaf = ValueUint, uint32(0)
+	case FieldUint64:
+		v.kind, v.leaf = ValueUint, uint64(0)
+	case FieldUint256:
+		v.kind, v.leaf = ValueUint, new(uint256.Int)
+	case FieldUint256BigInt:
+		v.kind, v.leaf = ValueUint, new(big.Int)
+	case FieldStaticBytes, FieldCheckedStaticBytes:
+		v.kind, v.leaf = ValueBytes, make([]byte, field.Size)
+	case FieldDynamicBytes:
+		v.kind, v.leaf = ValueBytes, []byte{}
+	case FieldArrayOfBits:
+		v.kind, v.leaf = ValueBits, make([]byte, (field.Size+7)/8)
+	case FieldSliceOfBits:
+		v.kind, v.leaf = ValueBits, []byte{0x01}
+	case FieldArrayOfUint64s, FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes:
+		v.kind = ValueVector
+		v.items = make([]*Value, field.Size)
+	case FieldSliceOfUint64s, FieldSliceOfStaticBytes, FieldSliceOfDynamicBytes,
+		FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects:
+		v.kind = ValueList
+	case FieldStaticObject, FieldDynamicObject:
+		v.kind = ValueContainer
+		v.items = make([]*Value, len(field.Schema.Fields))
+	}
+	// Fill in any leaf value from the tree
+	if v.leaf != nil {
+		if tree != nil {
+			v.leaf = tree
+		}
+		return v
+	}
+	// Fill in the items of vectors and lists
+	switch items := tree.(type) {
+	case []uint64:
+		v.items = make([]*Value, len(items))
+		for i, item := range items {
+			v.items[i] = newValue(v.item(), item)
+		}
+		return v
+	case [][]byte:
+		v.items = make([]*Value, len(items))
+		for i, item := range items {
+			v.items[i] = newValue(v.item(), item)
+		}
+		return v
+	case []map[string]any:
+		v.items = make([]*Value, len(items))
+		for i, item := range items {
+			v.items[i] = newValue(v.item(), item)
+		}
+		return v
+	}
+	// Fill in the fields of containers or the zero items of vectors
+	if v.kind == ValueContainer {
+		fields, _ := tree.(map[string]any)
+		for i, field := range field.Schema.Fields {
+			v.items[i] = newValue(field, fields[field.Name])
+		}
+		return v
+	}
+	for i := range v.items {
+		v.items[i] = newValue(v.item(), nil)
+	}
+	return v
+}
+
+// item returns the field schema of the items of a vector or list.
+func (v *Value) item() SchemaField {
+	switch v.field.Kind {
+	case FieldArrayOfUint64s, FieldSliceOfUint64s:
+		return SchemaField{Name: v.field.Name, Kind: FieldUint64}
+	case FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes, FieldSliceOfStaticBytes:
+		return SchemaField{Name: v.field.Name, Kind: FieldStaticBytes, Size: v.field.ItemSize}
+	case FieldSliceOfDynamicBytes:
+		return SchemaField{Name: v.field.Name, Kind: FieldDynamicBytes, MaxSize: v.field.MaxSize}
+	case FieldSliceOfStaticObjects:
+		return SchemaField{Name: v.field.Name, Kind: FieldStaticObject, Schema: v.field.Schema}
+	case FieldSliceOfDynamicObjects:
+		return SchemaField{Name: v.field.Name, Kind: FieldDynamicObject, Schema: v.field.Schema}
+	default:
+		panic(fmt.Sprintf("value of kind %d has no items", v.field.Kind))
+	}
+}
+
+// Kind returns the type of the value node.
+func (v *Value) Kind() ValueKind {
+	return v.kind
+}
+
+// Schema returns the schema of a container node, or nil for other kinds.
+func (v *Value) Schema() *Schema {
+	if v.kind != ValueContainer {
+		return nil
+	}
+	return v.field.Schema
+}
+
+// Len returns the number of fields of a container, the number of items of a
+// vector or list, or the number of bytes of a binary blob or bitfield.
+func (v *Value) Len() int {
+	if blob, ok := v.leaf.([]byte); ok {
+		return len(blob)
+	}
+	return len(v.items)
+}
+
+// Field returns the field of a container node with the given name, or nil if
+// the node is not a container or it has no such field.
+func (v *Value) Field(name string) *Value {
+	if v.kind != ValueContainer {
+		return nil
+	}
+	for i, field := range v.field.Schema.Fields {
+		if field.Name == name {
+			return v.items[i]
+		}
+	}
+	return nil
+}
+
+// Index returns the i-th item of a vector or list node (or the i-th field of a
+// container), or nil if the node has no such item.
+func (v *Value) Index(i int) *Value {
+	if i < 0 || i >= len(v.items) {
+		return nil
+	}
+	return v.items[i]
+}
+
+// Bool returns the value of a boolean node.
+func (v *Value) Bool() bool {
+	b, _ := v.leaf.(bool)
+	return b
+}
+
+// Uint returns the value of an unsigned integer node, truncated to 64 bits for
+// 256 bit integers.
+func (v *Value) Uint() uint64 {
+	switch n := v.leaf.(type) {
+	case uint8:
+		return uint64(n)
+	case uint16:
+		return uint64(n)
+	case uint32:
+		return uint64(n)
+	case uint64:
+		return n
+	case *uint256.Int:
+		return n.Uint64()
+	case *big.Int:
+		return n.Uint64()
+	default:
+		return 0
+	}
+}
+
+// Uint256 returns the value of an unsigned integer node of any width as a new
+// 256 bit integer.
+func (v *Value) Uint256() *uint256.Int {
+	switch n := v.leaf.(type) {
+	case *uint256.Int:
+		return new(uint256.Int).Set(n)
+	case *big.Int:
+		return uint256.MustFromBig(n)
+	default:
+		return uint256.NewInt(v.Uint())
+	}
+}
+
+// Bytes returns the content of a binary blob or bitfield node (bitlists with
+// their length bit). The returned slice must not be modified, use SetBytes.
+func (v *Value) Bytes() []byte {
+	blob, _ := v.leaf.([]byte)
+	return blob
+}
+
+// Interface returns the value node as a value tree as used by Schema (e.g. a
+// map[string]any for containers).
+func (v *Value) Interface() any {
+	if v.leaf != nil {
+		return v.leaf
+	}
+	switch v.field.Kind {
+	case FieldArrayOfUint64s, FieldSliceOfUint64s:
+		nums := make([]uint64, len(v.items))
+		for i, item := range v.items {
+			nums[i] = item.leaf.(uint64)
+		}
+		return nums
+	case FieldArrayOfStaticBytes, FieldCheckedArrayOfStaticBytes, FieldSliceOfStaticBytes, FieldSliceOfDynamicBytes:
+		blobs := make([][]byte, len(v.items))
+		for i, item := range v.items {
+			blobs[i] = item.leaf.([]byte)
+		}
+		return blobs
+	case FieldSliceOfStaticObjects, FieldSliceOfDynamicObjects:
+		objects := make([]map[string]any, len(v.items))
+		for i, item := range v.items {
+			objects[i] = item.Interface().(map[string]any)
+		}
+		return objects
+	default:
+		fields := make(map[string]any, len(v.items))
+		for i, field := range v.field.Schema.Fields {
+			fields[field.Name] = v.items[i].Interface()
+		}
+		return fields
+	}
+}
+
+// mismatch creates an error for a setter called on the wrong kind of node or
+// with a value not satisfying the schema.
+func (v *Value) mismatch(format string, args ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrSchemaMismatch, v.field.Name, fmt.Sprintf(format, args...))
+}
+
+// SetBool sets the value of a boolean node.
+func (v *Value) SetBool(b bool) error {
+	if v.kind != ValueBool {
+		return v.mismatch("not a boolean")
+	}
+	v.leaf = b
+	return nil
+}
+
+// SetUint sets the value of an unsigned integer node, failing if the value does
+// not fit into the node's width.
+func (v *Value) SetUint(n uint64) error {
+	switch v.leaf.(type) {
+	case uint8:
+		if n > math.MaxUint8 {
+			return v.mismatch("%d overflows uint8", n)
+		}
+		v.leaf = uint8(n)
+	case uint16:
+		if n > math.MaxUint16 {
+			return v.mismatch("%d overflows uint16", n)
+		}
+		v.leaf = uint16(n)
+	case uint32:
+		if n > math.MaxUint32 {
+			return v.mismatch("%d overflows uint32", n)
+		}
+		v.leaf = uint32(n)
+	case uint64:
+		v.leaf = n
+	case *uint256.Int:
+		v.leaf = uint256.NewInt(n)
+	case *big.Int:
+		v.leaf = new(big.Int).SetUint64(n)
+	default:
+		return v.mismatch("not an unsigned integer")
+	}
+	return nil
+}
+
+// SetUint256 sets the value of an unsigned integer node from a 256 bit integer,
+// failing if the value does not fit into the node's width.
+func (v *Value) SetUint256(n *uint256.Int) error {
+	switch v.leaf.(type) {
+	case *uint256.Int:
+		v.leaf = new(uint256.Int).Set(n)
+	case *big.Int:
+		v.leaf = n.ToBig()
+	default:
+		if !n.IsUint64() {
+			return v.mismatch("%s overflows uint64", n.Dec())
+		}
+		return v.SetUint(n.Uint64())
+	}
+	return nil
+}
+
+// SetBytes sets the content of a binary blob or bitfield node (bitlists with
+// their length bit), failing if the size or limit of the node is not satisfied.
+// The blob is copied.
+func (v *Value) SetBytes(blob []byte) error {
+	switch v.field.Kind {
+	case FieldStaticBytes, FieldCheckedStaticBytes:
+		if uint64(len(blob)) != v.field.Size {
+			return v.mismatch("have %d bytes, want %d", len(blob), v.field.Size)
+		}
+	case FieldDynamicBytes:
+		if uint64(len(blob)) > v.field.MaxSize {
+			return v.mismatch("have %d bytes, max %d", len(blob), v.field.MaxSize)
+		}
+	case FieldArrayOfBits:
+		if uint64(len(blob)) != (v.field.Size+7)/8 {
+			return v.mismatch("have %d bytes, want %d", len(blob), (v.field.Size+7)/8)
+		}
+		if v.field.Size%8 != 0 && blob[len(blob)-1]>>(v.field.Size%8) != 0 {
+			return v.mismatch("junk in bitvector unused bits")
+		}
+	case FieldSliceOfBits:
+		if len(blob) == 0 || blob[len(blob)-1] == 0 {
+			return v.mismatch("missing bitlist length bit")
+		}
+		if size := uint64(len(blob)-1)<<3 + uint64(bitops.Len8(blob[len(blob)-1])) - 1; size > v.field.MaxItems {
+			return v.mismatch("have %d bits, max %d", size, v.field.MaxItems)
+		}
+	default:
+		return v.mismatch("not a binary blob")
+	}
+	v.leaf = bytes.Clone(blob)
+	if v.leaf == nil {
+		v.leaf = []byte{}
+	}
+	return nil
+}
+
+// SetLen resizes a list node, appending zero items or dropping items from the
+// end, failing if the limit of the list would be exceeded.
+func (v *Value) SetLen(n int) error {
+	if v.kind != ValueList {
+		return v.mismatch("not a list")
+	}
+	if n < 0 || uint64(n) > v.field.MaxItems {
+		return v.mismatch("have %d items, max %d", n, v.field.MaxItems)
+	}
+	for len(v.items) < n {
+		v.items = append(v.items, newValue(v.item(), nil))
+	}
+	clear(v.items[n:])
+	v.items = v.items[:n]
+	return nil
+}
+
+// Append adds a zero item to the end of a list node and returns it, failing if
+// the limit of the list would be exceeded.
+func (v *Value) Append() (*Value, error) {
+	if err := v.SetLen(len(v.items) + 1); err != nil {
+		return nil, err
+	}
+	return v.items[len(v.items)-1], nil
+}
+
+// wrap returns a single field container schema holding the value node, which
+// encodes the same as the node (bar the offset of dynamic nodes) and has the
+// same merkle root.
+func (v *Value) wrap() (*Schema, map[string]any) {
+	field := v.field
+	field.Name = "value"
+	return &Schema{Name: v.field.Name, Fields: []SchemaField{field}}, map[string]any{"value": v.Interface()}
+}
+
+// Encode serializes the value node (e.g. a whole container, or just one of its
+// fields).
+func (v *Value) Encode() ([]byte, error) {
+	schema, tree := v.wrap()
+	blob, err := schema.Encode(tree)
+	if err != nil {
+		return nil, err
+	}
+	if v.field.Kind.dynamic() {
+		blob = blob[4:] // strip the offset of the wrapper container
+	}
+	return blob, nil
+}
+
+// Size returns the serialized size of the value node.
+func (v *Value) Size() (uint32, error) {
+	schema, tree := v.wrap()
+	size, err := schema.Size(tree)
+	if err != nil {
+		return 0, err
+	}
+	if v.field.Kind.dynamic() {
+		size -= 4 // strip the offset of the wrapper container
+	}
+	return size, nil
+}
+
+// Hash computes the ssz merkle root of the value node (e.g. a whole container,
+// or just one of its fields).
+func (v *Value) Hash() [32]byte {
+	schema, tree := v.wrap()
+	root, err := schema.Hash(tree)
+	if err != nil {
+		panic(err) // mutations are validated, can't happen
+	}
+	return root
+}
+
+// Prove creates a multiproof of the given nodes of the value node's hash tree,
+// addressed by generalized indices relative to the node (see GeneralizedIndex).
+func (v *Value) Prove(indices ...uint64) (*Multiproof, error) {
+	proof := &Multiproof{
+		Indices: slices.Clone(indices),
+		Leaves:  make([][32]byte, len(indices)),
+	}
+	for i, index := range indices {
+		node, ok := v.node(index)
+		if !ok {
+			return nil, v.mismatch("no node at generalized index %d", index)
+		}
+		proof.Leaves[i] = node
+	}
+	for _, index := range multiproofHelperIndices(indices) {
+		node, ok := v.node(index)
+		if !ok {
+			return nil, v.mismatch("no node at generalized index %d", index)
+		}
+		proof.Hashes = append(proof.Hashes, node)
+	}
+	return proof, nil
+}
+
+// node computes the node of the value's hash tree at a generalized index relative
+// to the value node, or returns false if the tree has no such node.
+func (v *Value) node(gindex uint64) ([32]byte, bool) {
+	if gindex == 0 {
+		return [32]byte{}, false
+	}
+	if gindex == 1 {
+		return v.Hash(), true
+	}
+	if v.kind == ValueBool || v.kind == ValueUint {
+		return [32]byte{}, false
+	}
+	// Split off the length mixin of lists, leaving the data subtree
+	limit, mixin := v.field.treeShape()
+	if mixin {
+		level := bitops.Len64(gindex) - 1
+		right := gindex>>(level-1)&1 == 1
+
+		gindex = 1<<(level-1) | gindex&(1<<(level-1)-1)
+		if right {
+			if gindex != 1 {
+				return [32]byte{}, false
+			}
+			var chunk [32]byte
+			binary.LittleEndian.PutUint64(chunk[:], v.length())
+			return chunk, true
+		}
+	}
+	// If the node is within the data subtree, compute it from the chunks
+	depth := treeDepth(limit)
+	level := bitops.Len64(gindex) - 1
+	if level <= depth {
+		return treeNode(v.chunks(), depth, gindex), true
+	}
+	// Node below a chunk, descend into the item if it's not a packed one
+	if v.field.packed() {
+		return [32]byte{}, false
+	}
+	shift := level - depth
+	index := gindex>>shift - 1<<depth
+	if index >= uint64(len(v.items)) {
+		return [32]byte{}, false
+	}
+	return v.items[index].node(1<<shift | gindex&(1<<shift-1))
+}
+
+// length returns the length mixed into the hash tree root of a list node.
+func (v *Value) length() uint64 {
+	switch v.kind {
+	case ValueBytes:
+		return uint64(len(v.leaf.([]byte)))
+	case ValueBits:
+		bits := v.leaf.([]byte)
+		return uint64(len(bits)-1)<<3 + uint64(bitops.Len8(bits[len(bits)-1])) - 1
+	default:
+		return uint64(len(v.items))
+	}
+}
+
+// chunks returns the leaf chunks of the data subtree of a non-basic value node.
+func (v *Value) chunks() [][32]byte {
+	switch v.kind {
+	case ValueBytes:
+		return packChunks(v.leaf.([]byte))
+	case ValueBits:
+		bits := v.leaf.([]byte)
+		if v.field.Kind == FieldSliceOfBits {
+			bits = bytes.Clone(bits)
+			bits[len(bits)-1] &^= 1 << (bitops.Len8(bits[len(bits)-1]) - 1)
+		}
+		return packChunks(bits)
+	}
+	if v.field.packed() {
+		blob := make([]byte, 8*len(v.items))
+		for i, item := range v.items {
+			binary.LittleEndian.PutUint64(blob[8*i:], item.leaf.(uint64))
+		}
+		return packChunks(blob)
+	}
+	chunks := make([][32]byte, len(v.items))
+	for i, item := range v.items {
+		// Basic values and small binary blobs are their own chunks, skip hashing
+		if item.kind == ValueBool || item.kind == ValueUint {
+			chunks[i] = item.chunk()
+			continue
+		}
+		if blob, ok := item.leaf.([]byte); ok && len(blob) <= 32 {
+			if _, mixin := item.field.treeShape(); !mixin {
+				copy(chunks[i][:], blob)
+				continue
+			}
+		}
+		chunks[i] = item.Hash()
+	}
+	return chunks
+}
@@ -0,0 +1,21 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: d3986ebed861b3c631a2b08ce9f1b94c337a4d78a82ae0bc2188998ea0a33df3
+
+package vectors
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Basics) SizeSSZ() uint32 {
+	return 1 + 1 + 2 + 4 + 8 + 32
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Basics) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineBool(codec, &obj.Bool)       // Field  (0) -    Bool -  1 bytes
+	ssz.DefineUint8(codec, &obj.Uint8)     // Field  (1) -   Uint8 -  1 bytes
+	ssz.DefineUint16(codec, &obj.Uint16)   // Field  (2) -  Uint16 -  2 bytes
+	ssz.DefineUint32(codec, &obj.Uint32)   // Field  (3) -  Uint32 -  4 bytes
+	ssz.DefineUint64(codec, &obj.Uint64)   // Field  (4) -  Uint64 -  8 bytes
+	ssz.DefineUint256(codec, &obj.Uint256) // Field  (5) - Uint256 - 32 bytes
+}
@@ -0,0 +1,43 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 4291fe84941d6f7a654b45224c6fb1fc08432bc21feab85f0200f8ffbbcd1a87
+
+package vectors
+
+import "github.com/karalabe/ssz"
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *Lists) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(4 + 4 + 4 + 4 + 4 + 4 + 8)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicBytes(obj.Bytes)
+	size += ssz.SizeSliceOfUint64s(obj.Uint64s)
+	size += ssz.SizeSliceOfStaticBytes(obj.Roots)
+	size += ssz.SizeSliceOfBits(obj.Bits)
+	size += ssz.SizeSliceOfDynamicBytes(obj.Blobs)
+	size += ssz.SizeSliceOfStaticObjects(obj.Statics)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Lists) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineDynamicBytesOffset(codec, &obj.Bytes, 32)          // Offset (0) -   Bytes - 4 bytes
+	ssz.DefineSliceOfUint64sOffset(codec, &obj.Uint64s, 4)       // Offset (1) - Uint64s - 4 bytes
+	ssz.DefineSliceOfStaticBytesOffset(codec, &obj.Roots, 3)     // Offset (2) -   Roots - 4 bytes
+	ssz.DefineSliceOfBitsOffset(codec, &obj.Bits, 12)            // Offset (3) -    Bits - 4 bytes
+	ssz.DefineSliceOfDynamicBytesOffset(codec, &obj.Blobs, 3, 8) // Offset (4) -   Blobs - 4 bytes
+	ssz.DefineSliceOfStaticObjectsOffset(codec, &obj.Statics, 2) // Offset (5) - Statics - 4 bytes
+	ssz.DefineUint64(codec, &obj.Tail)                           // Field  (6) -    Tail - 8 bytes
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicBytesContent(codec, &obj.Bytes, 32)          // Field  (0) -   Bytes - ? bytes
+	ssz.DefineSliceOfUint64sContent(codec, &obj.Uint64s, 4)       // Field  (1) - Uint64s - ? bytes
+	ssz.DefineSliceOfStaticBytesContent(codec, &obj.Roots, 3)     // Field  (2) -   Roots - ? bytes
+	ssz.DefineSliceOfBitsContent(codec, &obj.Bits, 12)            // Field  (3) -    Bits - ? bytes
+	ssz.DefineSliceOfDynamicBytesContent(codec, &obj.Blobs, 3, 8) // Field  (4) -   Blobs - ? bytes
+	ssz.DefineSliceOfStaticObjectsContent(codec, &obj.Statics, 2) // Field  (5) - Statics - ? bytes
+}
@@ -0,0 +1,35 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 2055edee1ad92238af9c537a8024b04e3542933dbfa8a4f7a233d2e8440a2007
+
+package vectors
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheNested = 2 + 4 + 4 + (*Basics)(nil).SizeSSZ()
+
+// SizeSSZ returns either the static size of the object if fixed == true, or
+// the total size otherwise.
+func (obj *Nested) SizeSSZ(fixed bool) uint32 {
+	var size = uint32(staticSizeCacheNested)
+	if fixed {
+		return size
+	}
+	size += ssz.SizeDynamicObject(obj.Inner)
+	size += ssz.SizeSliceOfDynamicObjects(obj.Items)
+
+	return size
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Nested) DefineSSZ(codec *ssz.Codec) {
+	// Define the static data (fields and dynamic offsets)
+	ssz.DefineUint16(codec, &obj.Head)                          // Field  (0) -  Head - 2 bytes
+	ssz.DefineDynamicObjectOffset(codec, &obj.Inner)            // Offset (1) - Inner - 4 bytes
+	ssz.DefineSliceOfDynamicObjectsOffset(codec, &obj.Items, 3) // Offset (2) - Items - 4 bytes
+	ssz.DefineStaticObject(codec, &obj.Tail)                    // Field  (3) -  Tail - ? bytes (Basics)
+
+	// Define the dynamic data (fields)
+	ssz.DefineDynamicObjectContent(codec, &obj.Inner)            // Field  (1) - Inner - ? bytes
+	ssz.DefineSliceOfDynamicObjectsContent(codec, &obj.Items, 3) // Field  (2) - Items - ? bytes
+}
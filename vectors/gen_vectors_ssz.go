@@ -0,0 +1,23 @@
+// Code generated by github.com/karalabe/ssz. DO NOT EDIT.
+// Schema hash: 266d53b9be60cfc7ae0d7ed8e4b66df6fa857946028a11ca2b49d50c90ebfa93
+
+package vectors
+
+import "github.com/karalabe/ssz"
+
+// Cached static size computed on package init.
+var staticSizeCacheVectors = 4 + 32 + 8*32 + 1 + (*Basics)(nil).SizeSSZ()
+
+// SizeSSZ returns the total size of the static ssz object.
+func (obj *Vectors) SizeSSZ() uint32 {
+	return staticSizeCacheVectors
+}
+
+// DefineSSZ defines how an object is encoded/decoded.
+func (obj *Vectors) DefineSSZ(codec *ssz.Codec) {
+	ssz.DefineStaticBytes(codec, &obj.Bytes4)               // Field  (0) -  Bytes4 -   4 bytes
+	ssz.DefineStaticBytes(codec, &obj.Bytes32)              // Field  (1) - Bytes32 -  32 bytes
+	ssz.DefineUnsafeArrayOfStaticBytes(codec, obj.Roots[:]) // Field  (2) -   Roots - 256 bytes
+	ssz.DefineArrayOfBits(codec, &obj.Bits, 5)              // Field  (3) -    Bits -   1 bytes
+	ssz.DefineStaticObject(codec, &obj.Basics)              // Field  (4) -  Basics -   ? bytes (Basics)
+}
@@ -0,0 +1,54 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package vectors
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz/bitfield"
+)
+
+//go:generate go run -cover ../cmd/sszgen -type Basics -out gen_basics_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type Vectors -out gen_vectors_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type Lists -out gen_lists_ssz.go
+//go:generate go run -cover ../cmd/sszgen -type Nested -out gen_nested_ssz.go
+
+// Basics is a static container with one field of every basic type.
+type Basics struct {
+	Bool    bool
+	Uint8   uint8
+	Uint16  uint16
+	Uint32  uint32
+	Uint64  uint64
+	Uint256 *uint256.Int
+}
+
+// Vectors is a static container with fixed size composite fields.
+type Vectors struct {
+	Bytes4  [4]byte
+	Bytes32 [32]byte
+	Roots   [8][32]byte
+	Bits    [1]byte `ssz-size:"5" ssz:"bits"`
+	Basics  *Basics
+}
+
+// Lists is a dynamic container with bounded list fields. The limits are small
+// so that max-size lists are easy to construct.
+type Lists struct {
+	Bytes   []byte           `ssz-max:"32"`
+	Uint64s []uint64         `ssz-max:"4"`
+	Roots   [][32]byte       `ssz-max:"3"`
+	Bits    bitfield.Bitlist `ssz-max:"12"`
+	Blobs   [][]byte         `ssz-max:"3,8"`
+	Statics []*Vectors       `ssz-max:"2"`
+	Tail    uint64
+}
+
+// Nested is a dynamic container with dynamic fields nested into each other.
+type Nested struct {
+	Head  uint16
+	Inner *Lists
+	Items []*Lists `ssz-max:"3"`
+	Tail  *Basics
+}
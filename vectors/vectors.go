@@ -0,0 +1,238 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package vectors contains golden SSZ encodings and merkle roots of a set of
+// representative synthetic containers (basic types, nested dynamic objects,
+// empty and max-size lists), which are guaranteed to remain stable across the
+// releases of this library.
+//
+// Downstream implementations can use them to validate their wire compatibility
+// with this library: Go projects by importing the package and calling Load,
+// others by downloading the vectors.json file, which carries the definitions of
+// the containers (in the spec's notation) along with the hex encoded vectors.
+package vectors
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/holiman/uint256"
+	"github.com/karalabe/ssz"
+	"github.com/karalabe/ssz/bitfield"
+)
+
+// vectorsJSON is the golden vectors file, shipped along with the package.
+//
+//go:embed vectors.json
+var vectorsJSON []byte
+
+// Vector is a golden test case: the encoding of an object of one of the package's
+// containers, along with its merkle root.
+type Vector struct {
+	Name string   // Unique name of the test case
+	Type string   // Name of the container type of the object (e.g. "Nested")
+	SSZ  []byte   // Expected SSZ encoding of the object
+	Root [32]byte // Expected merkle root of the object
+}
+
+// New creates an empty object of the vector's container type to decode into.
+func (v *Vector) New() ssz.Object {
+	return newObject(v.Type)
+}
+
+// Load parses the golden test vectors shipped with the package.
+func Load() ([]*Vector, error) {
+	var file vectorsFile
+	if err := json.Unmarshal(vectorsJSON, &file); err != nil {
+		return nil, err
+	}
+	vectors := make([]*Vector, 0, len(file.Vectors))
+	for _, entry := range file.Vectors {
+		if newObject(entry.Type) == nil {
+			return nil, fmt.Errorf("vector %s: unknown type %q", entry.Name, entry.Type)
+		}
+		blob, err := hex.DecodeString(strings.TrimPrefix(entry.SSZ, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("vector %s: invalid encoding: %v", entry.Name, err)
+		}
+		root, err := hex.DecodeString(strings.TrimPrefix(entry.Root, "0x"))
+		if err != nil || len(root) != 32 {
+			return nil, fmt.Errorf("vector %s: invalid root %q", entry.Name, entry.Root)
+		}
+		vectors = append(vectors, &Vector{
+			Name: entry.Name,
+			Type: entry.Type,
+			SSZ:  blob,
+			Root: [32]byte(root),
+		})
+	}
+	return vectors, nil
+}
+
+// JSON returns the raw golden vectors file, as shipped with the package.
+func JSON() []byte {
+	return vectorsJSON
+}
+
+// vectorsFile is the layout of the golden vectors file.
+type vectorsFile struct {
+	Types   map[string]string `json:"types"`
+	Vectors []vectorsEntry    `json:"vectors"`
+}
+
+// vectorsEntry is the layout of a single test case in the golden vectors file.
+type vectorsEntry struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	SSZ  string `json:"ssz"`
+	Root string `json:"root"`
+}
+
+// typeDefinitions are the definitions of the package's containers in the spec's
+// notation, included in the golden vectors file for non-Go implementations.
+var typeDefinitions = map[string]string{
+	"Basics":  "Container(Bool: boolean, Uint8: uint8, Uint16: uint16, Uint32: uint32, Uint64: uint64, Uint256: uint256)",
+	"Vectors": "Container(Bytes4: Vector[byte, 4], Bytes32: Vector[byte, 32], Roots: Vector[Vector[byte, 32], 8], Bits: Bitvector[5], Basics: Basics)",
+	"Lists":   "Container(Bytes: List[byte, 32], Uint64s: List[uint64, 4], Roots: List[Vector[byte, 32], 3], Bits: Bitlist[12], Blobs: List[List[byte, 8], 3], Statics: List[Vectors, 2], Tail: uint64)",
+	"Nested":  "Container(Head: uint16, Inner: Lists, Items: List[Lists, 3], Tail: Basics)",
+}
+
+// newObject creates an empty object of a container type by name, or nil if the
+// type is unknown.
+func newObject(typ string) ssz.Object {
+	switch typ {
+	case "Basics":
+		return new(Basics)
+	case "Vectors":
+		return new(Vectors)
+	case "Lists":
+		return new(Lists)
+	case "Nested":
+		return new(Nested)
+	default:
+		return nil
+	}
+}
+
+// cases are the objects the golden vectors are generated from. New cases may be
+// appended, but existing ones must never be changed.
+var cases = []struct {
+	name string
+	obj  func() ssz.Object
+}{
+	{"basics_zero", func() ssz.Object { return newBasics(0) }},
+	{"basics_max", func() ssz.Object { return newBasicsMax() }},
+	{"vectors_zero", func() ssz.Object { return newVectors(0) }},
+	{"vectors_filled", func() ssz.Object { return newVectors(1) }},
+	{"lists_empty", func() ssz.Object { return newListsEmpty() }},
+	{"lists_partial", func() ssz.Object { return newListsPartial() }},
+	{"lists_max", func() ssz.Object { return newListsMax() }},
+	{"nested_empty", func() ssz.Object {
+		return &Nested{Inner: newListsEmpty(), Tail: newBasics(0)}
+	}},
+	{"nested_full", func() ssz.Object {
+		return &Nested{
+			Head:  0xbeef,
+			Inner: newListsMax(),
+			Items: []*Lists{newListsEmpty(), newListsPartial(), newListsMax()},
+			Tail:  newBasicsMax(),
+		}
+	}},
+}
+
+// newBasics creates a container of basic types, deterministically filled from
+// the given seed.
+func newBasics(seed uint64) *Basics {
+	return &Basics{
+		Bool:    seed%2 == 1,
+		Uint8:   uint8(seed * 3),
+		Uint16:  uint16(seed * 0x0305),
+		Uint32:  uint32(seed * 0x03050709),
+		Uint64:  seed * 0x030507090b0d0f11,
+		Uint256: new(uint256.Int).Mul(uint256.NewInt(seed), uint256.NewInt(0x030507090b0d0f11)),
+	}
+}
+
+// newBasicsMax creates a container of basic types, all set to their maximum.
+func newBasicsMax() *Basics {
+	return &Basics{
+		Bool:    true,
+		Uint8:   math.MaxUint8,
+		Uint16:  math.MaxUint16,
+		Uint32:  math.MaxUint32,
+		Uint64:  math.MaxUint64,
+		Uint256: new(uint256.Int).SetAllOne(),
+	}
+}
+
+// newVectors creates a container of fixed size composites, deterministically
+// filled from the given seed.
+func newVectors(seed byte) *Vectors {
+	obj := &Vectors{Basics: newBasics(uint64(seed))}
+	if seed == 0 {
+		return obj
+	}
+	for i := range obj.Bytes4 {
+		obj.Bytes4[i] = seed + byte(i)
+	}
+	for i := range obj.Bytes32 {
+		obj.Bytes32[i] = seed * byte(i)
+	}
+	for i := range obj.Roots {
+		obj.Roots[i][0], obj.Roots[i][31] = seed, byte(i)
+	}
+	obj.Bits[0] = 0x15 // 10101
+	return obj
+}
+
+// newListsEmpty creates a container of lists, all of them empty.
+func newListsEmpty() *Lists {
+	return &Lists{Bits: bitfield.NewBitlist(0)}
+}
+
+// newListsPartial creates a container of lists, all of them partially filled.
+func newListsPartial() *Lists {
+	bits := bitfield.NewBitlist(5)
+	bits.SetBitAt(1, true)
+	bits.SetBitAt(4, true)
+
+	return &Lists{
+		Bytes:   []byte{0x01, 0x02, 0x03},
+		Uint64s: []uint64{1, 1 << 32},
+		Roots:   [][32]byte{{0xaa}},
+		Bits:    bits,
+		Blobs:   [][]byte{{}, {0x01, 0x02}},
+		Statics: []*Vectors{newVectors(2)},
+		Tail:    42,
+	}
+}
+
+// newListsMax creates a container of lists, all of them filled to their limits.
+func newListsMax() *Lists {
+	obj := &Lists{
+		Bytes:   make([]byte, 32),
+		Uint64s: []uint64{0, 1, math.MaxUint32, math.MaxUint64},
+		Roots:   [][32]byte{{0x01}, {0x02}, {0x03}},
+		Bits:    bitfield.NewBitlist(12),
+		Blobs:   [][]byte{make([]byte, 8), make([]byte, 8), make([]byte, 8)},
+		Statics: []*Vectors{newVectors(3), newVectors(4)},
+		Tail:    math.MaxUint64,
+	}
+	for i := range obj.Bytes {
+		obj.Bytes[i] = byte(i)
+	}
+	for i := uint64(0); i < 12; i += 3 {
+		obj.Bits.SetBitAt(i, true)
+	}
+	for i, blob := range obj.Blobs {
+		for j := range blob {
+			blob[j] = byte(i*8 + j)
+		}
+	}
+	return obj
+}
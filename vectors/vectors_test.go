@@ -0,0 +1,83 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package vectors
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+var updateVectors = flag.Bool("update-vectors", false, "append new cases to the golden vectors")
+
+// Tests that the library still produces the golden encodings and roots of all
+// the test cases, and that the golden encodings decode back into them. Run with
+// -update-vectors to accept new cases into the vectors file.
+func TestVectors(t *testing.T) {
+	vectors, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load vectors: %v", err)
+	}
+	golden := make(map[string]*Vector)
+	for _, vector := range vectors {
+		golden[vector.Name] = vector
+	}
+	file := vectorsFile{Types: typeDefinitions}
+	for _, tt := range cases {
+		obj := tt.obj()
+
+		blob := make([]byte, ssz.Size(obj))
+		if err := ssz.EncodeToBytes(blob, obj); err != nil {
+			t.Fatalf("%s: failed to encode object: %v", tt.name, err)
+		}
+		root := ssz.HashSequential(obj)
+
+		file.Vectors = append(file.Vectors, vectorsEntry{
+			Name: tt.name,
+			Type: reflect.TypeOf(obj).Elem().Name(),
+			SSZ:  "0x" + hex.EncodeToString(blob),
+			Root: "0x" + hex.EncodeToString(root[:]),
+		})
+		want, ok := golden[tt.name]
+		if !ok {
+			if !*updateVectors {
+				t.Errorf("%s: missing golden vector", tt.name)
+			}
+			continue
+		}
+		if !bytes.Equal(blob, want.SSZ) {
+			t.Errorf("%s: encoding mismatch:\nhave %x\nwant %x", tt.name, blob, want.SSZ)
+		}
+		if root != want.Root {
+			t.Errorf("%s: root mismatch: have %#x, want %#x", tt.name, root, want.Root)
+		}
+	}
+	// Ensure the golden encodings decode and hash correctly in their own right
+	for _, vector := range vectors {
+		obj := vector.New()
+		if err := ssz.DecodeFromBytesWithConfig(vector.SSZ, obj, &ssz.DecoderConfig{Strict: true}); err != nil {
+			t.Errorf("%s: failed to decode golden encoding: %v", vector.Name, err)
+			continue
+		}
+		if root := ssz.HashSequential(obj); root != vector.Root {
+			t.Errorf("%s: decoded root mismatch: have %#x, want %#x", vector.Name, root, vector.Root)
+		}
+	}
+	if *updateVectors && !t.Failed() {
+		blob, err := json.MarshalIndent(file, "", "  ")
+		if err != nil {
+			t.Fatalf("failed to serialize vectors: %v", err)
+		}
+		if err := os.WriteFile("vectors.json", append(blob, '\n'), 0644); err != nil {
+			t.Fatalf("failed to update vectors: %v", err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// The codec relies on a few platform dependent fast paths: zero-copy views of
+// integers and byte arrays as their ssz encoding (via package unsafe), and SIMD
+// accelerated hashing. They are validated against their portable definitions
+// when the package is initialized, and the ones failing on the current platform
+// (e.g. an exotic architecture or a miscompiled assembly) are disabled, falling
+// back to the portable code paths.
+var (
+	fastViews   = true // Whether zero-copy views of uint64s and byte arrays are enabled
+	fastHashing = true // Whether the SIMD accelerated hashing is enabled
+)
+
+func init() {
+	fastViews = verifyViews() == nil
+	fastHashing = verifyHashing() == nil
+}
+
+// Verify runs the platform self-check of the codec on demand, returning all the
+// mismatches found between the fast paths and their portable definitions.
+//
+// Failing fast paths with a portable fallback are reported as such, but they are
+// already disabled when the package is initialized, so the codec remains safe to
+// use. Any other failure means the platform is not supported.
+func Verify() error {
+	var errs []error
+	if err := verifyViews(); err != nil {
+		errs = append(errs, fmt.Errorf("%w (falling back to copying)", err))
+	}
+	if err := verifyHashing(); err != nil {
+		errs = append(errs, fmt.Errorf("%w (falling back to portable hashing)", err))
+	}
+	if err := verifyMemory(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// verifyViews checks that the zero-copy views of uint64s and byte arrays match
+// their ssz encodings, if available on this platform.
+func verifyViews() error {
+	ns := []uint64{0x0102030405060708, 0x1112131415161718}
+	if blob, ok := uint64sView(ns); ok {
+		want := binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint64(nil, ns[0]), ns[1])
+		if !bytes.Equal(blob, want) {
+			return fmt.Errorf("%w: uint64 view mismatch: have %x, want %x", ErrSelfCheckFailed, blob, want)
+		}
+	}
+	blobs := [][4]byte{{0x01, 0x02, 0x03, 0x04}, {0x05, 0x06, 0x07, 0x08}}
+	if blob, ok := itemsView(blobs); ok {
+		want := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+		if !bytes.Equal(blob, want) {
+			return fmt.Errorf("%w: byte array view mismatch: have %x, want %x", ErrSelfCheckFailed, blob, want)
+		}
+	}
+	return nil
+}
+
+// verifyHashing checks that the accelerated hashing matches the standard library
+// hashing, both for separate and aliased digests.
+func verifyHashing() error {
+	chunks := make([][32]byte, 2*hasherBatch)
+	for i := range chunks {
+		for j := range chunks[i] {
+			chunks[i][j] = byte(i*32 + j)
+		}
+	}
+	want := make([][32]byte, hasherBatch)
+	hashChunksPortable(want, chunks)
+
+	have := make([][32]byte, hasherBatch)
+	hashChunksAccelerated(have, chunks)
+	for i := range want {
+		if have[i] != want[i] {
+			return fmt.Errorf("%w: hash %d mismatch: have %x, want %x", ErrSelfCheckFailed, i, have[i], want[i])
+		}
+	}
+	hashChunksAccelerated(chunks, chunks)
+	for i := range want {
+		if chunks[i] != want[i] {
+			return fmt.Errorf("%w: aliased hash %d mismatch: have %x, want %x", ErrSelfCheckFailed, i, chunks[i], want[i])
+		}
+	}
+	if have, want := hasherZeroCache[1], sha256.Sum256(make([]byte, 64)); have != want {
+		return fmt.Errorf("%w: zero hash mismatch: have %x, want %x", ErrSelfCheckFailed, have, want)
+	}
+	return nil
+}
+
+// verifyMemory checks the memory layout assumptions of the codec that have no
+// portable fallback: type sizes, array views and buffer position tracking.
+func verifyMemory() error {
+	if have := sizeOf[uint64](); have != 8 {
+		return fmt.Errorf("%w: uint64 size mismatch: have %d, want 8", ErrSelfCheckFailed, have)
+	}
+	if have := sizeOf[[32]byte](); have != 32 {
+		return fmt.Errorf("%w: [32]byte size mismatch: have %d, want 32", ErrSelfCheckFailed, have)
+	}
+	var array [4]byte
+	if view := arrayBytes(&array); len(view) != len(array) {
+		return fmt.Errorf("%w: array view length mismatch: have %d, want %d", ErrSelfCheckFailed, len(view), len(array))
+	} else if view[3] = 0x01; array[3] != 0x01 {
+		return fmt.Errorf("%w: array view not aliasing the array", ErrSelfCheckFailed)
+	}
+	buf := make([]byte, 2)
+	if have := bufferAddr(buf[1:]) - bufferAddr(buf); have != 1 {
+		return fmt.Errorf("%w: buffer address delta mismatch: have %d, want 1", ErrSelfCheckFailed, have)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"testing"
+
+	"github.com/karalabe/ssz"
+)
+
+// Tests that the platform self-check passes on the platforms the tests run on.
+func TestVerify(t *testing.T) {
+	if err := ssz.Verify(); err != nil {
+		t.Fatalf("platform self-check failed: %v", err)
+	}
+}
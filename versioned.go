@@ -0,0 +1,125 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+)
+
+// Version is the version of the encoding layout produced by the codec. It is only
+// bumped if a release would interpret previously written encodings differently
+// (e.g. fixing a layout bug), so it can be persisted alongside long lived data.
+const Version uint16 = 1
+
+// MinCompatibleVersion is the oldest encoding layout version the codec can still
+// decode. Versioned blobs written by older releases are rejected.
+const MinCompatibleVersion uint16 = 1
+
+// versionMagic is the prefix identifying versioned blobs.
+var versionMagic = [4]byte{'s', 's', 'z', 0x00}
+
+// versionHeaderSize is the byte size of the header of versioned blobs: the magic,
+// the little-endian codec version and the schema fingerprint.
+const versionHeaderSize = len(versionMagic) + 2 + 32
+
+// VersionHeader is the header of a versioned blob, identifying the codec version
+// and the schema it was written with.
+type VersionHeader struct {
+	Version     uint16   // Encoding layout version of the codec writing the blob
+	Fingerprint [32]byte // Schema fingerprint supplied by the writer
+}
+
+// EncodeVersioned serializes the object and appends it to dst, prefixed by the
+// codec's Version and the schema fingerprint. This allows long lived archives
+// to detect blobs written by an incompatible codec or schema, instead of
+// misinterpreting their bytes. Use DecodeVersioned to parse them back.
+//
+// The fingerprint is opaque to the codec. It may be derived from a runtime
+// schema via Schema.Fingerprint, or be anything else identifying the layout of
+// the object's type (e.g. the hash of a fork name and a schema revision).
+func EncodeVersioned(dst []byte, obj Object, fingerprint [32]byte) ([]byte, error) {
+	dst = append(dst, versionMagic[:]...)
+	dst = binary.LittleEndian.AppendUint16(dst, Version)
+	dst = append(dst, fingerprint[:]...)
+	return EncodeAppend(dst, obj)
+}
+
+// DecodeVersioned parses an object out of a versioned blob, as written by
+// EncodeVersioned. The blob is rejected with ErrIncompatibleLayout if it was
+// written by an incompatible codec version or with a different fingerprint.
+//
+// To decode with a custom config, or to pick the schema based on the header,
+// use ParseVersionHeader and DecodeFromBytesWithConfig instead.
+func DecodeVersioned(blob []byte, obj Object, fingerprint [32]byte) error {
+	header, payload, err := ParseVersionHeader(blob)
+	if err != nil {
+		return err
+	}
+	if err := header.Compatible(fingerprint); err != nil {
+		return err
+	}
+	return DecodeFromBytes(payload, obj)
+}
+
+// ParseVersionHeader splits a versioned blob into its header and the encoding of
+// the object, without checking their compatibility.
+func ParseVersionHeader(blob []byte) (VersionHeader, []byte, error) {
+	if len(blob) < versionHeaderSize || !bytes.Equal(blob[:len(versionMagic)], versionMagic[:]) {
+		return VersionHeader{}, nil, ErrMissingVersionHeader
+	}
+	header := VersionHeader{
+		Version: binary.LittleEndian.Uint16(blob[len(versionMagic):]),
+	}
+	copy(header.Fingerprint[:], blob[len(versionMagic)+2:])
+	return header, blob[versionHeaderSize:], nil
+}
+
+// Compatible checks whether a blob with the header can be decoded by this codec
+// into an object with the given schema fingerprint.
+func (h VersionHeader) Compatible(fingerprint [32]byte) error {
+	if h.Version < MinCompatibleVersion || h.Version > Version {
+		return fmt.Errorf("%w: codec version %d, supported %d-%d", ErrIncompatibleLayout, h.Version, MinCompatibleVersion, Version)
+	}
+	if h.Fingerprint != fingerprint {
+		return fmt.Errorf("%w: schema fingerprint %x, expected %x", ErrIncompatibleLayout, h.Fingerprint, fingerprint)
+	}
+	return nil
+}
+
+// Fingerprint returns a digest of the encoding layout of the schema: the kinds,
+// sizes and limits of the fields, recursively. Container and field names are
+// not included, since they do not affect the encoding.
+func (s *Schema) Fingerprint() [32]byte {
+	hasher := sha256.New()
+	s.fingerprint(hasher, make(map[*Schema]int))
+
+	var digest [32]byte
+	hasher.Sum(digest[:0])
+	return digest
+}
+
+// fingerprint is the recursive version of Fingerprint, tracking the schemas
+// already visited to support self referencing ones via lists.
+func (s *Schema) fingerprint(h hash.Hash, seen map[*Schema]int) {
+	if id, ok := seen[s]; ok {
+		fmt.Fprintf(h, "@%d;", id)
+		return
+	}
+	seen[s] = len(seen)
+
+	fmt.Fprintf(h, "{%d:", len(s.Fields))
+	for _, f := range s.Fields {
+		fmt.Fprintf(h, "%d/%d/%d/%d/%d", f.Kind, f.Size, f.ItemSize, f.MaxItems, f.MaxSize)
+		if f.Schema != nil {
+			f.Schema.fingerprint(h, seen)
+		}
+		fmt.Fprint(h, ";")
+	}
+	fmt.Fprint(h, "}")
+}
@@ -0,0 +1,104 @@
+// ssz: Go Simple Serialize (SSZ) codec library
+// Copyright 2024 ssz Authors
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ssz_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/karalabe/ssz"
+	types "github.com/karalabe/ssz/tests/testtypes/consensus-spec-tests"
+)
+
+// checkpointSchema and attestationDataSchema describe the layouts of the
+// consensus types the versioned blobs are tested with.
+var (
+	checkpointSchema = &ssz.Schema{Name: "Checkpoint", Fields: []ssz.SchemaField{
+		{Name: "Epoch", Kind: ssz.FieldUint64},
+		{Name: "Root", Kind: ssz.FieldStaticBytes, Size: 32},
+	}}
+	attestationDataSchema = &ssz.Schema{Name: "AttestationData", Fields: []ssz.SchemaField{
+		{Name: "Slot", Kind: ssz.FieldUint64},
+		{Name: "Index", Kind: ssz.FieldUint64},
+		{Name: "BeaconBlockHash", Kind: ssz.FieldStaticBytes, Size: 32},
+		{Name: "Source", Kind: ssz.FieldStaticObject, Schema: checkpointSchema},
+		{Name: "Target", Kind: ssz.FieldStaticObject, Schema: checkpointSchema},
+	}}
+)
+
+// Tests that versioned blobs round trip, and that blobs of incompatible codec
+// versions or schemas are rejected.
+func TestVersionedEncoding(t *testing.T) {
+	obj := &types.Checkpoint{Epoch: 1, Root: types.Hash{2}}
+	fingerprint := checkpointSchema.Fingerprint()
+
+	blob, err := ssz.EncodeVersioned([]byte{0xff}, obj, fingerprint)
+	if err != nil {
+		t.Fatalf("failed to encode versioned object: %v", err)
+	}
+	if blob[0] != 0xff {
+		t.Fatalf("destination prefix overwritten: have %#x, want 0xff", blob[0])
+	}
+	blob = blob[1:]
+
+	dec := new(types.Checkpoint)
+	if err := ssz.DecodeVersioned(blob, dec, fingerprint); err != nil {
+		t.Fatalf("failed to decode versioned object: %v", err)
+	}
+	if *dec != *obj {
+		t.Errorf("decoded object mismatch: have %+v, want %+v", dec, obj)
+	}
+	header, payload, err := ssz.ParseVersionHeader(blob)
+	if err != nil {
+		t.Fatalf("failed to parse version header: %v", err)
+	}
+	if header.Version != ssz.Version || header.Fingerprint != fingerprint {
+		t.Errorf("version header mismatch: have %+v", header)
+	}
+	if want := ssz.Size(obj); uint32(len(payload)) != want {
+		t.Errorf("payload size mismatch: have %d, want %d", len(payload), want)
+	}
+	// Different schemas, future codecs and unversioned blobs should be rejected
+	if err := ssz.DecodeVersioned(blob, new(types.Checkpoint), attestationDataSchema.Fingerprint()); !errors.Is(err, ssz.ErrIncompatibleLayout) {
+		t.Errorf("schema mismatch error mismatch: have %v, want %v", err, ssz.ErrIncompatibleLayout)
+	}
+	future := bytes.Clone(blob)
+	binary.LittleEndian.PutUint16(future[4:], ssz.Version+1)
+	if err := ssz.DecodeVersioned(future, new(types.Checkpoint), fingerprint); !errors.Is(err, ssz.ErrIncompatibleLayout) {
+		t.Errorf("version mismatch error mismatch: have %v, want %v", err, ssz.ErrIncompatibleLayout)
+	}
+	if err := ssz.DecodeVersioned(payload, new(types.Checkpoint), fingerprint); !errors.Is(err, ssz.ErrMissingVersionHeader) {
+		t.Errorf("missing header error mismatch: have %v, want %v", err, ssz.ErrMissingVersionHeader)
+	}
+}
+
+// Tests that schema fingerprints only depend on the encoding layout, and that
+// they support self referencing schemas.
+func TestSchemaFingerprint(t *testing.T) {
+	renamed := &ssz.Schema{Name: "Renamed", Fields: []ssz.SchemaField{
+		{Name: "X", Kind: ssz.FieldUint64},
+		{Name: "Y", Kind: ssz.FieldStaticBytes, Size: 32},
+	}}
+	if renamed.Fingerprint() != checkpointSchema.Fingerprint() {
+		t.Errorf("renamed schema fingerprint mismatch")
+	}
+	resized := &ssz.Schema{Fields: []ssz.SchemaField{
+		{Name: "Epoch", Kind: ssz.FieldUint64},
+		{Name: "Root", Kind: ssz.FieldStaticBytes, Size: 48},
+	}}
+	if resized.Fingerprint() == checkpointSchema.Fingerprint() {
+		t.Errorf("resized schema fingerprint collision")
+	}
+	tree := &ssz.Schema{Name: "Tree"}
+	tree.Fields = []ssz.SchemaField{
+		{Name: "Value", Kind: ssz.FieldUint64},
+		{Name: "Children", Kind: ssz.FieldSliceOfDynamicObjects, MaxItems: 4, Schema: tree},
+	}
+	if tree.Fingerprint() != tree.Fingerprint() {
+		t.Errorf("recursive schema fingerprint not deterministic")
+	}
+}